@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+type (
+	// NodeArchitecture is the CPU architecture of the nodes the pods of a collector can be scheduled on, as reported
+	// by their kubernetes.io/arch label.
+	// +kubebuilder:validation:Enum=amd64;arm64
+	NodeArchitecture string
+)
+
+const (
+	// NodeArchitectureAMD64 schedules the collector on the x86-64 nodes.
+	NodeArchitectureAMD64 NodeArchitecture = "amd64"
+
+	// NodeArchitectureARM64 schedules the collector on the 64-bit ARM nodes.
+	NodeArchitectureARM64 NodeArchitecture = "arm64"
+)
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// autopilotAllowedCapabilities are the capabilities GKE Autopilot allows the containers to add.
+var autopilotAllowedCapabilities = map[v1.Capability]bool{
+	"AUDIT_WRITE":      true,
+	"CHOWN":            true,
+	"DAC_OVERRIDE":     true,
+	"FOWNER":           true,
+	"FSETID":           true,
+	"KILL":             true,
+	"MKNOD":            true,
+	"NET_BIND_SERVICE": true,
+	"NET_RAW":          true,
+	"SETFCAP":          true,
+	"SETGID":           true,
+	"SETPCAP":          true,
+	"SETUID":           true,
+	"SYS_CHROOT":       true,
+	"SYS_PTRACE":       true,
+}
+
+// validateAutopilot rejects the features forbidden on GKE Autopilot: the host network, the hostPath volumes, and the
+// privileged containers.
+func validateAutopilot(kind string, hostNetwork bool, volumes []v1.Volume, securityContexts ...*v1.SecurityContext) error {
+	if hostNetwork {
+		return fmt.Errorf("the %s must not use the host network on GKE Autopilot", kind)
+	}
+	for _, volume := range volumes {
+		if volume.HostPath != nil {
+			return fmt.Errorf("the %s must not use the hostPath volume %s on GKE Autopilot", kind, volume.Name)
+		}
+	}
+	for _, securityContext := range securityContexts {
+		if securityContext == nil {
+			continue
+		}
+		if securityContext.Privileged != nil && *securityContext.Privileged {
+			return fmt.Errorf("the %s must not run privileged containers on GKE Autopilot", kind)
+		}
+		if securityContext.Capabilities == nil {
+			continue
+		}
+		for _, capability := range securityContext.Capabilities.Add {
+			if !autopilotAllowedCapabilities[capability] {
+				return fmt.Errorf("the %s must not add the capability %s on GKE Autopilot", kind, capability)
+			}
+		}
+	}
+	return nil
+}
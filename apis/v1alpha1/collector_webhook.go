@@ -16,18 +16,31 @@ package v1alpha1
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/go-logr/logr"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/imagedigest"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/adapters"
 	ta "github.com/open-telemetry/opentelemetry-operator/internal/manifests/targetallocator/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
@@ -36,15 +49,26 @@ var (
 	_ admission.CustomDefaulter = &CollectorWebhook{}
 )
 
+// featureGateRegexp matches a feature gate of the OpenTelemetry Collector, optionally prefixed by '+' or '-'.
+var featureGateRegexp = regexp.MustCompile(`^[+-]?[0-9a-zA-Z][0-9a-zA-Z._-]*$`)
+
+// sidecarInjectedLabel is set on pods that received a sidecar from an OpenTelemetryCollector, see pkg/sidecar.
+const sidecarInjectedLabel = "sidecar.opentelemetry.io/injected"
+
 // +kubebuilder:webhook:path=/mutate-opentelemetry-io-v1alpha1-opentelemetrycollector,mutating=true,failurePolicy=fail,groups=opentelemetry.io,resources=opentelemetrycollectors,verbs=create;update,versions=v1alpha1,name=mopentelemetrycollector.kb.io,sideEffects=none,admissionReviewVersions=v1
 // +kubebuilder:webhook:verbs=create;update,path=/validate-opentelemetry-io-v1alpha1-opentelemetrycollector,mutating=false,failurePolicy=fail,groups=opentelemetry.io,resources=opentelemetrycollectors,versions=v1alpha1,name=vopentelemetrycollectorcreateupdate.kb.io,sideEffects=none,admissionReviewVersions=v1
 // +kubebuilder:webhook:verbs=delete,path=/validate-opentelemetry-io-v1alpha1-opentelemetrycollector,mutating=false,failurePolicy=ignore,groups=opentelemetry.io,resources=opentelemetrycollectors,versions=v1alpha1,name=vopentelemetrycollectordelete.kb.io,sideEffects=none,admissionReviewVersions=v1
+// +kubebuilder:rbac:groups="",resources=pods,verbs=list
+// +kubebuilder:rbac:groups="",resources=limitranges;resourcequotas,verbs=list
 // +kubebuilder:object:generate=false
 
 type CollectorWebhook struct {
 	logger logr.Logger
 	cfg    config.Config
 	scheme *runtime.Scheme
+	reader client.Reader
+	// pods reads the pods running an injected sidecar, see sidecarPodsCache.
+	pods client.Reader
 }
 
 func (c CollectorWebhook) Default(ctx context.Context, obj runtime.Object) error {
@@ -52,7 +76,14 @@ func (c CollectorWebhook) Default(ctx context.Context, obj runtime.Object) error
 	if !ok {
 		return fmt.Errorf("expected an OpenTelemetryCollector, received %T", obj)
 	}
-	return c.defaulter(otelcol)
+	// the collectors being deleted are only updated to remove their finalizers
+	if otelcol.DeletionTimestamp != nil {
+		return nil
+	}
+	if err := c.defaulter(otelcol); err != nil {
+		return err
+	}
+	return c.pinImages(ctx, otelcol)
 }
 
 func (c CollectorWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
@@ -60,7 +91,26 @@ func (c CollectorWebhook) ValidateCreate(ctx context.Context, obj runtime.Object
 	if !ok {
 		return nil, fmt.Errorf("expected an OpenTelemetryCollector, received %T", obj)
 	}
-	return c.validate(otelcol)
+	warnings, err := c.validate(otelcol)
+	if err != nil {
+		return warnings, err
+	}
+	if err := c.validateImageRegistries(otelcol); err != nil {
+		return warnings, err
+	}
+	if err := c.validateAutopilot(otelcol); err != nil {
+		return warnings, err
+	}
+	if err := c.validateNamespaceScoped(otelcol); err != nil {
+		return warnings, err
+	}
+	if err := c.validateArchitectures(ctx, otelcol); err != nil {
+		return warnings, err
+	}
+	if err := c.validateNames(ctx, otelcol); err != nil {
+		return warnings, err
+	}
+	return warnings, c.validateQuotas(ctx, otelcol)
 }
 
 func (c CollectorWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
@@ -68,7 +118,31 @@ func (c CollectorWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj run
 	if !ok {
 		return nil, fmt.Errorf("expected an OpenTelemetryCollector, received %T", newObj)
 	}
-	return c.validate(otelcol)
+	// the checks depend on the config of the operator, which may have changed since the collector was accepted: the
+	// collectors being deleted must still lose their finalizers, and the metadata of the others must still be editable
+	if old, ok := oldObj.(*OpenTelemetryCollector); otelcol.DeletionTimestamp != nil || (ok && equality.Semantic.DeepEqual(old.Spec, otelcol.Spec)) {
+		return nil, nil
+	}
+	warnings, err := c.validate(otelcol)
+	if err != nil {
+		return warnings, err
+	}
+	if err := c.validateImageRegistries(otelcol); err != nil {
+		return warnings, err
+	}
+	if err := c.validateAutopilot(otelcol); err != nil {
+		return warnings, err
+	}
+	if err := c.validateNamespaceScoped(otelcol); err != nil {
+		return warnings, err
+	}
+	if err := c.validateArchitectures(ctx, otelcol); err != nil {
+		return warnings, err
+	}
+	if err := c.validateNames(ctx, otelcol); err != nil {
+		return warnings, err
+	}
+	return warnings, c.validateQuotas(ctx, otelcol)
 }
 
 func (c CollectorWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
@@ -76,7 +150,11 @@ func (c CollectorWebhook) ValidateDelete(ctx context.Context, obj runtime.Object
 	if !ok || otelcol == nil {
 		return nil, fmt.Errorf("expected an OpenTelemetryCollector, received %T", obj)
 	}
-	return c.validate(otelcol)
+	warnings, err := c.validate(otelcol)
+	if err != nil {
+		return warnings, err
+	}
+	return append(warnings, c.validateReferences(ctx, otelcol)...), nil
 }
 
 func (c CollectorWebhook) defaulter(r *OpenTelemetryCollector) error {
@@ -95,10 +173,19 @@ func (c CollectorWebhook) defaulter(r *OpenTelemetryCollector) error {
 	}
 
 	// We can default to one because dependent objects Deployment and HorizontalPodAutoScaler
-	// default to 1 as well.
+	// default to 1 as well. When autoscaled, the collector starts with the autoscaler's lower bound.
 	one := int32(1)
 	if r.Spec.Replicas == nil {
-		r.Spec.Replicas = &one
+		switch {
+		case r.Spec.Autoscaler != nil && r.Spec.Autoscaler.MinReplicas != nil:
+			replicas := *r.Spec.Autoscaler.MinReplicas
+			r.Spec.Replicas = &replicas
+		case r.Spec.MinReplicas != nil:
+			replicas := *r.Spec.MinReplicas
+			r.Spec.Replicas = &replicas
+		default:
+			r.Spec.Replicas = &one
+		}
 	}
 	if r.Spec.TargetAllocator.Enabled && r.Spec.TargetAllocator.Replicas == nil {
 		r.Spec.TargetAllocator.Replicas = &one
@@ -139,6 +226,8 @@ func (c CollectorWebhook) defaulter(r *OpenTelemetryCollector) error {
 		}
 	}
 
+	defaultPorts(c.logger, r)
+
 	if r.Spec.Ingress.Type == IngressTypeRoute && r.Spec.Ingress.Route.Termination == "" {
 		r.Spec.Ingress.Route.Termination = TLSRouteTerminationTypeEdge
 	}
@@ -160,6 +249,56 @@ func (c CollectorWebhook) validate(r *OpenTelemetryCollector) (admission.Warning
 		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'volumeClaimTemplates'", r.Spec.Mode)
 	}
 
+	// validate prometheusSharding
+	if r.Spec.PrometheusSharding {
+		if r.Spec.Mode != ModeStatefulSet {
+			return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'prometheusSharding'", r.Spec.Mode)
+		}
+		if r.Spec.TargetAllocator.Enabled {
+			return warnings, fmt.Errorf("the OpenTelemetry Collector prometheusSharding can't be used along with the TargetAllocator, which shards the targets itself")
+		}
+	}
+
+	// validate persistentVolumeClaimRetentionPolicy
+	if r.Spec.Mode != ModeStatefulSet && r.Spec.PersistentVolumeClaimRetentionPolicy != nil {
+		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'persistentVolumeClaimRetentionPolicy'", r.Spec.Mode)
+	}
+
+	// validate name overrides, which are used as is in the names of the resources
+	for fieldName, override := range map[string]string{"nameOverride": r.Spec.NameOverride, "fullnameOverride": r.Spec.FullnameOverride} {
+		if errs := validation.IsDNS1123Label(override); override != "" && len(errs) > 0 {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec %s '%s' is incorrect: %s", fieldName, override, strings.Join(errs, ", "))
+		}
+	}
+
+	// validate the values of the standard labels
+	if err := validateStandardLabels("OpenTelemetry Spec ", r.Spec.StandardLabels); err != nil {
+		return warnings, err
+	}
+
+	// validate the volumes of the SecretProviderClasses
+	if err := validateSecretProviderClasses(r.Spec.SecretProviderClasses, r.Spec.Volumes, r.Spec.VolumeMounts); err != nil {
+		return warnings, err
+	}
+
+	// validate the IP families of the Services
+	if err := validateIPFamilies("OpenTelemetry Spec ", r.Spec.IPFamilies, r.Spec.IPFamilyPolicy); err != nil {
+		return warnings, err
+	}
+	if err := validateIPFamilies("OpenTelemetry Spec targetAllocator.", r.Spec.TargetAllocator.IPFamilies, r.Spec.TargetAllocator.IPFamilyPolicy); err != nil {
+		return warnings, err
+	}
+
+	// validate upgrade windows
+	for _, w := range r.Spec.UpgradeWindows {
+		if err := w.Validate(); err != nil {
+			return warnings, err
+		}
+	}
+	if len(r.Spec.UpgradeWindows) > 0 && r.Spec.UpgradeStrategy == UpgradeStrategyNone {
+		warnings = append(warnings, "the upgrade windows have no effect as the upgrade strategy is 'none'")
+	}
+
 	// validate tolerations
 	if r.Spec.Mode == ModeSidecar && len(r.Spec.Tolerations) > 0 {
 		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'tolerations'", r.Spec.Mode)
@@ -179,11 +318,29 @@ func (c CollectorWebhook) validate(r *OpenTelemetryCollector) (admission.Warning
 		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'AdditionalContainers'", r.Spec.Mode)
 	}
 
+	// validate SPIFFE, the sidecar can't add the spiffe-helper containers to the pod it's injected into
+	if r.Spec.Mode == ModeSidecar && r.Spec.Spiffe.Enabled {
+		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'spiffe'", r.Spec.Mode)
+	}
+
 	// validate target allocation
 	if r.Spec.TargetAllocator.Enabled && r.Spec.Mode != ModeStatefulSet {
 		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the target allocation deployment", r.Spec.Mode)
 	}
 
+	// validate the resolution of the Prometheus CRs by the operator
+	if r.Spec.PrometheusCR.Enabled {
+		if r.Spec.TargetAllocator.Enabled {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec prometheusCR must not be enabled along with the target allocator, use targetAllocator.prometheusCR instead")
+		}
+		if r.Spec.Mode == ModeSidecar {
+			return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'prometheusCR'", r.Spec.Mode)
+		}
+		if _, err := ta.ConfigToPromConfig(r.Spec.Config); err != nil {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec Prometheus configuration is incorrect, %w", err)
+		}
+	}
+
 	// validate Prometheus config for target allocation
 	if r.Spec.TargetAllocator.Enabled {
 		promCfg, err := ta.ConfigToPromConfig(r.Spec.Config)
@@ -200,6 +357,37 @@ func (c CollectorWebhook) validate(r *OpenTelemetryCollector) (admission.Warning
 		}
 	}
 
+	// validate the remote clusters of the target allocator
+	if len(r.Spec.TargetAllocator.PrometheusCR.RemoteClusters) > 0 && !r.Spec.TargetAllocator.PrometheusCR.Enabled {
+		return warnings, fmt.Errorf("the OpenTelemetry Spec targetAllocator.prometheusCR.remoteClusters requires targetAllocator.prometheusCR to be enabled")
+	}
+	remoteClusters := map[string]bool{}
+	for _, cluster := range r.Spec.TargetAllocator.PrometheusCR.RemoteClusters {
+		if errs := validation.IsDNS1123Label(cluster.Name); len(errs) > 0 {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec targetAllocator.prometheusCR.remoteClusters name '%s' is incorrect: %s", cluster.Name, strings.Join(errs, ", "))
+		}
+		if remoteClusters[cluster.Name] {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec targetAllocator.prometheusCR.remoteClusters name '%s' is duplicated", cluster.Name)
+		}
+		remoteClusters[cluster.Name] = true
+		if cluster.KubeconfigSecret.Name == "" || cluster.KubeconfigSecret.Key == "" {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec targetAllocator.prometheusCR.remoteClusters '%s' must set the name and key of its kubeconfigSecret", cluster.Name)
+		}
+	}
+
+	// validate the feature gates
+	featureGates := map[string]bool{}
+	for _, gate := range r.Spec.FeatureGates {
+		if !featureGateRegexp.MatchString(gate) {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec featureGates configuration is incorrect, '%s' isn't a feature gate optionally prefixed by '+' or '-'", gate)
+		}
+		id := strings.TrimLeft(gate, "+-")
+		if featureGates[id] {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec featureGates configuration is incorrect, the feature gate '%s' is set more than once", id)
+		}
+		featureGates[id] = true
+	}
+
 	// validator port config
 	for _, p := range r.Spec.Ports {
 		nameErrs := validation.IsValidPortName(p.Name)
@@ -208,9 +396,41 @@ func (c CollectorWebhook) validate(r *OpenTelemetryCollector) (admission.Warning
 			return warnings, fmt.Errorf("the OpenTelemetry Spec Ports configuration is incorrect, port name '%s' errors: %s, num '%d' errors: %s",
 				p.Name, nameErrs, p.Port, numErrs)
 		}
+		switch p.Protocol {
+		case "", corev1.ProtocolTCP, corev1.ProtocolUDP, corev1.ProtocolSCTP:
+		default:
+			return warnings, fmt.Errorf("the OpenTelemetry Spec Ports configuration is incorrect, the protocol '%s' of the port '%s' isn't one of TCP, UDP or SCTP", p.Protocol, p.Name)
+		}
+		if p.AppProtocol != nil {
+			if errs := validation.IsQualifiedName(*p.AppProtocol); len(errs) > 0 {
+				return warnings, fmt.Errorf("the OpenTelemetry Spec Ports configuration is incorrect, the appProtocol '%s' of the port '%s' is invalid: %s", *p.AppProtocol, p.Name, errs)
+			}
+		}
+		if p.ExposeOnService != nil && !*p.ExposeOnService && p.NodePort != 0 {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec Ports configuration is incorrect, the port '%s' has the nodePort %d but isn't exposed on the Service", p.Name, p.NodePort)
+		}
+		if p.HostPort != 0 {
+			if r.Spec.Mode != ModeDaemonSet {
+				return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'hostPort' of the port '%s'", r.Spec.Mode, p.Name)
+			}
+			if errs := validation.IsValidPortNum(int(p.HostPort)); len(errs) > 0 {
+				return warnings, fmt.Errorf("the OpenTelemetry Spec Ports configuration is incorrect, the hostPort %d of the port '%s' is invalid: %s", p.HostPort, p.Name, errs)
+			}
+			if r.Spec.HostNetwork && p.HostPort != p.Port {
+				return warnings, fmt.Errorf("the OpenTelemetry Spec Ports configuration is incorrect, the hostPort %d of the port '%s' must be its port %d on the network of the host", p.HostPort, p.Name, p.Port)
+			}
+		}
+	}
+	portWarnings, err := checkPortConflicts(c.logger, r)
+	if err != nil {
+		return warnings, fmt.Errorf("the OpenTelemetry Spec Ports configuration is incorrect, %w", err)
+	}
+	warnings = append(warnings, portWarnings...)
+	if err := checkPortProtocols(c.logger, r); err != nil {
+		return warnings, fmt.Errorf("the OpenTelemetry Spec Ports configuration is incorrect, %w", err)
 	}
 
-	var maxReplicas *int32
+	maxReplicas, maxPath := (*int32)(nil), field.NewPath("spec", "autoscaler", "maxReplicas")
 	if r.Spec.Autoscaler != nil && r.Spec.Autoscaler.MaxReplicas != nil {
 		maxReplicas = r.Spec.Autoscaler.MaxReplicas
 	}
@@ -218,10 +438,10 @@ func (c CollectorWebhook) validate(r *OpenTelemetryCollector) (admission.Warning
 	// check deprecated .Spec.MaxReplicas if maxReplicas is not set
 	if maxReplicas == nil && r.Spec.MaxReplicas != nil {
 		warnings = append(warnings, "MaxReplicas is deprecated")
-		maxReplicas = r.Spec.MaxReplicas
+		maxReplicas, maxPath = r.Spec.MaxReplicas, field.NewPath("spec", "maxReplicas")
 	}
 
-	var minReplicas *int32
+	minReplicas, minPath := (*int32)(nil), field.NewPath("spec", "autoscaler", "minReplicas")
 	if r.Spec.Autoscaler != nil && r.Spec.Autoscaler.MinReplicas != nil {
 		minReplicas = r.Spec.Autoscaler.MinReplicas
 	}
@@ -230,9 +450,9 @@ func (c CollectorWebhook) validate(r *OpenTelemetryCollector) (admission.Warning
 	if minReplicas == nil {
 		if r.Spec.MinReplicas != nil {
 			warnings = append(warnings, "MinReplicas is deprecated")
-			minReplicas = r.Spec.MinReplicas
+			minReplicas, minPath = r.Spec.MinReplicas, field.NewPath("spec", "minReplicas")
 		} else {
-			minReplicas = r.Spec.Replicas
+			minReplicas, minPath = r.Spec.Replicas, field.NewPath("spec", "replicas")
 		}
 	}
 
@@ -244,24 +464,69 @@ func (c CollectorWebhook) validate(r *OpenTelemetryCollector) (admission.Warning
 
 	// validate autoscale with horizontal pod autoscaler
 	if maxReplicas != nil {
+		if r.Spec.Mode == ModeDaemonSet || r.Spec.Mode == ModeSidecar {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, the mode %s can't be autoscaled, %s must not be set", r.Spec.Mode, maxPath)
+		}
+
 		if *maxReplicas < int32(1) {
-			return warnings, fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, maxReplicas should be defined and one or more")
+			return warnings, fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, maxReplicas should be defined and one or more, %s is %d", maxPath, *maxReplicas)
 		}
 
 		if r.Spec.Replicas != nil && *r.Spec.Replicas > *maxReplicas {
-			return warnings, fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, replicas must not be greater than maxReplicas")
+			return warnings, fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, replicas must not be greater than maxReplicas, spec.replicas is %d and %s is %d",
+				*r.Spec.Replicas, maxPath, *maxReplicas)
 		}
 
 		if minReplicas != nil && *minReplicas > *maxReplicas {
-			return warnings, fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, minReplicas must not be greater than maxReplicas")
+			return warnings, fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, minReplicas must not be greater than maxReplicas, %s is %d and %s is %d",
+				minPath, *minReplicas, maxPath, *maxReplicas)
 		}
 
 		if minReplicas != nil && *minReplicas < int32(1) {
-			return warnings, fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, minReplicas should be one or more")
+			return warnings, fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, minReplicas should be one or more, %s is %d", minPath, *minReplicas)
+		}
+
+		// the autoscaler owns the number of replicas, an explicit one outside its bounds is reverted on the next scaling
+		if r.Spec.Replicas != nil && minReplicas != nil && *r.Spec.Replicas < *minReplicas {
+			warnings = append(warnings, fmt.Sprintf("spec.replicas is %d, less than %s %d, the autoscaler scales the collector up to %d replicas",
+				*r.Spec.Replicas, minPath, *minReplicas, *minReplicas))
 		}
 
 		if r.Spec.Autoscaler != nil {
-			return warnings, checkAutoscalerSpec(r.Spec.Autoscaler)
+			if err := checkAutoscalerSpec(r.Spec.Autoscaler); err != nil {
+				return warnings, err
+			}
+		}
+	}
+
+	// validate the rollout, the replicas of the canary are derived from the ones of the spec
+	if r.Spec.Rollout != nil && r.Spec.Rollout.Strategy == RolloutStrategyCanary {
+		if r.Spec.Mode != ModeDeployment {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec rollout configuration is incorrect, the canary strategy is only supported in the %s mode", ModeDeployment)
+		}
+		if maxReplicas != nil {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec rollout configuration is incorrect, the canary strategy can't be used with autoscaling")
+		}
+		if pct := r.Spec.Rollout.CanaryPercentage; pct != nil && (*pct < 1 || *pct > 100) {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec rollout configuration is incorrect, canaryPercentage should be between 1 and 100, it is %d", *pct)
+		}
+		if delay := r.Spec.Rollout.PromotionDelay; delay != nil && delay.Duration < 0 {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec rollout configuration is incorrect, promotionDelay must not be negative")
+		}
+	}
+	if r.Spec.Rollout != nil && r.Spec.Rollout.Analysis != nil {
+		analysis := r.Spec.Rollout.Analysis
+		if r.Spec.Rollout.Strategy != RolloutStrategyCanary {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec rollout configuration is incorrect, the analysis requires the canary strategy")
+		}
+		if analysis.MaxRefused == nil && analysis.MaxDropped == nil {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec rollout configuration is incorrect, the analysis must set maxRefused or maxDropped")
+		}
+		if (analysis.MaxRefused != nil && *analysis.MaxRefused < 0) || (analysis.MaxDropped != nil && *analysis.MaxDropped < 0) {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec rollout configuration is incorrect, the thresholds of the analysis must not be negative")
+		}
+		if analysis.Window != nil && analysis.Window.Duration <= 0 {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec rollout configuration is incorrect, the window of the analysis must be positive")
 		}
 	}
 
@@ -274,6 +539,12 @@ func (c CollectorWebhook) validate(r *OpenTelemetryCollector) (admission.Warning
 		return warnings, fmt.Errorf("a valid Ingress hostname has to be defined for subdomain ruleType")
 	}
 
+	debugWarnings, err := checkDebugEndpoints(c.logger, r)
+	if err != nil {
+		return warnings, err
+	}
+	warnings = append(warnings, debugWarnings...)
+
 	if r.Spec.LivenessProbe != nil {
 		if r.Spec.LivenessProbe.InitialDelaySeconds != nil && *r.Spec.LivenessProbe.InitialDelaySeconds < 0 {
 			return warnings, fmt.Errorf("the OpenTelemetry Spec LivenessProbe InitialDelaySeconds configuration is incorrect. InitialDelaySeconds should be greater than or equal to 0")
@@ -295,56 +566,456 @@ func (c CollectorWebhook) validate(r *OpenTelemetryCollector) (admission.Warning
 		}
 	}
 
-	return warnings, nil
+	return append(warnings, collectorWarnings(r)...), nil
 }
 
 func checkAutoscalerSpec(autoscaler *AutoscalerSpec) error {
+	path := field.NewPath("spec", "autoscaler")
 	if autoscaler.Behavior != nil {
 		if autoscaler.Behavior.ScaleDown != nil && autoscaler.Behavior.ScaleDown.StabilizationWindowSeconds != nil &&
 			*autoscaler.Behavior.ScaleDown.StabilizationWindowSeconds < int32(1) {
-			return fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, scaleDown should be one or more")
+			return fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, scaleDown should be one or more, %s is %d",
+				path.Child("behavior", "scaleDown", "stabilizationWindowSeconds"), *autoscaler.Behavior.ScaleDown.StabilizationWindowSeconds)
 		}
 
 		if autoscaler.Behavior.ScaleUp != nil && autoscaler.Behavior.ScaleUp.StabilizationWindowSeconds != nil &&
 			*autoscaler.Behavior.ScaleUp.StabilizationWindowSeconds < int32(1) {
-			return fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, scaleUp should be one or more")
+			return fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, scaleUp should be one or more, %s is %d",
+				path.Child("behavior", "scaleUp", "stabilizationWindowSeconds"), *autoscaler.Behavior.ScaleUp.StabilizationWindowSeconds)
 		}
 	}
 	if autoscaler.TargetCPUUtilization != nil && (*autoscaler.TargetCPUUtilization < int32(1) || *autoscaler.TargetCPUUtilization > int32(99)) {
-		return fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, targetCPUUtilization should be greater than 0 and less than 100")
+		return fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, targetCPUUtilization should be greater than 0 and less than 100, %s is %d",
+			path.Child("targetCPUUtilization"), *autoscaler.TargetCPUUtilization)
 	}
 	if autoscaler.TargetMemoryUtilization != nil && (*autoscaler.TargetMemoryUtilization < int32(1) || *autoscaler.TargetMemoryUtilization > int32(99)) {
-		return fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, targetMemoryUtilization should be greater than 0 and less than 100")
+		return fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, targetMemoryUtilization should be greater than 0 and less than 100, %s is %d",
+			path.Child("targetMemoryUtilization"), *autoscaler.TargetMemoryUtilization)
 	}
 
-	for _, metric := range autoscaler.Metrics {
+	for i, metric := range autoscaler.Metrics {
+		metricPath := path.Child("metrics").Index(i)
 		if metric.Type != autoscalingv2.PodsMetricSourceType {
-			return fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, metric type unsupported. Expected metric of source type Pod")
+			return fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, metric type unsupported. Expected metric of source type Pod, %s is %s",
+				metricPath.Child("type"), metric.Type)
 		}
 
 		// pod metrics target only support value and averageValue.
+		targetPath := metricPath.Child("pods", "target")
 		if metric.Pods.Target.Type == autoscalingv2.AverageValueMetricType {
 			if val, ok := metric.Pods.Target.AverageValue.AsInt64(); !ok || val < int64(1) {
-				return fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, average value should be greater than 0")
+				return fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, average value should be greater than 0, %s is %s",
+					targetPath.Child("averageValue"), metric.Pods.Target.AverageValue)
 			}
 		} else if metric.Pods.Target.Type == autoscalingv2.ValueMetricType {
 			if val, ok := metric.Pods.Target.Value.AsInt64(); !ok || val < int64(1) {
-				return fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, value should be greater than 0")
+				return fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, value should be greater than 0, %s is %s",
+					targetPath.Child("value"), metric.Pods.Target.Value)
 			}
 		} else {
-			return fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, invalid pods target type")
+			return fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, invalid pods target type, %s is %s",
+				targetPath.Child("type"), metric.Pods.Target.Type)
 		}
 	}
 
 	return nil
 }
 
-func SetupCollectorWebhook(mgr ctrl.Manager, cfg config.Config) error {
-	cvw := &CollectorWebhook{
-		logger: mgr.GetLogger().WithValues("handler", "CollectorWebhook"),
-		scheme: mgr.GetScheme(),
+// checkPortConflicts rejects ports that would end up twice in the collector's Service because they're repeated in
+// spec.ports, and warns about the ports which clash with a port inferred from the configured components: the Service
+// leaves out the inferred port using the same number, and renames the inferred port using the same name. Repeating an
+// inferred port with the same name and number is the way to override its settings.
+func checkPortConflicts(logger logr.Logger, r *OpenTelemetryCollector) (admission.Warnings, error) {
+	names := map[string]corev1.ServicePort{}
+	numbers := map[string]corev1.ServicePort{}
+	for _, port := range r.Spec.Ports {
+		p := port.ServicePort
+		if other, ok := names[p.Name]; ok {
+			return nil, fmt.Errorf("the port name '%s' is used by the ports %d and %d", p.Name, other.Port, p.Port)
+		}
+		if other, ok := numbers[portKey(p)]; ok {
+			return nil, fmt.Errorf("the port %d/%s is used by the ports '%s' and '%s'", p.Port, portProtocol(p), other.Name, p.Name)
+		}
+		names[p.Name] = p
+		numbers[portKey(p)] = p
+	}
+	if len(r.Spec.Ports) == 0 {
+		return nil, nil
+	}
+
+	inferredPorts, err := adapters.ConfigStringToPorts(logger, r.Spec.Config)
+	if err != nil {
+		// the configuration itself is checked by the collector, there are no ports to compare against
+		return nil, nil
+	}
+	var warnings admission.Warnings
+	for _, inferred := range inferredPorts {
+		if p, ok := numbers[portKey(inferred)]; ok && p.Name != inferred.Name {
+			warnings = append(warnings, fmt.Sprintf("the port %d/%s of '%s' is also used by the port '%s' of the configuration, which is left out of the Service", p.Port, portProtocol(p), p.Name, inferred.Name))
+			continue
+		}
+		if p, ok := names[inferred.Name]; ok && p.Port != inferred.Port {
+			warnings = append(warnings, fmt.Sprintf("the port name '%s' of the port %d is also used by the port %d of the configuration, which is renamed to 'port-%d' in the Service", p.Name, p.Port, inferred.Port, inferred.Port))
+		}
+	}
+	return warnings, nil
+}
+
+// checkDebugEndpoints rejects the debugging endpoints without an admin namespace to restrict them to, and warns about
+// the debugging extensions which won't be reachable through their Service.
+func checkDebugEndpoints(logger logr.Logger, r *OpenTelemetryCollector) (admission.Warnings, error) {
+	debug := r.Spec.DebugEndpoints
+	if debug == nil || !debug.Enabled {
+		return nil, nil
+	}
+	if r.Spec.Mode == ModeSidecar {
+		return nil, fmt.Errorf("the OpenTelemetry Spec debugEndpoints configuration is incorrect, the debugging endpoints can't be exposed in the %s mode", ModeSidecar)
+	}
+	if debug.AdminNamespace == "" {
+		return nil, fmt.Errorf("the OpenTelemetry Spec debugEndpoints configuration is incorrect, adminNamespace must be set")
+	}
+	if errs := validation.IsDNS1123Label(debug.AdminNamespace); len(errs) > 0 {
+		return nil, fmt.Errorf("the OpenTelemetry Spec debugEndpoints configuration is incorrect, adminNamespace '%s' is not a valid namespace name: %s", debug.AdminNamespace, strings.Join(errs, ", "))
+	}
+
+	config, err := adapters.ConfigFromString(r.Spec.Config)
+	if err != nil {
+		// the configuration itself is checked by the collector
+		return nil, nil
+	}
+	endpoints := adapters.ConfigToDebugEndpoints(logger, config)
+	if len(endpoints) == 0 {
+		return admission.Warnings{"the debugging endpoints are enabled, but the configuration doesn't enable any pprof or remote_tap extension"}, nil
+	}
+	var warnings admission.Warnings
+	for _, e := range endpoints {
+		if e.Loopback() {
+			warnings = append(warnings, fmt.Sprintf("the extension '%s' listens on %s, it can't be reached through the debugging endpoints Service", e.Extension, e.Host))
+		}
+	}
+	return warnings, nil
+}
+
+// wellKnownPort is the protocol of the default port of a receiver.
+type wellKnownPort struct {
+	protocol    corev1.Protocol
+	appProtocol string
+}
+
+// wellKnownPorts are the default ports of the common receivers, by number.
+var wellKnownPorts = map[int32]wellKnownPort{
+	4317:  {protocol: corev1.ProtocolTCP, appProtocol: "grpc"}, // otlp grpc
+	4318:  {protocol: corev1.ProtocolTCP, appProtocol: "http"}, // otlp http
+	6831:  {protocol: corev1.ProtocolUDP},                      // jaeger thrift_compact
+	6832:  {protocol: corev1.ProtocolUDP},                      // jaeger thrift_binary
+	8125:  {protocol: corev1.ProtocolUDP},                      // statsd
+	9411:  {protocol: corev1.ProtocolTCP, appProtocol: "http"}, // zipkin
+	14250: {protocol: corev1.ProtocolTCP, appProtocol: "grpc"}, // jaeger grpc
+	14268: {protocol: corev1.ProtocolTCP, appProtocol: "http"}, // jaeger thrift_http
+	55678: {protocol: corev1.ProtocolTCP, appProtocol: "grpc"}, // opencensus
+}
+
+// defaultPorts sets the protocol and the appProtocol left out by the ports of the spec, which replace the ports of the
+// receivers listening on the same numbers in the Service. They are taken from the receiver of the configuration
+// listening on the port, falling back to the default ports of the common receivers. As the API server defaults the
+// protocol to TCP, a TCP port is switched to UDP when the receiver listening on it only accepts UDP.
+func defaultPorts(logger logr.Logger, r *OpenTelemetryCollector) {
+	if len(r.Spec.Ports) == 0 {
+		return
+	}
+	// the configuration itself is checked by the collector, the well-known ports still apply when it's invalid
+	inferredPorts, _ := adapters.ConfigStringToPorts(logger, r.Spec.Config)
+	for i := range r.Spec.Ports {
+		p := &r.Spec.Ports[i]
+		if inferred, ok := receiverPort(p.ServicePort, inferredPorts); ok {
+			if p.Protocol == "" || (p.Protocol == corev1.ProtocolTCP && portProtocol(inferred) == corev1.ProtocolUDP) {
+				p.Protocol = portProtocol(inferred)
+			}
+			if p.AppProtocol == nil && inferred.AppProtocol != nil {
+				appProtocol := *inferred.AppProtocol
+				p.AppProtocol = &appProtocol
+			}
+			continue
+		}
+		if known, ok := wellKnownPorts[p.Port]; ok {
+			if p.Protocol == "" {
+				p.Protocol = known.protocol
+			}
+			if p.AppProtocol == nil && known.appProtocol != "" {
+				appProtocol := known.appProtocol
+				p.AppProtocol = &appProtocol
+			}
+		}
+	}
+}
+
+// checkPortProtocols rejects the ports of the spec whose protocol differs from the one of the receiver listening on
+// them, as the Service would route the traffic to a port the collector doesn't listen on.
+func checkPortProtocols(logger logr.Logger, r *OpenTelemetryCollector) error {
+	if len(r.Spec.Ports) == 0 {
+		return nil
+	}
+	inferredPorts, err := adapters.ConfigStringToPorts(logger, r.Spec.Config)
+	if err != nil {
+		return nil
+	}
+	for _, port := range r.Spec.Ports {
+		p := port.ServicePort
+		if inferred, ok := receiverPort(p, inferredPorts); ok && portProtocol(p) != portProtocol(inferred) {
+			return fmt.Errorf("the port '%s' uses %s, while the port '%s' of the configuration listens on %d over %s", p.Name, portProtocol(p), inferred.Name, p.Port, portProtocol(inferred))
+		}
+	}
+	return nil
+}
+
+// receiverPort returns the port of the configuration with the number of the given port, preferring the one with the
+// same name when several receivers listen on the number, over different protocols.
+func receiverPort(p corev1.ServicePort, inferredPorts []corev1.ServicePort) (corev1.ServicePort, bool) {
+	var found *corev1.ServicePort
+	for i := range inferredPorts {
+		if inferredPorts[i].Port != p.Port {
+			continue
+		}
+		if found == nil || inferredPorts[i].Name == p.Name {
+			found = &inferredPorts[i]
+		}
+	}
+	if found == nil {
+		return corev1.ServicePort{}, false
+	}
+	return *found, true
+}
+
+func portProtocol(p corev1.ServicePort) corev1.Protocol {
+	if p.Protocol == "" {
+		return corev1.ProtocolTCP
+	}
+	return p.Protocol
+}
+
+func portKey(p corev1.ServicePort) string {
+	return fmt.Sprintf("%d/%s", p.Port, portProtocol(p))
+}
+
+// validateReferences warns about pods that still run a sidecar injected from the given collector. Those pods keep
+// the removed configuration until they are restarted, so deleting the collector leaves them orphaned. Whatever the
+// form of their annotation, name or namespace/name for the pods of other namespaces, the pods are labeled with the
+// namespace and the name of the collector.
+func (c CollectorWebhook) validateReferences(ctx context.Context, r *OpenTelemetryCollector) admission.Warnings {
+	if c.pods == nil || r.Spec.Mode != ModeSidecar {
+		return nil
+	}
+	pods := &corev1.PodList{}
+	selector := client.MatchingLabels{sidecarInjectedLabel: fmt.Sprintf("%s.%s", r.Namespace, r.Name)}
+	if err := c.pods.List(ctx, pods, selector); err != nil {
+		c.logger.Error(err, "failed to list pods referencing the OpenTelemetryCollector", "name", r.Name, "namespace", r.Namespace)
+		return nil
+	}
+	if len(pods.Items) == 0 {
+		return nil
+	}
+	return admission.Warnings{fmt.Sprintf("%d pod(s) still run a sidecar injected from OpenTelemetryCollector %s, they have to be restarted to remove it", len(pods.Items), r.Name)}
+}
+
+// pinImages pins the images set in the spec to digests, when enabled. The images left empty follow the default images
+// of the operator, which are pinned by setting the defaults to digests.
+func (c CollectorWebhook) pinImages(ctx context.Context, r *OpenTelemetryCollector) error {
+	images := []*string{&r.Spec.Image}
+	for i := range r.Spec.InitContainers {
+		images = append(images, &r.Spec.InitContainers[i].Image)
+	}
+	for i := range r.Spec.AdditionalContainers {
+		images = append(images, &r.Spec.AdditionalContainers[i].Image)
+	}
+	if r.Spec.TargetAllocator.Enabled {
+		images = append(images, &r.Spec.TargetAllocator.Image)
+	}
+	if r.Spec.Spiffe.Enabled {
+		images = append(images, &r.Spec.Spiffe.HelperImage)
+	}
+	if r.Spec.Drain != nil {
+		images = append(images, &r.Spec.Drain.Image)
+	}
+	return pinImages(ctx, c.cfg.ImageDigestResolver(), images...)
+}
+
+// validateImageRegistries rejects the collectors whose images aren't pulled from the allowed registries, when set.
+func (c CollectorWebhook) validateImageRegistries(r *OpenTelemetryCollector) error {
+	images := []containerImage{{name: "collector container", image: r.Spec.Image}}
+	for _, container := range r.Spec.InitContainers {
+		images = append(images, containerImage{name: fmt.Sprintf("init container %s", container.Name), image: container.Image})
+	}
+	for _, container := range r.Spec.AdditionalContainers {
+		images = append(images, containerImage{name: fmt.Sprintf("container %s", container.Name), image: container.Image})
+	}
+	if r.Spec.TargetAllocator.Enabled {
+		images = append(images, containerImage{name: "target allocator container", image: r.Spec.TargetAllocator.Image})
+	}
+	if r.Spec.Spiffe.Enabled {
+		images = append(images, containerImage{name: "spiffe-helper container", image: r.Spec.Spiffe.HelperImage})
+	}
+	if r.Spec.Drain != nil {
+		images = append(images, containerImage{name: "drain init container", image: r.Spec.Drain.Image})
+	}
+	defaults := map[string]bool{
+		c.cfg.CollectorImage():       true,
+		c.cfg.TargetAllocatorImage(): true,
+		c.cfg.SpiffeHelperImage():    true,
+		c.cfg.DrainImage():           true,
+	}
+	return validateImageRegistries("OpenTelemetry Collector", c.cfg.AllowedImageRegistries(), defaults, images...)
+}
+
+// validateAutopilot rejects the collectors using the features forbidden on GKE Autopilot, when enabled. It isn't run
+// on deletion: the collectors created before the mode was enabled can still be deleted.
+func (c CollectorWebhook) validateAutopilot(r *OpenTelemetryCollector) error {
+	if !c.cfg.Autopilot() {
+		return nil
+	}
+	securityContexts := []*corev1.SecurityContext{r.Spec.SecurityContext}
+	for _, container := range r.Spec.InitContainers {
+		securityContexts = append(securityContexts, container.SecurityContext)
+	}
+	for _, container := range r.Spec.AdditionalContainers {
+		securityContexts = append(securityContexts, container.SecurityContext)
+	}
+	return validateAutopilot("OpenTelemetry Collector", r.Spec.HostNetwork, r.Spec.Volumes, securityContexts...)
+}
+
+// validateNamespaceScoped rejects the collectors using the features which need cluster-scoped permissions, which a
+// namespace-scoped operator can't grant. It isn't run on deletion: the collectors created before the operator was
+// namespace-scoped can still be deleted.
+func (c CollectorWebhook) validateNamespaceScoped(r *OpenTelemetryCollector) error {
+	if c.cfg.NamespaceScoped() && r.Spec.TargetAllocator.Enabled && r.Spec.TargetAllocator.PrometheusCR.Enabled {
+		return fmt.Errorf("the OpenTelemetry Collector must not enable the targetAllocator prometheusCR with a namespace-scoped operator, which can't create the ClusterRole the target allocator needs")
+	}
+	return nil
+}
+
+// imageArchitectures looks up the architectures an image is built for, replaced by the tests.
+var imageArchitectures = (*imagedigest.Resolver).Architectures
+
+// validateArchitectures rejects the collectors whose image is built for several platforms, but not for all the node
+// architectures of the spec, which would crash-loop on the nodes of the other architectures. The architectures of the
+// images are only looked up when the registries are queried for the digests, and only the indexes of the
+// multi-platform images list them: the other images are accepted.
+func (c CollectorWebhook) validateArchitectures(ctx context.Context, r *OpenTelemetryCollector) error {
+	if len(r.Spec.NodeArchitectures) == 0 || r.Spec.Mode == ModeSidecar {
+		return nil
+	}
+	image := r.Spec.Image
+	if image == "" {
+		image = c.cfg.CollectorImage()
+	}
+	architectures, err := imageArchitectures(c.cfg.ImageDigestResolver(), ctx, image)
+	if err != nil {
+		c.logger.V(1).Info("couldn't look up the architectures of the collector image", "image", image, "error", err.Error())
+		return nil
+	}
+	if len(architectures) == 0 {
+		return nil
+	}
+	built := map[string]bool{}
+	for _, arch := range architectures {
+		built[arch] = true
+	}
+	var missing []string
+	for _, arch := range r.Spec.NodeArchitectures {
+		if !built[string(arch)] {
+			missing = append(missing, string(arch))
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("the OpenTelemetry Spec nodeArchitectures include %s, but the image %s is only built for %s", strings.Join(missing, ", "), image, strings.Join(architectures, ", "))
+	}
+	return nil
+}
+
+// validateNames rejects the collectors whose resources would have the names of the resources of another collector of
+// the namespace, which the name overrides make possible.
+func (c CollectorWebhook) validateNames(ctx context.Context, r *OpenTelemetryCollector) error {
+	if c.reader == nil {
+		return nil
+	}
+	list := &OpenTelemetryCollectorList{}
+	if err := c.reader.List(ctx, list, client.InNamespace(r.Namespace)); err != nil {
+		return fmt.Errorf("failed to list the OpenTelemetry Collectors of the namespace %s: %w", r.Namespace, err)
+	}
+	name := naming.Collector(r)
+	for i := range list.Items {
+		other := &list.Items[i]
+		if other.Name != r.Name && naming.Collector(other) == name {
+			return fmt.Errorf("the resources of the OpenTelemetry Collector would be named '%s', like the ones of the OpenTelemetry Collector '%s', set a distinct nameOverride or fullnameOverride", name, other.Name)
+		}
+	}
+	return nil
+}
+
+// validateQuotas rejects the collectors whose pods would be refused by the LimitRanges or the ResourceQuotas of the
+// namespace, when enabled. The target allocator runs in pods of its own.
+func (c CollectorWebhook) validateQuotas(ctx context.Context, r *OpenTelemetryCollector) error {
+	if !c.cfg.ResourceQuotaValidation() {
+		return nil
+	}
+	containers := []containerResources{{name: "collector container", resources: r.Spec.Resources}}
+	for _, container := range r.Spec.InitContainers {
+		containers = append(containers, containerResources{name: fmt.Sprintf("init container %s", container.Name), resources: container.Resources})
+	}
+	for _, container := range r.Spec.AdditionalContainers {
+		containers = append(containers, containerResources{name: fmt.Sprintf("container %s", container.Name), resources: container.Resources})
+	}
+	err := validateQuotas(ctx, c.reader, r.Namespace, "OpenTelemetry Collector", containers...)
+	if r.Spec.TargetAllocator.Enabled {
+		taContainer := containerResources{name: "target allocator container", resources: r.Spec.TargetAllocator.Resources}
+		err = errors.Join(err, validateQuotas(ctx, c.reader, r.Namespace, "OpenTelemetry Collector", taContainer))
+	}
+	return err
+}
+
+// NewCollectorWebhook returns the webhook defaulting and validating OpenTelemetryCollector resources.
+// The reader is used to look up the quotas of the namespace, it can be nil when there's no cluster to query.
+func NewCollectorWebhook(logger logr.Logger, scheme *runtime.Scheme, cfg config.Config, reader client.Reader) *CollectorWebhook {
+	return &CollectorWebhook{
+		logger: logger,
+		scheme: scheme,
 		cfg:    cfg,
+		reader: reader,
+	}
+}
+
+// sidecarPodsCache returns a cache of the metadata of the pods running an injected sidecar, in every namespace. The
+// manager only caches the pods of the collectors, its cache can't select other pods.
+func sidecarPodsCache(mgr ctrl.Manager) (cache.Cache, error) {
+	injected, err := labels.NewRequirement(sidecarInjectedLabel, selection.Exists, nil)
+	if err != nil {
+		return nil, err
+	}
+	return cache.New(mgr.GetConfig(), cache.Options{
+		Scheme:               mgr.GetScheme(),
+		Mapper:               mgr.GetRESTMapper(),
+		DefaultLabelSelector: labels.NewSelector().Add(*injected),
+		DefaultTransform: func(obj interface{}) (interface{}, error) {
+			pod, ok := obj.(*corev1.Pod)
+			if !ok {
+				return obj, nil
+			}
+			meta := pod.ObjectMeta
+			meta.ManagedFields = nil
+			return &corev1.Pod{ObjectMeta: meta}, nil
+		},
+	})
+}
+
+func SetupCollectorWebhook(mgr ctrl.Manager, cfg config.Config) error {
+	cvw := NewCollectorWebhook(mgr.GetLogger().WithValues("handler", "CollectorWebhook"), mgr.GetScheme(), cfg, mgr.GetAPIReader())
+	pods, err := sidecarPodsCache(mgr)
+	if err != nil {
+		return err
+	}
+	if err := mgr.Add(pods); err != nil {
+		return err
 	}
+	cvw.pods = pods
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&OpenTelemetryCollector{}).
 		WithValidator(cvw).
@@ -19,9 +19,12 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -29,8 +32,12 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/imagedigest"
 )
 
 var (
@@ -39,6 +46,7 @@ var (
 
 func TestOTELColDefaultingWebhook(t *testing.T) {
 	one := int32(1)
+	three := int32(3)
 	five := int32(5)
 	defaultCPUTarget := int32(90)
 
@@ -169,6 +177,41 @@ func TestOTELColDefaultingWebhook(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Replicas default to the autoscaler MinReplicas",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Autoscaler: &AutoscalerSpec{
+						MaxReplicas: &five,
+						MinReplicas: &three,
+					},
+				},
+			},
+			expected: OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"app.kubernetes.io/managed-by": "opentelemetry-operator",
+					},
+				},
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:            ModeDeployment,
+					Replicas:        &three,
+					UpgradeStrategy: UpgradeStrategyAutomatic,
+					ManagementState: ManagementStateManaged,
+					Autoscaler: &AutoscalerSpec{
+						TargetCPUUtilization: &defaultCPUTarget,
+						MaxReplicas:          &five,
+						MinReplicas:          &three,
+					},
+					PodDisruptionBudget: &PodDisruptionBudgetSpec{
+						MaxUnavailable: &intstr.IntOrString{
+							Type:   intstr.Int,
+							IntVal: 1,
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "MaxReplicas but no Autoscale",
 			otelcol: OpenTelemetryCollector{
@@ -293,16 +336,215 @@ func TestOTELColDefaultingWebhook(t *testing.T) {
 	}
 }
 
+func TestOTELColDefaultingPorts(t *testing.T) {
+	grpc, http := "grpc", "http"
+	for _, tt := range []struct {
+		name     string
+		config   string
+		ports    []PortsSpec
+		expected []PortsSpec
+	}{
+		{
+			name: "protocols of the receivers",
+			config: `receivers:
+  statsd:
+  otlp:
+    protocols:
+      grpc:
+service:
+  pipelines:
+    metrics:
+      receivers: [statsd, otlp]
+`,
+			ports: []PortsSpec{
+				{ServicePort: v1.ServicePort{Name: "statsd", Port: 8125, Protocol: v1.ProtocolTCP}},
+				{ServicePort: v1.ServicePort{Name: "otlp-grpc", Port: 4317, Protocol: v1.ProtocolTCP, NodePort: 30317}},
+			},
+			expected: []PortsSpec{
+				{ServicePort: v1.ServicePort{Name: "statsd", Port: 8125, Protocol: v1.ProtocolUDP}},
+				{ServicePort: v1.ServicePort{Name: "otlp-grpc", Port: 4317, Protocol: v1.ProtocolTCP, AppProtocol: &grpc, NodePort: 30317}},
+			},
+		},
+		{
+			name: "well-known ports",
+			ports: []PortsSpec{
+				{ServicePort: v1.ServicePort{Name: "jaeger-compact", Port: 6831}},
+				{ServicePort: v1.ServicePort{Name: "otlp-http", Port: 4318}},
+				{ServicePort: v1.ServicePort{Name: "custom", Port: 5555}},
+			},
+			expected: []PortsSpec{
+				{ServicePort: v1.ServicePort{Name: "jaeger-compact", Port: 6831, Protocol: v1.ProtocolUDP}},
+				{ServicePort: v1.ServicePort{Name: "otlp-http", Port: 4318, Protocol: v1.ProtocolTCP, AppProtocol: &http}},
+				{ServicePort: v1.ServicePort{Name: "custom", Port: 5555}},
+			},
+		},
+		{
+			name:   "provided protocols",
+			config: "receivers:\n  zipkin:\nservice:\n  pipelines:\n    traces:\n      receivers: [zipkin]\n",
+			ports: []PortsSpec{
+				{ServicePort: v1.ServicePort{Name: "zipkin", Port: 9411, Protocol: v1.ProtocolTCP, AppProtocol: &grpc}},
+				{ServicePort: v1.ServicePort{Name: "statsd", Port: 8125, Protocol: v1.ProtocolTCP}},
+			},
+			expected: []PortsSpec{
+				{ServicePort: v1.ServicePort{Name: "zipkin", Port: 9411, Protocol: v1.ProtocolTCP, AppProtocol: &grpc}},
+				{ServicePort: v1.ServicePort{Name: "statsd", Port: 8125, Protocol: v1.ProtocolTCP}},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// prepare
+			otelcol := OpenTelemetryCollector{Spec: OpenTelemetryCollectorSpec{Config: tt.config, Ports: tt.ports}}
+
+			// test
+			defaultPorts(logr.Discard(), &otelcol)
+
+			// verify
+			assert.Equal(t, tt.expected, otelcol.Spec.Ports)
+		})
+	}
+}
+
 // TODO: a lot of these tests use .Spec.MaxReplicas and .Spec.MinReplicas. These fields are
 // deprecated and moved to .Spec.Autoscaler. Fine to use these fields to test that old CRD is
 // still supported but should eventually be updated.
+func TestOTELColDefaultingImageDigests(t *testing.T) {
+	digest := "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	resolver, err := imagedigest.New(logr.Discard(), map[string]string{
+		"ghcr.io/org/collector:1.0": digest,
+		"busybox:1.36":              digest,
+	}, false)
+	require.NoError(t, err)
+	cvw := &CollectorWebhook{
+		logger: logr.Discard(),
+		scheme: testScheme,
+		cfg:    config.New(config.WithImageDigestResolver(resolver)),
+	}
+	otelcol := &OpenTelemetryCollector{
+		Spec: OpenTelemetryCollectorSpec{
+			Image:                "ghcr.io/org/collector:1.0",
+			InitContainers:       []v1.Container{{Name: "init", Image: "busybox:1.36"}},
+			AdditionalContainers: []v1.Container{{Name: "proxy", Image: "envoyproxy/envoy:v1.28"}},
+		},
+	}
+
+	err = cvw.Default(context.Background(), otelcol)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "ghcr.io/org/collector:1.0@"+digest, otelcol.Spec.Image)
+	assert.Equal(t, "busybox:1.36@"+digest, otelcol.Spec.InitContainers[0].Image)
+	assert.Equal(t, "envoyproxy/envoy:v1.28", otelcol.Spec.AdditionalContainers[0].Image, "the images missing from the mapping are left as is")
+	assert.Empty(t, otelcol.Spec.TargetAllocator.Image)
+}
+
+func TestOTELColDefaultingImageDigestsNotResolved(t *testing.T) {
+	// the registries are queried, the one of the image isn't reachable
+	resolver, err := imagedigest.New(logr.Discard(), nil, true)
+	require.NoError(t, err)
+	cvw := &CollectorWebhook{
+		logger: logr.Discard(),
+		scheme: testScheme,
+		cfg: config.New(
+			config.WithImageDigestResolver(resolver),
+			config.WithAllowedImageRegistries([]string{"127.0.0.1:1"}),
+		),
+	}
+	otelcol := &OpenTelemetryCollector{
+		Spec: OpenTelemetryCollectorSpec{
+			Image: "127.0.0.1:1/org/collector:1.0",
+		},
+	}
+
+	err = cvw.Default(context.Background(), otelcol)
+
+	assert.ErrorContains(t, err, "failed to resolve the digest of the image 127.0.0.1:1/org/collector:1.0")
+	assert.NotContains(t, err.Error(), "isn't one of the allowed image registries")
+}
+
+func TestOTELColValidateArchitectures(t *testing.T) {
+	defer func(lookup func(*imagedigest.Resolver, context.Context, string) ([]string, error)) {
+		imageArchitectures = lookup
+	}(imageArchitectures)
+	imageArchitectures = func(_ *imagedigest.Resolver, _ context.Context, image string) ([]string, error) {
+		switch image {
+		case "ghcr.io/org/collector:multi":
+			return []string{"amd64", "arm64"}, nil
+		case "ghcr.io/org/collector:amd64":
+			return []string{"amd64"}, nil
+		case "ghcr.io/org/collector:unreachable":
+			return nil, fmt.Errorf("the registry answered 503 Service Unavailable")
+		}
+		return nil, nil
+	}
+	cvw := &CollectorWebhook{
+		logger: logr.Discard(),
+		scheme: testScheme,
+		cfg:    config.New(config.WithCollectorImage("ghcr.io/org/collector:amd64")),
+	}
+
+	for _, tt := range []struct {
+		desc          string
+		image         string
+		mode          Mode
+		architectures []NodeArchitecture
+		expectedErr   string
+	}{
+		{
+			desc:          "multi-platform image",
+			image:         "ghcr.io/org/collector:multi",
+			architectures: []NodeArchitecture{NodeArchitectureAMD64, NodeArchitectureARM64},
+		},
+		{
+			desc:          "missing architecture",
+			image:         "ghcr.io/org/collector:amd64",
+			architectures: []NodeArchitecture{NodeArchitectureARM64, NodeArchitectureAMD64},
+			expectedErr:   "the OpenTelemetry Spec nodeArchitectures include arm64, but the image ghcr.io/org/collector:amd64 is only built for amd64",
+		},
+		{
+			desc:          "default image",
+			architectures: []NodeArchitecture{NodeArchitectureARM64},
+			expectedErr:   "the image ghcr.io/org/collector:amd64 is only built for amd64",
+		},
+		{
+			desc:          "single-platform image",
+			image:         "ghcr.io/org/collector:single",
+			architectures: []NodeArchitecture{NodeArchitectureARM64},
+		},
+		{
+			desc:          "unreachable registry",
+			image:         "ghcr.io/org/collector:unreachable",
+			architectures: []NodeArchitecture{NodeArchitectureARM64},
+		},
+		{
+			desc:          "sidecar",
+			image:         "ghcr.io/org/collector:amd64",
+			mode:          ModeSidecar,
+			architectures: []NodeArchitecture{NodeArchitectureARM64},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			otelcol := &OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{Mode: tt.mode, Image: tt.image, NodeArchitectures: tt.architectures},
+			}
+			err := cvw.validateArchitectures(context.Background(), otelcol)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
 func TestOTELColValidatingWebhook(t *testing.T) {
+	singleStack := v1.IPFamilyPolicySingleStack
+	notExposed := false
 	minusOne := int32(-1)
 	zero := int32(0)
 	zero64 := int64(0)
 	one := int32(1)
 	three := int32(3)
 	five := int32(5)
+	invalidAppProtocol := "not a protocol"
 
 	tests := []struct { //nolint:govet
 		name             string
@@ -314,6 +556,28 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 			name:    "valid empty spec",
 			otelcol: OpenTelemetryCollector{},
 		},
+		{
+			name: "valid override of an inferred port",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+exporters:
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [debug]
+`,
+					Ports: []PortsSpec{
+						{ServicePort: v1.ServicePort{Name: "otlp-grpc", Port: 4317, NodePort: 30317}},
+					},
+				},
+			},
+		},
 		{
 			name: "valid full spec",
 			otelcol: OpenTelemetryCollector{
@@ -341,16 +605,16 @@ func TestOTELColValidatingWebhook(t *testing.T) {
       thrift_http:
         endpoint: 0.0.0.0:15268
 `,
-					Ports: []v1.ServicePort{
-						{
+					Ports: []PortsSpec{
+						{ServicePort: v1.ServicePort{
 							Name: "port1",
 							Port: 5555,
-						},
-						{
+						}},
+						{ServicePort: v1.ServicePort{
 							Name:     "port2",
 							Port:     5554,
 							Protocol: v1.ProtocolUDP,
-						},
+						}},
 					},
 					Autoscaler: &AutoscalerSpec{
 						Behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{
@@ -376,6 +640,39 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 			},
 			expectedErr: "does not support the attribute 'volumeClaimTemplates'",
 		},
+		{
+			name: "invalid mode with prometheusSharding",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:               ModeDeployment,
+					PrometheusSharding: true,
+				},
+			},
+			expectedErr: "does not support the attribute 'prometheusSharding'",
+		},
+		{
+			name: "prometheusSharding with the TargetAllocator",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:               ModeStatefulSet,
+					PrometheusSharding: true,
+					TargetAllocator:    OpenTelemetryTargetAllocator{Enabled: true},
+				},
+			},
+			expectedErr: "prometheusSharding can't be used along with the TargetAllocator",
+		},
+		{
+			name: "invalid mode with persistentVolumeClaimRetentionPolicy",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDeployment,
+					PersistentVolumeClaimRetentionPolicy: &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
+						WhenDeleted: appsv1.DeletePersistentVolumeClaimRetentionPolicyType,
+					},
+				},
+			},
+			expectedErr: "does not support the attribute 'persistentVolumeClaimRetentionPolicy'",
+		},
 		{
 			name: "invalid mode with tolerations",
 			otelcol: OpenTelemetryCollector{
@@ -411,363 +708,1214 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 			expectedErr: "the OpenTelemetry Spec Prometheus configuration is incorrect",
 		},
 		{
-			name: "invalid port name",
+			name: "remote clusters without prometheusCR",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					Ports: []v1.ServicePort{
-						{
-							// this port name contains a non alphanumeric character, which is invalid.
-							Name:     "-test🦄port",
-							Port:     12345,
-							Protocol: v1.ProtocolTCP,
+					TargetAllocator: OpenTelemetryTargetAllocator{
+						PrometheusCR: OpenTelemetryTargetAllocatorPrometheusCR{
+							RemoteClusters: []OpenTelemetryTargetAllocatorRemoteCluster{{Name: "east"}},
 						},
 					},
 				},
 			},
-			expectedErr: "the OpenTelemetry Spec Ports configuration is incorrect",
+			expectedErr: "requires targetAllocator.prometheusCR to be enabled",
 		},
 		{
-			name: "invalid port name, too long",
+			name: "invalid remote cluster name",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					Ports: []v1.ServicePort{
-						{
-							Name: "aaaabbbbccccdddd", // len: 16, too long
-							Port: 5555,
+					TargetAllocator: OpenTelemetryTargetAllocator{
+						PrometheusCR: OpenTelemetryTargetAllocatorPrometheusCR{
+							Enabled:        true,
+							RemoteClusters: []OpenTelemetryTargetAllocatorRemoteCluster{{Name: "East_1"}},
 						},
 					},
 				},
 			},
-			expectedErr: "the OpenTelemetry Spec Ports configuration is incorrect",
+			expectedErr: "remoteClusters name 'East_1' is incorrect",
 		},
 		{
-			name: "invalid port num",
+			name: "duplicated remote cluster",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					Ports: []v1.ServicePort{
-						{
-							Name: "aaaabbbbccccddd", // len: 15
-							// no port set means it's 0, which is invalid
+					TargetAllocator: OpenTelemetryTargetAllocator{
+						PrometheusCR: OpenTelemetryTargetAllocatorPrometheusCR{
+							Enabled: true,
+							RemoteClusters: []OpenTelemetryTargetAllocatorRemoteCluster{
+								{Name: "east", KubeconfigSecret: v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "east"}, Key: "kubeconfig"}},
+								{Name: "east", KubeconfigSecret: v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "west"}, Key: "kubeconfig"}},
+							},
 						},
 					},
 				},
 			},
-			expectedErr: "the OpenTelemetry Spec Ports configuration is incorrect",
+			expectedErr: "remoteClusters name 'east' is duplicated",
 		},
 		{
-			name: "invalid max replicas",
+			name: "remote cluster without kubeconfig secret",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					MaxReplicas: &zero,
+					TargetAllocator: OpenTelemetryTargetAllocator{
+						PrometheusCR: OpenTelemetryTargetAllocatorPrometheusCR{
+							Enabled:        true,
+							RemoteClusters: []OpenTelemetryTargetAllocatorRemoteCluster{{Name: "east"}},
+						},
+					},
 				},
 			},
-			expectedErr:      "maxReplicas should be defined and one or more",
-			expectedWarnings: []string{"MaxReplicas is deprecated"},
+			expectedErr: "must set the name and key of its kubeconfigSecret",
 		},
 		{
-			name: "invalid replicas, greater than max",
+			name: "prometheusCR with target allocator",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					MaxReplicas: &three,
-					Replicas:    &five,
+					Mode: ModeStatefulSet,
+					TargetAllocator: OpenTelemetryTargetAllocator{
+						Enabled: true,
+					},
+					PrometheusCR: OpenTelemetryCollectorPrometheusCR{
+						Enabled: true,
+					},
 				},
 			},
-			expectedErr:      "replicas must not be greater than maxReplicas",
-			expectedWarnings: []string{"MaxReplicas is deprecated"},
+			expectedErr: "prometheusCR must not be enabled along with the target allocator",
 		},
 		{
-			name: "invalid min replicas, greater than max",
+			name: "invalid mode with prometheusCR",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					MaxReplicas: &three,
-					MinReplicas: &five,
+					Mode: ModeSidecar,
+					PrometheusCR: OpenTelemetryCollectorPrometheusCR{
+						Enabled: true,
+					},
 				},
 			},
-			expectedErr:      "minReplicas must not be greater than maxReplicas",
-			expectedWarnings: []string{"MaxReplicas is deprecated", "MinReplicas is deprecated"},
+			expectedErr: "does not support the attribute 'prometheusCR'",
 		},
 		{
-			name: "invalid min replicas, lesser than 1",
+			name: "prometheusCR without prometheus receiver",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					MaxReplicas: &three,
-					MinReplicas: &zero,
+					PrometheusCR: OpenTelemetryCollectorPrometheusCR{
+						Enabled: true,
+					},
+					Config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+`,
 				},
 			},
-			expectedErr:      "minReplicas should be one or more",
-			expectedWarnings: []string{"MaxReplicas is deprecated", "MinReplicas is deprecated"},
+			expectedErr: "the OpenTelemetry Spec Prometheus configuration is incorrect",
 		},
 		{
-			name: "invalid autoscaler scale down",
+			name: "invalid upgrade window schedule",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					MaxReplicas: &three,
-					Autoscaler: &AutoscalerSpec{
-						Behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{
-							ScaleDown: &autoscalingv2.HPAScalingRules{
-								StabilizationWindowSeconds: &zero,
-							},
-						},
+					UpgradeWindows: []UpgradeWindow{
+						{Schedule: "not a schedule", Duration: metav1.Duration{Duration: time.Hour}},
 					},
 				},
 			},
-			expectedErr:      "scaleDown should be one or more",
-			expectedWarnings: []string{"MaxReplicas is deprecated"},
+			expectedErr: "invalid upgrade window schedule",
 		},
 		{
-			name: "invalid autoscaler scale up",
+			name: "duplicated ip families",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					MaxReplicas: &three,
-					Autoscaler: &AutoscalerSpec{
-						Behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{
-							ScaleUp: &autoscalingv2.HPAScalingRules{
-								StabilizationWindowSeconds: &zero,
-							},
-						},
-					},
+					IPFamilies: []v1.IPFamily{v1.IPv6Protocol, v1.IPv6Protocol},
 				},
 			},
-			expectedErr:      "scaleUp should be one or more",
-			expectedWarnings: []string{"MaxReplicas is deprecated"},
+			expectedErr: "the OpenTelemetry Spec ipFamilies contains the IP family 'IPv6' more than once",
 		},
 		{
-			name: "invalid autoscaler target cpu utilization",
+			name: "dual-stack ip families with a single stack policy",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					MaxReplicas: &three,
-					Autoscaler: &AutoscalerSpec{
-						TargetCPUUtilization: &zero,
+					TargetAllocator: OpenTelemetryTargetAllocator{
+						IPFamilies:     []v1.IPFamily{v1.IPv4Protocol, v1.IPv6Protocol},
+						IPFamilyPolicy: &singleStack,
 					},
 				},
 			},
-			expectedErr:      "targetCPUUtilization should be greater than 0 and less than 100",
-			expectedWarnings: []string{"MaxReplicas is deprecated"},
+			expectedErr: "the OpenTelemetry Spec targetAllocator.ipFamilyPolicy is SingleStack, which does not support more than one IP family",
 		},
 		{
-			name: "autoscaler minReplicas is less than maxReplicas",
+			name: "invalid upgrade window duration",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					Autoscaler: &AutoscalerSpec{
-						MaxReplicas: &one,
-						MinReplicas: &five,
+					UpgradeWindows: []UpgradeWindow{
+						{Schedule: "0 2 * * *"},
 					},
 				},
 			},
-			expectedErr: "the OpenTelemetry Spec autoscale configuration is incorrect, minReplicas must not be greater than maxReplicas",
+			expectedErr: "must have a positive duration",
 		},
 		{
-			name: "invalid autoscaler metric type",
+			name: "invalid port name",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					MaxReplicas: &three,
-					Autoscaler: &AutoscalerSpec{
-						Metrics: []MetricSpec{
-							{
-								Type: autoscalingv2.ResourceMetricSourceType,
-							},
-						},
+					Ports: []PortsSpec{
+						{ServicePort: v1.ServicePort{
+							// this port name contains a non alphanumeric character, which is invalid.
+							Name:     "-test🦄port",
+							Port:     12345,
+							Protocol: v1.ProtocolTCP,
+						}},
 					},
 				},
 			},
-			expectedErr:      "the OpenTelemetry Spec autoscale configuration is incorrect, metric type unsupported. Expected metric of source type Pod",
-			expectedWarnings: []string{"MaxReplicas is deprecated"},
+			expectedErr: "the OpenTelemetry Spec Ports configuration is incorrect",
 		},
 		{
-			name: "invalid pod metric average value",
+			name: "invalid port protocol",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					MaxReplicas: &three,
-					Autoscaler: &AutoscalerSpec{
-						Metrics: []MetricSpec{
-							{
-								Type: autoscalingv2.PodsMetricSourceType,
-								Pods: &autoscalingv2.PodsMetricSource{
-									Metric: autoscalingv2.MetricIdentifier{
-										Name: "custom1",
-									},
-									Target: autoscalingv2.MetricTarget{
-										Type:         autoscalingv2.AverageValueMetricType,
-										AverageValue: resource.NewQuantity(int64(0), resource.DecimalSI),
-									},
-								},
-							},
-						},
-					},
+					Ports: []PortsSpec{{ServicePort: v1.ServicePort{Name: "quic", Port: 5555, Protocol: "QUIC"}}},
 				},
 			},
-			expectedErr:      "the OpenTelemetry Spec autoscale configuration is incorrect, average value should be greater than 0",
-			expectedWarnings: []string{"MaxReplicas is deprecated"},
+			expectedErr: "the protocol 'QUIC' of the port 'quic' isn't one of TCP, UDP or SCTP",
 		},
 		{
-			name: "utilization target is not valid with pod metrics",
+			name: "valid feature gates",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					MaxReplicas: &three,
-					Autoscaler: &AutoscalerSpec{
-						Metrics: []MetricSpec{
-							{
-								Type: autoscalingv2.PodsMetricSourceType,
-								Pods: &autoscalingv2.PodsMetricSource{
-									Metric: autoscalingv2.MetricIdentifier{
-										Name: "custom1",
-									},
-									Target: autoscalingv2.MetricTarget{
-										Type:               autoscalingv2.UtilizationMetricType,
-										AverageUtilization: &one,
-									},
-								},
-							},
-						},
-					},
+					FeatureGates: []string{"+pkg.translator.prometheus.NormalizeName", "-component.UseLocalHostAsDefaultHost", "telemetry.useOtelForInternalMetrics"},
 				},
 			},
-			expectedErr:      "the OpenTelemetry Spec autoscale configuration is incorrect, invalid pods target type",
-			expectedWarnings: []string{"MaxReplicas is deprecated"},
 		},
 		{
-			name: "invalid deployment mode incompabible with ingress settings",
+			name: "invalid feature gate",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					Mode: ModeSidecar,
-					Ingress: Ingress{
-						Type: IngressTypeNginx,
-					},
+					FeatureGates: []string{"+a,-b"},
 				},
 			},
-			expectedErr: fmt.Sprintf("Ingress can only be used in combination with the modes: %s, %s, %s",
-				ModeDeployment, ModeDaemonSet, ModeStatefulSet,
-			),
+			expectedErr: "the OpenTelemetry Spec featureGates configuration is incorrect, '+a,-b' isn't a feature gate",
 		},
 		{
-			name: "invalid mode with priorityClassName",
+			name: "feature gate set twice",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					Mode:              ModeSidecar,
-					PriorityClassName: "test-class",
+					FeatureGates: []string{"+pkg.translator.prometheus.NormalizeName", "-pkg.translator.prometheus.NormalizeName"},
 				},
 			},
-			expectedErr: "does not support the attribute 'priorityClassName'",
+			expectedErr: "the feature gate 'pkg.translator.prometheus.NormalizeName' is set more than once",
 		},
 		{
-			name: "invalid mode with affinity",
+			name: "canary rollout",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					Mode: ModeSidecar,
-					Affinity: &v1.Affinity{
-						NodeAffinity: &v1.NodeAffinity{
-							RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
-								NodeSelectorTerms: []v1.NodeSelectorTerm{
-									{
-										MatchExpressions: []v1.NodeSelectorRequirement{
-											{
-												Key:      "node",
-												Operator: v1.NodeSelectorOpIn,
-												Values:   []string{"test-node"},
-											},
-										},
-									},
-								},
-							},
-						},
+					Mode:     ModeDeployment,
+					Replicas: &five,
+					Rollout: &Rollout{
+						Strategy:         RolloutStrategyCanary,
+						CanaryPercentage: &three,
+						PromotionDelay:   &metav1.Duration{Duration: 10 * time.Minute},
 					},
 				},
 			},
-			expectedErr: "does not support the attribute 'affinity'",
 		},
 		{
-			name: "invalid InitialDelaySeconds",
+			name: "canary rollout of a daemonset",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					LivenessProbe: &Probe{
-						InitialDelaySeconds: &minusOne,
-					},
+					Mode:    ModeDaemonSet,
+					Rollout: &Rollout{Strategy: RolloutStrategyCanary},
 				},
 			},
-			expectedErr: "the OpenTelemetry Spec LivenessProbe InitialDelaySeconds configuration is incorrect",
+			expectedErr: "the OpenTelemetry Spec rollout configuration is incorrect, the canary strategy is only supported in the deployment mode",
 		},
 		{
-			name: "invalid PeriodSeconds",
+			name: "canary rollout of an autoscaled collector",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					LivenessProbe: &Probe{
-						PeriodSeconds: &zero,
+					Mode:       ModeDeployment,
+					Autoscaler: &AutoscalerSpec{MaxReplicas: &three},
+					Rollout:    &Rollout{Strategy: RolloutStrategyCanary},
+				},
+			},
+			expectedErr: "the canary strategy can't be used with autoscaling",
+		},
+		{
+			name: "canary percentage out of range",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:    ModeDeployment,
+					Rollout: &Rollout{Strategy: RolloutStrategyCanary, CanaryPercentage: &minusOne},
+				},
+			},
+			expectedErr: "canaryPercentage should be between 1 and 100, it is -1",
+		},
+		{
+			name: "canary analysis",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDeployment,
+					Rollout: &Rollout{
+						Strategy: RolloutStrategyCanary,
+						Analysis: &RolloutAnalysis{MaxRefused: &zero64, Window: &metav1.Duration{Duration: time.Minute}},
 					},
 				},
 			},
-			expectedErr: "the OpenTelemetry Spec LivenessProbe PeriodSeconds configuration is incorrect",
 		},
 		{
-			name: "invalid TimeoutSeconds",
+			name: "canary analysis without the canary strategy",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					LivenessProbe: &Probe{
-						TimeoutSeconds: &zero,
+					Mode:    ModeDeployment,
+					Rollout: &Rollout{Analysis: &RolloutAnalysis{MaxDropped: &zero64}},
+				},
+			},
+			expectedErr: "the analysis requires the canary strategy",
+		},
+		{
+			name: "canary analysis without thresholds",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:    ModeDeployment,
+					Rollout: &Rollout{Strategy: RolloutStrategyCanary, Analysis: &RolloutAnalysis{}},
+				},
+			},
+			expectedErr: "the analysis must set maxRefused or maxDropped",
+		},
+		{
+			name: "hostPort of a deployment",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:  ModeDeployment,
+					Ports: []PortsSpec{{HostPort: 4317, ServicePort: v1.ServicePort{Name: "otlp-grpc", Port: 4317}}},
+				},
+			},
+			expectedErr: "does not support the attribute 'hostPort' of the port 'otlp-grpc'",
+		},
+		{
+			name: "nodePort of a port not exposed on the service",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Ports: []PortsSpec{{ExposeOnService: &notExposed, ServicePort: v1.ServicePort{Name: "pprof", Port: 1777, NodePort: 31777}}},
+				},
+			},
+			expectedErr: "the port 'pprof' has the nodePort 31777 but isn't exposed on the Service",
+		},
+		{
+			name: "hostPort differing from the port on the network of the host",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:        ModeDaemonSet,
+					HostNetwork: true,
+					Ports:       []PortsSpec{{HostPort: 14317, ServicePort: v1.ServicePort{Name: "otlp-grpc", Port: 4317}}},
+				},
+			},
+			expectedErr: "the hostPort 14317 of the port 'otlp-grpc' must be its port 4317 on the network of the host",
+		},
+		{
+			name: "invalid port appProtocol",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Ports: []PortsSpec{{ServicePort: v1.ServicePort{Name: "custom", Port: 5555, AppProtocol: &invalidAppProtocol}}},
+				},
+			},
+			expectedErr: "the appProtocol 'not a protocol' of the port 'custom' is invalid",
+		},
+		{
+			name: "port protocol differing from the receiver",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+`,
+					Ports: []PortsSpec{{ServicePort: v1.ServicePort{Name: "otlp-grpc", Port: 4317, Protocol: v1.ProtocolUDP}}},
+				},
+			},
+			expectedErr: "the port 'otlp-grpc' uses UDP, while the port 'otlp-grpc' of the configuration listens on 4317 over TCP",
+		},
+		{
+			name: "invalid port name, too long",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Ports: []PortsSpec{
+						{ServicePort: v1.ServicePort{
+							Name: "aaaabbbbccccdddd", // len: 16, too long
+							Port: 5555,
+						}},
 					},
 				},
 			},
-			expectedErr: "the OpenTelemetry Spec LivenessProbe TimeoutSeconds configuration is incorrect",
+			expectedErr: "the OpenTelemetry Spec Ports configuration is incorrect",
 		},
 		{
-			name: "invalid SuccessThreshold",
+			name: "invalid port num",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					LivenessProbe: &Probe{
-						SuccessThreshold: &zero,
+					Ports: []PortsSpec{
+						{ServicePort: v1.ServicePort{
+							Name: "aaaabbbbccccddd", // len: 15
+							// no port set means it's 0, which is invalid
+						}},
 					},
 				},
 			},
-			expectedErr: "the OpenTelemetry Spec LivenessProbe SuccessThreshold configuration is incorrect",
+			expectedErr: "the OpenTelemetry Spec Ports configuration is incorrect",
 		},
 		{
-			name: "invalid FailureThreshold",
+			name: "invalid name override",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					LivenessProbe: &Probe{
-						FailureThreshold: &zero,
+					NameOverride: "My_Collector",
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec nameOverride 'My_Collector' is incorrect",
+		},
+		{
+			name: "invalid full name override",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					FullnameOverride: "gateway.collector",
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec fullnameOverride 'gateway.collector' is incorrect",
+		},
+		{
+			name: "invalid standard label",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					StandardLabels: &StandardLabels{PartOf: "payments/gateway"},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec standardLabels.partOf 'payments/gateway' is incorrect",
+		},
+		{
+			name: "invalid secret provider class name",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					SecretProviderClasses: []SecretProviderClassVolume{{Name: "Vault_Keys"}},
+				},
+			},
+			expectedErr: "the secretProviderClasses name 'Vault_Keys' is incorrect",
+		},
+		{
+			name: "relative secret provider class mount path",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					SecretProviderClasses: []SecretProviderClassVolume{{Name: "vault-keys", MountPath: "secrets"}},
+				},
+			},
+			expectedErr: "the secretProviderClasses vault-keys mountPath 'secrets' must be an absolute path",
+		},
+		{
+			name: "secret provider class mount path already mounted",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					VolumeMounts:          []v1.VolumeMount{{Name: "secrets", MountPath: "/var/run/secrets-store/vault-keys/"}},
+					SecretProviderClasses: []SecretProviderClassVolume{{Name: "vault-keys"}},
+				},
+			},
+			expectedErr: "the secretProviderClasses vault-keys mountPath '/var/run/secrets-store/vault-keys' is already mounted",
+		},
+		{
+			name: "secret provider class volume already defined",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Volumes:               []v1.Volume{{Name: "secrets-store-vault-keys"}},
+					SecretProviderClasses: []SecretProviderClassVolume{{Name: "vault-keys", MountPath: "/etc/vault"}},
+				},
+			},
+			expectedErr: "the volume secrets-store-vault-keys of the secretProviderClasses vault-keys is already defined",
+		},
+		{
+			name: "duplicate port name",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Ports: []PortsSpec{
+						{ServicePort: v1.ServicePort{Name: "port1", Port: 5555}},
+						{ServicePort: v1.ServicePort{Name: "port1", Port: 5556}},
 					},
 				},
 			},
-			expectedErr: "the OpenTelemetry Spec LivenessProbe FailureThreshold configuration is incorrect",
+			expectedErr: "the port name 'port1' is used by the ports 5555 and 5556",
 		},
 		{
-			name: "invalid TerminationGracePeriodSeconds",
+			name: "duplicate port number",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					LivenessProbe: &Probe{
-						TerminationGracePeriodSeconds: &zero64,
+					Ports: []PortsSpec{
+						{ServicePort: v1.ServicePort{Name: "port1", Port: 5555}},
+						{ServicePort: v1.ServicePort{Name: "port2", Port: 5555, Protocol: v1.ProtocolTCP}},
 					},
 				},
 			},
-			expectedErr: "the OpenTelemetry Spec LivenessProbe TerminationGracePeriodSeconds configuration is incorrect",
+			expectedErr: "the port 5555/TCP is used by the ports 'port1' and 'port2'",
 		},
 		{
-			name: "invalid AdditionalContainers",
+			name: "invalid max replicas",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					Mode: ModeSidecar,
-					AdditionalContainers: []v1.Container{
-						{
-							Name: "test",
+					MaxReplicas: &zero,
+				},
+			},
+			expectedErr:      "maxReplicas should be defined and one or more",
+			expectedWarnings: []string{"MaxReplicas is deprecated"},
+		},
+		{
+			name: "invalid replicas, greater than max",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					MaxReplicas: &three,
+					Replicas:    &five,
+				},
+			},
+			expectedErr:      "replicas must not be greater than maxReplicas",
+			expectedWarnings: []string{"MaxReplicas is deprecated"},
+		},
+		{
+			name: "invalid min replicas, greater than max",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					MaxReplicas: &three,
+					MinReplicas: &five,
+				},
+			},
+			expectedErr:      "minReplicas must not be greater than maxReplicas",
+			expectedWarnings: []string{"MaxReplicas is deprecated", "MinReplicas is deprecated"},
+		},
+		{
+			name: "invalid min replicas, lesser than 1",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					MaxReplicas: &three,
+					MinReplicas: &zero,
+				},
+			},
+			expectedErr:      "minReplicas should be one or more",
+			expectedWarnings: []string{"MaxReplicas is deprecated", "MinReplicas is deprecated"},
+		},
+		{
+			name: "invalid autoscaler scale down",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					MaxReplicas: &three,
+					Autoscaler: &AutoscalerSpec{
+						Behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{
+							ScaleDown: &autoscalingv2.HPAScalingRules{
+								StabilizationWindowSeconds: &zero,
+							},
 						},
 					},
 				},
 			},
-			expectedErr: "the OpenTelemetry Collector mode is set to sidecar, which does not support the attribute 'AdditionalContainers'",
+			expectedErr:      "scaleDown should be one or more",
+			expectedWarnings: []string{"MaxReplicas is deprecated"},
 		},
 		{
-			name: "missing ingress hostname for subdomain ruleType",
+			name: "invalid autoscaler scale up",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					Ingress: Ingress{
-						RuleType: IngressRuleTypeSubdomain,
+					MaxReplicas: &three,
+					Autoscaler: &AutoscalerSpec{
+						Behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{
+							ScaleUp: &autoscalingv2.HPAScalingRules{
+								StabilizationWindowSeconds: &zero,
+							},
+						},
 					},
 				},
 			},
-			expectedErr: "a valid Ingress hostname has to be defined for subdomain ruleType",
+			expectedErr:      "scaleUp should be one or more",
+			expectedWarnings: []string{"MaxReplicas is deprecated"},
 		},
-	}
-
+		{
+			name: "invalid autoscaler target cpu utilization",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					MaxReplicas: &three,
+					Autoscaler: &AutoscalerSpec{
+						TargetCPUUtilization: &zero,
+					},
+				},
+			},
+			expectedErr:      "targetCPUUtilization should be greater than 0 and less than 100",
+			expectedWarnings: []string{"MaxReplicas is deprecated"},
+		},
+		{
+			name: "autoscaler minReplicas is less than maxReplicas",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Autoscaler: &AutoscalerSpec{
+						MaxReplicas: &one,
+						MinReplicas: &five,
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec autoscale configuration is incorrect, minReplicas must not be greater than maxReplicas",
+		},
+		{
+			name: "autoscaler in daemonset mode",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDaemonSet,
+					Autoscaler: &AutoscalerSpec{
+						MaxReplicas: &three,
+					},
+				},
+			},
+			expectedErr: "the mode daemonset can't be autoscaled, spec.autoscaler.maxReplicas must not be set",
+		},
+		{
+			name: "deprecated maxReplicas in sidecar mode",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:        ModeSidecar,
+					MaxReplicas: &three,
+				},
+			},
+			expectedErr:      "the mode sidecar can't be autoscaled, spec.maxReplicas must not be set",
+			expectedWarnings: []string{"MaxReplicas is deprecated"},
+		},
+		{
+			name: "replicas less than the autoscaler minReplicas",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Replicas: &one,
+					Autoscaler: &AutoscalerSpec{
+						MinReplicas: &three,
+						MaxReplicas: &five,
+					},
+				},
+			},
+			expectedWarnings: []string{"spec.replicas is 1, less than spec.autoscaler.minReplicas 3, the autoscaler scales the collector up to 3 replicas"},
+		},
+		{
+			name: "autoscaler field paths",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Autoscaler: &AutoscalerSpec{
+						MaxReplicas:             &three,
+						TargetMemoryUtilization: &minusOne,
+					},
+				},
+			},
+			expectedErr: "targetMemoryUtilization should be greater than 0 and less than 100, spec.autoscaler.targetMemoryUtilization is -1",
+		},
+		{
+			name: "invalid autoscaler metric type",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					MaxReplicas: &three,
+					Autoscaler: &AutoscalerSpec{
+						Metrics: []MetricSpec{
+							{
+								Type: autoscalingv2.ResourceMetricSourceType,
+							},
+						},
+					},
+				},
+			},
+			expectedErr:      "the OpenTelemetry Spec autoscale configuration is incorrect, metric type unsupported. Expected metric of source type Pod",
+			expectedWarnings: []string{"MaxReplicas is deprecated"},
+		},
+		{
+			name: "invalid pod metric average value",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					MaxReplicas: &three,
+					Autoscaler: &AutoscalerSpec{
+						Metrics: []MetricSpec{
+							{
+								Type: autoscalingv2.PodsMetricSourceType,
+								Pods: &autoscalingv2.PodsMetricSource{
+									Metric: autoscalingv2.MetricIdentifier{
+										Name: "custom1",
+									},
+									Target: autoscalingv2.MetricTarget{
+										Type:         autoscalingv2.AverageValueMetricType,
+										AverageValue: resource.NewQuantity(int64(0), resource.DecimalSI),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr:      "the OpenTelemetry Spec autoscale configuration is incorrect, average value should be greater than 0",
+			expectedWarnings: []string{"MaxReplicas is deprecated"},
+		},
+		{
+			name: "utilization target is not valid with pod metrics",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					MaxReplicas: &three,
+					Autoscaler: &AutoscalerSpec{
+						Metrics: []MetricSpec{
+							{
+								Type: autoscalingv2.PodsMetricSourceType,
+								Pods: &autoscalingv2.PodsMetricSource{
+									Metric: autoscalingv2.MetricIdentifier{
+										Name: "custom1",
+									},
+									Target: autoscalingv2.MetricTarget{
+										Type:               autoscalingv2.UtilizationMetricType,
+										AverageUtilization: &one,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr:      "the OpenTelemetry Spec autoscale configuration is incorrect, invalid pods target type",
+			expectedWarnings: []string{"MaxReplicas is deprecated"},
+		},
+		{
+			name: "invalid deployment mode incompabible with ingress settings",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeSidecar,
+					Ingress: Ingress{
+						Type: IngressTypeNginx,
+					},
+				},
+			},
+			expectedErr: fmt.Sprintf("Ingress can only be used in combination with the modes: %s, %s, %s",
+				ModeDeployment, ModeDaemonSet, ModeStatefulSet,
+			),
+		},
+		{
+			name: "invalid mode with priorityClassName",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:              ModeSidecar,
+					PriorityClassName: "test-class",
+				},
+			},
+			expectedErr: "does not support the attribute 'priorityClassName'",
+		},
+		{
+			name: "invalid mode with affinity",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeSidecar,
+					Affinity: &v1.Affinity{
+						NodeAffinity: &v1.NodeAffinity{
+							RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+								NodeSelectorTerms: []v1.NodeSelectorTerm{
+									{
+										MatchExpressions: []v1.NodeSelectorRequirement{
+											{
+												Key:      "node",
+												Operator: v1.NodeSelectorOpIn,
+												Values:   []string{"test-node"},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: "does not support the attribute 'affinity'",
+		},
+		{
+			name: "invalid InitialDelaySeconds",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					LivenessProbe: &Probe{
+						InitialDelaySeconds: &minusOne,
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec LivenessProbe InitialDelaySeconds configuration is incorrect",
+		},
+		{
+			name: "invalid PeriodSeconds",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					LivenessProbe: &Probe{
+						PeriodSeconds: &zero,
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec LivenessProbe PeriodSeconds configuration is incorrect",
+		},
+		{
+			name: "invalid TimeoutSeconds",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					LivenessProbe: &Probe{
+						TimeoutSeconds: &zero,
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec LivenessProbe TimeoutSeconds configuration is incorrect",
+		},
+		{
+			name: "invalid SuccessThreshold",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					LivenessProbe: &Probe{
+						SuccessThreshold: &zero,
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec LivenessProbe SuccessThreshold configuration is incorrect",
+		},
+		{
+			name: "invalid FailureThreshold",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					LivenessProbe: &Probe{
+						FailureThreshold: &zero,
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec LivenessProbe FailureThreshold configuration is incorrect",
+		},
+		{
+			name: "invalid TerminationGracePeriodSeconds",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					LivenessProbe: &Probe{
+						TerminationGracePeriodSeconds: &zero64,
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec LivenessProbe TerminationGracePeriodSeconds configuration is incorrect",
+		},
+		{
+			name: "invalid AdditionalContainers",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeSidecar,
+					AdditionalContainers: []v1.Container{
+						{
+							Name: "test",
+						},
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector mode is set to sidecar, which does not support the attribute 'AdditionalContainers'",
+		},
+		{
+			name: "invalid mode with spiffe",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:   ModeSidecar,
+					Spiffe: SpiffeSpec{Enabled: true},
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector mode is set to sidecar, which does not support the attribute 'spiffe'",
+		},
+		{
+			name: "missing ingress hostname for subdomain ruleType",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Ingress: Ingress{
+						RuleType: IngressRuleTypeSubdomain,
+					},
+				},
+			},
+			expectedErr: "a valid Ingress hostname has to be defined for subdomain ruleType",
+		},
+		{
+			name: "debug endpoints without admin namespace",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					DebugEndpoints: &DebugEndpointsSpec{Enabled: true},
+				},
+			},
+			expectedErr: "adminNamespace must be set",
+		},
+		{
+			name: "debug endpoints with an invalid admin namespace",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					DebugEndpoints: &DebugEndpointsSpec{Enabled: true, AdminNamespace: "Admin_NS"},
+				},
+			},
+			expectedErr: "adminNamespace 'Admin_NS' is not a valid namespace name",
+		},
+		{
+			name: "debug endpoints in sidecar mode",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:           ModeSidecar,
+					DebugEndpoints: &DebugEndpointsSpec{Enabled: true, AdminNamespace: "admin"},
+				},
+			},
+			expectedErr: "the debugging endpoints can't be exposed in the sidecar mode",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cvw := &CollectorWebhook{
+				logger: logr.Discard(),
+				scheme: testScheme,
+				cfg: config.New(
+					config.WithCollectorImage("collector:v0.0.0"),
+					config.WithTargetAllocatorImage("ta:v0.0.0"),
+				),
+			}
+			ctx := context.Background()
+			warnings, err := cvw.ValidateCreate(ctx, &test.otelcol)
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+				for _, w := range test.expectedWarnings {
+					assert.Contains(t, warnings, w)
+				}
+				return
+			}
+			if len(test.expectedWarnings) == 0 {
+				assert.Empty(t, warnings, test.expectedWarnings)
+			} else {
+				assert.ElementsMatch(t, warnings, test.expectedWarnings)
+			}
+			assert.ErrorContains(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestOTELColValidatingWebhookAutopilot(t *testing.T) {
+	privileged := true
+	tests := []struct { //nolint:govet
+		name        string
+		otelcol     OpenTelemetryCollector
+		expectedErr string
+	}{
+		{
+			name: "compatible collector",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDaemonSet,
+					SecurityContext: &v1.SecurityContext{
+						Capabilities: &v1.Capabilities{Add: []v1.Capability{"NET_BIND_SERVICE"}},
+					},
+				},
+			},
+		},
+		{
+			name: "host network",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					HostNetwork: true,
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector must not use the host network on GKE Autopilot",
+		},
+		{
+			name: "hostPath volume",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDaemonSet,
+					Volumes: []v1.Volume{{
+						Name:         "varlog",
+						VolumeSource: v1.VolumeSource{HostPath: &v1.HostPathVolumeSource{Path: "/var/log"}},
+					}},
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector must not use the hostPath volume varlog on GKE Autopilot",
+		},
+		{
+			name: "privileged additional container",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					AdditionalContainers: []v1.Container{{
+						Name:            "helper",
+						SecurityContext: &v1.SecurityContext{Privileged: &privileged},
+					}},
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector must not run privileged containers on GKE Autopilot",
+		},
+		{
+			name: "forbidden capability",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					SecurityContext: &v1.SecurityContext{
+						Capabilities: &v1.Capabilities{Add: []v1.Capability{"SYS_ADMIN"}},
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector must not add the capability SYS_ADMIN on GKE Autopilot",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cvw := &CollectorWebhook{
+				logger: logr.Discard(),
+				scheme: testScheme,
+				cfg: config.New(
+					config.WithCollectorImage("collector:v0.0.0"),
+					config.WithAutopilot(true),
+				),
+			}
+			// the collectors created before the mode was enabled can still be deleted
+			_, err := cvw.ValidateDelete(context.Background(), &test.otelcol)
+			assert.NoError(t, err)
+
+			_, err = cvw.ValidateCreate(context.Background(), &test.otelcol)
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestOTELColValidatingWebhookNamespaceScoped(t *testing.T) {
+	tests := []struct { //nolint:govet
+		name        string
+		otelcol     OpenTelemetryCollector
+		expectedErr string
+	}{
+		{
+			name: "compatible collector",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDaemonSet,
+				},
+			},
+		},
+		{
+			name: "target allocator with prometheusCR",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeStatefulSet,
+					Config: `receivers:
+  prometheus:
+    config:
+      scrape_configs:
+        - job_name: otel-collector
+          scrape_interval: 10s
+`,
+					TargetAllocator: OpenTelemetryTargetAllocator{
+						Enabled:      true,
+						PrometheusCR: OpenTelemetryTargetAllocatorPrometheusCR{Enabled: true},
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector must not enable the targetAllocator prometheusCR with a namespace-scoped operator",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cvw := &CollectorWebhook{
+				logger: logr.Discard(),
+				scheme: testScheme,
+				cfg: config.New(
+					config.WithCollectorImage("collector:v0.0.0"),
+					config.WithNamespaceScoped(true),
+				),
+			}
+			// the collectors created before the operator was namespace-scoped can still be deleted
+			_, err := cvw.ValidateDelete(context.Background(), &test.otelcol)
+			assert.NoError(t, err)
+
+			_, err = cvw.ValidateCreate(context.Background(), &test.otelcol)
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestOTELColValidatingWebhookDebugEndpoints(t *testing.T) {
+	tests := []struct {
+		name             string
+		config           string
+		expectedWarnings admission.Warnings
+	}{
+		{
+			name: "extensions listening on all the interfaces",
+			config: `extensions:
+  pprof:
+    endpoint: 0.0.0.0:1777
+  remote_tap:
+    endpoint: :11000
+service:
+  extensions: [pprof, remote_tap]
+`,
+		},
+		{
+			name: "extensions listening on the loopback interface",
+			config: `extensions:
+  pprof:
+  remote_tap:
+    endpoint: 127.0.0.1:11000
+service:
+  extensions: [pprof, remote_tap]
+`,
+			expectedWarnings: admission.Warnings{
+				"the extension 'pprof' listens on localhost, it can't be reached through the debugging endpoints Service",
+				"the extension 'remote_tap' listens on 127.0.0.1, it can't be reached through the debugging endpoints Service",
+			},
+		},
+		{
+			name: "no debugging extension enabled",
+			config: `extensions:
+  pprof:
+service:
+  extensions: []
+`,
+			expectedWarnings: admission.Warnings{
+				"the debugging endpoints are enabled, but the configuration doesn't enable any pprof or remote_tap extension",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// prepare
+			otelcol := &OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Config:         test.config,
+					DebugEndpoints: &DebugEndpointsSpec{Enabled: true, AdminNamespace: "admin"},
+				},
+			}
+
+			// test
+			warnings, err := checkDebugEndpoints(logr.Discard(), otelcol)
+
+			// verify
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedWarnings, warnings)
+		})
+	}
+}
+
+func TestOTELColValidatingWebhookPortConflicts(t *testing.T) {
+	tests := []struct {
+		name             string
+		ports            []PortsSpec
+		expectedWarnings admission.Warnings
+	}{
+		{
+			name:  "override of an inferred port",
+			ports: []PortsSpec{{ServicePort: v1.ServicePort{Name: "otlp-grpc", Port: 4317, NodePort: 30317}}},
+		},
+		{
+			name:  "port number conflicts with a receiver",
+			ports: []PortsSpec{{ServicePort: v1.ServicePort{Name: "custom", Port: 4317}}},
+			expectedWarnings: admission.Warnings{
+				"the port 4317/TCP of 'custom' is also used by the port 'otlp-grpc' of the configuration, which is left out of the Service",
+			},
+		},
+		{
+			name:  "port name conflicts with a receiver",
+			ports: []PortsSpec{{ServicePort: v1.ServicePort{Name: "otlp-grpc", Port: 14317}}},
+			expectedWarnings: admission.Warnings{
+				"the port name 'otlp-grpc' of the port 14317 is also used by the port 4317 of the configuration, which is renamed to 'port-4317' in the Service",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// prepare
+			otelcol := &OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+exporters:
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [debug]
+`,
+					Ports: test.ports,
+				},
+			}
+
+			// test
+			warnings, err := checkPortConflicts(logr.Discard(), otelcol)
+
+			// verify
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedWarnings, warnings)
+		})
+	}
+}
+
+func TestOTELColValidatingWebhookImageRegistries(t *testing.T) {
+	tests := []struct { //nolint:govet
+		name        string
+		otelcol     OpenTelemetryCollector
+		expectedErr string
+	}{
+		{
+			name: "default images",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Image: "collector:v0.0.0",
+				},
+			},
+		},
+		{
+			name: "images from the allowed registries",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Image:                "registry.example.com/collector:0.89.0",
+					InitContainers:       []v1.Container{{Name: "init", Image: "docker.io/library/busybox:1"}},
+					AdditionalContainers: []v1.Container{{Name: "proxy", Image: "busybox:1"}},
+				},
+			},
+		},
+		{
+			name: "collector image from another registry",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Image: "ghcr.io/open-telemetry/collector:0.89.0",
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector collector container uses the image ghcr.io/open-telemetry/collector:0.89.0, which isn't pulled from one of the allowed registries: registry.example.com, docker.io/library",
+		},
+		{
+			name: "additional container image from Docker Hub",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					AdditionalContainers: []v1.Container{{Name: "proxy", Image: "envoyproxy/envoy:v1.28"}},
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector container proxy uses the image envoyproxy/envoy:v1.28",
+		},
+		{
+			name: "target allocator image from another registry",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeStatefulSet,
+					Config: `receivers:
+  prometheus:
+    config:
+      scrape_configs:
+        - job_name: otel-collector
+          scrape_interval: 10s
+`,
+					TargetAllocator: OpenTelemetryTargetAllocator{
+						Enabled: true,
+						Image:   "quay.io/target-allocator:0.89.0",
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector target allocator container uses the image quay.io/target-allocator:0.89.0",
+		},
+	}
+
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			cvw := &CollectorWebhook{
@@ -776,18 +1924,357 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 				cfg: config.New(
 					config.WithCollectorImage("collector:v0.0.0"),
 					config.WithTargetAllocatorImage("ta:v0.0.0"),
+					config.WithAllowedImageRegistries([]string{"registry.example.com", "docker.io/library"}),
 				),
 			}
-			ctx := context.Background()
-			warnings, err := cvw.ValidateCreate(ctx, &test.otelcol)
+			_, err := cvw.ValidateCreate(context.Background(), &test.otelcol)
 			if test.expectedErr == "" {
 				assert.NoError(t, err)
 				return
 			}
-			if len(test.expectedWarnings) == 0 {
-				assert.Empty(t, warnings, test.expectedWarnings)
-			} else {
-				assert.ElementsMatch(t, warnings, test.expectedWarnings)
+			assert.ErrorContains(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestOTELColValidatingWebhookResourceQuotas(t *testing.T) {
+	limitsQuota := &v1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: "my-ns"},
+		Spec: v1.ResourceQuotaSpec{Hard: v1.ResourceList{
+			v1.ResourceLimitsCPU:      resource.MustParse("2"),
+			v1.ResourceRequestsMemory: resource.MustParse("1Gi"),
+		}},
+	}
+	limits := v1.ResourceRequirements{Limits: v1.ResourceList{
+		v1.ResourceCPU:    resource.MustParse("500m"),
+		v1.ResourceMemory: resource.MustParse("256Mi"),
+	}}
+	collector := func(resources v1.ResourceRequirements) OpenTelemetryCollector {
+		return OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-collector", Namespace: "my-ns"},
+			Spec:       OpenTelemetryCollectorSpec{Mode: ModeDeployment, Resources: resources},
+		}
+	}
+
+	tests := []struct { //nolint:govet
+		name        string
+		otelcol     OpenTelemetryCollector
+		objects     []client.Object
+		disabled    bool
+		expectedErr string
+	}{
+		{
+			name:    "compatible collector",
+			otelcol: collector(limits),
+			objects: []client.Object{limitsQuota},
+		},
+		{
+			name:     "validation disabled",
+			otelcol:  collector(v1.ResourceRequirements{}),
+			objects:  []client.Object{limitsQuota},
+			disabled: true,
+		},
+		{
+			name:        "missing limit",
+			otelcol:     collector(v1.ResourceRequirements{}),
+			objects:     []client.Object{limitsQuota},
+			expectedErr: "the collector container of the OpenTelemetry Collector must set a cpu limit, required by the limits.cpu quota of the ResourceQuota compute",
+		},
+		{
+			name:    "limits defaulted by the LimitRange",
+			otelcol: collector(v1.ResourceRequirements{}),
+			objects: []client.Object{limitsQuota, &v1.LimitRange{
+				ObjectMeta: metav1.ObjectMeta{Name: "defaults", Namespace: "my-ns"},
+				Spec: v1.LimitRangeSpec{Limits: []v1.LimitRangeItem{{
+					Type:    v1.LimitTypeContainer,
+					Default: limits.Limits,
+				}}},
+			}},
+		},
+		{
+			name:    "pod exceeding the quota",
+			otelcol: collector(limits),
+			objects: []client.Object{&v1.ResourceQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: "my-ns"},
+				Spec:       v1.ResourceQuotaSpec{Hard: v1.ResourceList{v1.ResourceMemory: resource.MustParse("128Mi")}},
+			}},
+			expectedErr: "the memory requests 256Mi of a pod of the OpenTelemetry Collector exceed the memory quota 128Mi of the ResourceQuota compute",
+		},
+		{
+			name:    "limit above the maximum",
+			otelcol: collector(limits),
+			objects: []client.Object{&v1.LimitRange{
+				ObjectMeta: metav1.ObjectMeta{Name: "bounds", Namespace: "my-ns"},
+				Spec: v1.LimitRangeSpec{Limits: []v1.LimitRangeItem{{
+					Type: v1.LimitTypeContainer,
+					Max:  v1.ResourceList{v1.ResourceMemory: resource.MustParse("128Mi")},
+				}}},
+			}},
+			expectedErr: "the memory limit 256Mi of the collector container of the OpenTelemetry Collector exceeds the maximum 128Mi of the LimitRange bounds",
+		},
+		{
+			name: "request below the minimum",
+			otelcol: collector(v1.ResourceRequirements{Requests: v1.ResourceList{
+				v1.ResourceCPU: resource.MustParse("10m"),
+			}}),
+			objects: []client.Object{&v1.LimitRange{
+				ObjectMeta: metav1.ObjectMeta{Name: "bounds", Namespace: "my-ns"},
+				Spec: v1.LimitRangeSpec{Limits: []v1.LimitRangeItem{{
+					Type: v1.LimitTypeContainer,
+					Min:  v1.ResourceList{v1.ResourceCPU: resource.MustParse("50m")},
+				}}},
+			}},
+			expectedErr: "the cpu request 10m of the collector container of the OpenTelemetry Collector is below the minimum 50m of the LimitRange bounds",
+		},
+		{
+			name: "limit to request ratio",
+			otelcol: collector(v1.ResourceRequirements{
+				Limits:   v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")},
+				Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("100m")},
+			}),
+			objects: []client.Object{&v1.LimitRange{
+				ObjectMeta: metav1.ObjectMeta{Name: "bounds", Namespace: "my-ns"},
+				Spec: v1.LimitRangeSpec{Limits: []v1.LimitRangeItem{{
+					Type:                 v1.LimitTypeContainer,
+					MaxLimitRequestRatio: v1.ResourceList{v1.ResourceCPU: resource.MustParse("4")},
+				}}},
+			}},
+			expectedErr: "the cpu limit 1 of the collector container of the OpenTelemetry Collector exceeds 4 times its request 100m, the maximum ratio of the LimitRange bounds",
+		},
+		{
+			name: "target allocator without limits",
+			otelcol: OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-collector", Namespace: "my-ns"},
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:      ModeStatefulSet,
+					Resources: limits,
+					Config: `receivers:
+  prometheus:
+    config:
+      scrape_configs:
+      - job_name: otel-collector
+        static_configs:
+        - targets: ["0.0.0.0:8888"]
+exporters:
+  debug:
+service:
+  pipelines:
+    metrics:
+      receivers: [prometheus]
+      exporters: [debug]`,
+					TargetAllocator: OpenTelemetryTargetAllocator{Enabled: true},
+				},
+			},
+			objects:     []client.Object{limitsQuota},
+			expectedErr: "the target allocator container of the OpenTelemetry Collector must set a cpu limit",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cvw := &CollectorWebhook{
+				logger: logr.Discard(),
+				scheme: testScheme,
+				cfg: config.New(
+					config.WithCollectorImage("collector:v0.0.0"),
+					config.WithResourceQuotaValidation(!test.disabled),
+				),
+				reader: fake.NewClientBuilder().WithObjects(test.objects...).Build(),
+			}
+			_, err := cvw.ValidateCreate(context.Background(), &test.otelcol)
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestOTELColValidatingWebhookNames(t *testing.T) {
+	existing := &OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: "my-ns"},
+		Spec:       OpenTelemetryCollectorSpec{NameOverride: "otel"},
+	}
+	collector := func(namespace, name string, spec OpenTelemetryCollectorSpec) *OpenTelemetryCollector {
+		spec.Mode = ModeDeployment
+		return &OpenTelemetryCollector{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}, Spec: spec}
+	}
+	tests := []struct {
+		name        string
+		otelcol     *OpenTelemetryCollector
+		expectedErr string
+	}{
+		{
+			name:    "distinct names",
+			otelcol: collector("my-ns", "agent", OpenTelemetryCollectorSpec{}),
+		},
+		{
+			name:    "same name in another namespace",
+			otelcol: collector("other-ns", "otel", OpenTelemetryCollectorSpec{}),
+		},
+		{
+			name:    "update of the existing collector",
+			otelcol: collector("my-ns", "gateway", OpenTelemetryCollectorSpec{NameOverride: "otel"}),
+		},
+		{
+			name:        "name of the override of another collector",
+			otelcol:     collector("my-ns", "otel", OpenTelemetryCollectorSpec{}),
+			expectedErr: "would be named 'otel-collector', like the ones of the OpenTelemetry Collector 'gateway'",
+		},
+		{
+			name:        "full name colliding with another collector",
+			otelcol:     collector("my-ns", "agent", OpenTelemetryCollectorSpec{FullnameOverride: "otel-collector"}),
+			expectedErr: "like the ones of the OpenTelemetry Collector 'gateway'",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cvw := &CollectorWebhook{
+				logger: logr.Discard(),
+				scheme: testScheme,
+				cfg:    config.New(config.WithCollectorImage("collector:v0.0.0")),
+				reader: fake.NewClientBuilder().WithObjects(existing).Build(),
+			}
+			_, err := cvw.ValidateCreate(context.Background(), test.otelcol)
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestOTELColValidateDelete(t *testing.T) {
+	injected := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app",
+			Namespace: "my-ns",
+			Labels:    map[string]string{"sidecar.opentelemetry.io/injected": "my-ns.sidecar"},
+		},
+	}
+	// injected with the namespace/name form of the annotation
+	injectedFromOtherNamespace := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app",
+			Namespace: "other-ns",
+			Labels:    map[string]string{"sidecar.opentelemetry.io/injected": "my-ns.sidecar"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		otelcol  OpenTelemetryCollector
+		warnings admission.Warnings
+	}{
+		{
+			name: "sidecar with injected pods",
+			otelcol: OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{Name: "sidecar", Namespace: "my-ns"},
+				Spec:       OpenTelemetryCollectorSpec{Mode: ModeSidecar},
+			},
+			warnings: admission.Warnings{"2 pod(s) still run a sidecar injected from OpenTelemetryCollector sidecar, they have to be restarted to remove it"},
+		},
+		{
+			name: "sidecar without injected pods",
+			otelcol: OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "my-ns"},
+				Spec:       OpenTelemetryCollectorSpec{Mode: ModeSidecar},
+			},
+			warnings: admission.Warnings{},
+		},
+		{
+			name: "deployment",
+			otelcol: OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{Name: "sidecar", Namespace: "my-ns"},
+				Spec:       OpenTelemetryCollectorSpec{Mode: ModeDeployment},
+			},
+			warnings: admission.Warnings{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cvw := &CollectorWebhook{
+				logger: logr.Discard(),
+				scheme: testScheme,
+				cfg:    config.New(),
+				pods:   fake.NewClientBuilder().WithObjects(injected, injectedFromOtherNamespace).Build(),
+			}
+			warnings, err := cvw.ValidateDelete(context.Background(), &test.otelcol)
+			assert.NoError(t, err)
+			assert.Equal(t, test.warnings, warnings)
+		})
+	}
+}
+
+func TestOTELColValidateUpdateNoLongerValid(t *testing.T) {
+	cvw := &CollectorWebhook{
+		logger: logr.Discard(),
+		scheme: testScheme,
+		cfg: config.New(
+			config.WithCollectorImage("collector:v0.0.0"),
+			config.WithAutopilot(true),
+		),
+	}
+	now := metav1.Now()
+	// accepted before GKE Autopilot was enabled
+	otelcol := OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "my-collector",
+			Namespace:  "my-ns",
+			Finalizers: []string{"operator.opentelemetry.io/cluster-resources"},
+		},
+		Spec: OpenTelemetryCollectorSpec{
+			Mode:        ModeDaemonSet,
+			HostNetwork: true,
+		},
+	}
+	require.NoError(t, cvw.Default(context.Background(), &otelcol))
+	deleting := otelcol.DeepCopy()
+	deleting.DeletionTimestamp = &now
+
+	tests := []struct {
+		name        string
+		oldObj      *OpenTelemetryCollector
+		update      func(*OpenTelemetryCollector)
+		expectedErr string
+	}{
+		{
+			name:   "finalizer removed",
+			oldObj: deleting,
+			update: func(r *OpenTelemetryCollector) {
+				r.Finalizers = nil
+			},
+		},
+		{
+			name:   "metadata changed",
+			oldObj: &otelcol,
+			update: func(r *OpenTelemetryCollector) {
+				r.Labels = map[string]string{"team": "observability"}
+			},
+		},
+		{
+			name:   "spec changed",
+			oldObj: &otelcol,
+			update: func(r *OpenTelemetryCollector) {
+				r.Spec.PriorityClassName = "high"
+			},
+			expectedErr: "the OpenTelemetry Collector must not use the host network on GKE Autopilot",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			newObj := test.oldObj.DeepCopy()
+			test.update(newObj)
+			require.NoError(t, cvw.Default(context.Background(), newObj))
+			_, err := cvw.ValidateUpdate(context.Background(), test.oldObj, newObj)
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+				return
 			}
 			assert.ErrorContains(t, err, test.expectedErr)
 		})
@@ -0,0 +1,29 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+const (
+	// ConditionTypeReady indicates that the resource has been reconciled and its workloads are available.
+	ConditionTypeReady = "Ready"
+
+	// ConditionTypeProgressing indicates that the workloads of the resource are being rolled out.
+	ConditionTypeProgressing = "Progressing"
+
+	// ConditionTypeDegraded indicates that the last reconciliation of the resource failed.
+	ConditionTypeDegraded = "Degraded"
+
+	// ConditionTypeUpgraded indicates whether the resource has been migrated to the version managed by the operator.
+	ConditionTypeUpgraded = "Upgraded"
+)
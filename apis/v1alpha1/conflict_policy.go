@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+type (
+	// ConflictPolicy represents how the instrumentation is injected into containers already configured with another agent
+	// +kubebuilder:validation:Enum=skip;warn;merge
+	ConflictPolicy string
+)
+
+const (
+	// ConflictPolicySkip specifies that containers already configured with another agent are not instrumented.
+	ConflictPolicySkip ConflictPolicy = "skip"
+
+	// ConflictPolicyWarn specifies that the instrumentation is injected and a warning event is recorded on the
+	// Instrumentation.
+	ConflictPolicyWarn ConflictPolicy = "warn"
+
+	// ConflictPolicyMerge specifies that the existing agent is kept and configured with the settings of the
+	// instrumentation: only the environment variables missing from the container are added, no other agent is attached.
+	ConflictPolicyMerge ConflictPolicy = "merge"
+)
@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+type (
+	// NodeLocalEndpoint is how the OTLP receivers of a collector in daemonset mode are published to the pods of its
+	// node.
+	// +kubebuilder:validation:Enum=service;hostPort
+	NodeLocalEndpoint string
+)
+
+const (
+	// NodeLocalEndpointService publishes the receivers on the Service of the collector, which routes the telemetry
+	// to the collector of the node of the client, its internal traffic policy being Local.
+	NodeLocalEndpointService NodeLocalEndpoint = "service"
+
+	// NodeLocalEndpointHostPort publishes the OTLP receivers on the same ports of the IP of the node, which the pods
+	// resolve from their status.hostIP.
+	NodeLocalEndpointHostPort NodeLocalEndpoint = "hostPort"
+)
+
+// DaemonSetSpec defines how the pods of a node reach the collector of the node, in daemonset mode.
+type DaemonSetSpec struct {
+	// NodeLocalEndpoint publishes the OTLP receivers of the collector to the pods of its node, through its
+	// node-local Service with service, or on the IP of the node with hostPort. The instrumentations discovering
+	// their endpoint send their telemetry there. Defaults to service.
+	// +optional
+	NodeLocalEndpoint NodeLocalEndpoint `json:"nodeLocalEndpoint,omitempty"`
+}
@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+	"errors"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/imagedigest"
+)
+
+// pinImages pins the given images to the digests their tags point to, when the operator pins the images. The images
+// whose digest can't be resolved are reported together, so that they can all be fixed at once.
+func pinImages(ctx context.Context, resolver *imagedigest.Resolver, images ...*string) error {
+	if resolver == nil {
+		return nil
+	}
+	var errs []error
+	for _, image := range images {
+		pinned, err := resolver.Pin(ctx, *image)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		*image = pinned
+	}
+	return errors.Join(errs...)
+}
@@ -40,6 +40,12 @@ type InstrumentationSpec struct {
 	// +optional
 	Sampler `json:"sampler,omitempty"`
 
+	// ConflictPolicy defines how the instrumentation is injected into containers already configured with
+	// another agent, e.g. a javaagent in JAVA_TOOL_OPTIONS, a required module in NODE_OPTIONS or another CORECLR_PROFILER.
+	// When unset, the instrumentation is injected alongside the existing agent without emitting an event.
+	// +optional
+	ConflictPolicy ConflictPolicy `json:"conflictPolicy,omitempty"`
+
 	// Env defines common env vars. There are four layers for env vars' definitions and
 	// the precedence order is: `original container env vars` > `language specific env vars` > `common env vars` > `instrument spec configs' vars`.
 	// If the former var had been defined, then the other vars would be ignored.
@@ -89,6 +95,12 @@ type Resource struct {
 	// AddK8sUIDAttributes defines whether K8s UID attributes should be collected (e.g. k8s.deployment.uid).
 	// +optional
 	AddK8sUIDAttributes bool `json:"addK8sUIDAttributes,omitempty"`
+
+	// AddK8sDownwardAPIAttributes defines whether the pod name, pod UID, node name and namespace name
+	// attributes should always be resolved at runtime via the Kubernetes downward API,
+	// instead of using the values known when the pod is admitted.
+	// +optional
+	AddK8sDownwardAPIAttributes bool `json:"addK8sDownwardAPIAttributes,omitempty"`
 }
 
 // Exporter defines OTLP exporter configuration.
@@ -96,6 +108,11 @@ type Exporter struct {
 	// Endpoint is address of the collector with OTLP endpoint.
 	// +optional
 	Endpoint string `json:"endpoint,omitempty"`
+	// AutoDiscovery makes the operator resolve the endpoint of the nearest collector it manages when the
+	// endpoint is not set: the sidecar of the pod, then a DaemonSet collector on the node of the pod, then
+	// a Deployment or StatefulSet collector in the namespace of the pod.
+	// +optional
+	AutoDiscovery bool `json:"autoDiscovery,omitempty"`
 }
 
 // Sampler defines sampling configuration.
@@ -248,6 +265,12 @@ type ApacheHttpd struct {
 	// +optional
 	ConfigPath string `json:"configPath,omitempty"`
 
+	// SkipConfigClone disables the init container cloning the application container to copy
+	// the Apache HTTPD configuration. Only the OpenTelemetry module and its configuration file are injected.
+	// Use it for images whose configuration already includes "/opt/opentelemetry-webserver/agent/opentemetry_agent.conf".
+	// +optional
+	SkipConfigClone bool `json:"skipConfigClone,omitempty"`
+
 	// Resources describes the compute resource requirements.
 	// +optional
 	Resources corev1.ResourceRequirements `json:"resourceRequirements,omitempty"`
@@ -287,6 +310,40 @@ type Nginx struct {
 
 // InstrumentationStatus defines status of the instrumentation.
 type InstrumentationStatus struct {
+	// Images lists the auto-instrumentation images resolved for each language, the image tag identifies
+	// the version of the agent injected into the pods.
+	// +optional
+	Images AutoInstrumentationImages `json:"images,omitempty"`
+
+	// Conditions represent the latest available observations of the resource state, e.g. Ready, Progressing and Degraded.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// AutoInstrumentationImages defines the auto-instrumentation image per language.
+type AutoInstrumentationImages struct {
+	// +optional
+	Java string `json:"java,omitempty"`
+
+	// +optional
+	NodeJS string `json:"nodejs,omitempty"`
+
+	// +optional
+	Python string `json:"python,omitempty"`
+
+	// +optional
+	DotNet string `json:"dotnet,omitempty"`
+
+	// +optional
+	Go string `json:"go,omitempty"`
+
+	// +optional
+	ApacheHttpd string `json:"apacheHttpd,omitempty"`
+
+	// +optional
+	Nginx string `json:"nginx,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -296,6 +353,7 @@ type InstrumentationStatus struct {
 // +kubebuilder:printcolumn:name="Endpoint",type="string",JSONPath=".spec.exporter.endpoint"
 // +kubebuilder:printcolumn:name="Sampler",type="string",JSONPath=".spec.sampler.type"
 // +kubebuilder:printcolumn:name="Sampler Arg",type="string",JSONPath=".spec.sampler.argument"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].reason"
 // +operator-sdk:csv:customresourcedefinitions:displayName="OpenTelemetry Instrumentation"
 // +operator-sdk:csv:customresourcedefinitions:resources={{Pod,v1}}
 
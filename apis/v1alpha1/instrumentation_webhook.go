@@ -25,6 +25,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
@@ -34,6 +35,9 @@ import (
 const (
 	envPrefix       = "OTEL_"
 	envSplunkPrefix = "SPLUNK_"
+
+	// injectAnnotationPrefix is shared by all the instrumentation.opentelemetry.io/inject-* pod annotations.
+	injectAnnotationPrefix = "instrumentation.opentelemetry.io/inject-"
 )
 
 var (
@@ -58,6 +62,7 @@ type InstrumentationWebhook struct {
 	logger logr.Logger
 	cfg    config.Config
 	scheme *runtime.Scheme
+	reader client.Reader
 }
 
 func (w InstrumentationWebhook) Default(ctx context.Context, obj runtime.Object) error {
@@ -65,7 +70,18 @@ func (w InstrumentationWebhook) Default(ctx context.Context, obj runtime.Object)
 	if !ok {
 		return fmt.Errorf("expected an Instrumentation, received %T", obj)
 	}
-	return w.defaulter(instrumentation)
+	if err := w.defaulter(instrumentation); err != nil {
+		return err
+	}
+	return pinImages(ctx, w.cfg.ImageDigestResolver(),
+		&instrumentation.Spec.Java.Image,
+		&instrumentation.Spec.NodeJS.Image,
+		&instrumentation.Spec.Python.Image,
+		&instrumentation.Spec.DotNet.Image,
+		&instrumentation.Spec.Go.Image,
+		&instrumentation.Spec.ApacheHttpd.Image,
+		&instrumentation.Spec.Nginx.Image,
+	)
 }
 
 func (w InstrumentationWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
@@ -73,7 +89,14 @@ func (w InstrumentationWebhook) ValidateCreate(ctx context.Context, obj runtime.
 	if !ok {
 		return nil, fmt.Errorf("expected an Instrumentation, received %T", obj)
 	}
-	return w.validate(inst)
+	warnings, err := w.validate(inst)
+	if err != nil {
+		return warnings, err
+	}
+	if err := w.validateApacheHttpdVersion(nil, inst); err != nil {
+		return warnings, err
+	}
+	return warnings, w.validateImageRegistries(inst)
 }
 
 func (w InstrumentationWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
@@ -81,7 +104,15 @@ func (w InstrumentationWebhook) ValidateUpdate(ctx context.Context, oldObj, newO
 	if !ok {
 		return nil, fmt.Errorf("expected an Instrumentation, received %T", newObj)
 	}
-	return w.validate(inst)
+	warnings, err := w.validate(inst)
+	if err != nil {
+		return warnings, err
+	}
+	old, _ := oldObj.(*Instrumentation)
+	if err := w.validateApacheHttpdVersion(old, inst); err != nil {
+		return warnings, err
+	}
+	return warnings, w.validateImageRegistries(inst)
 }
 
 func (w InstrumentationWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
@@ -89,7 +120,11 @@ func (w InstrumentationWebhook) ValidateDelete(ctx context.Context, obj runtime.
 	if !ok || inst == nil {
 		return nil, fmt.Errorf("expected an Instrumentation, received %T", obj)
 	}
-	return w.validate(inst)
+	warnings, err := w.validate(inst)
+	if err != nil {
+		return warnings, err
+	}
+	return append(warnings, w.validateReferences(ctx, inst)...), nil
 }
 
 func (w InstrumentationWebhook) defaulter(r *Instrumentation) error {
@@ -218,6 +253,9 @@ func (w InstrumentationWebhook) defaulter(r *Instrumentation) error {
 
 func (w InstrumentationWebhook) validate(r *Instrumentation) (admission.Warnings, error) {
 	var warnings []string
+	if r.Spec.Exporter.AutoDiscovery && r.Spec.Exporter.Endpoint != "" {
+		warnings = append(warnings, "spec.exporter.autoDiscovery has no effect as spec.exporter.endpoint is set")
+	}
 	switch r.Spec.Sampler.Type {
 	case "":
 		warnings = append(warnings, "sampler type not set")
@@ -274,6 +312,43 @@ func (w InstrumentationWebhook) validate(r *Instrumentation) (admission.Warnings
 	return warnings, nil
 }
 
+// validateApacheHttpdVersion rejects the versions of Apache HTTPD which can't be instrumented. The instrumentations
+// updated without changing their version are accepted, since they were accepted before the version was validated.
+func (w InstrumentationWebhook) validateApacheHttpdVersion(old, r *Instrumentation) error {
+	if old != nil && old.Spec.ApacheHttpd.Version == r.Spec.ApacheHttpd.Version {
+		return nil
+	}
+	switch r.Spec.ApacheHttpd.Version {
+	case "", "2.2", "2.4":
+		return nil
+	default:
+		return fmt.Errorf("spec.apacheHttpd.version is not valid: %s, supported versions are 2.2 and 2.4", r.Spec.ApacheHttpd.Version)
+	}
+}
+
+// validateImageRegistries rejects the instrumentations whose images aren't pulled from the allowed registries, when
+// set. The images defaulted by the webhook are the default images of the operator, which are allowed.
+func (w InstrumentationWebhook) validateImageRegistries(r *Instrumentation) error {
+	defaults := map[string]bool{
+		w.cfg.AutoInstrumentationJavaImage():        true,
+		w.cfg.AutoInstrumentationNodeJSImage():      true,
+		w.cfg.AutoInstrumentationPythonImage():      true,
+		w.cfg.AutoInstrumentationDotNetImage():      true,
+		w.cfg.AutoInstrumentationGoImage():          true,
+		w.cfg.AutoInstrumentationApacheHttpdImage(): true,
+		w.cfg.AutoInstrumentationNginxImage():       true,
+	}
+	return validateImageRegistries("Instrumentation", w.cfg.AllowedImageRegistries(), defaults,
+		containerImage{name: "spec.java", image: r.Spec.Java.Image},
+		containerImage{name: "spec.nodejs", image: r.Spec.NodeJS.Image},
+		containerImage{name: "spec.python", image: r.Spec.Python.Image},
+		containerImage{name: "spec.dotnet", image: r.Spec.DotNet.Image},
+		containerImage{name: "spec.go", image: r.Spec.Go.Image},
+		containerImage{name: "spec.apacheHttpd", image: r.Spec.ApacheHttpd.Image},
+		containerImage{name: "spec.nginx", image: r.Spec.Nginx.Image},
+	)
+}
+
 func (w InstrumentationWebhook) validateEnv(envs []corev1.EnvVar) error {
 	for _, env := range envs {
 		if !strings.HasPrefix(env.Name, envPrefix) && !strings.HasPrefix(env.Name, envSplunkPrefix) {
@@ -314,6 +389,50 @@ func validateJaegerRemoteSamplerArgument(argument string) error {
 	return nil
 }
 
+// validateReferences warns about pods in the instrumentation namespace whose inject annotations still point to it.
+// Those pods keep the injected configuration until they are restarted, so deleting the instrumentation leaves them orphaned.
+func (w InstrumentationWebhook) validateReferences(ctx context.Context, r *Instrumentation) admission.Warnings {
+	if w.reader == nil {
+		return nil
+	}
+	pods := &corev1.PodList{}
+	if err := w.reader.List(ctx, pods, client.InNamespace(r.Namespace)); err != nil {
+		w.logger.Error(err, "failed to list pods referencing the Instrumentation", "name", r.Name, "namespace", r.Namespace)
+		return nil
+	}
+	// "true" selects the instrumentation of the namespace of the pod, only when it's the sole one
+	instances := &InstrumentationList{}
+	if err := w.reader.List(ctx, instances, client.InNamespace(r.Namespace)); err != nil {
+		w.logger.Error(err, "failed to list the Instrumentations of the namespace", "name", r.Name, "namespace", r.Namespace)
+		return nil
+	}
+	sole := len(instances.Items) == 1 && instances.Items[0].Name == r.Name
+	var referencing int
+	for _, pod := range pods.Items {
+		if referencesInstrumentation(pod, r, sole) {
+			referencing++
+		}
+	}
+	if referencing == 0 {
+		return nil
+	}
+	return admission.Warnings{fmt.Sprintf("%d pod(s) are still annotated to use Instrumentation %s, they have to be restarted to remove the injected configuration", referencing, r.Name)}
+}
+
+// referencesInstrumentation returns whether any of the pod inject annotations resolves to the given instrumentation,
+// "true" resolving to it when it's the sole instrumentation of the namespace.
+func referencesInstrumentation(pod corev1.Pod, r *Instrumentation, sole bool) bool {
+	for key, value := range pod.Annotations {
+		if !strings.HasPrefix(key, injectAnnotationPrefix) || strings.HasSuffix(key, "container-names") {
+			continue
+		}
+		if (sole && strings.EqualFold(value, "true")) || value == r.Name || value == fmt.Sprintf("%s/%s", r.Namespace, r.Name) {
+			return true
+		}
+	}
+	return false
+}
+
 func NewInstrumentationWebhook(logger logr.Logger, scheme *runtime.Scheme, cfg config.Config) *InstrumentationWebhook {
 	return &InstrumentationWebhook{
 		logger: logger,
@@ -328,6 +447,7 @@ func SetupInstrumentationWebhook(mgr ctrl.Manager, cfg config.Config) error {
 		mgr.GetScheme(),
 		cfg,
 	)
+	ivw.reader = mgr.GetAPIReader()
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&Instrumentation{}).
 		WithValidator(ivw).
@@ -18,10 +18,16 @@ import (
 	"context"
 	"testing"
 
+	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/imagedigest"
 )
 
 func TestInstrumentationDefaultingWebhook(t *testing.T) {
@@ -68,6 +74,15 @@ func TestInstrumentationValidatingWebhook(t *testing.T) {
 			},
 			warnings: []string{"sampler type not set"},
 		},
+		{
+			name: "auto discovery along with an endpoint",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Exporter: Exporter{Endpoint: "http://otel-collector:4317", AutoDiscovery: true},
+				},
+			},
+			warnings: []string{"spec.exporter.autoDiscovery has no effect as spec.exporter.endpoint is set", "sampler type not set"},
+		},
 		{
 			name: "argument is not a number",
 			err:  "spec.sampler.argument is not a number",
@@ -113,6 +128,33 @@ func TestInstrumentationValidatingWebhook(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "apache httpd version is supported",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: ParentBasedAlwaysOn,
+					},
+					ApacheHttpd: ApacheHttpd{
+						Version: "2.2",
+					},
+				},
+			},
+		},
+		{
+			name: "apache httpd version is not supported",
+			err:  "spec.apacheHttpd.version is not valid: 2.0",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: ParentBasedAlwaysOn,
+					},
+					ApacheHttpd: ApacheHttpd{
+						Version: "2.0",
+					},
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -137,6 +179,28 @@ func TestInstrumentationValidatingWebhook(t *testing.T) {
 	}
 }
 
+func TestInstrumentationValidatingWebhookUnchangedApacheHttpdVersion(t *testing.T) {
+	// prepare
+	old := &Instrumentation{
+		Spec: InstrumentationSpec{
+			Sampler:     Sampler{Type: ParentBasedAlwaysOn},
+			ApacheHttpd: ApacheHttpd{Version: "2.0"},
+		},
+	}
+	updated := old.DeepCopy()
+	updated.Labels = map[string]string{"team": "web"}
+	changed := old.DeepCopy()
+	changed.Spec.ApacheHttpd.Version = "2.1"
+
+	// test
+	_, unchangedErr := InstrumentationWebhook{}.ValidateUpdate(context.Background(), old, updated)
+	_, changedErr := InstrumentationWebhook{}.ValidateUpdate(context.Background(), old, changed)
+
+	// verify
+	assert.NoError(t, unchangedErr, "an existing instrumentation should stay updatable")
+	assert.ErrorContains(t, changedErr, "spec.apacheHttpd.version is not valid: 2.1")
+}
+
 func TestInstrumentationJaegerRemote(t *testing.T) {
 	tests := []struct {
 		name string
@@ -197,3 +261,155 @@ func TestInstrumentationJaegerRemote(t *testing.T) {
 		}
 	}
 }
+
+func TestInstrumentationValidateDelete(t *testing.T) {
+	inst := &Instrumentation{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-inst", Namespace: "my-ns"},
+		Spec: InstrumentationSpec{
+			Sampler: Sampler{Type: AlwaysOn},
+		},
+	}
+	pod := func(name string, annotations map[string]string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "my-ns", Annotations: annotations}}
+	}
+
+	tests := []struct {
+		name      string
+		pods      []*corev1.Pod
+		instances []*Instrumentation
+		warnings  admission.Warnings
+	}{
+		{
+			name: "no referencing pods",
+			pods: []*corev1.Pod{
+				pod("not-annotated", nil),
+				pod("other-instance", map[string]string{"instrumentation.opentelemetry.io/inject-java": "other-inst"}),
+				pod("disabled", map[string]string{"instrumentation.opentelemetry.io/inject-java": "false"}),
+				pod("container-names", map[string]string{"instrumentation.opentelemetry.io/java-container-names": "my-inst"}),
+			},
+		},
+		{
+			name: "referencing pods",
+			pods: []*corev1.Pod{
+				pod("by-name", map[string]string{"instrumentation.opentelemetry.io/inject-java": "my-inst"}),
+				pod("by-namespaced-name", map[string]string{"instrumentation.opentelemetry.io/inject-python": "my-ns/my-inst"}),
+				pod("default-instance", map[string]string{"instrumentation.opentelemetry.io/inject-nodejs": "true"}),
+			},
+			warnings: admission.Warnings{"3 pod(s) are still annotated to use Instrumentation my-inst, they have to be restarted to remove the injected configuration"},
+		},
+		{
+			name: "default instance among several",
+			pods: []*corev1.Pod{
+				pod("by-name", map[string]string{"instrumentation.opentelemetry.io/inject-java": "my-inst"}),
+				pod("default-instance", map[string]string{"instrumentation.opentelemetry.io/inject-nodejs": "true"}),
+			},
+			instances: []*Instrumentation{{ObjectMeta: metav1.ObjectMeta{Name: "other-inst", Namespace: "my-ns"}}},
+			warnings:  admission.Warnings{"1 pod(s) are still annotated to use Instrumentation my-inst, they have to be restarted to remove the injected configuration"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithObjects(inst.DeepCopy())
+			for _, p := range test.pods {
+				builder = builder.WithObjects(p)
+			}
+			for _, i := range test.instances {
+				builder = builder.WithObjects(i)
+			}
+			w := NewInstrumentationWebhook(logr.Discard(), nil, config.New())
+			w.reader = builder.Build()
+
+			warnings, err := w.ValidateDelete(context.Background(), inst)
+			assert.NoError(t, err)
+			assert.Equal(t, test.warnings, warnings)
+		})
+	}
+}
+
+func TestInstrumentationValidatingWebhookImageRegistries(t *testing.T) {
+	tests := []struct {
+		name string
+		inst Instrumentation
+		err  string
+	}{
+		{
+			name: "images from the allowed registries",
+			inst: Instrumentation{Spec: InstrumentationSpec{
+				Java:   Java{Image: "registry.example.com/java:1"},
+				NodeJS: NodeJS{Image: "mirror.example.com/otel/nodejs:1"},
+			}},
+		},
+		{
+			name: "default images of the operator",
+			inst: Instrumentation{Spec: InstrumentationSpec{
+				Python: Python{Image: "ghcr.io/open-telemetry/python:1"},
+			}},
+		},
+		{
+			name: "default images of the operator pinned to a digest",
+			inst: Instrumentation{Spec: InstrumentationSpec{
+				Python: Python{Image: "ghcr.io/open-telemetry/python:1@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"},
+			}},
+		},
+		{
+			name: "image from another registry",
+			inst: Instrumentation{Spec: InstrumentationSpec{
+				DotNet: DotNet{Image: "ghcr.io/open-telemetry/dotnet:1"},
+			}},
+			err: "the Instrumentation spec.dotnet uses the image ghcr.io/open-telemetry/dotnet:1, which isn't pulled from one of the allowed registries: registry.example.com, mirror.example.com/otel",
+		},
+		{
+			name: "image from another path of the registry",
+			inst: Instrumentation{Spec: InstrumentationSpec{
+				Go: Go{Image: "mirror.example.com/other/go:1"},
+			}},
+			err: "the Instrumentation spec.go uses the image mirror.example.com/other/go:1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// prepare
+			test.inst.Spec.Sampler = Sampler{Type: AlwaysOn}
+			w := InstrumentationWebhook{
+				cfg: config.New(
+					config.WithAutoInstrumentationPythonImage("ghcr.io/open-telemetry/python:1"),
+					config.WithAllowedImageRegistries([]string{"registry.example.com", "mirror.example.com/otel/"}),
+				),
+			}
+
+			// test
+			_, createErr := w.ValidateCreate(context.Background(), &test.inst)
+			_, updateErr := w.ValidateUpdate(context.Background(), &test.inst, &test.inst)
+
+			// verify
+			if test.err == "" {
+				assert.NoError(t, createErr)
+				assert.NoError(t, updateErr)
+				return
+			}
+			assert.ErrorContains(t, createErr, test.err)
+			assert.ErrorContains(t, updateErr, test.err)
+		})
+	}
+}
+
+func TestInstrumentationDefaultingImageDigests(t *testing.T) {
+	digest := "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	resolver, err := imagedigest.New(logr.Discard(), map[string]string{"java-img:1": digest}, false)
+	require.NoError(t, err)
+	inst := &Instrumentation{}
+
+	err = InstrumentationWebhook{
+		cfg: config.New(
+			config.WithAutoInstrumentationJavaImage("java-img:1"),
+			config.WithAutoInstrumentationNodeJSImage("nodejs-img:1"),
+			config.WithImageDigestResolver(resolver),
+		),
+	}.Default(context.Background(), inst)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "java-img:1@"+digest, inst.Spec.Java.Image)
+	assert.Equal(t, "nodejs-img:1", inst.Spec.NodeJS.Image)
+}
@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// validateIPFamilies checks that the IP families and the IP family policy of the Services of a resource
+// can be satisfied by a Kubernetes Service.
+func validateIPFamilies(field string, families []v1.IPFamily, policy *v1.IPFamilyPolicy) error {
+	if len(families) > 2 {
+		return fmt.Errorf("the %sipFamilies must not contain more than two IP families", field)
+	}
+	seen := map[v1.IPFamily]bool{}
+	for _, family := range families {
+		if family != v1.IPv4Protocol && family != v1.IPv6Protocol {
+			return fmt.Errorf("the %sipFamilies contains the unsupported IP family '%s', must be one of %s, %s", field, family, v1.IPv4Protocol, v1.IPv6Protocol)
+		}
+		if seen[family] {
+			return fmt.Errorf("the %sipFamilies contains the IP family '%s' more than once", field, family)
+		}
+		seen[family] = true
+	}
+	if policy == nil {
+		return nil
+	}
+	switch *policy {
+	case v1.IPFamilyPolicySingleStack:
+		if len(families) > 1 {
+			return fmt.Errorf("the %sipFamilyPolicy is %s, which does not support more than one IP family", field, *policy)
+		}
+	case v1.IPFamilyPolicyPreferDualStack, v1.IPFamilyPolicyRequireDualStack:
+	default:
+		return fmt.Errorf("the %sipFamilyPolicy '%s' is incorrect, must be one of %s, %s, %s", field, *policy,
+			v1.IPFamilyPolicySingleStack, v1.IPFamilyPolicyPreferDualStack, v1.IPFamilyPolicyRequireDualStack)
+	}
+	return nil
+}
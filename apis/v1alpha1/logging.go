@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+type (
+	// LogLevel represents the minimum level of the logs written by a component.
+	// +kubebuilder:validation:Enum=debug;info;error
+	LogLevel string
+
+	// LogFormat represents the encoding of the logs written by a component.
+	// +kubebuilder:validation:Enum=json;console
+	LogFormat string
+)
+
+const (
+	// LogLevelDebug writes the debug logs as well, e.g. the details of every target assignment.
+	LogLevelDebug LogLevel = "debug"
+
+	// LogLevelInfo writes the informational and error logs.
+	LogLevelInfo LogLevel = "info"
+
+	// LogLevelError writes the error logs only.
+	LogLevelError LogLevel = "error"
+)
+
+const (
+	// LogFormatJSON writes the logs as JSON objects, one per line.
+	LogFormatJSON LogFormat = "json"
+
+	// LogFormatConsole writes the logs as human-readable lines.
+	LogFormatConsole LogFormat = "console"
+)
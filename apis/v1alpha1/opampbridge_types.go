@@ -30,6 +30,35 @@ type OpAMPBridgeSpec struct {
 	// ComponentsAllowed is a list of allowed OpenTelemetry components for each pipeline type (receiver, processor, etc.)
 	// +optional
 	ComponentsAllowed map[string][]string `json:"componentsAllowed,omitempty"`
+	// CollectorSelector restricts the OpenTelemetryCollectors managed by the OpAMPBridge to the ones matching it, so
+	// that several OpAMPBridges manage disjoint sets of collectors. The selectors of the OpAMPBridges of a namespace
+	// must not overlap. All the collectors are managed when it's not set.
+	// +optional
+	CollectorSelector *metav1.LabelSelector `json:"collectorSelector,omitempty"`
+	// ConfigPatches is a YAML mapping merged into the configuration generated for the OpAMPBridge, to set the options
+	// of the bridge which don't have a field of their own in the spec yet. The options generated from the spec, such
+	// as the endpoint or the capabilities, can't be set with it.
+	// +optional
+	ConfigPatches string `json:"configPatches,omitempty"`
+	// DryRun makes the OpAMPBridge validate the remote configurations received from the OpAMP server, including with
+	// the webhooks of the operator, without applying them to the collectors.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+	// HeartbeatInterval is the interval at which the OpAMPBridge reports the health of the collectors to the OpAMP
+	// server, which also keeps the connection alive. It must be at least 5s. Defaults to 30s.
+	// +optional
+	// +kubebuilder:validation:Format:=duration
+	HeartbeatInterval *metav1.Duration `json:"heartbeatInterval,omitempty"`
+	// ReportingInterval is the interval at which the OpAMPBridge exports its own metrics to the destination offered by
+	// the OpAMP server, when it reports its own metrics. It must be at least 5s. Defaults to 5s.
+	// +optional
+	// +kubebuilder:validation:Format:=duration
+	ReportingInterval *metav1.Duration `json:"reportingInterval,omitempty"`
+	// PersistInstanceID makes the OpAMPBridge keep its OpAMP instance UID across restarts, so that the OpAMP server
+	// doesn't see a new agent after each restart. The UID is stored by the bridge in the ConfigMap
+	// <name>-opamp-bridge-instance-id, which its service account must be allowed to get, create and update.
+	// +optional
+	PersistInstanceID bool `json:"persistInstanceID,omitempty"`
 	// Resources to set on the OpAMPBridge pods.
 	// +optional
 	Resources v1.ResourceRequirements `json:"resources,omitempty"`
@@ -46,6 +75,15 @@ type OpAMPBridgeSpec struct {
 	// PodSecurityContext will be set as the pod security context.
 	// +optional
 	PodSecurityContext *v1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+	// Annotations is the set of annotations that will be attached to the Deployment of the OpAMPBridge, and not
+	// to its pods. They take precedence over the annotations of the OpAMPBridge, e.g. to configure a tool
+	// watching the workloads like Reloader.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// StandardLabels overrides the values of the standard app.kubernetes.io labels of the objects generated for the
+	// OpAMPBridge.
+	// +optional
+	StandardLabels *StandardLabels `json:"standardLabels,omitempty"`
 	// PodAnnotations is the set of annotations that will be attached to
 	// OpAMPBridge pods.
 	// +optional
@@ -54,12 +92,23 @@ type OpAMPBridgeSpec struct {
 	// the operator will not automatically create a ServiceAccount for the OpAMPBridge.
 	// +optional
 	ServiceAccount string `json:"serviceAccount,omitempty"`
+	// LogLevel is the minimum level of the logs written by the OpAMPBridge. Defaults to info.
+	// +optional
+	LogLevel LogLevel `json:"logLevel,omitempty"`
+	// LogFormat is the encoding of the logs written by the OpAMPBridge, json or console. Defaults to json.
+	// +optional
+	LogFormat LogFormat `json:"logFormat,omitempty"`
 	// Image indicates the container image to use for the OpAMPBridge.
 	// +optional
 	Image string `json:"image,omitempty"`
 	// UpgradeStrategy represents how the operator will handle upgrades to the CR when a newer version of the operator is deployed
 	// +optional
 	UpgradeStrategy UpgradeStrategy `json:"upgradeStrategy"`
+	// UpgradeWindows restricts the automatic upgrades, including the rollout of a new default image, to the given
+	// recurring periods of time. The upgrades are not restricted when no window is set.
+	// +optional
+	// +listType=atomic
+	UpgradeWindows []UpgradeWindow `json:"upgradeWindows,omitempty"`
 	// ImagePullPolicy indicates the pull policy to be used for retrieving the container image (Always, Never, IfNotPresent)
 	// +optional
 	ImagePullPolicy v1.PullPolicy `json:"imagePullPolicy,omitempty"`
@@ -71,6 +120,15 @@ type OpAMPBridgeSpec struct {
 	// +optional
 	// +listType=atomic
 	Ports []v1.ServicePort `json:"ports,omitempty"`
+	// IPFamilies is the list of IP families (e.g. IPv4, IPv6) assigned to the Services of the OpAMPBridge.
+	// Leave it empty to use the cluster default.
+	// +optional
+	// +listType=atomic
+	IPFamilies []v1.IPFamily `json:"ipFamilies,omitempty"`
+	// IPFamilyPolicy represents the dual-stack-ness requested by the Services of the OpAMPBridge,
+	// one of SingleStack, PreferDualStack or RequireDualStack.
+	// +optional
+	IPFamilyPolicy *v1.IPFamilyPolicy `json:"ipFamilyPolicy,omitempty"`
 	// ENV vars to set on the OpAMPBridge Pods.
 	// +optional
 	Env []v1.EnvVar `json:"env,omitempty"`
@@ -84,6 +142,12 @@ type OpAMPBridgeSpec struct {
 	// +optional
 	// +listType=atomic
 	Volumes []v1.Volume `json:"volumes,omitempty"`
+	// SecretProviderClasses mounts the secrets of SecretProviderClasses of the secrets-store CSI driver in the
+	// OpAMPBridge pods, e.g. the credentials used to connect to the OpAMP server.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	SecretProviderClasses []SecretProviderClassVolume `json:"secretProviderClasses,omitempty"`
 	// HostNetwork indicates if the pod should run in the host networking namespace.
 	// +optional
 	HostNetwork bool `json:"hostNetwork,omitempty"`
@@ -108,13 +172,79 @@ type OpAMPBridgeStatus struct {
 	// Version of the managed OpAMP Bridge (operand)
 	// +optional
 	Version string `json:"version,omitempty"`
+
+	// Image indicates the container image the OpAMP Bridge runs.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// StatusReplicas is the number of ready pods of the OpAMP Bridge out of the number of its pods, e.g. 1/1.
+	// +optional
+	StatusReplicas string `json:"statusReplicas,omitempty"`
+
+	// Conditions represent the latest available observations of the resource state, e.g. Ready, Progressing and Degraded.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// RemoteConfigs reports, for each collector, the outcome of the last remote configuration received from the
+	// OpAMP server.
+	// +optional
+	// +listType=map
+	// +listMapKey=collector
+	RemoteConfigs []OpAMPBridgeRemoteConfigStatus `json:"remoteConfigs,omitempty"`
+	// Components lists the objects generated by the operator for the OpAMPBridge, e.g. its Deployment, Service and
+	// ConfigMap, as of the last successful reconciliation.
+	// +optional
+	// +listType=map
+	// +listMapKey=kind
+	// +listMapKey=name
+	Components []ComponentStatus `json:"components,omitempty"`
+}
+
+// OpAMPBridgeRemoteConfigStatus reports the outcome of a remote configuration for a collector.
+type OpAMPBridgeRemoteConfigStatus struct {
+	// Collector is the namespace/name of the OpenTelemetryCollector the remote configuration is for.
+	// +required
+	Collector string `json:"collector"`
+
+	// Status of the remote configuration.
+	// +required
+	Status RemoteConfigStatus `json:"status"`
+
+	// Message explains why the remote configuration was rejected.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// LastUpdateTime is the last time the remote configuration of the collector was received.
+	// +optional
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
 }
 
+// RemoteConfigStatus represents the outcome of a remote configuration.
+// +kubebuilder:validation:Enum=Applied;Validated;Rejected
+type RemoteConfigStatus string
+
+const (
+	// RemoteConfigStatusApplied represents a remote configuration applied to the collector.
+	RemoteConfigStatusApplied RemoteConfigStatus = "Applied"
+
+	// RemoteConfigStatusValidated represents a remote configuration which passed the validation in dry-run mode.
+	RemoteConfigStatusValidated RemoteConfigStatus = "Validated"
+
+	// RemoteConfigStatusRejected represents a remote configuration which was refused, e.g. because it uses components
+	// which aren't allowed, or was rejected by the webhooks of the operator.
+	RemoteConfigStatusRejected RemoteConfigStatus = "Rejected"
+)
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.version",description="OpenTelemetry Version"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.statusReplicas"
 // +kubebuilder:printcolumn:name="Endpoint",type="string",JSONPath=".spec.endpoint"
+// +kubebuilder:printcolumn:name="Image",type="string",JSONPath=".status.image",priority=1
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].reason",priority=1
 // +operator-sdk:csv:customresourcedefinitions:displayName="OpAMP Bridge"
 // +operator-sdk:csv:customresourcedefinitions:resources={{Pod,v1},{Deployment,apps/v1},{ConfigMaps,v1},{Service,v1}}
 
@@ -18,15 +18,22 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/yaml"
 )
 
 var (
@@ -34,6 +41,23 @@ var (
 	_ admission.CustomDefaulter = &OpAMPBridgeWebhook{}
 )
 
+// minOpAMPBridgeInterval is the minimum heartbeat and reporting interval of the OpAMPBridge, so that large fleets of
+// bridges can't flood the OpAMP server.
+const minOpAMPBridgeInterval = 5 * time.Second
+
+// opAMPBridgeConfigFields maps the options of the configuration of the bridge generated by the operator to the fields
+// of the spec they're generated from, which the config patches can't override.
+var opAMPBridgeConfigFields = map[string]string{
+	"endpoint":            "endpoint",
+	"capabilities":        "capabilities",
+	"componentsAllowed":   "componentsAllowed",
+	"dryRun":              "dryRun",
+	"heartbeatInterval":   "heartbeatInterval",
+	"reportingInterval":   "reportingInterval",
+	"collectorSelector":   "collectorSelector",
+	"instanceIDConfigMap": "persistInstanceID",
+}
+
 //+kubebuilder:webhook:path=/mutate-opentelemetry-io-v1alpha1-opampbridge,mutating=true,failurePolicy=fail,sideEffects=None,groups=opentelemetry.io,resources=opampbridges,verbs=create;update,versions=v1alpha1,name=mopampbridge.kb.io,admissionReviewVersions=v1
 //+kubebuilder:webhook:path=/validate-opentelemetry-io-v1alpha1-opampbridge,mutating=false,failurePolicy=fail,sideEffects=None,groups=opentelemetry.io,resources=opampbridges,verbs=create;update,versions=v1alpha1,name=vopampbridgecreateupdate.kb.io,admissionReviewVersions=v1
 //+kubebuilder:webhook:path=/validate-opentelemetry-io-v1alpha1-opampbridge,mutating=false,failurePolicy=ignore,sideEffects=None,groups=opentelemetry.io,resources=opampbridges,verbs=delete,versions=v1alpha1,name=vopampbridgedelete.kb.io,admissionReviewVersions=v1
@@ -43,6 +67,7 @@ type OpAMPBridgeWebhook struct {
 	logger logr.Logger
 	cfg    config.Config
 	scheme *runtime.Scheme
+	reader client.Reader
 }
 
 func (o *OpAMPBridgeWebhook) Default(ctx context.Context, obj runtime.Object) error {
@@ -50,7 +75,10 @@ func (o *OpAMPBridgeWebhook) Default(ctx context.Context, obj runtime.Object) er
 	if !ok {
 		return fmt.Errorf("expected an OpAMPBridge, received %T", obj)
 	}
-	return o.defaulter(opampBridge)
+	if err := o.defaulter(opampBridge); err != nil {
+		return err
+	}
+	return pinImages(ctx, o.cfg.ImageDigestResolver(), &opampBridge.Spec.Image)
 }
 
 func (c OpAMPBridgeWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
@@ -58,7 +86,20 @@ func (c OpAMPBridgeWebhook) ValidateCreate(ctx context.Context, obj runtime.Obje
 	if !ok {
 		return nil, fmt.Errorf("expected an OpAMPBridge, received %T", obj)
 	}
-	return c.validate(opampBridge)
+	warnings, err := c.validate(opampBridge)
+	if err != nil {
+		return warnings, err
+	}
+	if err := c.validateCollectorSelectorOverlaps(ctx, opampBridge); err != nil {
+		return warnings, err
+	}
+	if err := c.validateImageRegistries(opampBridge); err != nil {
+		return warnings, err
+	}
+	if err := c.validateAutopilot(opampBridge); err != nil {
+		return warnings, err
+	}
+	return warnings, c.validateQuotas(ctx, opampBridge)
 }
 
 func (c OpAMPBridgeWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
@@ -66,7 +107,20 @@ func (c OpAMPBridgeWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj r
 	if !ok {
 		return nil, fmt.Errorf("expected an OpAMPBridge, received %T", newObj)
 	}
-	return c.validate(opampBridge)
+	warnings, err := c.validate(opampBridge)
+	if err != nil {
+		return warnings, err
+	}
+	if err := c.validateCollectorSelectorOverlaps(ctx, opampBridge); err != nil {
+		return warnings, err
+	}
+	if err := c.validateImageRegistries(opampBridge); err != nil {
+		return warnings, err
+	}
+	if err := c.validateAutopilot(opampBridge); err != nil {
+		return warnings, err
+	}
+	return warnings, c.validateQuotas(ctx, opampBridge)
 }
 
 func (o OpAMPBridgeWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
@@ -118,6 +172,43 @@ func (o OpAMPBridgeWebhook) validate(r *OpAMPBridge) (admission.Warnings, error)
 		return warnings, fmt.Errorf("the capabilities supported by OpAMP Bridge are not specified")
 	}
 
+	// validate the volumes of the SecretProviderClasses
+	if err := validateSecretProviderClasses(r.Spec.SecretProviderClasses, r.Spec.Volumes, r.Spec.VolumeMounts); err != nil {
+		return warnings, err
+	}
+
+	// validate upgrade windows
+	for _, w := range r.Spec.UpgradeWindows {
+		if err := w.Validate(); err != nil {
+			return warnings, err
+		}
+	}
+
+	// validate the heartbeat and reporting intervals
+	if r.Spec.HeartbeatInterval != nil && r.Spec.HeartbeatInterval.Duration < minOpAMPBridgeInterval {
+		return warnings, fmt.Errorf("the OpAMPBridge Spec heartbeatInterval %s is incorrect, it must be at least %s", r.Spec.HeartbeatInterval.Duration, minOpAMPBridgeInterval)
+	}
+	if r.Spec.ReportingInterval != nil && r.Spec.ReportingInterval.Duration < minOpAMPBridgeInterval {
+		return warnings, fmt.Errorf("the OpAMPBridge Spec reportingInterval %s is incorrect, it must be at least %s", r.Spec.ReportingInterval.Duration, minOpAMPBridgeInterval)
+	}
+
+	// validate the values of the standard labels
+	if err := validateStandardLabels("OpAMPBridge Spec ", r.Spec.StandardLabels); err != nil {
+		return warnings, err
+	}
+
+	// validate the config patches
+	if err := validateOpAMPBridgeConfigPatches(r.Spec.ConfigPatches); err != nil {
+		return warnings, err
+	}
+
+	// validate the collector selector
+	if r.Spec.CollectorSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(r.Spec.CollectorSelector); err != nil {
+			return warnings, fmt.Errorf("the OpAMPBridge Spec collectorSelector is incorrect: %w", err)
+		}
+	}
+
 	// validate port config
 	for _, p := range r.Spec.Ports {
 		nameErrs := validation.IsValidPortName(p.Name)
@@ -128,19 +219,153 @@ func (o OpAMPBridgeWebhook) validate(r *OpAMPBridge) (admission.Warnings, error)
 		}
 	}
 
+	// validate the IP families of the Service
+	if err := validateIPFamilies("OpAMPBridge Spec ", r.Spec.IPFamilies, r.Spec.IPFamilyPolicy); err != nil {
+		return warnings, err
+	}
+
 	// check for maximum replica count
 	if r.Spec.Replicas != nil && *r.Spec.Replicas > 1 {
 		return warnings, fmt.Errorf("replica count must not be greater than 1")
 	}
-	return warnings, nil
+	return append(warnings, opAMPBridgeWarnings(r)...), nil
 }
 
-func SetupOpAMPBridgeWebhook(mgr ctrl.Manager, cfg config.Config) error {
-	webhook := &OpAMPBridgeWebhook{
-		logger: mgr.GetLogger().WithValues("handler", "OpAMPBridgeWebhook"),
-		scheme: mgr.GetScheme(),
+// validateOpAMPBridgeConfigPatches checks that the config patches are a YAML mapping which doesn't set the options
+// generated from the spec.
+func validateOpAMPBridgeConfigPatches(patches string) error {
+	if len(strings.TrimSpace(patches)) == 0 {
+		return nil
+	}
+	options := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(patches), &options); err != nil {
+		return fmt.Errorf("the OpAMPBridge Spec configPatches is incorrect, it must be a YAML mapping: %w", err)
+	}
+	for option := range options {
+		if field, ok := opAMPBridgeConfigFields[option]; ok {
+			return fmt.Errorf("the OpAMPBridge Spec configPatches can't set the option '%s', set the spec field '%s' instead", option, field)
+		}
+	}
+	return nil
+}
+
+// validateCollectorSelectorOverlaps rejects the OpAMPBridges whose collector selector may match the same collectors as
+// the selector of another OpAMPBridge of the namespace, as both would then apply their remote configurations to them.
+// The OpAMPBridges without a selector manage all the collectors, they only overlap with the ones having a selector.
+func (o OpAMPBridgeWebhook) validateCollectorSelectorOverlaps(ctx context.Context, r *OpAMPBridge) error {
+	if o.reader == nil {
+		return nil
+	}
+	bridges := &OpAMPBridgeList{}
+	if err := o.reader.List(ctx, bridges, client.InNamespace(r.Namespace)); err != nil {
+		return fmt.Errorf("failed to list the OpAMPBridges of the namespace %s: %w", r.Namespace, err)
+	}
+	for _, other := range bridges.Items {
+		if other.Name == r.Name || (r.Spec.CollectorSelector == nil && other.Spec.CollectorSelector == nil) {
+			continue
+		}
+		if collectorSelectorsOverlap(r.Spec.CollectorSelector, other.Spec.CollectorSelector) {
+			return fmt.Errorf("the OpAMPBridge Spec collectorSelector overlaps with the one of the OpAMPBridge %s, the OpAMPBridges of a namespace must select disjoint sets of collectors", other.Name)
+		}
+	}
+	return nil
+}
+
+// validateQuotas rejects the OpAMPBridges whose pods would be refused by the LimitRanges or the ResourceQuotas of the
+// namespace, when enabled.
+// validateImageRegistries rejects the OpAMP bridges whose image isn't pulled from the allowed registries, when set.
+func (o OpAMPBridgeWebhook) validateImageRegistries(r *OpAMPBridge) error {
+	defaults := map[string]bool{o.cfg.OperatorOpAMPBridgeImage(): true}
+	return validateImageRegistries("OpAMP Bridge", o.cfg.AllowedImageRegistries(), defaults, containerImage{name: "container", image: r.Spec.Image})
+}
+
+// validateAutopilot rejects the OpAMP bridges using the features forbidden on GKE Autopilot, when enabled.
+func (o OpAMPBridgeWebhook) validateAutopilot(r *OpAMPBridge) error {
+	if !o.cfg.Autopilot() {
+		return nil
+	}
+	return validateAutopilot("OpAMPBridge", r.Spec.HostNetwork, r.Spec.Volumes, r.Spec.SecurityContext)
+}
+
+func (o OpAMPBridgeWebhook) validateQuotas(ctx context.Context, r *OpAMPBridge) error {
+	if !o.cfg.ResourceQuotaValidation() {
+		return nil
+	}
+	return validateQuotas(ctx, o.reader, r.Namespace, "OpAMPBridge", containerResources{name: "container", resources: r.Spec.Resources})
+}
+
+// collectorSelectorsOverlap tells whether some labels may match both selectors, a nil selector matching all the labels.
+// The selectors are only disjoint when the requirements of both on a label key can't be met together, e.g. different
+// values for the same key, or a key required by one and excluded by the other.
+func collectorSelectorsOverlap(a, b *metav1.LabelSelector) bool {
+	requirements := map[string][]labels.Requirement{}
+	for _, ls := range []*metav1.LabelSelector{a, b} {
+		if ls == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(ls)
+		if err != nil {
+			// an invalid selector can't be reasoned about
+			return true
+		}
+		reqs, _ := selector.Requirements()
+		for _, req := range reqs {
+			requirements[req.Key()] = append(requirements[req.Key()], req)
+		}
+	}
+	for _, reqs := range requirements {
+		if !satisfiable(reqs) {
+			return false
+		}
+	}
+	return true
+}
+
+// satisfiable tells whether a label value, or its absence, meets all the given requirements on a single key. The
+// numeric comparisons are assumed to be satisfiable.
+func satisfiable(reqs []labels.Requirement) bool {
+	exists, notExists := false, false
+	var allowed sets.Set[string]
+	forbidden := sets.New[string]()
+	for _, req := range reqs {
+		switch req.Operator() {
+		case selection.Exists:
+			exists = true
+		case selection.DoesNotExist:
+			notExists = true
+		case selection.In, selection.Equals, selection.DoubleEquals:
+			exists = true
+			values := sets.New[string](req.Values().UnsortedList()...)
+			if allowed == nil {
+				allowed = values
+			} else {
+				allowed = allowed.Intersection(values)
+			}
+		case selection.NotIn, selection.NotEquals:
+			forbidden.Insert(req.Values().UnsortedList()...)
+		case selection.GreaterThan, selection.LessThan:
+			exists = true
+		}
+	}
+	if exists && notExists {
+		return false
+	}
+	return allowed == nil || allowed.Difference(forbidden).Len() > 0
+}
+
+// NewOpAMPBridgeWebhook returns the webhook defaulting and validating OpAMPBridge resources.
+// The reader is used to look up the other OpAMPBridges of the namespace, it can be nil when there's no cluster to query.
+func NewOpAMPBridgeWebhook(logger logr.Logger, scheme *runtime.Scheme, cfg config.Config, reader client.Reader) *OpAMPBridgeWebhook {
+	return &OpAMPBridgeWebhook{
+		logger: logger,
+		scheme: scheme,
 		cfg:    cfg,
+		reader: reader,
 	}
+}
+
+func SetupOpAMPBridgeWebhook(mgr ctrl.Manager, cfg config.Config) error {
+	webhook := NewOpAMPBridgeWebhook(mgr.GetLogger().WithValues("handler", "OpAMPBridgeWebhook"), mgr.GetScheme(), cfg, mgr.GetAPIReader())
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&OpAMPBridge{}).
 		WithValidator(webhook).
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 
@@ -27,7 +28,9 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestOpAMPBridgeDefaultingWebhook(t *testing.T) {
@@ -129,6 +132,7 @@ func TestOpAMPBridgeDefaultingWebhook(t *testing.T) {
 func TestOpAMPBridgeValidatingWebhook(t *testing.T) {
 
 	two := int32(2)
+	dualStack := v1.IPFamilyPolicy("DualStack")
 
 	tests := []struct { //nolint:govet
 		name             string
@@ -161,6 +165,82 @@ func TestOpAMPBridgeValidatingWebhook(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "valid intervals",
+			opampBridge: OpAMPBridge{
+				Spec: OpAMPBridgeSpec{
+					Endpoint:          "ws://opamp-server:4320/v1/opamp",
+					Capabilities:      map[OpAMPBridgeCapability]bool{OpAMPBridgeCapabilityReportsStatus: true},
+					HeartbeatInterval: &metav1.Duration{Duration: 5 * time.Second},
+					ReportingInterval: &metav1.Duration{Duration: time.Minute},
+				},
+			},
+		},
+		{
+			name: "valid config patches",
+			opampBridge: OpAMPBridge{
+				Spec: OpAMPBridgeSpec{
+					Endpoint:      "ws://opamp-server:4320/v1/opamp",
+					Capabilities:  map[OpAMPBridgeCapability]bool{OpAMPBridgeCapabilityReportsStatus: true},
+					ConfigPatches: "name: team-a-bridge\nlistenAddr: \":8081\"\n",
+				},
+			},
+		},
+		{
+			name: "config patches which aren't a mapping",
+			opampBridge: OpAMPBridge{
+				Spec: OpAMPBridgeSpec{
+					Endpoint:      "ws://opamp-server:4320/v1/opamp",
+					Capabilities:  map[OpAMPBridgeCapability]bool{OpAMPBridgeCapabilityReportsStatus: true},
+					ConfigPatches: "- name: team-a-bridge",
+				},
+			},
+			expectedErr: "the OpAMPBridge Spec configPatches is incorrect, it must be a YAML mapping",
+		},
+		{
+			name: "config patches overriding the spec",
+			opampBridge: OpAMPBridge{
+				Spec: OpAMPBridgeSpec{
+					Endpoint:      "ws://opamp-server:4320/v1/opamp",
+					Capabilities:  map[OpAMPBridgeCapability]bool{OpAMPBridgeCapabilityReportsStatus: true},
+					ConfigPatches: "endpoint: ws://other:4320/v1/opamp",
+				},
+			},
+			expectedErr: "the OpAMPBridge Spec configPatches can't set the option 'endpoint', set the spec field 'endpoint' instead",
+		},
+		{
+			name: "invalid standard label",
+			opampBridge: OpAMPBridge{
+				Spec: OpAMPBridgeSpec{
+					Endpoint:       "ws://opamp-server:4320/v1/opamp",
+					Capabilities:   map[OpAMPBridgeCapability]bool{OpAMPBridgeCapabilityReportsStatus: true},
+					StandardLabels: &StandardLabels{Version: "-latest"},
+				},
+			},
+			expectedErr: "the OpAMPBridge Spec standardLabels.version '-latest' is incorrect",
+		},
+		{
+			name: "heartbeat interval below the minimum",
+			opampBridge: OpAMPBridge{
+				Spec: OpAMPBridgeSpec{
+					Endpoint:          "ws://opamp-server:4320/v1/opamp",
+					Capabilities:      map[OpAMPBridgeCapability]bool{OpAMPBridgeCapabilityReportsStatus: true},
+					HeartbeatInterval: &metav1.Duration{Duration: time.Second},
+				},
+			},
+			expectedErr: "the OpAMPBridge Spec heartbeatInterval 1s is incorrect, it must be at least 5s",
+		},
+		{
+			name: "reporting interval below the minimum",
+			opampBridge: OpAMPBridge{
+				Spec: OpAMPBridgeSpec{
+					Endpoint:          "ws://opamp-server:4320/v1/opamp",
+					Capabilities:      map[OpAMPBridgeCapability]bool{OpAMPBridgeCapabilityReportsStatus: true},
+					ReportingInterval: &metav1.Duration{Duration: 500 * time.Millisecond},
+				},
+			},
+			expectedErr: "the OpAMPBridge Spec reportingInterval 500ms is incorrect, it must be at least 5s",
+		},
 		{
 			name: "empty OpAMP Server endpoint",
 			opampBridge: OpAMPBridge{
@@ -170,6 +250,32 @@ func TestOpAMPBridgeValidatingWebhook(t *testing.T) {
 			},
 			expectedErr: "the OpAMP server endpoint is not specified",
 		},
+		{
+			name: "invalid secret provider class node publish secret",
+			opampBridge: OpAMPBridge{
+				Spec: OpAMPBridgeSpec{
+					Endpoint: "ws://opamp-server:4320/v1/opamp",
+					Capabilities: map[OpAMPBridgeCapability]bool{
+						OpAMPBridgeCapabilityReportsStatus: true,
+					},
+					SecretProviderClasses: []SecretProviderClassVolume{{Name: "opamp-credentials", NodePublishSecretRef: "Vault Credentials"}},
+				},
+			},
+			expectedErr: "the secretProviderClasses opamp-credentials nodePublishSecretRef 'Vault Credentials' is incorrect",
+		},
+		{
+			name: "invalid ip family policy",
+			opampBridge: OpAMPBridge{
+				Spec: OpAMPBridgeSpec{
+					Endpoint: "ws://opamp-server:4320/v1/opamp",
+					Capabilities: map[OpAMPBridgeCapability]bool{
+						OpAMPBridgeCapabilityReportsStatus: true,
+					},
+					IPFamilyPolicy: &dualStack,
+				},
+			},
+			expectedErr: "the OpAMPBridge Spec ipFamilyPolicy 'DualStack' is incorrect",
+		},
 		{
 			name: "empty capabilities",
 			opampBridge: OpAMPBridge{
@@ -319,3 +425,144 @@ func TestOpAMPBridgeValidatingWebhook(t *testing.T) {
 		})
 	}
 }
+
+func TestOpAMPBridgeCollectorSelectorOverlaps(t *testing.T) {
+	if err := AddToScheme(testScheme); err != nil {
+		fmt.Printf("failed to register scheme: %v", err)
+		os.Exit(1)
+	}
+	bridge := func(name string, selector *metav1.LabelSelector) *OpAMPBridge {
+		return &OpAMPBridge{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "my-ns"},
+			Spec: OpAMPBridgeSpec{
+				Endpoint:          "ws://opamp-server:4320/v1/opamp",
+				Capabilities:      map[OpAMPBridgeCapability]bool{OpAMPBridgeCapabilityReportsStatus: true},
+				CollectorSelector: selector,
+			},
+		}
+	}
+	teamA := &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}
+	existing := bridge("team-a", teamA)
+	tests := []struct {
+		name        string
+		opampBridge *OpAMPBridge
+		expectedErr string
+	}{
+		{
+			name:        "different value",
+			opampBridge: bridge("team-b", &metav1.LabelSelector{MatchLabels: map[string]string{"team": "b"}}),
+		},
+		{
+			name: "excluded value",
+			opampBridge: bridge("not-team-a", &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "team", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"a"}},
+			}}),
+		},
+		{
+			name: "excluded key",
+			opampBridge: bridge("no-team", &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "team", Operator: metav1.LabelSelectorOpDoesNotExist},
+			}}),
+		},
+		{
+			name:        "update of the same bridge",
+			opampBridge: bridge("team-a", &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a", "env": "dev"}}),
+		},
+		{
+			name:        "other bridge of another namespace",
+			opampBridge: &OpAMPBridge{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "other-ns"}, Spec: bridge("", teamA).Spec},
+		},
+		{
+			name:        "same selector",
+			opampBridge: bridge("other", teamA),
+			expectedErr: "the OpAMPBridge Spec collectorSelector overlaps with the one of the OpAMPBridge team-a",
+		},
+		{
+			name:        "other key",
+			opampBridge: bridge("env-dev", &metav1.LabelSelector{MatchLabels: map[string]string{"env": "dev"}}),
+			expectedErr: "overlaps with the one of the OpAMPBridge team-a",
+		},
+		{
+			name: "intersecting values",
+			opampBridge: bridge("team-a-or-b", &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "team", Operator: metav1.LabelSelectorOpIn, Values: []string{"a", "b"}},
+			}}),
+			expectedErr: "overlaps with the one of the OpAMPBridge team-a",
+		},
+		{
+			name:        "no selector",
+			opampBridge: bridge("all", nil),
+			expectedErr: "overlaps with the one of the OpAMPBridge team-a",
+		},
+		{
+			name: "invalid selector",
+			opampBridge: bridge("invalid", &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "team", Operator: metav1.LabelSelectorOpIn},
+			}}),
+			expectedErr: "the OpAMPBridge Spec collectorSelector is incorrect",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			webhook := &OpAMPBridgeWebhook{
+				logger: logr.Discard(),
+				scheme: testScheme,
+				cfg:    config.New(),
+				reader: fake.NewClientBuilder().WithScheme(testScheme).WithObjects(existing).Build(),
+			}
+			_, err := webhook.ValidateCreate(context.Background(), test.opampBridge)
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestOpAMPBridgeValidatingWebhookResourceQuotas(t *testing.T) {
+	opampBridge := &OpAMPBridge{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-bridge", Namespace: "my-ns"},
+		Spec: OpAMPBridgeSpec{
+			Endpoint:     "ws://opamp-server:4320/v1/opamp",
+			Capabilities: map[OpAMPBridgeCapability]bool{OpAMPBridgeCapabilityReportsStatus: true},
+		},
+	}
+	quota := &v1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: "my-ns"},
+		Spec:       v1.ResourceQuotaSpec{Hard: v1.ResourceList{v1.ResourceLimitsMemory: resource.MustParse("1Gi")}},
+	}
+	webhook := &OpAMPBridgeWebhook{
+		logger: logr.Discard(),
+		scheme: testScheme,
+		cfg:    config.New(config.WithResourceQuotaValidation(true)),
+		reader: fake.NewClientBuilder().WithScheme(testScheme).WithObjects(quota).Build(),
+	}
+
+	_, err := webhook.ValidateCreate(context.Background(), opampBridge)
+	assert.ErrorContains(t, err, "the container of the OpAMPBridge must set a memory limit, required by the limits.memory quota of the ResourceQuota compute")
+}
+
+func TestOpAMPBridgeValidatingWebhookImageRegistries(t *testing.T) {
+	opampBridge := &OpAMPBridge{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-bridge", Namespace: "my-ns"},
+		Spec: OpAMPBridgeSpec{
+			Endpoint:     "ws://opamp-server:4320/v1/opamp",
+			Capabilities: map[OpAMPBridgeCapability]bool{OpAMPBridgeCapabilityReportsStatus: true},
+			Image:        "ghcr.io/open-telemetry/opamp-bridge:1",
+		},
+	}
+	webhook := &OpAMPBridgeWebhook{
+		logger: logr.Discard(),
+		scheme: testScheme,
+		cfg:    config.New(config.WithAllowedImageRegistries([]string{"registry.example.com"})),
+	}
+
+	_, err := webhook.ValidateCreate(context.Background(), opampBridge)
+	assert.ErrorContains(t, err, "the OpAMP Bridge container uses the image ghcr.io/open-telemetry/opamp-bridge:1, which isn't pulled from one of the allowed registries: registry.example.com")
+
+	opampBridge.Spec.Image = "registry.example.com/opamp-bridge:1"
+	_, err = webhook.ValidateCreate(context.Background(), opampBridge)
+	assert.NoError(t, err)
+}
@@ -15,6 +15,7 @@
 package v1alpha1
 
 import (
+	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
@@ -108,6 +109,12 @@ type OpenTelemetryCollectorSpec struct {
 	// Args is the set of arguments to pass to the OpenTelemetry Collector binary
 	// +optional
 	Args map[string]string `json:"args,omitempty"`
+	// FeatureGates is the list of feature gates of the OpenTelemetry Collector to enable, prefixed by an optional '+',
+	// or to disable, prefixed by '-', for example "+pkg.translator.prometheus.NormalizeName" or "-exporter.datadogexporter.metricremappingdisabled".
+	// They're passed to the binary with the --feature-gates flag, after the ones of the args, if any.
+	// +optional
+	// +listType=set
+	FeatureGates []string `json:"featureGates,omitempty"`
 	// Replicas is the number of pod instances for the underlying OpenTelemetry Collector. Set this if your are not using autoscaling
 	// +optional
 	Replicas *int32 `json:"replicas,omitempty"`
@@ -124,6 +131,9 @@ type OpenTelemetryCollectorSpec struct {
 	//
 	// +optional
 	Autoscaler *AutoscalerSpec `json:"autoscaler,omitempty"`
+	// Rollout defines how the changes of the configuration are rolled out to the replicas of the collector.
+	// +optional
+	Rollout *Rollout `json:"rollout,omitempty"`
 	// PodDisruptionBudget specifies the pod disruption budget configuration to use
 	// for the OpenTelemetryCollector workload.
 	//
@@ -141,6 +151,12 @@ type OpenTelemetryCollectorSpec struct {
 	//
 	// +optional
 	SecurityContext *v1.SecurityContext `json:"securityContext,omitempty"`
+	// ReadOnlyRootFilesystem runs the opentelemetry-collector container with a read-only root filesystem.
+	// The operator mounts emptyDir volumes on the directories the collector writes to: /tmp, and the
+	// directories of the file_storage extensions enabled in the configuration. Those volumes are also
+	// mounted when the SecurityContext makes the root filesystem read-only.
+	// +optional
+	ReadOnlyRootFilesystem bool `json:"readOnlyRootFilesystem,omitempty"`
 	// PodSecurityContext configures the pod security context for the
 	// opentelemetry-collector pod, when running as a deployment, daemonset,
 	// or statefulset.
@@ -149,13 +165,70 @@ type OpenTelemetryCollectorSpec struct {
 	//
 	// +optional
 	PodSecurityContext *v1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+	// Annotations is the set of annotations that will be attached to the workload of the Collector, i.e. its
+	// Deployment, DaemonSet or StatefulSet, and not to its pods. They take precedence over the annotations
+	// of the OpenTelemetryCollector, e.g. to configure a tool watching the workloads like Reloader.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
 	// PodAnnotations is the set of annotations that will be attached to
 	// Collector and Target Allocator pods.
 	// +optional
 	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+	// ServiceMesh configures the annotations set on the Collector pods for the service mesh of the cluster,
+	// e.g. to keep the proxy from intercepting the telemetry sent to the receivers.
+	// In sidecar mode, the opentelemetry-operator will ignore this setting.
+	// +optional
+	ServiceMesh ServiceMeshSpec `json:"serviceMesh,omitempty"`
+	// Spiffe configures the mutual TLS of the receivers and exporters with the SVIDs issued by SPIRE.
+	// +optional
+	Spiffe SpiffeSpec `json:"spiffe,omitempty"`
+	// Telemetry configures the internal telemetry of the collector, i.e. its own metrics and logs. It is merged
+	// into the service::telemetry section of the configuration, and sets the ports of the monitoring Service.
+	// +optional
+	Telemetry TelemetrySpec `json:"telemetry,omitempty"`
+	// ServiceAnnotations is the set of annotations that will be attached to
+	// the Services of the Collector, e.g. to configure a cloud load balancer.
+	// +optional
+	ServiceAnnotations map[string]string `json:"serviceAnnotations,omitempty"`
+	// ServiceLabels is the set of labels that will be attached to the Services
+	// of the Collector. They don't override the labels set by the operator.
+	// +optional
+	ServiceLabels map[string]string `json:"serviceLabels,omitempty"`
+	// IPFamilies is the list of IP families (e.g. IPv4, IPv6) assigned to the Services of the Collector.
+	// Leave it empty to use the cluster default.
+	// +optional
+	// +listType=atomic
+	IPFamilies []v1.IPFamily `json:"ipFamilies,omitempty"`
+	// IPFamilyPolicy represents the dual-stack-ness requested by the Services of the Collector,
+	// one of SingleStack, PreferDualStack or RequireDualStack.
+	// +optional
+	IPFamilyPolicy *v1.IPFamilyPolicy `json:"ipFamilyPolicy,omitempty"`
+	// ServiceAccountAnnotations is the set of annotations that will be attached to
+	// the ServiceAccount created for the Collector, e.g. to bind a cloud IAM role.
+	// +optional
+	ServiceAccountAnnotations map[string]string `json:"serviceAccountAnnotations,omitempty"`
+	// ServiceAccountLabels is the set of labels that will be attached to the ServiceAccount
+	// created for the Collector. They don't override the labels set by the operator.
+	// +optional
+	ServiceAccountLabels map[string]string `json:"serviceAccountLabels,omitempty"`
+	// StandardLabels overrides the values of the standard app.kubernetes.io labels of the objects generated for the
+	// Collector and its TargetAllocator.
+	// +optional
+	StandardLabels *StandardLabels `json:"standardLabels,omitempty"`
 	// TargetAllocator indicates a value which determines whether to spawn a target allocation resource or not.
 	// +optional
 	TargetAllocator OpenTelemetryTargetAllocator `json:"targetAllocator,omitempty"`
+	// PrometheusCR makes the operator resolve the ServiceMonitors and PodMonitors of the Prometheus Operator into
+	// scrape configs of the prometheus receiver, for the collectors which don't use a TargetAllocator.
+	// The scrape configs are refreshed whenever the selected ServiceMonitors and PodMonitors change.
+	// +optional
+	PrometheusCR OpenTelemetryCollectorPrometheusCR `json:"prometheusCR,omitempty"`
+	// PrometheusSharding spreads the targets of the prometheus receiver across the replicas without a
+	// TargetAllocator: each replica only scrapes the targets whose address hashes to its ordinal, modulo the
+	// replicas. It relies on the apps.kubernetes.io/pod-index label of the pods, set since Kubernetes 1.28.
+	// Only available when the mode=statefulset, and not along with the TargetAllocator.
+	// +optional
+	PrometheusSharding bool `json:"prometheusSharding,omitempty"`
 	// Mode represents how the collector should be deployed (deployment, daemonset, statefulset or sidecar)
 	// +optional
 	Mode Mode `json:"mode,omitempty"`
@@ -163,12 +236,25 @@ type OpenTelemetryCollectorSpec struct {
 	// the operator will not automatically create a ServiceAccount for the collector.
 	// +optional
 	ServiceAccount string `json:"serviceAccount,omitempty"`
+	// NameOverride replaces the name of the instance in the names of the resources created for the collector,
+	// which are named <nameOverride>-collector instead of <name>-collector.
+	// +optional
+	NameOverride string `json:"nameOverride,omitempty"`
+	// FullnameOverride replaces the names of the resources created for the collector, e.g. its workload,
+	// Service, ServiceAccount and ConfigMap. It takes precedence over the NameOverride.
+	// +optional
+	FullnameOverride string `json:"fullnameOverride,omitempty"`
 	// Image indicates the container image to use for the OpenTelemetry Collector.
 	// +optional
 	Image string `json:"image,omitempty"`
 	// UpgradeStrategy represents how the operator will handle upgrades to the CR when a newer version of the operator is deployed
 	// +optional
 	UpgradeStrategy UpgradeStrategy `json:"upgradeStrategy"`
+	// UpgradeWindows restricts the automatic upgrades, including the rollout of a new default image, to the given
+	// recurring periods of time. The upgrades are not restricted when no window is set.
+	// +optional
+	// +listType=atomic
+	UpgradeWindows []UpgradeWindow `json:"upgradeWindows,omitempty"`
 
 	// ImagePullPolicy indicates the pull policy to be used for retrieving the container image (Always, Never, IfNotPresent)
 	// +optional
@@ -185,7 +271,7 @@ type OpenTelemetryCollectorSpec struct {
 	// used to open additional ports that can't be inferred by the operator, like for custom receivers.
 	// +optional
 	// +listType=atomic
-	Ports []v1.ServicePort `json:"ports,omitempty"`
+	Ports []PortsSpec `json:"ports,omitempty"`
 	// ENV vars to set on the OpenTelemetry Collector's Pods. These can then in certain cases be
 	// consumed in the config file for the Collector.
 	// +optional
@@ -198,6 +284,23 @@ type OpenTelemetryCollectorSpec struct {
 	// +optional
 	// +listType=atomic
 	VolumeClaimTemplates []v1.PersistentVolumeClaim `json:"volumeClaimTemplates,omitempty"`
+	// PersistentVolumeClaimRetentionPolicy describes whether the persistent volume claims created from the
+	// volumeClaimTemplates are deleted or retained when the collector is scaled down or deleted.
+	// Only available when the mode=statefulset.
+	// +optional
+	PersistentVolumeClaimRetentionPolicy *appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy `json:"persistentVolumeClaimRetentionPolicy,omitempty"`
+	// StatefulSet configures how the replicas are started and addressed. Only available when the mode=statefulset.
+	// +optional
+	StatefulSet StatefulSetSpec `json:"statefulSet,omitempty"`
+	// DaemonSet configures how the pods of a node reach the collector of the node. Only available when the
+	// mode=daemonset.
+	// +optional
+	DaemonSet DaemonSetSpec `json:"daemonSet,omitempty"`
+	// GracefulScaleDown holds the scale down of the collector until the target allocator has reassigned the targets
+	// of the departing replicas, so that no target goes unscraped. Only available when the mode=statefulset and the
+	// target allocator is enabled, without autoscaler.
+	// +optional
+	GracefulScaleDown *GracefulScaleDownSpec `json:"gracefulScaleDown,omitempty"`
 	// Toleration to schedule OpenTelemetry Collector pods.
 	// This is only relevant to daemonset, statefulset, and deployment mode
 	// +optional
@@ -206,6 +309,17 @@ type OpenTelemetryCollectorSpec struct {
 	// +optional
 	// +listType=atomic
 	Volumes []v1.Volume `json:"volumes,omitempty"`
+	// SecretProviderClasses mounts the secrets of SecretProviderClasses of the secrets-store CSI driver in the
+	// Collector pods, so that the credentials used in the configuration can come from an external secret store.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	SecretProviderClasses []SecretProviderClassVolume `json:"secretProviderClasses,omitempty"`
+	// MountTLSSecrets mounts in the Collector pods the Secrets the TLS settings of the exporters read their files from,
+	// by naming convention: the files under /etc/otelcol/tls/<secret>/ are the keys of the Secret <secret> of the
+	// namespace of the OpenTelemetryCollector. The directories already mounted by VolumeMounts are left as they are.
+	// +optional
+	MountTLSSecrets bool `json:"mountTLSSecrets,omitempty"`
 	// Ingress is used to specify how OpenTelemetry Collector is exposed. This
 	// functionality is only available if one of the valid modes is set.
 	// Valid modes are: deployment, daemonset and statefulset.
@@ -222,9 +336,20 @@ type OpenTelemetryCollectorSpec struct {
 	// If specified, indicates the pod's scheduling constraints
 	// +optional
 	Affinity *v1.Affinity `json:"affinity,omitempty"`
+	// NodeArchitectures restricts the scheduling of the collector pods to the nodes of these CPU architectures, which
+	// are added to each of the required node affinity terms. When the registries are queried for the digests of the
+	// images, the webhook rejects the collector images of multiple platforms which aren't built for all of them.
+	// +optional
+	// +listType=set
+	NodeArchitectures []NodeArchitecture `json:"nodeArchitectures,omitempty"`
 	// Actions that the management system should take in response to container lifecycle events. Cannot be updated.
 	// +optional
 	Lifecycle *v1.Lifecycle `json:"lifecycle,omitempty"`
+	// Drain delays the stop of the collector with a preStop hook, so that the clients stop sending telemetry to the
+	// pod before its receivers shut down, avoiding connection resets during rollouts. The termination grace period
+	// defaults to the delay plus 30 seconds. Ignored when the Lifecycle sets a preStop hook, and in sidecar mode.
+	// +optional
+	Drain *DrainSpec `json:"drain,omitempty"`
 	// Duration in seconds the pod needs to terminate gracefully upon probe failure.
 	// +optional
 	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
@@ -232,6 +357,10 @@ type OpenTelemetryCollectorSpec struct {
 	// It is only effective when healthcheckextension is configured in the OpenTelemetry Collector pipeline.
 	// +optional
 	LivenessProbe *Probe `json:"livenessProbe,omitempty"`
+	// DisableProbes keeps the operator from adding the liveness probe to the Collector container, even when the
+	// healthcheckextension is configured, e.g. for distributions of the Collector which can't serve it.
+	// +optional
+	DisableProbes bool `json:"disableProbes,omitempty"`
 	// InitContainers allows injecting initContainers to the Collector's pod definition.
 	// These init containers can be used to fetch secrets for injection into the
 	// configuration from external sources, run added checks, etc. Any errors during the execution of
@@ -275,6 +404,11 @@ type OpenTelemetryCollectorSpec struct {
 	// object, which shall be mounted into the Collector Pods.
 	// Each ConfigMap will be added to the Collector's Deployments as a volume named `configmap-<configmap-name>`.
 	ConfigMaps []ConfigMapsSpec `json:"configmaps,omitempty"`
+
+	// DebugEndpoints exposes the endpoints of the pprof and remote_tap extensions enabled in the configuration
+	// through a dedicated Service, reachable only from an admin namespace.
+	// +optional
+	DebugEndpoints *DebugEndpointsSpec `json:"debugEndpoints,omitempty"`
 }
 
 // OpenTelemetryTargetAllocator defines the configurations for the Prometheus target allocator.
@@ -303,6 +437,21 @@ type OpenTelemetryTargetAllocator struct {
 	// the operator will not automatically create a ServiceAccount for the TargetAllocator.
 	// +optional
 	ServiceAccount string `json:"serviceAccount,omitempty"`
+	// IPFamilies is the list of IP families (e.g. IPv4, IPv6) assigned to the Services of the TargetAllocator.
+	// Leave it empty to use the cluster default.
+	// +optional
+	// +listType=atomic
+	IPFamilies []v1.IPFamily `json:"ipFamilies,omitempty"`
+	// IPFamilyPolicy represents the dual-stack-ness requested by the Services of the TargetAllocator,
+	// one of SingleStack, PreferDualStack or RequireDualStack.
+	// +optional
+	IPFamilyPolicy *v1.IPFamilyPolicy `json:"ipFamilyPolicy,omitempty"`
+	// LogLevel is the minimum level of the logs written by the TargetAllocator. Defaults to info.
+	// +optional
+	LogLevel LogLevel `json:"logLevel,omitempty"`
+	// LogFormat is the encoding of the logs written by the TargetAllocator, json or console. Defaults to json.
+	// +optional
+	LogFormat LogFormat `json:"logFormat,omitempty"`
 	// Image indicates the container image to use for the OpenTelemetry TargetAllocator.
 	// +optional
 	Image string `json:"image,omitempty"`
@@ -349,6 +498,80 @@ type OpenTelemetryTargetAllocatorPrometheusCR struct {
 	// ServiceMonitor's meta labels. The requirements are ANDed.
 	// +optional
 	ServiceMonitorSelector map[string]string `json:"serviceMonitorSelector,omitempty"`
+	// RemoteClusters are the clusters, besides the one running the TargetAllocator, whose ServiceMonitors and
+	// PodMonitors are retrieved. Their targets are allocated to the collectors along with the local ones, which
+	// must be able to reach them.
+	// +optional
+	// +listType=atomic
+	RemoteClusters []OpenTelemetryTargetAllocatorRemoteCluster `json:"remoteClusters,omitempty"`
+}
+
+// OpenTelemetryTargetAllocatorRemoteCluster is a remote cluster watched by the TargetAllocator.
+type OpenTelemetryTargetAllocatorRemoteCluster struct {
+	// Name of the cluster. It prefixes the names of the scrape jobs of the cluster and is set as the "cluster" label
+	// of its targets.
+	Name string `json:"name"`
+	// KubeconfigSecret selects the key of a Secret, in the namespace of the collector, holding the kubeconfig used
+	// to access the cluster.
+	KubeconfigSecret v1.SecretKeySelector `json:"kubeconfigSecret"`
+}
+
+// PortsSpec defines a port of the collector Services, along with the way it's exposed by the pods.
+type PortsSpec struct {
+	// HostPort exposes the port on the IP of the node, so that the applications of the node can send their
+	// telemetry to the collector of their node without running it on the network of the host.
+	// Only available when the mode=daemonset.
+	// +optional
+	HostPort int32 `json:"hostPort,omitempty"`
+	// ExposeOnService adds the port to the Services of the collector. Set it to false for the ports only opened on
+	// the container, e.g. for pprof, which mustn't be reachable through the Services. The port of the configuration
+	// with the same number is then left out of the Services as well.
+	//
+	// Default: true
+	// +optional
+	ExposeOnService *bool `json:"exposeOnService,omitempty"`
+
+	v1.ServicePort `json:",inline"`
+}
+
+// DebugEndpointsSpec defines how the endpoints of the debugging extensions of the collector are exposed.
+type DebugEndpointsSpec struct {
+	// Enabled indicates whether a Service and a NetworkPolicy are generated for the endpoints of the pprof and
+	// remote_tap extensions enabled in the configuration.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// AdminNamespace is the only namespace whose pods are allowed to reach the debugging endpoints.
+	// +optional
+	AdminNamespace string `json:"adminNamespace,omitempty"`
+	// ServiceAnnotations are added to the Service of the debugging endpoints only, typically to have an
+	// authenticating proxy or the service mesh enforce the authentication of the callers.
+	// +optional
+	ServiceAnnotations map[string]string `json:"serviceAnnotations,omitempty"`
+}
+
+// OpenTelemetryCollectorPrometheusCR defines how the ServiceMonitors and PodMonitors are resolved into the
+// configuration of the collector.
+type OpenTelemetryCollectorPrometheusCR struct {
+	// Enabled indicates whether the scrape configs of the prometheus receiver are generated from the
+	// ServiceMonitors and PodMonitors or not.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// Interval between consecutive scrapes of the endpoints which don't set their own interval.
+	//
+	// Default: "30s"
+	// +kubebuilder:default:="30s"
+	// +kubebuilder:validation:Format:=duration
+	ScrapeInterval *metav1.Duration `json:"scrapeInterval,omitempty"`
+	// PodMonitors to be resolved into scrape configs. An empty selector selects all the PodMonitors of the cluster.
+	// This is a map of {key,value} pairs. Each {key,value} in the map is going to exactly match a label in a
+	// PodMonitor's meta labels. The requirements are ANDed.
+	// +optional
+	PodMonitorSelector map[string]string `json:"podMonitorSelector,omitempty"`
+	// ServiceMonitors to be resolved into scrape configs. An empty selector selects all the ServiceMonitors of the cluster.
+	// This is a map of {key,value} pairs. Each {key,value} in the map is going to exactly match a label in a
+	// ServiceMonitor's meta labels. The requirements are ANDed.
+	// +optional
+	ServiceMonitorSelector map[string]string `json:"serviceMonitorSelector,omitempty"`
 }
 
 // ScaleSubresourceStatus defines the observed state of the OpenTelemetryCollector's
@@ -385,6 +608,11 @@ type OpenTelemetryCollectorStatus struct {
 	// +optional
 	Image string `json:"image,omitempty"`
 
+	// Endpoint is the address of the OTLP receiver of the collector behind its Service, e.g.
+	// otel-collector.observability.svc:4317, gRPC being preferred over HTTP. It's empty in the sidecar mode.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
 	// Messages about actions performed by the operator on this resource.
 	// +optional
 	// +listType=atomic
@@ -395,10 +623,28 @@ type OpenTelemetryCollectorStatus struct {
 	// +optional
 	// Deprecated: use "OpenTelemetryCollector.Status.Scale.Replicas" instead.
 	Replicas int32 `json:"replicas,omitempty"`
+
+	// Conditions represent the latest available observations of the resource state, e.g. Ready, Progressing and Degraded.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Rollout reports the progress of the canary rollout of the configuration, when the canary strategy is used.
+	// +optional
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+	// Components lists the objects generated by the operator for the OpenTelemetryCollector, e.g. its workload,
+	// Services and ConfigMaps, as of the last successful reconciliation.
+	// +optional
+	// +listType=map
+	// +listMapKey=kind
+	// +listMapKey=name
+	Components []ComponentStatus `json:"components,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:shortName=otelcol;otelcols
+// +kubebuilder:storageversion
 // +kubebuilder:subresource:status
 // +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.scale.replicas,selectorpath=.status.scale.selector
 // +kubebuilder:printcolumn:name="Mode",type="string",JSONPath=".spec.mode",description="Deployment Mode"
@@ -407,6 +653,8 @@ type OpenTelemetryCollectorStatus struct {
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="Image",type="string",JSONPath=".status.image"
 // +kubebuilder:printcolumn:name="Management",type="string",JSONPath=".spec.managementState",description="Management State"
+// +kubebuilder:printcolumn:name="Endpoint",type="string",JSONPath=".status.endpoint",description="OTLP Endpoint"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].reason",priority=1
 // +operator-sdk:csv:customresourcedefinitions:displayName="OpenTelemetry Collector"
 // This annotation provides a hint for OLM which resources are managed by OpenTelemetryCollector kind.
 // It's not mandatory to list all resources.
@@ -421,6 +669,16 @@ type OpenTelemetryCollector struct {
 	Status OpenTelemetryCollectorStatus `json:"status,omitempty"`
 }
 
+// GetNameOverride returns the name replacing the one of the instance in the names of the collector resources.
+func (c *OpenTelemetryCollector) GetNameOverride() string {
+	return c.Spec.NameOverride
+}
+
+// GetFullnameOverride returns the name replacing the names of the collector resources.
+func (c *OpenTelemetryCollector) GetFullnameOverride() string {
+	return c.Spec.FullnameOverride
+}
+
 // +kubebuilder:object:root=true
 
 // OpenTelemetryCollectorList contains a list of OpenTelemetryCollector.
@@ -490,6 +748,27 @@ type ObservabilitySpec struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Metrics Config"
 	Metrics MetricsConfigSpec `json:"metrics,omitempty"`
+
+	// Dashboards defines the Grafana dashboards generated for the operands.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	Dashboards DashboardsConfigSpec `json:"dashboards,omitempty"`
+}
+
+// DashboardsConfigSpec defines the Grafana dashboards generated for the OpenTelemetry Collector.
+type DashboardsConfigSpec struct {
+	// Enabled specifies if a ConfigMap holding a dashboard of the health of the OpenTelemetry Collector should be
+	// created, to be discovered by the dashboards sidecar of Grafana. The dashboard queries the metrics of the
+	// Collector scraped through its monitoring Service, e.g. by the ServiceMonitor created with enableMetrics.
+	// Ignored in the sidecar mode.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Labels are the labels of the ConfigMap the dashboards sidecar of Grafana discovers the dashboards with.
+	// Defaults to grafana_dashboard: "1", the default label of the sidecar.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
 // Probe defines the OpenTelemetry's pod probe config. Only Liveness probe is supported currently.
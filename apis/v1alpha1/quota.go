@@ -0,0 +1,207 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// containerResources are the resources of one of the containers of a pod generated for a custom resource.
+type containerResources struct {
+	name      string
+	resources corev1.ResourceRequirements
+}
+
+// quotaResource is the resource of the containers a resource of a ResourceQuota bounds.
+type quotaResource struct {
+	name   corev1.ResourceName
+	limits bool
+}
+
+// quotaResources are the resources of the ResourceQuotas bounding the compute resources of the containers, which
+// every container must then set.
+var quotaResources = map[corev1.ResourceName]quotaResource{
+	corev1.ResourceCPU:            {name: corev1.ResourceCPU},
+	corev1.ResourceMemory:         {name: corev1.ResourceMemory},
+	corev1.ResourceRequestsCPU:    {name: corev1.ResourceCPU},
+	corev1.ResourceRequestsMemory: {name: corev1.ResourceMemory},
+	corev1.ResourceLimitsCPU:      {name: corev1.ResourceCPU, limits: true},
+	corev1.ResourceLimitsMemory:   {name: corev1.ResourceMemory, limits: true},
+}
+
+// validateQuotas rejects the pods whose containers would be refused by the LimitRanges or the ResourceQuotas of the
+// namespace, e.g. because they set no limits, instead of leaving the generated workload without pods. The containers
+// get the defaults of the LimitRanges first, as they would when their pod is created.
+func validateQuotas(ctx context.Context, reader client.Reader, namespace, kind string, containers ...containerResources) error {
+	if reader == nil {
+		return nil
+	}
+	limitRanges := &corev1.LimitRangeList{}
+	if err := reader.List(ctx, limitRanges, client.InNamespace(namespace)); err != nil {
+		return fmt.Errorf("failed to list the LimitRanges of the namespace %s: %w", namespace, err)
+	}
+	quotas := &corev1.ResourceQuotaList{}
+	if err := reader.List(ctx, quotas, client.InNamespace(namespace)); err != nil {
+		return fmt.Errorf("failed to list the ResourceQuotas of the namespace %s: %w", namespace, err)
+	}
+
+	defaulted := make([]containerResources, 0, len(containers))
+	for _, container := range containers {
+		defaulted = append(defaulted, containerResources{
+			name:      container.name,
+			resources: withLimitRangeDefaults(container.resources, limitRanges.Items),
+		})
+	}
+
+	var errs []error
+	for _, limitRange := range limitRanges.Items {
+		for _, item := range limitRange.Spec.Limits {
+			if item.Type != corev1.LimitTypeContainer {
+				continue
+			}
+			for _, container := range defaulted {
+				errs = append(errs, validateLimitRangeItem(kind, limitRange.Name, item, container)...)
+			}
+		}
+	}
+	for _, quota := range quotas.Items {
+		errs = append(errs, validateResourceQuota(kind, quota, defaulted)...)
+	}
+	return errors.Join(errs...)
+}
+
+// withLimitRangeDefaults returns the resources of a container once defaulted: the requests it doesn't set default to
+// its limits, then the LimitRanges set their default limits and requests. The defaults of a LimitRange default to its
+// maximum, and the default requests to its default limits, or its minimum.
+func withLimitRangeDefaults(resources corev1.ResourceRequirements, limitRanges []corev1.LimitRange) corev1.ResourceRequirements {
+	defaulted := corev1.ResourceRequirements{Limits: corev1.ResourceList{}, Requests: corev1.ResourceList{}}
+	for name, quantity := range resources.Limits {
+		defaulted.Limits[name] = quantity.DeepCopy()
+		defaulted.Requests[name] = quantity.DeepCopy()
+	}
+	for name, quantity := range resources.Requests {
+		defaulted.Requests[name] = quantity.DeepCopy()
+	}
+	for _, limitRange := range limitRanges {
+		for _, item := range limitRange.Spec.Limits {
+			if item.Type != corev1.LimitTypeContainer {
+				continue
+			}
+			// the LimitRanges read from the API server are already defaulted this way, unlike the ones of the tests
+			setMissing(defaulted.Limits, item.Default)
+			setMissing(defaulted.Limits, item.Max)
+			setMissing(defaulted.Requests, item.DefaultRequest)
+			setMissing(defaulted.Requests, item.Default)
+			setMissing(defaulted.Requests, item.Max)
+			setMissing(defaulted.Requests, item.Min)
+		}
+	}
+	return defaulted
+}
+
+func setMissing(resources, defaults corev1.ResourceList) {
+	for name, quantity := range defaults {
+		if _, ok := resources[name]; !ok {
+			resources[name] = quantity.DeepCopy()
+		}
+	}
+}
+
+func validateLimitRangeItem(kind, limitRange string, item corev1.LimitRangeItem, container containerResources) []error {
+	var errs []error
+	for _, name := range sortedResourceNames(item.Max) {
+		limit, ok := container.resources.Limits[name]
+		maximum := item.Max[name]
+		switch {
+		case !ok:
+			errs = append(errs, fmt.Errorf("the %s of the %s must set a %s limit, required by the maximum of the LimitRange %s", container.name, kind, name, limitRange))
+		case limit.Cmp(maximum) > 0:
+			errs = append(errs, fmt.Errorf("the %s limit %s of the %s of the %s exceeds the maximum %s of the LimitRange %s", name, limit.String(), container.name, kind, maximum.String(), limitRange))
+		}
+	}
+	for _, name := range sortedResourceNames(item.Min) {
+		request, ok := container.resources.Requests[name]
+		minimum := item.Min[name]
+		switch {
+		case !ok:
+			errs = append(errs, fmt.Errorf("the %s of the %s must set a %s request, required by the minimum of the LimitRange %s", container.name, kind, name, limitRange))
+		case request.Cmp(minimum) < 0:
+			errs = append(errs, fmt.Errorf("the %s request %s of the %s of the %s is below the minimum %s of the LimitRange %s", name, request.String(), container.name, kind, minimum.String(), limitRange))
+		}
+	}
+	for _, name := range sortedResourceNames(item.MaxLimitRequestRatio) {
+		limit, hasLimit := container.resources.Limits[name]
+		request, hasRequest := container.resources.Requests[name]
+		if !hasLimit || !hasRequest || request.IsZero() {
+			errs = append(errs, fmt.Errorf("the %s of the %s must set a %s limit and request, required by the maximum limit to request ratio of the LimitRange %s", container.name, kind, name, limitRange))
+			continue
+		}
+		maxRatio := item.MaxLimitRequestRatio[name]
+		if float64(limit.MilliValue())/float64(request.MilliValue()) > maxRatio.AsApproximateFloat64() {
+			errs = append(errs, fmt.Errorf("the %s limit %s of the %s of the %s exceeds %s times its request %s, the maximum ratio of the LimitRange %s", name, limit.String(), container.name, kind, maxRatio.String(), request.String(), limitRange))
+		}
+	}
+	return errs
+}
+
+func validateResourceQuota(kind string, quota corev1.ResourceQuota, containers []containerResources) []error {
+	var errs []error
+	for _, hardName := range sortedResourceNames(quota.Spec.Hard) {
+		bounded, ok := quotaResources[hardName]
+		if !ok {
+			continue
+		}
+		kindOfValue := "request"
+		if bounded.limits {
+			kindOfValue = "limit"
+		}
+		var total resource.Quantity
+		missing := false
+		for _, container := range containers {
+			values := container.resources.Requests
+			if bounded.limits {
+				values = container.resources.Limits
+			}
+			quantity, ok := values[bounded.name]
+			if !ok {
+				errs = append(errs, fmt.Errorf("the %s of the %s must set a %s %s, required by the %s quota of the ResourceQuota %s", container.name, kind, bounded.name, kindOfValue, hardName, quota.Name))
+				missing = true
+				continue
+			}
+			total.Add(quantity)
+		}
+		hard := quota.Spec.Hard[hardName]
+		if !missing && total.Cmp(hard) > 0 {
+			errs = append(errs, fmt.Errorf("the %s %ss %s of a pod of the %s exceed the %s quota %s of the ResourceQuota %s, the pod can never be created", bounded.name, kindOfValue, total.String(), kind, hardName, hard.String(), quota.Name))
+		}
+	}
+	return errs
+}
+
+func sortedResourceNames(resources corev1.ResourceList) []corev1.ResourceName {
+	names := make([]corev1.ResourceName, 0, len(resources))
+	for name := range resources {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+)
+
+// containerImage is the image of one of the containers of a pod generated for a custom resource.
+type containerImage struct {
+	name  string
+	image string
+}
+
+// validateImageRegistries rejects the images which aren't pulled from one of the allowed registries, when some are
+// set. The images left empty, or set to the default images of the operator, pinned to a digest or not, are allowed:
+// they are the ones the operator is configured with.
+func validateImageRegistries(kind string, allowed []string, defaults map[string]bool, images ...containerImage) error {
+	if len(allowed) == 0 {
+		return nil
+	}
+	for _, image := range images {
+		tagged, _, _ := strings.Cut(image.image, "@")
+		if image.image == "" || defaults[image.image] || defaults[tagged] || imageFromRegistries(image.image, allowed) {
+			continue
+		}
+		return fmt.Errorf("the %s %s uses the image %s, which isn't pulled from one of the allowed registries: %s", kind, image.name, image.image, strings.Join(allowed, ", "))
+	}
+	return nil
+}
+
+// imageFromRegistries returns whether the image is pulled from one of the registries, which may include a path within
+// the registry, e.g. "registry.example.com/mirror".
+func imageFromRegistries(image string, registries []string) bool {
+	image = normalizedImage(image)
+	for _, registry := range registries {
+		if strings.HasPrefix(image, registry+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizedImage returns the image along with the registry it's pulled from, resolving the images without one to
+// Docker Hub the way the container runtimes do, e.g. "busybox" to "docker.io/library/busybox".
+func normalizedImage(image string) string {
+	first, _, found := strings.Cut(image, "/")
+	if found && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		return image
+	}
+	if !found {
+		image = "library/" + image
+	}
+	return "docker.io/" + image
+}
@@ -0,0 +1,29 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+// GracefulScaleDownSpec defines how the replicas of a StatefulSet collector are relieved of their scrape targets
+// before they're removed.
+type GracefulScaleDownSpec struct {
+	// Enabled holds the scale down of the StatefulSet until the target allocator has reassigned the targets of the
+	// departing replicas to the remaining ones.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// TimeoutSeconds is how long the scale down is held at most, after which the departing replicas are removed even
+	// though targets are still assigned to them. Defaults to 300.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+// secretsStoreMountPathPrefix is the directory the secrets of the SecretProviderClasses are mounted in by default.
+const secretsStoreMountPathPrefix = "/var/run/secrets-store"
+
+// SecretProviderClassVolume mounts the secrets of a SecretProviderClass of the secrets-store CSI driver, e.g. the
+// API keys of the exporters stored in Vault or in AWS Secrets Manager.
+type SecretProviderClassVolume struct {
+	// Name of the SecretProviderClass, in the namespace of the resource.
+	// +required
+	Name string `json:"name"`
+	// MountPath is the directory the secrets are mounted in. Defaults to /var/run/secrets-store/<name>.
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
+	// NodePublishSecretRef is the name of the Secret holding the credentials the provider uses to fetch the secrets,
+	// when the provider doesn't use the workload identity of the pods.
+	// +optional
+	NodePublishSecretRef string `json:"nodePublishSecretRef,omitempty"`
+}
+
+// GetMountPath returns the directory the secrets of the SecretProviderClass are mounted in.
+func (s SecretProviderClassVolume) GetMountPath() string {
+	if s.MountPath != "" {
+		return s.MountPath
+	}
+	return path.Join(secretsStoreMountPathPrefix, s.Name)
+}
+
+// validateSecretProviderClasses checks that the SecretProviderClasses can be mounted along with the volumes and
+// the volume mounts of the resource.
+func validateSecretProviderClasses(classes []SecretProviderClassVolume, volumes []v1.Volume, volumeMounts []v1.VolumeMount) error {
+	volumeNames := map[string]bool{}
+	for _, volume := range volumes {
+		volumeNames[volume.Name] = true
+	}
+	mountPaths := map[string]bool{}
+	for _, mount := range volumeMounts {
+		mountPaths[path.Clean(mount.MountPath)] = true
+	}
+	for _, class := range classes {
+		if errs := validation.IsDNS1123Subdomain(class.Name); len(errs) > 0 {
+			return fmt.Errorf("the secretProviderClasses name '%s' is incorrect: %s", class.Name, strings.Join(errs, ", "))
+		}
+		if class.NodePublishSecretRef != "" {
+			if errs := validation.IsDNS1123Subdomain(class.NodePublishSecretRef); len(errs) > 0 {
+				return fmt.Errorf("the secretProviderClasses %s nodePublishSecretRef '%s' is incorrect: %s", class.Name, class.NodePublishSecretRef, strings.Join(errs, ", "))
+			}
+		}
+		volumeName := naming.SecretsStoreVolume(class.Name)
+		if volumeNames[volumeName] {
+			return fmt.Errorf("the volume %s of the secretProviderClasses %s is already defined", volumeName, class.Name)
+		}
+		volumeNames[volumeName] = true
+		mountPath := class.GetMountPath()
+		if !path.IsAbs(mountPath) {
+			return fmt.Errorf("the secretProviderClasses %s mountPath '%s' must be an absolute path", class.Name, mountPath)
+		}
+		if mountPaths[path.Clean(mountPath)] {
+			return fmt.Errorf("the secretProviderClasses %s mountPath '%s' is already mounted", class.Name, mountPath)
+		}
+		mountPaths[path.Clean(mountPath)] = true
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+type (
+	// ServiceMeshType represents the service mesh the pods of the collector are annotated for.
+	// +kubebuilder:validation:Enum=istio;linkerd;none
+	ServiceMeshType string
+)
+
+const (
+	// ServiceMeshTypeIstio annotates the pods for the Istio sidecar proxy.
+	ServiceMeshTypeIstio ServiceMeshType = "istio"
+
+	// ServiceMeshTypeLinkerd annotates the pods for the Linkerd proxy.
+	ServiceMeshTypeLinkerd ServiceMeshType = "linkerd"
+
+	// ServiceMeshTypeNone doesn't annotate the pods for any service mesh.
+	ServiceMeshTypeNone ServiceMeshType = "none"
+)
+
+// ServiceMeshSpec defines how the pods of the collector are integrated with the service mesh of the cluster.
+type ServiceMeshSpec struct {
+	// Type of the service mesh the pods of the collector are annotated for, or none not to annotate them.
+	// When empty, the service mesh installed in the cluster is detected by the operator.
+	// +optional
+	Type ServiceMeshType `json:"type,omitempty"`
+	// ExcludeReceiverPorts excludes the ports of the receivers from the inbound traffic intercepted by the proxy of
+	// the service mesh, so that the telemetry sent by the workloads outside of the mesh isn't dropped by the proxy.
+	// Defaults to true.
+	// +optional
+	ExcludeReceiverPorts *bool `json:"excludeReceiverPorts,omitempty"`
+	// HoldApplicationUntilProxyStarts delays the start of the collector until the proxy injected by the service mesh
+	// is ready, so that the exporters don't fail to reach their backends on startup.
+	// +optional
+	HoldApplicationUntilProxyStarts bool `json:"holdApplicationUntilProxyStarts,omitempty"`
+}
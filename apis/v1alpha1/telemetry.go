@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+type (
+	// TelemetryMetricsLevel represents the verbosity of the metrics the collector emits about itself.
+	// +kubebuilder:validation:Enum=none;basic;normal;detailed
+	TelemetryMetricsLevel string
+
+	// TelemetryLogLevel represents the minimum level of the logs written by the collector.
+	// +kubebuilder:validation:Enum=debug;info;warn;error
+	TelemetryLogLevel string
+)
+
+// TelemetrySpec defines the internal telemetry of the collector. The fields set here are merged into the
+// service::telemetry section of the configuration, overriding the values set there.
+type TelemetrySpec struct {
+	// Metrics configures the metrics the collector emits about itself.
+	// +optional
+	Metrics TelemetryMetricsSpec `json:"metrics,omitempty"`
+	// Logs configures the logs written by the collector.
+	// +optional
+	Logs TelemetryLogsSpec `json:"logs,omitempty"`
+}
+
+// TelemetryMetricsSpec defines the metrics the collector emits about itself.
+type TelemetryMetricsSpec struct {
+	// Level is the verbosity of the metrics, one of none, basic, normal or detailed.
+	// +optional
+	Level TelemetryMetricsLevel `json:"level,omitempty"`
+	// Port the metrics are exposed on, which is also the port of the monitoring Service and of the ServiceMonitor.
+	// Defaults to the port of the address set in the configuration, or 8888.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port,omitempty"`
+}
+
+// TelemetryLogsSpec defines the logs written by the collector.
+type TelemetryLogsSpec struct {
+	// Level is the minimum level of the logs, one of debug, info, warn or error.
+	// +optional
+	Level TelemetryLogLevel `json:"level,omitempty"`
+	// Encoding of the logs, either json or console.
+	// +optional
+	Encoding LogFormat `json:"encoding,omitempty"`
+}
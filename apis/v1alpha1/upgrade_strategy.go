@@ -14,6 +14,14 @@
 
 package v1alpha1
 
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/cronexpr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 type (
 	// UpgradeStrategy represents how the operator will handle upgrades to the CR when a newer version of the operator is deployed
 	// +kubebuilder:validation:Enum=automatic;none
@@ -27,3 +35,50 @@ const (
 	// UpgradeStrategyNone specifies that the operator will not apply any upgrades to the CR.
 	UpgradeStrategyNone UpgradeStrategy = "none"
 )
+
+// UpgradeWindow is a recurring period of time in which the operator may automatically upgrade an instance.
+type UpgradeWindow struct {
+	// Schedule is a cron expression, in the "minute hour day-of-month month day-of-week" format, telling when the
+	// window opens. The times are in UTC.
+	// +required
+	Schedule string `json:"schedule"`
+	// Duration is how long the window stays open.
+	// +required
+	Duration metav1.Duration `json:"duration"`
+}
+
+// Validate checks that the window has a valid schedule and a positive duration.
+func (w UpgradeWindow) Validate() error {
+	if _, err := cronexpr.Parse(w.Schedule); err != nil {
+		return fmt.Errorf("invalid upgrade window schedule %q: %w", w.Schedule, err)
+	}
+	if w.Duration.Duration <= 0 {
+		return fmt.Errorf("the upgrade window with the schedule %q must have a positive duration", w.Schedule)
+	}
+	return nil
+}
+
+// IsOpen tells whether the window is open at the given time, i.e. it opened less than its duration ago.
+func (w UpgradeWindow) IsOpen(now time.Time) bool {
+	expr, err := cronexpr.Parse(w.Schedule)
+	if err != nil {
+		return false
+	}
+	// cronexpr only returns the start times after the given time, the earliest start that can still be open is looked up
+	start := expr.Next(now.UTC().Add(-w.Duration.Duration))
+	return !start.IsZero() && !start.After(now.UTC())
+}
+
+// UpgradeAllowed tells whether automatic upgrades may be performed at the given time, which is the case when no
+// upgrade windows are defined, or when one of them is open.
+func UpgradeAllowed(windows []UpgradeWindow, now time.Time) bool {
+	if len(windows) == 0 {
+		return true
+	}
+	for _, w := range windows {
+		if w.IsOpen(now) {
+			return true
+		}
+	}
+	return false
+}
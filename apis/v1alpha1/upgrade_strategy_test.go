@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestUpgradeWindowIsOpen(t *testing.T) {
+	// every day at 02:00 UTC, for two hours
+	window := UpgradeWindow{Schedule: "0 2 * * *", Duration: metav1.Duration{Duration: 2 * time.Hour}}
+
+	for _, tt := range []struct {
+		name string
+		now  time.Time
+		open bool
+	}{
+		{name: "before the window", now: time.Date(2023, 11, 20, 1, 59, 0, 0, time.UTC), open: false},
+		{name: "at the start of the window", now: time.Date(2023, 11, 20, 2, 0, 0, 0, time.UTC), open: true},
+		{name: "within the window", now: time.Date(2023, 11, 20, 3, 30, 0, 0, time.UTC), open: true},
+		{name: "after the window", now: time.Date(2023, 11, 20, 4, 1, 0, 0, time.UTC), open: false},
+		{name: "other time zone", now: time.Date(2023, 11, 20, 3, 0, 0, 0, time.FixedZone("CET", 3600)), open: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.open, window.IsOpen(tt.now))
+		})
+	}
+}
+
+func TestUpgradeAllowed(t *testing.T) {
+	now := time.Date(2023, 11, 20, 12, 0, 0, 0, time.UTC)
+	night := UpgradeWindow{Schedule: "0 2 * * *", Duration: metav1.Duration{Duration: time.Hour}}
+	noon := UpgradeWindow{Schedule: "30 11 * * *", Duration: metav1.Duration{Duration: time.Hour}}
+
+	assert.True(t, UpgradeAllowed(nil, now))
+	assert.False(t, UpgradeAllowed([]UpgradeWindow{night}, now))
+	assert.True(t, UpgradeAllowed([]UpgradeWindow{night, noon}, now))
+}
+
+func TestUpgradeWindowValidate(t *testing.T) {
+	assert.NoError(t, UpgradeWindow{Schedule: "0 2 * * 6", Duration: metav1.Duration{Duration: time.Hour}}.Validate())
+	assert.Error(t, UpgradeWindow{Schedule: "0 25 * * *", Duration: metav1.Duration{Duration: time.Hour}}.Validate())
+	assert.Error(t, UpgradeWindow{Schedule: "0 2 * * *"}.Validate())
+}
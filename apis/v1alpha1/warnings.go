@@ -0,0 +1,336 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"net"
+	"path"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/yaml"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+// deprecatedComponents are the collector components which are deprecated or were removed from the collector
+// distributions, by kind and type, along with the way to replace them.
+var deprecatedComponents = map[string]map[string]string{
+	"exporters": {
+		"logging":       "it is deprecated, use the debug exporter instead",
+		"jaeger":        "it was removed in v0.85.0, Jaeger accepts OTLP natively, use the otlp exporter instead",
+		"jaeger_thrift": "it was removed in v0.85.0, Jaeger accepts OTLP natively, use the otlphttp exporter instead",
+	},
+	"processors": {
+		"spanmetrics":  "it is deprecated, use the spanmetrics connector instead",
+		"servicegraph": "it is deprecated, use the servicegraph connector instead",
+	},
+}
+
+// localhostNames are the hosts which can't be reached from outside the pod of the collector.
+var localhostNames = map[string]bool{"localhost": true, "127.0.0.1": true, "::1": true}
+
+// collectorWarnings returns the warnings about the deprecated components and the risky settings of the collector,
+// which are accepted but likely to break or to behave unexpectedly, e.g. on the next upgrade.
+func collectorWarnings(r *OpenTelemetryCollector) admission.Warnings {
+	warnings := admission.Warnings{}
+	if w := imageWarning(r.Spec.Image); w != "" {
+		warnings = append(warnings, w)
+	}
+
+	cfg := map[string]interface{}{}
+	// invalid configurations are reported by the Degraded condition, not by the webhook
+	if err := yaml.Unmarshal([]byte(r.Spec.Config), &cfg); err == nil {
+		for _, kind := range []string{"receivers", "processors", "exporters"} {
+			components, _ := cfg[kind].(map[string]interface{})
+			for _, id := range sortedKeys(components) {
+				componentType, _, _ := strings.Cut(id, "/")
+				if reason, deprecated := deprecatedComponents[kind][componentType]; deprecated {
+					warnings = append(warnings, fmt.Sprintf("the %s %s is used: %s", strings.TrimSuffix(kind, "s"), id, reason))
+				}
+				if kind == "receivers" && r.Spec.Mode != ModeSidecar {
+					if endpoint := localhostEndpoint(components[id]); endpoint != "" {
+						warnings = append(warnings, fmt.Sprintf("the receiver %s listens on %s, which can't be reached from other pods", id, endpoint))
+					}
+				}
+			}
+		}
+		warnings = append(warnings, pipelineWarnings(cfg)...)
+	}
+
+	warnings = append(warnings, tlsFileWarnings(r)...)
+	warnings = append(warnings, ignoredFieldWarnings(r)...)
+
+	if r.Spec.PrometheusCR.Enabled && !featuregate.PrometheusOperatorIsAvailable.IsEnabled() {
+		warnings = append(warnings, fmt.Sprintf("prometheusCR is enabled, but the ServiceMonitors and PodMonitors are ignored while the %s feature gate is disabled", featuregate.PrometheusOperatorIsAvailable.ID()))
+	}
+	if r.Spec.PrometheusCR.Enabled {
+		if len(r.Spec.PrometheusCR.ServiceMonitorSelector) == 0 {
+			warnings = append(warnings, "prometheusCR.serviceMonitorSelector is empty, all the ServiceMonitors of the cluster are resolved into scrape configs")
+		}
+		if len(r.Spec.PrometheusCR.PodMonitorSelector) == 0 {
+			warnings = append(warnings, "prometheusCR.podMonitorSelector is empty, all the PodMonitors of the cluster are resolved into scrape configs")
+		}
+	}
+
+	if r.Spec.Autoscaler != nil && r.Spec.Autoscaler.MaxReplicas != nil && r.Spec.Autoscaler.TargetCPUUtilization != nil {
+		if _, ok := r.Spec.Resources.Requests[corev1.ResourceCPU]; !ok {
+			warnings = append(warnings, "the autoscaler targets a CPU utilization, which can't be computed without CPU requests")
+		}
+	}
+	return warnings
+}
+
+// pipelineWarnings lints the pipelines of the configuration: the components which aren't used by any pipeline are
+// ignored by the collector, while the pipelines referencing undefined components make it fail on startup.
+// Connectors are both the exporter of a pipeline and the receiver of another one.
+func pipelineWarnings(cfg map[string]interface{}) admission.Warnings {
+	service, _ := cfg["service"].(map[string]interface{})
+	pipelines, _ := service["pipelines"].(map[string]interface{})
+	if len(pipelines) == 0 {
+		return nil
+	}
+
+	warnings := admission.Warnings{}
+	connectors, _ := cfg["connectors"].(map[string]interface{})
+	used := map[string]map[string]bool{}
+	for _, kind := range []string{"receivers", "processors", "exporters", "connectors"} {
+		used[kind] = map[string]bool{}
+	}
+	for _, name := range sortedKeys(pipelines) {
+		pipeline, _ := pipelines[name].(map[string]interface{})
+		for _, kind := range []string{"receivers", "processors", "exporters"} {
+			components, _ := cfg[kind].(map[string]interface{})
+			ids, _ := pipeline[kind].([]interface{})
+			for _, item := range ids {
+				id := fmt.Sprint(item)
+				if _, ok := components[id]; ok {
+					used[kind][id] = true
+					continue
+				}
+				if _, ok := connectors[id]; ok && kind != "processors" {
+					used["connectors"][id] = true
+					continue
+				}
+				warnings = append(warnings, fmt.Sprintf("the pipeline %s references the %s %s, which isn't defined", name, strings.TrimSuffix(kind, "s"), id))
+			}
+		}
+	}
+	for _, kind := range []string{"receivers", "processors", "exporters", "connectors"} {
+		components, _ := cfg[kind].(map[string]interface{})
+		for _, id := range sortedKeys(components) {
+			if !used[kind][id] {
+				warnings = append(warnings, fmt.Sprintf("the %s %s isn't used by any pipeline", strings.TrimSuffix(kind, "s"), id))
+			}
+		}
+	}
+
+	extensions, _ := cfg["extensions"].(map[string]interface{})
+	enabled := map[string]bool{}
+	ids, _ := service["extensions"].([]interface{})
+	for _, item := range ids {
+		id := fmt.Sprint(item)
+		enabled[id] = true
+		if _, ok := extensions[id]; !ok {
+			warnings = append(warnings, fmt.Sprintf("the service references the extension %s, which isn't defined", id))
+		}
+	}
+	for _, id := range sortedKeys(extensions) {
+		if !enabled[id] {
+			warnings = append(warnings, fmt.Sprintf("the extension %s isn't enabled in the service", id))
+		}
+	}
+	return warnings
+}
+
+// tlsFileWarnings warns about the files of the TLS settings of the exporters which aren't in any of the volumes mounted
+// in the collector: unless its image provides them, the collector fails on startup.
+func tlsFileWarnings(r *OpenTelemetryCollector) admission.Warnings {
+	cfg, err := adapters.ConfigFromString(r.Spec.Config)
+	if err != nil {
+		return nil
+	}
+	var mountPaths []string
+	for _, mount := range r.Spec.VolumeMounts {
+		mountPaths = append(mountPaths, mount.MountPath)
+	}
+	for _, class := range r.Spec.SecretProviderClasses {
+		mountPaths = append(mountPaths, class.GetMountPath())
+	}
+	for _, configMap := range r.Spec.ConfigMaps {
+		mountPaths = append(mountPaths, path.Join("/var/conf", configMap.MountPath, naming.ConfigMapExtra(configMap.Name)))
+	}
+
+	warnings := admission.Warnings{}
+	for _, file := range adapters.ConfigToTLSFiles(cfg) {
+		if _, ok := file.Secret(); ok && r.Spec.MountTLSSecrets {
+			continue
+		}
+		if !mounted(file.Path, mountPaths) {
+			warnings = append(warnings, fmt.Sprintf("the %s %s of the exporter %s isn't in any of the volumes mounted in the collector", file.Setting, file.Path, file.Exporter))
+		}
+	}
+	return warnings
+}
+
+// mounted returns whether the file is in one of the mount paths.
+func mounted(file string, mountPaths []string) bool {
+	file = path.Clean(file)
+	for _, mountPath := range mountPaths {
+		mountPath = path.Clean(mountPath)
+		if mountPath == "/" || file == mountPath || strings.HasPrefix(file, mountPath+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// ignoredFieldWarnings warns about the fields which have no effect in the mode of the collector. Unlike the fields
+// rejected by the webhook, they used to be accepted, so rejecting them would prevent updating existing collectors.
+func ignoredFieldWarnings(r *OpenTelemetryCollector) admission.Warnings {
+	var ignored []string
+	if r.Spec.Replicas != nil && *r.Spec.Replicas > 1 && (r.Spec.Mode == ModeDaemonSet || r.Spec.Mode == ModeSidecar) {
+		ignored = append(ignored, "replicas")
+	}
+	if r.Spec.Mode == ModeSidecar {
+		if len(r.Spec.NodeSelector) > 0 {
+			ignored = append(ignored, "nodeSelector")
+		}
+		if len(r.Spec.TopologySpreadConstraints) > 0 {
+			ignored = append(ignored, "topologySpreadConstraints")
+		}
+		if r.Spec.PodSecurityContext != nil {
+			ignored = append(ignored, "podSecurityContext")
+		}
+		if len(r.Spec.Annotations) > 0 {
+			ignored = append(ignored, "annotations")
+		}
+		if len(r.Spec.PodAnnotations) > 0 {
+			ignored = append(ignored, "podAnnotations")
+		}
+		if r.Spec.TerminationGracePeriodSeconds != nil {
+			ignored = append(ignored, "terminationGracePeriodSeconds")
+		}
+		// the sidecar shares the network of the pod it's injected into
+		if r.Spec.HostNetwork {
+			ignored = append(ignored, "hostNetwork")
+		}
+		if r.Spec.Ingress.Type != "" {
+			ignored = append(ignored, "ingress")
+		}
+		if r.Spec.ServiceMesh != (ServiceMeshSpec{}) {
+			ignored = append(ignored, "serviceMesh")
+		}
+		if r.Spec.Drain != nil {
+			ignored = append(ignored, "drain")
+		}
+		if r.Spec.Observability.Dashboards.Enabled {
+			ignored = append(ignored, "observability.dashboards")
+		}
+		if len(r.Spec.NodeArchitectures) > 0 {
+			ignored = append(ignored, "nodeArchitectures")
+		}
+	}
+	if r.Spec.Mode != ModeStatefulSet && r.Spec.StatefulSet != (StatefulSetSpec{}) {
+		ignored = append(ignored, "statefulSet")
+	}
+	if r.Spec.Mode != ModeDaemonSet && r.Spec.DaemonSet != (DaemonSetSpec{}) {
+		ignored = append(ignored, "daemonSet")
+	}
+	if r.Spec.Mode != ModeStatefulSet && r.Spec.GracefulScaleDown != nil {
+		ignored = append(ignored, "gracefulScaleDown")
+	}
+	warnings := admission.Warnings{}
+	for _, field := range ignored {
+		warnings = append(warnings, fmt.Sprintf("the attribute '%s' has no effect in the %s mode", field, r.Spec.Mode))
+	}
+	if r.Spec.Drain != nil && r.Spec.Mode != ModeSidecar && r.Spec.Lifecycle != nil && r.Spec.Lifecycle.PreStop != nil {
+		warnings = append(warnings, "the attribute 'drain' has no effect as the lifecycle sets a preStop hook")
+	}
+	if r.Spec.Mode == ModeStatefulSet && r.Spec.GracefulScaleDown != nil && r.Spec.GracefulScaleDown.Enabled {
+		if !r.Spec.TargetAllocator.Enabled {
+			warnings = append(warnings, "the attribute 'gracefulScaleDown' has no effect as the target allocator is disabled")
+		} else if r.Spec.Autoscaler != nil {
+			warnings = append(warnings, "the attribute 'gracefulScaleDown' has no effect as the replicas are managed by the autoscaler")
+		}
+	}
+	if r.Spec.DisableProbes && r.Spec.LivenessProbe != nil {
+		warnings = append(warnings, "the attribute 'livenessProbe' has no effect as the probes are disabled")
+	}
+	return warnings
+}
+
+// opAMPBridgeWarnings returns the warnings about the risky settings of the OpAMP bridge.
+func opAMPBridgeWarnings(r *OpAMPBridge) admission.Warnings {
+	warnings := admission.Warnings{}
+	if w := imageWarning(r.Spec.Image); w != "" {
+		warnings = append(warnings, w)
+	}
+	if r.Spec.Capabilities[OpAMPBridgeCapabilityAcceptsRemoteConfig] && len(r.Spec.ComponentsAllowed) == 0 {
+		warnings = append(warnings, "the bridge accepts remote configurations without restricting the components allowed, the OpAMP server may enable any of them")
+	}
+	return warnings
+}
+
+// imageWarning warns about images which aren't pinned to a version, as they may change when the pods are recreated.
+func imageWarning(image string) string {
+	if image == "" || strings.Contains(image, "@") {
+		return ""
+	}
+	name := image[strings.LastIndex(image, "/")+1:]
+	if _, tag, found := strings.Cut(name, ":"); found && tag != "latest" {
+		return ""
+	}
+	return fmt.Sprintf("the image %s isn't pinned to a version, the pods may run another version once they are recreated", image)
+}
+
+// localhostEndpoint returns the first endpoint of the component settings bound to localhost, if any.
+func localhostEndpoint(settings interface{}) string {
+	switch s := settings.(type) {
+	case map[string]interface{}:
+		for _, key := range sortedKeys(s) {
+			if endpoint, ok := s[key].(string); ok && key == "endpoint" {
+				if host, _, err := net.SplitHostPort(endpoint); err == nil && localhostNames[host] {
+					return endpoint
+				}
+				continue
+			}
+			if endpoint := localhostEndpoint(s[key]); endpoint != "" {
+				return endpoint
+			}
+		}
+	case []interface{}:
+		for _, item := range s {
+			if endpoint := localhostEndpoint(item); endpoint != "" {
+				return endpoint
+			}
+		}
+	}
+	return ""
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
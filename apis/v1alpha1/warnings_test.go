@@ -0,0 +1,354 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestCollectorWarnings(t *testing.T) {
+	five := int32(5)
+	ninety := int32(90)
+	for _, tt := range []struct {
+		desc     string
+		spec     OpenTelemetryCollectorSpec
+		expected []string
+	}{
+		{
+			desc: "no warnings",
+			spec: OpenTelemetryCollectorSpec{
+				Mode:  ModeDeployment,
+				Image: "ghcr.io/open-telemetry/opentelemetry-collector-releases/opentelemetry-collector:0.89.0",
+				Config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: 0.0.0.0:4317
+exporters:
+  debug:
+`,
+			},
+		},
+		{
+			desc: "deprecated components",
+			spec: OpenTelemetryCollectorSpec{
+				Config: `processors:
+  spanmetrics:
+exporters:
+  logging:
+  jaeger/backend:
+`,
+			},
+			expected: []string{
+				"the processor spanmetrics is used: it is deprecated, use the spanmetrics connector instead",
+				"the exporter jaeger/backend is used: it was removed in v0.85.0, Jaeger accepts OTLP natively, use the otlp exporter instead",
+				"the exporter logging is used: it is deprecated, use the debug exporter instead",
+			},
+		},
+		{
+			desc: "pipelines",
+			spec: OpenTelemetryCollectorSpec{
+				Mode:  ModeDeployment,
+				Image: "ghcr.io/open-telemetry/opentelemetry-collector-releases/opentelemetry-collector:0.89.0",
+				Config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+  zipkin:
+processors:
+  batch:
+  memory_limiter:
+exporters:
+  debug:
+connectors:
+  count:
+  forward:
+extensions:
+  health_check:
+  pprof:
+service:
+  extensions: [health_check, zpages]
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [batch, filter]
+      exporters: [debug, count]
+    metrics:
+      receivers: [count, prometheus]
+      exporters: [debug]
+`,
+			},
+			expected: []string{
+				"the pipeline metrics references the receiver prometheus, which isn't defined",
+				"the pipeline traces references the processor filter, which isn't defined",
+				"the receiver zipkin isn't used by any pipeline",
+				"the processor memory_limiter isn't used by any pipeline",
+				"the connector forward isn't used by any pipeline",
+				"the service references the extension zpages, which isn't defined",
+				"the extension pprof isn't enabled in the service",
+			},
+		},
+		{
+			desc: "receiver listening on localhost",
+			spec: OpenTelemetryCollectorSpec{
+				Mode: ModeDeployment,
+				Config: `receivers:
+  otlp:
+    protocols:
+      http:
+        endpoint: localhost:4318
+`,
+			},
+			expected: []string{"the receiver otlp listens on localhost:4318, which can't be reached from other pods"},
+		},
+		{
+			desc: "sidecar listening on localhost",
+			spec: OpenTelemetryCollectorSpec{
+				Mode: ModeSidecar,
+				Config: `receivers:
+  otlp:
+    protocols:
+      http:
+        endpoint: localhost:4318
+`,
+			},
+		},
+		{
+			desc: "unpinned images",
+			spec: OpenTelemetryCollectorSpec{
+				Image: "localhost:5000/collector",
+			},
+			expected: []string{"the image localhost:5000/collector isn't pinned to a version, the pods may run another version once they are recreated"},
+		},
+		{
+			desc: "latest image",
+			spec: OpenTelemetryCollectorSpec{
+				Image: "collector:latest",
+			},
+			expected: []string{"the image collector:latest isn't pinned to a version, the pods may run another version once they are recreated"},
+		},
+		{
+			desc: "image digest",
+			spec: OpenTelemetryCollectorSpec{
+				Image: "collector@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+			},
+		},
+		{
+			desc: "fields ignored by sidecars",
+			spec: OpenTelemetryCollectorSpec{
+				Mode:         ModeSidecar,
+				Replicas:     &five,
+				NodeSelector: map[string]string{"disk": "ssd"},
+				ServiceMesh:  ServiceMeshSpec{Type: ServiceMeshTypeIstio},
+				Drain:        &DrainSpec{DelaySeconds: 5},
+				Annotations:  map[string]string{"reloader.stakater.com/auto": "true"},
+				HostNetwork:  true,
+				Ingress:      Ingress{Type: IngressTypeRoute},
+				Observability: ObservabilitySpec{
+					Dashboards: DashboardsConfigSpec{Enabled: true},
+				},
+			},
+			expected: []string{
+				"the attribute 'replicas' has no effect in the sidecar mode",
+				"the attribute 'nodeSelector' has no effect in the sidecar mode",
+				"the attribute 'annotations' has no effect in the sidecar mode",
+				"the attribute 'hostNetwork' has no effect in the sidecar mode",
+				"the attribute 'ingress' has no effect in the sidecar mode",
+				"the attribute 'serviceMesh' has no effect in the sidecar mode",
+				"the attribute 'drain' has no effect in the sidecar mode",
+				"the attribute 'observability.dashboards' has no effect in the sidecar mode",
+			},
+		},
+		{
+			desc: "liveness probe with the probes disabled",
+			spec: OpenTelemetryCollectorSpec{
+				DisableProbes: true,
+				LivenessProbe: &Probe{},
+			},
+			expected: []string{"the attribute 'livenessProbe' has no effect as the probes are disabled"},
+		},
+		{
+			desc: "daemonSet of a deployment",
+			spec: OpenTelemetryCollectorSpec{
+				Mode:      ModeDeployment,
+				DaemonSet: DaemonSetSpec{NodeLocalEndpoint: NodeLocalEndpointHostPort},
+			},
+			expected: []string{"the attribute 'daemonSet' has no effect in the deployment mode"},
+		},
+		{
+			desc: "graceful scale down of a deployment",
+			spec: OpenTelemetryCollectorSpec{
+				Mode:              ModeDeployment,
+				GracefulScaleDown: &GracefulScaleDownSpec{Enabled: true},
+			},
+			expected: []string{"the attribute 'gracefulScaleDown' has no effect in the deployment mode"},
+		},
+		{
+			desc: "graceful scale down without target allocator",
+			spec: OpenTelemetryCollectorSpec{
+				Mode:              ModeStatefulSet,
+				GracefulScaleDown: &GracefulScaleDownSpec{Enabled: true},
+			},
+			expected: []string{"the attribute 'gracefulScaleDown' has no effect as the target allocator is disabled"},
+		},
+		{
+			desc: "graceful scale down with an autoscaler",
+			spec: OpenTelemetryCollectorSpec{
+				Mode:              ModeStatefulSet,
+				TargetAllocator:   OpenTelemetryTargetAllocator{Enabled: true},
+				Autoscaler:        &AutoscalerSpec{MaxReplicas: &five},
+				GracefulScaleDown: &GracefulScaleDownSpec{Enabled: true},
+			},
+			expected: []string{"the attribute 'gracefulScaleDown' has no effect as the replicas are managed by the autoscaler"},
+		},
+		{
+			desc: "drain with a preStop hook",
+			spec: OpenTelemetryCollectorSpec{
+				Drain:     &DrainSpec{DelaySeconds: 5},
+				Lifecycle: &corev1.Lifecycle{PreStop: &corev1.LifecycleHandler{Exec: &corev1.ExecAction{Command: []string{"/drain"}}}},
+			},
+			expected: []string{"the attribute 'drain' has no effect as the lifecycle sets a preStop hook"},
+		},
+		{
+			desc: "replicas of a daemonset",
+			spec: OpenTelemetryCollectorSpec{
+				Mode:     ModeDaemonSet,
+				Replicas: &five,
+			},
+			expected: []string{"the attribute 'replicas' has no effect in the daemonset mode"},
+		},
+		{
+			desc: "nodeArchitectures of a sidecar",
+			spec: OpenTelemetryCollectorSpec{
+				Mode:              ModeSidecar,
+				NodeArchitectures: []NodeArchitecture{NodeArchitectureARM64},
+			},
+			expected: []string{"the attribute 'nodeArchitectures' has no effect in the sidecar mode"},
+		},
+		{
+			desc: "statefulSet of a deployment",
+			spec: OpenTelemetryCollectorSpec{
+				Mode:        ModeDeployment,
+				StatefulSet: StatefulSetSpec{PodServices: true},
+			},
+			expected: []string{"the attribute 'statefulSet' has no effect in the deployment mode"},
+		},
+		{
+			desc: "CPU autoscaling without requests",
+			spec: OpenTelemetryCollectorSpec{
+				Autoscaler: &AutoscalerSpec{MaxReplicas: &five, TargetCPUUtilization: &ninety},
+			},
+			expected: []string{"the autoscaler targets a CPU utilization, which can't be computed without CPU requests"},
+		},
+		{
+			desc: "CPU autoscaling with requests",
+			spec: OpenTelemetryCollectorSpec{
+				Autoscaler: &AutoscalerSpec{MaxReplicas: &five, TargetCPUUtilization: &ninety},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+				},
+			},
+		},
+		{
+			desc: "TLS files out of the volumes",
+			spec: OpenTelemetryCollectorSpec{
+				Config: `exporters:
+  otlp:
+    tls:
+      ca_file: /etc/otelcol/tls/backend-ca/ca.crt
+      cert_file: /certs/client/tls.crt
+      key_file: /var/conf/client/configmap-client-key/tls.key
+`,
+			},
+			expected: []string{
+				"the ca_file /etc/otelcol/tls/backend-ca/ca.crt of the exporter otlp isn't in any of the volumes mounted in the collector",
+				"the cert_file /certs/client/tls.crt of the exporter otlp isn't in any of the volumes mounted in the collector",
+				"the key_file /var/conf/client/configmap-client-key/tls.key of the exporter otlp isn't in any of the volumes mounted in the collector",
+			},
+		},
+		{
+			desc: "TLS files in the volumes",
+			spec: OpenTelemetryCollectorSpec{
+				MountTLSSecrets: true,
+				VolumeMounts:    []corev1.VolumeMount{{Name: "client", MountPath: "/certs/client/"}},
+				ConfigMaps:      []ConfigMapsSpec{{Name: "client-key", MountPath: "client"}},
+				Config: `exporters:
+  otlp:
+    tls:
+      ca_file: /etc/otelcol/tls/backend-ca/ca.crt
+      cert_file: /certs/client/tls.crt
+      key_file: /var/conf/client/configmap-client-key/tls.key
+`,
+			},
+		},
+		{
+			desc: "prometheusCR without the feature gate",
+			spec: OpenTelemetryCollectorSpec{
+				PrometheusCR: OpenTelemetryCollectorPrometheusCR{Enabled: true},
+			},
+			expected: []string{
+				"prometheusCR is enabled, but the ServiceMonitors and PodMonitors are ignored while the operator.observability.prometheus feature gate is disabled",
+				"prometheusCR.serviceMonitorSelector is empty, all the ServiceMonitors of the cluster are resolved into scrape configs",
+				"prometheusCR.podMonitorSelector is empty, all the PodMonitors of the cluster are resolved into scrape configs",
+			},
+		},
+		{
+			desc: "prometheusCR with an empty podMonitorSelector",
+			spec: OpenTelemetryCollectorSpec{
+				PrometheusCR: OpenTelemetryCollectorPrometheusCR{
+					Enabled:                true,
+					ServiceMonitorSelector: map[string]string{"team": "payments"},
+				},
+			},
+			expected: []string{
+				"prometheusCR is enabled, but the ServiceMonitors and PodMonitors are ignored while the operator.observability.prometheus feature gate is disabled",
+				"prometheusCR.podMonitorSelector is empty, all the PodMonitors of the cluster are resolved into scrape configs",
+			},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			warnings := collectorWarnings(&OpenTelemetryCollector{Spec: tt.spec})
+			assert.ElementsMatch(t, tt.expected, warnings)
+		})
+	}
+}
+
+func TestOpAMPBridgeWarnings(t *testing.T) {
+	// prepare
+	bridge := &OpAMPBridge{
+		Spec: OpAMPBridgeSpec{
+			Capabilities: map[OpAMPBridgeCapability]bool{
+				OpAMPBridgeCapabilityAcceptsRemoteConfig: true,
+			},
+		},
+	}
+
+	// test
+	warnings := opAMPBridgeWarnings(bridge)
+
+	// verify
+	assert.Equal(t, []string{"the bridge accepts remote configurations without restricting the components allowed, the OpAMP server may enable any of them"}, []string(warnings))
+
+	// test
+	bridge.Spec.ComponentsAllowed = map[string][]string{"receivers": {"otlp"}}
+	warnings = opAMPBridgeWarnings(bridge)
+
+	// verify
+	assert.Empty(t, warnings)
+}
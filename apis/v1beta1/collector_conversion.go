@@ -0,0 +1,425 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+	"sigs.k8s.io/yaml"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+var _ conversion.Convertible = &OpenTelemetryCollector{}
+
+// ConvertTo converts this OpenTelemetryCollector to the hub version (v1alpha1).
+func (src *OpenTelemetryCollector) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1alpha1.OpenTelemetryCollector)
+	if !ok {
+		return fmt.Errorf("expected *v1alpha1.OpenTelemetryCollector, received %T", dstRaw)
+	}
+
+	cfg, err := yaml.Marshal(src.Spec.Config)
+	if err != nil {
+		return fmt.Errorf("failed to convert the collector config: %w", err)
+	}
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = v1alpha1.OpenTelemetryCollectorSpec{
+		ManagementState:               v1alpha1.ManagementStateType(src.Spec.ManagementState),
+		Resources:                     src.Spec.Resources,
+		NodeSelector:                  src.Spec.NodeSelector,
+		Args:                          src.Spec.Args,
+		FeatureGates:                  src.Spec.FeatureGates,
+		Replicas:                      src.Spec.Replicas,
+		PodDisruptionBudget:           (*v1alpha1.PodDisruptionBudgetSpec)(src.Spec.PodDisruptionBudget),
+		SecurityContext:               src.Spec.SecurityContext,
+		ReadOnlyRootFilesystem:        src.Spec.ReadOnlyRootFilesystem,
+		PodSecurityContext:            src.Spec.PodSecurityContext,
+		Annotations:                   src.Spec.Annotations,
+		PodAnnotations:                src.Spec.PodAnnotations,
+		Spiffe:                        v1alpha1.SpiffeSpec(src.Spec.Spiffe),
+		ServiceAnnotations:            src.Spec.ServiceAnnotations,
+		ServiceLabels:                 src.Spec.ServiceLabels,
+		IPFamilies:                    src.Spec.IPFamilies,
+		IPFamilyPolicy:                src.Spec.IPFamilyPolicy,
+		ServiceAccountAnnotations:     src.Spec.ServiceAccountAnnotations,
+		ServiceAccountLabels:          src.Spec.ServiceAccountLabels,
+		StandardLabels:                (*v1alpha1.StandardLabels)(src.Spec.StandardLabels),
+		Mode:                          v1alpha1.Mode(src.Spec.Mode),
+		ServiceAccount:                src.Spec.ServiceAccount,
+		NameOverride:                  src.Spec.NameOverride,
+		FullnameOverride:              src.Spec.FullnameOverride,
+		Image:                         src.Spec.Image,
+		UpgradeStrategy:               v1alpha1.UpgradeStrategy(src.Spec.UpgradeStrategy),
+		ImagePullPolicy:               src.Spec.ImagePullPolicy,
+		Config:                        string(cfg),
+		VolumeMounts:                  src.Spec.VolumeMounts,
+		Env:                           src.Spec.Env,
+		EnvFrom:                       src.Spec.EnvFrom,
+		VolumeClaimTemplates:          src.Spec.VolumeClaimTemplates,
+		GracefulScaleDown:             (*v1alpha1.GracefulScaleDownSpec)(src.Spec.GracefulScaleDown),
+		Tolerations:                   src.Spec.Tolerations,
+		Volumes:                       src.Spec.Volumes,
+		HostNetwork:                   src.Spec.HostNetwork,
+		PriorityClassName:             src.Spec.PriorityClassName,
+		Affinity:                      src.Spec.Affinity,
+		Lifecycle:                     src.Spec.Lifecycle,
+		Drain:                         (*v1alpha1.DrainSpec)(src.Spec.Drain),
+		TerminationGracePeriodSeconds: src.Spec.TerminationGracePeriodSeconds,
+		LivenessProbe:                 (*v1alpha1.Probe)(src.Spec.LivenessProbe),
+		DisableProbes:                 src.Spec.DisableProbes,
+		InitContainers:                src.Spec.InitContainers,
+		AdditionalContainers:          src.Spec.AdditionalContainers,
+		Observability: v1alpha1.ObservabilitySpec{
+			Metrics:    v1alpha1.MetricsConfigSpec(src.Spec.Observability.Metrics),
+			Dashboards: v1alpha1.DashboardsConfigSpec(src.Spec.Observability.Dashboards),
+		},
+		TopologySpreadConstraints: src.Spec.TopologySpreadConstraints,
+		Ingress: v1alpha1.Ingress{
+			Type:             v1alpha1.IngressType(src.Spec.Ingress.Type),
+			RuleType:         v1alpha1.IngressRuleType(src.Spec.Ingress.RuleType),
+			Hostname:         src.Spec.Ingress.Hostname,
+			Annotations:      src.Spec.Ingress.Annotations,
+			TLS:              src.Spec.Ingress.TLS,
+			IngressClassName: src.Spec.Ingress.IngressClassName,
+			Route: v1alpha1.OpenShiftRoute{
+				Termination: v1alpha1.TLSRouteTerminationType(src.Spec.Ingress.Route.Termination),
+			},
+		},
+		TargetAllocator: v1alpha1.OpenTelemetryTargetAllocator{
+			Replicas:           src.Spec.TargetAllocator.Replicas,
+			NodeSelector:       src.Spec.TargetAllocator.NodeSelector,
+			Resources:          src.Spec.TargetAllocator.Resources,
+			AllocationStrategy: v1alpha1.OpenTelemetryTargetAllocatorAllocationStrategy(src.Spec.TargetAllocator.AllocationStrategy),
+			FilterStrategy:     string(src.Spec.TargetAllocator.FilterStrategy),
+			ServiceAccount:     src.Spec.TargetAllocator.ServiceAccount,
+			IPFamilies:         src.Spec.TargetAllocator.IPFamilies,
+			IPFamilyPolicy:     src.Spec.TargetAllocator.IPFamilyPolicy,
+			LogLevel:           v1alpha1.LogLevel(src.Spec.TargetAllocator.LogLevel),
+			LogFormat:          v1alpha1.LogFormat(src.Spec.TargetAllocator.LogFormat),
+			Image:              src.Spec.TargetAllocator.Image,
+			Enabled:            src.Spec.TargetAllocator.Enabled,
+			PrometheusCR: v1alpha1.OpenTelemetryTargetAllocatorPrometheusCR{
+				Enabled:                src.Spec.TargetAllocator.PrometheusCR.Enabled,
+				ScrapeInterval:         src.Spec.TargetAllocator.PrometheusCR.ScrapeInterval,
+				PodMonitorSelector:     src.Spec.TargetAllocator.PrometheusCR.PodMonitorSelector,
+				ServiceMonitorSelector: src.Spec.TargetAllocator.PrometheusCR.ServiceMonitorSelector,
+			},
+			TopologySpreadConstraints: src.Spec.TargetAllocator.TopologySpreadConstraints,
+			Tolerations:               src.Spec.TargetAllocator.Tolerations,
+			Env:                       src.Spec.TargetAllocator.Env,
+		},
+		PrometheusCR: v1alpha1.OpenTelemetryCollectorPrometheusCR{
+			Enabled:                src.Spec.PrometheusCR.Enabled,
+			ScrapeInterval:         src.Spec.PrometheusCR.ScrapeInterval,
+			PodMonitorSelector:     src.Spec.PrometheusCR.PodMonitorSelector,
+			ServiceMonitorSelector: src.Spec.PrometheusCR.ServiceMonitorSelector,
+		},
+		StatefulSet:                          v1alpha1.StatefulSetSpec(src.Spec.StatefulSet),
+		PersistentVolumeClaimRetentionPolicy: src.Spec.PersistentVolumeClaimRetentionPolicy,
+		PrometheusSharding:                   src.Spec.PrometheusSharding,
+		MountTLSSecrets:                      src.Spec.MountTLSSecrets,
+		DebugEndpoints:                       (*v1alpha1.DebugEndpointsSpec)(src.Spec.DebugEndpoints),
+		DaemonSet: v1alpha1.DaemonSetSpec{
+			NodeLocalEndpoint: v1alpha1.NodeLocalEndpoint(src.Spec.DaemonSet.NodeLocalEndpoint),
+		},
+	}
+
+	if src.Spec.Autoscaler != nil {
+		dst.Spec.Autoscaler = &v1alpha1.AutoscalerSpec{
+			MinReplicas:             src.Spec.Autoscaler.MinReplicas,
+			MaxReplicas:             src.Spec.Autoscaler.MaxReplicas,
+			Behavior:                src.Spec.Autoscaler.Behavior,
+			TargetCPUUtilization:    src.Spec.Autoscaler.TargetCPUUtilization,
+			TargetMemoryUtilization: src.Spec.Autoscaler.TargetMemoryUtilization,
+		}
+		for _, m := range src.Spec.Autoscaler.Metrics {
+			dst.Spec.Autoscaler.Metrics = append(dst.Spec.Autoscaler.Metrics, v1alpha1.MetricSpec(m))
+		}
+	}
+	dst.Spec.ServiceMesh = v1alpha1.ServiceMeshSpec{
+		Type:                            v1alpha1.ServiceMeshType(src.Spec.ServiceMesh.Type),
+		ExcludeReceiverPorts:            src.Spec.ServiceMesh.ExcludeReceiverPorts,
+		HoldApplicationUntilProxyStarts: src.Spec.ServiceMesh.HoldApplicationUntilProxyStarts,
+	}
+	dst.Spec.Telemetry = v1alpha1.TelemetrySpec{
+		Metrics: v1alpha1.TelemetryMetricsSpec{
+			Level: v1alpha1.TelemetryMetricsLevel(src.Spec.Telemetry.Metrics.Level),
+			Port:  src.Spec.Telemetry.Metrics.Port,
+		},
+		Logs: v1alpha1.TelemetryLogsSpec{
+			Level:    v1alpha1.TelemetryLogLevel(src.Spec.Telemetry.Logs.Level),
+			Encoding: v1alpha1.LogFormat(src.Spec.Telemetry.Logs.Encoding),
+		},
+	}
+	if src.Spec.Rollout != nil {
+		dst.Spec.Rollout = &v1alpha1.Rollout{
+			Strategy:         v1alpha1.RolloutStrategy(src.Spec.Rollout.Strategy),
+			CanaryPercentage: src.Spec.Rollout.CanaryPercentage,
+			PromotionDelay:   src.Spec.Rollout.PromotionDelay,
+			Analysis:         (*v1alpha1.RolloutAnalysis)(src.Spec.Rollout.Analysis),
+		}
+	}
+	for _, p := range src.Spec.Ports {
+		dst.Spec.Ports = append(dst.Spec.Ports, v1alpha1.PortsSpec(p))
+	}
+	for _, cm := range src.Spec.ConfigMaps {
+		dst.Spec.ConfigMaps = append(dst.Spec.ConfigMaps, v1alpha1.ConfigMapsSpec(cm))
+	}
+	for _, class := range src.Spec.SecretProviderClasses {
+		dst.Spec.SecretProviderClasses = append(dst.Spec.SecretProviderClasses, v1alpha1.SecretProviderClassVolume(class))
+	}
+	for _, arch := range src.Spec.NodeArchitectures {
+		dst.Spec.NodeArchitectures = append(dst.Spec.NodeArchitectures, v1alpha1.NodeArchitecture(arch))
+	}
+	for _, w := range src.Spec.UpgradeWindows {
+		dst.Spec.UpgradeWindows = append(dst.Spec.UpgradeWindows, v1alpha1.UpgradeWindow(w))
+	}
+	for _, c := range src.Spec.TargetAllocator.PrometheusCR.RemoteClusters {
+		dst.Spec.TargetAllocator.PrometheusCR.RemoteClusters = append(dst.Spec.TargetAllocator.PrometheusCR.RemoteClusters, v1alpha1.OpenTelemetryTargetAllocatorRemoteCluster(c))
+	}
+
+	dst.Status = v1alpha1.OpenTelemetryCollectorStatus{
+		Scale:      v1alpha1.ScaleSubresourceStatus(src.Status.Scale),
+		Version:    src.Status.Version,
+		Image:      src.Status.Image,
+		Endpoint:   src.Status.Endpoint,
+		Conditions: src.Status.Conditions,
+	}
+	if src.Status.Rollout != nil {
+		dst.Status.Rollout = &v1alpha1.RolloutStatus{
+			Phase:               v1alpha1.RolloutPhase(src.Status.Rollout.Phase),
+			StableConfig:        src.Status.Rollout.StableConfig,
+			StableConfigHash:    src.Status.Rollout.StableConfigHash,
+			CanaryConfigHash:    src.Status.Rollout.CanaryConfigHash,
+			CanaryReplicas:      src.Status.Rollout.CanaryReplicas,
+			CanaryReadyReplicas: src.Status.Rollout.CanaryReadyReplicas,
+			CanaryReadySince:    src.Status.Rollout.CanaryReadySince,
+			CanaryRefused:       src.Status.Rollout.CanaryRefused,
+			CanaryDropped:       src.Status.Rollout.CanaryDropped,
+			Message:             src.Status.Rollout.Message,
+		}
+	}
+	for _, c := range src.Status.Components {
+		dst.Status.Components = append(dst.Status.Components, v1alpha1.ComponentStatus(c))
+	}
+	return nil
+}
+
+// ConvertFrom converts from the hub version (v1alpha1) to this version.
+func (dst *OpenTelemetryCollector) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1alpha1.OpenTelemetryCollector)
+	if !ok {
+		return fmt.Errorf("expected *v1alpha1.OpenTelemetryCollector, received %T", srcRaw)
+	}
+
+	cfg := Config{}
+	if err := yaml.Unmarshal([]byte(src.Spec.Config), &cfg); err != nil {
+		return fmt.Errorf("failed to parse the collector config: %w", err)
+	}
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = OpenTelemetryCollectorSpec{
+		ManagementState:               ManagementStateType(src.Spec.ManagementState),
+		Resources:                     src.Spec.Resources,
+		NodeSelector:                  src.Spec.NodeSelector,
+		Args:                          src.Spec.Args,
+		FeatureGates:                  src.Spec.FeatureGates,
+		Replicas:                      src.Spec.Replicas,
+		PodDisruptionBudget:           (*PodDisruptionBudgetSpec)(src.Spec.PodDisruptionBudget),
+		SecurityContext:               src.Spec.SecurityContext,
+		ReadOnlyRootFilesystem:        src.Spec.ReadOnlyRootFilesystem,
+		PodSecurityContext:            src.Spec.PodSecurityContext,
+		Annotations:                   src.Spec.Annotations,
+		PodAnnotations:                src.Spec.PodAnnotations,
+		Spiffe:                        SpiffeSpec(src.Spec.Spiffe),
+		ServiceAnnotations:            src.Spec.ServiceAnnotations,
+		ServiceLabels:                 src.Spec.ServiceLabels,
+		IPFamilies:                    src.Spec.IPFamilies,
+		IPFamilyPolicy:                src.Spec.IPFamilyPolicy,
+		ServiceAccountAnnotations:     src.Spec.ServiceAccountAnnotations,
+		ServiceAccountLabels:          src.Spec.ServiceAccountLabels,
+		StandardLabels:                (*StandardLabels)(src.Spec.StandardLabels),
+		Mode:                          Mode(src.Spec.Mode),
+		ServiceAccount:                src.Spec.ServiceAccount,
+		NameOverride:                  src.Spec.NameOverride,
+		FullnameOverride:              src.Spec.FullnameOverride,
+		Image:                         src.Spec.Image,
+		UpgradeStrategy:               UpgradeStrategy(src.Spec.UpgradeStrategy),
+		ImagePullPolicy:               src.Spec.ImagePullPolicy,
+		Config:                        cfg,
+		VolumeMounts:                  src.Spec.VolumeMounts,
+		Env:                           src.Spec.Env,
+		EnvFrom:                       src.Spec.EnvFrom,
+		VolumeClaimTemplates:          src.Spec.VolumeClaimTemplates,
+		GracefulScaleDown:             (*GracefulScaleDownSpec)(src.Spec.GracefulScaleDown),
+		Tolerations:                   src.Spec.Tolerations,
+		Volumes:                       src.Spec.Volumes,
+		HostNetwork:                   src.Spec.HostNetwork,
+		PriorityClassName:             src.Spec.PriorityClassName,
+		Affinity:                      src.Spec.Affinity,
+		Lifecycle:                     src.Spec.Lifecycle,
+		Drain:                         (*DrainSpec)(src.Spec.Drain),
+		TerminationGracePeriodSeconds: src.Spec.TerminationGracePeriodSeconds,
+		LivenessProbe:                 (*Probe)(src.Spec.LivenessProbe),
+		DisableProbes:                 src.Spec.DisableProbes,
+		InitContainers:                src.Spec.InitContainers,
+		AdditionalContainers:          src.Spec.AdditionalContainers,
+		Observability: ObservabilitySpec{
+			Metrics:    MetricsConfigSpec(src.Spec.Observability.Metrics),
+			Dashboards: DashboardsConfigSpec(src.Spec.Observability.Dashboards),
+		},
+		TopologySpreadConstraints: src.Spec.TopologySpreadConstraints,
+		Ingress: Ingress{
+			Type:             IngressType(src.Spec.Ingress.Type),
+			RuleType:         IngressRuleType(src.Spec.Ingress.RuleType),
+			Hostname:         src.Spec.Ingress.Hostname,
+			Annotations:      src.Spec.Ingress.Annotations,
+			TLS:              src.Spec.Ingress.TLS,
+			IngressClassName: src.Spec.Ingress.IngressClassName,
+			Route: OpenShiftRoute{
+				Termination: TLSRouteTerminationType(src.Spec.Ingress.Route.Termination),
+			},
+		},
+		TargetAllocator: TargetAllocatorEmbedded{
+			Replicas:           src.Spec.TargetAllocator.Replicas,
+			NodeSelector:       src.Spec.TargetAllocator.NodeSelector,
+			Resources:          src.Spec.TargetAllocator.Resources,
+			AllocationStrategy: TargetAllocatorAllocationStrategy(src.Spec.TargetAllocator.AllocationStrategy),
+			FilterStrategy:     TargetAllocatorFilterStrategy(src.Spec.TargetAllocator.FilterStrategy),
+			ServiceAccount:     src.Spec.TargetAllocator.ServiceAccount,
+			IPFamilies:         src.Spec.TargetAllocator.IPFamilies,
+			IPFamilyPolicy:     src.Spec.TargetAllocator.IPFamilyPolicy,
+			LogLevel:           LogLevel(src.Spec.TargetAllocator.LogLevel),
+			LogFormat:          LogFormat(src.Spec.TargetAllocator.LogFormat),
+			Image:              src.Spec.TargetAllocator.Image,
+			Enabled:            src.Spec.TargetAllocator.Enabled,
+			PrometheusCR: TargetAllocatorPrometheusCR{
+				Enabled:                src.Spec.TargetAllocator.PrometheusCR.Enabled,
+				ScrapeInterval:         src.Spec.TargetAllocator.PrometheusCR.ScrapeInterval,
+				PodMonitorSelector:     src.Spec.TargetAllocator.PrometheusCR.PodMonitorSelector,
+				ServiceMonitorSelector: src.Spec.TargetAllocator.PrometheusCR.ServiceMonitorSelector,
+			},
+			TopologySpreadConstraints: src.Spec.TargetAllocator.TopologySpreadConstraints,
+			Tolerations:               src.Spec.TargetAllocator.Tolerations,
+			Env:                       src.Spec.TargetAllocator.Env,
+		},
+		PrometheusCR: OpenTelemetryCollectorPrometheusCR{
+			Enabled:                src.Spec.PrometheusCR.Enabled,
+			ScrapeInterval:         src.Spec.PrometheusCR.ScrapeInterval,
+			PodMonitorSelector:     src.Spec.PrometheusCR.PodMonitorSelector,
+			ServiceMonitorSelector: src.Spec.PrometheusCR.ServiceMonitorSelector,
+		},
+		StatefulSet:                          StatefulSetSpec(src.Spec.StatefulSet),
+		PersistentVolumeClaimRetentionPolicy: src.Spec.PersistentVolumeClaimRetentionPolicy,
+		PrometheusSharding:                   src.Spec.PrometheusSharding,
+		MountTLSSecrets:                      src.Spec.MountTLSSecrets,
+		DebugEndpoints:                       (*DebugEndpointsSpec)(src.Spec.DebugEndpoints),
+		DaemonSet: DaemonSetSpec{
+			NodeLocalEndpoint: NodeLocalEndpoint(src.Spec.DaemonSet.NodeLocalEndpoint),
+		},
+	}
+
+	// the deprecated top-level replica bounds only exist in v1alpha1, they are folded into the autoscaler
+	if src.Spec.Autoscaler != nil || src.Spec.MinReplicas != nil || src.Spec.MaxReplicas != nil {
+		dst.Spec.Autoscaler = &AutoscalerSpec{
+			MinReplicas: src.Spec.MinReplicas,
+			MaxReplicas: src.Spec.MaxReplicas,
+		}
+	}
+	if src.Spec.Autoscaler != nil {
+		if src.Spec.Autoscaler.MinReplicas != nil {
+			dst.Spec.Autoscaler.MinReplicas = src.Spec.Autoscaler.MinReplicas
+		}
+		if src.Spec.Autoscaler.MaxReplicas != nil {
+			dst.Spec.Autoscaler.MaxReplicas = src.Spec.Autoscaler.MaxReplicas
+		}
+		dst.Spec.Autoscaler.Behavior = src.Spec.Autoscaler.Behavior
+		dst.Spec.Autoscaler.TargetCPUUtilization = src.Spec.Autoscaler.TargetCPUUtilization
+		dst.Spec.Autoscaler.TargetMemoryUtilization = src.Spec.Autoscaler.TargetMemoryUtilization
+		for _, m := range src.Spec.Autoscaler.Metrics {
+			dst.Spec.Autoscaler.Metrics = append(dst.Spec.Autoscaler.Metrics, MetricSpec(m))
+		}
+	}
+	dst.Spec.ServiceMesh = ServiceMeshSpec{
+		Type:                            ServiceMeshType(src.Spec.ServiceMesh.Type),
+		ExcludeReceiverPorts:            src.Spec.ServiceMesh.ExcludeReceiverPorts,
+		HoldApplicationUntilProxyStarts: src.Spec.ServiceMesh.HoldApplicationUntilProxyStarts,
+	}
+	dst.Spec.Telemetry = TelemetrySpec{
+		Metrics: TelemetryMetricsSpec{
+			Level: TelemetryMetricsLevel(src.Spec.Telemetry.Metrics.Level),
+			Port:  src.Spec.Telemetry.Metrics.Port,
+		},
+		Logs: TelemetryLogsSpec{
+			Level:    TelemetryLogLevel(src.Spec.Telemetry.Logs.Level),
+			Encoding: LogFormat(src.Spec.Telemetry.Logs.Encoding),
+		},
+	}
+	if src.Spec.Rollout != nil {
+		dst.Spec.Rollout = &Rollout{
+			Strategy:         RolloutStrategy(src.Spec.Rollout.Strategy),
+			CanaryPercentage: src.Spec.Rollout.CanaryPercentage,
+			PromotionDelay:   src.Spec.Rollout.PromotionDelay,
+			Analysis:         (*RolloutAnalysis)(src.Spec.Rollout.Analysis),
+		}
+	}
+	for _, p := range src.Spec.Ports {
+		dst.Spec.Ports = append(dst.Spec.Ports, PortsSpec(p))
+	}
+	for _, cm := range src.Spec.ConfigMaps {
+		dst.Spec.ConfigMaps = append(dst.Spec.ConfigMaps, ConfigMapsSpec(cm))
+	}
+	for _, class := range src.Spec.SecretProviderClasses {
+		dst.Spec.SecretProviderClasses = append(dst.Spec.SecretProviderClasses, SecretProviderClassVolume(class))
+	}
+	for _, arch := range src.Spec.NodeArchitectures {
+		dst.Spec.NodeArchitectures = append(dst.Spec.NodeArchitectures, NodeArchitecture(arch))
+	}
+	for _, w := range src.Spec.UpgradeWindows {
+		dst.Spec.UpgradeWindows = append(dst.Spec.UpgradeWindows, UpgradeWindow(w))
+	}
+	for _, c := range src.Spec.TargetAllocator.PrometheusCR.RemoteClusters {
+		dst.Spec.TargetAllocator.PrometheusCR.RemoteClusters = append(dst.Spec.TargetAllocator.PrometheusCR.RemoteClusters, TargetAllocatorRemoteCluster(c))
+	}
+
+	dst.Status = OpenTelemetryCollectorStatus{
+		Scale:      ScaleSubresourceStatus(src.Status.Scale),
+		Version:    src.Status.Version,
+		Image:      src.Status.Image,
+		Endpoint:   src.Status.Endpoint,
+		Conditions: src.Status.Conditions,
+	}
+	if src.Status.Rollout != nil {
+		dst.Status.Rollout = &RolloutStatus{
+			Phase:               RolloutPhase(src.Status.Rollout.Phase),
+			StableConfig:        src.Status.Rollout.StableConfig,
+			StableConfigHash:    src.Status.Rollout.StableConfigHash,
+			CanaryConfigHash:    src.Status.Rollout.CanaryConfigHash,
+			CanaryReplicas:      src.Status.Rollout.CanaryReplicas,
+			CanaryReadyReplicas: src.Status.Rollout.CanaryReadyReplicas,
+			CanaryReadySince:    src.Status.Rollout.CanaryReadySince,
+			CanaryRefused:       src.Status.Rollout.CanaryRefused,
+			CanaryDropped:       src.Status.Rollout.CanaryDropped,
+			Message:             src.Status.Rollout.Message,
+		}
+	}
+	for _, c := range src.Status.Components {
+		dst.Status.Components = append(dst.Status.Components, ComponentStatus(c))
+	}
+	return nil
+}
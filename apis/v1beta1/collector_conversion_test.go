@@ -0,0 +1,243 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+const collectorCfg = `receivers:
+  otlp:
+    protocols:
+      grpc: {}
+exporters:
+  debug: {}
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [debug]
+`
+
+func TestIsConvertible(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	require.NoError(t, AddToScheme(scheme))
+
+	convertible, err := conversion.IsConvertible(scheme, &v1alpha1.OpenTelemetryCollector{})
+	require.NoError(t, err)
+	assert.True(t, convertible)
+}
+
+func TestConvertFromHub(t *testing.T) {
+	one := int32(1)
+	three := int32(3)
+	src := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-collector", Namespace: "my-ns"},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:        v1alpha1.ModeDeployment,
+			Config:      collectorCfg,
+			MinReplicas: &one,
+			MaxReplicas: &three,
+			TargetAllocator: v1alpha1.OpenTelemetryTargetAllocator{
+				Enabled:        true,
+				FilterStrategy: "relabel-config",
+			},
+		},
+		Status: v1alpha1.OpenTelemetryCollectorStatus{
+			Version:  "0.88.0",
+			Messages: []string{"deprecated"},
+		},
+	}
+
+	dst := &OpenTelemetryCollector{}
+	require.NoError(t, dst.ConvertFrom(src))
+
+	assert.Equal(t, src.ObjectMeta, dst.ObjectMeta)
+	assert.Equal(t, ModeDeployment, dst.Spec.Mode)
+	assert.Equal(t, map[string]interface{}{"otlp": map[string]interface{}{"protocols": map[string]interface{}{"grpc": map[string]interface{}{}}}}, dst.Spec.Config.Receivers.Object)
+	assert.Equal(t, map[string]interface{}{"debug": map[string]interface{}{}}, dst.Spec.Config.Exporters.Object)
+	assert.Nil(t, dst.Spec.Config.Processors)
+	assert.Contains(t, dst.Spec.Config.Service.Pipelines.Object, "traces")
+	require.NotNil(t, dst.Spec.Autoscaler)
+	assert.Equal(t, &one, dst.Spec.Autoscaler.MinReplicas)
+	assert.Equal(t, &three, dst.Spec.Autoscaler.MaxReplicas)
+	assert.True(t, dst.Spec.TargetAllocator.Enabled)
+	assert.Equal(t, TargetAllocatorFilterStrategyRelabelConfig, dst.Spec.TargetAllocator.FilterStrategy)
+	assert.Equal(t, "0.88.0", dst.Status.Version)
+}
+
+func TestConvertFromHubInvalidConfig(t *testing.T) {
+	src := &v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config: "receivers: [",
+		},
+	}
+
+	dst := &OpenTelemetryCollector{}
+	assert.ErrorContains(t, dst.ConvertFrom(src), "failed to parse the collector config")
+}
+
+func TestConvertRoundTrip(t *testing.T) {
+	two := int32(2)
+	excludeReceiverPorts := false
+	singleStack := corev1.IPFamilyPolicySingleStack
+	dualStack := corev1.IPFamilyPolicyRequireDualStack
+	maxDropped := int64(10)
+	src := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-collector", Namespace: "my-ns"},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:     v1alpha1.ModeStatefulSet,
+			Replicas: &two,
+			Config:   collectorCfg,
+			Autoscaler: &v1alpha1.AutoscalerSpec{
+				MaxReplicas: &two,
+				Metrics:     []v1alpha1.MetricSpec{{Type: "Pods"}},
+			},
+			Ingress: v1alpha1.Ingress{
+				Type:     v1alpha1.IngressTypeRoute,
+				Hostname: "example.com",
+				Route:    v1alpha1.OpenShiftRoute{Termination: v1alpha1.TLSRouteTerminationTypeEdge},
+			},
+			ConfigMaps: []v1alpha1.ConfigMapsSpec{{Name: "cm", MountPath: "/etc/cm"}},
+			Observability: v1alpha1.ObservabilitySpec{
+				Metrics: v1alpha1.MetricsConfigSpec{EnableMetrics: true},
+				Dashboards: v1alpha1.DashboardsConfigSpec{
+					Enabled: true,
+					Labels:  map[string]string{"grafana_dashboard": "otel"},
+				},
+			},
+			DaemonSet: v1alpha1.DaemonSetSpec{
+				NodeLocalEndpoint: v1alpha1.NodeLocalEndpointHostPort,
+			},
+			PersistentVolumeClaimRetentionPolicy: &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
+				WhenDeleted: appsv1.DeletePersistentVolumeClaimRetentionPolicyType,
+				WhenScaled:  appsv1.RetainPersistentVolumeClaimRetentionPolicyType,
+			},
+			StatefulSet: v1alpha1.StatefulSetSpec{
+				PodManagementPolicy: appsv1.OrderedReadyPodManagement,
+				MinReadySeconds:     30,
+				PodServices:         true,
+				HeadlessService:     true,
+			},
+			ServiceMesh: v1alpha1.ServiceMeshSpec{
+				Type:                            v1alpha1.ServiceMeshTypeIstio,
+				ExcludeReceiverPorts:            &excludeReceiverPorts,
+				HoldApplicationUntilProxyStarts: true,
+			},
+			Annotations:               map[string]string{"configmap.reloader.stakater.com/reload": "otel-config"},
+			ServiceAnnotations:        map[string]string{"service.beta.kubernetes.io/aws-load-balancer-type": "nlb"},
+			ServiceLabels:             map[string]string{"team": "observability"},
+			ServiceAccountAnnotations: map[string]string{"eks.amazonaws.com/role-arn": "arn:aws:iam::123456789012:role/otel"},
+			ServiceAccountLabels:      map[string]string{"team": "observability"},
+			StandardLabels:            &v1alpha1.StandardLabels{PartOf: "observability", Version: "stable"},
+			NameOverride:              "otel",
+			FullnameOverride:          "otel-gateway",
+			TargetAllocator: v1alpha1.OpenTelemetryTargetAllocator{
+				IPFamilies:     []corev1.IPFamily{corev1.IPv6Protocol},
+				IPFamilyPolicy: &singleStack,
+				LogLevel:       v1alpha1.LogLevelDebug,
+				LogFormat:      v1alpha1.LogFormatConsole,
+				PrometheusCR: v1alpha1.OpenTelemetryTargetAllocatorPrometheusCR{
+					RemoteClusters: []v1alpha1.OpenTelemetryTargetAllocatorRemoteCluster{{
+						Name: "eu-west",
+						KubeconfigSecret: corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "eu-west-kubeconfig"},
+							Key:                  "kubeconfig",
+						},
+					}},
+				},
+			},
+			PrometheusCR: v1alpha1.OpenTelemetryCollectorPrometheusCR{
+				Enabled:                true,
+				ScrapeInterval:         &metav1.Duration{Duration: time.Minute},
+				ServiceMonitorSelector: map[string]string{"team": "payments"},
+			},
+			PrometheusSharding:    true,
+			MountTLSSecrets:       true,
+			SecretProviderClasses: []v1alpha1.SecretProviderClassVolume{{Name: "vault", NodePublishSecretRef: "vault-credentials"}},
+			IPFamilies:            []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol},
+			IPFamilyPolicy:        &dualStack,
+			Spiffe: v1alpha1.SpiffeSpec{
+				Enabled:   true,
+				Receivers: []string{"otlp"},
+				Exporters: []string{"otlp/backend"},
+			},
+			NodeArchitectures:      []v1alpha1.NodeArchitecture{v1alpha1.NodeArchitectureARM64},
+			ReadOnlyRootFilesystem: true,
+			DisableProbes:          true,
+			Telemetry: v1alpha1.TelemetrySpec{
+				Metrics: v1alpha1.TelemetryMetricsSpec{Level: "detailed", Port: 9090},
+				Logs:    v1alpha1.TelemetryLogsSpec{Level: "debug", Encoding: v1alpha1.LogFormatJSON},
+			},
+			GracefulScaleDown: &v1alpha1.GracefulScaleDownSpec{
+				Enabled:        true,
+				TimeoutSeconds: &two,
+			},
+			Drain:        &v1alpha1.DrainSpec{DelaySeconds: 15},
+			FeatureGates: []string{"+pkg.translator.prometheus.NormalizeName"},
+			DebugEndpoints: &v1alpha1.DebugEndpointsSpec{
+				Enabled:            true,
+				AdminNamespace:     "admin",
+				ServiceAnnotations: map[string]string{"team": "observability"},
+			},
+			Rollout: &v1alpha1.Rollout{
+				Strategy:         v1alpha1.RolloutStrategyCanary,
+				CanaryPercentage: &two,
+				PromotionDelay:   &metav1.Duration{Duration: 5 * time.Minute},
+				Analysis:         &v1alpha1.RolloutAnalysis{MaxDropped: &maxDropped},
+			},
+		},
+		Status: v1alpha1.OpenTelemetryCollectorStatus{
+			Endpoint: "my-collector-collector.my-ns.svc:4317",
+			Rollout: &v1alpha1.RolloutStatus{
+				Phase:            v1alpha1.RolloutPhaseCanaryReady,
+				StableConfig:     collectorCfg,
+				StableConfigHash: "stable",
+				CanaryConfigHash: "canary",
+				CanaryReplicas:   1,
+				CanaryDropped:    3,
+			},
+			Components: []v1alpha1.ComponentStatus{
+				{Kind: "Deployment", Name: "my-collector-collector", Hash: "abc"},
+				{Kind: "Service", Name: "my-collector-collector"},
+			},
+		},
+	}
+
+	beta := &OpenTelemetryCollector{}
+	require.NoError(t, beta.ConvertFrom(src))
+	hub := &v1alpha1.OpenTelemetryCollector{}
+	require.NoError(t, beta.ConvertTo(hub))
+
+	// the config is re-serialized, so compare it as a structured value
+	roundTripped := &OpenTelemetryCollector{}
+	require.NoError(t, roundTripped.ConvertFrom(hub))
+	assert.Equal(t, beta, roundTripped)
+
+	hub.Spec.Config = src.Spec.Config
+	assert.Equal(t, src, hub)
+}
@@ -0,0 +1,29 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+// ComponentStatus identifies an object generated by the operator for a custom resource.
+type ComponentStatus struct {
+	// Kind of the object, e.g. Deployment or Service.
+	// +required
+	Kind string `json:"kind"`
+	// Name of the object. The namespaced objects are in the namespace of the custom resource.
+	// +required
+	Name string `json:"name"`
+	// Hash of the manifest of the object, as generated by the operator. It changes whenever the operator generates a
+	// different object, e.g. when the spec of the custom resource changes.
+	// +optional
+	Hash string `json:"hash,omitempty"`
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// AnyConfig represents parts of the config which are not structured by the operator, such as the
+// configuration of the individual receivers, processors, exporters, connectors and extensions.
+// +kubebuilder:object:generate=false
+// +kubebuilder:pruning:PreserveUnknownFields
+// +kubebuilder:validation:Type=object
+type AnyConfig struct {
+	Object map[string]interface{} `json:"-"`
+}
+
+var (
+	_ json.Marshaler   = &AnyConfig{}
+	_ json.Unmarshaler = &AnyConfig{}
+)
+
+// DeepCopyInto is an deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (c *AnyConfig) DeepCopyInto(out *AnyConfig) {
+	*out = *c
+	if c.Object != nil {
+		out.Object = runtime.DeepCopyJSON(c.Object)
+	}
+}
+
+// DeepCopy is an deepcopy function, copying the receiver, creating a new AnyConfig.
+func (c *AnyConfig) DeepCopy() *AnyConfig {
+	if c == nil {
+		return nil
+	}
+	out := new(AnyConfig)
+	c.DeepCopyInto(out)
+	return out
+}
+
+// UnmarshalJSON implements an alternative parser for this field.
+func (c *AnyConfig) UnmarshalJSON(b []byte) error {
+	vals := map[string]interface{}{}
+	if err := json.Unmarshal(b, &vals); err != nil {
+		return err
+	}
+	c.Object = vals
+	return nil
+}
+
+// MarshalJSON specifies how to convert this object into JSON.
+func (c AnyConfig) MarshalJSON() ([]byte, error) {
+	if c.Object == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(c.Object)
+}
+
+// Config encapsulates collector config.
+type Config struct {
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Receivers AnyConfig `json:"receivers"`
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Exporters AnyConfig `json:"exporters"`
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Processors *AnyConfig `json:"processors,omitempty"`
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Connectors *AnyConfig `json:"connectors,omitempty"`
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Extensions *AnyConfig `json:"extensions,omitempty"`
+	Service    Service    `json:"service"`
+}
+
+// Service defines the service section of the collector config, which enables the configured components.
+type Service struct {
+	// +optional
+	Extensions []string `json:"extensions,omitempty"`
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Telemetry *AnyConfig `json:"telemetry,omitempty"`
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Pipelines AnyConfig `json:"pipelines"`
+}
@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigJSONRoundTrip(t *testing.T) {
+	in := `{"receivers":{"otlp":{"protocols":{"grpc":{"endpoint":"0.0.0.0:4317"}}}},"exporters":{"debug":{}},"service":{"extensions":["health_check"],"pipelines":{"traces":{"exporters":["debug"],"receivers":["otlp"]}}}}`
+
+	cfg := Config{}
+	require.NoError(t, json.Unmarshal([]byte(in), &cfg))
+	assert.Equal(t, []string{"health_check"}, cfg.Service.Extensions)
+	assert.Nil(t, cfg.Processors)
+
+	out, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	assert.JSONEq(t, in, string(out))
+}
+
+func TestAnyConfigDeepCopy(t *testing.T) {
+	cfg := &AnyConfig{Object: map[string]interface{}{"debug": map[string]interface{}{"verbosity": "detailed"}}}
+
+	copied := cfg.DeepCopy()
+	copied.Object["debug"].(map[string]interface{})["verbosity"] = "basic"
+
+	assert.Equal(t, "detailed", cfg.Object["debug"].(map[string]interface{})["verbosity"])
+	assert.Nil(t, (*AnyConfig)(nil).DeepCopy())
+}
+
+func TestAnyConfigMarshalEmpty(t *testing.T) {
+	out, err := json.Marshal(AnyConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, "{}", string(out))
+}
@@ -0,0 +1,27 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+// DrainSpec defines how the collector is drained before it stops.
+type DrainSpec struct {
+	// DelaySeconds is how long the collector keeps accepting telemetry after the pod is asked to stop, so that the
+	// pod is removed from the endpoints of the Services and of the load balancers before the receivers shut down.
+	// +kubebuilder:validation:Minimum=1
+	DelaySeconds int32 `json:"delaySeconds"`
+	// Image providing the sleep binary run by the preStop hook, which is copied into the pod as the collector
+	// images don't ship one. Defaults to the drain image of the operator.
+	// +optional
+	Image string `json:"image,omitempty"`
+}
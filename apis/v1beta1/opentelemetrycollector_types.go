@@ -0,0 +1,801 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// ManagementStateType defines the type for CR management states.
+//
+// +kubebuilder:validation:Enum=managed;unmanaged
+type ManagementStateType string
+
+const (
+	// ManagementStateManaged when the OpenTelemetryCollector custom resource should be
+	// reconciled by the operator.
+	ManagementStateManaged ManagementStateType = "managed"
+
+	// ManagementStateUnmanaged when the OpenTelemetryCollector custom resource should not be
+	// reconciled by the operator.
+	ManagementStateUnmanaged ManagementStateType = "unmanaged"
+)
+
+// Ingress is used to specify how OpenTelemetry Collector is exposed. This
+// functionality is only available if one of the valid modes is set.
+// Valid modes are: deployment, daemonset and statefulset.
+// NOTE: If this feature is activated, all specified receivers are exposed.
+// Currently this has a few limitations. Depending on the ingress controller
+// there are problems with TLS and gRPC.
+// SEE: https://github.com/open-telemetry/opentelemetry-operator/issues/1306.
+// NOTE: As a workaround, port name and appProtocol could be specified directly
+// in the CR.
+// SEE: OpenTelemetryCollector.spec.ports[index].
+type Ingress struct {
+	// Type default value is: ""
+	// Supported types are: ingress, route
+	Type IngressType `json:"type,omitempty"`
+
+	// RuleType defines how Ingress exposes collector receivers.
+	// IngressRuleTypePath ("path") exposes each receiver port on a unique path on single domain defined in Hostname.
+	// IngressRuleTypeSubdomain ("subdomain") exposes each receiver port on a unique subdomain of Hostname.
+	// Default is IngressRuleTypePath ("path").
+	RuleType IngressRuleType `json:"ruleType,omitempty"`
+
+	// Hostname by which the ingress proxy can be reached.
+	// +optional
+	Hostname string `json:"hostname,omitempty"`
+
+	// Annotations to add to ingress.
+	// e.g. 'cert-manager.io/cluster-issuer: "letsencrypt"'
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// TLS configuration.
+	// +optional
+	TLS []networkingv1.IngressTLS `json:"tls,omitempty"`
+
+	// IngressClassName is the name of an IngressClass cluster resource. Ingress
+	// controller implementations use this field to know whether they should be
+	// serving this Ingress resource.
+	// +optional
+	IngressClassName *string `json:"ingressClassName,omitempty"`
+
+	// Route is an OpenShift specific section that is only considered when
+	// type "route" is used.
+	// +optional
+	Route OpenShiftRoute `json:"route,omitempty"`
+}
+
+// OpenShiftRoute defines openshift route specific settings.
+type OpenShiftRoute struct {
+	// Termination indicates termination type. By default "edge" is used.
+	Termination TLSRouteTerminationType `json:"termination,omitempty"`
+}
+
+// OpenTelemetryCollectorSpec defines the desired state of OpenTelemetryCollector.
+type OpenTelemetryCollectorSpec struct {
+	// ManagementState defines if the CR should be managed by the operator or not.
+	// Default is managed.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:default:=managed
+	ManagementState ManagementStateType `json:"managementState,omitempty"`
+	// Resources to set on the OpenTelemetry Collector pods.
+	// +optional
+	Resources v1.ResourceRequirements `json:"resources,omitempty"`
+	// NodeSelector to schedule OpenTelemetry Collector pods.
+	// This is only relevant to daemonset, statefulset, and deployment mode
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Args is the set of arguments to pass to the OpenTelemetry Collector binary
+	// +optional
+	Args map[string]string `json:"args,omitempty"`
+	// FeatureGates is the list of feature gates of the OpenTelemetry Collector to enable, prefixed by an optional '+',
+	// or to disable, prefixed by '-', for example "+pkg.translator.prometheus.NormalizeName" or "-exporter.datadogexporter.metricremappingdisabled".
+	// They're passed to the binary with the --feature-gates flag, after the ones of the args, if any.
+	// +optional
+	// +listType=set
+	FeatureGates []string `json:"featureGates,omitempty"`
+	// Replicas is the number of pod instances for the underlying OpenTelemetry Collector. Set this if your are not using autoscaling
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+	// Autoscaler specifies the pod autoscaling configuration to use
+	// for the OpenTelemetryCollector workload.
+	//
+	// +optional
+	Autoscaler *AutoscalerSpec `json:"autoscaler,omitempty"`
+	// Rollout defines how the changes of the configuration are rolled out to the replicas of the collector.
+	// +optional
+	Rollout *Rollout `json:"rollout,omitempty"`
+	// PodDisruptionBudget specifies the pod disruption budget configuration to use
+	// for the OpenTelemetryCollector workload.
+	//
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+	// SecurityContext configures the container security context for
+	// the opentelemetry-collector container.
+	//
+	// In deployment, daemonset, or statefulset mode, this controls
+	// the security context settings for the primary application
+	// container.
+	//
+	// In sidecar mode, this controls the security context for the
+	// injected sidecar container.
+	//
+	// +optional
+	SecurityContext *v1.SecurityContext `json:"securityContext,omitempty"`
+	// ReadOnlyRootFilesystem runs the opentelemetry-collector container with a read-only root filesystem.
+	// The operator mounts emptyDir volumes on the directories the collector writes to: /tmp, and the
+	// directories of the file_storage extensions enabled in the configuration. Those volumes are also
+	// mounted when the SecurityContext makes the root filesystem read-only.
+	// +optional
+	ReadOnlyRootFilesystem bool `json:"readOnlyRootFilesystem,omitempty"`
+	// PodSecurityContext configures the pod security context for the
+	// opentelemetry-collector pod, when running as a deployment, daemonset,
+	// or statefulset.
+	//
+	// In sidecar mode, the opentelemetry-operator will ignore this setting.
+	//
+	// +optional
+	PodSecurityContext *v1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+	// Annotations is the set of annotations that will be attached to the workload of the Collector, i.e. its
+	// Deployment, DaemonSet or StatefulSet, and not to its pods. They take precedence over the annotations
+	// of the OpenTelemetryCollector, e.g. to configure a tool watching the workloads like Reloader.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// PodAnnotations is the set of annotations that will be attached to
+	// Collector and Target Allocator pods.
+	// +optional
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+	// ServiceMesh configures the annotations set on the Collector pods for the service mesh of the cluster,
+	// e.g. to keep the proxy from intercepting the telemetry sent to the receivers.
+	// In sidecar mode, the opentelemetry-operator will ignore this setting.
+	// +optional
+	ServiceMesh ServiceMeshSpec `json:"serviceMesh,omitempty"`
+	// Spiffe configures the mutual TLS of the receivers and exporters with the SVIDs issued by SPIRE.
+	// +optional
+	Spiffe SpiffeSpec `json:"spiffe,omitempty"`
+	// Telemetry configures the internal telemetry of the collector, i.e. its own metrics and logs. It is merged
+	// into the service::telemetry section of the configuration, and sets the ports of the monitoring Service.
+	// +optional
+	Telemetry TelemetrySpec `json:"telemetry,omitempty"`
+	// ServiceAnnotations is the set of annotations that will be attached to
+	// the Services of the Collector, e.g. to configure a cloud load balancer.
+	// +optional
+	ServiceAnnotations map[string]string `json:"serviceAnnotations,omitempty"`
+	// ServiceLabels is the set of labels that will be attached to the Services
+	// of the Collector. They don't override the labels set by the operator.
+	// +optional
+	ServiceLabels map[string]string `json:"serviceLabels,omitempty"`
+	// IPFamilies is the list of IP families (e.g. IPv4, IPv6) assigned to the Services of the Collector.
+	// Leave it empty to use the cluster default.
+	// +optional
+	// +listType=atomic
+	IPFamilies []v1.IPFamily `json:"ipFamilies,omitempty"`
+	// IPFamilyPolicy represents the dual-stack-ness requested by the Services of the Collector,
+	// one of SingleStack, PreferDualStack or RequireDualStack.
+	// +optional
+	IPFamilyPolicy *v1.IPFamilyPolicy `json:"ipFamilyPolicy,omitempty"`
+	// ServiceAccountAnnotations is the set of annotations that will be attached to
+	// the ServiceAccount created for the Collector, e.g. to bind a cloud IAM role.
+	// +optional
+	ServiceAccountAnnotations map[string]string `json:"serviceAccountAnnotations,omitempty"`
+	// ServiceAccountLabels is the set of labels that will be attached to the ServiceAccount
+	// created for the Collector. They don't override the labels set by the operator.
+	// +optional
+	ServiceAccountLabels map[string]string `json:"serviceAccountLabels,omitempty"`
+	// StandardLabels overrides the values of the standard app.kubernetes.io labels of the objects generated for the
+	// Collector and its TargetAllocator.
+	// +optional
+	StandardLabels *StandardLabels `json:"standardLabels,omitempty"`
+	// TargetAllocator indicates a value which determines whether to spawn a target allocation resource or not.
+	// +optional
+	TargetAllocator TargetAllocatorEmbedded `json:"targetAllocator,omitempty"`
+	// PrometheusCR makes the operator resolve the ServiceMonitors and PodMonitors of the Prometheus Operator into
+	// scrape configs of the prometheus receiver, for the collectors which don't use a TargetAllocator.
+	// The scrape configs are refreshed whenever the selected ServiceMonitors and PodMonitors change.
+	// +optional
+	PrometheusCR OpenTelemetryCollectorPrometheusCR `json:"prometheusCR,omitempty"`
+	// PrometheusSharding spreads the targets of the prometheus receiver across the replicas without a
+	// TargetAllocator: each replica only scrapes the targets whose address hashes to its ordinal, modulo the
+	// replicas. It relies on the apps.kubernetes.io/pod-index label of the pods, set since Kubernetes 1.28.
+	// Only available when the mode=statefulset, and not along with the TargetAllocator.
+	// +optional
+	PrometheusSharding bool `json:"prometheusSharding,omitempty"`
+	// GracefulScaleDown holds the scale down of the collector until the target allocator has reassigned the targets
+	// of the departing replicas, so that no target goes unscraped. Only available when the mode=statefulset and the
+	// target allocator is enabled, without autoscaler.
+	// +optional
+	GracefulScaleDown *GracefulScaleDownSpec `json:"gracefulScaleDown,omitempty"`
+	// Mode represents how the collector should be deployed (deployment, daemonset, statefulset or sidecar)
+	// +optional
+	Mode Mode `json:"mode,omitempty"`
+	// ServiceAccount indicates the name of an existing service account to use with this instance. When set,
+	// the operator will not automatically create a ServiceAccount for the collector.
+	// +optional
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+	// NameOverride replaces the name of the instance in the names of the resources created for the collector,
+	// which are named <nameOverride>-collector instead of <name>-collector.
+	// +optional
+	NameOverride string `json:"nameOverride,omitempty"`
+	// FullnameOverride replaces the names of the resources created for the collector, e.g. its workload,
+	// Service, ServiceAccount and ConfigMap. It takes precedence over the NameOverride.
+	// +optional
+	FullnameOverride string `json:"fullnameOverride,omitempty"`
+	// Image indicates the container image to use for the OpenTelemetry Collector.
+	// +optional
+	Image string `json:"image,omitempty"`
+	// UpgradeStrategy represents how the operator will handle upgrades to the CR when a newer version of the operator is deployed
+	// +optional
+	UpgradeStrategy UpgradeStrategy `json:"upgradeStrategy"`
+	// UpgradeWindows restricts the automatic upgrades, including the rollout of a new default image, to the given
+	// recurring periods of time. The upgrades are not restricted when no window is set.
+	// +optional
+	// +listType=atomic
+	UpgradeWindows []UpgradeWindow `json:"upgradeWindows,omitempty"`
+
+	// ImagePullPolicy indicates the pull policy to be used for retrieving the container image (Always, Never, IfNotPresent)
+	// +optional
+	ImagePullPolicy v1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	// Config is the structured configuration of the collector. Refer to the OpenTelemetry Collector documentation for details.
+	// +required
+	Config Config `json:"config"`
+	// VolumeMounts represents the mount points to use in the underlying collector deployment(s)
+	// +optional
+	// +listType=atomic
+	VolumeMounts []v1.VolumeMount `json:"volumeMounts,omitempty"`
+	// Ports allows a set of ports to be exposed by the underlying v1.Service. By default, the operator
+	// will attempt to infer the required ports by parsing the .Spec.Config property but this property can be
+	// used to open additional ports that can't be inferred by the operator, like for custom receivers.
+	// +optional
+	// +listType=atomic
+	Ports []PortsSpec `json:"ports,omitempty"`
+	// ENV vars to set on the OpenTelemetry Collector's Pods. These can then in certain cases be
+	// consumed in the config file for the Collector.
+	// +optional
+	Env []v1.EnvVar `json:"env,omitempty"`
+	// List of sources to populate environment variables on the OpenTelemetry Collector's Pods.
+	// These can then in certain cases be consumed in the config file for the Collector.
+	// +optional
+	EnvFrom []v1.EnvFromSource `json:"envFrom,omitempty"`
+	// VolumeClaimTemplates will provide stable storage using PersistentVolumes. Only available when the mode=statefulset.
+	// +optional
+	// +listType=atomic
+	VolumeClaimTemplates []v1.PersistentVolumeClaim `json:"volumeClaimTemplates,omitempty"`
+	// PersistentVolumeClaimRetentionPolicy describes whether the persistent volume claims created from the
+	// volumeClaimTemplates are deleted or retained when the collector is scaled down or deleted.
+	// Only available when the mode=statefulset.
+	// +optional
+	PersistentVolumeClaimRetentionPolicy *appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy `json:"persistentVolumeClaimRetentionPolicy,omitempty"`
+	// StatefulSet configures how the replicas are started and addressed. Only available when the mode=statefulset.
+	// +optional
+	StatefulSet StatefulSetSpec `json:"statefulSet,omitempty"`
+	// DaemonSet configures how the pods of a node reach the collector of the node. Only available when the
+	// mode=daemonset.
+	// +optional
+	DaemonSet DaemonSetSpec `json:"daemonSet,omitempty"`
+	// Toleration to schedule OpenTelemetry Collector pods.
+	// This is only relevant to daemonset, statefulset, and deployment mode
+	// +optional
+	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
+	// Volumes represents which volumes to use in the underlying collector deployment(s).
+	// +optional
+	// +listType=atomic
+	Volumes []v1.Volume `json:"volumes,omitempty"`
+	// SecretProviderClasses mounts the secrets of SecretProviderClasses of the secrets-store CSI driver in the
+	// Collector pods, so that the credentials used in the configuration can come from an external secret store.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	SecretProviderClasses []SecretProviderClassVolume `json:"secretProviderClasses,omitempty"`
+	// MountTLSSecrets mounts in the Collector pods the Secrets the TLS settings of the exporters read their files from,
+	// by naming convention: the files under /etc/otelcol/tls/<secret>/ are the keys of the Secret <secret> of the
+	// namespace of the OpenTelemetryCollector. The directories already mounted by VolumeMounts are left as they are.
+	// +optional
+	MountTLSSecrets bool `json:"mountTLSSecrets,omitempty"`
+	// Ingress is used to specify how OpenTelemetry Collector is exposed. This
+	// functionality is only available if one of the valid modes is set.
+	// Valid modes are: deployment, daemonset and statefulset.
+	// +optional
+	Ingress Ingress `json:"ingress,omitempty"`
+	// HostNetwork indicates if the pod should run in the host networking namespace.
+	// +optional
+	HostNetwork bool `json:"hostNetwork,omitempty"`
+	// If specified, indicates the pod's priority.
+	// If not specified, the pod priority will be default or zero if there is no
+	// default.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// If specified, indicates the pod's scheduling constraints
+	// +optional
+	Affinity *v1.Affinity `json:"affinity,omitempty"`
+	// NodeArchitectures restricts the scheduling of the collector pods to the nodes of these CPU architectures, which
+	// are added to each of the required node affinity terms. When the registries are queried for the digests of the
+	// images, the webhook rejects the collector images of multiple platforms which aren't built for all of them.
+	// +optional
+	// +listType=set
+	NodeArchitectures []NodeArchitecture `json:"nodeArchitectures,omitempty"`
+	// Actions that the management system should take in response to container lifecycle events. Cannot be updated.
+	// +optional
+	Lifecycle *v1.Lifecycle `json:"lifecycle,omitempty"`
+	// Drain delays the stop of the collector with a preStop hook, so that the clients stop sending telemetry to the
+	// pod before its receivers shut down, avoiding connection resets during rollouts. The termination grace period
+	// defaults to the delay plus 30 seconds. Ignored when the Lifecycle sets a preStop hook, and in sidecar mode.
+	// +optional
+	Drain *DrainSpec `json:"drain,omitempty"`
+	// Duration in seconds the pod needs to terminate gracefully upon probe failure.
+	// +optional
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+	// Liveness config for the OpenTelemetry Collector except the probe handler which is auto generated from the health extension of the collector.
+	// It is only effective when healthcheckextension is configured in the OpenTelemetry Collector pipeline.
+	// +optional
+	LivenessProbe *Probe `json:"livenessProbe,omitempty"`
+	// DisableProbes keeps the operator from adding the liveness probe to the Collector container, even when the
+	// healthcheckextension is configured, e.g. for distributions of the Collector which can't serve it.
+	// +optional
+	DisableProbes bool `json:"disableProbes,omitempty"`
+	// InitContainers allows injecting initContainers to the Collector's pod definition.
+	// These init containers can be used to fetch secrets for injection into the
+	// configuration from external sources, run added checks, etc. Any errors during the execution of
+	// an initContainer will lead to a restart of the Pod. More info:
+	// https://kubernetes.io/docs/concepts/workloads/pods/init-containers/
+	// +optional
+	InitContainers []v1.Container `json:"initContainers,omitempty"`
+
+	// AdditionalContainers allows injecting additional containers into the Collector's pod definition.
+	// These sidecar containers can be used for authentication proxies, log shipping sidecars, agents for shipping
+	// metrics to their cloud, or in general sidecars that do not support automatic injection. This option only
+	// applies to Deployment, DaemonSet, and StatefulSet deployment modes of the collector. It does not apply to the sidecar
+	// deployment mode. More info about sidecars:
+	// https://kubernetes.io/docs/tasks/configure-pod-container/share-process-namespace/
+	//
+	// Container names managed by the operator:
+	// * `otc-container`
+	//
+	// Overriding containers managed by the operator is outside the scope of what the maintainers will support and by
+	// doing so, you wil accept the risk of it breaking things.
+	//
+	// +optional
+	AdditionalContainers []v1.Container `json:"additionalContainers,omitempty"`
+
+	// ObservabilitySpec defines how telemetry data gets handled.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Observability"
+	Observability ObservabilitySpec `json:"observability,omitempty"`
+
+	// TopologySpreadConstraints embedded kubernetes pod configuration option,
+	// controls how pods are spread across your cluster among failure-domains
+	// such as regions, zones, nodes, and other user-defined topology domains
+	// https://kubernetes.io/docs/concepts/workloads/pods/pod-topology-spread-constraints/
+	// This is only relevant to statefulset, and deployment mode
+	// +optional
+	TopologySpreadConstraints []v1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// ConfigMaps is a list of ConfigMaps in the same namespace as the OpenTelemetryCollector
+	// object, which shall be mounted into the Collector Pods.
+	// Each ConfigMap will be added to the Collector's Deployments as a volume named `configmap-<configmap-name>`.
+	ConfigMaps []ConfigMapsSpec `json:"configmaps,omitempty"`
+
+	// DebugEndpoints exposes the endpoints of the pprof and remote_tap extensions enabled in the configuration
+	// through a dedicated Service, reachable only from an admin namespace.
+	// +optional
+	DebugEndpoints *DebugEndpointsSpec `json:"debugEndpoints,omitempty"`
+}
+
+// TargetAllocatorEmbedded defines the configurations for the Prometheus target allocator, embedded in the
+// OpenTelemetryCollector spec.
+type TargetAllocatorEmbedded struct {
+	// Replicas is the number of pod instances for the underlying TargetAllocator. This should only be set to a value
+	// other than 1 if a strategy that allows for high availability is chosen. Currently, the only allocation strategy
+	// that can be run in a high availability mode is consistent-hashing.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+	// NodeSelector to schedule OpenTelemetry TargetAllocator pods.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Resources to set on the OpenTelemetryTargetAllocator containers.
+	// +optional
+	Resources v1.ResourceRequirements `json:"resources,omitempty"`
+	// AllocationStrategy determines which strategy the target allocator should use for allocation.
+	// The current options are least-weighted and consistent-hashing. The default option is least-weighted
+	// +optional
+	AllocationStrategy TargetAllocatorAllocationStrategy `json:"allocationStrategy,omitempty"`
+	// FilterStrategy determines how to filter targets before allocating them among the collectors.
+	// The only current option is relabel-config (drops targets based on prom relabel_config).
+	// Filtering is disabled by default.
+	// +optional
+	FilterStrategy TargetAllocatorFilterStrategy `json:"filterStrategy,omitempty"`
+	// ServiceAccount indicates the name of an existing service account to use with this instance. When set,
+	// the operator will not automatically create a ServiceAccount for the TargetAllocator.
+	// +optional
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+	// IPFamilies is the list of IP families (e.g. IPv4, IPv6) assigned to the Services of the TargetAllocator.
+	// Leave it empty to use the cluster default.
+	// +optional
+	// +listType=atomic
+	IPFamilies []v1.IPFamily `json:"ipFamilies,omitempty"`
+	// IPFamilyPolicy represents the dual-stack-ness requested by the Services of the TargetAllocator,
+	// one of SingleStack, PreferDualStack or RequireDualStack.
+	// +optional
+	IPFamilyPolicy *v1.IPFamilyPolicy `json:"ipFamilyPolicy,omitempty"`
+	// LogLevel is the minimum level of the logs written by the TargetAllocator. Defaults to info.
+	// +optional
+	LogLevel LogLevel `json:"logLevel,omitempty"`
+	// LogFormat is the encoding of the logs written by the TargetAllocator, json or console. Defaults to json.
+	// +optional
+	LogFormat LogFormat `json:"logFormat,omitempty"`
+	// Image indicates the container image to use for the OpenTelemetry TargetAllocator.
+	// +optional
+	Image string `json:"image,omitempty"`
+	// Enabled indicates whether to use a target allocation mechanism for Prometheus targets or not.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// PrometheusCR defines the configuration for the retrieval of PrometheusOperator CRDs ( servicemonitor.monitoring.coreos.com/v1 and podmonitor.monitoring.coreos.com/v1 )  retrieval.
+	// All CR instances which the ServiceAccount has access to will be retrieved. This includes other namespaces.
+	// +optional
+	PrometheusCR TargetAllocatorPrometheusCR `json:"prometheusCR,omitempty"`
+	// TopologySpreadConstraints embedded kubernetes pod configuration option,
+	// controls how pods are spread across your cluster among failure-domains
+	// such as regions, zones, nodes, and other user-defined topology domains
+	// https://kubernetes.io/docs/concepts/workloads/pods/pod-topology-spread-constraints/
+	// +optional
+	TopologySpreadConstraints []v1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	// Toleration embedded kubernetes pod configuration option,
+	// controls how pods can be scheduled with matching taints
+	// +optional
+	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
+	// ENV vars to set on the OpenTelemetry TargetAllocator's Pods. These can then in certain cases be
+	// consumed in the config file for the TargetAllocator.
+	// +optional
+	Env []v1.EnvVar `json:"env,omitempty"`
+}
+
+// TargetAllocatorPrometheusCR configures Prometheus CustomResource handling in the Target Allocator.
+type TargetAllocatorPrometheusCR struct {
+	// Enabled indicates whether to use a PrometheusOperator custom resources as targets or not.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// Interval between consecutive scrapes. Equivalent to the same setting on the Prometheus CRD.
+	//
+	// Default: "30s"
+	// +kubebuilder:default:="30s"
+	// +kubebuilder:validation:Format:=duration
+	ScrapeInterval *metav1.Duration `json:"scrapeInterval,omitempty"`
+	// PodMonitors to be selected for target discovery.
+	// This is a map of {key,value} pairs. Each {key,value} in the map is going to exactly match a label in a
+	// PodMonitor's meta labels. The requirements are ANDed.
+	// +optional
+	PodMonitorSelector map[string]string `json:"podMonitorSelector,omitempty"`
+	// ServiceMonitors to be selected for target discovery.
+	// This is a map of {key,value} pairs. Each {key,value} in the map is going to exactly match a label in a
+	// ServiceMonitor's meta labels. The requirements are ANDed.
+	// +optional
+	ServiceMonitorSelector map[string]string `json:"serviceMonitorSelector,omitempty"`
+	// RemoteClusters are the clusters, besides the one running the TargetAllocator, whose ServiceMonitors and
+	// PodMonitors are retrieved. Their targets are allocated to the collectors along with the local ones, which
+	// must be able to reach them.
+	// +optional
+	// +listType=atomic
+	RemoteClusters []TargetAllocatorRemoteCluster `json:"remoteClusters,omitempty"`
+}
+
+// TargetAllocatorRemoteCluster is a remote cluster watched by the TargetAllocator.
+type TargetAllocatorRemoteCluster struct {
+	// Name of the cluster. It prefixes the names of the scrape jobs of the cluster and is set as the "cluster" label
+	// of its targets.
+	Name string `json:"name"`
+	// KubeconfigSecret selects the key of a Secret, in the namespace of the collector, holding the kubeconfig used
+	// to access the cluster.
+	KubeconfigSecret v1.SecretKeySelector `json:"kubeconfigSecret"`
+}
+
+// OpenTelemetryCollectorPrometheusCR defines how the ServiceMonitors and PodMonitors are resolved into the
+// configuration of the collector.
+type OpenTelemetryCollectorPrometheusCR struct {
+	// Enabled indicates whether the scrape configs of the prometheus receiver are generated from the
+	// ServiceMonitors and PodMonitors or not.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// Interval between consecutive scrapes of the endpoints which don't set their own interval.
+	//
+	// Default: "30s"
+	// +kubebuilder:default:="30s"
+	// +kubebuilder:validation:Format:=duration
+	ScrapeInterval *metav1.Duration `json:"scrapeInterval,omitempty"`
+	// PodMonitors to be resolved into scrape configs. An empty selector selects all the PodMonitors of the cluster.
+	// This is a map of {key,value} pairs. Each {key,value} in the map is going to exactly match a label in a
+	// PodMonitor's meta labels. The requirements are ANDed.
+	// +optional
+	PodMonitorSelector map[string]string `json:"podMonitorSelector,omitempty"`
+	// ServiceMonitors to be resolved into scrape configs. An empty selector selects all the ServiceMonitors of the cluster.
+	// This is a map of {key,value} pairs. Each {key,value} in the map is going to exactly match a label in a
+	// ServiceMonitor's meta labels. The requirements are ANDed.
+	// +optional
+	ServiceMonitorSelector map[string]string `json:"serviceMonitorSelector,omitempty"`
+}
+
+// ScaleSubresourceStatus defines the observed state of the OpenTelemetryCollector's
+// scale subresource.
+type ScaleSubresourceStatus struct {
+	// The selector used to match the OpenTelemetryCollector's
+	// deployment or statefulSet pods.
+	// +optional
+	Selector string `json:"selector,omitempty"`
+
+	// The total number non-terminated pods targeted by this
+	// OpenTelemetryCollector's deployment or statefulSet.
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// StatusReplicas is the number of pods targeted by this OpenTelemetryCollector's with a Ready Condition /
+	// Total number of non-terminated pods targeted by this OpenTelemetryCollector's (their labels match the selector).
+	// Deployment, Daemonset, StatefulSet.
+	// +optional
+	StatusReplicas string `json:"statusReplicas,omitempty"`
+}
+
+// OpenTelemetryCollectorStatus defines the observed state of OpenTelemetryCollector.
+type OpenTelemetryCollectorStatus struct {
+	// Scale is the OpenTelemetryCollector's scale subresource status.
+	// +optional
+	Scale ScaleSubresourceStatus `json:"scale,omitempty"`
+
+	// Version of the managed OpenTelemetry Collector (operand)
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Image indicates the container image to use for the OpenTelemetry Collector.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Endpoint is the address of the OTLP receiver of the collector behind its Service, e.g.
+	// otel-collector.observability.svc:4317, gRPC being preferred over HTTP. It's empty in the sidecar mode.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Conditions represent the latest available observations of the resource state, e.g. Ready, Progressing and Degraded.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Rollout reports the progress of the canary rollout of the configuration, when the canary strategy is used.
+	// +optional
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+	// Components lists the objects generated by the operator for the OpenTelemetryCollector, e.g. its workload,
+	// Services and ConfigMaps, as of the last successful reconciliation.
+	// +optional
+	// +listType=map
+	// +listMapKey=kind
+	// +listMapKey=name
+	Components []ComponentStatus `json:"components,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=otelcol;otelcols
+// +kubebuilder:subresource:status
+// +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.scale.replicas,selectorpath=.status.scale.selector
+// +kubebuilder:printcolumn:name="Mode",type="string",JSONPath=".spec.mode",description="Deployment Mode"
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.version",description="OpenTelemetry Version"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.scale.statusReplicas"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Image",type="string",JSONPath=".status.image"
+// +kubebuilder:printcolumn:name="Management",type="string",JSONPath=".spec.managementState",description="Management State"
+// +kubebuilder:printcolumn:name="Endpoint",type="string",JSONPath=".status.endpoint",description="OTLP Endpoint"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].reason",priority=1
+// +operator-sdk:csv:customresourcedefinitions:displayName="OpenTelemetry Collector"
+// This annotation provides a hint for OLM which resources are managed by OpenTelemetryCollector kind.
+// It's not mandatory to list all resources.
+// +operator-sdk:csv:customresourcedefinitions:resources={{Pod,v1},{Deployment,apps/v1},{DaemonSets,apps/v1},{StatefulSets,apps/v1},{ConfigMaps,v1},{Service,v1}}
+
+// OpenTelemetryCollector is the Schema for the opentelemetrycollectors API.
+type OpenTelemetryCollector struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OpenTelemetryCollectorSpec   `json:"spec,omitempty"`
+	Status OpenTelemetryCollectorStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OpenTelemetryCollectorList contains a list of OpenTelemetryCollector.
+type OpenTelemetryCollectorList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OpenTelemetryCollector `json:"items"`
+}
+
+// AutoscalerSpec defines the OpenTelemetryCollector's pod autoscaling specification.
+type AutoscalerSpec struct {
+	// MinReplicas sets a lower bound to the autoscaling feature.  Set this if your are using autoscaling. It must be at least 1
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// MaxReplicas sets an upper bound to the autoscaling feature. If MaxReplicas is set autoscaling is enabled.
+	// +optional
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+	// +optional
+	Behavior *autoscalingv2.HorizontalPodAutoscalerBehavior `json:"behavior,omitempty"`
+	// Metrics is meant to provide a customizable way to configure HPA metrics.
+	// currently the only supported custom metrics is type=Pod.
+	// Use TargetCPUUtilization or TargetMemoryUtilization instead if scaling on these common resource metrics.
+	// +optional
+	Metrics []MetricSpec `json:"metrics,omitempty"`
+	// TargetCPUUtilization sets the target average CPU used across all replicas.
+	// If average CPU exceeds this value, the HPA will scale up. Defaults to 90 percent.
+	// +optional
+	TargetCPUUtilization *int32 `json:"targetCPUUtilization,omitempty"`
+	// +optional
+	// TargetMemoryUtilization sets the target average memory utilization across all replicas
+	TargetMemoryUtilization *int32 `json:"targetMemoryUtilization,omitempty"`
+}
+
+// PodDisruptionBudgetSpec defines the OpenTelemetryCollector's pod disruption budget specification.
+type PodDisruptionBudgetSpec struct {
+	// An eviction is allowed if at least "minAvailable" pods selected by
+	// "selector" will still be available after the eviction, i.e. even in the
+	// absence of the evicted pod.  So for example you can prevent all voluntary
+	// evictions by specifying "100%".
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// An eviction is allowed if at most "maxUnavailable" pods selected by
+	// "selector" are unavailable after the eviction, i.e. even in absence of
+	// the evicted pod. For example, one can prevent all voluntary evictions
+	// by specifying 0. This is a mutually exclusive setting with "minAvailable".
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// MetricsConfigSpec defines a metrics config.
+type MetricsConfigSpec struct {
+	// EnableMetrics specifies if ServiceMonitor should be created for the OpenTelemetry Collector and Prometheus Exporters.
+	// The operator.observability.prometheus feature gate must be enabled to use this feature.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Create ServiceMonitors for OpenTelemetry Collector"
+	EnableMetrics bool `json:"enableMetrics,omitempty"`
+}
+
+// ObservabilitySpec defines how telemetry data gets handled.
+type ObservabilitySpec struct {
+	// Metrics defines the metrics configuration for operands.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Metrics Config"
+	Metrics MetricsConfigSpec `json:"metrics,omitempty"`
+
+	// Dashboards defines the Grafana dashboards generated for the operands.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	Dashboards DashboardsConfigSpec `json:"dashboards,omitempty"`
+}
+
+// DashboardsConfigSpec defines the Grafana dashboards generated for the OpenTelemetry Collector.
+type DashboardsConfigSpec struct {
+	// Enabled specifies if a ConfigMap holding a dashboard of the health of the OpenTelemetry Collector should be
+	// created, to be discovered by the dashboards sidecar of Grafana. The dashboard queries the metrics of the
+	// Collector scraped through its monitoring Service, e.g. by the ServiceMonitor created with enableMetrics.
+	// Ignored in the sidecar mode.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Labels are the labels of the ConfigMap the dashboards sidecar of Grafana discovers the dashboards with.
+	// Defaults to grafana_dashboard: "1", the default label of the sidecar.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// Probe defines the OpenTelemetry's pod probe config. Only Liveness probe is supported currently.
+type Probe struct {
+	// Number of seconds after the container has started before liveness probes are initiated.
+	// Defaults to 0 seconds. Minimum value is 0.
+	// More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes
+	// +optional
+	InitialDelaySeconds *int32 `json:"initialDelaySeconds,omitempty"`
+	// Number of seconds after which the probe times out.
+	// Defaults to 1 second. Minimum value is 1.
+	// More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+	// How often (in seconds) to perform the probe.
+	// Default to 10 seconds. Minimum value is 1.
+	// +optional
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
+	// Minimum consecutive successes for the probe to be considered successful after having failed.
+	// Defaults to 1. Must be 1 for liveness and startup. Minimum value is 1.
+	// +optional
+	SuccessThreshold *int32 `json:"successThreshold,omitempty"`
+	// Minimum consecutive failures for the probe to be considered failed after having succeeded.
+	// Defaults to 3. Minimum value is 1.
+	// +optional
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+	// Optional duration in seconds the pod needs to terminate gracefully upon probe failure.
+	// The grace period is the duration in seconds after the processes running in the pod are sent
+	// a termination signal and the time when the processes are forcibly halted with a kill signal.
+	// Set this value longer than the expected cleanup time for your process.
+	// If this value is nil, the pod's terminationGracePeriodSeconds will be used. Otherwise, this
+	// value overrides the value provided by the pod spec.
+	// Value must be non-negative integer. The value zero indicates stop immediately via
+	// the kill signal (no opportunity to shut down).
+	// This is a beta field and requires enabling ProbeTerminationGracePeriod feature gate.
+	// Minimum value is 1. spec.terminationGracePeriodSeconds is used if unset.
+	// +optional
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+}
+
+// MetricSpec defines a subset of metrics to be defined for the HPA's metric array
+// more metric type can be supported as needed.
+// See https://pkg.go.dev/k8s.io/api/autoscaling/v2#MetricSpec for reference.
+type MetricSpec struct {
+	Type autoscalingv2.MetricSourceType  `json:"type"`
+	Pods *autoscalingv2.PodsMetricSource `json:"pods,omitempty"`
+}
+
+// PortsSpec defines a port of the collector Services, along with the way it's exposed by the pods.
+type PortsSpec struct {
+	// HostPort exposes the port on the IP of the node, so that the applications of the node can send their
+	// telemetry to the collector of their node without running it on the network of the host.
+	// Only available when the mode=daemonset.
+	// +optional
+	HostPort int32 `json:"hostPort,omitempty"`
+	// ExposeOnService adds the port to the Services of the collector. Set it to false for the ports only opened on
+	// the container, e.g. for pprof, which mustn't be reachable through the Services. The port of the configuration
+	// with the same number is then left out of the Services as well.
+	//
+	// Default: true
+	// +optional
+	ExposeOnService *bool `json:"exposeOnService,omitempty"`
+
+	v1.ServicePort `json:",inline"`
+}
+
+// DebugEndpointsSpec defines how the endpoints of the debugging extensions of the collector are exposed.
+type DebugEndpointsSpec struct {
+	// Enabled indicates whether a Service and a NetworkPolicy are generated for the endpoints of the pprof and
+	// remote_tap extensions enabled in the configuration.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// AdminNamespace is the only namespace whose pods are allowed to reach the debugging endpoints.
+	// +optional
+	AdminNamespace string `json:"adminNamespace,omitempty"`
+	// ServiceAnnotations are added to the Service of the debugging endpoints only, typically to have an
+	// authenticating proxy or the service mesh enforce the authentication of the callers.
+	// +optional
+	ServiceAnnotations map[string]string `json:"serviceAnnotations,omitempty"`
+}
+
+// ConfigMapsSpec defines a ConfigMap to be mounted into the collector pods.
+type ConfigMapsSpec struct {
+	// Configmap defines name and path where the configMaps should be mounted.
+	Name      string `json:"name"`
+	MountPath string `json:"mountpath"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OpenTelemetryCollector{}, &OpenTelemetryCollectorList{})
+}
@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type (
+	// RolloutStrategy represents how the changes of the configuration are rolled out to the replicas of the collector.
+	// +kubebuilder:validation:Enum=immediate;canary
+	RolloutStrategy string
+
+	// RolloutPhase represents the progress of the canary rollout of the configuration.
+	RolloutPhase string
+)
+
+const (
+	// RolloutStrategyImmediate rolls the changes of the configuration out to all the replicas at once.
+	RolloutStrategyImmediate RolloutStrategy = "immediate"
+
+	// RolloutStrategyCanary rolls the changes of the configuration out to a canary workload first, and to all the
+	// replicas once the canary is promoted.
+	RolloutStrategyCanary RolloutStrategy = "canary"
+)
+
+const (
+	// RolloutPhaseStable means that all the replicas run the same configuration.
+	RolloutPhaseStable RolloutPhase = "Stable"
+
+	// RolloutPhaseProgressing means that the canary running the new configuration isn't ready yet.
+	RolloutPhaseProgressing RolloutPhase = "Progressing"
+
+	// RolloutPhaseCanaryReady means that the canary running the new configuration is ready and waits to be promoted.
+	RolloutPhaseCanaryReady RolloutPhase = "CanaryReady"
+
+	// RolloutPhaseRolledBack means that the canary was deleted, as it refused or dropped more data points than allowed
+	// by its analysis. The replicas keep the stable configuration until the configuration of the spec changes.
+	RolloutPhaseRolledBack RolloutPhase = "RolledBack"
+)
+
+// Rollout defines how the changes of the configuration are rolled out to the replicas of the collector.
+type Rollout struct {
+	// Strategy is how the changes of the configuration are rolled out. With the canary strategy, a change of the
+	// configuration first runs on a canary Deployment next to the replicas, which keep the previous configuration
+	// until the canary is promoted. Only supported in the deployment mode.
+	// +optional
+	// +kubebuilder:default:=immediate
+	Strategy RolloutStrategy `json:"strategy,omitempty"`
+	// CanaryPercentage is the number of replicas of the canary, as a percentage of the replicas of the collector,
+	// rounded up. Defaults to 10.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	CanaryPercentage *int32 `json:"canaryPercentage,omitempty"`
+	// PromotionDelay is how long the canary has to stay ready before it's promoted automatically. When it isn't
+	// set, the canary is promoted once the operator.opentelemetry.io/promote-canary annotation of the collector is
+	// set to its configuration hash, as reported in status.rollout.canaryConfigHash.
+	// +optional
+	PromotionDelay *metav1.Duration `json:"promotionDelay,omitempty"`
+	// Analysis rolls the canary back when it refuses or drops too many data points, as counted by the internal
+	// metrics of the collector.
+	// +optional
+	Analysis *RolloutAnalysis `json:"analysis,omitempty"`
+}
+
+// RolloutAnalysis defines the thresholds of the analysis of the canary. The internal metrics of the pods of the canary
+// are scraped during the window following its readiness, and the canary is rolled back as soon as they exceed a
+// threshold. It's not promoted automatically before the end of the window.
+type RolloutAnalysis struct {
+	// MaxRefused is the number of spans, metric points and log records the receivers of the canary may refuse.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxRefused *int64 `json:"maxRefused,omitempty"`
+	// MaxDropped is the number of spans, metric points and log records the processors of the canary may drop, or
+	// its exporters may fail to send.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxDropped *int64 `json:"maxDropped,omitempty"`
+	// Window is how long the canary is analyzed for once it's ready. Defaults to 5m.
+	// +optional
+	Window *metav1.Duration `json:"window,omitempty"`
+}
+
+// RolloutStatus reports the progress of the canary rollout of the configuration.
+type RolloutStatus struct {
+	// Phase is the progress of the rollout.
+	// +optional
+	Phase RolloutPhase `json:"phase,omitempty"`
+	// StableConfig is the configuration run by the replicas of the collector while a canary is in progress.
+	// +optional
+	StableConfig string `json:"stableConfig,omitempty"`
+	// StableConfigHash is the hash of the stable configuration.
+	// +optional
+	StableConfigHash string `json:"stableConfigHash,omitempty"`
+	// CanaryConfigHash is the hash of the configuration run by the canary, if any.
+	// +optional
+	CanaryConfigHash string `json:"canaryConfigHash,omitempty"`
+	// CanaryReplicas is the number of replicas of the canary.
+	// +optional
+	CanaryReplicas int32 `json:"canaryReplicas,omitempty"`
+	// CanaryReadyReplicas is the number of ready replicas of the canary.
+	// +optional
+	CanaryReadyReplicas int32 `json:"canaryReadyReplicas,omitempty"`
+	// CanaryReadySince is when the canary last became ready.
+	// +optional
+	CanaryReadySince *metav1.Time `json:"canaryReadySince,omitempty"`
+	// CanaryRefused is the number of data points refused by the receivers of the canary, when it's analyzed.
+	// +optional
+	CanaryRefused int64 `json:"canaryRefused,omitempty"`
+	// CanaryDropped is the number of data points dropped by the processors of the canary, or that its exporters
+	// failed to send, when it's analyzed.
+	// +optional
+	CanaryDropped int64 `json:"canaryDropped,omitempty"`
+	// Message is a human-readable message about the analysis of the canary, e.g. why it was rolled back.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+// SecretProviderClassVolume mounts the secrets of a SecretProviderClass of the secrets-store CSI driver, e.g. the
+// API keys of the exporters stored in Vault or in AWS Secrets Manager.
+type SecretProviderClassVolume struct {
+	// Name of the SecretProviderClass, in the namespace of the resource.
+	// +required
+	Name string `json:"name"`
+	// MountPath is the directory the secrets are mounted in. Defaults to /var/run/secrets-store/<name>.
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
+	// NodePublishSecretRef is the name of the Secret holding the credentials the provider uses to fetch the secrets,
+	// when the provider doesn't use the workload identity of the pods.
+	// +optional
+	NodePublishSecretRef string `json:"nodePublishSecretRef,omitempty"`
+}
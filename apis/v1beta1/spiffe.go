@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+// SpiffeSpec defines how the collector gets the X.509 SVIDs issued by SPIRE, to use them for mutual TLS.
+type SpiffeSpec struct {
+	// Enabled mounts the SPIFFE Workload API socket into the pods of the collector, adds the spiffe-helper
+	// containers writing the SVID and the trust bundle to files, and sets the TLS settings of the receivers
+	// and exporters to use them.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// CSIDriver is the name of the CSI driver mounting the Workload API socket. Defaults to csi.spiffe.io.
+	// +optional
+	CSIDriver string `json:"csiDriver,omitempty"`
+	// SocketName is the name of the Workload API socket in the directory mounted by the CSI driver.
+	// Defaults to spire-agent.sock.
+	// +optional
+	SocketName string `json:"socketName,omitempty"`
+	// HelperImage is the image of the spiffe-helper. Defaults to the one set on the operator.
+	// +optional
+	HelperImage string `json:"helperImage,omitempty"`
+	// Receivers are the names of the receivers requiring the clients to present an SVID of the trust domain.
+	// Defaults to the otlp receivers.
+	// +optional
+	// +listType=atomic
+	Receivers []string `json:"receivers,omitempty"`
+	// Exporters are the names of the exporters presenting the SVID of the collector to the servers.
+	// Defaults to the otlp and otlphttp exporters.
+	// +optional
+	// +listType=atomic
+	Exporters []string `json:"exporters,omitempty"`
+}
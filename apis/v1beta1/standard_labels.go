@@ -0,0 +1,31 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+// StandardLabels overrides the values of the standard app.kubernetes.io labels set by the operator on the objects it
+// generates, e.g. to follow the label conventions of an organization. The app.kubernetes.io/managed-by label can't be
+// overridden, the operator identifies the objects it manages with it.
+type StandardLabels struct {
+	// PartOf is the value of the app.kubernetes.io/part-of label, instead of "opentelemetry". As the label selects
+	// the pods, changing it recreates the workloads.
+	// +optional
+	PartOf string `json:"partOf,omitempty"`
+	// Name is the value of the app.kubernetes.io/name label, instead of the name of each object.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Version is the value of the app.kubernetes.io/version label, instead of the tag of the image.
+	// +optional
+	Version string `json:"version,omitempty"`
+}
@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import appsv1 "k8s.io/api/apps/v1"
+
+// StatefulSetSpec defines how the replicas of a collector in statefulset mode are started and addressed.
+type StatefulSetSpec struct {
+	// PodManagementPolicy controls whether the replicas are started and stopped all at once, with Parallel, or one
+	// after the other, with OrderedReady. Defaults to Parallel.
+	// The StatefulSet is recreated when it changes, as the policy of a StatefulSet is immutable.
+	// +optional
+	// +kubebuilder:validation:Enum=OrderedReady;Parallel
+	PodManagementPolicy appsv1.PodManagementPolicyType `json:"podManagementPolicy,omitempty"`
+	// MinReadySeconds is the number of seconds a new replica must be ready for before it's considered available,
+	// which holds the rollouts back while the collector warms up.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MinReadySeconds int32 `json:"minReadySeconds,omitempty"`
+	// PodServices creates a Service per replica, named after its pod, e.g. <name>-collector-0, so that the load
+	// balancers and the clients can address each replica individually. A Service is created for each of the
+	// replicas, or for each of the maximum replicas of the autoscaler.
+	// +optional
+	PodServices bool `json:"podServices,omitempty"`
+	// HeadlessService makes the headless Service govern the StatefulSet, and publish the addresses of the pods
+	// before they are ready, so that each replica is resolvable as <pod>.<headless service> from its start.
+	// The StatefulSet is recreated when it changes, as the service governing a StatefulSet is immutable.
+	// +optional
+	HeadlessService bool `json:"headlessService,omitempty"`
+}
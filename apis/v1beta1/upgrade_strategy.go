@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type (
+	// UpgradeStrategy represents how the operator will handle upgrades to the CR when a newer version of the operator is deployed
+	// +kubebuilder:validation:Enum=automatic;none
+	UpgradeStrategy string
+)
+
+const (
+	// UpgradeStrategyAutomatic specifies that the operator will automatically apply upgrades to the CR.
+	UpgradeStrategyAutomatic UpgradeStrategy = "automatic"
+
+	// UpgradeStrategyNone specifies that the operator will not apply any upgrades to the CR.
+	UpgradeStrategyNone UpgradeStrategy = "none"
+)
+
+// UpgradeWindow is a recurring period of time in which the operator may automatically upgrade an instance.
+type UpgradeWindow struct {
+	// Schedule is a cron expression, in the "minute hour day-of-month month day-of-week" format, telling when the
+	// window opens. The times are in UTC.
+	// +required
+	Schedule string `json:"schedule"`
+	// Duration is how long the window stays open.
+	// +required
+	Duration metav1.Duration `json:"duration"`
+}
@@ -18,6 +18,8 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -26,6 +28,8 @@ import (
 	"github.com/open-telemetry/opamp-go/client/types"
 	"github.com/open-telemetry/opamp-go/protobufs"
 	"go.uber.org/multierr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/clock"
 	"sigs.k8s.io/yaml"
 
@@ -54,9 +58,29 @@ type Agent struct {
 
 	done   chan struct{}
 	ticker *time.Ticker
+
+	instanceIDStore InstanceIDStore
+}
+
+// InstanceIDStore keeps the instance UID of the agent across restarts.
+type InstanceIDStore interface {
+	// Load returns the stored instance UID, and whether there's one.
+	Load(ctx context.Context) (ulid.ULID, bool, error)
+	// Save stores the instance UID.
+	Save(ctx context.Context, id ulid.ULID) error
+}
+
+// Option configures the optional behaviors of the Agent.
+type Option func(agent *Agent)
+
+// WithInstanceIDStore makes the Agent reuse the instance UID of the store, and store the ones it's given.
+func WithInstanceIDStore(store InstanceIDStore) Option {
+	return func(agent *Agent) {
+		agent.instanceIDStore = store
+	}
 }
 
-func NewAgent(logger logr.Logger, applier operator.ConfigApplier, config *config.Config, opampClient client.OpAMPClient) *Agent {
+func NewAgent(logger logr.Logger, applier operator.ConfigApplier, config *config.Config, opampClient client.OpAMPClient, opts ...Option) *Agent {
 	var t *time.Ticker
 	if config.HeartbeatInterval > 0 {
 		t = time.NewTicker(config.HeartbeatInterval)
@@ -74,6 +98,10 @@ func NewAgent(logger logr.Logger, applier operator.ConfigApplier, config *config
 		done:                make(chan struct{}, 1),
 		ticker:              t,
 	}
+	for _, opt := range opts {
+		opt(agent)
+	}
+	agent.loadInstanceID()
 
 	agent.logger.V(3).Info("Agent created",
 		"instanceId", agent.instanceId.String(),
@@ -83,6 +111,26 @@ func NewAgent(logger logr.Logger, applier operator.ConfigApplier, config *config
 	return agent
 }
 
+// loadInstanceID reuses the instance UID of the store, or stores the new instance UID of the agent. The agent keeps its
+// new instance UID when the store fails, it then appears as a new agent to the server.
+func (agent *Agent) loadInstanceID() {
+	if agent.instanceIDStore == nil {
+		return
+	}
+	ctx := context.Background()
+	id, found, err := agent.instanceIDStore.Load(ctx)
+	if err != nil {
+		agent.logger.Error(err, "failed to load the instance UID, using a new one")
+	}
+	if found {
+		agent.instanceId = id
+		return
+	}
+	if err := agent.instanceIDStore.Save(ctx, agent.instanceId); err != nil {
+		agent.logger.Error(err, "failed to store the instance UID")
+	}
+}
+
 // getHealth is called every heartbeat interval to report health.
 func (agent *Agent) getHealth() *protobufs.ComponentHealth {
 	healthMap, err := agent.generateComponentHealthMap()
@@ -102,8 +150,8 @@ func (agent *Agent) getHealth() *protobufs.ComponentHealth {
 	}
 }
 
-// generateComponentHealthMap allows the bridge to report the status of the collector pools it owns.
-// TODO: implement enhanced health messaging.
+// generateComponentHealthMap allows the bridge to report the status of the collector pools it owns. A collector is
+// healthy when all of its pods are ready, and the health of each pod is reported with its restarts.
 func (agent *Agent) generateComponentHealthMap() (map[string]*protobufs.ComponentHealth, error) {
 	cols, err := agent.applier.ListInstances()
 	if err != nil {
@@ -112,15 +160,69 @@ func (agent *Agent) generateComponentHealthMap() (map[string]*protobufs.Componen
 	healthMap := map[string]*protobufs.ComponentHealth{}
 	for _, col := range cols {
 		key := newCollectorKey(col.GetNamespace(), col.GetName())
+		pods, err := agent.applier.GetCollectorPods(col.GetName(), col.GetNamespace())
+		if err != nil {
+			return nil, err
+		}
+		var podsHealth map[string]*protobufs.ComponentHealth
+		healthy := len(pods) > 0
+		for _, pod := range pods {
+			if podsHealth == nil {
+				podsHealth = map[string]*protobufs.ComponentHealth{}
+			}
+			podHealth := agent.generatePodHealth(pod)
+			healthy = healthy && podHealth.Healthy
+			podsHealth[pod.GetName()] = podHealth
+		}
 		healthMap[key.String()] = &protobufs.ComponentHealth{
+			Healthy:            healthy,
 			StartTimeUnixNano:  uint64(col.ObjectMeta.GetCreationTimestamp().UnixNano()),
 			StatusTimeUnixNano: uint64(agent.clock.Now().UnixNano()),
 			Status:             col.Status.Scale.StatusReplicas,
+			ComponentHealthMap: podsHealth,
 		}
 	}
 	return healthMap, nil
 }
 
+// generatePodHealth reports the readiness of a collector pod. Its status is the phase of the pod, along with the
+// restarts of its containers, and its last error is the reason why a container isn't ready.
+func (agent *Agent) generatePodHealth(pod corev1.Pod) *protobufs.ComponentHealth {
+	health := &protobufs.ComponentHealth{
+		StatusTimeUnixNano: uint64(agent.clock.Now().UnixNano()),
+		Status:             string(pod.Status.Phase),
+	}
+	if pod.Status.StartTime != nil {
+		health.StartTimeUnixNano = uint64(pod.Status.StartTime.UnixNano())
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			health.Healthy = condition.Status == corev1.ConditionTrue
+		}
+	}
+	var restarts int32
+	var lastErrors []string
+	for _, container := range pod.Status.ContainerStatuses {
+		restarts += container.RestartCount
+		if container.Ready {
+			continue
+		}
+		switch {
+		case container.State.Waiting != nil:
+			lastErrors = append(lastErrors, fmt.Sprintf("container %s is waiting: %s", container.Name, container.State.Waiting.Reason))
+		case container.State.Terminated != nil:
+			lastErrors = append(lastErrors, fmt.Sprintf("container %s terminated: %s", container.Name, container.State.Terminated.Reason))
+		default:
+			lastErrors = append(lastErrors, fmt.Sprintf("container %s is not ready", container.Name))
+		}
+	}
+	if restarts > 0 {
+		health.Status = fmt.Sprintf("%s (%d restarts)", health.Status, restarts)
+	}
+	health.LastError = strings.Join(lastErrors, ", ")
+	return health
+}
+
 // onConnect is called when an agent is successfully connected to a server.
 func (agent *Agent) onConnect() {
 	agent.logger.V(3).Info("Connected to the server.")
@@ -214,10 +316,16 @@ func (agent *Agent) updateAgentIdentity(instanceId ulid.ULID) {
 		"old instanceId", agent.instanceId.String(),
 		"new instanceid", instanceId.String())
 	agent.instanceId = instanceId
+	if agent.instanceIDStore != nil {
+		if err := agent.instanceIDStore.Save(context.Background(), instanceId); err != nil {
+			agent.logger.Error(err, "failed to store the instance UID")
+		}
+	}
 }
 
 // getEffectiveConfig is called when a remote server needs to learn of the current effective configuration of each
-// collector the agent is managing.
+// collector the agent is managing. The configuration of each collector is the one rendered by the operator, when the
+// collector was reconciled, so that it reflects what is actually running.
 func (agent *Agent) getEffectiveConfig(ctx context.Context) (*protobufs.EffectiveConfig, error) {
 	instances, err := agent.applier.ListInstances()
 	if err != nil {
@@ -226,6 +334,14 @@ func (agent *Agent) getEffectiveConfig(ctx context.Context) (*protobufs.Effectiv
 	}
 	instanceMap := map[string]*protobufs.AgentConfigFile{}
 	for _, instance := range instances {
+		renderedConfig, err := agent.applier.GetRenderedConfig(instance.GetName(), instance.GetNamespace())
+		if err != nil {
+			agent.logger.Error(err, "failed to get the rendered config", "name", instance.GetName(), "namespace", instance.GetNamespace())
+			return nil, err
+		}
+		if len(renderedConfig) > 0 {
+			instance.Spec.Config = renderedConfig
+		}
 		marshaled, err := yaml.Marshal(instance)
 		if err != nil {
 			agent.logger.Error(err, "failed to marhsal config")
@@ -248,7 +364,7 @@ func (agent *Agent) getEffectiveConfig(ctx context.Context) (*protobufs.Effectiv
 // configured destination. The settings received will be used to initialize a reporter, shutting down any previously
 // running metrics reporting instances.
 func (agent *Agent) initMeter(settings *protobufs.TelemetryConnectionSettings) {
-	reporter, err := metrics.NewMetricReporter(agent.logger, settings, agent.config.GetAgentType(), agent.config.GetAgentVersion(), agent.instanceId)
+	reporter, err := metrics.NewMetricReporter(agent.logger, settings, agent.config.GetAgentType(), agent.config.GetAgentVersion(), agent.instanceId, agent.config.ReportingInterval)
 	if err != nil {
 		agent.logger.Error(err, "failed to create metric reporter")
 		return
@@ -266,11 +382,15 @@ func (agent *Agent) initMeter(settings *protobufs.TelemetryConnectionSettings) {
 //
 // For every key in the received remote configuration, the agent attempts to apply it to the connected
 // Kubernetes cluster. If an agent fails to apply a collector CRD, it will continue to the next entry. The agent will
-// store the received configuration hash regardless of application status as per the OpAMP spec.
+// store the received configuration hash regardless of application status as per the OpAMP spec. In dry-run mode, the
+// collectors are only validated and nothing is deleted. The outcome for every collector is reported to the status of
+// the OpAMPBridge resource.
 //
 // INVARIANT: The caller must verify that config isn't nil _and_ the configuration has changed between calls.
 func (agent *Agent) applyRemoteConfig(config *protobufs.AgentRemoteConfig) (*protobufs.RemoteConfigStatus, error) {
 	var multiErr error
+	var statuses []operator.RemoteConfigStatus
+	now := metav1.NewTime(agent.clock.Now())
 	// Apply changes from the received config map
 	for key, file := range config.Config.GetConfigMap() {
 		if len(key) == 0 || len(file.Body) == 0 {
@@ -281,11 +401,22 @@ func (agent *Agent) applyRemoteConfig(config *protobufs.AgentRemoteConfig) (*pro
 			multiErr = multierr.Append(multiErr, err)
 			continue
 		}
+		status := operator.RemoteConfigStatus{Collector: colKey.String(), LastUpdateTime: now}
 		err = agent.applier.Apply(colKey.name, colKey.namespace, file)
 		if err != nil {
 			multiErr = multierr.Append(multiErr, err)
+			status.Status = operator.RemoteConfigRejected
+			status.Message = err.Error()
+			statuses = append(statuses, status)
 			continue
 		}
+		if agent.config.DryRun {
+			status.Status = operator.RemoteConfigValidated
+			statuses = append(statuses, status)
+			continue
+		}
+		status.Status = operator.RemoteConfigApplied
+		statuses = append(statuses, status)
 		agent.appliedKeys[colKey] = true
 	}
 	// Check if anything was deleted
@@ -297,6 +428,12 @@ func (agent *Agent) applyRemoteConfig(config *protobufs.AgentRemoteConfig) (*pro
 			}
 		}
 	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Collector < statuses[j].Collector
+	})
+	if err := agent.applier.ReportRemoteConfigStatus(statuses); err != nil {
+		agent.logger.Error(err, "failed to report the remote config status")
+	}
 	agent.lastHash = config.GetConfigHash()
 	if multiErr != nil {
 		return &protobufs.RemoteConfigStatus{
@@ -305,6 +442,13 @@ func (agent *Agent) applyRemoteConfig(config *protobufs.AgentRemoteConfig) (*pro
 			ErrorMessage:         multiErr.Error(),
 		}, multiErr
 	}
+	if agent.config.DryRun {
+		// the configuration is valid, but it isn't in effect
+		return &protobufs.RemoteConfigStatus{
+			LastRemoteConfigHash: agent.lastHash,
+			Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_UNSET,
+		}, nil
+	}
 	return &protobufs.RemoteConfigStatus{
 		LastRemoteConfigHash: agent.lastHash,
 		Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED,
@@ -31,9 +31,12 @@ import (
 	"github.com/spf13/pflag"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	testingclock "k8s.io/utils/clock/testing"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
@@ -58,6 +61,7 @@ const (
 	agentTestFileBasicComponentsAllowedName = "testdata/agentbasiccomponentsallowed.yaml"
 	agentTestFileBatchNotAllowedName        = "testdata/agentbatchnotallowed.yaml"
 	agentTestFileNoProcessorsAllowedName    = "testdata/agentnoprocessorsallowed.yaml"
+	agentTestFileDryRunName                 = "testdata/agentdryrun.yaml"
 
 	// collectorStartTime is set to the result of a zero'd out creation timestamp
 	// read more here https://github.com/open-telemetry/opentelemetry-go/issues/4268
@@ -130,7 +134,7 @@ func (m *mockOpampClient) SetPackageStatuses(_ *protobufs.PackageStatuses) error
 	return nil
 }
 
-func getFakeApplier(t *testing.T, conf *config.Config) *operator.Client {
+func getFakeApplier(t *testing.T, conf *config.Config, objs ...ctrlclient.Object) *operator.Client {
 	schemeBuilder := runtime.NewSchemeBuilder(func(s *runtime.Scheme) error {
 		s.AddKnownTypes(v1alpha1.GroupVersion, &v1alpha1.OpenTelemetryCollector{}, &v1alpha1.OpenTelemetryCollectorList{})
 		metav1.AddToGroupVersion(s, v1alpha1.GroupVersion)
@@ -139,8 +143,10 @@ func getFakeApplier(t *testing.T, conf *config.Config) *operator.Client {
 	scheme := runtime.NewScheme()
 	err := schemeBuilder.AddToScheme(scheme)
 	require.NoError(t, err, "Should be able to add custom types")
-	c := fake.NewClientBuilder().WithScheme(scheme)
-	return operator.NewClient("test-bridge", l, c.Build(), conf.GetComponentsAllowed())
+	err = clientgoscheme.AddToScheme(scheme)
+	require.NoError(t, err, "Should be able to add the kubernetes types")
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...)
+	return operator.NewClient("test-bridge", l, c.Build(), conf.GetComponentsAllowed(), operator.WithDryRun(conf.DryRun))
 }
 
 func TestAgent_getHealth(t *testing.T) {
@@ -282,6 +288,108 @@ func TestAgent_getHealth(t *testing.T) {
 	}
 }
 
+func TestAgent_getHealthWithPods(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	podStartTime := metav1.NewTime(fakeClock.Now().Add(-time.Minute).Truncate(time.Second))
+	selectorLabels := map[string]string{
+		"app.kubernetes.io/managed-by": "opentelemetry-operator",
+		"app.kubernetes.io/instance":   testNamespace + "." + testCollectorName,
+		"app.kubernetes.io/component":  "opentelemetry-collector",
+	}
+	readyPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "collector-ready",
+			Namespace: testNamespace,
+			Labels:    selectorLabels,
+		},
+		Status: corev1.PodStatus{
+			Phase:     corev1.PodRunning,
+			StartTime: &podStartTime,
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+			},
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "otc-container", Ready: true},
+			},
+		},
+	}
+	crashingPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "collector-crashing",
+			Namespace: testNamespace,
+			Labels:    selectorLabels,
+		},
+		Status: corev1.PodStatus{
+			Phase:     corev1.PodRunning,
+			StartTime: &podStartTime,
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: corev1.ConditionFalse},
+			},
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name:         "otc-container",
+					RestartCount: 3,
+					State: corev1.ContainerState{
+						Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+					},
+				},
+			},
+		},
+	}
+	renderedConfig := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "collector-collector",
+			Namespace: testNamespace,
+			Labels:    selectorLabels,
+		},
+		Data: map[string]string{
+			"collector.yaml": "receivers:\n  otlp: {}\n",
+		},
+	}
+
+	mockClient := &mockOpampClient{}
+	conf := config.NewConfig(logr.Discard())
+	loadErr := config.LoadFromFile(conf, agentTestFileName)
+	require.NoError(t, loadErr, "should be able to load config")
+	applier := getFakeApplier(t, conf, readyPod, crashingPod, renderedConfig)
+	agent := NewAgent(l, applier, conf, mockClient)
+	agent.clock = fakeClock
+	err := agent.Start()
+	defer agent.Shutdown()
+	require.NoError(t, err, "should be able to start agent")
+	data, err := getMessageDataFromConfigFile(map[string]string{testCollectorKey: collectorBasicFile})
+	require.NoError(t, err, "should be able to load data")
+	agent.onMessage(context.Background(), data)
+
+	health := agent.getHealth()
+	require.Contains(t, health.ComponentHealthMap, testCollectorKey)
+	assert.Equal(t, &protobufs.ComponentHealth{
+		Healthy:            false,
+		StartTimeUnixNano:  collectorStartTime,
+		StatusTimeUnixNano: uint64(fakeClock.Now().UnixNano()),
+		ComponentHealthMap: map[string]*protobufs.ComponentHealth{
+			"collector-crashing": {
+				Healthy:            false,
+				StartTimeUnixNano:  uint64(podStartTime.UnixNano()),
+				StatusTimeUnixNano: uint64(fakeClock.Now().UnixNano()),
+				Status:             "Running (3 restarts)",
+				LastError:          "container otc-container is waiting: CrashLoopBackOff",
+			},
+			"collector-ready": {
+				Healthy:            true,
+				StartTimeUnixNano:  uint64(podStartTime.UnixNano()),
+				StatusTimeUnixNano: uint64(fakeClock.Now().UnixNano()),
+				Status:             "Running",
+			},
+		},
+	}, health.ComponentHealthMap[testCollectorKey])
+
+	// the effective config is the one rendered by the operator
+	effectiveConfig, err := agent.getEffectiveConfig(context.Background())
+	require.NoError(t, err, "should be able to get effective config")
+	assert.Contains(t, string(effectiveConfig.ConfigMap.GetConfigMap()[testCollectorKey].GetBody()), "config: |\n    receivers:\n      otlp: {}")
+}
+
 func TestAgent_onMessage(t *testing.T) {
 	type fields struct {
 		configFile string
@@ -616,6 +724,25 @@ func TestAgent_onMessage(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "dry run",
+			fields: fields{
+				configFile: agentTestFileDryRunName,
+			},
+			args: args{
+				ctx: context.Background(),
+				configFile: map[string]string{
+					testCollectorKey: collectorBasicFile,
+				},
+			},
+			want: want{
+				contents: map[string][]string{},
+				status: &protobufs.RemoteConfigStatus{
+					LastRemoteConfigHash: []byte(basicYamlConfigHash),
+					Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_UNSET,
+				},
+			},
+		},
 		{
 			name: "can delete existing collector",
 			fields: fields{
@@ -730,6 +857,49 @@ func Test_CanUpdateIdentity(t *testing.T) {
 	assert.Equal(t, agent.instanceId, newId)
 }
 
+// memoryInstanceIDStore is an InstanceIDStore keeping the instance UID in memory.
+type memoryInstanceIDStore struct {
+	id *ulid.ULID
+}
+
+func (s *memoryInstanceIDStore) Load(_ context.Context) (ulid.ULID, bool, error) {
+	if s.id == nil {
+		return ulid.ULID{}, false, nil
+	}
+	return *s.id, true, nil
+}
+
+func (s *memoryInstanceIDStore) Save(_ context.Context, id ulid.ULID) error {
+	s.id = &id
+	return nil
+}
+
+func Test_InstanceIDStore(t *testing.T) {
+	conf := config.NewConfig(logr.Discard())
+	loadErr := config.LoadFromFile(conf, agentTestFileName)
+	require.NoError(t, loadErr, "should be able to load config")
+	applier := getFakeApplier(t, conf)
+	store := &memoryInstanceIDStore{}
+
+	// The first agent stores its new instance UID
+	first := NewAgent(l, applier, conf, &mockOpampClient{}, WithInstanceIDStore(store))
+	require.NotNil(t, store.id)
+	assert.Equal(t, first.instanceId, *store.id)
+
+	// The agent of the next start reuses it
+	restarted := NewAgent(l, applier, conf, &mockOpampClient{}, WithInstanceIDStore(store))
+	assert.Equal(t, first.instanceId, restarted.instanceId)
+
+	// The instance UID given by the server is stored
+	newId := ulid.MustNew(ulid.MaxTime(), ulid.Monotonic(rand.Reader, 0))
+	restarted.onMessage(context.Background(), &types.MessageData{
+		AgentIdentification: &protobufs.AgentIdentification{
+			NewInstanceUid: newId.String(),
+		},
+	})
+	assert.Equal(t, newId, *store.id)
+}
+
 func getMessageDataFromConfigFile(filemap map[string]string) (*types.MessageData, error) {
 	toReturn := &types.MessageData{}
 	if filemap == nil {
@@ -31,6 +31,7 @@ import (
 	"github.com/spf13/pflag"
 	"gopkg.in/yaml.v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
@@ -46,6 +47,10 @@ import (
 
 const (
 	agentType = "io.opentelemetry.operator-opamp-bridge"
+
+	bridgeNameEnvVar      = "OPAMP_BRIDGE_NAME"
+	bridgeNamespaceEnvVar = "OTELCOL_NAMESPACE"
+	bridgeUIDEnvVar       = "OPAMP_BRIDGE_UID"
 )
 
 var (
@@ -96,7 +101,24 @@ type Config struct {
 	Endpoint          string              `yaml:"endpoint"`
 	Capabilities      map[Capability]bool `yaml:"capabilities"`
 	HeartbeatInterval time.Duration       `yaml:"heartbeatInterval,omitempty"`
-	Name              string              `yaml:"name,omitempty"`
+	// ReportingInterval is the interval at which the own metrics of the bridge are exported.
+	ReportingInterval time.Duration `yaml:"reportingInterval,omitempty"`
+	Name              string        `yaml:"name,omitempty"`
+	// DryRun makes the bridge validate the remote configurations without applying them to the collectors.
+	DryRun bool `yaml:"dryRun,omitempty"`
+	// CollectorSelector restricts the collectors managed by the bridge to the ones whose labels match it, in the
+	// string form of label selectors, e.g. "team=a,env in (dev,test)". All the collectors are managed when it's empty.
+	CollectorSelector string `yaml:"collectorSelector,omitempty"`
+	// InstanceIDConfigMap is the name of the ConfigMap of the namespace of the bridge in which it keeps its instance
+	// UID across restarts. A new instance UID is generated on each start when it's empty.
+	InstanceIDConfigMap string `yaml:"instanceIDConfigMap,omitempty"`
+
+	// BridgeName and BridgeNamespace identify the OpAMPBridge resource receiving the status of the remote
+	// configurations. They are set by the operator through the environment.
+	BridgeName      string `yaml:"-"`
+	BridgeNamespace string `yaml:"-"`
+	// BridgeUID is the UID of the OpAMPBridge resource, which owns the ConfigMap of the instance UID.
+	BridgeUID string `yaml:"-"`
 }
 
 func NewConfig(logger logr.Logger) *Config {
@@ -127,6 +149,18 @@ func (c *Config) GetComponentsAllowed() map[string]map[string]bool {
 	return m
 }
 
+// GetCollectorSelector parses the collector selector, it returns a selector matching all the collectors when it isn't set.
+func (c *Config) GetCollectorSelector() (labels.Selector, error) {
+	if len(c.CollectorSelector) == 0 {
+		return labels.Everything(), nil
+	}
+	selector, err := labels.Parse(c.CollectorSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid collector selector %q: %w", c.CollectorSelector, err)
+	}
+	return selector, nil
+}
+
 func (c *Config) GetCapabilities() protobufs.AgentCapabilities {
 	var capabilities int32
 	for capability, enabled := range c.Capabilities {
@@ -219,6 +253,7 @@ func Load(logger logr.Logger, flagSet *pflag.FlagSet) (*Config, error) {
 	if err != nil {
 		return nil, err
 	}
+	LoadFromEnv(cfg)
 
 	return cfg, nil
 }
@@ -245,24 +280,45 @@ func LoadFromCLI(target *Config, flagSet *pflag.FlagSet) error {
 	}
 	target.ClusterConfig = clusterConfig
 
-	target.ListenAddr, err = getListenAddr(flagSet)
-	if err != nil {
-		return err
+	// the options of the config file take precedence over the default values of the flags
+	if target.ListenAddr == "" || flagSet.Changed(listenAddrFlagName) {
+		target.ListenAddr, err = getListenAddr(flagSet)
+		if err != nil {
+			return err
+		}
 	}
 
-	target.HeartbeatInterval, err = getHeartbeatInterval(flagSet)
-	if err != nil {
-		return err
+	if target.HeartbeatInterval == 0 || flagSet.Changed(heartbeatIntervalFlagName) {
+		target.HeartbeatInterval, err = getHeartbeatInterval(flagSet)
+		if err != nil {
+			return err
+		}
 	}
 
-	target.Name, err = getName(flagSet)
-	if err != nil {
-		return err
+	if target.ReportingInterval == 0 || flagSet.Changed(reportingIntervalFlagName) {
+		target.ReportingInterval, err = getReportingInterval(flagSet)
+		if err != nil {
+			return err
+		}
+	}
+
+	if target.Name == "" || flagSet.Changed(nameFlagName) {
+		target.Name, err = getName(flagSet)
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// LoadFromEnv reads the OpAMPBridge resource running the bridge from the environment set by the operator.
+func LoadFromEnv(cfg *Config) {
+	cfg.BridgeName = os.Getenv(bridgeNameEnvVar)
+	cfg.BridgeNamespace = os.Getenv(bridgeNamespaceEnvVar)
+	cfg.BridgeUID = os.Getenv(bridgeUIDEnvVar)
+}
+
 func LoadFromFile(cfg *Config, configFile string) error {
 	yamlFile, err := os.ReadFile(configFile)
 	if err != nil {
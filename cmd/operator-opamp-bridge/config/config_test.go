@@ -21,6 +21,8 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 func TestLoad(t *testing.T) {
@@ -67,6 +69,7 @@ func TestLoad(t *testing.T) {
 				RootLogger:        logr.Discard(),
 				Endpoint:          "http://127.0.0.1:4320/v1/opamp",
 				HeartbeatInterval: 45 * time.Second,
+				ReportingInterval: time.Minute,
 				Name:              "http-test-bridge",
 				Capabilities: map[Capability]bool{
 					AcceptsRemoteConfig:            true,
@@ -151,3 +154,20 @@ func TestLoad(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCollectorSelector(t *testing.T) {
+	cfg := &Config{}
+	selector, err := cfg.GetCollectorSelector()
+	require.NoError(t, err)
+	assert.True(t, selector.Empty())
+
+	cfg.CollectorSelector = "team=a,env in (dev,test)"
+	selector, err = cfg.GetCollectorSelector()
+	require.NoError(t, err)
+	assert.True(t, selector.Matches(labels.Set{"team": "a", "env": "dev"}))
+	assert.False(t, selector.Matches(labels.Set{"team": "b", "env": "dev"}))
+
+	cfg.CollectorSelector = "team in a"
+	_, err = cfg.GetCollectorSelector()
+	assert.ErrorContains(t, err, "invalid collector selector")
+}
@@ -32,8 +32,10 @@ const (
 	listenAddrFlagName        = "listen-addr"
 	kubeConfigPathFlagName    = "kubeconfig-path"
 	heartbeatIntervalFlagName = "heartbeat-interval"
+	reportingIntervalFlagName = "reporting-interval"
 	nameFlagName              = "name"
 	defaultHeartbeatInterval  = 30 * time.Second
+	defaultReportingInterval  = 5 * time.Second
 )
 
 // We can't bind this flag to our FlagSet, so we need to handle it separately.
@@ -45,6 +47,7 @@ func GetFlagSet(errorHandling pflag.ErrorHandling) *pflag.FlagSet {
 	flagSet.String(listenAddrFlagName, ":8080", "The address where this service serves.")
 	flagSet.String(kubeConfigPathFlagName, filepath.Join(homedir.HomeDir(), ".kube", "config"), "absolute path to the KubeconfigPath file.")
 	flagSet.Duration(heartbeatIntervalFlagName, defaultHeartbeatInterval, "The interval to use for sending a heartbeat. Setting it to 0 disables the heartbeat.")
+	flagSet.Duration(reportingIntervalFlagName, defaultReportingInterval, "The interval to use for exporting the own metrics of the bridge.")
 	flagSet.String(nameFlagName, opampBridgeName, "The name of the bridge to use for querying managed collectors.")
 	zapFlagSet := flag.NewFlagSet("", flag.ErrorHandling(errorHandling))
 	zapCmdLineOpts.BindFlags(zapFlagSet)
@@ -56,6 +59,10 @@ func getHeartbeatInterval(flagset *pflag.FlagSet) (time.Duration, error) {
 	return flagset.GetDuration(heartbeatIntervalFlagName)
 }
 
+func getReportingInterval(flagset *pflag.FlagSet) (time.Duration, error) {
+	return flagset.GetDuration(reportingIntervalFlagName)
+}
+
 func getConfigFilePath(flagSet *pflag.FlagSet) (string, error) {
 	return flagSet.GetString(configFilePathFlagName)
 }
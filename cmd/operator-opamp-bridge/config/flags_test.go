@@ -70,6 +70,12 @@ func TestFlagGetters(t *testing.T) {
 			expectedValue: 45 * time.Second,
 			getterFunc:    func(fs *pflag.FlagSet) (interface{}, error) { return getHeartbeatInterval(fs) },
 		},
+		{
+			name:          "GetReportingInterval",
+			flagArgs:      []string{"--" + reportingIntervalFlagName, "1m"},
+			expectedValue: time.Minute,
+			getterFunc:    func(fs *pflag.FlagSet) (interface{}, error) { return getReportingInterval(fs) },
+		},
 		{
 			name:        "InvalidFlag",
 			flagArgs:    []string{"--invalid-flag", "value"},
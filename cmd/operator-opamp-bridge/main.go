@@ -19,6 +19,7 @@ import (
 	"os/signal"
 
 	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/agent"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/config"
@@ -46,10 +47,28 @@ func main() {
 		l.Error(kubeErr, "Couldn't create kubernetes client")
 		os.Exit(1)
 	}
-	operatorClient := operator.NewClient(cfg.Name, l.WithName("operator-client"), kubeClient, cfg.GetComponentsAllowed())
+	collectorSelector, selectorErr := cfg.GetCollectorSelector()
+	if selectorErr != nil {
+		l.Error(selectorErr, "Unable to load configuration")
+		os.Exit(1)
+	}
+	operatorClient := operator.NewClient(
+		cfg.Name,
+		l.WithName("operator-client"),
+		kubeClient,
+		cfg.GetComponentsAllowed(),
+		operator.WithDryRun(cfg.DryRun),
+		operator.WithStatusReporting(cfg.BridgeName, cfg.BridgeNamespace),
+		operator.WithCollectorSelector(collectorSelector),
+	)
 
 	opampClient := cfg.CreateClient()
-	opampAgent := agent.NewAgent(l.WithName("agent"), operatorClient, cfg, opampClient)
+	var agentOpts []agent.Option
+	if cfg.InstanceIDConfigMap != "" && cfg.BridgeNamespace != "" {
+		store := operator.NewInstanceIDStore(kubeClient, cfg.InstanceIDConfigMap, cfg.BridgeNamespace, cfg.BridgeName, types.UID(cfg.BridgeUID))
+		agentOpts = append(agentOpts, agent.WithInstanceIDStore(store))
+	}
+	opampAgent := agent.NewAgent(l.WithName("agent"), operatorClient, cfg, opampClient, agentOpts...)
 
 	if err := opampAgent.Start(); err != nil {
 		l.Error(err, "Cannot start OpAMP client")
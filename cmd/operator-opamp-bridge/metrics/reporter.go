@@ -51,10 +51,11 @@ type MetricReporter struct {
 	processCpuTime        metric.Float64ObservableCounter
 }
 
-// NewMetricReporter creates an OTLP/HTTP client to the destination address supplied by the server.
+// NewMetricReporter creates an OTLP/HTTP client to the destination address supplied by the server, which the metrics
+// are exported to at the given interval.
 // TODO: do more validation on the endpoint, allow for gRPC.
 // TODO: set global provider and add more metrics to be reported.
-func NewMetricReporter(logger logr.Logger, dest *protobufs.TelemetryConnectionSettings, agentType string, agentVersion string, instanceId ulid.ULID) (*MetricReporter, error) {
+func NewMetricReporter(logger logr.Logger, dest *protobufs.TelemetryConnectionSettings, agentType string, agentVersion string, instanceId ulid.ULID, interval time.Duration) (*MetricReporter, error) {
 
 	if dest.DestinationEndpoint == "" {
 		return nil, fmt.Errorf("metric destination must specify DestinationEndpoint")
@@ -98,7 +99,7 @@ func NewMetricReporter(logger logr.Logger, dest *protobufs.TelemetryConnectionSe
 
 	provider := sdkmetric.NewMeterProvider(
 		sdkmetric.WithResource(resource),
-		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(client, sdkmetric.WithInterval(5*time.Second))))
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(client, sdkmetric.WithInterval(interval))))
 
 	reporter := &MetricReporter{
 		logger: logger,
@@ -21,6 +21,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/open-telemetry/opamp-go/protobufs"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
@@ -50,6 +51,17 @@ type ConfigApplier interface {
 
 	// Delete attempts to delete an OpenTelemetryCollector object given a name and namespace.
 	Delete(name string, namespace string) error
+
+	// GetCollectorPods retrieves the pods running an OpenTelemetryCollector given a name and namespace.
+	GetCollectorPods(name string, namespace string) ([]corev1.Pod, error)
+
+	// GetRenderedConfig retrieves the configuration rendered by the operator for an OpenTelemetryCollector given a name
+	// and namespace.
+	GetRenderedConfig(name string, namespace string) (string, error)
+
+	// ReportRemoteConfigStatus writes the outcome of the last remote configuration of each collector to the status of
+	// the OpAMPBridge resource of the bridge.
+	ReportRemoteConfigStatus(statuses []RemoteConfigStatus) error
 }
 
 type Client struct {
@@ -58,18 +70,54 @@ type Client struct {
 	k8sClient         client.Client
 	close             chan bool
 	name              string
+	dryRun            bool
+	bridgeName        string
+	bridgeNamespace   string
+	collectorSelector labels.Selector
 }
 
 var _ ConfigApplier = &Client{}
 
-func NewClient(name string, log logr.Logger, c client.Client, componentsAllowed map[string]map[string]bool) *Client {
-	return &Client{
+// ClientOption configures the optional behaviors of the Client.
+type ClientOption func(c *Client)
+
+// WithDryRun makes the Client validate the collectors, including with the webhooks of the operator, without
+// persisting them.
+func WithDryRun(dryRun bool) ClientOption {
+	return func(c *Client) {
+		c.dryRun = dryRun
+	}
+}
+
+// WithStatusReporting makes the Client report the status of the remote configurations to the given OpAMPBridge.
+func WithStatusReporting(bridgeName, bridgeNamespace string) ClientOption {
+	return func(c *Client) {
+		c.bridgeName = bridgeName
+		c.bridgeNamespace = bridgeNamespace
+	}
+}
+
+// WithCollectorSelector restricts the collectors the Client lists and modifies to the ones matching the selector, so
+// that several bridges manage disjoint sets of collectors.
+func WithCollectorSelector(selector labels.Selector) ClientOption {
+	return func(c *Client) {
+		c.collectorSelector = selector
+	}
+}
+
+func NewClient(name string, log logr.Logger, c client.Client, componentsAllowed map[string]map[string]bool, opts ...ClientOption) *Client {
+	cl := &Client{
 		log:               log,
 		componentsAllowed: componentsAllowed,
 		k8sClient:         c,
 		close:             make(chan bool, 1),
 		name:              name,
+		collectorSelector: labels.Everything(),
+	}
+	for _, opt := range opts {
+		opt(cl)
 	}
+	return cl
 }
 
 func (c Client) labelSetContainsLabel(instance *v1alpha1.OpenTelemetryCollector, label, value string) bool {
@@ -82,6 +130,11 @@ func (c Client) labelSetContainsLabel(instance *v1alpha1.OpenTelemetryCollector,
 	return false
 }
 
+// selects tells whether the collector is one of the collectors managed by the bridge.
+func (c Client) selects(instance *v1alpha1.OpenTelemetryCollector) bool {
+	return c.collectorSelector.Matches(labels.Set(instance.GetLabels()))
+}
+
 func (c Client) create(ctx context.Context, name string, namespace string, collector *v1alpha1.OpenTelemetryCollector) error {
 	// Set the defaults
 	collector.Default()
@@ -101,6 +154,10 @@ func (c Client) create(ctx context.Context, name string, namespace string, colle
 	if warnings != nil {
 		c.log.Info("Some warnings present on collector", "warnings", warnings)
 	}
+	if c.dryRun {
+		c.log.Info("Validating collector creation")
+		return c.k8sClient.Create(ctx, collector, client.DryRunAll)
+	}
 	c.log.Info("Creating collector")
 	return c.k8sClient.Create(ctx, collector)
 }
@@ -115,6 +172,10 @@ func (c Client) update(ctx context.Context, old *v1alpha1.OpenTelemetryCollector
 	if warnings != nil {
 		c.log.Info("Some warnings present on collector", "warnings", warnings)
 	}
+	if c.dryRun {
+		c.log.Info("Validating collector update")
+		return c.k8sClient.Update(ctx, new, client.DryRunAll)
+	}
 	c.log.Info("Updating collector")
 	return c.k8sClient.Update(ctx, new)
 }
@@ -126,6 +187,19 @@ func (c Client) Apply(name string, namespace string, configmap *protobufs.AgentC
 	if err != nil {
 		return err
 	}
+	instance, err := c.GetInstance(name, namespace)
+	if err != nil {
+		return err
+	}
+	if len(collector.Spec.Config) == 0 && isCollectorConfig(configmap.Body) {
+		// the remote configuration is the configuration of the collector itself, which is written back to the
+		// spec.config of the existing collector
+		if instance == nil {
+			return errors.NewBadRequest(fmt.Sprintf("the collector %s/%s must exist to receive a configuration", namespace, name))
+		}
+		collector = *instance.DeepCopy()
+		collector.Spec.Config = string(configmap.Body)
+	}
 	if len(collector.Spec.Config) == 0 {
 		return errors.NewBadRequest("Must supply valid configuration")
 	}
@@ -138,10 +212,6 @@ func (c Client) Apply(name string, namespace string, configmap *protobufs.AgentC
 	}
 	updatedCollector := collector.DeepCopy()
 	ctx := context.Background()
-	instance, err := c.GetInstance(name, namespace)
-	if err != nil {
-		return err
-	}
 	// If either the received collector or the collector being created has reporting set to true, it should be denied
 	if c.labelSetContainsLabel(instance, ReportingLabelKey, "true") ||
 		c.labelSetContainsLabel(updatedCollector, ReportingLabelKey, "true") {
@@ -154,6 +224,15 @@ func (c Client) Apply(name string, namespace string, configmap *protobufs.AgentC
 		!c.labelSetContainsLabel(updatedCollector, ManagedLabelKey, c.name) {
 		return errors.NewBadRequest("cannot modify a collector that doesn't have `opentelemetry.io/opamp-managed: true | <bridge-name>` set")
 	}
+	// the labels of an existing collector are kept on update, so only the ones of a new collector come from the
+	// received configuration
+	target := updatedCollector
+	if instance != nil {
+		target = instance
+	}
+	if !c.selects(target) {
+		return errors.NewBadRequest(fmt.Sprintf("cannot modify a collector that doesn't match the collector selector `%s` of the bridge", c.collectorSelector))
+	}
 	if instance == nil {
 		return c.create(ctx, name, namespace, updatedCollector)
 	}
@@ -195,9 +274,13 @@ func (c Client) ListInstances() ([]v1alpha1.OpenTelemetryCollector, error) {
 	if err != nil {
 		return nil, err
 	}
-	items := append(result.Items, reportingCollectors.Items...)
-	for i := range items {
-		items[i].SetManagedFields(nil)
+	var items []v1alpha1.OpenTelemetryCollector
+	for _, item := range append(result.Items, reportingCollectors.Items...) {
+		if !c.selects(&item) {
+			continue
+		}
+		item.SetManagedFields(nil)
+		items = append(items, item)
 	}
 
 	return items, nil
@@ -248,3 +331,21 @@ func (c Client) validate(spec v1alpha1.OpenTelemetryCollectorSpec) ([]string, er
 	}
 	return invalidComponents, nil
 }
+
+// isCollectorConfig returns whether the body of a remote configuration is the configuration of a collector, rather
+// than an OpenTelemetryCollector resource.
+func isCollectorConfig(body []byte) bool {
+	var config map[string]interface{}
+	if err := yaml.Unmarshal(body, &config); err != nil {
+		return false
+	}
+	if _, ok := config["spec"]; ok {
+		return false
+	}
+	for _, key := range []string{"receivers", "exporters", "service"} {
+		if _, ok := config[key]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -24,6 +24,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -206,6 +208,48 @@ func Test_collectorUpdate(t *testing.T) {
 	assert.Contains(t, allInstances, *updatedInstance)
 }
 
+func Test_collectorSelector(t *testing.T) {
+	namespace := "testing"
+	fakeClient := getFakeClient(t)
+	for _, team := range []string{"a", "b"} {
+		col := &v1alpha1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "team-" + team,
+				Namespace: namespace,
+				Labels:    map[string]string{ManagedLabelKey: "true", "team": team},
+			},
+			Spec: v1alpha1.OpenTelemetryCollectorSpec{Config: "receivers: {}"},
+		}
+		require.NoError(t, fakeClient.Create(context.Background(), col))
+	}
+	selector, err := labels.Parse("team=a")
+	require.NoError(t, err)
+	c := NewClient(bridgeName, clientLogger, fakeClient, nil, WithCollectorSelector(selector))
+
+	// Only the selected collectors are listed
+	allInstances, err := c.ListInstances()
+	require.NoError(t, err, "Should be able to list all collectors")
+	require.Len(t, allInstances, 1)
+	assert.Equal(t, "team-a", allInstances[0].Name)
+
+	colConfig, err := loadConfig("testdata/collector.yaml")
+	require.NoError(t, err, "Should be no error on loading test configuration")
+	configmap := &protobufs.AgentConfigFile{
+		Body:        colConfig,
+		ContentType: "yaml",
+	}
+	// Only the selected collectors are modified
+	require.NoError(t, c.Apply("team-a", namespace, configmap), "Should update the selected collector")
+	err = c.Apply("team-b", namespace, configmap)
+	assert.ErrorContains(t, err, "doesn't match the collector selector `team=a` of the bridge")
+	// New collectors must be selected too, which the test collector without the team label isn't
+	err = c.Apply("new", namespace, configmap)
+	assert.ErrorContains(t, err, "doesn't match the collector selector `team=a` of the bridge")
+	instance, err := c.GetInstance("new", namespace)
+	require.NoError(t, err)
+	assert.Nil(t, instance)
+}
+
 func Test_collectorDelete(t *testing.T) {
 	name := "test"
 	namespace := "testing"
@@ -236,6 +280,97 @@ func Test_collectorDelete(t *testing.T) {
 	assert.Len(t, allInstances, 0)
 }
 
+func Test_collectorDryRun(t *testing.T) {
+	name := "test"
+	namespace := "testing"
+	fakeClient := getFakeClient(t)
+	c := NewClient(bridgeName, clientLogger, fakeClient, nil, WithDryRun(true))
+	colConfig, err := loadConfig("testdata/collector.yaml")
+	require.NoError(t, err, "Should be no error on loading test configuration")
+	configmap := &protobufs.AgentConfigFile{
+		Body:        colConfig,
+		ContentType: "yaml",
+	}
+	// Validate a valid configuration
+	err = c.Apply(name, namespace, configmap)
+	require.NoError(t, err, "Should validate base config")
+
+	// Check nothing was created
+	allInstances, err := c.ListInstances()
+	require.NoError(t, err, "Should be able to list all collectors")
+	assert.Len(t, allInstances, 0)
+}
+
+func Test_collectorConfigUpdate(t *testing.T) {
+	name := "test"
+	namespace := "testing"
+	fakeClient := getFakeClient(t)
+	c := NewClient(bridgeName, clientLogger, fakeClient, nil)
+	rawConfig, err := loadConfig("testdata/collector-config.yaml")
+	require.NoError(t, err, "Should be no error on loading test configuration")
+	rawConfigMap := &protobufs.AgentConfigFile{
+		Body:        rawConfig,
+		ContentType: "yaml",
+	}
+
+	// A collector configuration can't be applied to a collector which doesn't exist
+	err = c.Apply(name, namespace, rawConfigMap)
+	assert.ErrorContains(t, err, "must exist to receive a configuration")
+
+	colConfig, err := loadConfig("testdata/collector.yaml")
+	require.NoError(t, err, "Should be no error on loading test configuration")
+	err = c.Apply(name, namespace, &protobufs.AgentConfigFile{
+		Body:        colConfig,
+		ContentType: "yaml",
+	})
+	require.NoError(t, err, "Should apply base config")
+
+	// Write the collector configuration to the existing collector
+	err = c.Apply(name, namespace, rawConfigMap)
+	require.NoError(t, err, "Should be able to update the collector configuration")
+
+	updatedInstance, err := c.GetInstance(name, namespace)
+	require.NoError(t, err, "Should be able to get the updated instance")
+	assert.Equal(t, string(rawConfig), updatedInstance.Spec.Config)
+	assert.Equal(t, "true", updatedInstance.GetLabels()[ManagedLabelKey])
+}
+
+func Test_ReportRemoteConfigStatus(t *testing.T) {
+	gvk := v1alpha1.GroupVersion.WithKind("OpAMPBridge")
+	scheme := runtime.NewScheme()
+	scheme.AddKnownTypeWithName(gvk, &unstructured.Unstructured{})
+	bridge := &unstructured.Unstructured{}
+	bridge.SetGroupVersionKind(gvk)
+	bridge.SetName(bridgeName)
+	bridge.SetNamespace("testing")
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(bridge).WithStatusSubresource(bridge).Build()
+	statuses := []RemoteConfigStatus{
+		{
+			Collector: "testing/test",
+			Status:    RemoteConfigRejected,
+			Message:   "cannot modify a collector",
+		},
+	}
+
+	// Without a bridge resource, nothing is reported
+	c := NewClient(bridgeName, clientLogger, fakeClient, nil)
+	require.NoError(t, c.ReportRemoteConfigStatus(statuses))
+
+	c = NewClient(bridgeName, clientLogger, fakeClient, nil, WithStatusReporting(bridgeName, "testing"))
+	require.NoError(t, c.ReportRemoteConfigStatus(statuses))
+
+	updated := &unstructured.Unstructured{}
+	updated.SetGroupVersionKind(gvk)
+	err := fakeClient.Get(context.Background(), client.ObjectKey{Name: bridgeName, Namespace: "testing"}, updated)
+	require.NoError(t, err)
+	remoteConfigs, found, err := unstructured.NestedSlice(updated.Object, "status", "remoteConfigs")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Len(t, remoteConfigs, 1)
+	assert.Equal(t, "testing/test", remoteConfigs[0].(map[string]interface{})["collector"])
+	assert.Equal(t, RemoteConfigRejected, remoteConfigs[0].(map[string]interface{})["status"])
+}
+
 func loadConfig(file string) ([]byte, error) {
 	yamlFile, err := os.ReadFile(file)
 	if err != nil {
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// collectorComponent is the component label of the resources the operator generates for a collector.
+	collectorComponent = "opentelemetry-collector"
+	// collectorConfigKey is the key of the rendered configuration in the ConfigMap of a collector.
+	collectorConfigKey = "collector.yaml"
+)
+
+var (
+	regexpEndReplace   = regexp.MustCompile("[^A-Za-z0-9]+$")
+	regexpBeginReplace = regexp.MustCompile("^[^A-Za-z0-9]+")
+)
+
+// GetCollectorPods retrieves the pods the operator runs for an OpenTelemetryCollector, sorted by name.
+func (c Client) GetCollectorPods(name string, namespace string) ([]corev1.Pod, error) {
+	pods := corev1.PodList{}
+	err := c.k8sClient.List(context.Background(), &pods, client.InNamespace(namespace), collectorSelector(name, namespace))
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(pods.Items, func(i, j int) bool {
+		return pods.Items[i].Name < pods.Items[j].Name
+	})
+	return pods.Items, nil
+}
+
+// GetRenderedConfig retrieves the configuration the operator rendered for an OpenTelemetryCollector, i.e. the
+// configuration the collector actually runs with. It is empty when the operator didn't reconcile the collector yet.
+func (c Client) GetRenderedConfig(name string, namespace string) (string, error) {
+	configMaps := corev1.ConfigMapList{}
+	err := c.k8sClient.List(context.Background(), &configMaps, client.InNamespace(namespace), collectorSelector(name, namespace))
+	if err != nil {
+		return "", err
+	}
+	for _, configMap := range configMaps.Items {
+		if config, ok := configMap.Data[collectorConfigKey]; ok {
+			return config, nil
+		}
+	}
+	return "", nil
+}
+
+// collectorSelector matches the resources the operator generates for the collector of the given name and namespace.
+func collectorSelector(name string, namespace string) client.MatchingLabels {
+	return client.MatchingLabels{
+		"app.kubernetes.io/managed-by": "opentelemetry-operator",
+		"app.kubernetes.io/instance":   instanceLabel(name, namespace),
+		"app.kubernetes.io/component":  collectorComponent,
+	}
+}
+
+// instanceLabel returns the value of the app.kubernetes.io/instance label of a collector, truncated the way the
+// operator does it.
+func instanceLabel(name string, namespace string) string {
+	if excess := len(namespace) + len(name) + 1 - 63; excess > 0 {
+		if len(namespace) > excess {
+			namespace = namespace[:len(namespace)-excess]
+		} else {
+			name = name[:len(name)-(excess-len(namespace))]
+			namespace = ""
+		}
+	}
+	label := fmt.Sprintf("%s.%s", namespace, name)
+	return regexpBeginReplace.ReplaceAllString(regexpEndReplace.ReplaceAllString(label, ""), "")
+}
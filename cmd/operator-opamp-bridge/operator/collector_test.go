@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_instanceLabel(t *testing.T) {
+	tests := []struct {
+		name      string
+		colName   string
+		namespace string
+		want      string
+	}{
+		{
+			name:      "short name",
+			colName:   "simplest",
+			namespace: "testing",
+			want:      "testing.simplest",
+		},
+		{
+			name:      "long namespace",
+			colName:   "simplest",
+			namespace: strings.Repeat("n", 63),
+			want:      strings.Repeat("n", 54) + ".simplest",
+		},
+		{
+			name:      "truncated to a dash",
+			colName:   "simplest",
+			namespace: strings.Repeat("n", 53) + "-namespace",
+			want:      strings.Repeat("n", 53) + "-.simplest",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, instanceLabel(tt.colName, tt.namespace))
+		})
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"context"
+
+	"github.com/oklog/ulid/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// instanceUIDKey is the key of the ConfigMap holding the instance UID of the bridge.
+const instanceUIDKey = "instanceUid"
+
+// InstanceIDStore keeps the OpAMP instance UID of the bridge in a ConfigMap, so that the bridge keeps its identity
+// across restarts.
+type InstanceIDStore struct {
+	k8sClient client.Client
+	name      string
+	namespace string
+	bridge    string
+	bridgeUID types.UID
+}
+
+// NewInstanceIDStore returns a store keeping the instance UID in the given ConfigMap. When the UID of the OpAMPBridge
+// resource running the bridge is known, the ConfigMap is owned by it, so that it's deleted along with the resource.
+func NewInstanceIDStore(c client.Client, name, namespace, bridgeName string, bridgeUID types.UID) *InstanceIDStore {
+	return &InstanceIDStore{
+		k8sClient: c,
+		name:      name,
+		namespace: namespace,
+		bridge:    bridgeName,
+		bridgeUID: bridgeUID,
+	}
+}
+
+// Load returns the stored instance UID, if any.
+func (s *InstanceIDStore) Load(ctx context.Context) (ulid.ULID, bool, error) {
+	cm := &corev1.ConfigMap{}
+	if err := s.k8sClient.Get(ctx, client.ObjectKey{Name: s.name, Namespace: s.namespace}, cm); err != nil {
+		if errors.IsNotFound(err) {
+			return ulid.ULID{}, false, nil
+		}
+		return ulid.ULID{}, false, err
+	}
+	value, ok := cm.Data[instanceUIDKey]
+	if !ok {
+		return ulid.ULID{}, false, nil
+	}
+	id, err := ulid.Parse(value)
+	if err != nil {
+		return ulid.ULID{}, false, err
+	}
+	return id, true, nil
+}
+
+// Save stores the instance UID, creating the ConfigMap if needed.
+func (s *InstanceIDStore) Save(ctx context.Context, id ulid.ULID) error {
+	cm := &corev1.ConfigMap{}
+	err := s.k8sClient.Get(ctx, client.ObjectKey{Name: s.name, Namespace: s.namespace}, cm)
+	if errors.IsNotFound(err) {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      s.name,
+				Namespace: s.namespace,
+				Labels:    map[string]string{ResourceIdentifierKey: ResourceIdentifierValue},
+			},
+			Data: map[string]string{instanceUIDKey: id.String()},
+		}
+		if s.bridgeUID != "" && s.bridge != "" {
+			cm.OwnerReferences = []metav1.OwnerReference{{
+				APIVersion: v1alpha1.GroupVersion.String(),
+				Kind:       "OpAMPBridge",
+				Name:       s.bridge,
+				UID:        s.bridgeUID,
+			}}
+		}
+		return s.k8sClient.Create(ctx, cm)
+	}
+	if err != nil {
+		return err
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[instanceUIDKey] = id.String()
+	return s.k8sClient.Update(ctx, cm)
+}
@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestInstanceIDStore(t *testing.T) {
+	fakeClient := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).Build()
+	store := NewInstanceIDStore(fakeClient, "bridge-opamp-bridge-instance-id", "testing", "bridge", "bridge-uid")
+	ctx := context.Background()
+
+	// Nothing is stored at first
+	_, found, err := store.Load(ctx)
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	// The ConfigMap is created, owned by the OpAMPBridge
+	id := ulid.MustNew(ulid.Timestamp(time.Now()), ulid.Monotonic(rand.Reader, 0))
+	require.NoError(t, store.Save(ctx, id))
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: "bridge-opamp-bridge-instance-id", Namespace: "testing"}, cm))
+	require.Len(t, cm.OwnerReferences, 1)
+	assert.Equal(t, "OpAMPBridge", cm.OwnerReferences[0].Kind)
+	assert.Equal(t, "bridge", cm.OwnerReferences[0].Name)
+	loaded, found, err := store.Load(ctx)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, id, loaded)
+
+	// The ConfigMap is updated afterwards
+	newId := ulid.MustNew(ulid.MaxTime(), ulid.Monotonic(rand.Reader, 0))
+	require.NoError(t, store.Save(ctx, newId))
+	loaded, _, err = store.Load(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, newId, loaded)
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"context"
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+const (
+	// RemoteConfigApplied represents a remote configuration applied to the collector.
+	RemoteConfigApplied = "Applied"
+	// RemoteConfigValidated represents a remote configuration which passed the validation in dry-run mode.
+	RemoteConfigValidated = "Validated"
+	// RemoteConfigRejected represents a remote configuration which was refused.
+	RemoteConfigRejected = "Rejected"
+)
+
+// RemoteConfigStatus reports the outcome of a remote configuration for a collector. It matches the items of the
+// status.remoteConfigs of the OpAMPBridge resource.
+type RemoteConfigStatus struct {
+	Collector      string      `json:"collector"`
+	Status         string      `json:"status"`
+	Message        string      `json:"message,omitempty"`
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// ReportRemoteConfigStatus replaces the status.remoteConfigs of the OpAMPBridge resource of the bridge, when the
+// bridge runs on behalf of one. The resource is patched as unstructured content, so the bridge doesn't depend on the
+// version of its API.
+func (c Client) ReportRemoteConfigStatus(statuses []RemoteConfigStatus) error {
+	if c.bridgeName == "" || c.bridgeNamespace == "" {
+		return nil
+	}
+	if statuses == nil {
+		statuses = []RemoteConfigStatus{}
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			"remoteConfigs": statuses,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	bridge := &unstructured.Unstructured{}
+	bridge.SetGroupVersionKind(v1alpha1.GroupVersion.WithKind("OpAMPBridge"))
+	bridge.SetName(c.bridgeName)
+	bridge.SetNamespace(c.bridgeNamespace)
+	return c.k8sClient.Status().Patch(context.Background(), bridge, client.RawPatch(types.MergePatchType, patch))
+}
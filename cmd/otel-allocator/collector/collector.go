@@ -25,6 +25,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -34,6 +35,11 @@ import (
 
 const (
 	watcherTimeout = 15 * time.Minute
+
+	// drainingLabel is set by the operator on the collector pods departing on a graceful scale down of a StatefulSet.
+	// They're left out of the allocation, so that their targets are reassigned to the remaining collectors before the
+	// pods are removed.
+	drainingLabel = "operator.opentelemetry.io/collector-draining"
 )
 
 var (
@@ -67,7 +73,7 @@ func (k *Client) Watch(ctx context.Context, labelMap map[string]string, fn func(
 	collectorMap := map[string]*allocation.Collector{}
 
 	opts := metav1.ListOptions{
-		LabelSelector: labels.SelectorFromSet(labelMap).String(),
+		LabelSelector: collectorSelector(labelMap).String(),
 	}
 	pods, err := k.k8sClient.CoreV1().Pods(ns).List(ctx, opts)
 	if err != nil {
@@ -90,6 +96,13 @@ func (k *Client) Watch(ctx context.Context, labelMap map[string]string, fn func(
 	}
 }
 
+// collectorSelector selects the collector pods of the label map which aren't draining. The pods which start draining
+// no longer match it, which the watch reports as their deletion.
+func collectorSelector(labelMap map[string]string) labels.Selector {
+	notDraining, _ := labels.NewRequirement(drainingLabel, selection.DoesNotExist, nil)
+	return labels.SelectorFromSet(labelMap).Add(*notDraining)
+}
+
 func (k *Client) restartWatch(ctx context.Context, opts metav1.ListOptions, collectorMap map[string]*allocation.Collector, fn func(collectors map[string]*allocation.Collector)) bool {
 	// add timeout to the context before calling Watch
 	ctx, cancel := context.WithTimeout(ctx, watcherTimeout)
@@ -166,6 +166,18 @@ func Test_runWatch(t *testing.T) {
 	}
 }
 
+func Test_collectorSelector(t *testing.T) {
+	selector := collectorSelector(map[string]string{
+		"app.kubernetes.io/instance": "default.test",
+	})
+
+	draining := pod("test-pod1")
+	draining.Labels[drainingLabel] = "true"
+	assert.True(t, selector.Matches(labels.Set(pod("test-pod2").Labels)))
+	assert.False(t, selector.Matches(labels.Set(draining.Labels)))
+	assert.False(t, selector.Matches(labels.Set{"app.kubernetes.io/instance": "default.other"}))
+}
+
 // this tests runWatch in the case of watcher channel closing and watcher timing out.
 func Test_closeChannel(t *testing.T) {
 	tests := []struct {
@@ -53,8 +53,15 @@ type Config struct {
 }
 
 type PrometheusCRConfig struct {
-	Enabled        bool           `yaml:"enabled,omitempty"`
-	ScrapeInterval model.Duration `yaml:"scrape_interval,omitempty"`
+	Enabled        bool                  `yaml:"enabled,omitempty"`
+	ScrapeInterval model.Duration        `yaml:"scrape_interval,omitempty"`
+	RemoteClusters []RemoteClusterConfig `yaml:"remote_clusters,omitempty"`
+}
+
+// RemoteClusterConfig is a remote cluster whose ServiceMonitors and PodMonitors are watched along with the local ones.
+type RemoteClusterConfig struct {
+	Name               string `yaml:"name"`
+	KubeConfigFilePath string `yaml:"kube_config_file_path"`
 }
 
 func (c Config) GetAllocationStrategy() string {
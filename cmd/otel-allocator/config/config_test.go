@@ -160,6 +160,28 @@ func TestLoad(t *testing.T) {
 			},
 			wantErr: assert.NoError,
 		},
+		{
+			name: "remote clusters",
+			args: args{
+				file: "./testdata/remote_clusters_test.yaml",
+			},
+			want: Config{
+				LabelSelector: map[string]string{
+					"app.kubernetes.io/instance":   "default.test",
+					"app.kubernetes.io/managed-by": "opentelemetry-operator",
+				},
+				PrometheusCR: PrometheusCRConfig{
+					ScrapeInterval: DefaultCRScrapeInterval,
+					RemoteClusters: []RemoteClusterConfig{
+						{
+							Name:               "east",
+							KubeConfigFilePath: "/etc/remote-clusters/east/kubeconfig",
+						},
+					},
+				},
+			},
+			wantErr: assert.NoError,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -29,16 +29,22 @@ import (
 	"github.com/prometheus-operator/prometheus-operator/pkg/prometheus"
 	promconfig "github.com/prometheus/prometheus/config"
 	kubeDiscovery "github.com/prometheus/prometheus/discovery/kubernetes"
+	"github.com/prometheus/prometheus/model/relabel"
 	"gopkg.in/yaml.v2"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
 
 	allocatorconfig "github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/config"
 )
 
-const minEventInterval = time.Second * 5
+const (
+	minEventInterval = time.Second * 5
+	// clusterLabel is the label holding the name of the remote cluster of a target.
+	clusterLabel = "cluster"
+)
 
 func NewPrometheusCRWatcher(logger logr.Logger, cfg allocatorconfig.Config) (*PrometheusCRWatcher, error) {
 	mClient, err := monitoringclient.NewForConfig(cfg.ClusterConfig)
@@ -76,6 +82,15 @@ func NewPrometheusCRWatcher(logger logr.Logger, cfg allocatorconfig.Config) (*Pr
 
 	podMonSelector := getSelector(cfg.PodMonitorSelector)
 
+	var remoteClusters []*remoteCluster
+	for _, clusterCfg := range cfg.PrometheusCR.RemoteClusters {
+		cluster, clusterErr := newRemoteCluster(clusterCfg)
+		if clusterErr != nil {
+			return nil, fmt.Errorf("failed to create the clients of the remote cluster %s: %w", clusterCfg.Name, clusterErr)
+		}
+		remoteClusters = append(remoteClusters, cluster)
+	}
+
 	return &PrometheusCRWatcher{
 		logger:                 logger,
 		kubeMonitoringClient:   mClient,
@@ -87,6 +102,38 @@ func NewPrometheusCRWatcher(logger logr.Logger, cfg allocatorconfig.Config) (*Pr
 		kubeConfigPath:         cfg.KubeConfigFilePath,
 		serviceMonitorSelector: servMonSelector,
 		podMonitorSelector:     podMonSelector,
+		remoteClusters:         remoteClusters,
+	}, nil
+}
+
+// newRemoteCluster creates the clients and informers of the Prometheus CRs of a remote cluster from its kubeconfig.
+func newRemoteCluster(cfg allocatorconfig.RemoteClusterConfig) (*remoteCluster, error) {
+	clusterConfig, err := clientcmd.BuildConfigFromFlags("", cfg.KubeConfigFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	mClient, err := monitoringclient.NewForConfig(clusterConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	clientset, err := kubernetes.NewForConfig(clusterConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	factory := informers.NewMonitoringInformerFactories(map[string]struct{}{v1.NamespaceAll: {}}, map[string]struct{}{}, mClient, allocatorconfig.DefaultResyncTime, nil)
+	monitoringInformers, err := getInformers(factory)
+	if err != nil {
+		return nil, err
+	}
+
+	return &remoteCluster{
+		name:           cfg.Name,
+		k8sClient:      clientset,
+		informers:      monitoringInformers,
+		kubeConfigPath: cfg.KubeConfigFilePath,
 	}, nil
 }
 
@@ -102,6 +149,17 @@ type PrometheusCRWatcher struct {
 
 	serviceMonitorSelector labels.Selector
 	podMonitorSelector     labels.Selector
+
+	remoteClusters []*remoteCluster
+}
+
+// remoteCluster holds the clients and informers of a remote cluster. Its scrape jobs are prefixed with its name, and
+// its targets are labeled with it.
+type remoteCluster struct {
+	name           string
+	k8sClient      kubernetes.Interface
+	informers      map[string]*informers.ForResource
+	kubeConfigPath string
 }
 
 func getSelector(s map[string]string) labels.Selector {
@@ -135,7 +193,17 @@ func (w *PrometheusCRWatcher) Watch(upstreamEvents chan Event, upstreamErrors ch
 	// this channel needs to be buffered because notifications are asynchronous and neither producers nor consumers wait
 	notifyEvents := make(chan struct{}, 1)
 
+	allInformers := map[string]*informers.ForResource{}
 	for name, resource := range w.informers {
+		allInformers[name] = resource
+	}
+	for _, cluster := range w.remoteClusters {
+		for name, resource := range cluster.informers {
+			allInformers[cluster.name+"/"+name] = resource
+		}
+	}
+
+	for name, resource := range allInformers {
 		resource.Start(w.stopChannel)
 
 		if ok := cache.WaitForNamedCacheSync(name, w.stopChannel, resource.HasSynced); !ok {
@@ -215,9 +283,39 @@ func (w *PrometheusCRWatcher) Close() error {
 }
 
 func (w *PrometheusCRWatcher) LoadConfig(ctx context.Context) (*promconfig.Config, error) {
-	store := assets.NewStore(w.k8sClient.CoreV1(), w.k8sClient.CoreV1())
+	promCfg, err := w.loadClusterConfig(ctx, w.k8sClient, w.informers, w.kubeConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cluster := range w.remoteClusters {
+		clusterCfg, clusterErr := w.loadClusterConfig(ctx, cluster.k8sClient, cluster.informers, cluster.kubeConfigPath)
+		if clusterErr != nil {
+			return nil, fmt.Errorf("failed to load the configuration of the remote cluster %s: %w", cluster.name, clusterErr)
+		}
+		// the jobs of the clusters are generated from Prometheus CRs which may share their namespace and name
+		for _, scrapeConfig := range clusterCfg.ScrapeConfigs {
+			scrapeConfig.JobName = fmt.Sprintf("%s/%s", cluster.name, scrapeConfig.JobName)
+			scrapeConfig.RelabelConfigs = append(scrapeConfig.RelabelConfigs, clusterRelabelConfig(cluster.name))
+		}
+		promCfg.ScrapeConfigs = append(promCfg.ScrapeConfigs, clusterCfg.ScrapeConfigs...)
+	}
+	return promCfg, nil
+}
+
+// clusterRelabelConfig sets the cluster label of the targets of a remote cluster.
+func clusterRelabelConfig(cluster string) *relabel.Config {
+	cfg := relabel.DefaultRelabelConfig
+	cfg.TargetLabel = clusterLabel
+	cfg.Replacement = cluster
+	return &cfg
+}
+
+// loadClusterConfig generates the scrape configs of the Prometheus CRs of a cluster.
+func (w *PrometheusCRWatcher) loadClusterConfig(ctx context.Context, k8sClient kubernetes.Interface, monitoringInformers map[string]*informers.ForResource, kubeConfigPath string) (*promconfig.Config, error) {
+	store := assets.NewStore(k8sClient.CoreV1(), k8sClient.CoreV1())
 	serviceMonitorInstances := make(map[string]*monitoringv1.ServiceMonitor)
-	smRetrieveErr := w.informers[monitoringv1.ServiceMonitorName].ListAll(w.serviceMonitorSelector, func(sm interface{}) {
+	smRetrieveErr := monitoringInformers[monitoringv1.ServiceMonitorName].ListAll(w.serviceMonitorSelector, func(sm interface{}) {
 		monitor := sm.(*monitoringv1.ServiceMonitor)
 		key, _ := cache.DeletionHandlingMetaNamespaceKeyFunc(monitor)
 		w.addStoreAssetsForServiceMonitor(ctx, monitor.Name, monitor.Namespace, monitor.Spec.Endpoints, store)
@@ -228,7 +326,7 @@ func (w *PrometheusCRWatcher) LoadConfig(ctx context.Context) (*promconfig.Confi
 	}
 
 	podMonitorInstances := make(map[string]*monitoringv1.PodMonitor)
-	pmRetrieveErr := w.informers[monitoringv1.PodMonitorName].ListAll(w.podMonitorSelector, func(pm interface{}) {
+	pmRetrieveErr := monitoringInformers[monitoringv1.PodMonitorName].ListAll(w.podMonitorSelector, func(pm interface{}) {
 		monitor := pm.(*monitoringv1.PodMonitor)
 		key, _ := cache.DeletionHandlingMetaNamespaceKeyFunc(monitor)
 		w.addStoreAssetsForPodMonitor(ctx, monitor.Name, monitor.Namespace, monitor.Spec.PodMetricsEndpoints, store)
@@ -272,7 +370,7 @@ func (w *PrometheusCRWatcher) LoadConfig(ctx context.Context) (*promconfig.Confi
 		for _, serviceDiscoveryConfig := range scrapeConfig.ServiceDiscoveryConfigs {
 			if serviceDiscoveryConfig.Name() == "kubernetes" {
 				sdConfig := interface{}(serviceDiscoveryConfig).(*kubeDiscovery.SDConfig)
-				sdConfig.KubeConfig = w.kubeConfigPath
+				sdConfig.KubeConfig = kubeConfigPath
 			}
 		}
 	}
@@ -268,6 +268,54 @@ func TestLoadConfig(t *testing.T) {
 	}
 }
 
+func TestLoadConfigRemoteClusters(t *testing.T) {
+	serviceMonitor := &monitoringv1.ServiceMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "simple",
+			Namespace: "test",
+		},
+		Spec: monitoringv1.ServiceMonitorSpec{
+			JobLabel: "test",
+			Endpoints: []monitoringv1.Endpoint{
+				{
+					Port: "web",
+				},
+			},
+		},
+	}
+	w := getTestPrometheusCRWatcher(t, serviceMonitor, nil)
+	defer w.Close()
+	remote := getTestPrometheusCRWatcher(t, serviceMonitor, nil)
+	w.remoteClusters = []*remoteCluster{{
+		name:           "east",
+		k8sClient:      remote.k8sClient,
+		informers:      remote.informers,
+		kubeConfigPath: "/etc/remote-clusters/east/kubeconfig",
+	}}
+	for _, informers := range []map[string]*informers.ForResource{w.informers, remote.informers} {
+		for _, informer := range informers {
+			informer.Start(w.stopChannel)
+		}
+		for _, informer := range informers {
+			for !informer.HasSynced() {
+				time.Sleep(50 * time.Millisecond)
+			}
+		}
+	}
+
+	got, err := w.LoadConfig(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, got.ScrapeConfigs, 2)
+	local, east := got.ScrapeConfigs[0], got.ScrapeConfigs[1]
+	assert.Equal(t, "serviceMonitor/test/simple/0", local.JobName)
+	assert.Equal(t, "east/serviceMonitor/test/simple/0", east.JobName)
+	assert.Equal(t, "", local.ServiceDiscoveryConfigs[0].(*kubeDiscovery.SDConfig).KubeConfig)
+	assert.Equal(t, "/etc/remote-clusters/east/kubeconfig", east.ServiceDiscoveryConfigs[0].(*kubeDiscovery.SDConfig).KubeConfig)
+	assert.NotEqual(t, clusterRelabelConfig("east"), local.RelabelConfigs[len(local.RelabelConfigs)-1])
+	assert.Equal(t, clusterRelabelConfig("east"), east.RelabelConfigs[len(east.RelabelConfigs)-1])
+}
+
 func TestRateLimit(t *testing.T) {
 	var err error
 	serviceMonitor := &monitoringv1.ServiceMonitor{
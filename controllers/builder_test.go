@@ -29,6 +29,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
 )
 
 var (
@@ -151,8 +152,9 @@ service:
 								},
 								Containers: []corev1.Container{
 									{
-										Name:  "otc-container",
-										Image: "test",
+										Name:                     "otc-container",
+										Image:                    "test",
+										TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 										Args: []string{
 											"--config=/conf/collector.yaml",
 										},
@@ -285,12 +287,13 @@ service:
 						Name:      "test-collector-monitoring",
 						Namespace: "test",
 						Labels: map[string]string{
-							"app.kubernetes.io/component":  "opentelemetry-collector",
-							"app.kubernetes.io/instance":   "test.test",
-							"app.kubernetes.io/managed-by": "opentelemetry-operator",
-							"app.kubernetes.io/name":       "test-collector-monitoring",
-							"app.kubernetes.io/part-of":    "opentelemetry",
-							"app.kubernetes.io/version":    "latest",
+							"app.kubernetes.io/component":                         "opentelemetry-collector",
+							"app.kubernetes.io/instance":                          "test.test",
+							"app.kubernetes.io/managed-by":                        "opentelemetry-operator",
+							"app.kubernetes.io/name":                              "test-collector-monitoring",
+							"app.kubernetes.io/part-of":                           "opentelemetry",
+							"app.kubernetes.io/version":                           "latest",
+							"operator.opentelemetry.io/collector-metrics-service": "Exists",
 						},
 						Annotations: nil,
 					},
@@ -394,8 +397,9 @@ service:
 								},
 								Containers: []corev1.Container{
 									{
-										Name:  "otc-container",
-										Image: "test",
+										Name:                     "otc-container",
+										Image:                    "test",
+										TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 										Args: []string{
 											"--config=/conf/collector.yaml",
 										},
@@ -528,12 +532,13 @@ service:
 						Name:      "test-collector-monitoring",
 						Namespace: "test",
 						Labels: map[string]string{
-							"app.kubernetes.io/component":  "opentelemetry-collector",
-							"app.kubernetes.io/instance":   "test.test",
-							"app.kubernetes.io/managed-by": "opentelemetry-operator",
-							"app.kubernetes.io/name":       "test-collector-monitoring",
-							"app.kubernetes.io/part-of":    "opentelemetry",
-							"app.kubernetes.io/version":    "latest",
+							"app.kubernetes.io/component":                         "opentelemetry-collector",
+							"app.kubernetes.io/instance":                          "test.test",
+							"app.kubernetes.io/managed-by":                        "opentelemetry-operator",
+							"app.kubernetes.io/name":                              "test-collector-monitoring",
+							"app.kubernetes.io/part-of":                           "opentelemetry",
+							"app.kubernetes.io/version":                           "latest",
+							"operator.opentelemetry.io/collector-metrics-service": "Exists",
 						},
 						Annotations: nil,
 					},
@@ -589,6 +594,23 @@ service:
 			},
 			wantErr: false,
 		},
+		{
+			name: "invalid config",
+			args: args{
+				instance: v1alpha1.OpenTelemetryCollector{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test",
+						Namespace: "test",
+					},
+					Spec: v1alpha1.OpenTelemetryCollectorSpec{
+						Mode:   "deployment",
+						Config: "receivers: [",
+					},
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -611,6 +633,64 @@ service:
 	}
 }
 
+func TestBuildCollectorRollout(t *testing.T) {
+	// prepare
+	stableConfig := "receivers: {otlp: {protocols: {grpc: {}}}}\nexporters: {debug: {}}\nservice: {pipelines: {traces: {receivers: [otlp], exporters: [debug]}}}\n"
+	canaryConfig := "receivers: {otlp: {protocols: {http: {}}}}\nexporters: {debug: {}}\nservice: {pipelines: {traces: {receivers: [otlp], exporters: [debug]}}}\n"
+	params := manifests.Params{
+		Log:    logr.Discard(),
+		Config: config.New(config.WithCollectorImage("default-collector")),
+		OtelCol: v1alpha1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+			Spec: v1alpha1.OpenTelemetryCollectorSpec{
+				Mode:    v1alpha1.ModeDeployment,
+				Config:  canaryConfig,
+				Rollout: &v1alpha1.Rollout{Strategy: v1alpha1.RolloutStrategyCanary},
+			},
+			Status: v1alpha1.OpenTelemetryCollectorStatus{
+				Rollout: &v1alpha1.RolloutStatus{StableConfig: stableConfig},
+			},
+		},
+	}
+
+	// test
+	objs, err := BuildCollectorRollout(params)
+
+	// verify
+	require.NoError(t, err)
+	configs := map[string]string{}
+	deployments := map[string]bool{}
+	for _, obj := range objs {
+		switch o := obj.(type) {
+		case *corev1.ConfigMap:
+			configs[o.Name] = o.Data["collector.yaml"]
+		case *appsv1.Deployment:
+			deployments[o.Name] = true
+		}
+	}
+	require.Equal(t, map[string]bool{"test-collector": true, "test-collector-canary": true}, deployments)
+	require.Contains(t, configs["test-collector"], "grpc")
+	require.Contains(t, configs["test-collector-canary"], "http")
+
+	// the canary is left out once it's rolled back, or promoted
+	params.OtelCol.Status.Rollout.Phase = v1alpha1.RolloutPhaseRolledBack
+	params.OtelCol.Status.Rollout.CanaryConfigHash = collector.ConfigHash(canaryConfig)
+	objs, err = BuildCollectorRollout(params)
+	require.NoError(t, err)
+	for _, obj := range objs {
+		require.NotEqual(t, "test-collector-canary", obj.GetName())
+		if cm, ok := obj.(*corev1.ConfigMap); ok {
+			require.Contains(t, cm.Data["collector.yaml"], "grpc")
+		}
+	}
+	params.OtelCol.Status.Rollout.StableConfig = canaryConfig
+	objs, err = BuildCollectorRollout(params)
+	require.NoError(t, err)
+	for _, obj := range objs {
+		require.NotEqual(t, "test-collector-canary", obj.GetName())
+	}
+}
+
 func TestBuildAll_OpAMPBridge(t *testing.T) {
 	one := int32(1)
 	type args struct {
@@ -714,6 +794,10 @@ func TestBuildAll_OpAMPBridge(t *testing.T) {
 													},
 												},
 											},
+											{
+												Name:  "OPAMP_BRIDGE_NAME",
+												Value: "test",
+											},
 										},
 										VolumeMounts: []corev1.VolumeMount{
 											{
@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"reflect"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// apiReaderFallbackClient reads the objects of some types from the API server when they're missing from the cache.
+type apiReaderFallbackClient struct {
+	client.Client
+	apiReader client.Reader
+	types     map[reflect.Type]bool
+}
+
+// WithAPIReaderFallback returns a client getting the objects of the given types from the API server when they aren't
+// found in the cache of the given client. The cache of the manager only holds the child objects labelled as managed by
+// the operator, the fallback lets the reconcilers see the other ones, e.g. the existing objects to adopt.
+func WithAPIReaderFallback(c client.Client, apiReader client.Reader, objs ...client.Object) client.Client {
+	types := map[reflect.Type]bool{}
+	for _, obj := range objs {
+		types[reflect.TypeOf(obj)] = true
+	}
+	return &apiReaderFallbackClient{Client: c, apiReader: apiReader, types: types}
+}
+
+func (c *apiReaderFallbackClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	err := c.Client.Get(ctx, key, obj, opts...)
+	if apierrors.IsNotFound(err) && c.types[reflect.TypeOf(obj)] {
+		return c.apiReader.Get(ctx, key, obj, opts...)
+	}
+	return err
+}
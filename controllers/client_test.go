@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestAPIReaderFallback(t *testing.T) {
+	// prepare
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+	unmanaged := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "unmanaged", Namespace: "test"}}
+	filtered := &v1alpha1.OpenTelemetryCollector{ObjectMeta: metav1.ObjectMeta{Name: "filtered", Namespace: "test"}}
+	cached := fake.NewClientBuilder().WithScheme(scheme).Build()
+	apiReader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(unmanaged, filtered).Build()
+	cl := WithAPIReaderFallback(cached, apiReader, &corev1.ConfigMap{})
+
+	// test
+	cmErr := cl.Get(context.Background(), client.ObjectKeyFromObject(unmanaged), &corev1.ConfigMap{})
+	otelcolErr := cl.Get(context.Background(), client.ObjectKeyFromObject(filtered), &v1alpha1.OpenTelemetryCollector{})
+	missingErr := cl.Get(context.Background(), client.ObjectKey{Name: "missing", Namespace: "test"}, &corev1.ConfigMap{})
+
+	// verify
+	require.NoError(t, cmErr)
+	assert.True(t, apierrors.IsNotFound(otelcolErr), "the custom resources filtered out of the cache must not be read")
+	assert.True(t, apierrors.IsNotFound(missingErr))
+}
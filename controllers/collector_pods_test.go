@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestCollectorOfPod(t *testing.T) {
+	pod := func(component, instance string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:      "pod",
+			Namespace: "observability",
+			Labels: map[string]string{
+				"app.kubernetes.io/component": component,
+				"app.kubernetes.io/instance":  instance,
+			},
+		}}
+	}
+
+	assert.Equal(t, []ctrl.Request{{NamespacedName: client.ObjectKey{Namespace: "observability", Name: "my.collector"}}},
+		collectorOfPod(context.Background(), pod("opentelemetry-collector", "observability.my.collector")))
+	assert.Empty(t, collectorOfPod(context.Background(), pod("opentelemetry-targetallocator", "observability.my.collector")))
+	assert.Empty(t, collectorOfPod(context.Background(), pod("opentelemetry-collector", "other.my.collector")))
+}
+
+func TestContainerStartsCrashing(t *testing.T) {
+	pod := func(waitingReason string) *corev1.Pod {
+		status := corev1.ContainerStatus{Name: "otc-container"}
+		if waitingReason != "" {
+			status.State.Waiting = &corev1.ContainerStateWaiting{Reason: waitingReason}
+		}
+		return &corev1.Pod{Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{status}}}
+	}
+	p := containerStartsCrashing()
+
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: pod("ContainerCreating"), ObjectNew: pod("CrashLoopBackOff")}))
+	assert.False(t, p.Update(event.UpdateEvent{ObjectOld: pod("CrashLoopBackOff"), ObjectNew: pod("CrashLoopBackOff")}))
+	assert.False(t, p.Update(event.UpdateEvent{ObjectOld: pod("CrashLoopBackOff"), ObjectNew: pod("")}))
+	assert.False(t, p.Create(event.CreateEvent{Object: pod("CrashLoopBackOff")}))
+}
@@ -16,20 +16,65 @@ package controllers
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strconv"
+	"sync"
 
 	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/adapters"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/opampbridge"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/targetallocator"
+	"github.com/open-telemetry/opentelemetry-operator/internal/metrics"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/internal/status/conditions"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+const (
+	// fieldOwner is the field manager used when the child resources are reconciled with server-side apply.
+	fieldOwner = "opentelemetry-operator"
+
+	// adoptAnnotation allows the operator to take over existing resources which weren't created by it, for instance
+	// the workload of a collector installed with Helm, when set to "true" on the custom resource.
+	adoptAnnotation = "operator.opentelemetry.io/adopt-existing-resources"
+
+	// clusterResourcesFinalizer is set on the custom resources having cluster-scoped child objects, which can't be
+	// garbage collected through owner references, so that the operator deletes them along with the custom resource.
+	clusterResourcesFinalizer = "operator.opentelemetry.io/cluster-resources"
+
+	// generationAnnotation records the generation of the custom resource the child objects were generated from.
+	generationAnnotation = "operator.opentelemetry.io/generated-from-generation"
+
+	// configHashAnnotation records the hash of the configuration rendered for the custom resource.
+	configHashAnnotation = "operator.opentelemetry.io/config-hash"
+
+	// configOwnerAnnotation records the custom resource the generated ConfigMaps belong to, as <namespace>/<name>, so
+	// that a ConfigMap of the user which happens to have the same name is never overwritten.
+	configOwnerAnnotation = "operator.opentelemetry.io/config-owner"
+
+	// ownerAnnotation records the custom resource the generated cluster-scoped objects belong to, as <namespace>/<name>,
+	// as they can't have an owner reference to it, and their labels can't hold its whole name.
+	ownerAnnotation = "operator.opentelemetry.io/owner"
 )
 
 func isNamespaceScoped(obj client.Object) bool {
@@ -43,6 +88,9 @@ func isNamespaceScoped(obj client.Object) bool {
 
 // BuildCollector returns the generation and collected errors of all manifests for a given instance.
 func BuildCollector(params manifests.Params) ([]client.Object, error) {
+	if _, err := adapters.ConfigFromString(params.OtelCol.Spec.Config); err != nil {
+		return nil, conditions.WithReason(conditions.ReasonInvalidConfig, err)
+	}
 	builders := []manifests.Builder{
 		collector.Build,
 		targetallocator.Build,
@@ -51,13 +99,33 @@ func BuildCollector(params manifests.Params) ([]client.Object, error) {
 	for _, builder := range builders {
 		objs, err := builder(params)
 		if err != nil {
-			return nil, err
+			return nil, conditions.WithReason(conditions.ReasonManifestBuildFailed, err)
 		}
 		resources = append(resources, objs...)
 	}
 	return resources, nil
 }
 
+// BuildCollectorRollout returns the manifests of the collector while its configuration is rolled out: when a canary is
+// in progress, the collector keeps the stable configuration of its status and the canary runs the one of the spec,
+// unless it was rolled back.
+func BuildCollectorRollout(params manifests.Params) ([]client.Object, error) {
+	if !collector.CanaryInProgress(params.OtelCol) {
+		return BuildCollector(params)
+	}
+	if _, err := adapters.ConfigFromString(params.OtelCol.Spec.Config); err != nil {
+		return nil, conditions.WithReason(conditions.ReasonInvalidConfig, err)
+	}
+	stable := params
+	stable.OtelCol = *params.OtelCol.DeepCopy()
+	stable.OtelCol.Spec.Config = params.OtelCol.Status.Rollout.StableConfig
+	resources, err := BuildCollector(stable)
+	if err != nil || collector.CanaryRolledBack(params.OtelCol) {
+		return resources, err
+	}
+	return append(resources, collector.Canary(params)...), nil
+}
+
 // BuildOpAMPBridge returns the generation and collected errors of all manifests for a given instance.
 func BuildOpAMPBridge(params manifests.Params) ([]client.Object, error) {
 	builders := []manifests.Builder{
@@ -67,51 +135,490 @@ func BuildOpAMPBridge(params manifests.Params) ([]client.Object, error) {
 	for _, builder := range builders {
 		objs, err := builder(params)
 		if err != nil {
-			return nil, err
+			return nil, conditions.WithReason(conditions.ReasonManifestBuildFailed, err)
 		}
 		resources = append(resources, objs...)
 	}
 	return resources, nil
 }
 
-// reconcileDesiredObjects runs the reconcile process using the mutateFn over the given list of objects.
-func reconcileDesiredObjects(ctx context.Context, kubeClient client.Client, logger logr.Logger, owner metav1.Object, scheme *runtime.Scheme, desiredObjects ...client.Object) error {
+// stampGeneratedObjects annotates the desired objects with the generation of the owner and the hash of the configuration
+// rendered in its ConfigMap, so that external tooling can detect stale child objects and correlate the rollouts with the
+// edits of the owner. The ConfigMaps and the cluster-scoped objects are also annotated with their owner, see
+// checkConfigMapOwnership and checkClusterScopedOwnership.
+func stampGeneratedObjects(owner client.Object, configMapName string, desiredObjects ...client.Object) {
+	configHash := ""
+	for _, obj := range desiredObjects {
+		if cm, ok := obj.(*corev1.ConfigMap); ok && cm.Name == configMapName {
+			configHash = hashConfigMap(cm)
+		}
+	}
+	for _, obj := range desiredObjects {
+		// the annotations may be shared with the owner or other objects, so they are copied
+		annotations := map[string]string{}
+		for k, v := range obj.GetAnnotations() {
+			annotations[k] = v
+		}
+		annotations[generationAnnotation] = strconv.FormatInt(owner.GetGeneration(), 10)
+		if configHash != "" {
+			annotations[configHashAnnotation] = configHash
+		}
+		if _, ok := obj.(*corev1.ConfigMap); ok {
+			annotations[configOwnerAnnotation] = ownerName(owner)
+		}
+		if !isNamespaceScoped(obj) {
+			annotations[ownerAnnotation] = ownerName(owner)
+		}
+		obj.SetAnnotations(annotations)
+	}
+}
+
+// ownerName returns the <namespace>/<name> of the owner recorded in the annotations of its child objects.
+func ownerName(owner client.Object) string {
+	return owner.GetNamespace() + "/" + owner.GetName()
+}
+
+func hashConfigMap(cm *corev1.ConfigMap) string {
+	keys := make([]string, 0, len(cm.Data))
+	for k := range cm.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	h := sha256.New()
+	for _, k := range keys {
+		fmt.Fprintf(h, "%s\x00%s\x00", k, cm.Data[k])
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// generatedComponents lists the desired objects for the status of their owner, sorted by kind and name, with the hash
+// of their manifest. It's called before the objects are stamped, so that the hash doesn't change with the generation
+// of the owner when the objects themselves don't.
+func generatedComponents(scheme *runtime.Scheme, desiredObjects ...client.Object) []v1alpha1.ComponentStatus {
+	components := make([]v1alpha1.ComponentStatus, 0, len(desiredObjects))
+	for _, obj := range desiredObjects {
+		component := v1alpha1.ComponentStatus{
+			Kind: ownerKindFor(obj, scheme),
+			Name: obj.GetName(),
+		}
+		if manifest, err := json.Marshal(obj); err == nil {
+			component.Hash = fmt.Sprintf("%x", sha256.Sum256(manifest))
+		}
+		components = append(components, component)
+	}
+	sort.Slice(components, func(i, j int) bool {
+		if components[i].Kind != components[j].Kind {
+			return components[i].Kind < components[j].Kind
+		}
+		return components[i].Name < components[j].Name
+	})
+	return components
+}
+
+// reconcileDesiredObjects runs the reconcile process using the mutateFn over the given list of objects. The objects are
+// reconciled concurrently, in stages, so that the workloads are only reconciled once the objects they depend on, such as
+// their ConfigMap and ServiceAccount, have been.
+func reconcileDesiredObjects(ctx context.Context, kubeClient client.Client, logger logr.Logger, owner client.Object, scheme *runtime.Scheme, desiredObjects ...client.Object) error {
 	var errs []error
-	for _, desired := range desiredObjects {
-		l := logger.WithValues(
-			"object_name", desired.GetName(),
-			"object_kind", desired.GetObjectKind(),
-		)
-		if isNamespaceScoped(desired) {
-			if setErr := ctrl.SetControllerReference(owner, desired, scheme); setErr != nil {
-				l.Error(setErr, "failed to set controller owner reference to desired")
-				errs = append(errs, setErr)
-				continue
+	for _, stage := range reconcileStages(desiredObjects) {
+		// each object records its error at its own index, which keeps the errors in the order of the desired objects
+		stageErrs := make([]error, len(stage))
+		var wg sync.WaitGroup
+		for i, desired := range stage {
+			wg.Add(1)
+			go func(i int, desired client.Object) {
+				defer wg.Done()
+				stageErrs[i] = reconcileDesiredObject(ctx, kubeClient, logger, owner, scheme, desired)
+			}(i, desired)
+		}
+		wg.Wait()
+		for _, err := range stageErrs {
+			if err != nil {
+				errs = append(errs, err)
 			}
 		}
+	}
+	if len(errs) > 0 {
+		err := fmt.Errorf("failed to create objects for %s: %w", owner.GetName(), errors.Join(errs...))
+		// the errors annotated with a reason of their own, such as a ConfigMap which isn't owned, keep it
+		if conditions.ReasonFor(err) != conditions.ReasonReconcileError {
+			return err
+		}
+		return conditions.WithReason(conditions.ReasonResourceRejected, err)
+	}
+	return nil
+}
+
+// reconcileStages splits the desired objects into the stages they are reconciled in: the workloads come last, as they
+// reference most of the other objects.
+func reconcileStages(desiredObjects []client.Object) [][]client.Object {
+	var dependencies, workloads []client.Object
+	for _, obj := range desiredObjects {
+		switch obj.(type) {
+		case *appsv1.Deployment, *appsv1.StatefulSet, *appsv1.DaemonSet:
+			workloads = append(workloads, obj)
+		default:
+			dependencies = append(dependencies, obj)
+		}
+	}
+	var stages [][]client.Object
+	for _, stage := range [][]client.Object{dependencies, workloads} {
+		if len(stage) > 0 {
+			stages = append(stages, stage)
+		}
+	}
+	return stages
+}
+
+// reconcileDesiredObject creates or updates a single desired object.
+func reconcileDesiredObject(ctx context.Context, kubeClient client.Client, logger logr.Logger, owner client.Object, scheme *runtime.Scheme, desired client.Object) error {
+	ownerKey := client.ObjectKeyFromObject(owner)
+	ownerKind := ownerKindFor(owner, scheme)
+	l := logger.WithValues(
+		"object_name", desired.GetName(),
+		"object_kind", desired.GetObjectKind(),
+	)
+	if isNamespaceScoped(desired) {
+		if setErr := ctrl.SetControllerReference(owner, desired, scheme); setErr != nil {
+			l.Error(setErr, "failed to set controller owner reference to desired")
+			return setErr
+		}
+	}
 
-		// existing is an object the controller runtime will hydrate for us
-		// we obtain the existing object by deep copying the desired object because it's the most convenient way
-		existing := desired.DeepCopyObject().(client.Object)
+	// existing is an object the controller runtime will hydrate for us
+	// we obtain the existing object by deep copying the desired object because it's the most convenient way
+	existing := desired.DeepCopyObject().(client.Object)
+	var op controllerutil.OperationResult
+	var crudErr error
+	if featuregate.EnableServerSideApply.IsEnabled() {
+		op, crudErr = applyDesired(ctx, kubeClient, l, scheme, owner, existing, desired)
+	} else {
+		var before client.Object
 		mutateFn := manifests.MutateFuncFor(existing, desired)
-		op, crudErr := ctrl.CreateOrUpdate(ctx, kubeClient, existing, mutateFn)
-		if crudErr != nil && errors.Is(crudErr, manifests.ImmutableChangeErr) {
-			l.Error(crudErr, "detected immutable field change, trying to delete, new object will be created on next reconcile", "existing", existing.GetName())
-			delErr := kubeClient.Delete(ctx, existing)
-			if delErr != nil {
-				return delErr
+		// the changes are sent as a merge patch, and only when the mutated object differs from the existing one, so
+		// that the fields defaulted by the API server aren't sent back and steady-state reconciles don't write
+		op, crudErr = controllerutil.CreateOrPatch(ctx, kubeClient, existing, func() error {
+			if err := checkAdoption(l, owner, existing); err != nil {
+				return err
 			}
+			before = existing.DeepCopyObject().(client.Object)
+			return mutateFn()
+		})
+		if crudErr == nil && isUpdate(op) {
+			logChanges(l, before, existing)
+		}
+	}
+	if crudErr != nil && errors.Is(crudErr, manifests.ImmutableChangeErr) {
+		l.Error(crudErr, "detected immutable field change, trying to delete, new object will be created on next reconcile", "existing", existing.GetName())
+		if delErr := kubeClient.Delete(ctx, existing); delErr != nil {
+			return delErr
+		}
+		metrics.RecordChildObject(ownerKind, ownerKey, metrics.OperationDeleted)
+		return nil
+	} else if crudErr != nil {
+		l.Error(crudErr, "failed to configure desired")
+		return crudErr
+	}
+
+	switch op {
+	case controllerutil.OperationResultCreated:
+		metrics.RecordChildObject(ownerKind, ownerKey, metrics.OperationCreated)
+	case controllerutil.OperationResultUpdated, controllerutil.OperationResultUpdatedStatus, controllerutil.OperationResultUpdatedStatusOnly:
+		metrics.RecordChildObject(ownerKind, ownerKey, metrics.OperationUpdated)
+	}
+	l.V(1).Info(fmt.Sprintf("desired has been %s", op))
+	return nil
+}
+
+// isUpdate tells whether the object was patched, including its status only.
+func isUpdate(op controllerutil.OperationResult) bool {
+	switch op {
+	case controllerutil.OperationResultUpdated, controllerutil.OperationResultUpdatedStatus, controllerutil.OperationResultUpdatedStatusOnly:
+		return true
+	default:
+		return false
+	}
+}
+
+// checkAdoption verifies that an existing namespaced object may be reconciled for the owner. Objects controlled by the
+// owner, or labelled as managed by the operator for it, are reconciled as usual. Any other object is only adopted when
+// the owner is annotated with the adopt annotation, and never when it is controlled by another resource.
+func checkAdoption(logger logr.Logger, owner, existing client.Object) error {
+	if existing.GetResourceVersion() == "" {
+		return nil
+	}
+	if !isNamespaceScoped(existing) {
+		return checkClusterScopedOwnership(owner, existing)
+	}
+	if cm, ok := existing.(*corev1.ConfigMap); ok {
+		if err := checkConfigMapOwnership(owner, cm); err != nil {
+			return err
+		}
+	}
+	if metav1.IsControlledBy(existing, owner) {
+		return nil
+	}
+	if labels.SelectorFromSet(labels.Set(ownedByLabels(owner))).Matches(labels.Set(existing.GetLabels())) {
+		return nil
+	}
+	if controller := metav1.GetControllerOf(existing); controller != nil {
+		return fmt.Errorf("the existing object %s is controlled by %s %s and can't be adopted", existing.GetName(), controller.Kind, controller.Name)
+	}
+	if owner.GetAnnotations()[adoptAnnotation] != "true" {
+		return fmt.Errorf("the existing object %s isn't managed by the operator, annotate %s with %s=true to adopt it", existing.GetName(), owner.GetName(), adoptAnnotation)
+	}
+	logger.Info("adopting existing object")
+	return nil
+}
+
+// checkClusterScopedOwnership verifies that an existing cluster-scoped object may be overwritten for the owner. The
+// objects annotated as belonging to another owner are never overwritten, and neither are the objects without the
+// annotation, such as a ClusterRole of the user named after the generated one, unless they're adopted.
+func checkClusterScopedOwnership(owner, existing client.Object) error {
+	ownerValue, annotated := existing.GetAnnotations()[ownerAnnotation]
+	switch {
+	case annotated && ownerValue == ownerName(owner):
+		return nil
+	case annotated:
+		return fmt.Errorf("the existing object %s belongs to %s and won't be overwritten", existing.GetName(), ownerValue)
+	case owner.GetAnnotations()[adoptAnnotation] == "true":
+		return nil
+	default:
+		return fmt.Errorf("the existing object %s isn't managed by the operator for %s and won't be overwritten, rename or delete it, or annotate %s with %s=true to adopt it", existing.GetName(), owner.GetName(), owner.GetName(), adoptAnnotation)
+	}
+}
+
+// checkConfigMapOwnership verifies that an existing ConfigMap may be overwritten for the owner. The ConfigMaps annotated
+// as belonging to another owner are never overwritten, and neither are the ConfigMaps without the annotation which
+// aren't controlled by the owner, such as a ConfigMap of the user named after the generated one, unless they're adopted.
+func checkConfigMapOwnership(owner client.Object, existing *corev1.ConfigMap) error {
+	configOwnerValue, annotated := existing.GetAnnotations()[configOwnerAnnotation]
+	switch {
+	case annotated && configOwnerValue == ownerName(owner):
+		return nil
+	case annotated:
+		return conditions.WithReason(conditions.ReasonConfigMapNotOwned, fmt.Errorf("the existing ConfigMap %s belongs to %s and won't be overwritten", existing.Name, configOwnerValue))
+	case metav1.IsControlledBy(existing, owner) || owner.GetAnnotations()[adoptAnnotation] == "true":
+		return nil
+	default:
+		return conditions.WithReason(conditions.ReasonConfigMapNotOwned, fmt.Errorf("the existing ConfigMap %s isn't managed by the operator for %s and won't be overwritten, rename or delete it, or annotate %s with %s=true to adopt it", existing.Name, owner.GetName(), owner.GetName(), adoptAnnotation))
+	}
+}
+
+// pruneOrphanedObjects deletes the child objects of the owner which aren't desired anymore, for instance the Deployment
+// of a collector switched to the statefulset mode, or the Service of a removed port. Only the objects of the given list
+// types, labelled as managed by the operator for the owner and controlled by it, are considered.
+func pruneOrphanedObjects(ctx context.Context, kubeClient client.Client, logger logr.Logger, owner client.Object, scheme *runtime.Scheme, ownedLists []client.ObjectList, desiredObjects ...client.Object) error {
+	desired := map[string]bool{}
+	for _, obj := range desiredObjects {
+		desired[objectKey(obj, scheme)] = true
+	}
+
+	var errs []error
+	ownerKey := client.ObjectKeyFromObject(owner)
+	ownerKind := ownerKindFor(owner, scheme)
+	for _, list := range ownedLists {
+		if err := kubeClient.List(ctx, list, client.InNamespace(owner.GetNamespace()), ownedByLabels(owner)); err != nil {
+			errs = append(errs, err)
 			continue
-		} else if crudErr != nil {
-			l.Error(crudErr, "failed to configure desired")
-			errs = append(errs, crudErr)
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			errs = append(errs, err)
 			continue
 		}
+		for _, item := range items {
+			obj, ok := item.(client.Object)
+			if !ok || !metav1.IsControlledBy(obj, owner) || desired[objectKey(obj, scheme)] {
+				continue
+			}
+			if err := kubeClient.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+				errs = append(errs, err)
+				continue
+			}
+			metrics.RecordChildObject(ownerKind, ownerKey, metrics.OperationDeleted)
+			logger.V(1).Info("deleted orphaned object", "object_name", obj.GetName(), "object_kind", objectKind(obj, scheme))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to prune the orphaned objects of %s: %w", owner.GetName(), errors.Join(errs...))
+	}
+	return nil
+}
+
+// clusterScopedObjectLists returns the kinds of cluster-scoped child objects the operator may create.
+func clusterScopedObjectLists() []client.ObjectList {
+	return []client.ObjectList{
+		&rbacv1.ClusterRoleList{},
+		&rbacv1.ClusterRoleBindingList{},
+	}
+}
+
+// addClusterResourcesFinalizer sets the finalizer on the owner when some of its desired objects are cluster-scoped,
+// before they are created, so that they can't outlive the owner.
+func addClusterResourcesFinalizer(ctx context.Context, kubeClient client.Client, owner client.Object, desiredObjects ...client.Object) error {
+	if controllerutil.ContainsFinalizer(owner, clusterResourcesFinalizer) || !hasClusterScopedObjects(desiredObjects) {
+		return nil
+	}
+	patch := client.MergeFrom(owner.DeepCopyObject().(client.Object))
+	controllerutil.AddFinalizer(owner, clusterResourcesFinalizer)
+	if err := kubeClient.Patch(ctx, owner, patch); err != nil {
+		return fmt.Errorf("failed to add the finalizer to %s: %w", owner.GetName(), err)
+	}
+	return nil
+}
 
-		l.V(1).Info(fmt.Sprintf("desired has been %s", op))
+// pruneClusterScopedObjects deletes the cluster-scoped child objects of the owner which aren't desired anymore, and
+// removes the finalizer once the owner has none left. As cluster-scoped objects can't be controlled by a namespaced
+// owner, they are found by their labels, and only the ones annotated for the owner are deleted, as the labels of
+// owners with long names may be the same. Owners without the finalizer never had cluster-scoped objects.
+func pruneClusterScopedObjects(ctx context.Context, kubeClient client.Client, logger logr.Logger, owner client.Object, scheme *runtime.Scheme, desiredObjects ...client.Object) error {
+	if !controllerutil.ContainsFinalizer(owner, clusterResourcesFinalizer) {
+		return nil
+	}
+	desired := map[string]bool{}
+	for _, obj := range desiredObjects {
+		desired[objectKey(obj, scheme)] = true
+	}
+
+	var errs []error
+	ownerKey := client.ObjectKeyFromObject(owner)
+	ownerKind := ownerKindFor(owner, scheme)
+	for _, list := range clusterScopedObjectLists() {
+		if err := kubeClient.List(ctx, list, ownedByLabels(owner)); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, item := range items {
+			obj, ok := item.(client.Object)
+			if !ok || desired[objectKey(obj, scheme)] || obj.GetAnnotations()[ownerAnnotation] != ownerName(owner) {
+				continue
+			}
+			if err := kubeClient.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+				errs = append(errs, err)
+				continue
+			}
+			metrics.RecordChildObject(ownerKind, ownerKey, metrics.OperationDeleted)
+			logger.V(1).Info("deleted cluster-scoped object", "object_name", obj.GetName(), "object_kind", objectKind(obj, scheme))
+		}
 	}
 	if len(errs) > 0 {
-		return fmt.Errorf("failed to create objects for %s: %w", owner.GetName(), errors.Join(errs...))
+		return fmt.Errorf("failed to prune the cluster-scoped objects of %s: %w", owner.GetName(), errors.Join(errs...))
+	}
+
+	if hasClusterScopedObjects(desiredObjects) {
+		return nil
+	}
+	patch := client.MergeFrom(owner.DeepCopyObject().(client.Object))
+	controllerutil.RemoveFinalizer(owner, clusterResourcesFinalizer)
+	if err := kubeClient.Patch(ctx, owner, patch); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to remove the finalizer from %s: %w", owner.GetName(), err)
 	}
 	return nil
 }
+
+func hasClusterScopedObjects(objs []client.Object) bool {
+	for _, obj := range objs {
+		if !isNamespaceScoped(obj) {
+			return true
+		}
+	}
+	return false
+}
+
+// ownedByLabels returns the labels the operator sets on the child objects of the owner.
+func ownedByLabels(owner client.Object) client.MatchingLabels {
+	return client.MatchingLabels{
+		"app.kubernetes.io/managed-by": "opentelemetry-operator",
+		"app.kubernetes.io/instance":   naming.Truncate("%s.%s", 63, owner.GetNamespace(), owner.GetName()),
+	}
+}
+
+func objectKey(obj client.Object, scheme *runtime.Scheme) string {
+	return fmt.Sprintf("%s/%s/%s", objectKind(obj, scheme), obj.GetNamespace(), obj.GetName())
+}
+
+func objectKind(obj client.Object, scheme *runtime.Scheme) string {
+	gvk, err := apiutil.GVKForObject(obj, scheme)
+	if err != nil {
+		return obj.GetObjectKind().GroupVersionKind().String()
+	}
+	return gvk.String()
+}
+
+// ownerKindFor returns the kind of the owner used to label the metrics, the typed objects don't carry it themselves.
+func ownerKindFor(owner client.Object, scheme *runtime.Scheme) string {
+	gvk, err := apiutil.GVKForObject(owner, scheme)
+	if err != nil {
+		return owner.GetObjectKind().GroupVersionKind().Kind
+	}
+	return gvk.Kind
+}
+
+// applyDesired reconciles the desired object with server-side apply. Fields the operator sets but which were changed by
+// another field manager are reported as a conflict first, and then reverted by forcing the ownership.
+// The existing object is hydrated beforehand, so that changes to immutable fields are detected the same way as with
+// the mutate functions and the result tells whether the object was created, updated or left untouched.
+func applyDesired(ctx context.Context, kubeClient client.Client, logger logr.Logger, scheme *runtime.Scheme, owner, existing, desired client.Object) (controllerutil.OperationResult, error) {
+	gvk, err := apiutil.GVKForObject(desired, scheme)
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	getErr := kubeClient.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	if getErr != nil && !apierrors.IsNotFound(getErr) {
+		return controllerutil.OperationResultNone, getErr
+	}
+	if getErr == nil {
+		if err := checkAdoption(logger, owner, existing); err != nil {
+			return controllerutil.OperationResultNone, err
+		}
+		// the mutate function works on a copy, it's only used to detect immutable field changes
+		if err := manifests.MutateFuncFor(existing.DeepCopyObject().(client.Object), desired)(); errors.Is(err, manifests.ImmutableChangeErr) {
+			return controllerutil.OperationResultNone, err
+		}
+	}
+
+	applied := desired.DeepCopyObject().(client.Object)
+	applied.GetObjectKind().SetGroupVersionKind(gvk)
+	applied.SetResourceVersion("")
+	applied.SetManagedFields(nil)
+	err = kubeClient.Patch(ctx, applied, client.Apply, client.FieldOwner(fieldOwner))
+	if apierrors.IsConflict(err) {
+		logger.Info("detected changes to fields managed by the operator, reverting them", "conflicts", err.Error())
+		err = kubeClient.Patch(ctx, applied, client.Apply, client.FieldOwner(fieldOwner), client.ForceOwnership)
+	}
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	switch {
+	case apierrors.IsNotFound(getErr):
+		return controllerutil.OperationResultCreated, nil
+	case applied.GetResourceVersion() != existing.GetResourceVersion():
+		logChanges(logger, existing, applied)
+		return controllerutil.OperationResultUpdated, nil
+	default:
+		return controllerutil.OperationResultNone, nil
+	}
+}
+
+// logChanges logs the fields of an object changed by the operator, which tells why the pods of a workload were rolled
+// for instance. The changes are only computed when the verbosity is high enough, as they aren't cheap to compute.
+func logChanges(logger logr.Logger, before, after client.Object) {
+	l := logger.V(2)
+	if !l.Enabled() {
+		return
+	}
+	changes, err := manifests.Diff(before, after)
+	if err != nil {
+		l.Info("failed to compute the changes of the object", "error", err.Error())
+		return
+	}
+	l.Info("applied changes to the object", "changes", changes)
+}
@@ -0,0 +1,401 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/status/conditions"
+)
+
+func TestClusterScopedObjectsCleanup(t *testing.T) {
+	// prepare
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+	owner := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+	}
+	clusterRole := func(name, instance, owner string) *rbacv1.ClusterRole {
+		return &rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					"app.kubernetes.io/managed-by": "opentelemetry-operator",
+					"app.kubernetes.io/instance":   instance,
+				},
+				Annotations: map[string]string{ownerAnnotation: owner},
+			},
+		}
+	}
+	desired := clusterRole("test-desired", "test.test", "test/test")
+	orphaned := clusterRole("test-orphaned", "test.test", "test/test")
+	unrelated := clusterRole("other", "other.other", "other/other")
+	// the labels of owners with long names may be the same, the annotation tells them apart
+	sameLabels := clusterRole("test-other", "test.test", "test/test-other")
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(owner, desired, orphaned, unrelated, sameLabels).Build()
+	ctx := context.Background()
+	exists := func(obj client.Object) bool {
+		err := cl.Get(ctx, client.ObjectKeyFromObject(obj), &rbacv1.ClusterRole{})
+		if !apierrors.IsNotFound(err) {
+			require.NoError(t, err)
+		}
+		return err == nil
+	}
+
+	// test: the finalizer is added along with the first cluster-scoped object
+	require.NoError(t, addClusterResourcesFinalizer(ctx, cl, owner, desired))
+	require.NoError(t, pruneClusterScopedObjects(ctx, cl, logr.Discard(), owner, scheme, desired))
+
+	// verify
+	require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(owner), owner))
+	assert.True(t, controllerutil.ContainsFinalizer(owner, clusterResourcesFinalizer))
+	assert.True(t, exists(desired))
+	assert.False(t, exists(orphaned))
+	assert.True(t, exists(unrelated))
+	assert.True(t, exists(sameLabels))
+
+	// test: the owner is deleted
+	require.NoError(t, cl.Delete(ctx, owner))
+	require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(owner), owner))
+	require.NoError(t, pruneClusterScopedObjects(ctx, cl, logr.Discard(), owner, scheme))
+
+	// verify
+	assert.False(t, exists(desired))
+	assert.True(t, exists(unrelated))
+	assert.True(t, apierrors.IsNotFound(cl.Get(ctx, client.ObjectKeyFromObject(owner), owner)))
+}
+
+func TestNoFinalizerWithoutClusterScopedObjects(t *testing.T) {
+	// prepare
+	scheme := runtime.NewScheme()
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+	owner := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(owner).Build()
+
+	// test
+	err := addClusterResourcesFinalizer(context.Background(), cl, owner)
+
+	// verify
+	require.NoError(t, err)
+	require.NoError(t, cl.Get(context.Background(), client.ObjectKeyFromObject(owner), owner))
+	assert.Empty(t, owner.GetFinalizers())
+}
+
+func TestStampGeneratedObjects(t *testing.T) {
+	// prepare
+	owner := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test",
+			Namespace:   "test",
+			Generation:  3,
+			Annotations: map[string]string{"team": "a"},
+		},
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-collector"},
+		Data:       map[string]string{"collector.yaml": "receivers: {}"},
+	}
+	sa := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-collector", Annotations: owner.Annotations},
+	}
+
+	// test
+	stampGeneratedObjects(owner, "test-collector", cm, sa)
+
+	// verify
+	for _, obj := range []client.Object{cm, sa} {
+		assert.Equal(t, "3", obj.GetAnnotations()[generationAnnotation])
+		assert.Equal(t, hashConfigMap(cm), obj.GetAnnotations()[configHashAnnotation])
+	}
+	assert.Equal(t, "test/test", cm.Annotations[configOwnerAnnotation])
+	assert.NotContains(t, sa.Annotations, configOwnerAnnotation)
+	assert.Equal(t, "a", sa.Annotations["team"])
+	assert.Equal(t, map[string]string{"team": "a"}, owner.Annotations)
+	assert.Len(t, hashConfigMap(cm), 64)
+
+	changed := cm.DeepCopy()
+	changed.Data["collector.yaml"] = "receivers: {otlp: {}}"
+	assert.NotEqual(t, hashConfigMap(cm), hashConfigMap(changed))
+}
+
+func TestGeneratedComponents(t *testing.T) {
+	// prepare
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	desired := func(replicas int32) []client.Object {
+		return []client.Object{
+			&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "test-collector-monitoring"}},
+			&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-collector"},
+				Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			},
+			&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "test-collector"}},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-collector"},
+				Data:       map[string]string{"collector.yaml": "receivers: {}"},
+			},
+		}
+	}
+
+	// test
+	components := generatedComponents(scheme, desired(2)...)
+	scaled := generatedComponents(scheme, desired(3)...)
+
+	// verify
+	require.Len(t, components, 4)
+	var names []string
+	for _, component := range components {
+		names = append(names, component.Kind+"/"+component.Name)
+		assert.Len(t, component.Hash, 64)
+	}
+	assert.Equal(t, []string{"ConfigMap/test-collector", "Deployment/test-collector", "Service/test-collector", "Service/test-collector-monitoring"}, names)
+	assert.Equal(t, components, generatedComponents(scheme, desired(2)...))
+	assert.NotEqual(t, components[1].Hash, scaled[1].Hash)
+	assert.Equal(t, components[0].Hash, scaled[0].Hash)
+}
+
+func TestReconcileDesiredObjectsInStages(t *testing.T) {
+	// prepare
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+	owner := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test", UID: "uid"},
+	}
+	var mu sync.Mutex
+	var created []string
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			mu.Lock()
+			created = append(created, obj.GetName())
+			mu.Unlock()
+			return c.Create(ctx, obj, opts...)
+		},
+	}).Build()
+	meta := func(name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: name, Namespace: "test"}
+	}
+	desired := []client.Object{
+		&appsv1.Deployment{ObjectMeta: meta("deployment")},
+		&corev1.ConfigMap{ObjectMeta: meta("configmap")},
+		&corev1.ServiceAccount{ObjectMeta: meta("serviceaccount")},
+		&corev1.Service{ObjectMeta: meta("service")},
+	}
+
+	// test
+	err := reconcileDesiredObjects(context.Background(), cl, logr.Discard(), owner, scheme, desired...)
+
+	// verify
+	require.NoError(t, err)
+	require.Len(t, created, 4)
+	assert.ElementsMatch(t, []string{"configmap", "serviceaccount", "service"}, created[:3])
+	assert.Equal(t, "deployment", created[3])
+}
+
+func TestReconcileDesiredObjectsCollectsErrors(t *testing.T) {
+	// prepare
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+	owner := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test", UID: "uid"},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if obj.GetName() != "valid" {
+				return fmt.Errorf("rejected %s", obj.GetName())
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+	}).Build()
+	desired := []client.Object{
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "first", Namespace: "test"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "valid", Namespace: "test"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "second", Namespace: "test"}},
+	}
+
+	// test
+	err := reconcileDesiredObjects(context.Background(), cl, logr.Discard(), owner, scheme, desired...)
+
+	// verify
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rejected first\nrejected second")
+	assert.NoError(t, cl.Get(context.Background(), client.ObjectKeyFromObject(desired[1]), &corev1.ConfigMap{}))
+}
+
+func TestReconcileDesiredConfigMapOwnership(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+	for _, tt := range []struct {
+		desc        string
+		annotations map[string]string
+		controlled  bool
+		adopt       bool
+		expectedErr string
+	}{
+		{
+			desc:        "annotated for the owner",
+			annotations: map[string]string{configOwnerAnnotation: "test/test"},
+			controlled:  true,
+		},
+		{
+			desc:       "controlled by the owner before the annotation",
+			controlled: true,
+		},
+		{
+			desc:        "annotated for another owner",
+			annotations: map[string]string{configOwnerAnnotation: "test/other"},
+			adopt:       true,
+			expectedErr: "the existing ConfigMap test-collector belongs to test/other and won't be overwritten",
+		},
+		{
+			desc:        "created by the user",
+			expectedErr: "the existing ConfigMap test-collector isn't managed by the operator for test and won't be overwritten",
+		},
+		{
+			desc:  "created by the user and adopted",
+			adopt: true,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			owner := &v1alpha1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test", UID: "uid"},
+			}
+			if tt.adopt {
+				owner.Annotations = map[string]string{adoptAnnotation: "true"}
+			}
+			existing := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-collector", Namespace: "test", Annotations: tt.annotations},
+				Data:       map[string]string{"collector.yaml": "mine"},
+			}
+			if tt.controlled {
+				require.NoError(t, controllerutil.SetControllerReference(owner, existing, scheme))
+			}
+			cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing).Build()
+			desired := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-collector", Namespace: "test"},
+				Data:       map[string]string{"collector.yaml": "receivers: {}"},
+			}
+			stampGeneratedObjects(owner, "test-collector", desired)
+
+			// test
+			err := reconcileDesiredObjects(context.Background(), cl, logr.Discard(), owner, scheme, desired)
+
+			// verify
+			actual := &corev1.ConfigMap{}
+			require.NoError(t, cl.Get(context.Background(), client.ObjectKeyFromObject(desired), actual))
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				assert.Equal(t, conditions.ReasonConfigMapNotOwned, conditions.ReasonFor(err))
+				assert.Equal(t, "mine", actual.Data["collector.yaml"])
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "receivers: {}", actual.Data["collector.yaml"])
+			assert.Equal(t, "test/test", actual.Annotations[configOwnerAnnotation])
+		})
+	}
+}
+
+func TestReconcileDesiredClusterScopedOwnership(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+	for _, tt := range []struct {
+		desc        string
+		annotations map[string]string
+		adopt       bool
+		expectedErr string
+	}{
+		{
+			desc:        "annotated for the owner",
+			annotations: map[string]string{ownerAnnotation: "test/test"},
+		},
+		{
+			desc:        "annotated for another owner",
+			annotations: map[string]string{ownerAnnotation: "test/other"},
+			adopt:       true,
+			expectedErr: "the existing object test.test-targetallocator belongs to test/other and won't be overwritten",
+		},
+		{
+			desc:        "created by the user",
+			expectedErr: "the existing object test.test-targetallocator isn't managed by the operator for test and won't be overwritten",
+		},
+		{
+			desc:  "created by the user and adopted",
+			adopt: true,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			owner := &v1alpha1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test", UID: "uid"},
+			}
+			if tt.adopt {
+				owner.Annotations = map[string]string{adoptAnnotation: "true"}
+			}
+			existing := &rbacv1.ClusterRole{
+				ObjectMeta: metav1.ObjectMeta{Name: "test.test-targetallocator", Annotations: tt.annotations},
+				Rules:      []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get"}}},
+			}
+			cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing).Build()
+			desired := &rbacv1.ClusterRole{
+				ObjectMeta: metav1.ObjectMeta{Name: "test.test-targetallocator"},
+				Rules:      []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"list"}}},
+			}
+			stampGeneratedObjects(owner, "test-collector", desired)
+
+			// test
+			err := reconcileDesiredObjects(context.Background(), cl, logr.Discard(), owner, scheme, desired)
+
+			// verify
+			actual := &rbacv1.ClusterRole{}
+			require.NoError(t, cl.Get(context.Background(), client.ObjectKeyFromObject(desired), actual))
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				assert.Equal(t, "secrets", actual.Rules[0].Resources[0])
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "pods", actual.Rules[0].Resources[0])
+			assert.Equal(t, "test/test", actual.Annotations[ownerAnnotation])
+		})
+	}
+}
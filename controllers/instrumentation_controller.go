@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	instrumentationStatus "github.com/open-telemetry/opentelemetry-operator/internal/status/instrumentation"
+)
+
+// InstrumentationReconciler reconciles the status of an Instrumentation object.
+type InstrumentationReconciler struct {
+	client.Client
+	scheme *runtime.Scheme
+	log    logr.Logger
+
+	maxConcurrentReconciles int
+	rateLimiter             ratelimiter.RateLimiter
+}
+
+// InstrumentationReconcilerParams is the set of options to build a new InstrumentationReconciler.
+type InstrumentationReconcilerParams struct {
+	client.Client
+	Scheme *runtime.Scheme
+	Log    logr.Logger
+	// MaxConcurrentReconciles is the number of instrumentations reconciled in parallel, one when unset.
+	MaxConcurrentReconciles int
+	// RateLimiter limits how often the failed reconciles are retried, the default of controller-runtime when unset.
+	RateLimiter ratelimiter.RateLimiter
+}
+
+func NewInstrumentationReconciler(params InstrumentationReconcilerParams) *InstrumentationReconciler {
+	return &InstrumentationReconciler{
+		Client: params.Client,
+		scheme: params.Scheme,
+		log:    params.Log,
+
+		maxConcurrentReconciles: params.MaxConcurrentReconciles,
+		rateLimiter:             params.RateLimiter,
+	}
+}
+
+//+kubebuilder:rbac:groups=opentelemetry.io,resources=instrumentations,verbs=get;list;watch
+//+kubebuilder:rbac:groups=opentelemetry.io,resources=instrumentations/status,verbs=get;update;patch
+
+// Reconcile keeps the status of the Instrumentation in sync with the images it resolves to.
+func (r *InstrumentationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("instrumentation", req.NamespacedName)
+	var instance v1alpha1.Instrumentation
+	if err := r.Client.Get(ctx, req.NamespacedName, &instance); err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "unable to fetch Instrumentation")
+		}
+		// we'll ignore not-found errors, since they can't be fixed by an immediate
+		// requeue (we'll need to wait for a new notification), and we can get them
+		// on deleted requests.
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	changed := instance.DeepCopy()
+	instrumentationStatus.UpdateInstrumentationStatus(changed)
+	if reflect.DeepEqual(changed.Status, instance.Status) {
+		return ctrl.Result{}, nil
+	}
+
+	log.V(2).Info("updating instrumentation status")
+	if err := r.Client.Status().Patch(ctx, changed, client.MergeFrom(&instance)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to apply status changes to the Instrumentation CR: %w", err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *InstrumentationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.Instrumentation{}).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.maxConcurrentReconciles, RateLimiter: r.rateLimiter}).
+		Complete(r)
+}
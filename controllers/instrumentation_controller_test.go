@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	k8sreconcile "sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/controllers"
+)
+
+var instrumentationLogger = logf.Log.WithName("instrumentation-controller-unit-tests")
+
+func TestStatusOnReconciliation_Instrumentation(t *testing.T) {
+	// prepare
+	nsn := types.NamespacedName{Name: "my-instrumentation", Namespace: "default"}
+	reconciler := controllers.NewInstrumentationReconciler(controllers.InstrumentationReconcilerParams{
+		Client: k8sClient,
+		Log:    instrumentationLogger,
+		Scheme: testScheme,
+	})
+	created := &v1alpha1.Instrumentation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nsn.Name,
+			Namespace: nsn.Namespace,
+		},
+		Spec: v1alpha1.InstrumentationSpec{
+			Java: v1alpha1.Java{
+				Image: "java-img:1",
+			},
+			NodeJS: v1alpha1.NodeJS{
+				Image: "nodejs-img:1",
+			},
+		},
+	}
+	err := k8sClient.Create(context.Background(), created)
+	require.NoError(t, err)
+
+	// test
+	req := k8sreconcile.Request{
+		NamespacedName: nsn,
+	}
+	_, err = reconciler.Reconcile(context.Background(), req)
+
+	// verify
+	require.NoError(t, err)
+	actual := &v1alpha1.Instrumentation{}
+	require.NoError(t, k8sClient.Get(context.Background(), nsn, actual))
+	assert.Equal(t, "java-img:1", actual.Status.Images.Java)
+	assert.Equal(t, "nodejs-img:1", actual.Status.Images.NodeJS)
+
+	// cleanup
+	require.NoError(t, k8sClient.Delete(context.Background(), created))
+}
+
+func TestSkipWhenInstanceDoesNotExist_Instrumentation(t *testing.T) {
+	// prepare
+	nsn := types.NamespacedName{Name: "non-existing-my-instrumentation", Namespace: "default"}
+	reconciler := controllers.NewInstrumentationReconciler(controllers.InstrumentationReconcilerParams{
+		Client: k8sClient,
+		Log:    instrumentationLogger,
+		Scheme: testScheme,
+	})
+
+	// test
+	req := k8sreconcile.Request{
+		NamespacedName: nsn,
+	}
+	_, err := reconciler.Reconcile(context.Background(), req)
+
+	// verify
+	assert.NoError(t, err)
+}
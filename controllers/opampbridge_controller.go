@@ -16,6 +16,7 @@ package controllers
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
@@ -25,11 +26,16 @@ import (
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/metrics"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 	opampbridgeStatus "github.com/open-telemetry/opentelemetry-operator/internal/status/opampbridge"
+	"github.com/open-telemetry/opentelemetry-operator/internal/telemetry"
 )
 
 // OpAMPBridgeReconciler reconciles a OpAMPBridge object.
@@ -39,6 +45,9 @@ type OpAMPBridgeReconciler struct {
 	log      logr.Logger
 	recorder record.EventRecorder
 	config   config.Config
+
+	maxConcurrentReconciles int
+	rateLimiter             ratelimiter.RateLimiter
 }
 
 // OpAMPBridgeReconcilerParams is the set of options to build a new OpAMPBridgeReconciler.
@@ -48,6 +57,10 @@ type OpAMPBridgeReconcilerParams struct {
 	Scheme   *runtime.Scheme
 	Log      logr.Logger
 	Config   config.Config
+	// MaxConcurrentReconciles is the number of bridges reconciled in parallel, one when unset.
+	MaxConcurrentReconciles int
+	// RateLimiter limits how often the failed reconciles are retried, the default of controller-runtime when unset.
+	RateLimiter ratelimiter.RateLimiter
 }
 
 func (r *OpAMPBridgeReconciler) getParams(instance v1alpha1.OpAMPBridge) manifests.Params {
@@ -68,6 +81,9 @@ func NewOpAMPBridgeReconciler(params OpAMPBridgeReconcilerParams) *OpAMPBridgeRe
 		log:      params.Log,
 		recorder: params.Recorder,
 		config:   params.Config,
+
+		maxConcurrentReconciles: params.MaxConcurrentReconciles,
+		rateLimiter:             params.RateLimiter,
 	}
 	return reconciler
 }
@@ -80,11 +96,19 @@ func NewOpAMPBridgeReconciler(params OpAMPBridgeReconcilerParams) *OpAMPBridgeRe
 // move the current state of the cluster closer to the desired state.
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.2/pkg/reconcile
-func (r *OpAMPBridgeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *OpAMPBridgeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
+	start := time.Now()
 	log := r.log.WithValues("opamp-bridge", req.NamespacedName)
 	var instance v1alpha1.OpAMPBridge
-	if err := r.Client.Get(ctx, req.NamespacedName, &instance); err != nil {
-		if !apierrors.IsNotFound(err) {
+	kind := ownerKindFor(&instance, r.scheme)
+	ctx, span := telemetry.StartReconcile(ctx, kind, req.NamespacedName)
+	defer func() {
+		telemetry.End(span, err)
+	}()
+	if err = r.Client.Get(ctx, req.NamespacedName, &instance); err != nil {
+		if apierrors.IsNotFound(err) {
+			metrics.Forget(kind, req.NamespacedName)
+		} else {
 			log.Error(err, "unable to fetch OpAMPBridge")
 		}
 		// we'll ignore not-found errors, since they can't be fixed by an immediate
@@ -92,21 +116,37 @@ func (r *OpAMPBridgeReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		// on deleted requests.
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
+	defer func() {
+		metrics.ObserveReconcile(kind, req.NamespacedName, time.Since(start), err)
+	}()
 
 	params := r.getParams(instance)
 
+	buildStart := time.Now()
 	desiredObjects, buildErr := BuildOpAMPBridge(params)
+	metrics.ObserveManifestBuild(kind, req.NamespacedName, time.Since(buildStart))
 	if buildErr != nil {
-		return ctrl.Result{}, buildErr
+		return opampbridgeStatus.HandleReconcileStatus(ctx, log, params, nil, buildErr)
+	}
+	components := generatedComponents(params.Scheme, desiredObjects...)
+	stampGeneratedObjects(&params.OpAMPBridge, naming.OpAMPBridgeConfigMap(params.OpAMPBridge.Name), desiredObjects...)
+	err = reconcileDesiredObjects(ctx, r.Client, log, &params.OpAMPBridge, params.Scheme, desiredObjects...)
+	if err == nil {
+		err = pruneOrphanedObjects(ctx, r.Client, log, &params.OpAMPBridge, params.Scheme, []client.ObjectList{
+			&corev1.ConfigMapList{},
+			&corev1.ServiceAccountList{},
+			&corev1.ServiceList{},
+			&appsv1.DeploymentList{},
+		}, desiredObjects...)
 	}
-	err := reconcileDesiredObjects(ctx, r.Client, log, &params.OpAMPBridge, params.Scheme, desiredObjects...)
-	return opampbridgeStatus.HandleReconcileStatus(ctx, log, params, err)
+	return opampbridgeStatus.HandleReconcileStatus(ctx, log, params, components, err)
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *OpAMPBridgeReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.OpAMPBridge{}).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.maxConcurrentReconciles, RateLimiter: r.rateLimiter}).
 		Owns(&corev1.ConfigMap{}).
 		Owns(&corev1.ServiceAccount{}).
 		Owns(&corev1.Service{}).
@@ -18,24 +18,38 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	policyV1 "k8s.io/api/policy/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/metrics"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 	collectorStatus "github.com/open-telemetry/opentelemetry-operator/internal/status/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/status/conditions"
+	"github.com/open-telemetry/opentelemetry-operator/internal/telemetry"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/reconcile"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
@@ -49,6 +63,10 @@ type OpenTelemetryCollectorReconciler struct {
 	log      logr.Logger
 	config   config.Config
 
+	maxConcurrentReconciles int
+	rateLimiter             ratelimiter.RateLimiter
+	statusInterval          time.Duration
+
 	tasks   []Task
 	muTasks sync.RWMutex
 }
@@ -68,6 +86,14 @@ type Params struct {
 	Log      logr.Logger
 	Tasks    []Task
 	Config   config.Config
+	// MaxConcurrentReconciles is the number of collectors reconciled in parallel, one when unset.
+	MaxConcurrentReconciles int
+	// RateLimiter limits how often the failed reconciles are retried, the default of controller-runtime when unset.
+	RateLimiter ratelimiter.RateLimiter
+	// StatusInterval, when set, is the interval at which the readiness of the workloads is aggregated into the status
+	// of the collectors. The changes of the status of the workloads don't trigger reconciles then, which spares the
+	// operator a reconcile for each event of their pods. The status follows the workloads closely when unset.
+	StatusInterval time.Duration
 }
 
 func (r *OpenTelemetryCollectorReconciler) onOpenShiftRoutesChange() error {
@@ -135,6 +161,10 @@ func NewReconciler(p Params) *OpenTelemetryCollectorReconciler {
 		config:   p.Config,
 		tasks:    p.Tasks,
 		recorder: p.Recorder,
+
+		maxConcurrentReconciles: p.MaxConcurrentReconciles,
+		rateLimiter:             p.RateLimiter,
+		statusInterval:          p.StatusInterval,
 	}
 
 	if len(r.tasks) == 0 {
@@ -152,19 +182,32 @@ func NewReconciler(p Params) *OpenTelemetryCollectorReconciler {
 // +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;create;update
 // +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses;networkpolicies,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=route.openshift.io,resources=routes;routes/custom-host,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles;clusterrolebindings,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=pods;endpoints;namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=podmonitors,verbs=get;list;watch
+// +kubebuilder:rbac:groups=config.openshift.io,resources=clusterversions,verbs=get
 // +kubebuilder:rbac:groups=opentelemetry.io,resources=opentelemetrycollectors,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=opentelemetry.io,resources=opentelemetrycollectors/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=opentelemetry.io,resources=opentelemetrycollectors/finalizers,verbs=get;update;patch
 
 // Reconcile the current state of an OpenTelemetry collector resource with the desired state.
-func (r *OpenTelemetryCollectorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *OpenTelemetryCollectorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
+	start := time.Now()
 	log := r.log.WithValues("opentelemetrycollector", req.NamespacedName)
 
 	var instance v1alpha1.OpenTelemetryCollector
-	if err := r.Get(ctx, req.NamespacedName, &instance); err != nil {
-		if !apierrors.IsNotFound(err) {
+	kind := ownerKindFor(&instance, r.scheme)
+	ctx, span := telemetry.StartReconcile(ctx, kind, req.NamespacedName)
+	defer func() {
+		telemetry.End(span, err)
+	}()
+	if err = r.Get(ctx, req.NamespacedName, &instance); err != nil {
+		if apierrors.IsNotFound(err) {
+			metrics.Forget(kind, req.NamespacedName)
+		} else {
 			log.Error(err, "unable to fetch OpenTelemetryCollector")
 		}
 
@@ -173,6 +216,15 @@ func (r *OpenTelemetryCollectorReconciler) Reconcile(ctx context.Context, req ct
 		// on deleted requests.
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
+	defer func() {
+		metrics.ObserveReconcile(kind, req.NamespacedName, time.Since(start), err)
+	}()
+
+	if !instance.GetDeletionTimestamp().IsZero() {
+		// the namespaced child objects are garbage collected through their owner references, while the cluster-scoped
+		// ones are deleted before the finalizer is removed, whether the instance is managed or not
+		return ctrl.Result{}, pruneClusterScopedObjects(ctx, r.Client, log, &instance, r.scheme)
+	}
 
 	if instance.Spec.ManagementState == v1alpha1.ManagementStateUnmanaged {
 		log.Info("Skipping reconciliation for unmanaged OpenTelemetryCollector resource", "name", req.String())
@@ -181,16 +233,71 @@ func (r *OpenTelemetryCollectorReconciler) Reconcile(ctx context.Context, req ct
 	}
 
 	params := r.getParams(instance)
-	if err := r.RunTasks(ctx, params); err != nil {
+	if params.ScrapeConfigs, err = r.prometheusCRScrapeConfigs(ctx, log, instance); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err = r.RunTasks(ctx, params); err != nil {
 		return ctrl.Result{}, err
 	}
 
-	desiredObjects, buildErr := BuildCollector(params)
+	buildStart := time.Now()
+	desiredObjects, buildErr := BuildCollectorRollout(params)
+	metrics.ObserveManifestBuild(kind, req.NamespacedName, time.Since(buildStart))
 	if buildErr != nil {
-		return ctrl.Result{}, buildErr
+		return collectorStatus.HandleReconcileStatus(ctx, log, params, nil, buildErr)
+	}
+	// the replicas departing on a graceful scale down are kept until their targets are reassigned
+	holdFor, err := holdScaleDown(ctx, r.Client, log, params.OtelCol, desiredObjects, time.Now())
+	if err != nil {
+		return collectorStatus.HandleReconcileStatus(ctx, log, params, nil, err)
+	}
+	components := generatedComponents(params.Scheme, desiredObjects...)
+	stampGeneratedObjects(&params.OtelCol, naming.ConfigMap(&params.OtelCol), desiredObjects...)
+	err = addClusterResourcesFinalizer(ctx, r.Client, &params.OtelCol, desiredObjects...)
+	if err == nil {
+		err = reconcileDesiredObjects(ctx, r.Client, log, &params.OtelCol, params.Scheme, desiredObjects...)
 	}
-	err := reconcileDesiredObjects(ctx, r.Client, log, &params.OtelCol, params.Scheme, desiredObjects...)
-	return collectorStatus.HandleReconcileStatus(ctx, log, params, err)
+	if err == nil {
+		// the orphaned objects are only pruned once their replacements, if any, have been created
+		err = pruneOrphanedObjects(ctx, r.Client, log, &params.OtelCol, params.Scheme, ownedCollectorObjectLists(), desiredObjects...)
+	}
+	if err == nil {
+		err = pruneClusterScopedObjects(ctx, r.Client, log, &params.OtelCol, params.Scheme, desiredObjects...)
+	}
+	result, err := collectorStatus.HandleReconcileStatus(ctx, log, params, components, err)
+	if err == nil && r.statusInterval > 0 && instance.Spec.Mode != v1alpha1.ModeSidecar {
+		// the changes of the status of the workload are ignored, its readiness is refreshed on the next interval
+		result.RequeueAfter = r.statusInterval
+	}
+	if promotion := collectorStatus.RolloutRequeueAfter(instance, time.Now()); err == nil && promotion > 0 && (result.RequeueAfter == 0 || promotion < result.RequeueAfter) {
+		// the ready canary is promoted once its promotion delay has elapsed
+		result.RequeueAfter = promotion
+	}
+	if err == nil && holdFor > 0 && (result.RequeueAfter == 0 || holdFor < result.RequeueAfter) {
+		result.RequeueAfter = holdFor
+	}
+	return result, err
+}
+
+// ownedCollectorObjectLists returns the kinds of namespaced child objects created for collectors. The routes are left
+// out, as they are pruned by their own task.
+func ownedCollectorObjectLists() []client.ObjectList {
+	lists := []client.ObjectList{
+		&corev1.ConfigMapList{},
+		&corev1.ServiceAccountList{},
+		&corev1.ServiceList{},
+		&appsv1.DeploymentList{},
+		&appsv1.DaemonSetList{},
+		&appsv1.StatefulSetList{},
+		&autoscalingv2.HorizontalPodAutoscalerList{},
+		&policyV1.PodDisruptionBudgetList{},
+		&networkingv1.IngressList{},
+		&networkingv1.NetworkPolicyList{},
+	}
+	if featuregate.PrometheusOperatorIsAvailable.IsEnabled() {
+		lists = append(lists, &monitoringv1.ServiceMonitorList{})
+	}
+	return lists
 }
 
 // RunTasks runs all the tasks associated with this reconciler.
@@ -221,19 +328,74 @@ func (r *OpenTelemetryCollectorReconciler) SetupWithManager(mgr ctrl.Manager) er
 	}
 	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.OpenTelemetryCollector{}).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.maxConcurrentReconciles, RateLimiter: r.rateLimiter}).
 		Owns(&corev1.ConfigMap{}).
 		Owns(&corev1.ServiceAccount{}).
 		Owns(&corev1.Service{}).
-		Owns(&appsv1.Deployment{}).
-		Owns(&appsv1.DaemonSet{}).
-		Owns(&appsv1.StatefulSet{})
+		Owns(&appsv1.Deployment{}, r.workloadPredicates()...).
+		Owns(&appsv1.DaemonSet{}, r.workloadPredicates()...).
+		Owns(&appsv1.StatefulSet{}, r.workloadPredicates()...).
+		// the status of the collectors reports the crashes of their containers, which don't change their workloads
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(collectorOfPod), ctrlbuilder.WithPredicates(containerStartsCrashing()))
 
 	if featuregate.PrometheusOperatorIsAvailable.IsEnabled() {
 		builder.Owns(&monitoringv1.ServiceMonitor{})
+		// the scrape configs of the collectors selecting the monitors are refreshed when they change
+		builder.Watches(&monitoringv1.ServiceMonitor{}, handler.EnqueueRequestsFromMapFunc(r.collectorsSelectingMonitor))
+		builder.Watches(&monitoringv1.PodMonitor{}, handler.EnqueueRequestsFromMapFunc(r.collectorsSelectingMonitor))
 	}
 
 	builder = builder.Owns(&autoscalingv2.HorizontalPodAutoscaler{})
 	builder = builder.Owns(&policyV1.PodDisruptionBudget{})
+	builder = builder.Owns(&networkingv1.NetworkPolicy{})
 
 	return builder.Complete(r)
 }
+
+// workloadPredicates filters the events of the workloads which only change their status when the readiness of the
+// workloads is aggregated on an interval.
+func (r *OpenTelemetryCollectorReconciler) workloadPredicates() []ctrlbuilder.OwnsOption {
+	if r.statusInterval <= 0 {
+		return nil
+	}
+	return []ctrlbuilder.OwnsOption{ctrlbuilder.WithPredicates(ignoreStatusChanges())}
+}
+
+// ignoreStatusChanges drops the updates which leave the spec, labels and annotations of the objects untouched.
+func ignoreStatusChanges() predicate.Predicate {
+	return predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{}, predicate.AnnotationChangedPredicate{})
+}
+
+// collectorOfPod maps the pods of the collectors to their OpenTelemetryCollector, through their instance label.
+func collectorOfPod(_ context.Context, obj client.Object) []ctrl.Request {
+	podLabels := obj.GetLabels()
+	if podLabels["app.kubernetes.io/component"] != collector.ComponentOpenTelemetryCollector {
+		return nil
+	}
+	// the instance label is the namespace and the name of the collector, joined by a dot
+	name, found := strings.CutPrefix(podLabels["app.kubernetes.io/instance"], obj.GetNamespace()+".")
+	if !found || name == "" {
+		return nil
+	}
+	return []ctrl.Request{{NamespacedName: client.ObjectKey{Namespace: obj.GetNamespace(), Name: name}}}
+}
+
+// containerStartsCrashing only lets through the updates of the pods whose collector container starts crash-looping.
+func containerStartsCrashing() predicate.Predicate {
+	crashing := func(obj client.Object) bool {
+		pod, ok := obj.(*corev1.Pod)
+		if !ok {
+			return false
+		}
+		_, found := conditions.CrashingContainer([]corev1.Pod{*pod}, naming.Container())
+		return found
+	}
+	return predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !crashing(e.ObjectOld) && crashing(e.ObjectNew)
+		},
+	}
+}
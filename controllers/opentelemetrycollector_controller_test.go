@@ -19,12 +19,16 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	routev1 "github.com/openshift/api/route/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	colfeaturegate "go.opentelemetry.io/collector/featuregate"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
@@ -39,7 +43,9 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/controllers"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 var logger = logf.Log.WithName("unit-tests")
@@ -72,11 +78,11 @@ func TestNewObjectsOnReconciliation(t *testing.T) {
 		},
 		Spec: v1alpha1.OpenTelemetryCollectorSpec{
 			Mode: v1alpha1.ModeDeployment,
-			Ports: []corev1.ServicePort{
-				{
+			Ports: []v1alpha1.PortsSpec{
+				{ServicePort: corev1.ServicePort{
 					Name: "telnet",
 					Port: 49935,
-				},
+				}},
 			},
 			Ingress: v1alpha1.Ingress{
 				Type: v1alpha1.IngressTypeRoute,
@@ -254,6 +260,352 @@ func TestNewStatefulSetObjectsOnReconciliation(t *testing.T) {
 
 }
 
+func TestServerSideApplyRevertsDrift(t *testing.T) {
+	// prepare
+	require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableServerSideApply.ID(), true))
+	t.Cleanup(func() {
+		_ = colfeaturegate.GlobalRegistry().Set(featuregate.EnableServerSideApply.ID(), false)
+	})
+	cfg := config.New(
+		config.WithCollectorImage("default-collector"),
+		config.WithTargetAllocatorImage("default-ta-allocator"),
+		config.WithAutoDetect(mockAutoDetector),
+	)
+	nsn := types.NamespacedName{Name: "my-ssa-instance", Namespace: "default"}
+	reconciler := controllers.NewReconciler(controllers.Params{
+		Client:   k8sClient,
+		Log:      logger,
+		Scheme:   testScheme,
+		Recorder: record.NewFakeRecorder(10),
+		Config:   cfg,
+	})
+	require.NoError(t, cfg.AutoDetect())
+	created := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nsn.Name,
+			Namespace: nsn.Namespace,
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode: v1alpha1.ModeDeployment,
+		},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), created))
+	t.Cleanup(func() {
+		_ = k8sClient.Delete(context.Background(), created)
+	})
+	req := k8sreconcile.Request{
+		NamespacedName: nsn,
+	}
+	_, err := reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	cmName := types.NamespacedName{Name: naming.ConfigMap(created), Namespace: nsn.Namespace}
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, k8sClient.Get(context.Background(), cmName, cm))
+	desiredData := cm.Data
+
+	// a manual edit of a field managed by the operator
+	cm.Data = map[string]string{"collector.yaml": "edited"}
+	require.NoError(t, k8sClient.Update(context.Background(), cm, client.FieldOwner("kubectl-edit")))
+
+	// test
+	_, err = reconciler.Reconcile(context.Background(), req)
+
+	// verify
+	require.NoError(t, err)
+	require.NoError(t, k8sClient.Get(context.Background(), cmName, cm))
+	assert.Equal(t, desiredData, cm.Data)
+}
+
+func TestSteadyStateReconcileKeepsObjects(t *testing.T) {
+	// prepare
+	cfg := config.New(
+		config.WithCollectorImage("default-collector"),
+		config.WithTargetAllocatorImage("default-ta-allocator"),
+		config.WithAutoDetect(mockAutoDetector),
+	)
+	nsn := types.NamespacedName{Name: "my-steady-instance", Namespace: "default"}
+	reconciler := controllers.NewReconciler(controllers.Params{
+		Client:   k8sClient,
+		Log:      logger,
+		Scheme:   testScheme,
+		Recorder: record.NewFakeRecorder(10),
+		Config:   cfg,
+	})
+	require.NoError(t, cfg.AutoDetect())
+	created := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nsn.Name,
+			Namespace: nsn.Namespace,
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode: v1alpha1.ModeDeployment,
+			Config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+exporters:
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [debug]
+`,
+		},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), created))
+	t.Cleanup(func() {
+		_ = k8sClient.Delete(context.Background(), created)
+	})
+	req := k8sreconcile.Request{
+		NamespacedName: nsn,
+	}
+	_, err := reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	objects := []client.Object{&appsv1.Deployment{}, &corev1.ConfigMap{}, &corev1.Service{}}
+	names := []string{naming.Collector(created), naming.ConfigMap(created), naming.Service(created)}
+	versions := make([]string, len(objects))
+	for i, obj := range objects {
+		require.NoError(t, k8sClient.Get(context.Background(), types.NamespacedName{Name: names[i], Namespace: nsn.Namespace}, obj))
+		versions[i] = obj.GetResourceVersion()
+	}
+
+	// test
+	_, err = reconciler.Reconcile(context.Background(), req)
+
+	// verify
+	require.NoError(t, err)
+	for i, obj := range objects {
+		require.NoError(t, k8sClient.Get(context.Background(), types.NamespacedName{Name: names[i], Namespace: nsn.Namespace}, obj))
+		assert.Equal(t, versions[i], obj.GetResourceVersion(), "the %T %s was written by a steady-state reconcile", obj, names[i])
+	}
+}
+
+func TestStatusIntervalRequeues(t *testing.T) {
+	// prepare
+	cfg := config.New(
+		config.WithCollectorImage("default-collector"),
+		config.WithTargetAllocatorImage("default-ta-allocator"),
+		config.WithAutoDetect(mockAutoDetector),
+	)
+	require.NoError(t, cfg.AutoDetect())
+	reconciler := controllers.NewReconciler(controllers.Params{
+		Client:         k8sClient,
+		Log:            logger,
+		Scheme:         testScheme,
+		Recorder:       record.NewFakeRecorder(10),
+		Config:         cfg,
+		StatusInterval: time.Minute,
+	})
+	for _, mode := range []v1alpha1.Mode{v1alpha1.ModeDeployment, v1alpha1.ModeSidecar} {
+		t.Run(string(mode), func(t *testing.T) {
+			created := &v1alpha1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-interval-" + string(mode),
+					Namespace: "default",
+				},
+				Spec: v1alpha1.OpenTelemetryCollectorSpec{
+					Mode: mode,
+					Config: `receivers: {otlp: {protocols: {grpc: }}}
+exporters: {debug: }
+service: {pipelines: {traces: {receivers: [otlp], exporters: [debug]}}}
+`,
+				},
+			}
+			require.NoError(t, k8sClient.Create(context.Background(), created))
+			t.Cleanup(func() {
+				_ = k8sClient.Delete(context.Background(), created)
+			})
+
+			// test
+			result, err := reconciler.Reconcile(context.Background(), k8sreconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(created),
+			})
+
+			// verify
+			require.NoError(t, err)
+			if mode == v1alpha1.ModeSidecar {
+				// the sidecars have no workload to follow
+				assert.Zero(t, result.RequeueAfter)
+			} else {
+				assert.Equal(t, time.Minute, result.RequeueAfter)
+			}
+		})
+	}
+}
+
+func TestAdoptExistingResources(t *testing.T) {
+	// prepare
+	cfg := config.New(
+		config.WithCollectorImage("default-collector"),
+		config.WithTargetAllocatorImage("default-ta-allocator"),
+		config.WithAutoDetect(mockAutoDetector),
+	)
+	nsn := types.NamespacedName{Name: "my-adopting-instance", Namespace: "default"}
+	reconciler := controllers.NewReconciler(controllers.Params{
+		Client:   k8sClient,
+		Log:      logger,
+		Scheme:   testScheme,
+		Recorder: record.NewFakeRecorder(10),
+		Config:   cfg,
+	})
+	require.NoError(t, cfg.AutoDetect())
+
+	created := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nsn.Name,
+			Namespace: nsn.Namespace,
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode: v1alpha1.ModeDeployment,
+		},
+	}
+	// a config map created by another tool, with the name the operator would use
+	existing := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.ConfigMap(created),
+			Namespace: nsn.Namespace,
+			Labels:    map[string]string{"app.kubernetes.io/managed-by": "Helm"},
+		},
+		Data: map[string]string{"collector.yaml": "from-helm"},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), existing))
+	t.Cleanup(func() {
+		_ = k8sClient.Delete(context.Background(), existing)
+	})
+	require.NoError(t, k8sClient.Create(context.Background(), created))
+	t.Cleanup(func() {
+		_ = k8sClient.Delete(context.Background(), created)
+	})
+	req := k8sreconcile.Request{
+		NamespacedName: nsn,
+	}
+
+	// test
+	_, err := reconciler.Reconcile(context.Background(), req)
+
+	// verify
+	assert.ErrorContains(t, err, "isn't managed by the operator")
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKeyFromObject(existing), cm))
+	assert.Equal(t, "from-helm", cm.Data["collector.yaml"])
+
+	// test
+	require.NoError(t, k8sClient.Get(context.Background(), nsn, created))
+	created.Annotations = map[string]string{"operator.opentelemetry.io/adopt-existing-resources": "true"}
+	require.NoError(t, k8sClient.Update(context.Background(), created))
+	_, err = reconciler.Reconcile(context.Background(), req)
+
+	// verify
+	require.NoError(t, err)
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKeyFromObject(existing), cm))
+	assert.NotEqual(t, "from-helm", cm.Data["collector.yaml"])
+	assert.True(t, metav1.IsControlledBy(cm, created))
+	assert.Equal(t, "opentelemetry-operator", cm.Labels["app.kubernetes.io/managed-by"])
+}
+
+func TestPruneOrphanedObjectsOnModeChange(t *testing.T) {
+	// prepare
+	cfg := config.New(
+		config.WithCollectorImage("default-collector"),
+		config.WithTargetAllocatorImage("default-ta-allocator"),
+		config.WithAutoDetect(mockAutoDetector),
+	)
+	nsn := types.NamespacedName{Name: "my-mode-changing-instance", Namespace: "default"}
+	reconciler := controllers.NewReconciler(controllers.Params{
+		Client:   k8sClient,
+		Log:      logger,
+		Scheme:   testScheme,
+		Recorder: record.NewFakeRecorder(10),
+		Config:   cfg,
+	})
+	require.NoError(t, cfg.AutoDetect())
+	created := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nsn.Name,
+			Namespace: nsn.Namespace,
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode: v1alpha1.ModeDeployment,
+		},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), created))
+	t.Cleanup(func() {
+		_ = k8sClient.Delete(context.Background(), created)
+	})
+	req := k8sreconcile.Request{
+		NamespacedName: nsn,
+	}
+	_, err := reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	workloadName := types.NamespacedName{Name: naming.Collector(created), Namespace: nsn.Namespace}
+	require.NoError(t, k8sClient.Get(context.Background(), workloadName, &appsv1.Deployment{}))
+
+	// test
+	require.NoError(t, k8sClient.Get(context.Background(), nsn, created))
+	created.Spec.Mode = v1alpha1.ModeStatefulSet
+	require.NoError(t, k8sClient.Update(context.Background(), created))
+	_, err = reconciler.Reconcile(context.Background(), req)
+
+	// verify
+	require.NoError(t, err)
+	require.NoError(t, k8sClient.Get(context.Background(), workloadName, &appsv1.StatefulSet{}))
+	assert.True(t, apierrors.IsNotFound(k8sClient.Get(context.Background(), workloadName, &appsv1.Deployment{})))
+	// the objects which are still desired are kept
+	require.NoError(t, k8sClient.Get(context.Background(), types.NamespacedName{Name: naming.ConfigMap(created), Namespace: nsn.Namespace}, &corev1.ConfigMap{}))
+}
+
+func TestClusterRBACForTargetAllocator(t *testing.T) {
+	// prepare
+	cfg := config.New(
+		config.WithCollectorImage("default-collector"),
+		config.WithTargetAllocatorImage("default-ta-allocator"),
+		config.WithAutoDetect(mockAutoDetector),
+		config.WithRBACPermissions(autodetect.RBACPermissionsAvailable),
+	)
+	reconciler := controllers.NewReconciler(controllers.Params{
+		Client:   k8sClient,
+		Log:      logger,
+		Scheme:   testScheme,
+		Recorder: record.NewFakeRecorder(10),
+		Config:   cfg,
+	})
+	params, err := newParams("", "")
+	require.NoError(t, err)
+	created := params.OtelCol.DeepCopy()
+	created.Name = "my-ta-rbac-instance"
+	created.Spec.TargetAllocator.PrometheusCR.Enabled = true
+	nsn := types.NamespacedName{Name: created.Name, Namespace: created.Namespace}
+	require.NoError(t, k8sClient.Create(context.Background(), created))
+	req := k8sreconcile.Request{
+		NamespacedName: nsn,
+	}
+	roleName := types.NamespacedName{Name: naming.TargetAllocatorClusterRole(nsn.Name, nsn.Namespace)}
+
+	// test
+	_, err = reconciler.Reconcile(context.Background(), req)
+
+	// verify
+	require.NoError(t, err)
+	require.NoError(t, k8sClient.Get(context.Background(), roleName, &rbacv1.ClusterRole{}))
+	require.NoError(t, k8sClient.Get(context.Background(), roleName, &rbacv1.ClusterRoleBinding{}))
+	require.NoError(t, k8sClient.Get(context.Background(), nsn, created))
+	assert.Contains(t, created.Finalizers, "operator.opentelemetry.io/cluster-resources")
+
+	// test: the cluster-scoped objects are deleted along with the collector
+	require.NoError(t, k8sClient.Delete(context.Background(), created))
+	_, err = reconciler.Reconcile(context.Background(), req)
+
+	// verify
+	require.NoError(t, err)
+	assert.True(t, apierrors.IsNotFound(k8sClient.Get(context.Background(), roleName, &rbacv1.ClusterRole{})))
+	assert.True(t, apierrors.IsNotFound(k8sClient.Get(context.Background(), roleName, &rbacv1.ClusterRoleBinding{})))
+	assert.True(t, apierrors.IsNotFound(k8sClient.Get(context.Background(), nsn, created)))
+}
+
 func TestContinueOnRecoverableFailure(t *testing.T) {
 	// prepare
 	taskCalled := false
@@ -335,6 +687,10 @@ var _ autodetect.AutoDetect = (*mockAutoDetect)(nil)
 
 type mockAutoDetect struct {
 	OpenShiftRoutesAvailabilityFunc func() (autodetect.OpenShiftRoutesAvailability, error)
+	PlatformFunc                    func() (autodetect.Platform, error)
+	OpenShiftVersionFunc            func() (string, error)
+	RBACPermissionsFunc             func() (autodetect.RBACPermissions, error)
+	ServiceMeshFunc                 func() (autodetect.ServiceMesh, error)
 }
 
 func (m *mockAutoDetect) OpenShiftRoutesAvailability() (autodetect.OpenShiftRoutesAvailability, error) {
@@ -343,3 +699,31 @@ func (m *mockAutoDetect) OpenShiftRoutesAvailability() (autodetect.OpenShiftRout
 	}
 	return autodetect.OpenShiftRoutesNotAvailable, nil
 }
+
+func (m *mockAutoDetect) Platform() (autodetect.Platform, error) {
+	if m.PlatformFunc != nil {
+		return m.PlatformFunc()
+	}
+	return autodetect.PlatformKubernetes, nil
+}
+
+func (m *mockAutoDetect) OpenShiftVersion() (string, error) {
+	if m.OpenShiftVersionFunc != nil {
+		return m.OpenShiftVersionFunc()
+	}
+	return "", nil
+}
+
+func (m *mockAutoDetect) RBACPermissions() (autodetect.RBACPermissions, error) {
+	if m.RBACPermissionsFunc != nil {
+		return m.RBACPermissionsFunc()
+	}
+	return autodetect.RBACPermissionsNotAvailable, nil
+}
+
+func (m *mockAutoDetect) ServiceMesh() (autodetect.ServiceMesh, error) {
+	if m.ServiceMeshFunc != nil {
+		return m.ServiceMeshFunc()
+	}
+	return autodetect.ServiceMeshNone, nil
+}
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+const reasonPrometheusCREndpointSkipped = "PrometheusCREndpointSkipped"
+
+// prometheusCRScrapeConfigs lists the ServiceMonitors and PodMonitors selected by the collector, in all namespaces,
+// and resolves them into scrape configs. The endpoints which can't be resolved are skipped, so they don't prevent
+// scraping the other ones, and a warning event is recorded on the collector for each of them.
+func (r *OpenTelemetryCollectorReconciler) prometheusCRScrapeConfigs(ctx context.Context, log logr.Logger, instance v1alpha1.OpenTelemetryCollector) ([]map[string]interface{}, error) {
+	if !instance.Spec.PrometheusCR.Enabled || !featuregate.PrometheusOperatorIsAvailable.IsEnabled() {
+		return nil, nil
+	}
+
+	serviceMonitors := &monitoringv1.ServiceMonitorList{}
+	if err := r.List(ctx, serviceMonitors, client.MatchingLabels(instance.Spec.PrometheusCR.ServiceMonitorSelector)); err != nil {
+		return nil, err
+	}
+	podMonitors := &monitoringv1.PodMonitorList{}
+	if err := r.List(ctx, podMonitors, client.MatchingLabels(instance.Spec.PrometheusCR.PodMonitorSelector)); err != nil {
+		return nil, err
+	}
+
+	scrapeConfigs, err := collector.PrometheusCRScrapeConfigs(instance, serviceMonitors.Items, podMonitors.Items)
+	if err != nil {
+		log.Info("skipping the endpoints which can't be resolved into scrape configs", "reason", err.Error())
+		errs := []error{err}
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			errs = joined.Unwrap()
+		}
+		for _, endpointErr := range errs {
+			r.recorder.Event(&instance, "Warning", reasonPrometheusCREndpointSkipped, endpointErr.Error())
+		}
+	}
+	return scrapeConfigs, nil
+}
+
+// collectorsSelectingMonitor maps a ServiceMonitor or PodMonitor to the collectors selecting it, whose scrape configs
+// need to be refreshed. On updates, it's called for both the old and the new version of the monitor, so the
+// collectors which stopped selecting it are refreshed as well.
+func (r *OpenTelemetryCollectorReconciler) collectorsSelectingMonitor(ctx context.Context, monitor client.Object) []ctrl.Request {
+	collectors := &v1alpha1.OpenTelemetryCollectorList{}
+	if err := r.List(ctx, collectors); err != nil {
+		r.log.Error(err, "failed to list the collectors selecting the monitor", "monitor", client.ObjectKeyFromObject(monitor))
+		return nil
+	}
+
+	var requests []ctrl.Request
+	for _, otelcol := range collectors.Items {
+		prometheusCR := otelcol.Spec.PrometheusCR
+		if !prometheusCR.Enabled {
+			continue
+		}
+		selector := prometheusCR.ServiceMonitorSelector
+		if _, ok := monitor.(*monitoringv1.PodMonitor); ok {
+			selector = prometheusCR.PodMonitorSelector
+		}
+		if labels.SelectorFromSet(selector).Matches(labels.Set(monitor.GetLabels())) {
+			requests = append(requests, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&otelcol)})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	colfeaturegate "go.opentelemetry.io/collector/featuregate"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+func TestPrometheusCRScrapeConfigsRecordsSkippedEndpoints(t *testing.T) {
+	require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.PrometheusOperatorIsAvailable.ID(), true))
+	t.Cleanup(func() {
+		_ = colfeaturegate.GlobalRegistry().Set(featuregate.PrometheusOperatorIsAvailable.ID(), false)
+	})
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+	utilruntime.Must(monitoringv1.AddToScheme(scheme))
+	serviceMonitor := &monitoringv1.ServiceMonitor{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "test"},
+		Spec: monitoringv1.ServiceMonitorSpec{
+			Endpoints: []monitoringv1.Endpoint{
+				{Port: "metrics"},
+				{Port: "secured", BearerTokenSecret: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "token"}}},
+			},
+		},
+	}
+	podMonitor := &monitoringv1.PodMonitor{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "test"},
+		Spec: monitoringv1.PodMonitorSpec{
+			PodMetricsEndpoints: []monitoringv1.PodMetricsEndpoint{
+				{Port: "secured", BearerTokenSecret: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "token"}}},
+			},
+		},
+	}
+	recorder := record.NewFakeRecorder(10)
+	reconciler := &OpenTelemetryCollectorReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(serviceMonitor, podMonitor).Build(),
+		recorder: recorder,
+	}
+	instance := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			PrometheusCR: v1alpha1.OpenTelemetryCollectorPrometheusCR{Enabled: true},
+		},
+	}
+
+	scrapeConfigs, err := reconciler.prometheusCRScrapeConfigs(context.Background(), logr.Discard(), instance)
+	require.NoError(t, err)
+
+	// the resolved endpoint is still scraped
+	require.Len(t, scrapeConfigs, 1)
+	assert.Equal(t, "serviceMonitor/test/app/0", scrapeConfigs[0]["job_name"])
+	// and a warning is recorded for each skipped one
+	require.Len(t, recorder.Events, 2)
+	assert.Equal(t, "Warning PrometheusCREndpointSkipped serviceMonitor/test/app/1: the bearerTokenSecret isn't supported without a TargetAllocator", <-recorder.Events)
+	assert.Equal(t, "Warning PrometheusCREndpointSkipped podMonitor/test/app/0: the bearerTokenSecret isn't supported without a TargetAllocator", <-recorder.Events)
+}
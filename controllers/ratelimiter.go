@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
+)
+
+const (
+	// DefaultBackoffBaseDelay is the delay before the first retry of a failed reconcile, as in controller-runtime.
+	DefaultBackoffBaseDelay = 5 * time.Millisecond
+	// DefaultBackoffMaxDelay is the longest delay between the retries of a failed reconcile, as in controller-runtime.
+	DefaultBackoffMaxDelay = 1000 * time.Second
+)
+
+// NewRateLimiter returns the rate limiter of the requests requeued by a controller. The failed reconciles of a resource
+// are retried with a delay doubling from baseDelay up to maxDelay, while the overall rate of the requeues is capped as
+// by the default rate limiter of controller-runtime.
+func NewRateLimiter(baseDelay, maxDelay time.Duration) ratelimiter.RateLimiter {
+	return workqueue.NewMaxOfRateLimiter(
+		workqueue.NewItemExponentialFailureRateLimiter(baseDelay, maxDelay),
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(10), 100)},
+	)
+}
@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiterBackoff(t *testing.T) {
+	// prepare
+	limiter := NewRateLimiter(time.Second, 3*time.Second)
+
+	// test
+	var delays []time.Duration
+	for i := 0; i < 4; i++ {
+		delays = append(delays, limiter.When("item"))
+	}
+	limiter.Forget("item")
+
+	// verify
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second}, delays)
+	assert.Zero(t, limiter.NumRequeues("item"))
+	assert.Equal(t, time.Second, limiter.When("item"))
+}
@@ -80,7 +80,7 @@ func TestOpenTelemetryCollectorReconciler_Reconcile(t *testing.T) {
 		annotationName: annotationVal,
 	}
 	deploymentExtraPorts := paramsWithModeAndReplicas(v1alpha1.ModeDeployment, 3)
-	deploymentExtraPorts.OtelCol.Spec.Ports = append(deploymentExtraPorts.OtelCol.Spec.Ports, extraPorts)
+	deploymentExtraPorts.OtelCol.Spec.Ports = append(deploymentExtraPorts.OtelCol.Spec.Ports, v1alpha1.PortsSpec{ServicePort: extraPorts})
 	ingressParams := newParamsAssertNoErr(t, "", testFileIngress)
 	ingressParams.OtelCol.Spec.Ingress.Type = "ingress"
 	updatedIngressParams := newParamsAssertNoErr(t, "", testFileIngress)
@@ -127,16 +127,16 @@ func TestOpenTelemetryCollectorReconciler_Reconcile(t *testing.T) {
 					checks: []check{
 						func(t *testing.T, params manifests.Params) {
 							d := appsv1.Deployment{}
-							exists, err := populateObjectIfExists(t, &d, namespacedObjectName(naming.Collector(params.OtelCol.Name), params.OtelCol.Namespace))
+							exists, err := populateObjectIfExists(t, &d, namespacedObjectName(naming.Collector(&params.OtelCol), params.OtelCol.Namespace))
 							assert.NoError(t, err)
 							assert.True(t, exists)
 							assert.Equal(t, int32(2), *d.Spec.Replicas)
 							assert.Contains(t, d.Annotations, annotationName)
 							assert.Contains(t, d.Labels, labelName)
-							exists, err = populateObjectIfExists(t, &v1.Service{}, namespacedObjectName(naming.Service(params.OtelCol.Name), params.OtelCol.Namespace))
+							exists, err = populateObjectIfExists(t, &v1.Service{}, namespacedObjectName(naming.Service(&params.OtelCol), params.OtelCol.Namespace))
 							assert.NoError(t, err)
 							assert.True(t, exists)
-							exists, err = populateObjectIfExists(t, &v1.ServiceAccount{}, namespacedObjectName(naming.ServiceAccount(params.OtelCol.Name), params.OtelCol.Namespace))
+							exists, err = populateObjectIfExists(t, &v1.ServiceAccount{}, namespacedObjectName(naming.ServiceAccount(&params.OtelCol), params.OtelCol.Namespace))
 							assert.NoError(t, err)
 							assert.True(t, exists)
 						},
@@ -149,7 +149,7 @@ func TestOpenTelemetryCollectorReconciler_Reconcile(t *testing.T) {
 					checks: []check{
 						func(t *testing.T, params manifests.Params) {
 							d := appsv1.Deployment{}
-							exists, err := populateObjectIfExists(t, &d, namespacedObjectName(naming.Collector(params.OtelCol.Name), params.OtelCol.Namespace))
+							exists, err := populateObjectIfExists(t, &d, namespacedObjectName(naming.Collector(&params.OtelCol), params.OtelCol.Namespace))
 							assert.NoError(t, err)
 							assert.True(t, exists)
 							assert.Equal(t, int32(3), *d.Spec.Replicas)
@@ -157,7 +157,7 @@ func TestOpenTelemetryCollectorReconciler_Reconcile(t *testing.T) {
 							assert.Contains(t, d.Annotations, annotationName)
 							assert.Contains(t, d.Labels, labelName)
 							actual := v1.Service{}
-							exists, err = populateObjectIfExists(t, &actual, namespacedObjectName(naming.Service(params.OtelCol.Name), params.OtelCol.Namespace))
+							exists, err = populateObjectIfExists(t, &actual, namespacedObjectName(naming.Service(&params.OtelCol), params.OtelCol.Namespace))
 							assert.NoError(t, err)
 							assert.True(t, exists)
 							assert.Contains(t, actual.Spec.Ports, extraPorts)
@@ -288,7 +288,7 @@ func TestOpenTelemetryCollectorReconciler_Reconcile(t *testing.T) {
 					checks: []check{
 						func(t *testing.T, params manifests.Params) {
 							actual := autoscalingv2.HorizontalPodAutoscaler{}
-							exists, hpaErr := populateObjectIfExists(t, &actual, namespacedObjectName(naming.HorizontalPodAutoscaler(params.OtelCol.Name), params.OtelCol.Namespace))
+							exists, hpaErr := populateObjectIfExists(t, &actual, namespacedObjectName(naming.HorizontalPodAutoscaler(&params.OtelCol), params.OtelCol.Namespace))
 							assert.NoError(t, hpaErr)
 							require.Len(t, actual.Spec.Metrics, 1)
 							assert.Equal(t, int32(90), *actual.Spec.Metrics[0].Resource.Target.AverageUtilization)
@@ -305,7 +305,7 @@ func TestOpenTelemetryCollectorReconciler_Reconcile(t *testing.T) {
 					checks: []check{
 						func(t *testing.T, params manifests.Params) {
 							actual := autoscalingv2.HorizontalPodAutoscaler{}
-							exists, hpaErr := populateObjectIfExists(t, &actual, namespacedObjectName(naming.HorizontalPodAutoscaler(params.OtelCol.Name), params.OtelCol.Namespace))
+							exists, hpaErr := populateObjectIfExists(t, &actual, namespacedObjectName(naming.HorizontalPodAutoscaler(&params.OtelCol), params.OtelCol.Namespace))
 							assert.NoError(t, hpaErr)
 							require.Len(t, actual.Spec.Metrics, 1)
 							assert.Equal(t, int32(90), *actual.Spec.Metrics[0].Resource.Target.AverageUtilization)
@@ -331,7 +331,7 @@ func TestOpenTelemetryCollectorReconciler_Reconcile(t *testing.T) {
 					checks: []check{
 						func(t *testing.T, params manifests.Params) {
 							actual := policyV1.PodDisruptionBudget{}
-							exists, pdbErr := populateObjectIfExists(t, &actual, namespacedObjectName(naming.HorizontalPodAutoscaler(params.OtelCol.Name), params.OtelCol.Namespace))
+							exists, pdbErr := populateObjectIfExists(t, &actual, namespacedObjectName(naming.HorizontalPodAutoscaler(&params.OtelCol), params.OtelCol.Namespace))
 							assert.NoError(t, pdbErr)
 							assert.Equal(t, int32(1), actual.Spec.MinAvailable.IntVal)
 							assert.Nil(t, actual.Spec.MaxUnavailable)
@@ -346,7 +346,7 @@ func TestOpenTelemetryCollectorReconciler_Reconcile(t *testing.T) {
 					checks: []check{
 						func(t *testing.T, params manifests.Params) {
 							actual := policyV1.PodDisruptionBudget{}
-							exists, pdbErr := populateObjectIfExists(t, &actual, namespacedObjectName(naming.HorizontalPodAutoscaler(params.OtelCol.Name), params.OtelCol.Namespace))
+							exists, pdbErr := populateObjectIfExists(t, &actual, namespacedObjectName(naming.HorizontalPodAutoscaler(&params.OtelCol), params.OtelCol.Namespace))
 							assert.NoError(t, pdbErr)
 							assert.Nil(t, actual.Spec.MinAvailable)
 							assert.Equal(t, int32(1), actual.Spec.MaxUnavailable.IntVal)
@@ -368,7 +368,7 @@ func TestOpenTelemetryCollectorReconciler_Reconcile(t *testing.T) {
 					result: controllerruntime.Result{},
 					checks: []check{
 						func(t *testing.T, params manifests.Params) {
-							exists, err := populateObjectIfExists(t, &appsv1.DaemonSet{}, namespacedObjectName(naming.Collector(params.OtelCol.Name), params.OtelCol.Namespace))
+							exists, err := populateObjectIfExists(t, &appsv1.DaemonSet{}, namespacedObjectName(naming.Collector(&params.OtelCol), params.OtelCol.Namespace))
 							assert.NoError(t, err)
 							assert.True(t, exists)
 						},
@@ -393,10 +393,10 @@ func TestOpenTelemetryCollectorReconciler_Reconcile(t *testing.T) {
 					result: controllerruntime.Result{},
 					checks: []check{
 						func(t *testing.T, params manifests.Params) {
-							exists, err := populateObjectIfExists(t, &v1.ConfigMap{}, namespacedObjectName(naming.Collector(params.OtelCol.Name), params.OtelCol.Namespace))
+							exists, err := populateObjectIfExists(t, &v1.ConfigMap{}, namespacedObjectName(naming.Collector(&params.OtelCol), params.OtelCol.Namespace))
 							assert.NoError(t, err)
 							assert.True(t, exists)
-							exists, err = populateObjectIfExists(t, &appsv1.StatefulSet{}, namespacedObjectName(naming.Collector(params.OtelCol.Name), params.OtelCol.Namespace))
+							exists, err = populateObjectIfExists(t, &appsv1.StatefulSet{}, namespacedObjectName(naming.Collector(&params.OtelCol), params.OtelCol.Namespace))
 							assert.NoError(t, err)
 							assert.True(t, exists)
 							// Check the TA doesn't exist
@@ -415,7 +415,7 @@ func TestOpenTelemetryCollectorReconciler_Reconcile(t *testing.T) {
 					result: controllerruntime.Result{},
 					checks: []check{
 						func(t *testing.T, params manifests.Params) {
-							exists, err := populateObjectIfExists(t, &v1.ConfigMap{}, namespacedObjectName(naming.Collector(params.OtelCol.Name), params.OtelCol.Namespace))
+							exists, err := populateObjectIfExists(t, &v1.ConfigMap{}, namespacedObjectName(naming.Collector(&params.OtelCol), params.OtelCol.Namespace))
 							assert.NoError(t, err)
 							assert.True(t, exists)
 							actual := v1.ConfigMap{}
@@ -456,7 +456,7 @@ func TestOpenTelemetryCollectorReconciler_Reconcile(t *testing.T) {
 					result: controllerruntime.Result{},
 					checks: []check{
 						func(t *testing.T, params manifests.Params) {
-							exists, err := populateObjectIfExists(t, &v1.ConfigMap{}, namespacedObjectName(naming.Collector(params.OtelCol.Name), params.OtelCol.Namespace))
+							exists, err := populateObjectIfExists(t, &v1.ConfigMap{}, namespacedObjectName(naming.Collector(&params.OtelCol), params.OtelCol.Namespace))
 							assert.NoError(t, err)
 							assert.True(t, exists)
 							actual := v1.ConfigMap{}
@@ -609,7 +609,7 @@ func TestOpAMPBridgeReconciler_Reconcile(t *testing.T) {
 							exists, err = populateObjectIfExists(t, &v1.Service{}, namespacedObjectName(naming.OpAMPBridgeService(params.OpAMPBridge.Name), params.OpAMPBridge.Namespace))
 							assert.NoError(t, err)
 							assert.True(t, exists)
-							exists, err = populateObjectIfExists(t, &v1.ServiceAccount{}, namespacedObjectName(naming.ServiceAccount(params.OpAMPBridge.Name), params.OpAMPBridge.Namespace))
+							exists, err = populateObjectIfExists(t, &v1.ServiceAccount{}, namespacedObjectName(naming.OpAMPBridgeServiceAccount(params.OpAMPBridge.Name), params.OpAMPBridge.Namespace))
 							assert.NoError(t, err)
 							assert.True(t, exists)
 						},
@@ -0,0 +1,264 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+const (
+	// drainingLabel marks the collector pods departing on a graceful scale down, which the target allocator leaves out
+	// of the allocation of the targets.
+	drainingLabel = "operator.opentelemetry.io/collector-draining"
+
+	// drainingSinceAnnotation records when the pod started draining, the scale down is held for the timeout at most.
+	drainingSinceAnnotation = "operator.opentelemetry.io/draining-since"
+
+	// targetsReleasedAnnotation records when the target allocator stopped assigning targets to the draining pod.
+	targetsReleasedAnnotation = "operator.opentelemetry.io/targets-released"
+
+	defaultScaleDownTimeout = 5 * time.Minute
+
+	// targetsRefreshInterval is how long the remaining collectors may take to fetch their new targets from the target
+	// allocator, the refresh interval of its HTTP service discovery.
+	targetsRefreshInterval = time.Minute
+
+	// scaleDownCheckInterval is how often a held scale down is checked.
+	scaleDownCheckInterval = 5 * time.Second
+)
+
+var (
+	allocatorClient = &http.Client{Timeout: 5 * time.Second}
+
+	// collectorHasTargets tells whether the target allocator of the collector assigns targets to the collector pod,
+	// it's replaced by the tests.
+	collectorHasTargets = allocatorHasTargets
+)
+
+// +kubebuilder:rbac:groups="",resources=pods,verbs=patch
+
+// holdScaleDown drains the replicas departing on the scale down of a StatefulSet collector: they're labelled, so that
+// the target allocator reassigns their targets, and the desired StatefulSet keeps its current replicas until no target
+// has been assigned to them for a refresh of the targets of the remaining collectors, or the timeout of the graceful
+// scale down has elapsed. It returns the delay after which a held scale down is checked again. The pods left draining
+// by a scale down which was reverted or disabled are relabelled.
+func holdScaleDown(ctx context.Context, cli client.Client, log logr.Logger, otelcol v1alpha1.OpenTelemetryCollector, desiredObjects []client.Object, now time.Time) (time.Duration, error) {
+	if otelcol.Spec.Mode != v1alpha1.ModeStatefulSet || !otelcol.Spec.TargetAllocator.Enabled {
+		return 0, nil
+	}
+	var desired *appsv1.StatefulSet
+	for _, obj := range desiredObjects {
+		if sts, ok := obj.(*appsv1.StatefulSet); ok {
+			desired = sts
+		}
+	}
+	if desired == nil {
+		return 0, nil
+	}
+	replicas := int32(1)
+	if desired.Spec.Replicas != nil {
+		replicas = *desired.Spec.Replicas
+	}
+	current := replicas
+	if gracefulScaleDownEnabled(otelcol) {
+		existing := &appsv1.StatefulSet{}
+		err := cli.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return 0, fmt.Errorf("failed to get the StatefulSet %s: %w", desired.Name, err)
+		}
+		if err == nil && existing.Spec.Replicas != nil && *existing.Spec.Replicas > replicas {
+			current = *existing.Spec.Replicas
+		}
+	}
+
+	pods := &corev1.PodList{}
+	if err := cli.List(ctx, pods, client.InNamespace(desired.Namespace), client.MatchingLabels(desired.Spec.Selector.MatchLabels)); err != nil {
+		return 0, fmt.Errorf("failed to list the pods of the StatefulSet %s: %w", desired.Name, err)
+	}
+	var departing []corev1.Pod
+	for i := range pods.Items {
+		pod := pods.Items[i]
+		ordinal, ok := podOrdinal(desired.Name, pod.Name)
+		if !ok {
+			continue
+		}
+		draining := ordinal >= replicas && ordinal < current
+		if _, labelled := pod.Labels[drainingLabel]; draining != labelled {
+			if err := setDraining(ctx, cli, &pod, draining, now); err != nil {
+				return 0, err
+			}
+		}
+		if draining {
+			departing = append(departing, pod)
+		}
+	}
+	if len(departing) == 0 {
+		return 0, nil
+	}
+
+	timeout := scaleDownTimeout(otelcol)
+	remaining := timeout
+	for _, pod := range departing {
+		if since, err := time.Parse(time.RFC3339, pod.Annotations[drainingSinceAnnotation]); err == nil && timeout-now.Sub(since) < remaining {
+			remaining = timeout - now.Sub(since)
+		}
+	}
+	if remaining <= 0 {
+		log.Info("Scaling down the collector, the targets of the departing replicas weren't released in time", "timeout", timeout)
+		return 0, nil
+	}
+	refreshed := true
+	for i := range departing {
+		pod := &departing[i]
+		released, err := time.Parse(time.RFC3339, pod.Annotations[targetsReleasedAnnotation])
+		if err != nil {
+			assigned, queryErr := collectorHasTargets(ctx, otelcol, pod.Name)
+			if queryErr != nil {
+				log.V(2).Info("Failed to get the targets of the departing replica from the target allocator", "pod", pod.Name, "error", queryErr.Error())
+			}
+			if queryErr != nil || assigned {
+				refreshed = false
+				continue
+			}
+			released = now
+			if err := annotatePod(ctx, cli, pod, targetsReleasedAnnotation, now.UTC().Format(time.RFC3339)); err != nil {
+				return 0, err
+			}
+		}
+		if now.Sub(released) < targetsRefreshInterval {
+			refreshed = false
+		}
+	}
+	if refreshed {
+		return 0, nil
+	}
+	desired.Spec.Replicas = &current
+	if remaining < scaleDownCheckInterval {
+		return remaining, nil
+	}
+	return scaleDownCheckInterval, nil
+}
+
+func gracefulScaleDownEnabled(otelcol v1alpha1.OpenTelemetryCollector) bool {
+	return otelcol.Spec.GracefulScaleDown != nil && otelcol.Spec.GracefulScaleDown.Enabled && otelcol.Spec.Autoscaler == nil
+}
+
+func scaleDownTimeout(otelcol v1alpha1.OpenTelemetryCollector) time.Duration {
+	if otelcol.Spec.GracefulScaleDown == nil || otelcol.Spec.GracefulScaleDown.TimeoutSeconds == nil {
+		return defaultScaleDownTimeout
+	}
+	return time.Duration(*otelcol.Spec.GracefulScaleDown.TimeoutSeconds) * time.Second
+}
+
+// podOrdinal returns the ordinal of the pod of the StatefulSet, the suffix of its name.
+func podOrdinal(statefulSet, pod string) (int32, bool) {
+	suffix, found := strings.CutPrefix(pod, statefulSet+"-")
+	if !found {
+		return 0, false
+	}
+	ordinal, err := strconv.ParseInt(suffix, 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return int32(ordinal), true
+}
+
+// setDraining labels the pod as draining, along with the time it started, or removes the label and annotations of the
+// draining.
+func setDraining(ctx context.Context, cli client.Client, pod *corev1.Pod, draining bool, now time.Time) error {
+	patch := client.MergeFrom(pod.DeepCopy())
+	if draining {
+		if pod.Labels == nil {
+			pod.Labels = map[string]string{}
+		}
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		pod.Labels[drainingLabel] = "true"
+		pod.Annotations[drainingSinceAnnotation] = now.UTC().Format(time.RFC3339)
+	} else {
+		delete(pod.Labels, drainingLabel)
+		delete(pod.Annotations, drainingSinceAnnotation)
+		delete(pod.Annotations, targetsReleasedAnnotation)
+	}
+	if err := cli.Patch(ctx, pod, patch); err != nil {
+		return fmt.Errorf("failed to update the draining of the pod %s: %w", pod.Name, err)
+	}
+	return nil
+}
+
+func annotatePod(ctx context.Context, cli client.Client, pod *corev1.Pod, key, value string) error {
+	patch := client.MergeFrom(pod.DeepCopy())
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[key] = value
+	if err := cli.Patch(ctx, pod, patch); err != nil {
+		return fmt.Errorf("failed to annotate the pod %s: %w", pod.Name, err)
+	}
+	return nil
+}
+
+// allocatorHasTargets asks the target allocator of the collector for the targets it assigns to the collector pod,
+// in each of its jobs.
+func allocatorHasTargets(ctx context.Context, otelcol v1alpha1.OpenTelemetryCollector, collectorID string) (bool, error) {
+	endpoint := fmt.Sprintf("http://%s.%s.svc:80", naming.TAService(otelcol.Name), otelcol.Namespace)
+	jobs := map[string]json.RawMessage{}
+	if err := getAllocatorJSON(ctx, endpoint+"/jobs", &jobs); err != nil {
+		return false, err
+	}
+	for job := range jobs {
+		var targets []json.RawMessage
+		if err := getAllocatorJSON(ctx, fmt.Sprintf("%s/jobs/%s/targets?collector_id=%s", endpoint, url.QueryEscape(job), url.QueryEscape(collectorID)), &targets); err != nil {
+			return false, err
+		}
+		if len(targets) > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func getAllocatorJSON(ctx context.Context, endpoint string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := allocatorClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
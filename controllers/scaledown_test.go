@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestHoldScaleDown(t *testing.T) {
+	// prepare
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	selector := map[string]string{"app.kubernetes.io/instance": "test.test"}
+	statefulSet := func(replicas int32) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-collector", Namespace: "test"},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: &replicas,
+				Selector: &metav1.LabelSelector{MatchLabels: selector},
+			},
+		}
+	}
+	objects := []client.Object{statefulSet(3)}
+	for i := 0; i < 3; i++ {
+		objects = append(objects, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("test-collector-%d", i),
+			Namespace: "test",
+			Labels:    selector,
+		}})
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:              v1alpha1.ModeStatefulSet,
+			TargetAllocator:   v1alpha1.OpenTelemetryTargetAllocator{Enabled: true},
+			GracefulScaleDown: &v1alpha1.GracefulScaleDownSpec{Enabled: true},
+		},
+	}
+	assigned := map[string]bool{"test-collector-1": true, "test-collector-2": true}
+	collectorHasTargets = func(_ context.Context, _ v1alpha1.OpenTelemetryCollector, collectorID string) (bool, error) {
+		return assigned[collectorID], nil
+	}
+	defer func() {
+		collectorHasTargets = allocatorHasTargets
+	}()
+	start := time.Date(2023, 11, 1, 10, 0, 0, 0, time.UTC)
+	draining := func() []string {
+		pods := &corev1.PodList{}
+		require.NoError(t, cli.List(context.Background(), pods))
+		var names []string
+		for _, pod := range pods.Items {
+			if pod.Labels[drainingLabel] == "true" {
+				names = append(names, pod.Name)
+			}
+		}
+		return names
+	}
+
+	t.Run("should hold the scale down while targets are assigned to the departing replicas", func(t *testing.T) {
+		// test
+		desired := statefulSet(1)
+		requeue, err := holdScaleDown(context.Background(), cli, logr.Discard(), otelcol, []client.Object{desired}, start)
+
+		// verify
+		require.NoError(t, err)
+		assert.Equal(t, scaleDownCheckInterval, requeue)
+		assert.Equal(t, int32(3), *desired.Spec.Replicas)
+		assert.Equal(t, []string{"test-collector-1", "test-collector-2"}, draining())
+	})
+
+	t.Run("should hold the scale down until the remaining replicas refreshed their targets", func(t *testing.T) {
+		// prepare
+		assigned = map[string]bool{}
+
+		// test
+		desired := statefulSet(1)
+		requeue, err := holdScaleDown(context.Background(), cli, logr.Discard(), otelcol, []client.Object{desired}, start.Add(10*time.Second))
+
+		// verify
+		require.NoError(t, err)
+		assert.Equal(t, scaleDownCheckInterval, requeue)
+		assert.Equal(t, int32(3), *desired.Spec.Replicas)
+	})
+
+	t.Run("should scale down once the targets were released", func(t *testing.T) {
+		// test
+		desired := statefulSet(1)
+		requeue, err := holdScaleDown(context.Background(), cli, logr.Discard(), otelcol, []client.Object{desired}, start.Add(10*time.Second+targetsRefreshInterval))
+
+		// verify
+		require.NoError(t, err)
+		assert.Zero(t, requeue)
+		assert.Equal(t, int32(1), *desired.Spec.Replicas)
+	})
+
+	t.Run("should relabel the replicas when the scale down is reverted", func(t *testing.T) {
+		// test
+		desired := statefulSet(3)
+		requeue, err := holdScaleDown(context.Background(), cli, logr.Discard(), otelcol, []client.Object{desired}, start.Add(time.Minute))
+
+		// verify
+		require.NoError(t, err)
+		assert.Zero(t, requeue)
+		assert.Equal(t, int32(3), *desired.Spec.Replicas)
+		assert.Empty(t, draining())
+	})
+
+	t.Run("should scale down once the timeout elapsed", func(t *testing.T) {
+		// prepare
+		assigned = map[string]bool{"test-collector-2": true}
+		_, err := holdScaleDown(context.Background(), cli, logr.Discard(), otelcol, []client.Object{statefulSet(2)}, start)
+		require.NoError(t, err)
+
+		// test
+		desired := statefulSet(2)
+		requeue, err := holdScaleDown(context.Background(), cli, logr.Discard(), otelcol, []client.Object{desired}, start.Add(defaultScaleDownTimeout))
+
+		// verify
+		require.NoError(t, err)
+		assert.Zero(t, requeue)
+		assert.Equal(t, int32(2), *desired.Spec.Replicas)
+		assert.Equal(t, []string{"test-collector-2"}, draining())
+	})
+}
+
+func TestPodOrdinal(t *testing.T) {
+	for _, tt := range []struct {
+		pod     string
+		ordinal int32
+		ok      bool
+	}{
+		{pod: "test-collector-0", ordinal: 0, ok: true},
+		{pod: "test-collector-12", ordinal: 12, ok: true},
+		{pod: "test-collector-canary-7d9f", ok: false},
+		{pod: "other-collector-1", ok: false},
+	} {
+		ordinal, ok := podOrdinal("test-collector", tt.pod)
+		assert.Equal(t, tt.ok, ok, tt.pod)
+		assert.Equal(t, tt.ordinal, ordinal, tt.pod)
+	}
+}
@@ -136,6 +136,11 @@ func TestMain(m *testing.M) {
 		os.Exit(1)
 	}
 
+	if err = v1alpha1.SetupInstrumentationWebhook(mgr, config.New()); err != nil {
+		fmt.Printf("failed to SetupWebhookWithManager: %v", err)
+		os.Exit(1)
+	}
+
 	ctx, cancel = context.WithCancel(context.TODO())
 	defer cancel()
 	go func() {
@@ -210,7 +215,7 @@ func paramsWithModeAndReplicas(mode v1alpha1.Mode, replicas int32) manifests.Par
 			},
 			Spec: v1alpha1.OpenTelemetryCollectorSpec{
 				Image: "ghcr.io/open-telemetry/opentelemetry-operator/opentelemetry-operator:0.47.0",
-				Ports: []v1.ServicePort{{
+				Ports: []v1alpha1.PortsSpec{{ServicePort: v1.ServicePort{
 					Name: "web",
 					Port: 80,
 					TargetPort: intstr.IntOrString{
@@ -218,7 +223,7 @@ func paramsWithModeAndReplicas(mode v1alpha1.Mode, replicas int32) manifests.Par
 						IntVal: 80,
 					},
 					NodePort: 0,
-				}},
+				}}},
 				Replicas: &replicas,
 				Config:   string(configYAML),
 				Mode:     mode,
@@ -260,7 +265,7 @@ func newParams(taContainerImage string, file string) (manifests.Params, error) {
 			},
 			Spec: v1alpha1.OpenTelemetryCollectorSpec{
 				Mode: v1alpha1.ModeStatefulSet,
-				Ports: []v1.ServicePort{{
+				Ports: []v1alpha1.PortsSpec{{ServicePort: v1.ServicePort{
 					Name: "web",
 					Port: 80,
 					TargetPort: intstr.IntOrString{
@@ -268,7 +273,7 @@ func newParams(taContainerImage string, file string) (manifests.Params, error) {
 						IntVal: 80,
 					},
 					NodePort: 0,
-				}},
+				}}},
 				TargetAllocator: v1alpha1.OpenTelemetryTargetAllocator{
 					Enabled: true,
 					Image:   taContainerImage,
@@ -306,7 +311,7 @@ func paramsWithHPA(minReps, maxReps int32) manifests.Params {
 				UID:       instanceUID,
 			},
 			Spec: v1alpha1.OpenTelemetryCollectorSpec{
-				Ports: []v1.ServicePort{{
+				Ports: []v1alpha1.PortsSpec{{ServicePort: v1.ServicePort{
 					Name: "web",
 					Port: 80,
 					TargetPort: intstr.IntOrString{
@@ -314,7 +319,7 @@ func paramsWithHPA(minReps, maxReps int32) manifests.Params {
 						IntVal: 80,
 					},
 					NodePort: 0,
-				}},
+				}}},
 				Config: string(configYAML),
 				Autoscaler: &v1alpha1.AutoscalerSpec{
 					MinReplicas:          &minReps,
@@ -372,7 +377,7 @@ func paramsWithPolicy(minAvailable, maxUnavailable int32) manifests.Params {
 				UID:       instanceUID,
 			},
 			Spec: v1alpha1.OpenTelemetryCollectorSpec{
-				Ports: []v1.ServicePort{{
+				Ports: []v1alpha1.PortsSpec{{ServicePort: v1.ServicePort{
 					Name: "web",
 					Port: 80,
 					TargetPort: intstr.IntOrString{
@@ -380,7 +385,7 @@ func paramsWithPolicy(minAvailable, maxUnavailable int32) manifests.Params {
 						IntVal: 80,
 					},
 					NodePort: 0,
-				}},
+				}}},
 				Config:              string(configYAML),
 				PodDisruptionBudget: pdb,
 			},
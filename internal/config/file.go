@@ -0,0 +1,200 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v2"
+)
+
+// FileLoader sets the flags of the operator from a YAML file, whose keys are the names of the flags, e.g.:
+//
+//	collector-image: ghcr.io/open-telemetry/opentelemetry-collector-releases/opentelemetry-collector-contrib:0.89.0
+//	labels: ["team", "*.example.com/*"]
+//	feature-gates: "+operator.autoinstrumentation.go"
+//
+// The flags given on the command line take precedence over the file.
+type FileLoader struct {
+	flags   *pflag.FlagSet
+	path    string
+	logger  logr.Logger
+	cli     map[string]bool
+	applied map[string]interface{}
+	content []byte
+}
+
+// NewFileLoader returns a loader for the given file. The flags must have been parsed already, so that the ones given on
+// the command line are known.
+func NewFileLoader(flags *pflag.FlagSet, path string, logger logr.Logger) *FileLoader {
+	cli := map[string]bool{}
+	flags.Visit(func(f *pflag.Flag) {
+		cli[f.Name] = true
+	})
+	return &FileLoader{flags: flags, path: path, logger: logger, cli: cli, applied: map[string]interface{}{}}
+}
+
+// Load sets the flags from the file, and returns the names of the flags whose value changed.
+func (l *FileLoader) Load() ([]string, error) {
+	changed, _, err := l.load(nil)
+	return changed, err
+}
+
+// Reload sets the reloadable flags from the file, when it changed, and returns the names of the flags whose value
+// changed. Reloadable flags which were set from a previous version of the file but aren't part of it anymore are reset
+// to their default value. The changes to the other settings are left out, as the operator only reads them when it
+// starts, and their names are returned as ignored.
+func (l *FileLoader) Reload(reloadable map[string]bool) (changed []string, ignored []string, err error) {
+	return l.load(reloadable)
+}
+
+// load applies the settings of the file whose name is in reloadable, or all of them when reloadable is nil.
+func (l *FileLoader) load(reloadable map[string]bool) ([]string, []string, error) {
+	content, err := os.ReadFile(l.path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read the configuration file: %w", err)
+	}
+	if l.content != nil && bytes.Equal(content, l.content) {
+		return nil, nil, nil
+	}
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal(content, &values); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse the configuration file %s: %w", l.path, err)
+	}
+	for name := range values {
+		if l.flags.Lookup(name) == nil {
+			return nil, nil, fmt.Errorf("unknown setting %q in the configuration file %s", name, l.path)
+		}
+	}
+	apply := func(name string) bool {
+		return reloadable == nil || reloadable[name]
+	}
+
+	var ignored []string
+	for name, value := range values {
+		if current, ok := l.applied[name]; !apply(name) && !l.cli[name] && (!ok || !reflect.DeepEqual(current, value)) {
+			ignored = append(ignored, name)
+		}
+	}
+	for name := range l.applied {
+		if _, ok := values[name]; !ok && !apply(name) {
+			ignored = append(ignored, name)
+		}
+	}
+
+	before := map[string]string{}
+	l.flags.VisitAll(func(f *pflag.Flag) {
+		before[f.Name] = f.Value.String()
+	})
+	for name := range l.applied {
+		if _, ok := values[name]; !ok && apply(name) {
+			if err := setFlag(l.flags.Lookup(name), defaultValue(l.flags.Lookup(name))); err != nil {
+				return nil, nil, fmt.Errorf("failed to reset %q to its default value: %w", name, err)
+			}
+			delete(l.applied, name)
+		}
+	}
+	for name, value := range values {
+		if l.cli[name] {
+			l.logger.V(1).Info("ignoring the setting of the configuration file given on the command line", "setting", name)
+			continue
+		}
+		if !apply(name) {
+			continue
+		}
+		if err := setFlag(l.flags.Lookup(name), value); err != nil {
+			return nil, nil, fmt.Errorf("invalid value for %q in the configuration file %s: %w", name, l.path, err)
+		}
+		l.applied[name] = value
+	}
+	l.content = content
+
+	var changed []string
+	l.flags.VisitAll(func(f *pflag.Flag) {
+		if before[f.Name] != f.Value.String() {
+			changed = append(changed, f.Name)
+		}
+	})
+	sort.Strings(changed)
+	sort.Strings(ignored)
+	return changed, ignored, nil
+}
+
+// Watch checks the file for changes at the given interval until the context is done, reloads the given flags and calls
+// onChange with the names of the flags whose value changed, and of the changed settings left out until the operator
+// restarts. Checking the content of the file, rather than watching file system events, keeps working with ConfigMaps,
+// which are updated by swapping symbolic links.
+func (l *FileLoader) Watch(ctx context.Context, interval time.Duration, reloadable map[string]bool, onChange func(changed, ignored []string)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			changed, ignored, err := l.Reload(reloadable)
+			if err != nil {
+				l.logger.Error(err, "failed to reload the configuration file, keeping the current settings")
+				continue
+			}
+			if len(changed) > 0 || len(ignored) > 0 {
+				onChange(changed, ignored)
+			}
+		}
+	}
+}
+
+func setFlag(f *pflag.Flag, value interface{}) error {
+	var items []string
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			items = append(items, fmt.Sprint(item))
+		}
+	case []string:
+		items = v
+	case map[interface{}]interface{}:
+		return fmt.Errorf("nested settings aren't supported")
+	case nil:
+		items = nil
+	default:
+		items = []string{fmt.Sprint(v)}
+	}
+	if sv, ok := f.Value.(pflag.SliceValue); ok {
+		return sv.Replace(items)
+	}
+	return f.Value.Set(strings.Join(items, ","))
+}
+
+func defaultValue(f *pflag.Flag) interface{} {
+	if _, ok := f.Value.(pflag.SliceValue); ok {
+		// the default values of the slice flags are formatted as "[a,b]"
+		def := strings.TrimSuffix(strings.TrimPrefix(f.DefValue, "["), "]")
+		if def == "" {
+			return []string{}
+		}
+		return strings.Split(def, ",")
+	}
+	return f.DefValue
+}
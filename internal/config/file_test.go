@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func newTestFlags(t *testing.T, args ...string) (*pflag.FlagSet, *string, *[]string, *bool) {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	image := flags.String("collector-image", "default-image", "")
+	labels := flags.StringSlice("labels", []string{}, "")
+	metrics := flags.Bool("enable-metrics", false, "")
+	require.NoError(t, flags.Parse(args))
+	return flags, image, labels, metrics
+}
+
+func writeConfigFile(t *testing.T, path, content string) {
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+}
+
+func TestFileLoaderSetsFlags(t *testing.T) {
+	// prepare
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfigFile(t, path, `
+collector-image: file-image
+labels: ["team", "*.example.com/*"]
+enable-metrics: true
+`)
+	flags, image, labels, metrics := newTestFlags(t)
+
+	// test
+	changed, err := config.NewFileLoader(flags, path, logr.Discard()).Load()
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, []string{"collector-image", "enable-metrics", "labels"}, changed)
+	assert.Equal(t, "file-image", *image)
+	assert.Equal(t, []string{"team", "*.example.com/*"}, *labels)
+	assert.True(t, *metrics)
+}
+
+func TestFileLoaderCommandLineTakesPrecedence(t *testing.T) {
+	// prepare
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfigFile(t, path, "collector-image: file-image\nenable-metrics: true\n")
+	flags, image, _, metrics := newTestFlags(t, "--collector-image=cli-image")
+
+	// test
+	changed, err := config.NewFileLoader(flags, path, logr.Discard()).Load()
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, []string{"enable-metrics"}, changed)
+	assert.Equal(t, "cli-image", *image)
+	assert.True(t, *metrics)
+}
+
+func TestFileLoaderUnknownSetting(t *testing.T) {
+	// prepare
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfigFile(t, path, "collector-image: file-image\nunknown: value\n")
+	flags, image, _, _ := newTestFlags(t)
+
+	// test
+	_, err := config.NewFileLoader(flags, path, logr.Discard()).Load()
+
+	// verify
+	assert.ErrorContains(t, err, "unknown")
+	assert.Equal(t, "default-image", *image)
+}
+
+func TestFileLoaderReload(t *testing.T) {
+	// prepare
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfigFile(t, path, "collector-image: file-image\nlabels: [team]\n")
+	flags, image, labels, _ := newTestFlags(t)
+	loader := config.NewFileLoader(flags, path, logr.Discard())
+	_, err := loader.Load()
+	require.NoError(t, err)
+
+	reloadable := map[string]bool{"collector-image": true, "labels": true}
+
+	// test: the unchanged file is ignored
+	changed, ignored, err := loader.Reload(reloadable)
+	require.NoError(t, err)
+	assert.Empty(t, changed)
+	assert.Empty(t, ignored)
+
+	// test: the settings removed from the file are reset to their default value
+	writeConfigFile(t, path, "collector-image: other-image\n")
+	changed, ignored, err = loader.Reload(reloadable)
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, []string{"collector-image", "labels"}, changed)
+	assert.Empty(t, ignored)
+	assert.Equal(t, "other-image", *image)
+	assert.Empty(t, *labels)
+}
+
+func TestFileLoaderReloadIgnoresNonReloadableSettings(t *testing.T) {
+	// prepare
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfigFile(t, path, "collector-image: file-image\nenable-metrics: true\n")
+	flags, image, labels, metrics := newTestFlags(t)
+	loader := config.NewFileLoader(flags, path, logr.Discard())
+	_, err := loader.Load()
+	require.NoError(t, err)
+	reloadable := map[string]bool{"collector-image": true}
+
+	// test: the removed setting keeps the value the operator started with
+	writeConfigFile(t, path, "collector-image: other-image\nlabels: [team]\n")
+	changed, ignored, err := loader.Reload(reloadable)
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, []string{"collector-image"}, changed)
+	assert.Equal(t, []string{"enable-metrics", "labels"}, ignored)
+	assert.Equal(t, "other-image", *image)
+	assert.Empty(t, *labels)
+	assert.True(t, *metrics)
+
+	// test: the setting back to the value the operator started with isn't reported anymore
+	writeConfigFile(t, path, "collector-image: other-image\nenable-metrics: true\n")
+	changed, ignored, err = loader.Reload(reloadable)
+
+	// verify
+	require.NoError(t, err)
+	assert.Empty(t, changed)
+	assert.Empty(t, ignored)
+	assert.True(t, *metrics)
+}
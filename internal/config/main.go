@@ -20,8 +20,10 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
+	"github.com/open-telemetry/opentelemetry-operator/internal/imagedigest"
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect"
 )
@@ -35,29 +37,98 @@ const (
 
 // Config holds the static configuration for this operator.
 type Config struct {
-	autoDetect                          autodetect.AutoDetect
-	logger                              logr.Logger
+	autoDetect                        autodetect.AutoDetect
+	logger                            logr.Logger
+	collectorConfigMapEntry           string
+	targetAllocatorConfigMapEntry     string
+	operatorOpAMPBridgeConfigMapEntry string
+	onOpenShiftRoutesChange           changeHandler
+	openshiftRoutes                   openshiftRoutesStore
+	platform                          platformStore
+	rbacPermissions                   rbacPermissionsStore
+	serviceMesh                       serviceMeshStore
+	settings                          settingsStore
+	autoDetectFrequency               time.Duration
+	autopilot                         bool
+	defaultSecurityContext            *corev1.SecurityContext
+	sidecarMaxResources               corev1.ResourceList
+	sidecarAllowPrivileged            bool
+	namespaceScoped                   bool
+	watchNamespaces                   []string
+	resourceQuotaValidation           bool
+	imageDigestResolver               *imagedigest.Resolver
+	defaultTolerations                []corev1.Toleration
+	defaultNodeSelector               map[string]string
+	defaultResources                  corev1.ResourceRequirements
+}
+
+// settings holds the parts of the configuration which can be reloaded while the operator runs.
+type settings struct {
+	collectorImage                      string
 	targetAllocatorImage                string
 	operatorOpAMPBridgeImage            string
+	autoInstrumentationJavaImage        string
+	autoInstrumentationNodeJSImage      string
 	autoInstrumentationPythonImage      string
-	collectorImage                      string
-	collectorConfigMapEntry             string
 	autoInstrumentationDotNetImage      string
 	autoInstrumentationGoImage          string
 	autoInstrumentationApacheHttpdImage string
 	autoInstrumentationNginxImage       string
-	targetAllocatorConfigMapEntry       string
-	operatorOpAMPBridgeConfigMapEntry   string
-	autoInstrumentationNodeJSImage      string
-	autoInstrumentationJavaImage        string
-	onOpenShiftRoutesChange             changeHandler
+	spiffeHelperImage                   string
+	drainImage                          string
 	labelsFilter                        []string
-	openshiftRoutes                     openshiftRoutesStore
-	autoDetectFrequency                 time.Duration
+	annotationsFilter                   []string
+	allowedImageRegistries              []string
 }
 
 // New constructs a new configuration based on the given options.
 func New(opts ...Option) Config {
+	o := newOptions(opts...)
+	o.imageDigestResolver.SetRegistries(o.allowedImageRegistries)
+	return Config{
+		autoDetect:                        o.autoDetect,
+		autoDetectFrequency:               o.autoDetectFrequency,
+		collectorConfigMapEntry:           o.collectorConfigMapEntry,
+		targetAllocatorConfigMapEntry:     o.targetAllocatorConfigMapEntry,
+		operatorOpAMPBridgeConfigMapEntry: o.operatorOpAMPBridgeConfigMapEntry,
+		logger:                            o.logger,
+		openshiftRoutes:                   o.openshiftRoutes,
+		platform:                          o.platform,
+		rbacPermissions:                   o.rbacPermissions,
+		serviceMesh:                       o.serviceMesh,
+		onOpenShiftRoutesChange:           o.onOpenShiftRoutesChange,
+		settings:                          newSettingsWrapper(o.settings()),
+		autopilot:                         o.autopilot,
+		defaultSecurityContext:            o.defaultSecurityContext,
+		sidecarMaxResources:               o.sidecarMaxResources,
+		sidecarAllowPrivileged:            o.sidecarAllowPrivileged,
+		namespaceScoped:                   o.namespaceScoped,
+		watchNamespaces:                   o.watchNamespaces,
+		resourceQuotaValidation:           o.resourceQuotaValidation,
+		imageDigestResolver:               o.imageDigestResolver,
+		defaultTolerations:                o.defaultTolerations,
+		defaultNodeSelector:               o.defaultNodeSelector,
+		defaultResources:                  o.defaultResources,
+	}
+}
+
+// Reload replaces the default images and the labels filter with the ones of the given options, for all the copies
+// of the configuration. The other options are ignored, as they can't change while the operator runs.
+func (c *Config) Reload(opts ...Option) {
+	o := newOptions(opts...)
+	c.logger.V(1).Info("reloading the configuration")
+	c.settings.Set(o.settings())
+	c.imageDigestResolver.SetRegistries(o.allowedImageRegistries)
+}
+
+func (c *Config) current() settings {
+	if c.settings == nil {
+		return settings{}
+	}
+	return c.settings.Get()
+}
+
+func newOptions(opts ...Option) options {
 	// initialize with the default values
 	o := options{
 		autoDetectFrequency:               defaultAutoDetectFrequency,
@@ -66,34 +137,16 @@ func New(opts ...Option) Config {
 		operatorOpAMPBridgeConfigMapEntry: defaultOperatorOpAMPBridgeConfigMapEntry,
 		logger:                            logf.Log.WithName("config"),
 		openshiftRoutes:                   newOpenShiftRoutesWrapper(),
+		platform:                          newPlatformWrapper(),
+		rbacPermissions:                   newRBACPermissionsWrapper(),
+		serviceMesh:                       newServiceMeshWrapper(),
 		version:                           version.Get(),
 		onOpenShiftRoutesChange:           newOnChange(),
 	}
 	for _, opt := range opts {
 		opt(&o)
 	}
-
-	return Config{
-		autoDetect:                          o.autoDetect,
-		autoDetectFrequency:                 o.autoDetectFrequency,
-		collectorImage:                      o.collectorImage,
-		collectorConfigMapEntry:             o.collectorConfigMapEntry,
-		targetAllocatorImage:                o.targetAllocatorImage,
-		operatorOpAMPBridgeImage:            o.operatorOpAMPBridgeImage,
-		targetAllocatorConfigMapEntry:       o.targetAllocatorConfigMapEntry,
-		operatorOpAMPBridgeConfigMapEntry:   o.operatorOpAMPBridgeConfigMapEntry,
-		logger:                              o.logger,
-		openshiftRoutes:                     o.openshiftRoutes,
-		onOpenShiftRoutesChange:             o.onOpenShiftRoutesChange,
-		autoInstrumentationJavaImage:        o.autoInstrumentationJavaImage,
-		autoInstrumentationNodeJSImage:      o.autoInstrumentationNodeJSImage,
-		autoInstrumentationPythonImage:      o.autoInstrumentationPythonImage,
-		autoInstrumentationDotNetImage:      o.autoInstrumentationDotNetImage,
-		autoInstrumentationGoImage:          o.autoInstrumentationGoImage,
-		autoInstrumentationApacheHttpdImage: o.autoInstrumentationApacheHttpdImage,
-		autoInstrumentationNginxImage:       o.autoInstrumentationNginxImage,
-		labelsFilter:                        o.labelsFilter,
-	}
+	return o
 }
 
 // StartAutoDetect attempts to automatically detect relevant information for this operator. This will block until the first
@@ -133,12 +186,52 @@ func (c *Config) AutoDetect() error {
 		}
 	}
 
+	plt, err := c.autoDetect.Platform()
+	if err != nil {
+		return err
+	}
+	version := ""
+	if plt == autodetect.PlatformOpenShift && !c.namespaceScoped {
+		// the version is informative only, not being allowed to read it mustn't prevent the operator from working
+		if version, err = c.autoDetect.OpenShiftVersion(); err != nil {
+			c.logger.V(1).Info("couldn't detect the OpenShift version", "error", err)
+		}
+	}
+	if current, currentVersion := c.platform.Get(); current != plt || currentVersion != version {
+		c.logger.V(1).Info("platform detected", "platform", plt, "version", version)
+		c.platform.Set(plt, version)
+	}
+
+	// the permissions are checked with access reviews, which any user may create, but a failed check mustn't prevent
+	// the operator from working either: the components then need RBAC objects created by the cluster admins
+	// the namespace-scoped operator never creates cluster-scoped objects, whatever its permissions
+	rbac := autodetect.RBACPermissionsNotAvailable
+	if !c.namespaceScoped {
+		if rbac, err = c.autoDetect.RBACPermissions(); err != nil {
+			c.logger.V(1).Info("couldn't check the RBAC permissions of the operator", "error", err)
+			rbac = autodetect.RBACPermissionsNotAvailable
+		}
+	}
+	if c.rbacPermissions.Get() != rbac {
+		c.logger.V(1).Info("RBAC permissions detected", "available", rbac)
+		c.rbacPermissions.Set(rbac)
+	}
+
+	mesh, err := c.autoDetect.ServiceMesh()
+	if err != nil {
+		return err
+	}
+	if c.serviceMesh.Get() != mesh {
+		c.logger.V(1).Info("service mesh detected", "mesh", mesh)
+		c.serviceMesh.Set(mesh)
+	}
+
 	return nil
 }
 
 // CollectorImage represents the flag to override the OpenTelemetry Collector container image.
 func (c *Config) CollectorImage() string {
-	return c.collectorImage
+	return c.current().collectorImage
 }
 
 // CollectorConfigMapEntry represents the configuration file name for the collector. Immutable.
@@ -148,12 +241,23 @@ func (c *Config) CollectorConfigMapEntry() string {
 
 // TargetAllocatorImage represents the flag to override the OpenTelemetry TargetAllocator container image.
 func (c *Config) TargetAllocatorImage() string {
-	return c.targetAllocatorImage
+	return c.current().targetAllocatorImage
 }
 
 // OperatorOpAMPBridgeImage represents the flag to override the OpAMPBridge container image.
 func (c *Config) OperatorOpAMPBridgeImage() string {
-	return c.operatorOpAMPBridgeImage
+	return c.current().operatorOpAMPBridgeImage
+}
+
+// SpiffeHelperImage represents the flag to override the spiffe-helper container image.
+func (c *Config) SpiffeHelperImage() string {
+	return c.current().spiffeHelperImage
+}
+
+// DrainImage represents the flag to override the image providing the sleep binary of the preStop hook draining the
+// collectors.
+func (c *Config) DrainImage() string {
+	return c.current().drainImage
 }
 
 // TargetAllocatorConfigMapEntry represents the configuration file name for the TargetAllocator. Immutable.
@@ -171,44 +275,155 @@ func (c *Config) OpenShiftRoutes() autodetect.OpenShiftRoutesAvailability {
 	return c.openshiftRoutes.Get()
 }
 
+// Platform represents the platform the operator runs on.
+func (c *Config) Platform() autodetect.Platform {
+	if c.platform == nil {
+		return autodetect.PlatformKubernetes
+	}
+	plt, _ := c.platform.Get()
+	return plt
+}
+
+// OpenShiftVersion returns the version of the OpenShift cluster the operator runs on, if it could be detected.
+func (c *Config) OpenShiftVersion() string {
+	if c.platform == nil {
+		return ""
+	}
+	_, version := c.platform.Get()
+	return version
+}
+
+// CreateRBACPermissions represents whether the operator may create the ClusterRoles and ClusterRoleBindings needed by
+// the components it manages.
+func (c *Config) CreateRBACPermissions() autodetect.RBACPermissions {
+	if c.rbacPermissions == nil {
+		return autodetect.RBACPermissionsNotAvailable
+	}
+	return c.rbacPermissions.Get()
+}
+
+// ServiceMesh represents the service mesh installed in the cluster the operator runs on.
+func (c *Config) ServiceMesh() autodetect.ServiceMesh {
+	if c.serviceMesh == nil {
+		return autodetect.ServiceMeshNone
+	}
+	return c.serviceMesh.Get()
+}
+
+// Autopilot represents whether the manifests are generated for the constraints of GKE Autopilot clusters. Immutable.
+func (c *Config) Autopilot() bool {
+	return c.autopilot
+}
+
+// DefaultSecurityContext returns the security context the fields unset in the security contexts of the generated
+// containers are defaulted from, nil when there's none. Immutable.
+func (c *Config) DefaultSecurityContext() *corev1.SecurityContext {
+	return c.defaultSecurityContext.DeepCopy()
+}
+
+// SidecarMaxResources returns the upper bounds of the requests and limits the pods may set on their sidecar with
+// annotations, the resources without a bound aren't part of it. Immutable.
+func (c *Config) SidecarMaxResources() corev1.ResourceList {
+	return c.sidecarMaxResources.DeepCopy()
+}
+
+// SidecarAllowPrivileged represents whether the pods may make their sidecar privileged, or run it as root, with
+// annotations. Immutable.
+func (c *Config) SidecarAllowPrivileged() bool {
+	return c.sidecarAllowPrivileged
+}
+
+// NamespaceScoped represents whether the operator runs with namespace-scoped permissions only: it then neither reads
+// nor creates cluster-scoped objects, and rejects the custom resources needing them. Immutable.
+func (c *Config) NamespaceScoped() bool {
+	return c.namespaceScoped
+}
+
+// WatchNamespaces represents the namespaces the operator watches, all of them when empty. Immutable.
+func (c *Config) WatchNamespaces() []string {
+	return c.watchNamespaces
+}
+
+// ResourceQuotaValidation represents whether the webhooks reject the custom resources whose pods would be refused by
+// the LimitRanges or the ResourceQuotas of their namespace. Immutable.
+func (c *Config) ResourceQuotaValidation() bool {
+	return c.resourceQuotaValidation
+}
+
+// DefaultTolerations returns the tolerations of the pods generated by the operator whose custom resource sets none.
+// Immutable.
+func (c *Config) DefaultTolerations() []corev1.Toleration {
+	return c.defaultTolerations
+}
+
+// DefaultNodeSelector returns the node selector of the pods generated by the operator whose custom resource sets none.
+// Immutable.
+func (c *Config) DefaultNodeSelector() map[string]string {
+	return c.defaultNodeSelector
+}
+
+// DefaultResources returns the resource requirements of the containers generated by the operator whose custom
+// resource sets none. Immutable.
+func (c *Config) DefaultResources() corev1.ResourceRequirements {
+	return c.defaultResources
+}
+
+// ImageDigestResolver returns the resolver pinning the images of the custom resources to digests in the defaulting
+// webhooks, nil when they aren't pinned. It resolves the digests from the allowed image registries only. Immutable.
+func (c *Config) ImageDigestResolver() *imagedigest.Resolver {
+	return c.imageDigestResolver
+}
+
 // AutoInstrumentationJavaImage returns OpenTelemetry Java auto-instrumentation container image.
 func (c *Config) AutoInstrumentationJavaImage() string {
-	return c.autoInstrumentationJavaImage
+	return c.current().autoInstrumentationJavaImage
 }
 
 // AutoInstrumentationNodeJSImage returns OpenTelemetry NodeJS auto-instrumentation container image.
 func (c *Config) AutoInstrumentationNodeJSImage() string {
-	return c.autoInstrumentationNodeJSImage
+	return c.current().autoInstrumentationNodeJSImage
 }
 
 // AutoInstrumentationPythonImage returns OpenTelemetry Python auto-instrumentation container image.
 func (c *Config) AutoInstrumentationPythonImage() string {
-	return c.autoInstrumentationPythonImage
+	return c.current().autoInstrumentationPythonImage
 }
 
 // AutoInstrumentationDotNetImage returns OpenTelemetry DotNet auto-instrumentation container image.
 func (c *Config) AutoInstrumentationDotNetImage() string {
-	return c.autoInstrumentationDotNetImage
+	return c.current().autoInstrumentationDotNetImage
 }
 
 // AutoInstrumentationGoImage returns OpenTelemetry Go auto-instrumentation container image.
 func (c *Config) AutoInstrumentationGoImage() string {
-	return c.autoInstrumentationGoImage
+	return c.current().autoInstrumentationGoImage
 }
 
 // AutoInstrumentationApacheHttpdImage returns OpenTelemetry ApacheHttpd auto-instrumentation container image.
 func (c *Config) AutoInstrumentationApacheHttpdImage() string {
-	return c.autoInstrumentationApacheHttpdImage
+	return c.current().autoInstrumentationApacheHttpdImage
 }
 
 // AutoInstrumentationNginxImage returns OpenTelemetry Nginx auto-instrumentation container image.
 func (c *Config) AutoInstrumentationNginxImage() string {
-	return c.autoInstrumentationNginxImage
+	return c.current().autoInstrumentationNginxImage
 }
 
 // LabelsFilter Returns the filters converted to regex strings used to filter out unwanted labels from propagations.
 func (c *Config) LabelsFilter() []string {
-	return c.labelsFilter
+	return c.current().labelsFilter
+}
+
+// AnnotationsFilter returns the filters converted to regex strings used to filter out unwanted annotations from
+// propagations.
+func (c *Config) AnnotationsFilter() []string {
+	return c.current().annotationsFilter
+}
+
+// AllowedImageRegistries returns the registries the images of the custom resources must be pulled from, any registry is
+// allowed when empty.
+func (c *Config) AllowedImageRegistries() []string {
+	return c.current().allowedImageRegistries
 }
 
 // RegisterOpenShiftRoutesChangeCallback registers the given function as a callback that
@@ -245,3 +460,119 @@ func (p *openshiftRoutesWrapper) Get() autodetect.OpenShiftRoutesAvailability {
 	p.mu.Unlock()
 	return ora
 }
+
+type platformStore interface {
+	Set(plt autodetect.Platform, version string)
+	Get() (autodetect.Platform, string)
+}
+
+func newPlatformWrapper() platformStore {
+	return &platformWrapper{
+		current: autodetect.PlatformKubernetes,
+	}
+}
+
+type platformWrapper struct {
+	mu      sync.Mutex
+	current autodetect.Platform
+	version string
+}
+
+func (p *platformWrapper) Set(plt autodetect.Platform, version string) {
+	p.mu.Lock()
+	p.current = plt
+	p.version = version
+	p.mu.Unlock()
+}
+
+func (p *platformWrapper) Get() (autodetect.Platform, string) {
+	p.mu.Lock()
+	plt, version := p.current, p.version
+	p.mu.Unlock()
+	return plt, version
+}
+
+type rbacPermissionsStore interface {
+	Set(rbac autodetect.RBACPermissions)
+	Get() autodetect.RBACPermissions
+}
+
+func newRBACPermissionsWrapper() rbacPermissionsStore {
+	return &rbacPermissionsWrapper{
+		current: autodetect.RBACPermissionsNotAvailable,
+	}
+}
+
+type rbacPermissionsWrapper struct {
+	mu      sync.Mutex
+	current autodetect.RBACPermissions
+}
+
+func (p *rbacPermissionsWrapper) Set(rbac autodetect.RBACPermissions) {
+	p.mu.Lock()
+	p.current = rbac
+	p.mu.Unlock()
+}
+
+func (p *rbacPermissionsWrapper) Get() autodetect.RBACPermissions {
+	p.mu.Lock()
+	rbac := p.current
+	p.mu.Unlock()
+	return rbac
+}
+
+type serviceMeshStore interface {
+	Set(mesh autodetect.ServiceMesh)
+	Get() autodetect.ServiceMesh
+}
+
+func newServiceMeshWrapper() serviceMeshStore {
+	return &serviceMeshWrapper{
+		current: autodetect.ServiceMeshNone,
+	}
+}
+
+type serviceMeshWrapper struct {
+	mu      sync.Mutex
+	current autodetect.ServiceMesh
+}
+
+func (p *serviceMeshWrapper) Set(mesh autodetect.ServiceMesh) {
+	p.mu.Lock()
+	p.current = mesh
+	p.mu.Unlock()
+}
+
+func (p *serviceMeshWrapper) Get() autodetect.ServiceMesh {
+	p.mu.Lock()
+	mesh := p.current
+	p.mu.Unlock()
+	return mesh
+}
+
+type settingsStore interface {
+	Set(s settings)
+	Get() settings
+}
+
+func newSettingsWrapper(s settings) settingsStore {
+	return &settingsWrapper{current: s}
+}
+
+type settingsWrapper struct {
+	mu      sync.RWMutex
+	current settings
+}
+
+func (p *settingsWrapper) Set(s settings) {
+	p.mu.Lock()
+	p.current = s
+	p.mu.Unlock()
+}
+
+func (p *settingsWrapper) Get() settings {
+	p.mu.RLock()
+	s := p.current
+	p.mu.RUnlock()
+	return s
+}
@@ -68,6 +68,98 @@ func TestOnPlatformChangeCallback(t *testing.T) {
 	assert.True(t, calledBack)
 }
 
+func TestAutoDetectPlatform(t *testing.T) {
+	// prepare
+	mock := &mockAutoDetect{
+		PlatformFunc: func() (autodetect.Platform, error) {
+			return autodetect.PlatformOpenShift, nil
+		},
+		OpenShiftVersionFunc: func() (string, error) {
+			return "4.14.1", nil
+		},
+	}
+	cfg := config.New(config.WithAutoDetect(mock))
+
+	// sanity check
+	require.Equal(t, autodetect.PlatformKubernetes, cfg.Platform())
+
+	// test
+	err := cfg.AutoDetect()
+	require.NoError(t, err)
+
+	// verify
+	assert.Equal(t, autodetect.PlatformOpenShift, cfg.Platform())
+	assert.Equal(t, "4.14.1", cfg.OpenShiftVersion())
+}
+
+func TestAutoDetectRBACPermissions(t *testing.T) {
+	// prepare
+	mock := &mockAutoDetect{
+		RBACPermissionsFunc: func() (autodetect.RBACPermissions, error) {
+			return autodetect.RBACPermissionsAvailable, nil
+		},
+	}
+	cfg := config.New(config.WithAutoDetect(mock))
+
+	// sanity check
+	require.Equal(t, autodetect.RBACPermissionsNotAvailable, cfg.CreateRBACPermissions())
+
+	// test
+	err := cfg.AutoDetect()
+	require.NoError(t, err)
+
+	// verify
+	assert.Equal(t, autodetect.RBACPermissionsAvailable, cfg.CreateRBACPermissions())
+}
+
+func TestAutoDetectNamespaceScoped(t *testing.T) {
+	// prepare
+	mock := &mockAutoDetect{
+		PlatformFunc: func() (autodetect.Platform, error) {
+			return autodetect.PlatformOpenShift, nil
+		},
+		OpenShiftVersionFunc: func() (string, error) {
+			t.Error("the namespace-scoped operator mustn't read the cluster version")
+			return "", nil
+		},
+		RBACPermissionsFunc: func() (autodetect.RBACPermissions, error) {
+			t.Error("the namespace-scoped operator mustn't check its RBAC permissions")
+			return autodetect.RBACPermissionsAvailable, nil
+		},
+	}
+	cfg := config.New(config.WithAutoDetect(mock), config.WithNamespaceScoped(true))
+
+	// test
+	err := cfg.AutoDetect()
+	require.NoError(t, err)
+
+	// verify
+	assert.True(t, cfg.NamespaceScoped())
+	assert.Equal(t, autodetect.PlatformOpenShift, cfg.Platform())
+	assert.Empty(t, cfg.OpenShiftVersion())
+	assert.Equal(t, autodetect.RBACPermissionsNotAvailable, cfg.CreateRBACPermissions())
+}
+
+func TestAutoDetectServiceMesh(t *testing.T) {
+	// prepare
+	mock := &mockAutoDetect{
+		ServiceMeshFunc: func() (autodetect.ServiceMesh, error) {
+			return autodetect.ServiceMeshIstio, nil
+		},
+	}
+	cfg := config.New(config.WithAutoDetect(mock))
+
+	// sanity check
+	require.Equal(t, autodetect.ServiceMeshNone, cfg.ServiceMesh())
+
+	// test
+	err := cfg.AutoDetect()
+	require.NoError(t, err)
+
+	// verify
+	assert.Equal(t, autodetect.ServiceMeshIstio, cfg.ServiceMesh())
+}
+
 func TestAutoDetectInBackground(t *testing.T) {
 	// prepare
 	wg := &sync.WaitGroup{}
@@ -98,6 +190,10 @@ var _ autodetect.AutoDetect = (*mockAutoDetect)(nil)
 
 type mockAutoDetect struct {
 	OpenShiftRoutesAvailabilityFunc func() (autodetect.OpenShiftRoutesAvailability, error)
+	PlatformFunc                    func() (autodetect.Platform, error)
+	OpenShiftVersionFunc            func() (string, error)
+	RBACPermissionsFunc             func() (autodetect.RBACPermissions, error)
+	ServiceMeshFunc                 func() (autodetect.ServiceMesh, error)
 }
 
 func (m *mockAutoDetect) OpenShiftRoutesAvailability() (autodetect.OpenShiftRoutesAvailability, error) {
@@ -106,3 +202,60 @@ func (m *mockAutoDetect) OpenShiftRoutesAvailability() (autodetect.OpenShiftRout
 	}
 	return autodetect.OpenShiftRoutesNotAvailable, nil
 }
+
+func (m *mockAutoDetect) Platform() (autodetect.Platform, error) {
+	if m.PlatformFunc != nil {
+		return m.PlatformFunc()
+	}
+	return autodetect.PlatformKubernetes, nil
+}
+
+func (m *mockAutoDetect) OpenShiftVersion() (string, error) {
+	if m.OpenShiftVersionFunc != nil {
+		return m.OpenShiftVersionFunc()
+	}
+	return "", nil
+}
+
+func (m *mockAutoDetect) RBACPermissions() (autodetect.RBACPermissions, error) {
+	if m.RBACPermissionsFunc != nil {
+		return m.RBACPermissionsFunc()
+	}
+	return autodetect.RBACPermissionsNotAvailable, nil
+}
+
+func (m *mockAutoDetect) ServiceMesh() (autodetect.ServiceMesh, error) {
+	if m.ServiceMeshFunc != nil {
+		return m.ServiceMeshFunc()
+	}
+	return autodetect.ServiceMeshNone, nil
+}
+
+func TestReload(t *testing.T) {
+	// prepare
+	cfg := config.New(
+		config.WithCollectorImage("some-image"),
+		config.WithLabelFilters([]string{"team"}),
+		config.WithCollectorConfigMapEntry("some-config.yaml"),
+	)
+
+	// test
+	cfg.Reload(config.WithCollectorImage("other-image"))
+
+	// verify
+	assert.Equal(t, "other-image", cfg.CollectorImage())
+	assert.Empty(t, cfg.LabelsFilter())
+	assert.Equal(t, "some-config.yaml", cfg.CollectorConfigMapEntry())
+}
+
+func TestAllowedImageRegistries(t *testing.T) {
+	// prepare
+	cfg := config.New(config.WithAllowedImageRegistries([]string{" registry.example.com/ ", "", "mirror.example.com/otel"}))
+	assert.Equal(t, []string{"registry.example.com", "mirror.example.com/otel"}, cfg.AllowedImageRegistries())
+
+	// test
+	cfg.Reload(config.WithAllowedImageRegistries([]string{"registry.example.com"}))
+
+	// verify
+	assert.Equal(t, []string{"registry.example.com"}, cfg.AllowedImageRegistries())
+}
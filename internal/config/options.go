@@ -20,7 +20,9 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 
+	"github.com/open-telemetry/opentelemetry-operator/internal/imagedigest"
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect"
 )
@@ -45,10 +47,48 @@ type options struct {
 	operatorOpAMPBridgeConfigMapEntry   string
 	targetAllocatorImage                string
 	operatorOpAMPBridgeImage            string
+	spiffeHelperImage                   string
+	drainImage                          string
 	onOpenShiftRoutesChange             changeHandler
 	labelsFilter                        []string
+	annotationsFilter                   []string
+	allowedImageRegistries              []string
 	openshiftRoutes                     openshiftRoutesStore
+	platform                            platformStore
+	rbacPermissions                     rbacPermissionsStore
+	serviceMesh                         serviceMeshStore
 	autoDetectFrequency                 time.Duration
+	autopilot                           bool
+	defaultSecurityContext              *corev1.SecurityContext
+	sidecarMaxResources                 corev1.ResourceList
+	sidecarAllowPrivileged              bool
+	namespaceScoped                     bool
+	watchNamespaces                     []string
+	resourceQuotaValidation             bool
+	imageDigestResolver                 *imagedigest.Resolver
+	defaultTolerations                  []corev1.Toleration
+	defaultNodeSelector                 map[string]string
+	defaultResources                    corev1.ResourceRequirements
+}
+
+func (o options) settings() settings {
+	return settings{
+		collectorImage:                      o.collectorImage,
+		targetAllocatorImage:                o.targetAllocatorImage,
+		operatorOpAMPBridgeImage:            o.operatorOpAMPBridgeImage,
+		autoInstrumentationJavaImage:        o.autoInstrumentationJavaImage,
+		autoInstrumentationNodeJSImage:      o.autoInstrumentationNodeJSImage,
+		autoInstrumentationPythonImage:      o.autoInstrumentationPythonImage,
+		autoInstrumentationDotNetImage:      o.autoInstrumentationDotNetImage,
+		autoInstrumentationGoImage:          o.autoInstrumentationGoImage,
+		autoInstrumentationApacheHttpdImage: o.autoInstrumentationApacheHttpdImage,
+		autoInstrumentationNginxImage:       o.autoInstrumentationNginxImage,
+		spiffeHelperImage:                   o.spiffeHelperImage,
+		drainImage:                          o.drainImage,
+		labelsFilter:                        o.labelsFilter,
+		annotationsFilter:                   o.annotationsFilter,
+		allowedImageRegistries:              o.allowedImageRegistries,
+	}
 }
 
 func WithAutoDetect(a autodetect.AutoDetect) Option {
@@ -71,6 +111,16 @@ func WithOperatorOpAMPBridgeImage(s string) Option {
 		o.operatorOpAMPBridgeImage = s
 	}
 }
+func WithSpiffeHelperImage(s string) Option {
+	return func(o *options) {
+		o.spiffeHelperImage = s
+	}
+}
+func WithDrainImage(s string) Option {
+	return func(o *options) {
+		o.drainImage = s
+	}
+}
 func WithCollectorImage(s string) Option {
 	return func(o *options) {
 		o.collectorImage = s
@@ -110,6 +160,113 @@ func WithPlatform(ora autodetect.OpenShiftRoutesAvailability) Option {
 		o.openshiftRoutes.Set(ora)
 	}
 }
+
+// WithOpenShiftPlatform marks the cluster as an OpenShift cluster of the given version, instead of detecting it.
+func WithOpenShiftPlatform(version string) Option {
+	return func(o *options) {
+		o.platform.Set(autodetect.PlatformOpenShift, version)
+	}
+}
+
+// WithRBACPermissions sets whether the operator may create ClusterRoles and ClusterRoleBindings, instead of detecting it.
+func WithRBACPermissions(rbac autodetect.RBACPermissions) Option {
+	return func(o *options) {
+		o.rbacPermissions.Set(rbac)
+	}
+}
+
+// WithServiceMesh sets the service mesh installed in the cluster, instead of detecting it.
+func WithServiceMesh(mesh autodetect.ServiceMesh) Option {
+	return func(o *options) {
+		o.serviceMesh.Set(mesh)
+	}
+}
+
+// WithAutopilot generates the manifests for the constraints of GKE Autopilot clusters.
+func WithAutopilot(autopilot bool) Option {
+	return func(o *options) {
+		o.autopilot = autopilot
+	}
+}
+
+// WithDefaultSecurityContext sets the security context the fields unset in the security contexts of the generated
+// containers are defaulted from.
+func WithDefaultSecurityContext(securityContext *corev1.SecurityContext) Option {
+	return func(o *options) {
+		o.defaultSecurityContext = securityContext
+	}
+}
+
+// WithSidecarMaxResources sets the upper bounds of the requests and limits the pods may set on their sidecar with
+// annotations.
+func WithSidecarMaxResources(resources corev1.ResourceList) Option {
+	return func(o *options) {
+		o.sidecarMaxResources = resources
+	}
+}
+
+// WithSidecarAllowPrivileged lets the pods make their sidecar privileged, or run it as root, with annotations.
+func WithSidecarAllowPrivileged(allow bool) Option {
+	return func(o *options) {
+		o.sidecarAllowPrivileged = allow
+	}
+}
+
+// WithNamespaceScoped runs the operator with namespace-scoped permissions only, without the features needing
+// cluster-scoped ones.
+func WithNamespaceScoped(namespaceScoped bool) Option {
+	return func(o *options) {
+		o.namespaceScoped = namespaceScoped
+	}
+}
+
+// WithWatchNamespaces sets the namespaces the operator watches, all of them when empty.
+func WithWatchNamespaces(namespaces []string) Option {
+	return func(o *options) {
+		o.watchNamespaces = namespaces
+	}
+}
+
+// WithResourceQuotaValidation checks the resources of the custom resources against the LimitRanges and the
+// ResourceQuotas of their namespace in the webhooks.
+func WithResourceQuotaValidation(validate bool) Option {
+	return func(o *options) {
+		o.resourceQuotaValidation = validate
+	}
+}
+
+// WithDefaultTolerations sets the tolerations of the pods generated by the operator whose custom resource sets none,
+// e.g. to schedule them on a dedicated node pool.
+func WithDefaultTolerations(tolerations []corev1.Toleration) Option {
+	return func(o *options) {
+		o.defaultTolerations = tolerations
+	}
+}
+
+// WithDefaultNodeSelector sets the node selector of the pods generated by the operator whose custom resource sets
+// none.
+func WithDefaultNodeSelector(nodeSelector map[string]string) Option {
+	return func(o *options) {
+		o.defaultNodeSelector = nodeSelector
+	}
+}
+
+// WithDefaultResources sets the resource requirements of the containers generated by the operator whose custom
+// resource sets neither requests nor limits.
+func WithDefaultResources(resources corev1.ResourceRequirements) Option {
+	return func(o *options) {
+		o.defaultResources = resources
+	}
+}
+
+// WithImageDigestResolver pins the images of the custom resources to the digests resolved by the given resolver in
+// the defaulting webhooks.
+func WithImageDigestResolver(resolver *imagedigest.Resolver) Option {
+	return func(o *options) {
+		o.imageDigestResolver = resolver
+	}
+}
+
 func WithVersion(v version.Version) Option {
 	return func(o *options) {
 		o.version = v
@@ -160,25 +317,49 @@ func WithAutoInstrumentationNginxImage(s string) Option {
 
 func WithLabelFilters(labelFilters []string) Option {
 	return func(o *options) {
+		o.labelsFilter = filterPatterns(labelFilters)
+	}
+}
 
-		filters := []string{}
-		for _, pattern := range labelFilters {
-			var result strings.Builder
+// WithAnnotationFilters sets the annotations of the custom resources which aren't propagated onto the objects
+// generated by the operator. The filters are wildcard patterns, as for the labels.
+func WithAnnotationFilters(annotationFilters []string) Option {
+	return func(o *options) {
+		o.annotationsFilter = filterPatterns(annotationFilters)
+	}
+}
 
-			for i, literal := range strings.Split(pattern, "*") {
+// WithAllowedImageRegistries sets the registries the images of the custom resources must be pulled from, e.g.
+// "registry.example.com" or "registry.example.com/mirror". The trailing slashes are ignored.
+func WithAllowedImageRegistries(registries []string) Option {
+	return func(o *options) {
+		o.allowedImageRegistries = nil
+		for _, registry := range registries {
+			if registry = strings.TrimSuffix(strings.TrimSpace(registry), "/"); registry != "" {
+				o.allowedImageRegistries = append(o.allowedImageRegistries, registry)
+			}
+		}
+	}
+}
+
+// filterPatterns converts the given wildcard patterns to regular expressions.
+func filterPatterns(patterns []string) []string {
+	filters := []string{}
+	for _, pattern := range patterns {
+		var result strings.Builder
 
-				// Replace * with .*
-				if i > 0 {
-					result.WriteString(".*")
-				}
+		for i, literal := range strings.Split(pattern, "*") {
 
-				// Quote any regular expression meta characters in the
-				// literal text.
-				result.WriteString(regexp.QuoteMeta(literal))
+			// Replace * with .*
+			if i > 0 {
+				result.WriteString(".*")
 			}
-			filters = append(filters, result.String())
-		}
 
-		o.labelsFilter = filters
+			// Quote any regular expression meta characters in the
+			// literal text.
+			result.WriteString(regexp.QuoteMeta(literal))
+		}
+		filters = append(filters, result.String())
 	}
+	return filters
 }
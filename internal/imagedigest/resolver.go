@@ -0,0 +1,399 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package imagedigest resolves the tags of container images to the digests of their manifests, so that the pods of a
+// custom resource run the same image everywhere, even when the tag is moved to another image.
+package imagedigest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	// requestTimeout bounds the requests to a registry, which are made while the webhooks handle a request.
+	requestTimeout = 5 * time.Second
+	// cacheTTL is how long a resolved digest is reused, so that the dry-run and the actual request of an apply, or the
+	// requests for the custom resources using the same image, only ask the registry once.
+	cacheTTL = time.Minute
+)
+
+// manifestMediaTypes are the manifests accepted from the registries, preferring the indexes of the multi-platform
+// images, whose digest is the one the nodes of every platform can pull.
+var manifestMediaTypes = strings.Join([]string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}, ", ")
+
+var digestPattern = regexp.MustCompile(`^[a-z0-9]+(?:[.+_-][a-z0-9]+)*:[a-zA-Z0-9=_-]{32,}$`)
+
+// ErrNotResolved indicates that the digest of an image is neither in the mapping nor resolved from its registry.
+var ErrNotResolved = errors.New("the digest of the image isn't known")
+
+// Resolver resolves the tags of the images to digests, from a static mapping first, then from the registries when
+// enabled. The registries are queried anonymously, so only the public images can be resolved from them. As the images
+// are set by the authors of the custom resources, only the allowed registries are queried, see SetRegistries.
+type Resolver struct {
+	mapping  map[string]string
+	registry bool
+	client   *http.Client
+	logger   logr.Logger
+
+	mu                 sync.Mutex
+	registries         []string
+	cache              map[string]cachedDigest
+	architecturesCache map[string]cachedArchitectures
+	now                func() time.Time
+}
+
+type cachedDigest struct {
+	digest  string
+	expires time.Time
+}
+
+type cachedArchitectures struct {
+	architectures []string
+	expires       time.Time
+}
+
+// New returns a resolver pinning the images of the given mapping, keyed by image, e.g.
+// "ghcr.io/org/image:1.0" to "sha256:...". When registry is set, the other images are resolved from their registries.
+func New(logger logr.Logger, mapping map[string]string, registry bool) (*Resolver, error) {
+	m := make(map[string]string, len(mapping))
+	for image, digest := range mapping {
+		if !digestPattern.MatchString(digest) {
+			return nil, fmt.Errorf("invalid digest %q for the image %s", digest, image)
+		}
+		m[normalize(image)] = digest
+	}
+	return &Resolver{
+		mapping:            m,
+		registry:           registry,
+		client:             &http.Client{Timeout: requestTimeout},
+		logger:             logger,
+		cache:              map[string]cachedDigest{},
+		architecturesCache: map[string]cachedArchitectures{},
+		now:                time.Now,
+	}, nil
+}
+
+// SetRegistries sets the registries the digests are resolved from, which may include a path within the registry, e.g.
+// "registry.example.com/mirror". The digests of the images of the other registries aren't resolved.
+func (r *Resolver) SetRegistries(registries []string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	r.registries = registries
+	r.mu.Unlock()
+}
+
+// Pin returns the image along with the digest its tag points to, e.g. "ghcr.io/org/image:1.0@sha256:...". The images
+// which are empty or already pinned are returned as is, as are the ones missing from the mapping when the registries
+// aren't queried. The tag is kept for readability, the container runtimes pull the digest.
+func (r *Resolver) Pin(ctx context.Context, image string) (string, error) {
+	if r == nil || image == "" || strings.Contains(image, "@") {
+		return image, nil
+	}
+	digest, err := r.resolve(ctx, image)
+	if errors.Is(err, ErrNotResolved) && !r.registry {
+		return image, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve the digest of the image %s: %w", image, err)
+	}
+	return image + "@" + digest, nil
+}
+
+func (r *Resolver) resolve(ctx context.Context, image string) (string, error) {
+	normalized := normalize(image)
+	if digest, ok := r.mapping[normalized]; ok {
+		return digest, nil
+	}
+	if !r.registry {
+		return "", ErrNotResolved
+	}
+
+	r.mu.Lock()
+	cached, ok := r.cache[normalized]
+	r.mu.Unlock()
+	if ok && r.now().Before(cached.expires) {
+		return cached.digest, nil
+	}
+
+	digest, err := r.fromRegistry(ctx, normalized)
+	if err != nil {
+		return "", err
+	}
+	r.logger.V(1).Info("resolved the digest of the image", "image", image, "digest", digest)
+	r.mu.Lock()
+	r.cache[normalized] = cachedDigest{digest: digest, expires: r.now().Add(cacheTTL)}
+	r.mu.Unlock()
+	return digest, nil
+}
+
+// fromRegistry asks the registry for the digest of the manifest of the normalized image, with a HEAD request as
+// described by the OCI distribution specification.
+func (r *Resolver) fromRegistry(ctx context.Context, image string) (string, error) {
+	host, repository, tag := parse(image)
+	resp, err := r.manifest(ctx, http.MethodHead, host, repository, tag)
+	if err != nil {
+		return "", err
+	}
+	_ = resp.Body.Close()
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if !digestPattern.MatchString(digest) {
+		return "", fmt.Errorf("the registry %s didn't return a valid digest: %q", host, digest)
+	}
+	return digest, nil
+}
+
+// Architectures returns the sorted CPU architectures the image is built for, read from the index of the multi-platform
+// images. It returns nil when the registries aren't queried or when the image is built for a single platform, whose
+// architecture isn't known without fetching its configuration.
+func (r *Resolver) Architectures(ctx context.Context, image string) ([]string, error) {
+	if r == nil || !r.registry || image == "" {
+		return nil, nil
+	}
+	name, reference, pinned := strings.Cut(image, "@")
+	host, repository, tag := parse(name)
+	if pinned {
+		tag = reference
+	}
+	key := fmt.Sprintf("%s/%s@%s", host, repository, tag)
+
+	r.mu.Lock()
+	cached, ok := r.architecturesCache[key]
+	r.mu.Unlock()
+	if ok && r.now().Before(cached.expires) {
+		return cached.architectures, nil
+	}
+
+	resp, err := r.manifest(ctx, http.MethodGet, host, repository, tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the manifest of the image %s: %w", image, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	var index struct {
+		MediaType string `json:"mediaType"`
+		Manifests []struct {
+			Platform struct {
+				Architecture string `json:"architecture"`
+			} `json:"platform"`
+		} `json:"manifests"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&index); err != nil {
+		return nil, fmt.Errorf("failed to decode the manifest of the image %s: %w", image, err)
+	}
+	var architectures []string
+	if isIndex(index.MediaType) || isIndex(resp.Header.Get("Content-Type")) {
+		seen := map[string]bool{}
+		for _, m := range index.Manifests {
+			// the attestations of the images are listed with an unknown platform
+			if arch := m.Platform.Architecture; arch != "" && arch != "unknown" && !seen[arch] {
+				seen[arch] = true
+				architectures = append(architectures, arch)
+			}
+		}
+		sort.Strings(architectures)
+	}
+	r.mu.Lock()
+	r.architecturesCache[key] = cachedArchitectures{architectures: architectures, expires: r.now().Add(cacheTTL)}
+	r.mu.Unlock()
+	return architectures, nil
+}
+
+// isIndex returns whether the media type is the one of the index of a multi-platform image.
+func isIndex(mediaType string) bool {
+	return strings.HasPrefix(mediaType, "application/vnd.oci.image.index.v1+json") ||
+		strings.HasPrefix(mediaType, "application/vnd.docker.distribution.manifest.list.v2+json")
+}
+
+// allowed returns whether the repository belongs to one of the registries the digests are resolved from.
+func (r *Resolver) allowed(host, repository string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, registry := range r.registries {
+		if strings.HasPrefix(host+"/"+repository, registry+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// manifest requests the manifest of the repository for the tag or digest, with the given method. The registries
+// requiring a token, e.g. Docker Hub, are given an anonymous one. The body of the response is left to the caller to
+// close.
+func (r *Resolver) manifest(ctx context.Context, method, host, repository, reference string) (*http.Response, error) {
+	if !r.allowed(host, repository) {
+		return nil, fmt.Errorf("the registry %s isn't one of the allowed image registries, which the digests are only resolved from", host)
+	}
+	if host == "docker.io" {
+		host = "registry-1.docker.io"
+	}
+	manifestURL := fmt.Sprintf("https://%s/v2/%s/manifests/%s", host, repository, reference)
+
+	resp, err := r.do(ctx, method, manifestURL, "")
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		_ = resp.Body.Close()
+		token, err := r.token(ctx, resp.Header.Get("WWW-Authenticate"), host, repository)
+		if err != nil {
+			return nil, err
+		}
+		if resp, err = r.do(ctx, method, manifestURL, token); err != nil {
+			return nil, err
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("the registry %s answered %s", host, resp.Status)
+	}
+	return resp, nil
+}
+
+func (r *Resolver) do(ctx context.Context, method, manifestURL, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", manifestMediaTypes)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return r.client.Do(req)
+}
+
+// token requests an anonymous token for pulling the repository of the registry from the realm of the given challenge,
+// e.g. 'Bearer realm="https://auth.docker.io/token",service="registry.docker.io"'.
+func (r *Resolver) token(ctx context.Context, challenge, host, repository string) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return "", fmt.Errorf("unsupported authentication challenge %q, only the public images can be resolved", challenge)
+	}
+	values := parseChallenge(params)
+	if values["realm"] == "" {
+		return "", fmt.Errorf("the authentication challenge %q has no realm", challenge)
+	}
+	// the realm is chosen by the registry, it mustn't send the requests of the operator to other hosts
+	realm, err := url.Parse(values["realm"])
+	if err != nil || realm.Scheme != "https" || !sameSite(realm.Hostname(), hostname(host)) {
+		return "", fmt.Errorf("the realm %q of the registry %s isn't one of its HTTPS URLs", values["realm"], host)
+	}
+	query := url.Values{"scope": {fmt.Sprintf("repository:%s:pull", repository)}}
+	if service := values["service"]; service != "" {
+		query.Set("service", service)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, values["realm"]+"?"+query.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("the token service %s answered %s", values["realm"], resp.Status)
+	}
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to decode the token: %w", err)
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	return body.AccessToken, nil
+}
+
+// sameSite returns whether the host belongs to the registry: it's either the registry itself, or shares its parent
+// domain, e.g. auth.docker.io for registry-1.docker.io.
+func sameSite(host, registry string) bool {
+	if host == registry {
+		return true
+	}
+	_, parent, found := strings.Cut(registry, ".")
+	if !found || !strings.Contains(parent, ".") || net.ParseIP(registry) != nil {
+		return false
+	}
+	return host == parent || strings.HasSuffix(host, "."+parent)
+}
+
+// hostname returns the host of the registry without its port.
+func hostname(host string) string {
+	if name, _, err := net.SplitHostPort(host); err == nil {
+		return name
+	}
+	return host
+}
+
+// parseChallenge returns the parameters of an authentication challenge, e.g. 'realm="...",service="..."'.
+func parseChallenge(params string) map[string]string {
+	values := map[string]string{}
+	for _, param := range strings.Split(params, ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(param), "=")
+		if found {
+			values[strings.ToLower(key)] = strings.Trim(value, `"`)
+		}
+	}
+	return values
+}
+
+// normalize returns the image with its registry and tag, resolving the images without a registry to Docker Hub and
+// the ones without a tag to "latest", the way the container runtimes do, e.g. "busybox" to
+// "docker.io/library/busybox:latest".
+func normalize(image string) string {
+	host, repository, tag := parse(image)
+	return fmt.Sprintf("%s/%s:%s", host, repository, tag)
+}
+
+// parse splits the image into its registry, repository and tag.
+func parse(image string) (host, repository, tag string) {
+	name := image
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		name, tag = image[:i], image[i+1:]
+	}
+	if tag == "" {
+		tag = "latest"
+	}
+	first, rest, found := strings.Cut(name, "/")
+	switch {
+	case found && (strings.ContainsAny(first, ".:") || first == "localhost"):
+		host, repository = first, rest
+	case found:
+		host, repository = "docker.io", name
+	default:
+		host, repository = "docker.io", "library/"+name
+	}
+	return host, repository, tag
+}
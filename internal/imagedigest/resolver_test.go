@@ -0,0 +1,252 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imagedigest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	digest      = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	otherDigest = "sha256:fedcba9876543210fedcba9876543210fedcba9876543210fedcba9876543210"
+)
+
+// registry serves the manifest of org/collector:1.0, along with the index of its digest and a single-platform
+// manifest, to the clients holding the token, counting the HEAD requests.
+func registry(t *testing.T) (*httptest.Server, *int) {
+	heads := 0
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token":
+			assert.Equal(t, "repository:org/collector:pull", r.URL.Query().Get("scope"))
+			assert.Equal(t, "test-registry", r.URL.Query().Get("service"))
+			_, _ = w.Write([]byte(`{"token": "anonymous"}`))
+		case r.Header.Get("Authorization") != "Bearer anonymous":
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="test-registry"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.Method == http.MethodGet && r.URL.Path == "/v2/org/collector/manifests/"+digest:
+			w.Header().Set("Content-Type", "application/vnd.oci.image.index.v1+json")
+			_, _ = w.Write([]byte(`{"manifests": [{"platform": {"architecture": "arm64", "os": "linux"}}, {"platform": {"architecture": "amd64", "os": "linux"}}, {"platform": {"architecture": "unknown", "os": "unknown"}}]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v2/org/collector/manifests/single":
+			w.Header().Set("Content-Type", "application/vnd.oci.image.manifest.v1+json")
+			_, _ = w.Write([]byte(`{"config": {"mediaType": "application/vnd.oci.image.config.v1+json"}}`))
+		case r.Method == http.MethodHead && r.URL.Path == "/v2/org/collector/manifests/1.0":
+			heads++
+			assert.Contains(t, r.Header.Get("Accept"), "application/vnd.oci.image.index.v1+json")
+			w.Header().Set("Docker-Content-Digest", digest)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &heads
+}
+
+func TestPinFromMapping(t *testing.T) {
+	// prepare
+	r, err := New(logr.Discard(), map[string]string{
+		"ghcr.io/org/collector:1.0": digest,
+		"busybox":                   otherDigest,
+	}, false)
+	require.NoError(t, err)
+
+	for image, expected := range map[string]string{
+		"ghcr.io/org/collector:1.0":       "ghcr.io/org/collector:1.0@" + digest,
+		"busybox:latest":                  "busybox:latest@" + otherDigest,
+		"docker.io/library/busybox":       "docker.io/library/busybox@" + otherDigest,
+		"ghcr.io/org/collector:2.0":       "ghcr.io/org/collector:2.0",
+		"ghcr.io/org/collector@" + digest: "ghcr.io/org/collector@" + digest,
+		"":                                "",
+	} {
+		// test
+		pinned, err := r.Pin(context.Background(), image)
+
+		// verify
+		assert.NoError(t, err)
+		assert.Equal(t, expected, pinned, image)
+	}
+}
+
+func TestInvalidMapping(t *testing.T) {
+	_, err := New(logr.Discard(), map[string]string{"ghcr.io/org/collector:1.0": "latest"}, false)
+	assert.ErrorContains(t, err, `invalid digest "latest" for the image ghcr.io/org/collector:1.0`)
+}
+
+func TestPinFromRegistry(t *testing.T) {
+	// prepare
+	server, heads := registry(t)
+	host := strings.TrimPrefix(server.URL, "https://")
+	r, err := New(logr.Discard(), nil, true)
+	require.NoError(t, err)
+	r.client = server.Client()
+	r.SetRegistries([]string{host})
+	now := time.Now()
+	r.now = func() time.Time { return now }
+
+	// test
+	pinned, err := r.Pin(context.Background(), host+"/org/collector:1.0")
+	require.NoError(t, err)
+	again, err := r.Pin(context.Background(), host+"/org/collector:1.0")
+	require.NoError(t, err)
+	now = now.Add(2 * cacheTTL)
+	_, err = r.Pin(context.Background(), host+"/org/collector:1.0")
+	require.NoError(t, err)
+
+	// verify
+	assert.Equal(t, host+"/org/collector:1.0@"+digest, pinned)
+	assert.Equal(t, pinned, again)
+	assert.Equal(t, 2, *heads, "the digest should be cached until it expires")
+}
+
+func TestPinUnknownTagFromRegistry(t *testing.T) {
+	// prepare
+	server, _ := registry(t)
+	host := strings.TrimPrefix(server.URL, "https://")
+	r, err := New(logr.Discard(), nil, true)
+	require.NoError(t, err)
+	r.client = server.Client()
+	r.SetRegistries([]string{host})
+
+	// test
+	_, err = r.Pin(context.Background(), host+"/org/collector:2.0")
+
+	// verify
+	assert.ErrorContains(t, err, "failed to resolve the digest of the image "+host+"/org/collector:2.0")
+	assert.ErrorContains(t, err, "404 Not Found")
+}
+
+func TestArchitectures(t *testing.T) {
+	// prepare
+	server, _ := registry(t)
+	host := strings.TrimPrefix(server.URL, "https://")
+	r, err := New(logr.Discard(), nil, true)
+	require.NoError(t, err)
+	r.client = server.Client()
+	r.SetRegistries([]string{host})
+
+	// test
+	multi, err := r.Architectures(context.Background(), host+"/org/collector:1.0@"+digest)
+	require.NoError(t, err)
+	single, err := r.Architectures(context.Background(), host+"/org/collector:single")
+	require.NoError(t, err)
+	_, err = r.Architectures(context.Background(), host+"/org/collector:2.0")
+
+	// verify
+	assert.Equal(t, []string{"amd64", "arm64"}, multi)
+	assert.Nil(t, single, "the architecture of a single-platform image isn't known")
+	assert.ErrorContains(t, err, "404 Not Found")
+}
+
+func TestPinFromRegistryNotAllowed(t *testing.T) {
+	// prepare
+	server, heads := registry(t)
+	host := strings.TrimPrefix(server.URL, "https://")
+	r, err := New(logr.Discard(), nil, true)
+	require.NoError(t, err)
+	r.client = server.Client()
+	r.SetRegistries([]string{"registry.example.com", host + "/mirror"})
+
+	// test
+	_, err = r.Pin(context.Background(), host+"/org/collector:1.0")
+
+	// verify
+	assert.ErrorContains(t, err, "the registry "+host+" isn't one of the allowed image registries")
+	assert.Zero(t, *heads, "the registry shouldn't be queried")
+}
+
+func TestPinFromRegistryWithForeignRealm(t *testing.T) {
+	// prepare
+	tokens := 0
+	realm := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokens++
+	}))
+	t.Cleanup(realm.Close)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the realm is another host, reached through another name than the registry
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token"`, strings.Replace(realm.URL, "127.0.0.1", "localhost", 1)))
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	t.Cleanup(server.Close)
+	host := strings.TrimPrefix(server.URL, "https://")
+	r, err := New(logr.Discard(), nil, true)
+	require.NoError(t, err)
+	r.client = server.Client()
+	r.SetRegistries([]string{host})
+
+	// test
+	_, err = r.Pin(context.Background(), host+"/org/collector:1.0")
+
+	// verify
+	assert.ErrorContains(t, err, "of the registry "+host+" isn't one of its HTTPS URLs")
+	assert.Zero(t, tokens, "the realm shouldn't be queried")
+}
+
+func TestSameSite(t *testing.T) {
+	for _, tt := range []struct {
+		host, registry string
+		expected       bool
+	}{
+		{host: "ghcr.io", registry: "ghcr.io", expected: true},
+		{host: "auth.docker.io", registry: "registry-1.docker.io", expected: true},
+		{host: "example.com", registry: "registry.example.com", expected: true},
+		{host: "metadata.google.internal", registry: "ghcr.io"},
+		{host: "evil.io", registry: "ghcr.io"},
+		{host: "10.0.0.1", registry: "127.0.0.1"},
+		{host: "example.com.evil.io", registry: "registry.example.com"},
+	} {
+		t.Run(tt.host+" "+tt.registry, func(t *testing.T) {
+			assert.Equal(t, tt.expected, sameSite(tt.host, tt.registry))
+		})
+	}
+}
+
+func TestArchitecturesWithoutRegistry(t *testing.T) {
+	r, err := New(logr.Discard(), nil, false)
+	require.NoError(t, err)
+	architectures, err := r.Architectures(context.Background(), "ghcr.io/org/collector:1.0")
+	assert.NoError(t, err)
+	assert.Nil(t, architectures)
+}
+
+func TestNilResolver(t *testing.T) {
+	var r *Resolver
+	pinned, err := r.Pin(context.Background(), "ghcr.io/org/collector:1.0")
+	assert.NoError(t, err)
+	assert.Equal(t, "ghcr.io/org/collector:1.0", pinned)
+}
+
+func TestParse(t *testing.T) {
+	for image, expected := range map[string][3]string{
+		"busybox":                             {"docker.io", "library/busybox", "latest"},
+		"grafana/agent:v0.38":                 {"docker.io", "grafana/agent", "v0.38"},
+		"ghcr.io/org/collector:1.0":           {"ghcr.io", "org/collector", "1.0"},
+		"localhost/collector":                 {"localhost", "collector", "latest"},
+		"registry.example.com:5000/collector": {"registry.example.com:5000", "collector", "latest"},
+	} {
+		host, repository, tag := parse(image)
+		assert.Equal(t, expected, [3]string{host, repository, tag}, image)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// configCacheSize bounds the number of parsed configurations kept in memory. The operator parses the configuration of
+// each collector several times per reconcile, so the steady state needs one entry per distinct configuration.
+const configCacheSize = 1024
+
+// parsedConfigs caches the configurations parsed by ConfigFromString, keyed by the hash of their string.
+var parsedConfigs = newConfigCache(configCacheSize)
+
+// lruCache is a least recently used cache of the values computed from configuration strings, keyed by the hash of the
+// strings. The cached values are never handed out, callers get a copy they are free to mutate.
+type lruCache[V any] struct {
+	mu      sync.Mutex
+	size    int
+	copy    func(V) V
+	order   *list.List
+	entries map[[sha256.Size]byte]*list.Element
+}
+
+type lruCacheEntry[V any] struct {
+	key   [sha256.Size]byte
+	value V
+}
+
+func newLRUCache[V any](size int, copyFn func(V) V) *lruCache[V] {
+	return &lruCache[V]{
+		size:    size,
+		copy:    copyFn,
+		order:   list.New(),
+		entries: map[[sha256.Size]byte]*list.Element{},
+	}
+}
+
+func newConfigCache(size int) *lruCache[map[interface{}]interface{}] {
+	return newLRUCache(size, copyConfigMap)
+}
+
+// get returns a copy of the value cached for the given configuration string, if any.
+func (c *lruCache[V]) get(configStr string) (V, bool) {
+	key := sha256.Sum256([]byte(configStr))
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(element)
+	return c.copy(element.Value.(*lruCacheEntry[V]).value), true
+}
+
+// add caches a copy of the value computed from the given configuration string, evicting the least recently used one if
+// full.
+func (c *lruCache[V]) add(configStr string, value V) {
+	key := sha256.Sum256([]byte(configStr))
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[key]; ok {
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&lruCacheEntry[V]{key: key, value: c.copy(value)})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruCacheEntry[V]).key)
+	}
+}
+
+func copyConfigMap(config map[interface{}]interface{}) map[interface{}]interface{} {
+	copied := make(map[interface{}]interface{}, len(config))
+	for k, v := range config {
+		copied[k] = copyConfigValue(v)
+	}
+	return copied
+}
+
+// copyConfigValue deep copies the maps and slices decoded from YAML, the other values are immutable.
+func copyConfigValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		return copyConfigMap(v)
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i := range v {
+			copied[i] = copyConfigValue(v[i])
+		}
+		return copied
+	default:
+		return v
+	}
+}
+
+func copyPorts(ports []corev1.ServicePort) []corev1.ServicePort {
+	if ports == nil {
+		return nil
+	}
+	copied := make([]corev1.ServicePort, len(ports))
+	for i := range ports {
+		ports[i].DeepCopyInto(&copied[i])
+	}
+	return copied
+}
@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigCacheEviction(t *testing.T) {
+	// prepare
+	cache := newConfigCache(2)
+	cache.add("a", map[interface{}]interface{}{"a": []interface{}{1}})
+	cache.add("b", map[interface{}]interface{}{"b": 2})
+
+	// test
+	_, ok := cache.get("a") // a is now the most recently used
+	assert.True(t, ok)
+	cache.add("c", map[interface{}]interface{}{"c": 3})
+
+	// verify
+	_, ok = cache.get("b")
+	assert.False(t, ok)
+	a, ok := cache.get("a")
+	assert.True(t, ok)
+	assert.Equal(t, map[interface{}]interface{}{"a": []interface{}{1}}, a)
+	_, ok = cache.get("c")
+	assert.True(t, ok)
+	assert.Len(t, cache.entries, 2)
+}
+
+func TestConfigCacheCopies(t *testing.T) {
+	// prepare
+	cache := newConfigCache(1)
+	config := map[interface{}]interface{}{"a": []interface{}{map[interface{}]interface{}{"b": 1}}}
+	cache.add("a", config)
+
+	// test
+	config["a"].([]interface{})[0].(map[interface{}]interface{})["b"] = 2
+	cached, _ := cache.get("a")
+	cached["a"] = nil
+
+	// verify
+	again, _ := cache.get("a")
+	assert.Equal(t, map[interface{}]interface{}{"a": []interface{}{map[interface{}]interface{}{"b": 1}}}, again)
+}
@@ -28,11 +28,17 @@ var (
 
 // ConfigFromString extracts a configuration map from the given string.
 // If the given string isn't a valid YAML, ErrInvalidYAML is returned.
+// The parsed configurations are cached, the returned map is a copy the caller may modify.
 func ConfigFromString(configStr string) (map[interface{}]interface{}, error) {
+	if config, ok := parsedConfigs.get(configStr); ok {
+		return config, nil
+	}
+
 	config := make(map[interface{}]interface{})
 	if err := yaml.Unmarshal([]byte(configStr), &config); err != nil {
 		return nil, ErrInvalidYAML
 	}
+	parsedConfigs.add(configStr, config)
 
 	return config, nil
 }
@@ -37,3 +37,30 @@ func TestEmptyString(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Empty(t, res, 0)
 }
+
+func TestConfigFromStringReturnsCopies(t *testing.T) {
+	// prepare
+	configStr := `receivers:
+  otlp:
+    protocols:
+      grpc:
+`
+	first, err := adapters.ConfigFromString(configStr)
+	assert.NoError(t, err)
+
+	// test
+	first["receivers"].(map[interface{}]interface{})["otlp"] = "changed"
+	second, err := adapters.ConfigFromString(configStr)
+
+	// verify
+	assert.NoError(t, err)
+	assert.Equal(t, map[interface{}]interface{}{
+		"receivers": map[interface{}]interface{}{
+			"otlp": map[interface{}]interface{}{
+				"protocols": map[interface{}]interface{}{
+					"grpc": nil,
+				},
+			},
+		},
+	}, second)
+}
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+import (
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+// defaultDebugEndpoints holds the endpoints the debugging extensions listen on when they don't configure one.
+var defaultDebugEndpoints = map[string]string{
+	"pprof":      "localhost:1777",
+	"remote_tap": "localhost:11000",
+}
+
+// DebugEndpoint is the endpoint of a debugging extension enabled in the configuration.
+type DebugEndpoint struct {
+	// Extension is the name of the extension, including its qualifier.
+	Extension string
+	// Host is the host the extension listens on, empty when it listens on all the interfaces.
+	Host string
+	// Port is the port the extension listens on.
+	Port int32
+}
+
+// Loopback returns whether the extension only listens on the loopback interface, which makes it unreachable from
+// outside the pod.
+func (e DebugEndpoint) Loopback() bool {
+	if e.Host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(e.Host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// ServicePort returns the port of the service exposing the endpoint.
+func (e DebugEndpoint) ServicePort() corev1.ServicePort {
+	return corev1.ServicePort{
+		Name: naming.PortName(e.Extension, e.Port),
+		Port: e.Port,
+	}
+}
+
+// ConfigToDebugEndpoints returns the endpoints of the pprof and remote_tap extensions enabled in the service of the
+// configuration, sorted by extension name. The extensions whose endpoint can't be parsed are skipped.
+func ConfigToDebugEndpoints(logger logr.Logger, config map[interface{}]interface{}) []DebugEndpoint {
+	service, ok := config["service"].(map[interface{}]interface{})
+	if !ok {
+		return nil
+	}
+	enabled, ok := service["extensions"].([]interface{})
+	if !ok {
+		return nil
+	}
+	extensions, _ := config["extensions"].(map[interface{}]interface{})
+
+	var endpoints []DebugEndpoint
+	for _, ext := range enabled {
+		name, ok := ext.(string)
+		if !ok {
+			continue
+		}
+		endpoint, ok := defaultDebugEndpoints[extensionType(name)]
+		if !ok {
+			continue
+		}
+		if extension, ok := extensions[name].(map[interface{}]interface{}); ok {
+			if e, ok := extension["endpoint"].(string); ok && e != "" {
+				endpoint = e
+			}
+		}
+
+		host, port, err := net.SplitHostPort(endpoint)
+		if err != nil {
+			logger.V(2).Info("couldn't parse the endpoint of the debugging extension", "extension", name, "endpoint", endpoint)
+			continue
+		}
+		portNumber, err := strconv.ParseInt(port, 10, 32)
+		if err != nil || portNumber <= 0 {
+			logger.V(2).Info("couldn't parse the port of the debugging extension", "extension", name, "endpoint", endpoint)
+			continue
+		}
+		endpoints = append(endpoints, DebugEndpoint{Extension: name, Host: host, Port: int32(portNumber)})
+	}
+
+	sort.Slice(endpoints, func(i, j int) bool {
+		return endpoints[i].Extension < endpoints[j].Extension
+	})
+	return endpoints
+}
+
+// extensionType returns the type of the extension, without the qualifier following the slash of its name.
+func extensionType(name string) string {
+	if i := strings.Index(name, "/"); i >= 0 {
+		return name[:i]
+	}
+	return name
+}
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigToDebugEndpoints(t *testing.T) {
+	tests := []struct {
+		desc     string
+		config   string
+		expected []DebugEndpoint
+	}{
+		{
+			desc: "default endpoints",
+			config: `extensions:
+  pprof:
+  remote_tap:
+service:
+  extensions: [remote_tap, pprof]`,
+			expected: []DebugEndpoint{
+				{Extension: "pprof", Host: "localhost", Port: 1777},
+				{Extension: "remote_tap", Host: "localhost", Port: 11000},
+			},
+		},
+		{
+			desc: "custom endpoints and qualified names",
+			config: `extensions:
+  pprof/cpu:
+    endpoint: 0.0.0.0:1888
+  remote_tap:
+    endpoint: :12000
+service:
+  extensions: [pprof/cpu, remote_tap]`,
+			expected: []DebugEndpoint{
+				{Extension: "pprof/cpu", Host: "0.0.0.0", Port: 1888},
+				{Extension: "remote_tap", Host: "", Port: 12000},
+			},
+		},
+		{
+			desc: "extensions not enabled in the service",
+			config: `extensions:
+  pprof:
+  health_check:
+service:
+  extensions: [health_check]`,
+		},
+		{
+			desc: "unparsable endpoint",
+			config: `extensions:
+  pprof:
+    endpoint: not-an-endpoint
+service:
+  extensions: [pprof]`,
+		},
+		{
+			desc:   "no service extensions",
+			config: `receivers: {}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			// prepare
+			config, err := ConfigFromString(test.config)
+			require.NoError(t, err)
+
+			// test
+			endpoints := ConfigToDebugEndpoints(logr.Discard(), config)
+
+			// verify
+			assert.Equal(t, test.expected, endpoints)
+		})
+	}
+}
+
+func TestDebugEndpointLoopback(t *testing.T) {
+	for host, loopback := range map[string]bool{
+		"localhost": true,
+		"127.0.0.1": true,
+		"::1":       true,
+		"0.0.0.0":   false,
+		"":          false,
+		"10.0.0.1":  false,
+	} {
+		assert.Equal(t, loopback, DebugEndpoint{Host: host}.Loopback(), host)
+	}
+}
@@ -102,9 +102,7 @@ func ConfigToExporterPorts(logger logr.Logger, config map[interface{}]interface{
 		}
 	}
 
-	sort.Slice(ports, func(i, j int) bool {
-		return ports[i].Name < ports[j].Name
-	})
+	sortPorts(ports)
 
 	return ports, nil
 }
@@ -168,9 +166,7 @@ func ConfigToReceiverPorts(logger logr.Logger, config map[interface{}]interface{
 		}
 	}
 
-	sort.Slice(ports, func(i, j int) bool {
-		return ports[i].Name < ports[j].Name
-	})
+	sortPorts(ports)
 
 	return ports, nil
 }
@@ -187,13 +183,30 @@ func ConfigToPorts(logger logr.Logger, config map[interface{}]interface{}) []cor
 	}
 	ports = append(ports, exporterPorts...)
 
-	sort.Slice(ports, func(i, j int) bool {
-		return ports[i].Name < ports[j].Name
-	})
+	sortPorts(ports)
 
 	return ports
 }
 
+// inferredPorts caches the ports inferred by ConfigStringToPorts, keyed by the hash of the configuration.
+var inferredPorts = newLRUCache(configCacheSize, copyPorts)
+
+// ConfigStringToPorts parses the given configuration and returns the ports inferred from its components, as
+// ConfigToPorts does. The results are cached, so that the same configuration submitted over and over, like GitOps tools
+// do with their dry-run and apply requests, is only processed once.
+func ConfigStringToPorts(logger logr.Logger, configStr string) ([]corev1.ServicePort, error) {
+	if ports, ok := inferredPorts.get(configStr); ok {
+		return ports, nil
+	}
+	config, err := ConfigFromString(configStr)
+	if err != nil {
+		return nil, err
+	}
+	ports := ConfigToPorts(logger, config)
+	inferredPorts.add(configStr, ports)
+	return ports, nil
+}
+
 // ConfigToMetricsPort gets the port number for the metrics endpoint from the collector config if it has been set.
 func ConfigToMetricsPort(logger logr.Logger, config map[interface{}]interface{}) (int32, error) {
 	// we don't need to unmarshal the whole config, just follow the keys down to
@@ -227,3 +240,17 @@ func ConfigToMetricsPort(logger logr.Logger, config map[interface{}]interface{})
 
 	return int32(i64), nil
 }
+
+// sortPorts orders the ports by name, then by port number and protocol, so that the ports of a collector are always
+// rendered in the same order, even when several components declare ports of the same name.
+func sortPorts(ports []corev1.ServicePort) {
+	sort.SliceStable(ports, func(i, j int) bool {
+		if ports[i].Name != ports[j].Name {
+			return ports[i].Name < ports[j].Name
+		}
+		if ports[i].Port != ports[j].Port {
+			return ports[i].Port < ports[j].Port
+		}
+		return ports[i].Protocol < ports[j].Protocol
+	})
+}
@@ -109,6 +109,32 @@ func TestExtractPortsFromConfig(t *testing.T) {
 	assert.ElementsMatch(t, expectedPorts, ports)
 }
 
+func TestConfigStringToPorts(t *testing.T) {
+	// prepare
+	config, err := adapters.ConfigFromString(portConfigStr)
+	require.NoError(t, err)
+	expected := adapters.ConfigToPorts(logger, config)
+
+	// test
+	ports, err := adapters.ConfigStringToPorts(logger, portConfigStr)
+	require.NoError(t, err)
+	ports[0].Name = "modified"
+	cached, err := adapters.ConfigStringToPorts(logger, portConfigStr)
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, expected, cached)
+}
+
+func TestConfigStringToPortsInvalidYAML(t *testing.T) {
+	// test
+	ports, err := adapters.ConfigStringToPorts(logger, "🦄")
+
+	// verify
+	assert.ErrorIs(t, err, adapters.ErrInvalidYAML)
+	assert.Nil(t, ports)
+}
+
 func TestNoPortsParsed(t *testing.T) {
 	for _, tt := range []struct {
 		expected  error
@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// defaultFileStorageDirectory is the directory the file_storage extension writes to when none is configured.
+const defaultFileStorageDirectory = "/var/lib/otelcol/file_storage"
+
+// ConfigToStorageDirectories returns the sorted directories the file_storage extensions enabled in the given
+// configuration write to, including their compaction directories.
+func ConfigToStorageDirectories(config map[interface{}]interface{}) []string {
+	service, _ := config["service"].(map[interface{}]interface{})
+	enabled, _ := service["extensions"].([]interface{})
+	extensions, _ := config["extensions"].(map[interface{}]interface{})
+
+	unique := map[string]bool{}
+	for _, e := range enabled {
+		name, ok := e.(string)
+		if !ok || (name != "file_storage" && !strings.HasPrefix(name, "file_storage/")) {
+			continue
+		}
+		settings, _ := extensions[name].(map[interface{}]interface{})
+		directory := defaultFileStorageDirectory
+		if d, ok := settings["directory"].(string); ok && d != "" {
+			directory = d
+		}
+		unique[path.Clean(directory)] = true
+		if compaction, ok := settings["compaction"].(map[interface{}]interface{}); ok {
+			if d, ok := compaction["directory"].(string); ok && d != "" {
+				unique[path.Clean(d)] = true
+			}
+		}
+	}
+
+	directories := make([]string, 0, len(unique))
+	for d := range unique {
+		directories = append(directories, d)
+	}
+	sort.Strings(directories)
+	return directories
+}
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigToStorageDirectories(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		config   string
+		expected []string
+	}{
+		{
+			desc: "no extensions",
+			config: `receivers:
+  otlp:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]`,
+			expected: []string{},
+		},
+		{
+			desc: "default directory",
+			config: `extensions:
+  file_storage:
+service:
+  extensions: [file_storage]`,
+			expected: []string{"/var/lib/otelcol/file_storage"},
+		},
+		{
+			desc: "custom and compaction directories",
+			config: `extensions:
+  file_storage/queue:
+    directory: /var/queue/
+    compaction:
+      directory: /var/compaction
+  file_storage/unused:
+    directory: /var/unused
+  health_check:
+service:
+  extensions: [health_check, file_storage/queue]`,
+			expected: []string{"/var/compaction", "/var/queue"},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			config, err := ConfigFromString(tt.config)
+			require.NoError(t, err)
+
+			// test
+			directories := ConfigToStorageDirectories(config)
+
+			// verify
+			assert.Equal(t, tt.expected, directories)
+		})
+	}
+}
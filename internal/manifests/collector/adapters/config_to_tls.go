@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// TLSSecretsDir is the directory of the TLS files read from Secrets by naming convention: the files under
+// <TLSSecretsDir>/<secret>/ are the keys of the Secret <secret>, mounted there when the collector mounts the TLS Secrets
+// of its exporters.
+const TLSSecretsDir = "/etc/otelcol/tls"
+
+// tlsFileSettings are the settings of the TLS configuration of the collector holding the path of a file.
+var tlsFileSettings = map[string]bool{"ca_file": true, "cert_file": true, "key_file": true}
+
+// TLSFile is a file read by the TLS settings of an exporter.
+type TLSFile struct {
+	// Exporter is the name of the exporter, including its qualifier.
+	Exporter string
+	// Setting is the name of the setting, e.g. cert_file.
+	Setting string
+	// Path is the absolute path of the file.
+	Path string
+}
+
+// Secret returns the name of the Secret the file is read from by naming convention, when it's in a directory of
+// TLSSecretsDir named after a valid Secret name.
+func (f TLSFile) Secret() (string, bool) {
+	relative, found := strings.CutPrefix(path.Clean(f.Path), TLSSecretsDir+"/")
+	if !found {
+		return "", false
+	}
+	secret, _, found := strings.Cut(relative, "/")
+	if !found || len(validation.IsDNS1123Subdomain(secret)) > 0 {
+		return "", false
+	}
+	return secret, true
+}
+
+// ConfigToTLSFiles returns the files read by the TLS settings of the exporters of the configuration, at any depth of
+// their settings, sorted by exporter and setting. The relative paths and the ones set from the environment can't be
+// resolved, they're left out.
+func ConfigToTLSFiles(config map[interface{}]interface{}) []TLSFile {
+	exporters, ok := config["exporters"].(map[interface{}]interface{})
+	if !ok {
+		return nil
+	}
+	var files []TLSFile
+	for name, settings := range exporters {
+		files = appendTLSFiles(files, fmt.Sprint(name), settings)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].Exporter != files[j].Exporter {
+			return files[i].Exporter < files[j].Exporter
+		}
+		return files[i].Setting < files[j].Setting
+	})
+	return files
+}
+
+func appendTLSFiles(files []TLSFile, exporter string, settings interface{}) []TLSFile {
+	switch s := settings.(type) {
+	case map[interface{}]interface{}:
+		for key, value := range s {
+			file, ok := value.(string)
+			if ok && tlsFileSettings[fmt.Sprint(key)] {
+				if path.IsAbs(file) {
+					files = append(files, TLSFile{Exporter: exporter, Setting: fmt.Sprint(key), Path: file})
+				}
+				continue
+			}
+			files = appendTLSFiles(files, exporter, value)
+		}
+	case []interface{}:
+		for _, item := range s {
+			files = appendTLSFiles(files, exporter, item)
+		}
+	}
+	return files
+}
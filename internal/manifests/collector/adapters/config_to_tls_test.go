@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigToTLSFiles(t *testing.T) {
+	// prepare
+	config, err := ConfigFromString(`exporters:
+  otlp:
+    endpoint: backend:4317
+    tls:
+      ca_file: /etc/otelcol/tls/backend-ca/ca.crt
+      cert_file: /certs/client.crt
+      key_file: ${env:KEY_FILE}
+  kafka:
+    auth:
+      tls:
+        ca_file: kafka-ca.crt
+  debug:
+`)
+	require.NoError(t, err)
+
+	// test
+	files := ConfigToTLSFiles(config)
+
+	// verify
+	assert.Equal(t, []TLSFile{
+		{Exporter: "otlp", Setting: "ca_file", Path: "/etc/otelcol/tls/backend-ca/ca.crt"},
+		{Exporter: "otlp", Setting: "cert_file", Path: "/certs/client.crt"},
+	}, files)
+}
+
+func TestTLSFileSecret(t *testing.T) {
+	for _, tt := range []struct {
+		path   string
+		secret string
+		ok     bool
+	}{
+		{path: "/etc/otelcol/tls/backend-ca/ca.crt", secret: "backend-ca", ok: true},
+		{path: "/etc/otelcol/tls/client/certs/tls.crt", secret: "client", ok: true},
+		{path: "/etc/otelcol/tls/ca.crt"},
+		{path: "/etc/otelcol/tls/Invalid_Name/ca.crt"},
+		{path: "/certs/ca.crt"},
+	} {
+		secret, ok := TLSFile{Path: tt.path}.Secret()
+		assert.Equal(t, tt.ok, ok, tt.path)
+		assert.Equal(t, tt.secret, secret, tt.path)
+	}
+}
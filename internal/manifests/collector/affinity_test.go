@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestAffinityNodeArchitectures(t *testing.T) {
+	architectures := corev1.NodeSelectorRequirement{
+		Key:      "kubernetes.io/arch",
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   []string{"amd64", "arm64"},
+	}
+	zone := corev1.NodeSelectorRequirement{Key: "topology.kubernetes.io/zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"a"}}
+	pool := corev1.NodeSelectorRequirement{Key: "pool", Operator: corev1.NodeSelectorOpExists}
+	preferred := []corev1.PreferredSchedulingTerm{{Weight: 1, Preference: corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{zone}}}}
+
+	for _, tt := range []struct {
+		desc     string
+		affinity *corev1.Affinity
+		expected *corev1.Affinity
+	}{
+		{
+			desc: "no affinity",
+			expected: &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{architectures}}},
+				},
+			}},
+		},
+		{
+			desc: "required terms",
+			affinity: &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{
+						{MatchExpressions: []corev1.NodeSelectorRequirement{zone}},
+						{MatchExpressions: []corev1.NodeSelectorRequirement{pool}},
+					},
+				},
+				PreferredDuringSchedulingIgnoredDuringExecution: preferred,
+			}},
+			expected: &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{
+						{MatchExpressions: []corev1.NodeSelectorRequirement{zone, architectures}},
+						{MatchExpressions: []corev1.NodeSelectorRequirement{pool, architectures}},
+					},
+				},
+				PreferredDuringSchedulingIgnoredDuringExecution: preferred,
+			}},
+		},
+		{
+			desc:     "pod anti-affinity",
+			affinity: &corev1.Affinity{PodAntiAffinity: &corev1.PodAntiAffinity{}},
+			expected: &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{architectures}}},
+					},
+				},
+				PodAntiAffinity: &corev1.PodAntiAffinity{},
+			},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			params := deploymentParams()
+			params.OtelCol.Spec.Affinity = tt.affinity
+			params.OtelCol.Spec.NodeArchitectures = []v1alpha1.NodeArchitecture{v1alpha1.NodeArchitectureAMD64, v1alpha1.NodeArchitectureARM64}
+			original := tt.affinity.DeepCopy()
+
+			// test
+			d := Deployment(params)
+
+			// verify
+			assert.Equal(t, tt.expected, d.Spec.Template.Spec.Affinity)
+			assert.Equal(t, original, params.OtelCol.Spec.Affinity, "the affinity of the custom resource shouldn't be modified")
+		})
+	}
+}
@@ -17,34 +17,46 @@ package collector
 import (
 	"crypto/sha256"
 	"fmt"
+	"strconv"
+
+	"github.com/go-logr/logr"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 )
 
-// Annotations return the annotations for OpenTelemetryCollector pod.
-func Annotations(instance v1alpha1.OpenTelemetryCollector) map[string]string {
+// Annotations return the annotations for OpenTelemetryCollector pod. The annotations of the instance matching the filters
+// aren't propagated.
+func Annotations(instance v1alpha1.OpenTelemetryCollector, filterAnnotations []string) map[string]string {
 	// new map every time, so that we don't touch the instance's annotations
 	annotations := map[string]string{}
 
 	// set default prometheus annotations
 	annotations["prometheus.io/scrape"] = "true"
-	annotations["prometheus.io/port"] = "8888"
+	annotations["prometheus.io/port"] = strconv.Itoa(int(metricsPort(logr.Discard(), instance)))
 	annotations["prometheus.io/path"] = "/metrics"
 
-	// allow override of prometheus annotations
-	if nil != instance.Annotations {
-		for k, v := range instance.Annotations {
-			annotations[k] = v
+	// allow override of prometheus annotations, the promotion of the canary mustn't restart the pods though
+	for k, v := range manifestutils.FilterAnnotations(instance.Annotations, filterAnnotations) {
+		if k == PromoteCanaryAnnotation {
+			continue
 		}
+		annotations[k] = v
 	}
 	// make sure sha256 for configMap is always calculated
-	annotations["opentelemetry-operator-config/sha256"] = getConfigMapSHA(instance.Spec.Config)
+	annotations["opentelemetry-operator-config/sha256"] = getConfigMapSHA(configWithSpec(instance))
 
 	return annotations
 }
 
+// WorkloadAnnotations return the annotations for the workload of the OpenTelemetryCollector, i.e. its Deployment,
+// DaemonSet or StatefulSet. The annotations of the spec are set on the workload only, not on its pods.
+func WorkloadAnnotations(instance v1alpha1.OpenTelemetryCollector, filterAnnotations []string) map[string]string {
+	return manifestutils.Merge(Annotations(instance, filterAnnotations), instance.Spec.Annotations)
+}
+
 // PodAnnotations return the spec annotations for OpenTelemetryCollector pod.
-func PodAnnotations(instance v1alpha1.OpenTelemetryCollector) map[string]string {
+func PodAnnotations(instance v1alpha1.OpenTelemetryCollector, filterAnnotations []string) map[string]string {
 	// new map every time, so that we don't touch the instance's annotations
 	podAnnotations := map[string]string{}
 
@@ -54,18 +66,33 @@ func PodAnnotations(instance v1alpha1.OpenTelemetryCollector) map[string]string
 	}
 
 	// propagating annotations from metadata.annotations
-	for kMeta, vMeta := range Annotations(instance) {
+	for kMeta, vMeta := range Annotations(instance, filterAnnotations) {
 		if _, found := podAnnotations[kMeta]; !found {
 			podAnnotations[kMeta] = vMeta
 		}
 	}
 
 	// make sure sha256 for configMap is always calculated
-	podAnnotations["opentelemetry-operator-config/sha256"] = getConfigMapSHA(instance.Spec.Config)
+	podAnnotations["opentelemetry-operator-config/sha256"] = getConfigMapSHA(configWithSpec(instance))
 
 	return podAnnotations
 }
 
+// configWithSpec returns the configuration merged with the telemetry and the sharding of the spec, so that the pods
+// are rolled out when they change, falling back to the configuration of the spec when it can't be merged.
+func configWithSpec(instance v1alpha1.OpenTelemetryCollector) string {
+	cfg, err := AddTelemetry(instance.Spec.Config, instance.Spec.Telemetry)
+	if err != nil {
+		return instance.Spec.Config
+	}
+	if prometheusShardingEnabled(instance) {
+		if sharded, err := AddPrometheusSharding(cfg, prometheusShards(instance)); err == nil {
+			return sharded
+		}
+	}
+	return cfg
+}
+
 func getConfigMapSHA(config string) string {
 	h := sha256.Sum256([]byte(config))
 	return fmt.Sprintf("%x", h)
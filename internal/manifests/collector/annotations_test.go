@@ -36,8 +36,8 @@ func TestDefaultAnnotations(t *testing.T) {
 	}
 
 	// test
-	annotations := Annotations(otelcol)
-	podAnnotations := PodAnnotations(otelcol)
+	annotations := Annotations(otelcol, nil)
+	podAnnotations := PodAnnotations(otelcol, nil)
 
 	//verify
 	assert.Equal(t, "true", annotations["prometheus.io/scrape"])
@@ -69,8 +69,8 @@ func TestUserAnnotations(t *testing.T) {
 	}
 
 	// test
-	annotations := Annotations(otelcol)
-	podAnnotations := PodAnnotations(otelcol)
+	annotations := Annotations(otelcol, nil)
+	podAnnotations := PodAnnotations(otelcol, nil)
 
 	//verify
 	assert.Equal(t, "false", annotations["prometheus.io/scrape"])
@@ -92,8 +92,8 @@ func TestAnnotationsPropagateDown(t *testing.T) {
 	}
 
 	// test
-	annotations := Annotations(otelcol)
-	podAnnotations := PodAnnotations(otelcol)
+	annotations := Annotations(otelcol, nil)
+	podAnnotations := PodAnnotations(otelcol, nil)
 
 	// verify
 	assert.Len(t, annotations, 5)
@@ -101,3 +101,53 @@ func TestAnnotationsPropagateDown(t *testing.T) {
 	assert.Equal(t, "mycomponent", podAnnotations["myapp"])
 	assert.Equal(t, "pod_annotation_value", podAnnotations["pod_annotation"])
 }
+
+func TestFilteredAnnotations(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				"myapp":                                  "mycomponent",
+				"kustomize.config.k8s.io/needs-hash":     "true",
+				"kubectl.kubernetes.io/restartedAt":      "now",
+				"kubectl.kubernetes.io/last-applied-cfg": "{}",
+			},
+		},
+	}
+	filters := []string{"kustomize\\.config\\.k8s\\.io/.*", "kubectl\\.kubernetes\\.io/.*"}
+
+	// test
+	annotations := Annotations(otelcol, filters)
+	podAnnotations := PodAnnotations(otelcol, filters)
+
+	// verify
+	for _, a := range []map[string]string{annotations, podAnnotations} {
+		assert.Equal(t, "mycomponent", a["myapp"])
+		assert.NotContains(t, a, "kustomize.config.k8s.io/needs-hash")
+		assert.NotContains(t, a, "kubectl.kubernetes.io/restartedAt")
+		assert.NotContains(t, a, "kubectl.kubernetes.io/last-applied-cfg")
+	}
+	assert.Len(t, otelcol.Annotations, 4, "the annotations of the custom resource should be left untouched")
+}
+
+func TestWorkloadAnnotations(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{"myapp": "mycomponent", "reloader.stakater.com/auto": "false"},
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Annotations: map[string]string{"reloader.stakater.com/auto": "true"},
+		},
+	}
+
+	// test
+	annotations := WorkloadAnnotations(otelcol, nil)
+	podAnnotations := PodAnnotations(otelcol, nil)
+
+	// verify
+	assert.Equal(t, "mycomponent", annotations["myapp"])
+	assert.Equal(t, "true", annotations["reloader.stakater.com/auto"])
+	assert.Equal(t, "8888", annotations["prometheus.io/port"])
+	assert.Equal(t, "false", podAnnotations["reloader.stakater.com/auto"], "the annotations of the spec aren't set on the pods")
+}
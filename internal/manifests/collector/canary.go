@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+const (
+	// PromoteCanaryAnnotation promotes the canary of the collector when it's set to the hash of its configuration.
+	PromoteCanaryAnnotation = "operator.opentelemetry.io/promote-canary"
+
+	// canaryLabel tells the pods of the canary apart from the other replicas, the Services select them alike.
+	canaryLabel = "operator.opentelemetry.io/canary"
+
+	defaultCanaryPercentage = 10
+)
+
+// CanaryRolloutEnabled returns whether the changes of the configuration of the instance are rolled out to a canary first.
+func CanaryRolloutEnabled(otelcol v1alpha1.OpenTelemetryCollector) bool {
+	return otelcol.Spec.Mode == v1alpha1.ModeDeployment && otelcol.Spec.Rollout != nil && otelcol.Spec.Rollout.Strategy == v1alpha1.RolloutStrategyCanary
+}
+
+// CanaryInProgress returns whether the configuration of the spec is run by a canary, while the replicas of the
+// instance keep the stable configuration of its status.
+func CanaryInProgress(otelcol v1alpha1.OpenTelemetryCollector) bool {
+	rollout := otelcol.Status.Rollout
+	return CanaryRolloutEnabled(otelcol) && rollout != nil && rollout.StableConfig != "" && rollout.StableConfig != otelcol.Spec.Config
+}
+
+// CanaryRolledBack returns whether the canary of the configuration of the spec was rolled back by its analysis, the
+// replicas then keep the stable configuration without a canary.
+func CanaryRolledBack(otelcol v1alpha1.OpenTelemetryCollector) bool {
+	rollout := otelcol.Status.Rollout
+	return CanaryInProgress(otelcol) && rollout.Phase == v1alpha1.RolloutPhaseRolledBack && rollout.CanaryConfigHash == ConfigHash(otelcol.Spec.Config)
+}
+
+// ConfigHash returns the hash identifying a configuration in the status of the rollout.
+func ConfigHash(config string) string {
+	return getConfigMapSHA(config)
+}
+
+// CanaryReplicas returns the number of replicas of the canary, the canary percentage of the replicas rounded up.
+func CanaryReplicas(otelcol v1alpha1.OpenTelemetryCollector) int32 {
+	replicas := int32(1)
+	if otelcol.Spec.Replicas != nil {
+		replicas = *otelcol.Spec.Replicas
+	}
+	percentage := int32(defaultCanaryPercentage)
+	if otelcol.Spec.Rollout != nil && otelcol.Spec.Rollout.CanaryPercentage != nil {
+		percentage = *otelcol.Spec.Rollout.CanaryPercentage
+	}
+	canary := (replicas*percentage + 99) / 100
+	if canary < 1 {
+		return 1
+	}
+	return canary
+}
+
+// Canary builds the Deployment and the ConfigMap of the canary running the configuration of the spec. It runs next to
+// the replicas of the collector and is selected by the same Services.
+func Canary(params manifests.Params) []client.Object {
+	name := naming.CollectorCanary(&params.OtelCol)
+
+	configMap := ConfigMap(params)
+	configMap.Name = name
+
+	deployment := Deployment(params)
+	deployment.Name = name
+	replicas := CanaryReplicas(params.OtelCol)
+	deployment.Spec.Replicas = &replicas
+	selector := map[string]string{canaryLabel: "true"}
+	for k, v := range deployment.Spec.Selector.MatchLabels {
+		selector[k] = v
+	}
+	deployment.Spec.Selector.MatchLabels = selector
+	// the labels of the template are shared with the Deployment, so they are copied
+	podLabels := map[string]string{canaryLabel: "true"}
+	for k, v := range deployment.Spec.Template.Labels {
+		podLabels[k] = v
+	}
+	deployment.Spec.Template.Labels = podLabels
+	for i, volume := range deployment.Spec.Template.Spec.Volumes {
+		if volume.Name == naming.ConfigMapVolume() && volume.ConfigMap != nil {
+			deployment.Spec.Template.Spec.Volumes[i].ConfigMap.Name = name
+		}
+	}
+	return []client.Object{configMap, deployment}
+}
@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	. "github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+func TestCanary(t *testing.T) {
+	// prepare
+	five := int32(5)
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "my-namespace",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:     v1alpha1.ModeDeployment,
+			Replicas: &five,
+			Config:   "receivers: {otlp: {}}\n",
+			Rollout:  &v1alpha1.Rollout{Strategy: v1alpha1.RolloutStrategyCanary},
+		},
+	}
+	params := manifests.Params{
+		Config:  config.New(),
+		OtelCol: otelcol,
+		Log:     logger,
+	}
+
+	// test
+	objs := Canary(params)
+
+	// verify
+	require.Len(t, objs, 2)
+	configMap, deployment := objs[0].(*corev1.ConfigMap), objs[1].(*appsv1.Deployment)
+	assert.Equal(t, "my-instance-collector-canary", configMap.Name)
+	assert.Equal(t, ConfigMap(params).Data, configMap.Data)
+	assert.Equal(t, "my-instance-collector-canary", deployment.Name)
+	assert.Equal(t, int32(1), *deployment.Spec.Replicas)
+	assert.Equal(t, "true", deployment.Spec.Selector.MatchLabels["operator.opentelemetry.io/canary"])
+	assert.Equal(t, "true", deployment.Spec.Template.Labels["operator.opentelemetry.io/canary"])
+	assert.NotContains(t, deployment.Labels, "operator.opentelemetry.io/canary")
+	for _, volume := range deployment.Spec.Template.Spec.Volumes {
+		if volume.Name == naming.ConfigMapVolume() {
+			assert.Equal(t, "my-instance-collector-canary", volume.ConfigMap.Name)
+		}
+	}
+}
+
+func TestCanaryReplicas(t *testing.T) {
+	three, ten, fifteen, fifty, hundred := int32(3), int32(10), int32(15), int32(50), int32(100)
+	for _, tt := range []struct {
+		name       string
+		replicas   *int32
+		percentage *int32
+		expected   int32
+	}{
+		{name: "default replicas and percentage", expected: 1},
+		{name: "rounded up", replicas: &fifteen, expected: 2},
+		{name: "percentage", replicas: &ten, percentage: &fifty, expected: 5},
+		{name: "all the replicas", replicas: &three, percentage: &hundred, expected: 3},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// prepare
+			otelcol := v1alpha1.OpenTelemetryCollector{
+				Spec: v1alpha1.OpenTelemetryCollectorSpec{
+					Replicas: tt.replicas,
+					Rollout:  &v1alpha1.Rollout{Strategy: v1alpha1.RolloutStrategyCanary, CanaryPercentage: tt.percentage},
+				},
+			}
+
+			// test
+			replicas := CanaryReplicas(otelcol)
+
+			// verify
+			assert.Equal(t, tt.expected, replicas)
+		})
+	}
+}
+
+func TestCanaryInProgress(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:    v1alpha1.ModeDeployment,
+			Config:  "receivers: {otlp: {}}\n",
+			Rollout: &v1alpha1.Rollout{Strategy: v1alpha1.RolloutStrategyCanary},
+		},
+	}
+
+	// test and verify
+	assert.False(t, CanaryInProgress(otelcol))
+	otelcol.Status.Rollout = &v1alpha1.RolloutStatus{StableConfig: otelcol.Spec.Config}
+	assert.False(t, CanaryInProgress(otelcol))
+	otelcol.Status.Rollout.StableConfig = "receivers: {}\n"
+	assert.True(t, CanaryInProgress(otelcol))
+	assert.False(t, CanaryRolledBack(otelcol))
+	otelcol.Status.Rollout.Phase = v1alpha1.RolloutPhaseRolledBack
+	otelcol.Status.Rollout.CanaryConfigHash = ConfigHash(otelcol.Spec.Config)
+	assert.True(t, CanaryRolledBack(otelcol))
+	otelcol.Spec.Rollout.Strategy = v1alpha1.RolloutStrategyImmediate
+	assert.False(t, CanaryInProgress(otelcol))
+}
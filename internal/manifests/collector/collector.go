@@ -42,17 +42,38 @@ func Build(params manifests.Params) ([]client.Object, error) {
 	case v1alpha1.ModeSidecar:
 		params.Log.V(5).Info("not building sidecar...")
 	}
-	manifestFactories = append(manifestFactories, []manifests.K8sManifestFactory{
-		manifests.FactoryWithoutError(ConfigMap),
-		manifests.FactoryWithoutError(HorizontalPodAutoscaler),
-		manifests.FactoryWithoutError(ServiceAccount),
-		manifests.FactoryWithoutError(Service),
-		manifests.FactoryWithoutError(HeadlessService),
-		manifests.FactoryWithoutError(MonitoringService),
-		manifests.FactoryWithoutError(Ingress),
-	}...)
-	if params.OtelCol.Spec.Observability.Metrics.EnableMetrics && featuregate.PrometheusOperatorIsAvailable.IsEnabled() {
-		manifestFactories = append(manifestFactories, manifests.Factory(ServiceMonitor))
+	// the sidecars get their configuration through the pod mutation and aren't selected by the collector labels, so
+	// neither the ConfigMap nor the Services, which all require parsing the configuration, are of any use for them
+	isSidecar := params.OtelCol.Spec.Mode == v1alpha1.ModeSidecar
+	if !isSidecar {
+		manifestFactories = append(manifestFactories, manifests.FactoryWithoutError(ConfigMap))
+	}
+	if params.OtelCol.Spec.Autoscaler != nil {
+		manifestFactories = append(manifestFactories, manifests.FactoryWithoutError(HorizontalPodAutoscaler))
+	}
+	manifestFactories = append(manifestFactories, manifests.FactoryWithoutError(ServiceAccount))
+	if !isSidecar {
+		manifestFactories = append(manifestFactories, []manifests.K8sManifestFactory{
+			manifests.FactoryWithoutError(Service),
+			manifests.FactoryWithoutError(HeadlessService),
+			manifests.FactoryWithoutError(MonitoringService),
+			manifests.FactoryWithoutError(PrometheusExporterService),
+		}...)
+		if params.OtelCol.Spec.DebugEndpoints != nil && params.OtelCol.Spec.DebugEndpoints.Enabled {
+			manifestFactories = append(manifestFactories,
+				manifests.FactoryWithoutError(DebugService),
+				manifests.FactoryWithoutError(DebugNetworkPolicy),
+			)
+		}
+		if params.OtelCol.Spec.Ingress.Type == v1alpha1.IngressTypeNginx {
+			manifestFactories = append(manifestFactories, manifests.FactoryWithoutError(Ingress))
+		}
+		if params.OtelCol.Spec.Observability.Dashboards.Enabled {
+			manifestFactories = append(manifestFactories, manifests.Factory(DashboardsConfigMap))
+		}
+		if params.OtelCol.Spec.Observability.Metrics.EnableMetrics && featuregate.PrometheusOperatorIsAvailable.IsEnabled() {
+			manifestFactories = append(manifestFactories, manifests.Factory(ServiceMonitor))
+		}
 	}
 	for _, factory := range manifestFactories {
 		res, err := factory(params)
@@ -62,10 +83,15 @@ func Build(params manifests.Params) ([]client.Object, error) {
 			resourceManifests = append(resourceManifests, res)
 		}
 	}
-	routes := Routes(params)
-	// NOTE: we cannot just unpack the slice, the type checker doesn't coerce the type correctly.
-	for _, route := range routes {
-		resourceManifests = append(resourceManifests, route)
+	if !isSidecar && params.OtelCol.Spec.Ingress.Type == v1alpha1.IngressTypeRoute {
+		routes := Routes(params)
+		// NOTE: we cannot just unpack the slice, the type checker doesn't coerce the type correctly.
+		for _, route := range routes {
+			resourceManifests = append(resourceManifests, route)
+		}
+	}
+	for _, svc := range PodServices(params) {
+		resourceManifests = append(resourceManifests, svc)
 	}
 	return resourceManifests, nil
 }
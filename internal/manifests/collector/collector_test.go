@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func kindsOf(objects []client.Object) []string {
+	var kinds []string
+	for _, obj := range objects {
+		switch obj.(type) {
+		case *appsv1.Deployment:
+			kinds = append(kinds, "Deployment")
+		case *corev1.ConfigMap:
+			kinds = append(kinds, "ConfigMap")
+		case *corev1.Service:
+			kinds = append(kinds, "Service")
+		case *corev1.ServiceAccount:
+			kinds = append(kinds, "ServiceAccount")
+		case *autoscalingv2.HorizontalPodAutoscaler:
+			kinds = append(kinds, "HorizontalPodAutoscaler")
+		case *networkingv1.Ingress:
+			kinds = append(kinds, "Ingress")
+		}
+	}
+	return kinds
+}
+
+func TestBuildSidecar(t *testing.T) {
+	// prepare
+	params := paramsWithMode(v1alpha1.ModeSidecar)
+
+	// test
+	objects, err := Build(params)
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ServiceAccount"}, kindsOf(objects))
+}
+
+func TestBuildSkipsUnsetOptionalObjects(t *testing.T) {
+	// prepare
+	params := deploymentParams()
+
+	// test
+	objects, err := Build(params)
+
+	// verify
+	require.NoError(t, err)
+	kinds := kindsOf(objects)
+	assert.Contains(t, kinds, "ConfigMap")
+	assert.Contains(t, kinds, "Service")
+	assert.NotContains(t, kinds, "HorizontalPodAutoscaler")
+	assert.NotContains(t, kinds, "Ingress")
+}
+
+func TestBuildOptionalObjects(t *testing.T) {
+	// prepare
+	maxReplicas := int32(3)
+	params := deploymentParams()
+	params.OtelCol.Spec.Autoscaler = &v1alpha1.AutoscalerSpec{MaxReplicas: &maxReplicas}
+	params.OtelCol.Spec.Ingress = v1alpha1.Ingress{
+		Type:     v1alpha1.IngressTypeNginx,
+		Hostname: "example.com",
+	}
+
+	// test
+	objects, err := Build(params)
+
+	// verify
+	require.NoError(t, err)
+	kinds := kindsOf(objects)
+	assert.Contains(t, kinds, "HorizontalPodAutoscaler")
+	assert.Contains(t, kinds, "Ingress")
+}
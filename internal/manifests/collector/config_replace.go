@@ -19,10 +19,10 @@ import (
 
 	promconfig "github.com/prometheus/prometheus/config"
 	_ "github.com/prometheus/prometheus/discovery/install" // Package install has the side-effect of registering all builtin.
-	"gopkg.in/yaml.v2"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 	ta "github.com/open-telemetry/opentelemetry-operator/internal/manifests/targetallocator/adapters"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
@@ -43,17 +43,22 @@ type Config struct {
 }
 
 func ReplaceConfig(instance v1alpha1.OpenTelemetryCollector) (string, error) {
-	// Check if TargetAllocator is enabled, if not, return the original config
+	cfg, err := AddTelemetry(instance.Spec.Config, instance.Spec.Telemetry)
+	if err != nil {
+		return "", err
+	}
+
+	// Check if TargetAllocator is enabled, if not, return the config with the telemetry of the spec
 	if !instance.Spec.TargetAllocator.Enabled {
-		return instance.Spec.Config, nil
+		return cfg, nil
 	}
 
-	config, err := adapters.ConfigFromString(instance.Spec.Config)
+	config, err := adapters.ConfigFromString(cfg)
 	if err != nil {
 		return "", err
 	}
 
-	promCfgMap, getCfgPromErr := ta.ConfigToPromConfig(instance.Spec.Config)
+	promCfgMap, getCfgPromErr := ta.ConfigToPromConfig(cfg)
 	if getCfgPromErr != nil {
 		return "", getCfgPromErr
 	}
@@ -74,7 +79,7 @@ func ReplaceConfig(instance v1alpha1.OpenTelemetryCollector) (string, error) {
 		// type coercion checks are handled in the AddTAConfigToPromConfig method above
 		config["receivers"].(map[interface{}]interface{})["prometheus"] = updPromCfgMap
 
-		out, updCfgMarshalErr := yaml.Marshal(config)
+		out, updCfgMarshalErr := manifestutils.MarshalYAML(config)
 		if updCfgMarshalErr != nil {
 			return "", updCfgMarshalErr
 		}
@@ -92,7 +97,7 @@ func ReplaceConfig(instance v1alpha1.OpenTelemetryCollector) (string, error) {
 	// type coercion checks are handled in the ConfigToPromConfig method above
 	config["receivers"].(map[interface{}]interface{})["prometheus"] = updPromCfgMap
 
-	out, err := yaml.Marshal(config)
+	out, err := manifestutils.MarshalYAML(config)
 	if err != nil {
 		return "", err
 	}
@@ -24,13 +24,38 @@ import (
 )
 
 func ConfigMap(params manifests.Params) *corev1.ConfigMap {
-	name := naming.ConfigMap(params.OtelCol.Name)
-	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, []string{})
+	name := naming.ConfigMap(&params.OtelCol)
+	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, []string{}, params.OtelCol.Spec.StandardLabels)
 
 	replacedConf, err := ReplaceConfig(params.OtelCol)
 	if err != nil {
 		params.Log.V(2).Info("failed to update prometheus config to use sharded targets: ", "err", err)
 	}
+	if params.OtelCol.Spec.PrometheusCR.Enabled {
+		if withScrapeConfigs, err := AddPrometheusCRScrapeConfigs(replacedConf, params.ScrapeConfigs); err != nil {
+			params.Log.V(2).Info("failed to add the scrape configs of the ServiceMonitors and PodMonitors to the prometheus config", "err", err)
+		} else {
+			replacedConf = withScrapeConfigs
+		}
+	}
+	if prometheusShardingEnabled(params.OtelCol) {
+		if sharded, err := AddPrometheusSharding(replacedConf, prometheusShards(params.OtelCol)); err != nil {
+			params.Log.V(2).Info("failed to shard the scrape configs of the prometheus receiver", "err", err)
+		} else {
+			replacedConf = sharded
+		}
+	}
+
+	data := map[string]string{}
+	if spiffeEnabled(params.OtelCol) {
+		if withSpiffe, err := AddSpiffeTLS(replacedConf, params.OtelCol.Spec.Spiffe); err != nil {
+			params.Log.V(2).Info("failed to set the TLS settings of the receivers and exporters to the SVID", "err", err)
+		} else {
+			replacedConf = withSpiffe
+		}
+		data[SpiffeHelperConfigMapEntry] = SpiffeHelperConfig(params.OtelCol)
+	}
+	data["collector.yaml"] = replacedConf
 
 	return &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
@@ -39,8 +64,6 @@ func ConfigMap(params manifests.Params) *corev1.ConfigMap {
 			Labels:      labels,
 			Annotations: params.OtelCol.Annotations,
 		},
-		Data: map[string]string{
-			"collector.yaml": replacedConf,
-		},
+		Data: data,
 	}
 }
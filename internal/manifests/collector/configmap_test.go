@@ -212,4 +212,42 @@ service:
 
 	})
 
+	t.Run("should return expected collector config map with the scrape configs of the prometheus CRs", func(t *testing.T) {
+		expectedData := map[string]string{
+			"collector.yaml": `exporters:
+  debug: null
+processors: null
+receivers:
+  jaeger:
+    protocols:
+      grpc: null
+  prometheus:
+    config:
+      scrape_configs:
+      - job_name: otel-collector
+        scrape_interval: 10s
+        static_configs:
+        - targets:
+          - 0.0.0.0:8888
+          - 0.0.0.0:9999
+      - job_name: serviceMonitor/default/app/0
+service:
+  pipelines:
+    metrics:
+      exporters:
+      - debug
+      processors: []
+      receivers:
+      - prometheus
+      - jaeger
+`,
+		}
+
+		param := deploymentParams()
+		param.OtelCol.Spec.PrometheusCR.Enabled = true
+		param.ScrapeConfigs = []map[string]interface{}{{"job_name": "serviceMonitor/default/app/0"}}
+		actual := ConfigMap(param)
+
+		assert.Equal(t, expectedData, actual.Data)
+	})
 }
@@ -19,6 +19,8 @@ import (
 	"fmt"
 	"path"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/operator-framework/operator-lib/proxy"
@@ -28,6 +30,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 )
 
@@ -35,20 +38,37 @@ import (
 // https://pkg.go.dev/k8s.io/apimachinery/pkg/util/validation#IsValidPortName
 const maxPortLen = 15
 
+// featureGatesArg is the flag of the collector binary enabling or disabling its feature gates.
+const featureGatesArg = "feature-gates"
+
 // Container builds a container for the given collector.
 func Container(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector, addConfig bool) corev1.Container {
 	image := otelcol.Spec.Image
 	if len(image) == 0 {
 		image = cfg.CollectorImage()
+		// outside of the upgrade windows, keep the image currently deployed instead of the new default
+		if len(otelcol.Status.Image) > 0 && !v1alpha1.UpgradeAllowed(otelcol.Spec.UpgradeWindows, time.Now()) {
+			image = otelcol.Status.Image
+		}
 	}
 
 	// build container ports from service ports
-	ports := getConfigContainerPorts(logger, otelcol.Spec.Config)
+	ports := getConfigContainerPorts(logger, otelcol)
 	for _, p := range otelcol.Spec.Ports {
 		ports[p.Name] = corev1.ContainerPort{
 			Name:          p.Name,
 			ContainerPort: p.Port,
 			Protocol:      p.Protocol,
+			HostPort:      hostPort(otelcol, p),
+		}
+	}
+	// the OTLP receivers published on the node are exposed on the same ports of the node, unless the spec sets them
+	if otelcol.Spec.Mode == v1alpha1.ModeDaemonSet && otelcol.Spec.DaemonSet.NodeLocalEndpoint == v1alpha1.NodeLocalEndpointHostPort {
+		for name, p := range ports {
+			if strings.HasPrefix(name, "otlp") && p.HostPort == 0 {
+				p.HostPort = p.ContainerPort
+				ports[name] = p
+			}
 		}
 	}
 
@@ -84,14 +104,25 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelem
 	// ordering in args.
 	var sortedArgs []string
 	for k, v := range argsMap {
+		if k == featureGatesArg && len(otelcol.Spec.FeatureGates) > 0 {
+			continue
+		}
 		sortedArgs = append(sortedArgs, fmt.Sprintf("--%s=%s", k, v))
 	}
+	if len(otelcol.Spec.FeatureGates) > 0 {
+		sortedArgs = append(sortedArgs, fmt.Sprintf("--%s=%s", featureGatesArg, featureGates(argsMap[featureGatesArg], otelcol.Spec.FeatureGates)))
+	}
 	sort.Strings(sortedArgs)
 	args = append(args, sortedArgs...)
 
 	if len(otelcol.Spec.VolumeMounts) > 0 {
 		volumeMounts = append(volumeMounts, otelcol.Spec.VolumeMounts...)
 	}
+	volumeMounts = append(volumeMounts, manifestutils.SecretsStoreVolumeMounts(otelcol.Spec.SecretProviderClasses)...)
+	volumeMounts = append(volumeMounts, spiffeVolumeMounts(otelcol)...)
+	volumeMounts = append(volumeMounts, writableVolumeMounts(cfg, otelcol)...)
+	volumeMounts = append(volumeMounts, drainVolumeMounts(otelcol)...)
+	volumeMounts = append(volumeMounts, tlsSecretVolumeMounts(otelcol)...)
 
 	var envVars = otelcol.Spec.Env
 	if otelcol.Spec.Env == nil {
@@ -126,10 +157,14 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelem
 			Name:  "SHARD",
 			Value: "0",
 		})
+	} else if prometheusShardingEnabled(otelcol) {
+		envVars = append(envVars, shardEnvVar())
 	}
 
 	var livenessProbe *corev1.Probe
-	if configFromString, err := adapters.ConfigFromString(otelcol.Spec.Config); err == nil {
+	if otelcol.Spec.DisableProbes {
+		logger.V(1).Info("probes disabled, skipping liveness probe creation")
+	} else if configFromString, err := adapters.ConfigFromString(otelcol.Spec.Config); err == nil {
 		if probe, err := getLivenessProbe(configFromString, otelcol.Spec.LivenessProbe); err == nil {
 			livenessProbe = probe
 		} else if errors.Is(err, adapters.ErrNoServiceExtensions) {
@@ -151,16 +186,19 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelem
 		Args:            args,
 		Env:             envVars,
 		EnvFrom:         otelcol.Spec.EnvFrom,
-		Resources:       otelcol.Spec.Resources,
-		SecurityContext: otelcol.Spec.SecurityContext,
+		Resources:       manifestutils.Resources(cfg, otelcol.Spec.Resources),
+		SecurityContext: collectorSecurityContext(cfg, otelcol),
 		LivenessProbe:   livenessProbe,
-		Lifecycle:       otelcol.Spec.Lifecycle,
+		Lifecycle:       lifecycle(otelcol),
+		// the collector logs why it fails to start, e.g. an invalid configuration, before exiting: the last lines are
+		// kept as the termination message, which is reported in the status of the OpenTelemetryCollector
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 	}
 }
 
-func getConfigContainerPorts(logger logr.Logger, cfg string) map[string]corev1.ContainerPort {
+func getConfigContainerPorts(logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector) map[string]corev1.ContainerPort {
 	ports := map[string]corev1.ContainerPort{}
-	c, err := adapters.ConfigFromString(cfg)
+	c, err := adapters.ConfigFromString(otelcol.Spec.Config)
 	if err != nil {
 		logger.Error(err, "couldn't extract the configuration")
 		return ports
@@ -188,20 +226,23 @@ func getConfigContainerPorts(logger logr.Logger, cfg string) map[string]corev1.C
 		}
 	}
 
-	metricsPort, err := adapters.ConfigToMetricsPort(logger, c)
-	if err != nil {
-		logger.Info("couldn't determine metrics port from configuration, using 8888 default value", "error", err)
-		metricsPort = 8888
-	}
 	ports["metrics"] = corev1.ContainerPort{
 		Name:          "metrics",
-		ContainerPort: metricsPort,
+		ContainerPort: metricsPort(logger, otelcol),
 		Protocol:      corev1.ProtocolTCP,
 	}
 
 	return ports
 }
 
+// hostPort returns the port of the node the port of the spec is exposed on, which only daemonsets can share.
+func hostPort(otelcol v1alpha1.OpenTelemetryCollector, p v1alpha1.PortsSpec) int32 {
+	if otelcol.Spec.Mode != v1alpha1.ModeDaemonSet {
+		return 0
+	}
+	return p.HostPort
+}
+
 func portMapToList(portMap map[string]corev1.ContainerPort) []corev1.ContainerPort {
 	ports := make([]corev1.ContainerPort, 0, len(portMap))
 	for _, p := range portMap {
@@ -238,3 +279,12 @@ func getLivenessProbe(config map[interface{}]interface{}, probeConfig *v1alpha1.
 	}
 	return probe, nil
 }
+
+// featureGates returns the value of the feature gates flag, the feature gates of the args coming before the ones
+// of the spec so that the latter take precedence.
+func featureGates(fromArgs string, gates []string) string {
+	if len(fromArgs) == 0 {
+		return strings.Join(gates, ",")
+	}
+	return strings.Join(append([]string{fromArgs}, gates...), ",")
+}
@@ -17,11 +17,13 @@ package collector_test
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
@@ -66,6 +68,53 @@ func TestContainerWithImageOverridden(t *testing.T) {
 	assert.Equal(t, "overridden-image", c.Image)
 }
 
+func TestContainerImagePinnedOutsideUpgradeWindows(t *testing.T) {
+	cfg := config.New(config.WithCollectorImage("default-image"))
+	for _, tt := range []struct {
+		desc     string
+		windows  []v1alpha1.UpgradeWindow
+		expected string
+	}{
+		{
+			desc:     "no upgrade windows",
+			expected: "default-image",
+		},
+		{
+			desc: "within an upgrade window",
+			windows: []v1alpha1.UpgradeWindow{
+				{Schedule: "* * * * *", Duration: metav1.Duration{Duration: time.Hour}},
+			},
+			expected: "default-image",
+		},
+		{
+			desc: "outside of the upgrade windows",
+			windows: []v1alpha1.UpgradeWindow{
+				// once a year, for a second
+				{Schedule: "0 0 1 1 *", Duration: metav1.Duration{Duration: time.Second}},
+			},
+			expected: "deployed-image",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			otelcol := v1alpha1.OpenTelemetryCollector{
+				Spec: v1alpha1.OpenTelemetryCollectorSpec{
+					UpgradeWindows: tt.windows,
+				},
+				Status: v1alpha1.OpenTelemetryCollectorStatus{
+					Image: "deployed-image",
+				},
+			}
+
+			// test
+			c := Container(cfg, logger, otelcol, true)
+
+			// verify
+			assert.Equal(t, tt.expected, c.Image)
+		})
+	}
+}
+
 func TestContainerPorts(t *testing.T) {
 	var goodConfig = `receivers:
   examplereceiver:
@@ -80,7 +129,7 @@ service:
 	tests := []struct {
 		description   string
 		specConfig    string
-		specPorts     []corev1.ServicePort
+		specPorts     []v1alpha1.PortsSpec
 		expectedPorts []corev1.ContainerPort
 	}{
 		{
@@ -109,11 +158,11 @@ service:
 		},
 		{
 			description: "ports in spec ContainerPorts",
-			specPorts: []corev1.ServicePort{
-				{
+			specPorts: []v1alpha1.PortsSpec{
+				{ServicePort: corev1.ServicePort{
 					Name: "testport1",
 					Port: 12345,
-				},
+				}},
 			},
 			expectedPorts: []corev1.ContainerPort{
 				metricContainerPort,
@@ -126,16 +175,16 @@ service:
 		{
 			description: "ports in spec Config and ContainerPorts",
 			specConfig:  goodConfig,
-			specPorts: []corev1.ServicePort{
-				{
+			specPorts: []v1alpha1.PortsSpec{
+				{ServicePort: corev1.ServicePort{
 					Name: "testport1",
 					Port: 12345,
-				},
-				{
+				}},
+				{ServicePort: corev1.ServicePort{
 					Name:     "testport2",
 					Port:     54321,
 					Protocol: corev1.ProtocolUDP,
-				},
+				}},
 			},
 			expectedPorts: []corev1.ContainerPort{
 				{
@@ -157,15 +206,15 @@ service:
 		{
 			description: "duplicate port name",
 			specConfig:  goodConfig,
-			specPorts: []corev1.ServicePort{
-				{
+			specPorts: []v1alpha1.PortsSpec{
+				{ServicePort: corev1.ServicePort{
 					Name: "testport1",
 					Port: 12345,
-				},
-				{
+				}},
+				{ServicePort: corev1.ServicePort{
 					Name: "testport1",
 					Port: 11111,
-				},
+				}},
 			},
 			expectedPorts: []corev1.ContainerPort{
 				{
@@ -190,7 +239,7 @@ service:
             exporters: [prometheus]
 `,
 
-			specPorts: []corev1.ServicePort{},
+			specPorts: []v1alpha1.PortsSpec{},
 			expectedPorts: []corev1.ContainerPort{
 				metricContainerPort,
 				{
@@ -211,7 +260,7 @@ service:
         metrics:
             exporters: [prometheus/prod, prometheus/dev]
 `,
-			specPorts: []corev1.ServicePort{},
+			specPorts: []v1alpha1.PortsSpec{},
 			expectedPorts: []corev1.ContainerPort{
 				metricContainerPort,
 				{
@@ -229,7 +278,7 @@ service:
 			specConfig: `exporters:
     prometheusremotewrite/prometheus:
         endpoint: http://prometheus-server.monitoring/api/v1/write`,
-			specPorts:     []corev1.ServicePort{},
+			specPorts:     []v1alpha1.PortsSpec{},
 			expectedPorts: []corev1.ContainerPort{metricContainerPort},
 		},
 		{
@@ -245,7 +294,7 @@ service:
     pipelines:
         metrics:
             exporters: [prometheus/prod, prometheus/dev, prometheusremotewrite/prometheus]`,
-			specPorts: []corev1.ServicePort{},
+			specPorts: []v1alpha1.PortsSpec{},
 			expectedPorts: []corev1.ContainerPort{
 				metricContainerPort,
 				{
@@ -515,6 +564,43 @@ func TestContainerOrderedArgs(t *testing.T) {
 	assert.Equal(t, "--log-level=debug", c.Args[2])
 }
 
+func TestContainerFeatureGates(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		args     map[string]string
+		expected string
+	}{
+		{
+			desc:     "only in the spec",
+			expected: "--feature-gates=+pkg.translator.prometheus.NormalizeName,-component.UseLocalHostAsDefaultHost",
+		},
+		{
+			desc:     "also in the args",
+			args:     map[string]string{"feature-gates": "+random-feature", "log-level": "debug"},
+			expected: "--feature-gates=+random-feature,+pkg.translator.prometheus.NormalizeName,-component.UseLocalHostAsDefaultHost",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			otelcol := v1alpha1.OpenTelemetryCollector{
+				Spec: v1alpha1.OpenTelemetryCollectorSpec{
+					Args:         tt.args,
+					FeatureGates: []string{"+pkg.translator.prometheus.NormalizeName", "-component.UseLocalHostAsDefaultHost"},
+				},
+			}
+			cfg := config.New()
+
+			// test
+			c := Container(cfg, logger, otelcol, true)
+
+			// verify
+			assert.Equal(t, "--config=/conf/collector.yaml", c.Args[0])
+			assert.Equal(t, tt.expected, c.Args[1])
+			assert.NotContains(t, c.Args, "--feature-gates=+random-feature")
+		})
+	}
+}
+
 func TestContainerImagePullPolicy(t *testing.T) {
 	// prepare
 	otelcol := v1alpha1.OpenTelemetryCollector{
@@ -652,6 +738,26 @@ service:
 	assert.Equal(t, "", c.LivenessProbe.HTTPGet.Host)
 }
 
+func TestContainerProbesDisabled(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config: `extensions:
+  health_check:
+service:
+  extensions: [health_check]`,
+			DisableProbes: true,
+		},
+	}
+	cfg := config.New()
+
+	// test
+	c := Container(cfg, logger, otelcol, true)
+
+	// verify
+	assert.Nil(t, c.LivenessProbe)
+}
+
 func TestContainerLifecycle(t *testing.T) {
 	// prepare
 	otelcol := v1alpha1.OpenTelemetryCollector{
@@ -683,3 +789,111 @@ func TestContainerLifecycle(t *testing.T) {
 	// verify
 	assert.Equal(t, expectedLifecycleHooks, *c.Lifecycle)
 }
+
+func TestContainerSecretProviderClassesVolumes(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			SecretProviderClasses: []v1alpha1.SecretProviderClassVolume{
+				{Name: "vault-api-keys"},
+				{Name: "aws-api-keys", MountPath: "/etc/secrets/aws"},
+			},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	c := Container(cfg, logger, otelcol, true)
+
+	// verify
+	assert.Equal(t, []corev1.VolumeMount{
+		{Name: "otc-internal", MountPath: "/conf"},
+		{Name: "secrets-store-vault-api-keys", MountPath: "/var/run/secrets-store/vault-api-keys", ReadOnly: true},
+		{Name: "secrets-store-aws-api-keys", MountPath: "/etc/secrets/aws", ReadOnly: true},
+	}, c.VolumeMounts)
+}
+
+func TestContainerPortsNotExposedOnService(t *testing.T) {
+	// prepare
+	hidden := false
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Ports: []v1alpha1.PortsSpec{{
+				ExposeOnService: &hidden,
+				ServicePort:     corev1.ServicePort{Name: "pprof", Port: 1777, Protocol: corev1.ProtocolTCP},
+			}},
+		},
+	}
+
+	// test
+	c := Container(config.New(), logger, otelcol, true)
+
+	// verify
+	assert.Contains(t, c.Ports, corev1.ContainerPort{Name: "pprof", ContainerPort: 1777, Protocol: corev1.ProtocolTCP})
+}
+
+func TestContainerHostPorts(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode: v1alpha1.ModeDaemonSet,
+			Ports: []v1alpha1.PortsSpec{{
+				HostPort:    8125,
+				ServicePort: corev1.ServicePort{Name: "statsd", Port: 8125, Protocol: corev1.ProtocolUDP},
+			}},
+		},
+	}
+
+	// test
+	c := Container(config.New(), logger, otelcol, true)
+
+	// verify
+	assert.Contains(t, c.Ports, corev1.ContainerPort{Name: "statsd", ContainerPort: 8125, HostPort: 8125, Protocol: corev1.ProtocolUDP})
+
+	// the replicas of the other modes would compete for the port of their node
+	otelcol.Spec.Mode = v1alpha1.ModeDeployment
+	c = Container(config.New(), logger, otelcol, true)
+	assert.Contains(t, c.Ports, corev1.ContainerPort{Name: "statsd", ContainerPort: 8125, Protocol: corev1.ProtocolUDP})
+}
+
+func TestContainerNodeLocalEndpoint(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:      v1alpha1.ModeDaemonSet,
+			DaemonSet: v1alpha1.DaemonSetSpec{NodeLocalEndpoint: v1alpha1.NodeLocalEndpointHostPort},
+			Ports: []v1alpha1.PortsSpec{{
+				HostPort:    14317,
+				ServicePort: corev1.ServicePort{Name: "otlp-grpc", Port: 4317},
+			}},
+			Config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+      http:
+  jaeger:
+    protocols:
+      thrift_compact:
+exporters:
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp, jaeger]
+      exporters: [debug]`,
+		},
+	}
+
+	// test
+	c := Container(config.New(), logger, otelcol, true)
+
+	// verify
+	assert.Contains(t, c.Ports, corev1.ContainerPort{Name: "otlp-grpc", ContainerPort: 4317, HostPort: 14317})
+	assert.Contains(t, c.Ports, corev1.ContainerPort{Name: "otlp-http", ContainerPort: 4318, HostPort: 4318})
+	assert.Contains(t, c.Ports, corev1.ContainerPort{Name: "port-6831", ContainerPort: 6831, Protocol: corev1.ProtocolUDP})
+
+	// the Service publishes the receivers by default
+	otelcol.Spec.DaemonSet.NodeLocalEndpoint = v1alpha1.NodeLocalEndpointService
+	c = Container(config.New(), logger, otelcol, true)
+	assert.Contains(t, c.Ports, corev1.ContainerPort{Name: "otlp-http", ContainerPort: 4318})
+}
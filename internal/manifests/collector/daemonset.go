@@ -26,21 +26,22 @@ import (
 
 // DaemonSet builds the deployment for the given instance.
 func DaemonSet(params manifests.Params) *appsv1.DaemonSet {
-	name := naming.Collector(params.OtelCol.Name)
-	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter())
+	name := naming.Collector(&params.OtelCol)
+	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter(), params.OtelCol.Spec.StandardLabels)
 
-	annotations := Annotations(params.OtelCol)
-	podAnnotations := PodAnnotations(params.OtelCol)
+	annotations := WorkloadAnnotations(params.OtelCol, params.Config.AnnotationsFilter())
+	podAnnotations := PodAnnotations(params.OtelCol, params.Config.AnnotationsFilter())
+	addServiceMeshAnnotations(params, podAnnotations)
 	return &appsv1.DaemonSet{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:        naming.Collector(params.OtelCol.Name),
+			Name:        naming.Collector(&params.OtelCol),
 			Namespace:   params.OtelCol.Namespace,
 			Labels:      labels,
 			Annotations: annotations,
 		},
 		Spec: appsv1.DaemonSetSpec{
 			Selector: &metav1.LabelSelector{
-				MatchLabels: manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollector),
+				MatchLabels: manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollector, params.OtelCol.Spec.StandardLabels),
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
@@ -48,17 +49,18 @@ func DaemonSet(params manifests.Params) *appsv1.DaemonSet {
 					Annotations: podAnnotations,
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName: ServiceAccountName(params.OtelCol),
-					InitContainers:     params.OtelCol.Spec.InitContainers,
-					Containers:         append(params.OtelCol.Spec.AdditionalContainers, Container(params.Config, params.Log, params.OtelCol, true)),
-					Volumes:            Volumes(params.Config, params.OtelCol),
-					Tolerations:        params.OtelCol.Spec.Tolerations,
-					NodeSelector:       params.OtelCol.Spec.NodeSelector,
-					HostNetwork:        params.OtelCol.Spec.HostNetwork,
-					DNSPolicy:          getDNSPolicy(params.OtelCol),
-					SecurityContext:    params.OtelCol.Spec.PodSecurityContext,
-					PriorityClassName:  params.OtelCol.Spec.PriorityClassName,
-					Affinity:           params.OtelCol.Spec.Affinity,
+					ServiceAccountName:            ServiceAccountName(params.OtelCol),
+					InitContainers:                InitContainers(params),
+					Containers:                    Containers(params),
+					Volumes:                       Volumes(params.Config, params.OtelCol),
+					Tolerations:                   manifestutils.Tolerations(params.Config, params.OtelCol.Spec.Tolerations),
+					NodeSelector:                  manifestutils.NodeSelector(params.Config, params.OtelCol.Spec.NodeSelector),
+					HostNetwork:                   params.OtelCol.Spec.HostNetwork,
+					DNSPolicy:                     getDNSPolicy(params.OtelCol),
+					SecurityContext:               params.OtelCol.Spec.PodSecurityContext,
+					PriorityClassName:             params.OtelCol.Spec.PriorityClassName,
+					Affinity:                      affinity(params.OtelCol),
+					TerminationGracePeriodSeconds: terminationGracePeriodSeconds(params.OtelCol),
 				},
 			},
 		},
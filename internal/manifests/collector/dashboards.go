@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+// defaultDashboardLabels are the labels the dashboards sidecar of Grafana discovers the dashboards with by default.
+var defaultDashboardLabels = map[string]string{"grafana_dashboard": "1"}
+
+// dashboardPanel is a time series panel of the dashboard, with a query for each of its expressions.
+type dashboardPanel struct {
+	title string
+	unit  string
+	exprs []string
+}
+
+// DashboardsConfigMap builds the ConfigMap holding the dashboard of the health of the collector, labelled to be
+// discovered by the dashboards sidecar of Grafana.
+func DashboardsConfigMap(params manifests.Params) (*corev1.ConfigMap, error) {
+	name := naming.DashboardsConfigMap(&params.OtelCol)
+	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, []string{}, params.OtelCol.Spec.StandardLabels)
+	discovery := params.OtelCol.Spec.Observability.Dashboards.Labels
+	if len(discovery) == 0 {
+		discovery = defaultDashboardLabels
+	}
+
+	dashboard, err := collectorDashboard(params.OtelCol.Namespace, params.OtelCol.Name, naming.MonitoringService(&params.OtelCol))
+	if err != nil {
+		return nil, err
+	}
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   params.OtelCol.Namespace,
+			Labels:      manifestutils.Merge(labels, discovery),
+			Annotations: params.OtelCol.Annotations,
+		},
+		Data: map[string]string{
+			// the sidecar writes the dashboards of all the namespaces in the same directory, the key has to be unique
+			fmt.Sprintf("%s-%s-collector-health.json", params.OtelCol.Namespace, params.OtelCol.Name): dashboard,
+		},
+	}, nil
+}
+
+// collectorDashboard returns the JSON model of the dashboard of the health of the collector, querying the metrics
+// scraped from the given monitoring Service, which is the job of the targets of its ServiceMonitor.
+func collectorDashboard(namespace, name, job string) (string, error) {
+	selector := fmt.Sprintf(`job="%s", namespace="%s"`, job, namespace)
+	rate := func(metric, by string) string {
+		return fmt.Sprintf("sum by (%s) (rate(%s{%s}[$__rate_interval]))", by, metric, selector)
+	}
+	panels := []dashboardPanel{
+		{title: "Up", unit: "none", exprs: []string{fmt.Sprintf("sum(up{%s})", selector)}},
+		{title: "Accepted spans", unit: "ops", exprs: []string{rate("otelcol_receiver_accepted_spans", "receiver")}},
+		{title: "Refused spans", unit: "ops", exprs: []string{rate("otelcol_receiver_refused_spans", "receiver")}},
+		{title: "Accepted metric points", unit: "ops", exprs: []string{rate("otelcol_receiver_accepted_metric_points", "receiver")}},
+		{title: "Refused metric points", unit: "ops", exprs: []string{rate("otelcol_receiver_refused_metric_points", "receiver")}},
+		{title: "Accepted log records", unit: "ops", exprs: []string{rate("otelcol_receiver_accepted_log_records", "receiver")}},
+		{title: "Refused log records", unit: "ops", exprs: []string{rate("otelcol_receiver_refused_log_records", "receiver")}},
+		{title: "Exporter send failures", unit: "ops", exprs: []string{
+			rate("otelcol_exporter_send_failed_spans", "exporter"),
+			rate("otelcol_exporter_send_failed_metric_points", "exporter"),
+			rate("otelcol_exporter_send_failed_log_records", "exporter"),
+		}},
+		{title: "Exporter queue usage", unit: "percentunit", exprs: []string{
+			fmt.Sprintf("max by (exporter) (otelcol_exporter_queue_size{%s} / otelcol_exporter_queue_capacity{%s})", selector, selector),
+		}},
+		{title: "Memory", unit: "bytes", exprs: []string{fmt.Sprintf("max by (pod) (otelcol_process_memory_rss{%s})", selector)}},
+		{title: "CPU", unit: "short", exprs: []string{rate("otelcol_process_cpu_seconds", "pod")}},
+	}
+
+	var models []map[string]interface{}
+	for i, panel := range panels {
+		var targets []map[string]interface{}
+		for j, expr := range panel.exprs {
+			targets = append(targets, map[string]interface{}{
+				"datasource": map[string]string{"type": "prometheus", "uid": "${datasource}"},
+				"expr":       expr,
+				"refId":      string(rune('A' + j)),
+			})
+		}
+		models = append(models, map[string]interface{}{
+			"id":          i + 1,
+			"type":        "timeseries",
+			"title":       panel.title,
+			"datasource":  map[string]string{"type": "prometheus", "uid": "${datasource}"},
+			"fieldConfig": map[string]interface{}{"defaults": map[string]string{"unit": panel.unit}},
+			"gridPos":     map[string]int{"h": 8, "w": 12, "x": (i % 2) * 12, "y": (i / 2) * 8},
+			"targets":     targets,
+		})
+	}
+
+	dashboard := map[string]interface{}{
+		// the uid is limited to 40 characters by Grafana, and has to be unique across the namespaces
+		"uid":           fmt.Sprintf("otelcol-%x", sha256.Sum256([]byte(namespace+"/"+name)))[:40],
+		"title":         fmt.Sprintf("OpenTelemetry Collector / %s / %s", namespace, name),
+		"tags":          []string{"opentelemetry", "opentelemetry-collector"},
+		"schemaVersion": 38,
+		"editable":      true,
+		"refresh":       "30s",
+		"time":          map[string]string{"from": "now-1h", "to": "now"},
+		"templating": map[string]interface{}{
+			"list": []map[string]interface{}{{
+				"name":  "datasource",
+				"label": "Data source",
+				"type":  "datasource",
+				"query": "prometheus",
+			}},
+		},
+		"panels": models,
+	}
+	out, err := json.MarshalIndent(dashboard, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal the dashboard of the collector: %w", err)
+	}
+	return string(out), nil
+}
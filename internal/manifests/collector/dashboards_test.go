@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+)
+
+func dashboardsParams(mode v1alpha1.Mode, dashboards v1alpha1.DashboardsConfigSpec) manifests.Params {
+	return manifests.Params{
+		Config: config.New(),
+		Log:    logger,
+		OtelCol: v1alpha1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-instance",
+				Namespace: "observability",
+			},
+			Spec: v1alpha1.OpenTelemetryCollectorSpec{
+				Mode:   mode,
+				Config: debugConfig,
+				Observability: v1alpha1.ObservabilitySpec{
+					Dashboards: dashboards,
+				},
+			},
+		},
+	}
+}
+
+func TestDashboardsConfigMap(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		labels   map[string]string
+		expected map[string]string
+	}{
+		{
+			desc:     "default discovery label",
+			expected: map[string]string{"grafana_dashboard": "1"},
+		},
+		{
+			desc:     "custom discovery labels",
+			labels:   map[string]string{"dashboards": "observability"},
+			expected: map[string]string{"dashboards": "observability"},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			params := dashboardsParams(v1alpha1.ModeDeployment, v1alpha1.DashboardsConfigSpec{Enabled: true, Labels: tt.labels})
+
+			// test
+			cm, err := DashboardsConfigMap(params)
+
+			// verify
+			require.NoError(t, err)
+			assert.Equal(t, "my-instance-collector-dashboards", cm.Name)
+			assert.Subset(t, cm.Labels, tt.expected)
+			assert.Equal(t, "opentelemetry-operator", cm.Labels["app.kubernetes.io/managed-by"])
+			require.Contains(t, cm.Data, "observability-my-instance-collector-health.json")
+
+			dashboard := map[string]interface{}{}
+			require.NoError(t, json.Unmarshal([]byte(cm.Data["observability-my-instance-collector-health.json"]), &dashboard))
+			assert.Len(t, dashboard["uid"], 40)
+			assert.Equal(t, "OpenTelemetry Collector / observability / my-instance", dashboard["title"])
+			panels := dashboard["panels"].([]interface{})
+			require.NotEmpty(t, panels)
+			for _, panel := range panels {
+				for _, target := range panel.(map[string]interface{})["targets"].([]interface{}) {
+					expr := target.(map[string]interface{})["expr"].(string)
+					assert.True(t, strings.Contains(expr, `job="my-instance-collector-monitoring", namespace="observability"`), expr)
+				}
+			}
+		})
+	}
+}
+
+func TestDashboardsUniquePerInstance(t *testing.T) {
+	// prepare
+	first, err := collectorDashboard("team-a", "gateway", "gateway-collector-monitoring")
+	require.NoError(t, err)
+	second, err := collectorDashboard("team-b", "gateway", "gateway-collector-monitoring")
+	require.NoError(t, err)
+	uid := func(dashboard string) interface{} {
+		model := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal([]byte(dashboard), &model))
+		return model["uid"]
+	}
+
+	// test
+	again, err := collectorDashboard("team-a", "gateway", "gateway-collector-monitoring")
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, first, again)
+	assert.NotEqual(t, uid(first), uid(second))
+}
+
+func TestBuildDashboards(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		mode     v1alpha1.Mode
+		enabled  bool
+		expected bool
+	}{
+		{desc: "enabled", mode: v1alpha1.ModeDeployment, enabled: true, expected: true},
+		{desc: "disabled", mode: v1alpha1.ModeDeployment},
+		{desc: "sidecar", mode: v1alpha1.ModeSidecar, enabled: true},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			params := dashboardsParams(tt.mode, v1alpha1.DashboardsConfigSpec{Enabled: tt.enabled})
+
+			// test
+			objects, err := Build(params)
+
+			// verify
+			require.NoError(t, err)
+			found := false
+			for _, obj := range objects {
+				if _, ok := obj.(*corev1.ConfigMap); ok && obj.GetName() == "my-instance-collector-dashboards" {
+					found = true
+				}
+			}
+			assert.Equal(t, tt.expected, found)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+// namespaceNameLabel is set by the API server on every namespace to the name of the namespace.
+const namespaceNameLabel = "kubernetes.io/metadata.name"
+
+// DebugService builds the service exposing the endpoints of the pprof and remote_tap extensions of the collector.
+func DebugService(params manifests.Params) *corev1.Service {
+	ports := debugPorts(params)
+	if len(ports) == 0 {
+		return nil
+	}
+
+	name := naming.DebugService(&params.OtelCol)
+	labels := manifestutils.Merge(params.OtelCol.Spec.ServiceLabels, manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, []string{}, params.OtelCol.Spec.StandardLabels))
+
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   params.OtelCol.Namespace,
+			Labels:      labels,
+			Annotations: manifestutils.Merge(params.OtelCol.Annotations, params.OtelCol.Spec.DebugEndpoints.ServiceAnnotations),
+		},
+		Spec: corev1.ServiceSpec{
+			Selector:       manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollector, params.OtelCol.Spec.StandardLabels),
+			ClusterIP:      "",
+			Ports:          ports,
+			IPFamilies:     params.OtelCol.Spec.IPFamilies,
+			IPFamilyPolicy: params.OtelCol.Spec.IPFamilyPolicy,
+		},
+	}
+}
+
+// DebugNetworkPolicy builds the network policy letting only the pods of the admin namespace reach the endpoints of the
+// pprof and remote_tap extensions of the collector.
+func DebugNetworkPolicy(params manifests.Params) *networkingv1.NetworkPolicy {
+	ports := debugPorts(params)
+	if len(ports) == 0 {
+		return nil
+	}
+
+	name := naming.DebugNetworkPolicy(&params.OtelCol)
+	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, []string{}, params.OtelCol.Spec.StandardLabels)
+
+	policyPorts := make([]networkingv1.NetworkPolicyPort, 0, len(ports))
+	for _, p := range ports {
+		port := intstr.FromInt(int(p.Port))
+		protocol := corev1.ProtocolTCP
+		policyPorts = append(policyPorts, networkingv1.NetworkPolicyPort{Protocol: &protocol, Port: &port})
+	}
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   params.OtelCol.Namespace,
+			Labels:      labels,
+			Annotations: params.OtelCol.Annotations,
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollector, params.OtelCol.Spec.StandardLabels),
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{{
+				Ports: policyPorts,
+				From: []networkingv1.NetworkPolicyPeer{{
+					NamespaceSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{namespaceNameLabel: params.OtelCol.Spec.DebugEndpoints.AdminNamespace},
+					},
+				}},
+			}},
+		},
+	}
+}
+
+// debugPorts returns the ports of the debugging extensions to expose, if the instance asks for them.
+func debugPorts(params manifests.Params) []corev1.ServicePort {
+	debug := params.OtelCol.Spec.DebugEndpoints
+	if debug == nil || !debug.Enabled || debug.AdminNamespace == "" {
+		return nil
+	}
+
+	config, err := adapters.ConfigFromString(params.OtelCol.Spec.Config)
+	if err != nil {
+		params.Log.Error(err, "couldn't extract the configuration")
+		return nil
+	}
+
+	var ports []corev1.ServicePort
+	for _, endpoint := range adapters.ConfigToDebugEndpoints(params.Log, config) {
+		ports = append(ports, endpoint.ServicePort())
+	}
+	if len(ports) == 0 {
+		params.Log.V(1).Info("the instance's configuration doesn't enable any debugging extension, skipping the debug service", "instance.name", params.OtelCol.Name, "instance.namespace", params.OtelCol.Namespace)
+	}
+	return ports
+}
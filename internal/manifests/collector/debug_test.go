@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+)
+
+const debugConfig = `receivers:
+  otlp:
+    protocols:
+      grpc:
+exporters:
+  debug:
+extensions:
+  pprof:
+    endpoint: 0.0.0.0:1777
+  remote_tap:
+    endpoint: 0.0.0.0:11000
+service:
+  extensions: [pprof, remote_tap]
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [debug]
+`
+
+func debugParams(debug *v1alpha1.DebugEndpointsSpec) manifests.Params {
+	return manifests.Params{
+		Config: config.New(),
+		Log:    logger,
+		OtelCol: v1alpha1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "my-instance",
+				Namespace:   "observability",
+				Annotations: map[string]string{"owner": "team"},
+			},
+			Spec: v1alpha1.OpenTelemetryCollectorSpec{
+				Mode:           v1alpha1.ModeDeployment,
+				Config:         debugConfig,
+				DebugEndpoints: debug,
+			},
+		},
+	}
+}
+
+func TestDebugService(t *testing.T) {
+	t.Run("should expose the debugging extensions", func(t *testing.T) {
+		// prepare
+		params := debugParams(&v1alpha1.DebugEndpointsSpec{
+			Enabled:            true,
+			AdminNamespace:     "admin",
+			ServiceAnnotations: map[string]string{"auth.example.com/required": "true"},
+		})
+
+		// test
+		svc := DebugService(params)
+
+		// verify
+		require.NotNil(t, svc)
+		assert.Equal(t, "my-instance-collector-debug", svc.Name)
+		assert.Equal(t, map[string]string{"owner": "team", "auth.example.com/required": "true"}, svc.Annotations)
+		assert.Equal(t, []corev1.ServicePort{
+			{Name: "pprof", Port: 1777},
+			{Name: "remote-tap", Port: 11000},
+		}, svc.Spec.Ports)
+		assert.Equal(t, "opentelemetry-collector", svc.Spec.Selector["app.kubernetes.io/component"])
+	})
+
+	t.Run("should not be created when disabled", func(t *testing.T) {
+		assert.Nil(t, DebugService(debugParams(nil)))
+		assert.Nil(t, DebugService(debugParams(&v1alpha1.DebugEndpointsSpec{AdminNamespace: "admin"})))
+	})
+
+	t.Run("should not be created without debugging extensions", func(t *testing.T) {
+		// prepare
+		params := debugParams(&v1alpha1.DebugEndpointsSpec{Enabled: true, AdminNamespace: "admin"})
+		params.OtelCol.Spec.Config = `receivers:
+  otlp:
+    protocols:
+      grpc:
+exporters:
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [debug]
+`
+
+		// test
+		svc := DebugService(params)
+
+		// verify
+		assert.Nil(t, svc)
+	})
+}
+
+func TestDebugNetworkPolicy(t *testing.T) {
+	t.Run("should only allow the admin namespace", func(t *testing.T) {
+		// prepare
+		params := debugParams(&v1alpha1.DebugEndpointsSpec{Enabled: true, AdminNamespace: "admin"})
+		tcp := corev1.ProtocolTCP
+		pprof, remoteTap := intstr.FromInt(1777), intstr.FromInt(11000)
+
+		// test
+		np := DebugNetworkPolicy(params)
+
+		// verify
+		require.NotNil(t, np)
+		assert.Equal(t, "my-instance-collector-debug", np.Name)
+		assert.Equal(t, "observability", np.Namespace)
+		assert.Equal(t, "opentelemetry-collector", np.Spec.PodSelector.MatchLabels["app.kubernetes.io/component"])
+		assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}, np.Spec.PolicyTypes)
+		assert.Equal(t, []networkingv1.NetworkPolicyIngressRule{{
+			Ports: []networkingv1.NetworkPolicyPort{
+				{Protocol: &tcp, Port: &pprof},
+				{Protocol: &tcp, Port: &remoteTap},
+			},
+			From: []networkingv1.NetworkPolicyPeer{{
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"kubernetes.io/metadata.name": "admin"},
+				},
+			}},
+		}}, np.Spec.Ingress)
+	})
+
+	t.Run("should not be created when disabled", func(t *testing.T) {
+		assert.Nil(t, DebugNetworkPolicy(debugParams(nil)))
+	})
+}
+
+func TestBuildDebugEndpoints(t *testing.T) {
+	// prepare
+	params := debugParams(&v1alpha1.DebugEndpointsSpec{Enabled: true, AdminNamespace: "admin"})
+
+	// test
+	objects, err := Build(params)
+
+	// verify
+	require.NoError(t, err)
+	var services, policies int
+	for _, obj := range objects {
+		switch obj.(type) {
+		case *corev1.Service:
+			if obj.GetName() == "my-instance-collector-debug" {
+				services++
+			}
+		case *networkingv1.NetworkPolicy:
+			policies++
+		}
+	}
+	assert.Equal(t, 1, services)
+	assert.Equal(t, 1, policies)
+}
@@ -26,11 +26,12 @@ import (
 
 // Deployment builds the deployment for the given instance.
 func Deployment(params manifests.Params) *appsv1.Deployment {
-	name := naming.Collector(params.OtelCol.Name)
-	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter())
+	name := naming.Collector(&params.OtelCol)
+	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter(), params.OtelCol.Spec.StandardLabels)
 
-	annotations := Annotations(params.OtelCol)
-	podAnnotations := PodAnnotations(params.OtelCol)
+	annotations := WorkloadAnnotations(params.OtelCol, params.Config.AnnotationsFilter())
+	podAnnotations := PodAnnotations(params.OtelCol, params.Config.AnnotationsFilter())
+	addServiceMeshAnnotations(params, podAnnotations)
 
 	return &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
@@ -42,7 +43,7 @@ func Deployment(params manifests.Params) *appsv1.Deployment {
 		Spec: appsv1.DeploymentSpec{
 			Replicas: params.OtelCol.Spec.Replicas,
 			Selector: &metav1.LabelSelector{
-				MatchLabels: manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollector),
+				MatchLabels: manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollector, params.OtelCol.Spec.StandardLabels),
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
@@ -51,17 +52,17 @@ func Deployment(params manifests.Params) *appsv1.Deployment {
 				},
 				Spec: corev1.PodSpec{
 					ServiceAccountName:            ServiceAccountName(params.OtelCol),
-					InitContainers:                params.OtelCol.Spec.InitContainers,
-					Containers:                    append(params.OtelCol.Spec.AdditionalContainers, Container(params.Config, params.Log, params.OtelCol, true)),
+					InitContainers:                InitContainers(params),
+					Containers:                    Containers(params),
 					Volumes:                       Volumes(params.Config, params.OtelCol),
 					DNSPolicy:                     getDNSPolicy(params.OtelCol),
 					HostNetwork:                   params.OtelCol.Spec.HostNetwork,
-					Tolerations:                   params.OtelCol.Spec.Tolerations,
-					NodeSelector:                  params.OtelCol.Spec.NodeSelector,
+					Tolerations:                   manifestutils.Tolerations(params.Config, params.OtelCol.Spec.Tolerations),
+					NodeSelector:                  manifestutils.NodeSelector(params.Config, params.OtelCol.Spec.NodeSelector),
 					SecurityContext:               params.OtelCol.Spec.PodSecurityContext,
 					PriorityClassName:             params.OtelCol.Spec.PriorityClassName,
-					Affinity:                      params.OtelCol.Spec.Affinity,
-					TerminationGracePeriodSeconds: params.OtelCol.Spec.TerminationGracePeriodSeconds,
+					Affinity:                      affinity(params.OtelCol),
+					TerminationGracePeriodSeconds: terminationGracePeriodSeconds(params.OtelCol),
 					TopologySpreadConstraints:     params.OtelCol.Spec.TopologySpreadConstraints,
 				},
 			},
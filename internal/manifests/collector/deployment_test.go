@@ -322,6 +322,37 @@ func TestDeploymentNodeSelector(t *testing.T) {
 	assert.Equal(t, d2.Spec.Template.Spec.NodeSelector, map[string]string{"node-key": "node-value"})
 }
 
+func TestDeploymentDefaultScheduling(t *testing.T) {
+	cfg := config.New(
+		config.WithDefaultTolerations(testTolerationValues),
+		config.WithDefaultNodeSelector(map[string]string{"pool": "telemetry"}),
+	)
+
+	// the pods of the collectors setting neither get the defaults of the operator
+	d1 := Deployment(manifests.Params{
+		Config:  cfg,
+		OtelCol: v1alpha1.OpenTelemetryCollector{ObjectMeta: metav1.ObjectMeta{Name: "my-instance"}},
+		Log:     logger,
+	})
+	assert.Equal(t, testTolerationValues, d1.Spec.Template.Spec.Tolerations)
+	assert.Equal(t, map[string]string{"pool": "telemetry"}, d1.Spec.Template.Spec.NodeSelector)
+
+	// the ones of the custom resource take precedence
+	d2 := Deployment(manifests.Params{
+		Config: cfg,
+		OtelCol: v1alpha1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-instance"},
+			Spec: v1alpha1.OpenTelemetryCollectorSpec{
+				Tolerations:  []v1.Toleration{{Key: "other", Operator: v1.TolerationOpExists}},
+				NodeSelector: map[string]string{"pool": "other"},
+			},
+		},
+		Log: logger,
+	})
+	assert.Equal(t, []v1.Toleration{{Key: "other", Operator: v1.TolerationOpExists}}, d2.Spec.Template.Spec.Tolerations)
+	assert.Equal(t, map[string]string{"pool": "other"}, d2.Spec.Template.Spec.NodeSelector)
+}
+
 func TestDeploymentPriorityClassName(t *testing.T) {
 	otelcol1 := v1alpha1.OpenTelemetryCollector{
 		ObjectMeta: metav1.ObjectMeta{
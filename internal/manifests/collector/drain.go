@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"path"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+)
+
+const (
+	drainInitContainerName = "otc-drain-init"
+	drainVolume            = "otc-drain"
+	drainDir               = "/otel-drain"
+	drainSleepBinary       = "sleep"
+	// defaultTerminationGracePeriodSeconds is the grace period of Kubernetes, left to the collector to shut down once
+	// drained.
+	defaultTerminationGracePeriodSeconds = 30
+)
+
+func drainEnabled(otelcol v1alpha1.OpenTelemetryCollector) bool {
+	return otelcol.Spec.Drain != nil && otelcol.Spec.Drain.DelaySeconds > 0 && otelcol.Spec.Mode != v1alpha1.ModeSidecar &&
+		(otelcol.Spec.Lifecycle == nil || otelcol.Spec.Lifecycle.PreStop == nil)
+}
+
+// lifecycle returns the lifecycle of the collector container, with a preStop hook sleeping for the drain delay.
+func lifecycle(otelcol v1alpha1.OpenTelemetryCollector) *corev1.Lifecycle {
+	if !drainEnabled(otelcol) {
+		return otelcol.Spec.Lifecycle
+	}
+	lc := &corev1.Lifecycle{}
+	if otelcol.Spec.Lifecycle != nil {
+		// copy to avoid modifying the lifecycle of the custom resource
+		lc = otelcol.Spec.Lifecycle.DeepCopy()
+	}
+	lc.PreStop = &corev1.LifecycleHandler{
+		Exec: &corev1.ExecAction{
+			Command: []string{path.Join(drainDir, drainSleepBinary), strconv.Itoa(int(otelcol.Spec.Drain.DelaySeconds))},
+		},
+	}
+	return lc
+}
+
+// terminationGracePeriodSeconds returns the termination grace period of the pods of the collector, which leaves the
+// default grace period to the collector to shut down after the drain delay, unless set in the spec.
+func terminationGracePeriodSeconds(otelcol v1alpha1.OpenTelemetryCollector) *int64 {
+	if otelcol.Spec.TerminationGracePeriodSeconds != nil || !drainEnabled(otelcol) {
+		return otelcol.Spec.TerminationGracePeriodSeconds
+	}
+	seconds := int64(otelcol.Spec.Drain.DelaySeconds) + defaultTerminationGracePeriodSeconds
+	return &seconds
+}
+
+// drainVolumes returns the volume the sleep binary of the preStop hook is copied to.
+func drainVolumes(otelcol v1alpha1.OpenTelemetryCollector) []corev1.Volume {
+	if !drainEnabled(otelcol) {
+		return nil
+	}
+	return []corev1.Volume{{
+		Name:         drainVolume,
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}}
+}
+
+// drainVolumeMounts returns the volume mount of the sleep binary of the preStop hook in the collector container.
+func drainVolumeMounts(otelcol v1alpha1.OpenTelemetryCollector) []corev1.VolumeMount {
+	if !drainEnabled(otelcol) {
+		return nil
+	}
+	return []corev1.VolumeMount{{Name: drainVolume, MountPath: drainDir, ReadOnly: true}}
+}
+
+// drainInitContainer returns the init container copying the sleep binary of the preStop hook into the pod. It shares
+// the security context of the collector, so that the collector can run the copied binary.
+func drainInitContainer(params manifests.Params) corev1.Container {
+	image := params.OtelCol.Spec.Drain.Image
+	if image == "" {
+		image = params.Config.DrainImage()
+	}
+	return corev1.Container{
+		Name:            drainInitContainerName,
+		Image:           image,
+		Command:         []string{"cp", "/bin/" + drainSleepBinary, path.Join(drainDir, drainSleepBinary)},
+		VolumeMounts:    []corev1.VolumeMount{{Name: drainVolume, MountPath: drainDir}},
+		Resources:       manifestutils.Resources(params.Config, corev1.ResourceRequirements{}),
+		SecurityContext: manifestutils.SecurityContext(params.Config, params.OtelCol.Spec.SecurityContext),
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func TestDrain(t *testing.T) {
+	// prepare
+	params := deploymentParams()
+	params.Config = config.New(config.WithDrainImage("busybox:test"))
+	params.OtelCol.Spec.Drain = &v1alpha1.DrainSpec{DelaySeconds: 15}
+
+	// test
+	d := Deployment(params)
+
+	// verify
+	podSpec := d.Spec.Template.Spec
+	require.Len(t, podSpec.InitContainers, 1)
+	assert.Equal(t, "otc-drain-init", podSpec.InitContainers[0].Name)
+	assert.Equal(t, "busybox:test", podSpec.InitContainers[0].Image)
+	assert.Equal(t, []string{"cp", "/bin/sleep", "/otel-drain/sleep"}, podSpec.InitContainers[0].Command)
+
+	c := podSpec.Containers[len(podSpec.Containers)-1]
+	require.NotNil(t, c.Lifecycle)
+	assert.Equal(t, []string{"/otel-drain/sleep", "15"}, c.Lifecycle.PreStop.Exec.Command)
+	assert.Contains(t, c.VolumeMounts, corev1.VolumeMount{Name: "otc-drain", MountPath: "/otel-drain", ReadOnly: true})
+	assert.Contains(t, podSpec.Volumes, corev1.Volume{Name: "otc-drain", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}})
+	assert.EqualValues(t, 45, *podSpec.TerminationGracePeriodSeconds)
+}
+
+func TestDrainSettings(t *testing.T) {
+	gracePeriod := int64(60)
+	postStart := &corev1.LifecycleHandler{Exec: &corev1.ExecAction{Command: []string{"/start"}}}
+	preStop := &corev1.LifecycleHandler{Exec: &corev1.ExecAction{Command: []string{"/stop"}}}
+	for _, tt := range []struct {
+		desc                string
+		spec                v1alpha1.OpenTelemetryCollectorSpec
+		expectedPreStop     []string
+		expectedGracePeriod *int64
+	}{
+		{
+			desc: "no drain",
+			spec: v1alpha1.OpenTelemetryCollectorSpec{},
+		},
+		{
+			desc: "the grace period of the spec is kept",
+			spec: v1alpha1.OpenTelemetryCollectorSpec{
+				Drain:                         &v1alpha1.DrainSpec{DelaySeconds: 15},
+				TerminationGracePeriodSeconds: &gracePeriod,
+			},
+			expectedPreStop:     []string{"/otel-drain/sleep", "15"},
+			expectedGracePeriod: &gracePeriod,
+		},
+		{
+			desc: "the preStop hook of the spec takes precedence",
+			spec: v1alpha1.OpenTelemetryCollectorSpec{
+				Drain:     &v1alpha1.DrainSpec{DelaySeconds: 15},
+				Lifecycle: &corev1.Lifecycle{PreStop: preStop},
+			},
+			expectedPreStop: []string{"/stop"},
+		},
+		{
+			desc: "sidecars aren't drained",
+			spec: v1alpha1.OpenTelemetryCollectorSpec{
+				Mode:  v1alpha1.ModeSidecar,
+				Drain: &v1alpha1.DrainSpec{DelaySeconds: 15},
+			},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			otelcol := v1alpha1.OpenTelemetryCollector{Spec: tt.spec}
+
+			// test
+			lc := lifecycle(otelcol)
+			gracePeriod := terminationGracePeriodSeconds(otelcol)
+
+			// verify
+			if tt.expectedPreStop == nil {
+				assert.True(t, lc == nil || lc.PreStop == nil)
+			} else {
+				assert.Equal(t, tt.expectedPreStop, lc.PreStop.Exec.Command)
+			}
+			assert.Equal(t, tt.expectedGracePeriod, gracePeriod)
+		})
+	}
+
+	t.Run("the other hooks of the spec are kept", func(t *testing.T) {
+		// prepare
+		otelcol := v1alpha1.OpenTelemetryCollector{Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Drain:     &v1alpha1.DrainSpec{DelaySeconds: 15},
+			Lifecycle: &corev1.Lifecycle{PostStart: postStart},
+		}}
+
+		// test
+		lc := lifecycle(otelcol)
+
+		// verify
+		assert.Equal(t, postStart, lc.PostStart)
+		assert.NotNil(t, lc.PreStop)
+		assert.Nil(t, otelcol.Spec.Lifecycle.PreStop, "the custom resource should be left untouched")
+	})
+}
@@ -27,13 +27,13 @@ import (
 )
 
 func HorizontalPodAutoscaler(params manifests.Params) client.Object {
-	name := naming.Collector(params.OtelCol.Name)
-	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter())
-	annotations := Annotations(params.OtelCol)
+	name := naming.Collector(&params.OtelCol)
+	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter(), params.OtelCol.Spec.StandardLabels)
+	annotations := Annotations(params.OtelCol, params.Config.AnnotationsFilter())
 	var result client.Object
 
 	objectMeta := metav1.ObjectMeta{
-		Name:        naming.HorizontalPodAutoscaler(params.OtelCol.Name),
+		Name:        naming.HorizontalPodAutoscaler(&params.OtelCol),
 		Namespace:   params.OtelCol.Namespace,
 		Labels:      labels,
 		Annotations: annotations,
@@ -17,7 +17,6 @@ package collector
 import (
 	"fmt"
 
-	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -33,7 +32,7 @@ func Ingress(params manifests.Params) *networkingv1.Ingress {
 		return nil
 	}
 
-	ports := servicePortsFromCfg(params.Log, params.OtelCol)
+	ports := servicePortsFromCfg(params)
 
 	// if we have no ports, we don't need a ingress entry
 	if len(ports) == 0 {
@@ -48,9 +47,9 @@ func Ingress(params manifests.Params) *networkingv1.Ingress {
 	var rules []networkingv1.IngressRule
 	switch params.OtelCol.Spec.Ingress.RuleType {
 	case v1alpha1.IngressRuleTypePath, "":
-		rules = []networkingv1.IngressRule{createPathIngressRules(params.OtelCol.Name, params.OtelCol.Spec.Ingress.Hostname, ports)}
+		rules = []networkingv1.IngressRule{createPathIngressRules(&params.OtelCol, params.OtelCol.Spec.Ingress.Hostname, ports)}
 	case v1alpha1.IngressRuleTypeSubdomain:
-		rules = createSubdomainIngressRules(params.OtelCol.Name, params.OtelCol.Spec.Ingress.Hostname, ports)
+		rules = createSubdomainIngressRules(&params.OtelCol, params.OtelCol.Spec.Ingress.Hostname, ports)
 	}
 
 	return &networkingv1.Ingress{
@@ -72,7 +71,7 @@ func Ingress(params manifests.Params) *networkingv1.Ingress {
 	}
 }
 
-func createPathIngressRules(otelcol string, hostname string, ports []corev1.ServicePort) networkingv1.IngressRule {
+func createPathIngressRules(otelcol naming.Instance, hostname string, ports []corev1.ServicePort) networkingv1.IngressRule {
 	pathType := networkingv1.PathTypePrefix
 	paths := make([]networkingv1.HTTPIngressPath, len(ports))
 	for i, port := range ports {
@@ -100,7 +99,7 @@ func createPathIngressRules(otelcol string, hostname string, ports []corev1.Serv
 	}
 }
 
-func createSubdomainIngressRules(otelcol string, hostname string, ports []corev1.ServicePort) []networkingv1.IngressRule {
+func createSubdomainIngressRules(otelcol naming.Instance, hostname string, ports []corev1.ServicePort) []networkingv1.IngressRule {
 	var rules []networkingv1.IngressRule
 	pathType := networkingv1.PathTypePrefix
 	for _, port := range ports {
@@ -137,19 +136,20 @@ func createSubdomainIngressRules(otelcol string, hostname string, ports []corev1
 }
 
 // TODO: Update this to properly return an error https://github.com/open-telemetry/opentelemetry-operator/issues/1972
-func servicePortsFromCfg(logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector) []corev1.ServicePort {
-	configFromString, err := adapters.ConfigFromString(otelcol.Spec.Config)
+func servicePortsFromCfg(params manifests.Params) []corev1.ServicePort {
+	configFromString, err := adapters.ConfigFromString(params.OtelCol.Spec.Config)
 	if err != nil {
-		logger.Error(err, "couldn't extract the configuration from the context")
+		params.Log.Error(err, "couldn't extract the configuration from the context")
 		return nil
 	}
 
-	ports, err := adapters.ConfigToReceiverPorts(logger, configFromString)
+	ports, err := adapters.ConfigToReceiverPorts(params.Log, configFromString)
 	if err != nil {
-		logger.Error(err, "couldn't build the ingress for this instance")
+		params.Log.Error(err, "couldn't build the ingress for this instance")
 	}
+	ports = meshPortNames(params, ports)
 
-	if len(otelcol.Spec.Ports) > 0 {
+	if len(params.OtelCol.Spec.Ports) > 0 {
 		// we should add all the ports from the CR
 		// there are two cases where problems might occur:
 		// 1) when the port number is already being used by a receiver
@@ -157,15 +157,16 @@ func servicePortsFromCfg(logger logr.Logger, otelcol v1alpha1.OpenTelemetryColle
 		//
 		// in the first case, we remove the port we inferred from the list
 		// in the second case, we rename our inferred port to something like "port-%d"
-		portNumbers, portNames := extractPortNumbersAndNames(otelcol.Spec.Ports)
+		specPorts := specServicePorts(params.OtelCol)
+		portNumbers, portNames := extractPortNumbersAndNames(specPorts)
 		var resultingInferredPorts []corev1.ServicePort
 		for _, inferred := range ports {
-			if filtered := filterPort(logger, inferred, portNumbers, portNames); filtered != nil {
+			if filtered := filterPort(params.Log, inferred, portNumbers, portNames); filtered != nil {
 				resultingInferredPorts = append(resultingInferredPorts, *filtered)
 			}
 		}
 
-		ports = append(otelcol.Spec.Ports, resultingInferredPorts...)
+		ports = append(exposedServicePorts(params.OtelCol), resultingInferredPorts...)
 	}
 	return ports
 }
@@ -108,6 +108,33 @@ var (
 	}
 )
 
+// transportProtocol returns the protocol the receiver listens on: UDP for the udplog receiver, the syslog receiver
+// listening on UDP and the receivers whose transport is UDP, e.g. carbon and statsd, and the given default otherwise.
+func transportProtocol(name string, config map[interface{}]interface{}, defaultProtocol corev1.Protocol) corev1.Protocol {
+	switch receiverType(name) {
+	case "udplog":
+		return corev1.ProtocolUDP
+	case "tcplog":
+		return corev1.ProtocolTCP
+	case "syslog":
+		if config["udp"] != nil {
+			return corev1.ProtocolUDP
+		}
+		if config["tcp"] != nil {
+			return corev1.ProtocolTCP
+		}
+	}
+	if transport, ok := config["transport"].(string); ok {
+		switch {
+		case strings.HasPrefix(transport, "udp"):
+			return corev1.ProtocolUDP
+		case strings.HasPrefix(transport, "tcp"):
+			return corev1.ProtocolTCP
+		}
+	}
+	return defaultProtocol
+}
+
 func isScraperReceiver(name string) bool {
 	_, exists := scraperReceivers[name]
 	return exists
@@ -120,7 +147,7 @@ func singlePortFromConfigEndpoint(logger logr.Logger, name string, config map[in
 	// that needs to be exposed one level down inside config
 	// i.e. either in tcp or udp section with field key
 	// as `listen_address`
-	case name == "syslog":
+	case receiverType(name) == "syslog":
 		var c map[interface{}]interface{}
 		if udp, isUDP := config["udp"]; isUDP && udp != nil {
 			c = udp.(map[interface{}]interface{})
@@ -132,7 +159,7 @@ func singlePortFromConfigEndpoint(logger logr.Logger, name string, config map[in
 
 	// tcplog and udplog receivers hold the endpoint
 	// value in `listen_address` field
-	case name == "tcplog" || name == "udplog":
+	case receiverType(name) == "tcplog" || receiverType(name) == "udplog":
 		endpoint = getAddressFromConfig(logger, name, listenAddressKey, config)
 
 	// ignore the receiver as it holds the field key endpoint, and it
@@ -20,12 +20,14 @@ const parserNameCollectd = "__collectd"
 
 // NewCollectdReceiverParser builds a new parser for Collectd receivers, from the contrib repository.
 func NewCollectdReceiverParser(logger logr.Logger, name string, config map[interface{}]interface{}) ReceiverParser {
+	http := "http"
 	return &GenericReceiver{
-		logger:      logger,
-		name:        name,
-		config:      config,
-		defaultPort: 8081,
-		parserName:  parserNameCollectd,
+		logger:             logger,
+		name:               name,
+		config:             config,
+		defaultPort:        8081,
+		defaultAppProtocol: &http,
+		parserName:         parserNameCollectd,
 	}
 }
 
@@ -51,9 +51,10 @@ func NewGenericReceiverParser(logger logr.Logger, name string, config map[interf
 
 // Ports returns all the service ports for all protocols in this parser.
 func (g *GenericReceiver) Ports() ([]corev1.ServicePort, error) {
+	protocol := transportProtocol(g.name, g.config, g.defaultProtocol)
 	port := singlePortFromConfigEndpoint(g.logger, g.name, g.config)
 	if port != nil {
-		port.Protocol = g.defaultProtocol
+		port.Protocol = protocol
 		port.AppProtocol = g.defaultAppProtocol
 		return []corev1.ServicePort{*port}, nil
 	}
@@ -62,7 +63,7 @@ func (g *GenericReceiver) Ports() ([]corev1.ServicePort, error) {
 		return []corev1.ServicePort{{
 			Port:        g.defaultPort,
 			Name:        naming.PortName(g.name, g.defaultPort),
-			Protocol:    g.defaultProtocol,
+			Protocol:    protocol,
 			AppProtocol: g.defaultAppProtocol,
 		}}, nil
 	}
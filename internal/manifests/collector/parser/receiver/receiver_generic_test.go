@@ -19,6 +19,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/parser/receiver"
@@ -57,6 +58,62 @@ func TestFailedToParseEndpoint(t *testing.T) {
 	assert.Len(t, ports, 0)
 }
 
+func TestTransportProtocol(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		name     string
+		config   map[interface{}]interface{}
+		expected corev1.Protocol
+	}{
+		{
+			desc:     "udplog",
+			name:     "udplog/app",
+			config:   map[interface{}]interface{}{"listen_address": "0.0.0.0:54525"},
+			expected: corev1.ProtocolUDP,
+		},
+		{
+			desc:     "syslog over udp",
+			name:     "syslog",
+			config:   map[interface{}]interface{}{"udp": map[interface{}]interface{}{"listen_address": "0.0.0.0:54526"}},
+			expected: corev1.ProtocolUDP,
+		},
+		{
+			desc:     "syslog over tcp",
+			name:     "syslog/tcp",
+			config:   map[interface{}]interface{}{"tcp": map[interface{}]interface{}{"listen_address": "0.0.0.0:54527"}},
+			expected: corev1.ProtocolTCP,
+		},
+		{
+			desc:     "carbon over udp",
+			name:     "carbon",
+			config:   map[interface{}]interface{}{"endpoint": "0.0.0.0:2003", "transport": "udp"},
+			expected: corev1.ProtocolUDP,
+		},
+		{
+			desc:     "statsd over tcp",
+			name:     "statsd",
+			config:   map[interface{}]interface{}{"endpoint": "0.0.0.0:8125", "transport": "tcp"},
+			expected: corev1.ProtocolTCP,
+		},
+		{
+			desc:     "statsd by default",
+			name:     "statsd",
+			config:   map[interface{}]interface{}{},
+			expected: corev1.ProtocolUDP,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// test
+			ports, err := receiver.For(logger, tt.name, tt.config).Ports()
+
+			// verify
+			assert.NoError(t, err)
+			assert.Len(t, ports, 1)
+			assert.Equal(t, tt.expected, ports[0].Protocol)
+		})
+	}
+}
+
 func TestDownstreamParsers(t *testing.T) {
 	for _, tt := range []struct {
 		builder      func(logr.Logger, string, map[interface{}]interface{}) receiver.ReceiverParser
@@ -64,22 +121,23 @@ func TestDownstreamParsers(t *testing.T) {
 		receiverName string
 		parserName   string
 		defaultPort  int
+		appProtocol  string
 	}{
-		{receiver.NewZipkinReceiverParser, "zipkin", "zipkin", "__zipkin", 9411},
-		{receiver.NewOpenCensusReceiverParser, "opencensus", "opencensus", "__opencensus", 55678},
+		{receiver.NewZipkinReceiverParser, "zipkin", "zipkin", "__zipkin", 9411, "http"},
+		{receiver.NewOpenCensusReceiverParser, "opencensus", "opencensus", "__opencensus", 55678, "grpc"},
 
 		// contrib receivers
-		{receiver.NewCarbonReceiverParser, "carbon", "carbon", "__carbon", 2003},
-		{receiver.NewCollectdReceiverParser, "collectd", "collectd", "__collectd", 8081},
-		{receiver.NewSAPMReceiverParser, "sapm", "sapm", "__sapm", 7276},
-		{receiver.NewSignalFxReceiverParser, "signalfx", "signalfx", "__signalfx", 9943},
-		{receiver.NewWavefrontReceiverParser, "wavefront", "wavefront", "__wavefront", 2003},
-		{receiver.NewZipkinScribeReceiverParser, "zipkin-scribe", "zipkin-scribe", "__zipkinscribe", 9410},
-		{receiver.NewFluentForwardReceiverParser, "fluentforward", "fluentforward", "__fluentforward", 8006},
-		{receiver.NewStatsdReceiverParser, "statsd", "statsd", "__statsd", 8125},
-		{receiver.NewInfluxdbReceiverParser, "influxdb", "influxdb", "__influxdb", 8086},
-		{receiver.NewSplunkHecReceiverParser, "splunk-hec", "splunk-hec", "__splunk_hec", 8088},
-		{receiver.NewAWSXrayReceiverParser, "awsxray", "awsxray", "__awsxray", 2000},
+		{receiver.NewCarbonReceiverParser, "carbon", "carbon", "__carbon", 2003, ""},
+		{receiver.NewCollectdReceiverParser, "collectd", "collectd", "__collectd", 8081, "http"},
+		{receiver.NewSAPMReceiverParser, "sapm", "sapm", "__sapm", 7276, "http"},
+		{receiver.NewSignalFxReceiverParser, "signalfx", "signalfx", "__signalfx", 9943, "http"},
+		{receiver.NewWavefrontReceiverParser, "wavefront", "wavefront", "__wavefront", 2003, ""},
+		{receiver.NewZipkinScribeReceiverParser, "zipkin-scribe", "zipkin-scribe", "__zipkinscribe", 9410, ""},
+		{receiver.NewFluentForwardReceiverParser, "fluentforward", "fluentforward", "__fluentforward", 8006, ""},
+		{receiver.NewStatsdReceiverParser, "statsd", "statsd", "__statsd", 8125, ""},
+		{receiver.NewInfluxdbReceiverParser, "influxdb", "influxdb", "__influxdb", 8086, "http"},
+		{receiver.NewSplunkHecReceiverParser, "splunk-hec", "splunk-hec", "__splunk_hec", 8088, "http"},
+		{receiver.NewAWSXrayReceiverParser, "awsxray", "awsxray", "__awsxray", 2000, ""},
 	} {
 		t.Run(tt.receiverName, func(t *testing.T) {
 			t.Run("builds successfully", func(t *testing.T) {
@@ -102,6 +160,11 @@ func TestDownstreamParsers(t *testing.T) {
 				assert.Len(t, ports, 1)
 				assert.EqualValues(t, tt.defaultPort, ports[0].Port)
 				assert.Equal(t, tt.receiverName, ports[0].Name)
+				if tt.appProtocol == "" {
+					assert.Nil(t, ports[0].AppProtocol)
+				} else {
+					assert.Equal(t, tt.appProtocol, *ports[0].AppProtocol)
+				}
 			})
 
 			t.Run("allows port to be overridden", func(t *testing.T) {
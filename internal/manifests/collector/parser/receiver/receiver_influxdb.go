@@ -20,12 +20,14 @@ const parserNameInfluxdb = "__influxdb"
 
 // NewInfluxdbReceiverParser builds a new parser for Influxdb receivers, from the contrib repository.
 func NewInfluxdbReceiverParser(logger logr.Logger, name string, config map[interface{}]interface{}) ReceiverParser {
+	http := "http"
 	return &GenericReceiver{
-		logger:      logger,
-		name:        name,
-		config:      config,
-		defaultPort: 8086,
-		parserName:  parserNameInfluxdb,
+		logger:             logger,
+		name:               name,
+		config:             config,
+		defaultPort:        8086,
+		defaultAppProtocol: &http,
+		parserName:         parserNameInfluxdb,
 	}
 }
 
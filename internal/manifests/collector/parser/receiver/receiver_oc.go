@@ -20,12 +20,14 @@ const parserNameOpenCensus = "__opencensus"
 
 // NewOpenCensusReceiverParser builds a new parser for OpenCensus receivers.
 func NewOpenCensusReceiverParser(logger logr.Logger, name string, config map[interface{}]interface{}) ReceiverParser {
+	grpc := "grpc"
 	return &GenericReceiver{
-		logger:      logger,
-		name:        name,
-		config:      config,
-		defaultPort: 55678,
-		parserName:  parserNameOpenCensus,
+		logger:             logger,
+		name:               name,
+		config:             config,
+		defaultPort:        55678,
+		defaultAppProtocol: &grpc,
+		parserName:         parserNameOpenCensus,
 	}
 }
 
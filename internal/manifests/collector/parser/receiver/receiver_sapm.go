@@ -20,12 +20,14 @@ const parserNameSAPM = "__sapm"
 
 // NewSAPMReceiverParser builds a new parser for SAPM receivers, from the contrib repository.
 func NewSAPMReceiverParser(logger logr.Logger, name string, config map[interface{}]interface{}) ReceiverParser {
+	http := "http"
 	return &GenericReceiver{
-		logger:      logger,
-		name:        name,
-		config:      config,
-		defaultPort: 7276,
-		parserName:  parserNameSAPM,
+		logger:             logger,
+		name:               name,
+		config:             config,
+		defaultPort:        7276,
+		defaultAppProtocol: &http,
+		parserName:         parserNameSAPM,
 	}
 }
 
@@ -20,12 +20,14 @@ const parserNameSignalFx = "__signalfx"
 
 // NewSignalFxReceiverParser builds a new parser for SignalFx receivers, from the contrib repository.
 func NewSignalFxReceiverParser(logger logr.Logger, name string, config map[interface{}]interface{}) ReceiverParser {
+	http := "http"
 	return &GenericReceiver{
-		logger:      logger,
-		name:        name,
-		config:      config,
-		defaultPort: 9943,
-		parserName:  parserNameSignalFx,
+		logger:             logger,
+		name:               name,
+		config:             config,
+		defaultPort:        9943,
+		defaultAppProtocol: &http,
+		parserName:         parserNameSignalFx,
 	}
 }
 
@@ -20,12 +20,14 @@ const parserNameSplunkHec = "__splunk_hec"
 
 // NewSplunkHecReceiverParser builds a new parser for Splunk Hec receivers, from the contrib repository.
 func NewSplunkHecReceiverParser(logger logr.Logger, name string, config map[interface{}]interface{}) ReceiverParser {
+	http := "http"
 	return &GenericReceiver{
-		logger:      logger,
-		name:        name,
-		config:      config,
-		defaultPort: 8088,
-		parserName:  parserNameSplunkHec,
+		logger:             logger,
+		name:               name,
+		config:             config,
+		defaultPort:        8088,
+		defaultAppProtocol: &http,
+		parserName:         parserNameSplunkHec,
 	}
 }
 
@@ -31,12 +31,12 @@ func PodDisruptionBudget(params manifests.Params) client.Object {
 		return nil
 	}
 
-	name := naming.Collector(params.OtelCol.Name)
-	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter())
-	annotations := Annotations(params.OtelCol)
+	name := naming.Collector(&params.OtelCol)
+	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter(), params.OtelCol.Spec.StandardLabels)
+	annotations := Annotations(params.OtelCol, params.Config.AnnotationsFilter())
 
 	objectMeta := metav1.ObjectMeta{
-		Name:        naming.PodDisruptionBudget(params.OtelCol.Name),
+		Name:        naming.PodDisruptionBudget(&params.OtelCol),
 		Namespace:   params.OtelCol.Namespace,
 		Labels:      labels,
 		Annotations: annotations,
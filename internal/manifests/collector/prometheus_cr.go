@@ -0,0 +1,514 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+)
+
+var invalidLabelCharRE = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// PrometheusCRScrapeConfigs resolves the endpoints of the ServiceMonitors and PodMonitors into scrape configs of the
+// prometheus receiver, the same way the Prometheus Operator generates the configuration of Prometheus. The endpoints
+// reading credentials or certificates from secrets and config maps can't be resolved, as the collector doesn't mount
+// them: they are skipped, and reported in the returned error.
+func PrometheusCRScrapeConfigs(instance v1alpha1.OpenTelemetryCollector, serviceMonitors []*monitoringv1.ServiceMonitor, podMonitors []*monitoringv1.PodMonitor) ([]map[string]interface{}, error) {
+	var scrapeConfigs []map[string]interface{}
+	var errs []error
+
+	sort.Slice(serviceMonitors, func(i, j int) bool {
+		return monitorKey(serviceMonitors[i].ObjectMeta) < monitorKey(serviceMonitors[j].ObjectMeta)
+	})
+	for _, sm := range serviceMonitors {
+		for i := range sm.Spec.Endpoints {
+			cfg, err := serviceMonitorScrapeConfig(instance, sm, i)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			scrapeConfigs = append(scrapeConfigs, cfg)
+		}
+	}
+
+	sort.Slice(podMonitors, func(i, j int) bool {
+		return monitorKey(podMonitors[i].ObjectMeta) < monitorKey(podMonitors[j].ObjectMeta)
+	})
+	for _, pm := range podMonitors {
+		for i := range pm.Spec.PodMetricsEndpoints {
+			cfg, err := podMonitorScrapeConfig(instance, pm, i)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			scrapeConfigs = append(scrapeConfigs, cfg)
+		}
+	}
+
+	return scrapeConfigs, errors.Join(errs...)
+}
+
+// AddPrometheusCRScrapeConfigs appends the scrape configs resolved from the ServiceMonitors and PodMonitors to the
+// ones of the prometheus receiver.
+func AddPrometheusCRScrapeConfigs(cfg string, scrapeConfigs []map[string]interface{}) (string, error) {
+	config, err := adapters.ConfigFromString(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	receivers, ok := config["receivers"].(map[interface{}]interface{})
+	if !ok {
+		return "", errors.New("no receivers available as part of the configuration")
+	}
+	prometheus, ok := receivers["prometheus"].(map[interface{}]interface{})
+	if !ok {
+		if receivers["prometheus"] != nil {
+			return "", errors.New("the prometheus receiver isn't a map")
+		}
+		prometheus = map[interface{}]interface{}{}
+		receivers["prometheus"] = prometheus
+	}
+	promConfig, ok := prometheus["config"].(map[interface{}]interface{})
+	if !ok {
+		if prometheus["config"] != nil {
+			return "", errors.New("the prometheus receiver config isn't a map")
+		}
+		promConfig = map[interface{}]interface{}{}
+		prometheus["config"] = promConfig
+	}
+	existing, ok := promConfig["scrape_configs"].([]interface{})
+	if !ok && promConfig["scrape_configs"] != nil {
+		return "", errors.New("the prometheus receiver scrape_configs isn't a list")
+	}
+	for _, resolved := range scrapeConfigs {
+		existing = append(existing, resolved)
+	}
+	if existing == nil {
+		existing = []interface{}{}
+	}
+	promConfig["scrape_configs"] = existing
+
+	out, err := manifestutils.MarshalYAML(config)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func monitorKey(meta metav1.ObjectMeta) string {
+	return meta.Namespace + "/" + meta.Name
+}
+
+func serviceMonitorScrapeConfig(instance v1alpha1.OpenTelemetryCollector, sm *monitoringv1.ServiceMonitor, i int) (map[string]interface{}, error) {
+	ep := sm.Spec.Endpoints[i]
+	jobName := fmt.Sprintf("serviceMonitor/%s/%s/%d", sm.Namespace, sm.Name, i)
+	if ep.BearerTokenSecret != nil && ep.BearerTokenSecret.Name != "" {
+		return nil, unsupportedEndpointError(jobName, "bearerTokenSecret")
+	}
+	if ep.TLSConfig != nil {
+		if err := checkSafeTLSConfig(jobName, ep.TLSConfig.SafeTLSConfig); err != nil {
+			return nil, err
+		}
+	}
+	if err := checkAuth(jobName, ep.BasicAuth, ep.OAuth2, ep.Authorization); err != nil {
+		return nil, err
+	}
+
+	cfg := scrapeConfig(instance, jobName, sm.Namespace, "endpoints", sm.Spec.NamespaceSelector, scrapeEndpoint{
+		interval:        ep.Interval,
+		scrapeTimeout:   ep.ScrapeTimeout,
+		path:            ep.Path,
+		scheme:          ep.Scheme,
+		params:          ep.Params,
+		honorLabels:     ep.HonorLabels,
+		honorTimestamps: ep.HonorTimestamps,
+		proxyURL:        ep.ProxyURL,
+		followRedirects: ep.FollowRedirects,
+		enableHTTP2:     ep.EnableHttp2,
+	})
+	if ep.TLSConfig != nil {
+		tlsConfig := safeTLSConfig(ep.TLSConfig.SafeTLSConfig)
+		setIfNotEmpty(tlsConfig, "ca_file", ep.TLSConfig.CAFile)
+		setIfNotEmpty(tlsConfig, "cert_file", ep.TLSConfig.CertFile)
+		setIfNotEmpty(tlsConfig, "key_file", ep.TLSConfig.KeyFile)
+		cfg["tls_config"] = tlsConfig
+	}
+	if ep.BearerTokenFile != "" {
+		cfg["authorization"] = map[string]interface{}{"type": "Bearer", "credentials_file": ep.BearerTokenFile}
+	}
+
+	relabelings := selectorRelabelings("__meta_kubernetes_service_label", "__meta_kubernetes_service_labelpresent", sm.Spec.Selector)
+	if ep.FilterRunning == nil || *ep.FilterRunning {
+		relabelings = append(relabelings, map[string]interface{}{
+			"action":        "drop",
+			"source_labels": []string{"__meta_kubernetes_pod_phase"},
+			"regex":         "(Failed|Succeeded)",
+		})
+	}
+	switch {
+	case ep.Port != "":
+		relabelings = append(relabelings, keepRelabeling("__meta_kubernetes_endpoint_port_name", ep.Port))
+	case ep.TargetPort != nil:
+		relabelings = append(relabelings, targetPortRelabeling(*ep.TargetPort))
+	}
+	relabelings = append(relabelings,
+		copyRelabeling("__meta_kubernetes_namespace", "namespace"),
+		copyRelabeling("__meta_kubernetes_service_name", "service"),
+		copyRelabeling("__meta_kubernetes_pod_name", "pod"),
+		copyRelabeling("__meta_kubernetes_pod_container_name", "container"),
+	)
+	for _, label := range sm.Spec.TargetLabels {
+		relabelings = append(relabelings, copyRelabeling("__meta_kubernetes_service_label_"+sanitizeLabelName(label), sanitizeLabelName(label)))
+	}
+	for _, label := range sm.Spec.PodTargetLabels {
+		relabelings = append(relabelings, copyRelabeling("__meta_kubernetes_pod_label_"+sanitizeLabelName(label), sanitizeLabelName(label)))
+	}
+	relabelings = append(relabelings, copyRelabeling("__meta_kubernetes_service_name", "job"))
+	if sm.Spec.JobLabel != "" {
+		relabelings = append(relabelings, copyRelabeling("__meta_kubernetes_service_label_"+sanitizeLabelName(sm.Spec.JobLabel), "job"))
+	}
+	relabelings = append(relabelings, endpointRelabeling(ep.Port, ep.TargetPort))
+	cfg["relabel_configs"] = append(relabelings, relabelConfigs(ep.RelabelConfigs)...)
+	if len(ep.MetricRelabelConfigs) > 0 {
+		cfg["metric_relabel_configs"] = relabelConfigs(ep.MetricRelabelConfigs)
+	}
+	return escapeDollarSigns(cfg).(map[string]interface{}), nil
+}
+
+func podMonitorScrapeConfig(instance v1alpha1.OpenTelemetryCollector, pm *monitoringv1.PodMonitor, i int) (map[string]interface{}, error) {
+	ep := pm.Spec.PodMetricsEndpoints[i]
+	jobName := fmt.Sprintf("podMonitor/%s/%s/%d", pm.Namespace, pm.Name, i)
+	if ep.BearerTokenSecret.Name != "" {
+		return nil, unsupportedEndpointError(jobName, "bearerTokenSecret")
+	}
+	if ep.TLSConfig != nil {
+		if err := checkSafeTLSConfig(jobName, ep.TLSConfig.SafeTLSConfig); err != nil {
+			return nil, err
+		}
+	}
+	if err := checkAuth(jobName, ep.BasicAuth, ep.OAuth2, ep.Authorization); err != nil {
+		return nil, err
+	}
+
+	cfg := scrapeConfig(instance, jobName, pm.Namespace, "pod", pm.Spec.NamespaceSelector, scrapeEndpoint{
+		interval:        ep.Interval,
+		scrapeTimeout:   ep.ScrapeTimeout,
+		path:            ep.Path,
+		scheme:          ep.Scheme,
+		params:          ep.Params,
+		honorLabels:     ep.HonorLabels,
+		honorTimestamps: ep.HonorTimestamps,
+		proxyURL:        ep.ProxyURL,
+		followRedirects: ep.FollowRedirects,
+		enableHTTP2:     ep.EnableHttp2,
+	})
+	if ep.TLSConfig != nil {
+		cfg["tls_config"] = safeTLSConfig(ep.TLSConfig.SafeTLSConfig)
+	}
+
+	relabelings := selectorRelabelings("__meta_kubernetes_pod_label", "__meta_kubernetes_pod_labelpresent", pm.Spec.Selector)
+	if ep.FilterRunning == nil || *ep.FilterRunning {
+		relabelings = append(relabelings, map[string]interface{}{
+			"action":        "drop",
+			"source_labels": []string{"__meta_kubernetes_pod_phase"},
+			"regex":         "(Failed|Succeeded)",
+		})
+	}
+	switch {
+	case ep.Port != "":
+		relabelings = append(relabelings, keepRelabeling("__meta_kubernetes_pod_container_port_name", ep.Port))
+	case ep.TargetPort != nil:
+		relabelings = append(relabelings, targetPortRelabeling(*ep.TargetPort))
+	}
+	relabelings = append(relabelings,
+		copyRelabeling("__meta_kubernetes_namespace", "namespace"),
+		copyRelabeling("__meta_kubernetes_pod_container_name", "container"),
+		copyRelabeling("__meta_kubernetes_pod_name", "pod"),
+	)
+	for _, label := range pm.Spec.PodTargetLabels {
+		relabelings = append(relabelings, copyRelabeling("__meta_kubernetes_pod_label_"+sanitizeLabelName(label), sanitizeLabelName(label)))
+	}
+	relabelings = append(relabelings, map[string]interface{}{
+		"target_label": "job",
+		"replacement":  pm.Namespace + "/" + pm.Name,
+	})
+	if pm.Spec.JobLabel != "" {
+		relabelings = append(relabelings, copyRelabeling("__meta_kubernetes_pod_label_"+sanitizeLabelName(pm.Spec.JobLabel), "job"))
+	}
+	relabelings = append(relabelings, endpointRelabeling(ep.Port, ep.TargetPort))
+	cfg["relabel_configs"] = append(relabelings, relabelConfigs(ep.RelabelConfigs)...)
+	if len(ep.MetricRelabelConfigs) > 0 {
+		cfg["metric_relabel_configs"] = relabelConfigs(ep.MetricRelabelConfigs)
+	}
+	return escapeDollarSigns(cfg).(map[string]interface{}), nil
+}
+
+// scrapeEndpoint holds the settings shared by the endpoints of the ServiceMonitors and PodMonitors.
+type scrapeEndpoint struct {
+	interval        monitoringv1.Duration
+	scrapeTimeout   monitoringv1.Duration
+	path            string
+	scheme          string
+	params          map[string][]string
+	honorLabels     bool
+	honorTimestamps *bool
+	proxyURL        *string
+	followRedirects *bool
+	enableHTTP2     *bool
+}
+
+func scrapeConfig(instance v1alpha1.OpenTelemetryCollector, jobName, namespace, role string, namespaceSelector monitoringv1.NamespaceSelector, ep scrapeEndpoint) map[string]interface{} {
+	sdConfig := map[string]interface{}{"role": role}
+	switch {
+	case namespaceSelector.Any:
+	case len(namespaceSelector.MatchNames) > 0:
+		sdConfig["namespaces"] = map[string]interface{}{"names": namespaceSelector.MatchNames}
+	default:
+		sdConfig["namespaces"] = map[string]interface{}{"names": []string{namespace}}
+	}
+
+	cfg := map[string]interface{}{
+		"job_name":              jobName,
+		"honor_labels":          ep.honorLabels,
+		"kubernetes_sd_configs": []interface{}{sdConfig},
+	}
+	if ep.interval != "" {
+		cfg["scrape_interval"] = string(ep.interval)
+	} else if instance.Spec.PrometheusCR.ScrapeInterval != nil {
+		cfg["scrape_interval"] = instance.Spec.PrometheusCR.ScrapeInterval.Duration.String()
+	}
+	if ep.scrapeTimeout != "" {
+		cfg["scrape_timeout"] = string(ep.scrapeTimeout)
+	}
+	if ep.honorTimestamps != nil {
+		cfg["honor_timestamps"] = *ep.honorTimestamps
+	}
+	setIfNotEmpty(cfg, "metrics_path", ep.path)
+	setIfNotEmpty(cfg, "scheme", ep.scheme)
+	if len(ep.params) > 0 {
+		cfg["params"] = ep.params
+	}
+	if ep.proxyURL != nil {
+		cfg["proxy_url"] = *ep.proxyURL
+	}
+	if ep.followRedirects != nil {
+		cfg["follow_redirects"] = *ep.followRedirects
+	}
+	if ep.enableHTTP2 != nil {
+		cfg["enable_http2"] = *ep.enableHTTP2
+	}
+	return cfg
+}
+
+func unsupportedEndpointError(jobName, field string) error {
+	return fmt.Errorf("%s: the %s isn't supported without a TargetAllocator", jobName, field)
+}
+
+func checkSafeTLSConfig(jobName string, tlsConfig monitoringv1.SafeTLSConfig) error {
+	if tlsConfig.CA.Secret != nil || tlsConfig.CA.ConfigMap != nil {
+		return unsupportedEndpointError(jobName, "tlsConfig.ca")
+	}
+	if tlsConfig.Cert.Secret != nil || tlsConfig.Cert.ConfigMap != nil {
+		return unsupportedEndpointError(jobName, "tlsConfig.cert")
+	}
+	if tlsConfig.KeySecret != nil {
+		return unsupportedEndpointError(jobName, "tlsConfig.keySecret")
+	}
+	return nil
+}
+
+func checkAuth(jobName string, basicAuth *monitoringv1.BasicAuth, oauth2 *monitoringv1.OAuth2, authorization *monitoringv1.SafeAuthorization) error {
+	if basicAuth != nil {
+		return unsupportedEndpointError(jobName, "basicAuth")
+	}
+	if oauth2 != nil {
+		return unsupportedEndpointError(jobName, "oauth2")
+	}
+	if authorization != nil && authorization.Credentials != nil {
+		return unsupportedEndpointError(jobName, "authorization")
+	}
+	return nil
+}
+
+func safeTLSConfig(tlsConfig monitoringv1.SafeTLSConfig) map[string]interface{} {
+	cfg := map[string]interface{}{}
+	setIfNotEmpty(cfg, "server_name", tlsConfig.ServerName)
+	if tlsConfig.InsecureSkipVerify {
+		cfg["insecure_skip_verify"] = true
+	}
+	return cfg
+}
+
+func setIfNotEmpty(cfg map[string]interface{}, key, value string) {
+	if value != "" {
+		cfg[key] = value
+	}
+}
+
+// selectorRelabelings keeps the targets whose service or pod matches the label selector of the monitor.
+func selectorRelabelings(labelPrefix, labelPresentPrefix string, selector metav1.LabelSelector) []map[string]interface{} {
+	var relabelings []map[string]interface{}
+	keys := make([]string, 0, len(selector.MatchLabels))
+	for k := range selector.MatchLabels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		relabelings = append(relabelings, map[string]interface{}{
+			"action":        "keep",
+			"source_labels": []string{labelPrefix + "_" + sanitizeLabelName(k), labelPresentPrefix + "_" + sanitizeLabelName(k)},
+			"regex":         fmt.Sprintf("(%s);true", selector.MatchLabels[k]),
+		})
+	}
+	for _, exp := range selector.MatchExpressions {
+		label := sanitizeLabelName(exp.Key)
+		switch exp.Operator {
+		case metav1.LabelSelectorOpIn, metav1.LabelSelectorOpNotIn:
+			action := "keep"
+			if exp.Operator == metav1.LabelSelectorOpNotIn {
+				action = "drop"
+			}
+			relabelings = append(relabelings, map[string]interface{}{
+				"action":        action,
+				"source_labels": []string{labelPrefix + "_" + label, labelPresentPrefix + "_" + label},
+				"regex":         fmt.Sprintf("(%s);true", strings.Join(exp.Values, "|")),
+			})
+		case metav1.LabelSelectorOpExists, metav1.LabelSelectorOpDoesNotExist:
+			action := "keep"
+			if exp.Operator == metav1.LabelSelectorOpDoesNotExist {
+				action = "drop"
+			}
+			relabelings = append(relabelings, map[string]interface{}{
+				"action":        action,
+				"source_labels": []string{labelPresentPrefix + "_" + label},
+				"regex":         "true",
+			})
+		}
+	}
+	return relabelings
+}
+
+func keepRelabeling(sourceLabel, regex string) map[string]interface{} {
+	return map[string]interface{}{
+		"action":        "keep",
+		"source_labels": []string{sourceLabel},
+		"regex":         regex,
+	}
+}
+
+func targetPortRelabeling(targetPort intstr.IntOrString) map[string]interface{} {
+	if targetPort.Type == intstr.Int {
+		return keepRelabeling("__meta_kubernetes_pod_container_port_number", targetPort.String())
+	}
+	return keepRelabeling("__meta_kubernetes_pod_container_port_name", targetPort.String())
+}
+
+func copyRelabeling(sourceLabel, targetLabel string) map[string]interface{} {
+	return map[string]interface{}{
+		"source_labels": []string{sourceLabel},
+		"target_label":  targetLabel,
+	}
+}
+
+func endpointRelabeling(port string, targetPort *intstr.IntOrString) map[string]interface{} {
+	endpoint := port
+	if endpoint == "" && targetPort != nil {
+		endpoint = targetPort.String()
+	}
+	return map[string]interface{}{
+		"target_label": "endpoint",
+		"replacement":  endpoint,
+	}
+}
+
+// relabelConfigs converts the relabelings of the monitors to the snake case keys of the Prometheus configuration.
+func relabelConfigs(configs []*monitoringv1.RelabelConfig) []map[string]interface{} {
+	var relabelings []map[string]interface{}
+	for _, rc := range configs {
+		if rc == nil {
+			continue
+		}
+		relabeling := map[string]interface{}{}
+		if len(rc.SourceLabels) > 0 {
+			sourceLabels := make([]string, len(rc.SourceLabels))
+			for i, label := range rc.SourceLabels {
+				sourceLabels[i] = string(label)
+			}
+			relabeling["source_labels"] = sourceLabels
+		}
+		setIfNotEmpty(relabeling, "separator", rc.Separator)
+		setIfNotEmpty(relabeling, "target_label", rc.TargetLabel)
+		setIfNotEmpty(relabeling, "regex", rc.Regex)
+		if rc.Modulus > 0 {
+			relabeling["modulus"] = rc.Modulus
+		}
+		setIfNotEmpty(relabeling, "replacement", rc.Replacement)
+		setIfNotEmpty(relabeling, "action", strings.ToLower(rc.Action))
+		relabelings = append(relabelings, relabeling)
+	}
+	return relabelings
+}
+
+func sanitizeLabelName(name string) string {
+	return invalidLabelCharRE.ReplaceAllString(name, "_")
+}
+
+// escapeDollarSigns escapes the dollar signs of the strings, as the collector expands the environment variables in
+// its configuration.
+func escapeDollarSigns(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return strings.ReplaceAll(v, "$", "$$")
+	case []string:
+		escaped := make([]string, len(v))
+		for i, s := range v {
+			escaped[i] = strings.ReplaceAll(s, "$", "$$")
+		}
+		return escaped
+	case map[string][]string:
+		escaped := make(map[string][]string, len(v))
+		for k, values := range v {
+			escaped[k] = escapeDollarSigns(values).([]string)
+		}
+		return escaped
+	case []interface{}:
+		for i := range v {
+			v[i] = escapeDollarSigns(v[i])
+		}
+		return v
+	case []map[string]interface{}:
+		for i := range v {
+			v[i] = escapeDollarSigns(v[i]).(map[string]interface{})
+		}
+		return v
+	case map[string]interface{}:
+		for k := range v {
+			v[k] = escapeDollarSigns(v[k])
+		}
+		return v
+	}
+	return value
+}
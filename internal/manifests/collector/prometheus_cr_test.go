@@ -0,0 +1,264 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"testing"
+	"time"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestServiceMonitorScrapeConfigs(t *testing.T) {
+	// prepare
+	instance := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			PrometheusCR: v1alpha1.OpenTelemetryCollectorPrometheusCR{
+				Enabled:        true,
+				ScrapeInterval: &metav1.Duration{Duration: 30 * time.Second},
+			},
+		},
+	}
+	sm := &monitoringv1.ServiceMonitor{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "observability"},
+		Spec: monitoringv1.ServiceMonitorSpec{
+			JobLabel: "app.kubernetes.io/name",
+			Selector: metav1.LabelSelector{
+				MatchLabels: map[string]string{"app.kubernetes.io/name": "app"},
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "tier", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"test", "dev"}},
+				},
+			},
+			Endpoints: []monitoringv1.Endpoint{
+				{
+					Port:     "metrics",
+					Interval: "10s",
+					Path:     "/stats",
+					RelabelConfigs: []*monitoringv1.RelabelConfig{
+						{SourceLabels: []monitoringv1.LabelName{"__meta_kubernetes_pod_node_name"}, TargetLabel: "node", Replacement: "$1", Action: "Replace"},
+					},
+				},
+			},
+		},
+	}
+
+	// test
+	scrapeConfigs, err := PrometheusCRScrapeConfigs(instance, []*monitoringv1.ServiceMonitor{sm}, nil)
+
+	// verify
+	require.NoError(t, err)
+	expected := `- honor_labels: false
+  job_name: serviceMonitor/observability/app/0
+  kubernetes_sd_configs:
+  - namespaces:
+      names:
+      - observability
+    role: endpoints
+  metrics_path: /stats
+  relabel_configs:
+  - action: keep
+    regex: (app);true
+    source_labels:
+    - __meta_kubernetes_service_label_app_kubernetes_io_name
+    - __meta_kubernetes_service_labelpresent_app_kubernetes_io_name
+  - action: drop
+    regex: (test|dev);true
+    source_labels:
+    - __meta_kubernetes_service_label_tier
+    - __meta_kubernetes_service_labelpresent_tier
+  - action: drop
+    regex: (Failed|Succeeded)
+    source_labels:
+    - __meta_kubernetes_pod_phase
+  - action: keep
+    regex: metrics
+    source_labels:
+    - __meta_kubernetes_endpoint_port_name
+  - source_labels:
+    - __meta_kubernetes_namespace
+    target_label: namespace
+  - source_labels:
+    - __meta_kubernetes_service_name
+    target_label: service
+  - source_labels:
+    - __meta_kubernetes_pod_name
+    target_label: pod
+  - source_labels:
+    - __meta_kubernetes_pod_container_name
+    target_label: container
+  - source_labels:
+    - __meta_kubernetes_service_name
+    target_label: job
+  - source_labels:
+    - __meta_kubernetes_service_label_app_kubernetes_io_name
+    target_label: job
+  - replacement: metrics
+    target_label: endpoint
+  - action: replace
+    replacement: $$1
+    source_labels:
+    - __meta_kubernetes_pod_node_name
+    target_label: node
+  scrape_interval: 10s
+`
+	actual, err := yaml.Marshal(scrapeConfigs)
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(actual))
+}
+
+func TestPodMonitorScrapeConfigs(t *testing.T) {
+	// prepare
+	instance := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			PrometheusCR: v1alpha1.OpenTelemetryCollectorPrometheusCR{
+				Enabled:        true,
+				ScrapeInterval: &metav1.Duration{Duration: 30 * time.Second},
+			},
+		},
+	}
+	targetPort := intstr.FromInt(8080)
+	pm := &monitoringv1.PodMonitor{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "observability"},
+		Spec: monitoringv1.PodMonitorSpec{
+			NamespaceSelector: monitoringv1.NamespaceSelector{Any: true},
+			PodMetricsEndpoints: []monitoringv1.PodMetricsEndpoint{
+				{TargetPort: &targetPort},
+			},
+		},
+	}
+
+	// test
+	scrapeConfigs, err := PrometheusCRScrapeConfigs(instance, nil, []*monitoringv1.PodMonitor{pm})
+
+	// verify
+	require.NoError(t, err)
+	expected := `- honor_labels: false
+  job_name: podMonitor/observability/app/0
+  kubernetes_sd_configs:
+  - role: pod
+  relabel_configs:
+  - action: drop
+    regex: (Failed|Succeeded)
+    source_labels:
+    - __meta_kubernetes_pod_phase
+  - action: keep
+    regex: "8080"
+    source_labels:
+    - __meta_kubernetes_pod_container_port_number
+  - source_labels:
+    - __meta_kubernetes_namespace
+    target_label: namespace
+  - source_labels:
+    - __meta_kubernetes_pod_container_name
+    target_label: container
+  - source_labels:
+    - __meta_kubernetes_pod_name
+    target_label: pod
+  - replacement: observability/app
+    target_label: job
+  - replacement: "8080"
+    target_label: endpoint
+  scrape_interval: 30s
+`
+	actual, err := yaml.Marshal(scrapeConfigs)
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(actual))
+}
+
+func TestPrometheusCRScrapeConfigsUnsupportedEndpoints(t *testing.T) {
+	// prepare
+	sm := &monitoringv1.ServiceMonitor{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "observability"},
+		Spec: monitoringv1.ServiceMonitorSpec{
+			Endpoints: []monitoringv1.Endpoint{
+				{Port: "secured", BasicAuth: &monitoringv1.BasicAuth{}},
+				{Port: "metrics"},
+			},
+		},
+	}
+	pm := &monitoringv1.PodMonitor{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "observability"},
+		Spec: monitoringv1.PodMonitorSpec{
+			PodMetricsEndpoints: []monitoringv1.PodMetricsEndpoint{
+				{Port: "secured", BearerTokenSecret: v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "token"}}},
+			},
+		},
+	}
+
+	// test
+	scrapeConfigs, err := PrometheusCRScrapeConfigs(v1alpha1.OpenTelemetryCollector{}, []*monitoringv1.ServiceMonitor{sm}, []*monitoringv1.PodMonitor{pm})
+
+	// verify
+	assert.ErrorContains(t, err, "serviceMonitor/observability/app/0: the basicAuth isn't supported without a TargetAllocator")
+	assert.ErrorContains(t, err, "podMonitor/observability/app/0: the bearerTokenSecret isn't supported without a TargetAllocator")
+	require.Len(t, scrapeConfigs, 1)
+	assert.Equal(t, "serviceMonitor/observability/app/1", scrapeConfigs[0]["job_name"])
+}
+
+func TestAddPrometheusCRScrapeConfigs(t *testing.T) {
+	scrapeConfigs := []map[string]interface{}{{"job_name": "serviceMonitor/observability/app/0"}}
+
+	for _, tt := range []struct {
+		desc     string
+		config   string
+		expected string
+	}{
+		{
+			desc: "existing scrape configs",
+			config: `receivers:
+  prometheus:
+    config:
+      scrape_configs:
+      - job_name: self
+`,
+			expected: `receivers:
+  prometheus:
+    config:
+      scrape_configs:
+      - job_name: self
+      - job_name: serviceMonitor/observability/app/0
+`,
+		},
+		{
+			desc: "no scrape configs",
+			config: `receivers:
+  prometheus:
+`,
+			expected: `receivers:
+  prometheus:
+    config:
+      scrape_configs:
+      - job_name: serviceMonitor/observability/app/0
+`,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// test
+			actual, err := AddPrometheusCRScrapeConfigs(tt.config, scrapeConfigs)
+
+			// verify
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
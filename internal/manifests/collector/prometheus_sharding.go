@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+)
+
+const (
+	// podIndexLabel is set by the statefulset controller on its pods, since Kubernetes 1.28.
+	podIndexLabel = "apps.kubernetes.io/pod-index"
+	// shardTmpLabel holds the hash of the address of a target while its scrape configs are relabeled.
+	shardTmpLabel = "__tmp_hash"
+)
+
+// prometheusShardingEnabled returns whether the targets of the prometheus receiver are spread across the replicas.
+func prometheusShardingEnabled(otelcol v1alpha1.OpenTelemetryCollector) bool {
+	return otelcol.Spec.PrometheusSharding && otelcol.Spec.Mode == v1alpha1.ModeStatefulSet && !otelcol.Spec.TargetAllocator.Enabled
+}
+
+// prometheusShards returns the number of shards, which is the number of replicas of the statefulset.
+func prometheusShards(otelcol v1alpha1.OpenTelemetryCollector) int32 {
+	if otelcol.Spec.Replicas != nil && *otelcol.Spec.Replicas > 0 {
+		return *otelcol.Spec.Replicas
+	}
+	return 1
+}
+
+// shardEnvVar holds the ordinal of the replica, which is the shard whose targets it keeps.
+func shardEnvVar() corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: "SHARD",
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{
+				FieldPath: fmt.Sprintf("metadata.labels['%s']", podIndexLabel),
+			},
+		},
+	}
+}
+
+// AddPrometheusSharding appends a hashmod relabeling to each scrape config of the prometheus receiver, the same
+// way the Prometheus Operator shards Prometheus: every replica hashes the addresses of the targets modulo the
+// number of shards, and keeps the targets of its own shard, read from the SHARD environment variable.
+func AddPrometheusSharding(cfg string, shards int32) (string, error) {
+	config, err := adapters.ConfigFromString(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	receivers, _ := config["receivers"].(map[interface{}]interface{})
+	prometheus, _ := receivers["prometheus"].(map[interface{}]interface{})
+	promConfig, _ := prometheus["config"].(map[interface{}]interface{})
+	scrapeConfigs, ok := promConfig["scrape_configs"].([]interface{})
+	if !ok {
+		if promConfig["scrape_configs"] != nil {
+			return "", errors.New("the prometheus receiver scrape_configs isn't a list")
+		}
+		return cfg, nil
+	}
+
+	for i, item := range scrapeConfigs {
+		scrapeConfig, ok := item.(map[interface{}]interface{})
+		if !ok {
+			return "", fmt.Errorf("the prometheus receiver scrape config at index %d isn't a map", i)
+		}
+		relabelConfigs, ok := scrapeConfig["relabel_configs"].([]interface{})
+		if !ok && scrapeConfig["relabel_configs"] != nil {
+			return "", fmt.Errorf("the relabel_configs of the prometheus receiver scrape config at index %d isn't a list", i)
+		}
+		scrapeConfig["relabel_configs"] = append(relabelConfigs,
+			map[interface{}]interface{}{
+				"source_labels": []interface{}{"__address__"},
+				"target_label":  shardTmpLabel,
+				"modulus":       shards,
+				"action":        "hashmod",
+			},
+			map[interface{}]interface{}{
+				"source_labels": []interface{}{shardTmpLabel},
+				"regex":         "${env:SHARD}",
+				"action":        "keep",
+			},
+		)
+	}
+
+	out, err := manifestutils.MarshalYAML(config)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func TestAddPrometheusSharding(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		config   string
+		expected string
+	}{
+		{
+			desc: "scrape configs with and without relabel configs",
+			config: `receivers:
+  prometheus:
+    config:
+      scrape_configs:
+      - job_name: self
+      - job_name: app
+        relabel_configs:
+        - action: labeldrop
+          regex: tmp
+`,
+			expected: `receivers:
+  prometheus:
+    config:
+      scrape_configs:
+      - job_name: self
+        relabel_configs:
+        - action: hashmod
+          modulus: 3
+          source_labels:
+          - __address__
+          target_label: __tmp_hash
+        - action: keep
+          regex: ${env:SHARD}
+          source_labels:
+          - __tmp_hash
+      - job_name: app
+        relabel_configs:
+        - action: labeldrop
+          regex: tmp
+        - action: hashmod
+          modulus: 3
+          source_labels:
+          - __address__
+          target_label: __tmp_hash
+        - action: keep
+          regex: ${env:SHARD}
+          source_labels:
+          - __tmp_hash
+`,
+		},
+		{
+			desc: "no prometheus receiver",
+			config: `receivers:
+  otlp:
+`,
+			expected: `receivers:
+  otlp:
+`,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// test
+			actual, err := AddPrometheusSharding(tt.config, 3)
+
+			// verify
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestPrometheusShardingOfTheReplicas(t *testing.T) {
+	// prepare
+	params := paramsWithMode(v1alpha1.ModeStatefulSet)
+	params.OtelCol.Spec.Config = `receivers:
+  prometheus:
+    config:
+      scrape_configs:
+      - job_name: self
+exporters:
+  debug:
+service:
+  pipelines:
+    metrics:
+      receivers: [prometheus]
+      exporters: [debug]
+`
+	before := PodAnnotations(params.OtelCol, nil)["opentelemetry-operator-config/sha256"]
+	params.OtelCol.Spec.PrometheusSharding = true
+
+	// test
+	cm := ConfigMap(params)
+	c := Container(config.New(), logger, params.OtelCol, true)
+
+	// verify
+	assert.Contains(t, cm.Data["collector.yaml"], "modulus: 2")
+	assert.Contains(t, c.Env, corev1.EnvVar{
+		Name: "SHARD",
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.labels['apps.kubernetes.io/pod-index']"},
+		},
+	})
+	// the replicas read the number of shards from the configuration, so they are rolled out when it changes
+	sharded := PodAnnotations(params.OtelCol, nil)["opentelemetry-operator-config/sha256"]
+	assert.NotEqual(t, before, sharded)
+	replicas := int32(3)
+	params.OtelCol.Spec.Replicas = &replicas
+	assert.NotEqual(t, sharded, PodAnnotations(params.OtelCol, nil)["opentelemetry-operator-config/sha256"])
+
+	// the deployments don't know the ordinals of their pods
+	params.OtelCol.Spec.Mode = v1alpha1.ModeDeployment
+	assert.NotContains(t, ConfigMap(params).Data["collector.yaml"], "modulus")
+}
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+)
+
+const (
+	writableVolumePrefix = "otc-writable"
+	tmpDir               = "/tmp"
+)
+
+// readOnlyRootFilesystem returns whether the collector container runs with a read-only root filesystem, either as
+// requested by the spec or as set by its security context, possibly defaulted by the operator.
+func readOnlyRootFilesystem(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) bool {
+	if otelcol.Spec.ReadOnlyRootFilesystem {
+		return true
+	}
+	securityContext := manifestutils.SecurityContext(cfg, otelcol.Spec.SecurityContext)
+	return securityContext != nil && securityContext.ReadOnlyRootFilesystem != nil && *securityContext.ReadOnlyRootFilesystem
+}
+
+// collectorSecurityContext returns the security context of the collector container, making the root filesystem
+// read-only when requested by the spec.
+func collectorSecurityContext(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) *corev1.SecurityContext {
+	securityContext := manifestutils.SecurityContext(cfg, otelcol.Spec.SecurityContext)
+	if !otelcol.Spec.ReadOnlyRootFilesystem {
+		return securityContext
+	}
+	if securityContext == nil {
+		securityContext = &corev1.SecurityContext{}
+	} else {
+		// copy to avoid modifying the security context of the custom resource
+		securityContext = securityContext.DeepCopy()
+	}
+	readOnly := true
+	securityContext.ReadOnlyRootFilesystem = &readOnly
+	return securityContext
+}
+
+// writableDirs returns the directories the collector writes to when its root filesystem is read-only: /tmp and the
+// directories of the file_storage extensions, except the ones already mounted through the spec.
+func writableDirs(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) []string {
+	if !readOnlyRootFilesystem(cfg, otelcol) {
+		return nil
+	}
+	candidates := []string{tmpDir}
+	if c, err := adapters.ConfigFromString(otelcol.Spec.Config); err == nil {
+		candidates = append(candidates, adapters.ConfigToStorageDirectories(c)...)
+	}
+
+	skipped := map[string]bool{}
+	for _, m := range otelcol.Spec.VolumeMounts {
+		skipped[path.Clean(m.MountPath)] = true
+	}
+	var dirs []string
+	for _, dir := range candidates {
+		if !skipped[dir] {
+			dirs = append(dirs, dir)
+			skipped[dir] = true
+		}
+	}
+	return dirs
+}
+
+// WritableVolumes returns the emptyDir volumes mounted on the directories the collector writes to when its root
+// filesystem is read-only.
+func WritableVolumes(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) []corev1.Volume {
+	dirs := writableDirs(cfg, otelcol)
+	volumes := make([]corev1.Volume, len(dirs))
+	for i := range dirs {
+		volumes[i] = corev1.Volume{
+			Name:         writableVolumeName(i),
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		}
+	}
+	return volumes
+}
+
+// writableVolumeMounts returns the volume mounts of the volumes returned by WritableVolumes.
+func writableVolumeMounts(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) []corev1.VolumeMount {
+	dirs := writableDirs(cfg, otelcol)
+	mounts := make([]corev1.VolumeMount, len(dirs))
+	for i, dir := range dirs {
+		mounts[i] = corev1.VolumeMount{Name: writableVolumeName(i), MountPath: dir}
+	}
+	return mounts
+}
+
+func writableVolumeName(i int) string {
+	return fmt.Sprintf("%s-%d", writableVolumePrefix, i)
+}
@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+const fileStorageConfig = `receivers:
+  otlp:
+    protocols:
+      grpc:
+extensions:
+  file_storage:
+    directory: /var/lib/storage
+exporters:
+  debug:
+service:
+  extensions: [file_storage]
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [debug]`
+
+func TestReadOnlyRootFilesystem(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config:                 fileStorageConfig,
+			ReadOnlyRootFilesystem: true,
+		},
+	}
+	cfg := config.New()
+
+	// test
+	c := Container(cfg, logger, otelcol, true)
+	volumes := Volumes(cfg, otelcol)
+
+	// verify
+	assert.True(t, *c.SecurityContext.ReadOnlyRootFilesystem)
+	assert.Contains(t, c.VolumeMounts, corev1.VolumeMount{Name: "otc-writable-0", MountPath: "/tmp"})
+	assert.Contains(t, c.VolumeMounts, corev1.VolumeMount{Name: "otc-writable-1", MountPath: "/var/lib/storage"})
+	assert.Contains(t, volumes, corev1.Volume{Name: "otc-writable-0", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}})
+	assert.Contains(t, volumes, corev1.Volume{Name: "otc-writable-1", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}})
+}
+
+func TestReadOnlyRootFilesystemKeepsSecurityContext(t *testing.T) {
+	// prepare
+	runAsNonRoot := true
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config:                 fileStorageConfig,
+			ReadOnlyRootFilesystem: true,
+			SecurityContext:        &corev1.SecurityContext{RunAsNonRoot: &runAsNonRoot},
+		},
+	}
+
+	// test
+	c := Container(config.New(), logger, otelcol, true)
+
+	// verify
+	assert.True(t, *c.SecurityContext.ReadOnlyRootFilesystem)
+	assert.True(t, *c.SecurityContext.RunAsNonRoot)
+	assert.Nil(t, otelcol.Spec.SecurityContext.ReadOnlyRootFilesystem, "the custom resource should be left untouched")
+}
+
+func TestWritableDirs(t *testing.T) {
+	readOnly := true
+	for _, tt := range []struct {
+		desc     string
+		spec     v1alpha1.OpenTelemetryCollectorSpec
+		expected []string
+	}{
+		{
+			desc: "writable root filesystem",
+			spec: v1alpha1.OpenTelemetryCollectorSpec{Config: fileStorageConfig},
+		},
+		{
+			desc: "read-only through the security context",
+			spec: v1alpha1.OpenTelemetryCollectorSpec{
+				Config:          fileStorageConfig,
+				SecurityContext: &corev1.SecurityContext{ReadOnlyRootFilesystem: &readOnly},
+			},
+			expected: []string{"/tmp", "/var/lib/storage"},
+		},
+		{
+			desc: "directories mounted through the spec are skipped",
+			spec: v1alpha1.OpenTelemetryCollectorSpec{
+				Config:                 fileStorageConfig,
+				ReadOnlyRootFilesystem: true,
+				VolumeMounts:           []corev1.VolumeMount{{Name: "storage", MountPath: "/var/lib/storage/"}},
+			},
+			expected: []string{"/tmp"},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// test
+			dirs := writableDirs(config.New(), v1alpha1.OpenTelemetryCollector{Spec: tt.spec})
+
+			// verify
+			assert.Equal(t, tt.expected, dirs)
+		})
+	}
+}
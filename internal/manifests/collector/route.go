@@ -50,7 +50,7 @@ func Routes(params manifests.Params) []*routev1.Route {
 		return nil
 	}
 
-	ports := servicePortsFromCfg(params.Log, params.OtelCol)
+	ports := servicePortsFromCfg(params)
 
 	// if we have no ports, we don't need a ingress entry
 	if len(ports) == 0 {
@@ -86,7 +86,7 @@ func Routes(params manifests.Params) []*routev1.Route {
 				Host: host,
 				To: routev1.RouteTargetReference{
 					Kind: "Service",
-					Name: naming.Service(params.OtelCol.Name),
+					Name: naming.Service(&params.OtelCol),
 				},
 				Port: &routev1.RoutePort{
 					TargetPort: intstr.FromString(portName),
@@ -16,6 +16,7 @@ package collector
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/go-logr/logr"
@@ -35,66 +36,163 @@ const (
 	headlessExists = "Exists"
 )
 
+// pod service label holds the ordinal of the pod of the statefulset selected by the service.
+const (
+	podServiceLabel = "operator.opentelemetry.io/collector-pod-service"
+	podNameLabel    = "statefulset.kubernetes.io/pod-name"
+)
+
+// metrics service label marks the services exposing the metrics of the collector, which its ServiceMonitor selects.
+const (
+	metricsServiceLabel  = "operator.opentelemetry.io/collector-metrics-service"
+	metricsServiceExists = "Exists"
+)
+
 func HeadlessService(params manifests.Params) *corev1.Service {
 	h := Service(params)
 	if h == nil {
 		return h
 	}
 
-	h.Name = naming.HeadlessService(params.OtelCol.Name)
+	h.Name = naming.HeadlessService(&params.OtelCol)
 	h.Labels[headlessLabel] = headlessExists
 
-	// copy to avoid modifying params.OtelCol.Annotations
-	annotations := map[string]string{
-		"service.beta.openshift.io/serving-cert-secret-name": fmt.Sprintf("%s-tls", h.Name),
-	}
-	for k, v := range h.Annotations {
-		annotations[k] = v
-	}
-	h.Annotations = annotations
+	// copy to avoid modifying params.OtelCol.Annotations, the annotations are taken from the custom resource
+	// as the ones of the cluster IP service might request a serving certificate into another secret
+	h.Annotations = manifestutils.Merge(
+		map[string]string{manifestutils.ServingCertSecretAnnotation: fmt.Sprintf("%s-tls", h.Name)},
+		params.OtelCol.Annotations,
+		params.OtelCol.Spec.ServiceAnnotations,
+	)
 
 	h.Spec.ClusterIP = "None"
+	if governsStatefulSet(params.OtelCol) {
+		h.Spec.PublishNotReadyAddresses = true
+	}
 	return h
 }
 
+// PodServices builds a service per replica of the statefulset, selecting its pod through the label set by the
+// statefulset controller, so that the replicas can be addressed individually.
+func PodServices(params manifests.Params) []*corev1.Service {
+	if params.OtelCol.Spec.Mode != v1alpha1.ModeStatefulSet || !params.OtelCol.Spec.StatefulSet.PodServices {
+		return nil
+	}
+	svc := Service(params)
+	if svc == nil {
+		return nil
+	}
+
+	var services []*corev1.Service
+	for ordinal := 0; ordinal < int(podServicesReplicas(params.OtelCol)); ordinal++ {
+		s := svc.DeepCopy()
+		s.Name = naming.PodService(&params.OtelCol, ordinal)
+		s.Labels["app.kubernetes.io/name"] = s.Name
+		s.Labels[podServiceLabel] = strconv.Itoa(ordinal)
+		s.Annotations = manifestutils.Merge(params.OtelCol.Annotations, params.OtelCol.Spec.ServiceAnnotations)
+		s.Spec.Selector = manifestutils.Merge(s.Spec.Selector, map[string]string{
+			podNameLabel: fmt.Sprintf("%s-%d", naming.Collector(&params.OtelCol), ordinal),
+		})
+		services = append(services, s)
+	}
+	return services
+}
+
+// podServicesReplicas returns the number of replicas the statefulset may scale up to.
+func podServicesReplicas(otelcol v1alpha1.OpenTelemetryCollector) int32 {
+	replicas := int32(1)
+	if otelcol.Spec.Replicas != nil {
+		replicas = *otelcol.Spec.Replicas
+	}
+	if otelcol.Spec.Autoscaler != nil && otelcol.Spec.Autoscaler.MaxReplicas != nil && *otelcol.Spec.Autoscaler.MaxReplicas > replicas {
+		replicas = *otelcol.Spec.Autoscaler.MaxReplicas
+	}
+	return replicas
+}
+
+// governsStatefulSet returns whether the headless service governs the statefulset of the instance.
+func governsStatefulSet(otelcol v1alpha1.OpenTelemetryCollector) bool {
+	return otelcol.Spec.Mode == v1alpha1.ModeStatefulSet && otelcol.Spec.StatefulSet.HeadlessService
+}
+
+// statefulSetServiceName returns the name of the service governing the statefulset of the instance.
+func statefulSetServiceName(otelcol v1alpha1.OpenTelemetryCollector) string {
+	if governsStatefulSet(otelcol) {
+		return naming.HeadlessService(&otelcol)
+	}
+	return naming.Service(&otelcol)
+}
+
 func MonitoringService(params manifests.Params) *corev1.Service {
-	name := naming.MonitoringService(params.OtelCol.Name)
-	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, []string{})
+	name := naming.MonitoringService(&params.OtelCol)
+	labels := manifestutils.Merge(params.OtelCol.Spec.ServiceLabels, manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, []string{}, params.OtelCol.Spec.StandardLabels))
 
-	c, err := adapters.ConfigFromString(params.OtelCol.Spec.Config)
 	// TODO: Update this to properly return an error https://github.com/open-telemetry/opentelemetry-operator/issues/1972
-	if err != nil {
+	if _, err := adapters.ConfigFromString(params.OtelCol.Spec.Config); err != nil {
 		params.Log.Error(err, "couldn't extract the configuration")
 		return nil
 	}
 
-	metricsPort, err := adapters.ConfigToMetricsPort(params.Log, c)
-	if err != nil {
-		params.Log.V(2).Info("couldn't determine metrics port from configuration, using 8888 default value", "error", err)
-		metricsPort = 8888
-	}
+	labels[metricsServiceLabel] = metricsServiceExists
 
 	return &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        name,
 			Namespace:   params.OtelCol.Namespace,
 			Labels:      labels,
-			Annotations: params.OtelCol.Annotations,
+			Annotations: manifestutils.Merge(params.OtelCol.Annotations, params.OtelCol.Spec.ServiceAnnotations),
 		},
 		Spec: corev1.ServiceSpec{
-			Selector:  manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollector),
+			Selector:  manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollector, params.OtelCol.Spec.StandardLabels),
 			ClusterIP: "",
 			Ports: []corev1.ServicePort{{
 				Name: "monitoring",
-				Port: metricsPort,
+				Port: metricsPort(params.Log, params.OtelCol),
 			}},
+			IPFamilies:     params.OtelCol.Spec.IPFamilies,
+			IPFamilyPolicy: params.OtelCol.Spec.IPFamilyPolicy,
+		},
+	}
+}
+
+// PrometheusExporterService builds the service exposing the ports of the prometheus exporters of the configuration, so
+// that the metrics they serve can be scraped without going through the service of the receivers.
+func PrometheusExporterService(params manifests.Params) *corev1.Service {
+	name := naming.PrometheusExporterService(&params.OtelCol)
+	labels := manifestutils.Merge(params.OtelCol.Spec.ServiceLabels, manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, []string{}, params.OtelCol.Spec.StandardLabels))
+	labels[metricsServiceLabel] = metricsServiceExists
+
+	configFromString, err := adapters.ConfigFromString(params.OtelCol.Spec.Config)
+	if err != nil {
+		params.Log.Error(err, "couldn't extract the configuration")
+		return nil
+	}
+	// the prometheus exporter is the only exporter listening on a port
+	ports, err := adapters.ConfigToExporterPorts(params.Log, configFromString)
+	if err != nil || len(ports) == 0 {
+		return nil
+	}
+
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   params.OtelCol.Namespace,
+			Labels:      labels,
+			Annotations: manifestutils.Merge(params.OtelCol.Annotations, params.OtelCol.Spec.ServiceAnnotations),
+		},
+		Spec: corev1.ServiceSpec{
+			Selector:       manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollector, params.OtelCol.Spec.StandardLabels),
+			ClusterIP:      "",
+			Ports:          ports,
+			IPFamilies:     params.OtelCol.Spec.IPFamilies,
+			IPFamilyPolicy: params.OtelCol.Spec.IPFamilyPolicy,
 		},
 	}
 }
 
 func Service(params manifests.Params) *corev1.Service {
-	name := naming.Service(params.OtelCol.Name)
-	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, []string{})
+	name := naming.Service(&params.OtelCol)
+	labels := manifestutils.Merge(params.OtelCol.Spec.ServiceLabels, manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, []string{}, params.OtelCol.Spec.StandardLabels))
 
 	configFromString, err := adapters.ConfigFromString(params.OtelCol.Spec.Config)
 	if err != nil {
@@ -102,7 +200,7 @@ func Service(params manifests.Params) *corev1.Service {
 		return nil
 	}
 
-	ports := adapters.ConfigToPorts(params.Log, configFromString)
+	ports := meshPortNames(params, adapters.ConfigToPorts(params.Log, configFromString))
 
 	// set appProtocol to h2c for grpc ports on OpenShift.
 	// OpenShift uses HA proxy that uses appProtocol for its configuration.
@@ -121,7 +219,8 @@ func Service(params manifests.Params) *corev1.Service {
 		//
 		// in the first case, we remove the port we inferred from the list
 		// in the second case, we rename our inferred port to something like "port-%d"
-		portNumbers, portNames := extractPortNumbersAndNames(params.OtelCol.Spec.Ports)
+		specPorts := specServicePorts(params.OtelCol)
+		portNumbers, portNames := extractPortNumbersAndNames(specPorts)
 		var resultingInferredPorts []corev1.ServicePort
 		for _, inferred := range ports {
 			if filtered := filterPort(params.Log, inferred, portNumbers, portNames); filtered != nil {
@@ -129,7 +228,7 @@ func Service(params manifests.Params) *corev1.Service {
 			}
 		}
 
-		ports = append(params.OtelCol.Spec.Ports, resultingInferredPorts...)
+		ports = append(exposedServicePorts(params.OtelCol), resultingInferredPorts...)
 	}
 
 	// if we have no ports, we don't need a service
@@ -145,20 +244,42 @@ func Service(params manifests.Params) *corev1.Service {
 
 	return &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:        naming.Service(params.OtelCol.Name),
+			Name:        naming.Service(&params.OtelCol),
 			Namespace:   params.OtelCol.Namespace,
 			Labels:      labels,
-			Annotations: params.OtelCol.Annotations,
+			Annotations: manifestutils.ServiceAnnotations(params.Config, name, manifestutils.Merge(params.OtelCol.Annotations, params.OtelCol.Spec.ServiceAnnotations)),
 		},
 		Spec: corev1.ServiceSpec{
 			InternalTrafficPolicy: &trafficPolicy,
-			Selector:              manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollector),
+			Selector:              manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollector, params.OtelCol.Spec.StandardLabels),
 			ClusterIP:             "",
 			Ports:                 ports,
+			IPFamilies:            params.OtelCol.Spec.IPFamilies,
+			IPFamilyPolicy:        params.OtelCol.Spec.IPFamilyPolicy,
 		},
 	}
 }
 
+// specServicePorts returns the ports of the spec, without the settings of the pods.
+func specServicePorts(otelcol v1alpha1.OpenTelemetryCollector) []corev1.ServicePort {
+	ports := make([]corev1.ServicePort, 0, len(otelcol.Spec.Ports))
+	for _, p := range otelcol.Spec.Ports {
+		ports = append(ports, p.ServicePort)
+	}
+	return ports
+}
+
+// exposedServicePorts returns the ports of the spec exposed on the Services, without the settings of the pods.
+func exposedServicePorts(otelcol v1alpha1.OpenTelemetryCollector) []corev1.ServicePort {
+	ports := make([]corev1.ServicePort, 0, len(otelcol.Spec.Ports))
+	for _, p := range otelcol.Spec.Ports {
+		if p.ExposeOnService == nil || *p.ExposeOnService {
+			ports = append(ports, p.ServicePort)
+		}
+	}
+	return ports
+}
+
 func filterPort(logger logr.Logger, candidate corev1.ServicePort, portNumbers map[int32]bool, portNames map[string]bool) *corev1.ServicePort {
 	if portNumbers[candidate.Port] {
 		return nil
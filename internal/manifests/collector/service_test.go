@@ -15,9 +15,11 @@
 package collector
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -26,6 +28,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect"
 )
 
 func TestExtractPortNumbersAndNames(t *testing.T) {
@@ -121,7 +124,7 @@ func TestDesiredService(t *testing.T) {
 			AppProtocol: &grpc,
 		}
 		params := deploymentParams()
-		ports := append(params.OtelCol.Spec.Ports, jaegerPorts)
+		ports := append(specServicePorts(params.OtelCol), jaegerPorts)
 		expected := service("test-collector", ports)
 		actual := Service(params)
 
@@ -129,6 +132,20 @@ func TestDesiredService(t *testing.T) {
 
 	})
 
+	t.Run("should leave out the ports which aren't exposed on the service", func(t *testing.T) {
+		hidden := false
+		params := deploymentParams()
+		exposed := specServicePorts(params.OtelCol)
+		params.OtelCol.Spec.Ports = append(params.OtelCol.Spec.Ports,
+			v1alpha1.PortsSpec{ExposeOnService: &hidden, ServicePort: v1.ServicePort{Name: "pprof", Port: 1777}},
+			v1alpha1.PortsSpec{ExposeOnService: &hidden, ServicePort: v1.ServicePort{Name: "jaeger-grpc", Port: 14250}},
+		)
+		expected := service("test-collector", exposed)
+		actual := Service(params)
+
+		assert.Equal(t, expected, *actual)
+	})
+
 	t.Run("on OpenShift gRPC appProtocol should be h2c", func(t *testing.T) {
 		h2c := "h2c"
 		jaegerPort := v1.ServicePort{
@@ -142,7 +159,25 @@ func TestDesiredService(t *testing.T) {
 		params.OtelCol.Spec.Ingress.Type = v1alpha1.IngressTypeRoute
 		actual := Service(params)
 
-		ports := append(params.OtelCol.Spec.Ports, jaegerPort)
+		ports := append(specServicePorts(params.OtelCol), jaegerPort)
+		expected := service("test-collector", ports)
+		assert.Equal(t, expected, *actual)
+	})
+
+	t.Run("in a service mesh the ports are named after their protocol", func(t *testing.T) {
+		grpc := "grpc"
+		jaegerPort := v1.ServicePort{
+			Name:        "grpc-jaeger",
+			Protocol:    "TCP",
+			Port:        14250,
+			AppProtocol: &grpc,
+		}
+
+		params := deploymentParams()
+		params.Config = config.New(config.WithServiceMesh(autodetect.ServiceMeshIstio))
+		actual := Service(params)
+
+		ports := append(specServicePorts(params.OtelCol), jaegerPort)
 		expected := service("test-collector", ports)
 		assert.Equal(t, expected, *actual)
 	})
@@ -157,7 +192,7 @@ func TestDesiredService(t *testing.T) {
 			AppProtocol: &grpc,
 		}
 		p := paramsWithMode(v1alpha1.ModeDaemonSet)
-		ports := append(p.OtelCol.Spec.Ports, jaegerPorts)
+		ports := append(specServicePorts(p.OtelCol), jaegerPorts)
 		expected := serviceWithInternalTrafficPolicy("test-collector", ports, v1.ServiceInternalTrafficPolicyLocal)
 		actual := Service(p)
 
@@ -172,6 +207,93 @@ func TestHeadlessService(t *testing.T) {
 		assert.Equal(t, actual.GetAnnotations()["service.beta.openshift.io/serving-cert-secret-name"], "test-collector-headless-tls")
 		assert.Equal(t, actual.Spec.ClusterIP, "None")
 	})
+	t.Run("on OpenShift the headless and cluster IP services request distinct serving certificates", func(t *testing.T) {
+		params := deploymentParams()
+		params.Config = config.New(config.WithOpenShiftPlatform("4.14.1"))
+		assert.Equal(t, "test-collector-tls", Service(params).GetAnnotations()["service.beta.openshift.io/serving-cert-secret-name"])
+		assert.Equal(t, "test-collector-headless-tls", HeadlessService(params).GetAnnotations()["service.beta.openshift.io/serving-cert-secret-name"])
+		assert.Empty(t, params.OtelCol.Annotations)
+	})
+}
+
+func TestPodServices(t *testing.T) {
+	t.Run("should return a service per replica selecting its pod", func(t *testing.T) {
+		params := paramsWithMode(v1alpha1.ModeStatefulSet)
+		params.OtelCol.Spec.StatefulSet.PodServices = true
+
+		services := PodServices(params)
+
+		require.Len(t, services, 2)
+		for i, svc := range services {
+			assert.Equal(t, fmt.Sprintf("test-collector-%d", i), svc.Name)
+			assert.Equal(t, fmt.Sprintf("test-collector-%d", i), svc.Spec.Selector["statefulset.kubernetes.io/pod-name"])
+			assert.Equal(t, fmt.Sprint(i), svc.Labels["operator.opentelemetry.io/collector-pod-service"])
+			assert.Equal(t, Service(params).Spec.Ports, svc.Spec.Ports)
+		}
+	})
+	t.Run("should cover the maximum replicas of the autoscaler", func(t *testing.T) {
+		params := paramsWithMode(v1alpha1.ModeStatefulSet)
+		params.OtelCol.Spec.StatefulSet.PodServices = true
+		maxReplicas := int32(4)
+		params.OtelCol.Spec.Autoscaler = &v1alpha1.AutoscalerSpec{MaxReplicas: &maxReplicas}
+
+		assert.Len(t, PodServices(params), 4)
+	})
+	t.Run("should return nothing outside of the statefulset mode", func(t *testing.T) {
+		params := deploymentParams()
+		params.OtelCol.Spec.StatefulSet.PodServices = true
+
+		assert.Empty(t, PodServices(params))
+	})
+}
+
+func TestHeadlessServiceGoverningStatefulSet(t *testing.T) {
+	// prepare
+	params := paramsWithMode(v1alpha1.ModeStatefulSet)
+
+	// test
+	assert.False(t, HeadlessService(params).Spec.PublishNotReadyAddresses)
+	assert.Equal(t, "test-collector", StatefulSet(params).Spec.ServiceName)
+	params.OtelCol.Spec.StatefulSet.HeadlessService = true
+
+	// verify
+	assert.True(t, HeadlessService(params).Spec.PublishNotReadyAddresses)
+	assert.Equal(t, "test-collector-headless", StatefulSet(params).Spec.ServiceName)
+}
+
+func TestServiceLabelsAndAnnotations(t *testing.T) {
+	// prepare
+	params := deploymentParams()
+	params.OtelCol.Annotations = map[string]string{"team": "observability"}
+	params.OtelCol.Spec.ServiceAnnotations = map[string]string{"service.beta.kubernetes.io/aws-load-balancer-type": "nlb"}
+	params.OtelCol.Spec.ServiceLabels = map[string]string{
+		"sidecar.istio.io/inject":   "false",
+		"app.kubernetes.io/part-of": "mesh",
+	}
+
+	for _, svc := range []*v1.Service{Service(params), HeadlessService(params), MonitoringService(params)} {
+		// verify
+		assert.Equal(t, "observability", svc.Annotations["team"], svc.Name)
+		assert.Equal(t, "nlb", svc.Annotations["service.beta.kubernetes.io/aws-load-balancer-type"], svc.Name)
+		assert.Equal(t, "false", svc.Labels["sidecar.istio.io/inject"], svc.Name)
+		// the labels set by the operator can't be overridden
+		assert.Equal(t, "opentelemetry", svc.Labels["app.kubernetes.io/part-of"], svc.Name)
+	}
+	assert.Len(t, params.OtelCol.Annotations, 1)
+}
+
+func TestServiceIPFamilies(t *testing.T) {
+	// prepare
+	params := deploymentParams()
+	policy := v1.IPFamilyPolicyPreferDualStack
+	params.OtelCol.Spec.IPFamilies = []v1.IPFamily{v1.IPv6Protocol, v1.IPv4Protocol}
+	params.OtelCol.Spec.IPFamilyPolicy = &policy
+
+	for _, svc := range []*v1.Service{Service(params), HeadlessService(params), MonitoringService(params)} {
+		// verify
+		assert.Equal(t, []v1.IPFamily{v1.IPv6Protocol, v1.IPv4Protocol}, svc.Spec.IPFamilies, svc.Name)
+		assert.Equal(t, &policy, svc.Spec.IPFamilyPolicy, svc.Name)
+	}
 }
 
 func TestMonitoringService(t *testing.T) {
@@ -202,13 +324,47 @@ func TestMonitoringService(t *testing.T) {
 	})
 }
 
+func TestPrometheusExporterService(t *testing.T) {
+	t.Run("should expose the ports of the prometheus exporters", func(t *testing.T) {
+		// prepare
+		params, err := newParams("", "testdata/prometheus-exporter.yaml")
+		require.NoError(t, err)
+
+		// test
+		actual := PrometheusExporterService(params)
+
+		// verify
+		require.NotNil(t, actual)
+		assert.Equal(t, "test-collector-prometheus", actual.Name)
+		assert.Equal(t, metricsServiceExists, actual.Labels[metricsServiceLabel])
+		var ports []string
+		for _, p := range actual.Spec.Ports {
+			ports = append(ports, fmt.Sprintf("%s:%d", p.Name, p.Port))
+		}
+		assert.Equal(t, []string{"prometheus-dev:8885", "prometheus-prod:8884"}, ports)
+		assert.Equal(t, metricsServiceExists, MonitoringService(params).Labels[metricsServiceLabel])
+		assert.NotContains(t, Service(params).Labels, metricsServiceLabel)
+	})
+
+	t.Run("should not be created without prometheus exporter", func(t *testing.T) {
+		// prepare
+		params := deploymentParams()
+
+		// test
+		actual := PrometheusExporterService(params)
+
+		// verify
+		assert.Nil(t, actual)
+	})
+}
+
 func service(name string, ports []v1.ServicePort) v1.Service {
 	return serviceWithInternalTrafficPolicy(name, ports, v1.ServiceInternalTrafficPolicyCluster)
 }
 
 func serviceWithInternalTrafficPolicy(name string, ports []v1.ServicePort, internalTrafficPolicy v1.ServiceInternalTrafficPolicyType) v1.Service {
 	params := deploymentParams()
-	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, []string{})
+	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, []string{}, params.OtelCol.Spec.StandardLabels)
 
 	return v1.Service{
 		ObjectMeta: metav1.ObjectMeta{
@@ -219,7 +375,7 @@ func serviceWithInternalTrafficPolicy(name string, ports []v1.ServicePort, inter
 		},
 		Spec: v1.ServiceSpec{
 			InternalTrafficPolicy: &internalTrafficPolicy,
-			Selector:              manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollector),
+			Selector:              manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollector, params.OtelCol.Spec.StandardLabels),
 			ClusterIP:             "",
 			Ports:                 ports,
 		},
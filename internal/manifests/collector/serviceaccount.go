@@ -27,7 +27,7 @@ import (
 // ServiceAccountName returns the name of the existing or self-provisioned service account to use for the given instance.
 func ServiceAccountName(instance v1alpha1.OpenTelemetryCollector) string {
 	if len(instance.Spec.ServiceAccount) == 0 {
-		return naming.ServiceAccount(instance.Name)
+		return naming.ServiceAccount(&instance)
 	}
 
 	return instance.Spec.ServiceAccount
@@ -35,15 +35,15 @@ func ServiceAccountName(instance v1alpha1.OpenTelemetryCollector) string {
 
 // ServiceAccount returns the service account for the given instance.
 func ServiceAccount(params manifests.Params) *corev1.ServiceAccount {
-	name := naming.ServiceAccount(params.OtelCol.Name)
-	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, []string{})
+	name := naming.ServiceAccount(&params.OtelCol)
+	labels := manifestutils.Merge(params.OtelCol.Spec.ServiceAccountLabels, manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, []string{}, params.OtelCol.Spec.StandardLabels))
 
 	return &corev1.ServiceAccount{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        name,
 			Namespace:   params.OtelCol.Namespace,
 			Labels:      labels,
-			Annotations: params.OtelCol.Annotations,
+			Annotations: manifestutils.Merge(params.OtelCol.Annotations, params.OtelCol.Spec.ServiceAccountAnnotations),
 		},
 	}
 }
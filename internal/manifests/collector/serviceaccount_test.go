@@ -21,6 +21,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
 	. "github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
 )
 
@@ -56,3 +57,27 @@ func TestServiceAccountOverride(t *testing.T) {
 	// verify
 	assert.Equal(t, "my-special-sa", sa)
 }
+
+func TestServiceAccountLabelsAndAnnotations(t *testing.T) {
+	// prepare
+	params := manifests.Params{
+		OtelCol: v1alpha1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-instance",
+				Namespace: "observability",
+			},
+			Spec: v1alpha1.OpenTelemetryCollectorSpec{
+				ServiceAccountAnnotations: map[string]string{"eks.amazonaws.com/role-arn": "arn:aws:iam::123456789012:role/collector"},
+				ServiceAccountLabels:      map[string]string{"azure.workload.identity/use": "true"},
+			},
+		},
+	}
+
+	// test
+	sa := ServiceAccount(params)
+
+	// verify
+	assert.Equal(t, "arn:aws:iam::123456789012:role/collector", sa.Annotations["eks.amazonaws.com/role-arn"])
+	assert.Equal(t, "true", sa.Labels["azure.workload.identity/use"])
+	assert.Equal(t, "opentelemetry-operator", sa.Labels["app.kubernetes.io/managed-by"])
+}
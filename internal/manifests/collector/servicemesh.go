@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect"
+)
+
+const (
+	istioExcludeInboundPortsAnnotation = "traffic.sidecar.istio.io/excludeInboundPorts"
+	istioProxyConfigAnnotation         = "proxy.istio.io/config"
+	linkerdSkipInboundPortsAnnotation  = "config.linkerd.io/skip-inbound-ports"
+	linkerdProxyAwaitAnnotation        = "config.linkerd.io/proxy-await"
+)
+
+// addServiceMeshAnnotations sets the annotations keeping the proxy of the service mesh from dropping the telemetry
+// sent to the receivers, and delaying the start of the collector until the proxy is ready. The annotations already
+// set on the pods, e.g. through the podAnnotations, take precedence.
+func addServiceMeshAnnotations(params manifests.Params, podAnnotations map[string]string) {
+	spec := params.OtelCol.Spec.ServiceMesh
+	meshType := serviceMeshType(params)
+
+	annotations := map[string]string{}
+	ports := ""
+	if spec.ExcludeReceiverPorts == nil || *spec.ExcludeReceiverPorts {
+		ports = receiverTargetPorts(params)
+	}
+	switch meshType {
+	case v1alpha1.ServiceMeshTypeIstio:
+		if ports != "" {
+			annotations[istioExcludeInboundPortsAnnotation] = ports
+		}
+		if spec.HoldApplicationUntilProxyStarts {
+			annotations[istioProxyConfigAnnotation] = `{"holdApplicationUntilProxyStarts":true}`
+		}
+	case v1alpha1.ServiceMeshTypeLinkerd:
+		if ports != "" {
+			annotations[linkerdSkipInboundPortsAnnotation] = ports
+		}
+		if spec.HoldApplicationUntilProxyStarts {
+			annotations[linkerdProxyAwaitAnnotation] = "enabled"
+		}
+	}
+
+	for k, v := range annotations {
+		if _, found := podAnnotations[k]; !found {
+			podAnnotations[k] = v
+		}
+	}
+}
+
+// serviceMeshType returns the service mesh the collector runs in, as set in its spec or detected in the cluster.
+func serviceMeshType(params manifests.Params) v1alpha1.ServiceMeshType {
+	if params.OtelCol.Spec.ServiceMesh.Type != "" {
+		return params.OtelCol.Spec.ServiceMesh.Type
+	}
+	switch params.Config.ServiceMesh() {
+	case autodetect.ServiceMeshIstio:
+		return v1alpha1.ServiceMeshTypeIstio
+	case autodetect.ServiceMeshLinkerd:
+		return v1alpha1.ServiceMeshTypeLinkerd
+	default:
+		return v1alpha1.ServiceMeshTypeNone
+	}
+}
+
+// meshPortNames renames the ports with a known application protocol after the "<protocol>-<suffix>" convention of the
+// service meshes when the collector runs in one, e.g. otlp-grpc becomes grpc-otlp, so that the proxies of the clients
+// pick the right protocol even when they don't look at the appProtocol. The given ports are left untouched.
+func meshPortNames(params manifests.Params, ports []corev1.ServicePort) []corev1.ServicePort {
+	if serviceMeshType(params) == v1alpha1.ServiceMeshTypeNone {
+		return ports
+	}
+
+	used := map[string]bool{}
+	for _, p := range ports {
+		used[p.Name] = true
+	}
+	renamed := make([]corev1.ServicePort, len(ports))
+	for i, p := range ports {
+		renamed[i] = p
+		if name := meshPortName(p, used); name != p.Name {
+			delete(used, p.Name)
+			used[name] = true
+			renamed[i].Name = name
+		}
+	}
+	return renamed
+}
+
+// meshPortName returns the name of the given port prefixed with its application protocol, falling back to the protocol
+// and the port number when the prefixed name is taken or too long to be a container port name, which the ingress
+// requires. The current name is kept when the port has no application protocol or already follows the convention.
+func meshPortName(port corev1.ServicePort, used map[string]bool) string {
+	if port.AppProtocol == nil || *port.AppProtocol == "" {
+		return port.Name
+	}
+	protocol := strings.ToLower(*port.AppProtocol)
+	if port.Name == protocol || strings.HasPrefix(port.Name, protocol+"-") {
+		return port.Name
+	}
+
+	var candidates []string
+	if base := strings.TrimSuffix(port.Name, "-"+protocol); base != fmt.Sprintf("port-%d", port.Port) {
+		candidates = append(candidates, fmt.Sprintf("%s-%s", protocol, base))
+	}
+	candidates = append(candidates, fmt.Sprintf("%s-%d", protocol, port.Port))
+	for _, candidate := range candidates {
+		if len(candidate) <= 15 && len(validation.IsDNS1123Label(candidate)) == 0 && !used[candidate] {
+			return candidate
+		}
+	}
+	return port.Name
+}
+
+// receiverTargetPorts returns the comma-separated container ports the receivers listen on.
+func receiverTargetPorts(params manifests.Params) string {
+	unique := map[int]bool{}
+	for _, p := range servicePortsFromCfg(params) {
+		port := int(p.Port)
+		if p.TargetPort.IntVal != 0 {
+			port = int(p.TargetPort.IntVal)
+		}
+		unique[port] = true
+	}
+
+	ports := make([]int, 0, len(unique))
+	for port := range unique {
+		ports = append(ports, port)
+	}
+	sort.Ints(ports)
+	portStrs := make([]string, len(ports))
+	for i, port := range ports {
+		portStrs[i] = strconv.Itoa(port)
+	}
+	return strings.Join(portStrs, ",")
+}
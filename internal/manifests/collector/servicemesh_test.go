@@ -0,0 +1,151 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect"
+)
+
+func TestServiceMeshAnnotations(t *testing.T) {
+	disabled := false
+	for _, tt := range []struct {
+		desc           string
+		detected       autodetect.ServiceMesh
+		serviceMesh    v1alpha1.ServiceMeshSpec
+		podAnnotations map[string]string
+		expected       map[string]string
+	}{
+		{
+			desc:     "no service mesh",
+			expected: map[string]string{},
+		},
+		{
+			desc:     "detected istio",
+			detected: autodetect.ServiceMeshIstio,
+			expected: map[string]string{"traffic.sidecar.istio.io/excludeInboundPorts": "80,14250"},
+		},
+		{
+			desc:        "detected mesh overridden",
+			detected:    autodetect.ServiceMeshIstio,
+			serviceMesh: v1alpha1.ServiceMeshSpec{Type: v1alpha1.ServiceMeshTypeNone},
+			expected:    map[string]string{},
+		},
+		{
+			desc:        "linkerd holding the application",
+			serviceMesh: v1alpha1.ServiceMeshSpec{Type: v1alpha1.ServiceMeshTypeLinkerd, HoldApplicationUntilProxyStarts: true},
+			expected: map[string]string{
+				"config.linkerd.io/skip-inbound-ports": "80,14250",
+				"config.linkerd.io/proxy-await":        "enabled",
+			},
+		},
+		{
+			desc:        "istio holding the application without excluding the ports",
+			serviceMesh: v1alpha1.ServiceMeshSpec{Type: v1alpha1.ServiceMeshTypeIstio, ExcludeReceiverPorts: &disabled, HoldApplicationUntilProxyStarts: true},
+			expected:    map[string]string{"proxy.istio.io/config": `{"holdApplicationUntilProxyStarts":true}`},
+		},
+		{
+			desc:           "pod annotations take precedence",
+			detected:       autodetect.ServiceMeshIstio,
+			podAnnotations: map[string]string{"traffic.sidecar.istio.io/excludeInboundPorts": "4317"},
+			expected:       map[string]string{"traffic.sidecar.istio.io/excludeInboundPorts": "4317"},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			params := deploymentParams()
+			params.Config = config.New(config.WithServiceMesh(tt.detected))
+			params.OtelCol.Spec.ServiceMesh = tt.serviceMesh
+			podAnnotations := map[string]string{}
+			for k, v := range tt.podAnnotations {
+				podAnnotations[k] = v
+			}
+
+			// test
+			addServiceMeshAnnotations(params, podAnnotations)
+
+			// verify
+			assert.Equal(t, tt.expected, podAnnotations)
+		})
+	}
+}
+
+func TestMeshPortNames(t *testing.T) {
+	grpc, http := "grpc", "http"
+	ports := []corev1.ServicePort{
+		{Name: "otlp-grpc", Port: 4317, AppProtocol: &grpc},
+		{Name: "otlp-http", Port: 4318, AppProtocol: &http},
+		{Name: "zipkin", Port: 9411, AppProtocol: &http},
+		{Name: "http-custom", Port: 8080, AppProtocol: &http},
+		{Name: "port-9999", Port: 9999, AppProtocol: &http},
+		{Name: "jaeger-thrift-http", Port: 14268, AppProtocol: &http},
+		{Name: "statsd", Port: 8125, Protocol: corev1.ProtocolUDP},
+	}
+	for _, tt := range []struct {
+		desc     string
+		detected autodetect.ServiceMesh
+		expected []string
+	}{
+		{
+			desc:     "no service mesh",
+			expected: []string{"otlp-grpc", "otlp-http", "zipkin", "http-custom", "port-9999", "jaeger-thrift-http", "statsd"},
+		},
+		{
+			desc:     "istio",
+			detected: autodetect.ServiceMeshIstio,
+			expected: []string{"grpc-otlp", "http-otlp", "http-zipkin", "http-custom", "http-9999", "http-14268", "statsd"},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			params := deploymentParams()
+			params.Config = config.New(config.WithServiceMesh(tt.detected))
+
+			// test
+			renamed := meshPortNames(params, ports)
+
+			// verify
+			names := make([]string, len(renamed))
+			for i, p := range renamed {
+				names[i] = p.Name
+			}
+			assert.Equal(t, tt.expected, names)
+			assert.Equal(t, "otlp-grpc", ports[0].Name, "the given ports should be left untouched")
+		})
+	}
+
+	t.Run("keeps the names unique", func(t *testing.T) {
+		// prepare
+		params := deploymentParams()
+		params.OtelCol.Spec.ServiceMesh.Type = v1alpha1.ServiceMeshTypeLinkerd
+		taken := []corev1.ServicePort{
+			{Name: "otlp-grpc", Port: 4317, AppProtocol: &grpc},
+			{Name: "grpc-otlp", Port: 4319},
+		}
+
+		// test
+		renamed := meshPortNames(params, taken)
+
+		// verify
+		assert.Equal(t, "grpc-4317", renamed[0].Name)
+		assert.Equal(t, "grpc-otlp", renamed[1].Name)
+	})
+}
@@ -41,9 +41,9 @@ func ServiceMonitor(params manifests.Params) (*monitoringv1.ServiceMonitor, erro
 	sm := monitoringv1.ServiceMonitor{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: params.OtelCol.Namespace,
-			Name:      naming.ServiceMonitor(params.OtelCol.Name),
+			Name:      naming.ServiceMonitor(&params.OtelCol),
 			Labels: map[string]string{
-				"app.kubernetes.io/name":       naming.ServiceMonitor(params.OtelCol.Name),
+				"app.kubernetes.io/name":       naming.ServiceMonitor(&params.OtelCol),
 				"app.kubernetes.io/instance":   fmt.Sprintf("%s.%s", params.OtelCol.Namespace, params.OtelCol.Name),
 				"app.kubernetes.io/managed-by": "opentelemetry-operator",
 			},
@@ -53,10 +53,13 @@ func ServiceMonitor(params manifests.Params) (*monitoringv1.ServiceMonitor, erro
 			NamespaceSelector: monitoringv1.NamespaceSelector{
 				MatchNames: []string{params.OtelCol.Namespace},
 			},
+			// only the monitoring service and the service of the prometheus exporters are selected, the other services
+			// of the collector expose the ports of the prometheus exporters too, which would be scraped several times
 			Selector: metav1.LabelSelector{
 				MatchLabels: map[string]string{
 					"app.kubernetes.io/managed-by": "opentelemetry-operator",
 					"app.kubernetes.io/instance":   fmt.Sprintf("%s.%s", params.OtelCol.Namespace, params.OtelCol.Name),
+					metricsServiceLabel:            metricsServiceExists,
 				},
 			},
 		},
@@ -47,4 +47,5 @@ func TestDesiredServiceMonitors(t *testing.T) {
 	assert.Equal(t, "monitoring", actual.Spec.Endpoints[0].Port)
 	assert.Equal(t, "prometheus-dev", actual.Spec.Endpoints[1].Port)
 	assert.Equal(t, "prometheus-prod", actual.Spec.Endpoints[2].Port)
+	assert.Equal(t, metricsServiceExists, actual.Spec.Selector.MatchLabels[metricsServiceLabel])
 }
@@ -0,0 +1,297 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+const (
+	// SpiffeHelperConfigMapEntry is the entry of the config map holding the configuration of the spiffe-helper.
+	SpiffeHelperConfigMapEntry = "spiffe-helper.conf"
+
+	spiffeHelperContainerName     = "spiffe-helper"
+	spiffeHelperInitContainerName = "spiffe-helper-init"
+	spiffeWorkloadAPIVolume       = "spiffe-workload-api"
+	spiffeWorkloadAPIDir          = "/spiffe-workload-api"
+	spiffeCertsVolume             = "spiffe-certs"
+	spiffeCertsDir                = "/var/run/spiffe/certs"
+	spiffeHelperConfigVolume      = "spiffe-helper-config"
+	spiffeHelperConfigDir         = "/etc/spiffe-helper"
+	spiffeDefaultCSIDriver        = "csi.spiffe.io"
+	spiffeDefaultSocketName       = "spire-agent.sock"
+	spiffeSVIDFile                = "svid.pem"
+	spiffeSVIDKeyFile             = "svid_key.pem"
+	spiffeBundleFile              = "svid_bundle.pem"
+	// spiffeReloadInterval is how often the collector reloads the certificates rotated by the spiffe-helper.
+	spiffeReloadInterval = "5m"
+)
+
+func spiffeEnabled(otelcol v1alpha1.OpenTelemetryCollector) bool {
+	return otelcol.Spec.Spiffe.Enabled && otelcol.Spec.Mode != v1alpha1.ModeSidecar
+}
+
+// SpiffeHelperConfig returns the configuration of the spiffe-helper, writing the SVID of the collector and the trust
+// bundle of its trust domain into the directory shared with the collector container.
+func SpiffeHelperConfig(otelcol v1alpha1.OpenTelemetryCollector) string {
+	socketName := otelcol.Spec.Spiffe.SocketName
+	if socketName == "" {
+		socketName = spiffeDefaultSocketName
+	}
+	return fmt.Sprintf(`agent_address = "%s"
+cert_dir = "%s"
+svid_file_name = "%s"
+svid_key_file_name = "%s"
+svid_bundle_file_name = "%s"
+`, path.Join(spiffeWorkloadAPIDir, socketName), spiffeCertsDir, spiffeSVIDFile, spiffeSVIDKeyFile, spiffeBundleFile)
+}
+
+// spiffeVolumes returns the volumes of the Workload API socket, of the certificates and of the configuration of the
+// spiffe-helper.
+func spiffeVolumes(otelcol v1alpha1.OpenTelemetryCollector) []corev1.Volume {
+	if !spiffeEnabled(otelcol) {
+		return nil
+	}
+	driver := otelcol.Spec.Spiffe.CSIDriver
+	if driver == "" {
+		driver = spiffeDefaultCSIDriver
+	}
+	readOnly := true
+	return []corev1.Volume{
+		{
+			Name: spiffeWorkloadAPIVolume,
+			VolumeSource: corev1.VolumeSource{
+				CSI: &corev1.CSIVolumeSource{Driver: driver, ReadOnly: &readOnly},
+			},
+		},
+		{
+			Name: spiffeCertsVolume,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory},
+			},
+		},
+		{
+			Name: spiffeHelperConfigVolume,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: naming.ConfigMap(&otelcol)},
+					Items: []corev1.KeyToPath{{
+						Key:  SpiffeHelperConfigMapEntry,
+						Path: SpiffeHelperConfigMapEntry,
+					}},
+				},
+			},
+		},
+	}
+}
+
+// spiffeVolumeMounts returns the volume mounts of the certificates written by the spiffe-helper in the collector
+// container.
+func spiffeVolumeMounts(otelcol v1alpha1.OpenTelemetryCollector) []corev1.VolumeMount {
+	if !spiffeEnabled(otelcol) {
+		return nil
+	}
+	return []corev1.VolumeMount{{Name: spiffeCertsVolume, MountPath: spiffeCertsDir, ReadOnly: true}}
+}
+
+// spiffeHelperContainer returns the spiffe-helper container. The init container writes the first SVID before the
+// collector starts, the sidecar one rotates it. They share the security context of the collector, so that the
+// collector can read the key written by the spiffe-helper.
+func spiffeHelperContainer(params manifests.Params, init bool) corev1.Container {
+	image := params.OtelCol.Spec.Spiffe.HelperImage
+	if image == "" {
+		image = params.Config.SpiffeHelperImage()
+	}
+	name := spiffeHelperContainerName
+	args := []string{"-config", path.Join(spiffeHelperConfigDir, SpiffeHelperConfigMapEntry)}
+	if init {
+		name = spiffeHelperInitContainerName
+		args = append(args, "-daemon-mode=false")
+	}
+	return corev1.Container{
+		Name:  name,
+		Image: image,
+		Args:  args,
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: spiffeWorkloadAPIVolume, MountPath: spiffeWorkloadAPIDir, ReadOnly: true},
+			{Name: spiffeCertsVolume, MountPath: spiffeCertsDir},
+			{Name: spiffeHelperConfigVolume, MountPath: spiffeHelperConfigDir, ReadOnly: true},
+		},
+		Resources:       manifestutils.Resources(params.Config, corev1.ResourceRequirements{}),
+		SecurityContext: manifestutils.SecurityContext(params.Config, params.OtelCol.Spec.SecurityContext),
+	}
+}
+
+// InitContainers returns the init containers of the pods of the collector.
+func InitContainers(params manifests.Params) []corev1.Container {
+	var operatorContainers []corev1.Container
+	if spiffeEnabled(params.OtelCol) {
+		operatorContainers = append(operatorContainers, spiffeHelperContainer(params, true))
+	}
+	if drainEnabled(params.OtelCol) {
+		operatorContainers = append(operatorContainers, drainInitContainer(params))
+	}
+	if len(operatorContainers) == 0 {
+		return params.OtelCol.Spec.InitContainers
+	}
+	containers := append([]corev1.Container{}, params.OtelCol.Spec.InitContainers...)
+	return append(containers, operatorContainers...)
+}
+
+// Containers returns the containers of the pods of the collector, the collector container being the last one.
+func Containers(params manifests.Params) []corev1.Container {
+	if !spiffeEnabled(params.OtelCol) {
+		return append(params.OtelCol.Spec.AdditionalContainers, Container(params.Config, params.Log, params.OtelCol, true))
+	}
+	containers := append([]corev1.Container{}, params.OtelCol.Spec.AdditionalContainers...)
+	return append(containers, spiffeHelperContainer(params, false), Container(params.Config, params.Log, params.OtelCol, true))
+}
+
+// AddSpiffeTLS sets the TLS settings of the selected receivers and exporters to the SVID and trust bundle written by
+// the spiffe-helper. The receivers require the clients to present an SVID of the trust domain. The TLS settings
+// already set in the configuration take precedence.
+func AddSpiffeTLS(cfg string, spiffe v1alpha1.SpiffeSpec) (string, error) {
+	config, err := adapters.ConfigFromString(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	serverTLS := map[interface{}]interface{}{
+		"cert_file":             path.Join(spiffeCertsDir, spiffeSVIDFile),
+		"key_file":              path.Join(spiffeCertsDir, spiffeSVIDKeyFile),
+		"client_ca_file":        path.Join(spiffeCertsDir, spiffeBundleFile),
+		"client_ca_file_reload": true,
+		"reload_interval":       spiffeReloadInterval,
+	}
+	clientTLS := map[interface{}]interface{}{
+		"cert_file":       path.Join(spiffeCertsDir, spiffeSVIDFile),
+		"key_file":        path.Join(spiffeCertsDir, spiffeSVIDKeyFile),
+		"ca_file":         path.Join(spiffeCertsDir, spiffeBundleFile),
+		"reload_interval": spiffeReloadInterval,
+	}
+
+	if err := setComponentsTLS(config, "receivers", spiffe.Receivers, []string{"otlp"}, serverTLS); err != nil {
+		return "", err
+	}
+	if err := setComponentsTLS(config, "exporters", spiffe.Exporters, []string{"otlp", "otlphttp"}, clientTLS); err != nil {
+		return "", err
+	}
+
+	out, err := manifestutils.MarshalYAML(config)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// setComponentsTLS sets the TLS settings of the components of the kind (receivers or exporters) selected by their
+// names, or by their types when no name is given. The TLS settings of the otlp receivers are set on each protocol.
+func setComponentsTLS(config map[interface{}]interface{}, kind string, names []string, defaultTypes []string, tls map[interface{}]interface{}) error {
+	components, ok := config[kind].(map[interface{}]interface{})
+	if !ok {
+		if config[kind] != nil {
+			return fmt.Errorf("the %s aren't a map", kind)
+		}
+		return nil
+	}
+	for key, value := range components {
+		name, ok := key.(string)
+		if !ok || !spiffeSelected(name, names, defaultTypes) {
+			continue
+		}
+		settings, ok := value.(map[interface{}]interface{})
+		if !ok {
+			if value != nil {
+				return fmt.Errorf("the %s %s isn't a map", kind, name)
+			}
+			settings = map[interface{}]interface{}{}
+			components[key] = settings
+		}
+		if kind != "receivers" || componentType(name) != "otlp" {
+			if err := setTLS(settings, tls); err != nil {
+				return fmt.Errorf("the %s %s: %w", kind, name, err)
+			}
+			continue
+		}
+		protocols, ok := settings["protocols"].(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+		for protocol, protocolSettings := range protocols {
+			ps, ok := protocolSettings.(map[interface{}]interface{})
+			if !ok {
+				if protocolSettings != nil {
+					return fmt.Errorf("the %s %s protocol %v isn't a map", kind, name, protocol)
+				}
+				ps = map[interface{}]interface{}{}
+				protocols[protocol] = ps
+			}
+			if err := setTLS(ps, tls); err != nil {
+				return fmt.Errorf("the %s %s protocol %v: %w", kind, name, protocol, err)
+			}
+		}
+	}
+	return nil
+}
+
+func setTLS(settings map[interface{}]interface{}, tls map[interface{}]interface{}) error {
+	existing, ok := settings["tls"].(map[interface{}]interface{})
+	if !ok {
+		if settings["tls"] != nil {
+			return errors.New("the tls settings aren't a map")
+		}
+		existing = map[interface{}]interface{}{}
+		settings["tls"] = existing
+	}
+	for k, v := range tls {
+		if _, found := existing[k]; !found {
+			existing[k] = v
+		}
+	}
+	return nil
+}
+
+func spiffeSelected(name string, names []string, defaultTypes []string) bool {
+	if len(names) > 0 {
+		for _, n := range names {
+			if n == name {
+				return true
+			}
+		}
+		return false
+	}
+	for _, t := range defaultTypes {
+		if componentType(name) == t {
+			return true
+		}
+	}
+	return false
+}
+
+// componentType returns the type of a component from its name, e.g. otlp for otlp/2.
+func componentType(name string) string {
+	return strings.SplitN(name, "/", 2)[0]
+}
@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func TestAddSpiffeTLS(t *testing.T) {
+	cfg := `receivers:
+  otlp:
+    protocols:
+      grpc:
+      http:
+        tls:
+          cert_file: /certs/custom.pem
+  jaeger:
+    protocols:
+      grpc:
+exporters:
+  otlp:
+    endpoint: gateway:4317
+  otlphttp/backend:
+    endpoint: https://backend:4318
+  debug:
+`
+
+	t.Run("default receivers and exporters", func(t *testing.T) {
+		// test
+		out, err := AddSpiffeTLS(cfg, v1alpha1.SpiffeSpec{Enabled: true})
+		require.NoError(t, err)
+
+		// verify
+		config := map[string]interface{}{}
+		require.NoError(t, yaml.Unmarshal([]byte(out), &config))
+		receivers := config["receivers"].(map[interface{}]interface{})
+		otlp := receivers["otlp"].(map[interface{}]interface{})["protocols"].(map[interface{}]interface{})
+		grpcTLS := otlp["grpc"].(map[interface{}]interface{})["tls"].(map[interface{}]interface{})
+		assert.Equal(t, "/var/run/spiffe/certs/svid.pem", grpcTLS["cert_file"])
+		assert.Equal(t, "/var/run/spiffe/certs/svid_key.pem", grpcTLS["key_file"])
+		assert.Equal(t, "/var/run/spiffe/certs/svid_bundle.pem", grpcTLS["client_ca_file"])
+		// the settings of the configuration take precedence
+		httpTLS := otlp["http"].(map[interface{}]interface{})["tls"].(map[interface{}]interface{})
+		assert.Equal(t, "/certs/custom.pem", httpTLS["cert_file"])
+		assert.Equal(t, "/var/run/spiffe/certs/svid_key.pem", httpTLS["key_file"])
+		assert.NotContains(t, receivers["jaeger"], "tls")
+
+		exporters := config["exporters"].(map[interface{}]interface{})
+		for _, name := range []string{"otlp", "otlphttp/backend"} {
+			tls := exporters[name].(map[interface{}]interface{})["tls"].(map[interface{}]interface{})
+			assert.Equal(t, "/var/run/spiffe/certs/svid.pem", tls["cert_file"], name)
+			assert.Equal(t, "/var/run/spiffe/certs/svid_bundle.pem", tls["ca_file"], name)
+		}
+		assert.Nil(t, exporters["debug"])
+	})
+
+	t.Run("selected receivers and exporters", func(t *testing.T) {
+		// test
+		out, err := AddSpiffeTLS(cfg, v1alpha1.SpiffeSpec{Enabled: true, Receivers: []string{"jaeger"}, Exporters: []string{"otlphttp/backend"}})
+		require.NoError(t, err)
+
+		// verify
+		config := map[string]interface{}{}
+		require.NoError(t, yaml.Unmarshal([]byte(out), &config))
+		receivers := config["receivers"].(map[interface{}]interface{})
+		assert.Contains(t, receivers["jaeger"], "tls")
+		grpc := receivers["otlp"].(map[interface{}]interface{})["protocols"].(map[interface{}]interface{})["grpc"]
+		assert.Nil(t, grpc)
+		exporters := config["exporters"].(map[interface{}]interface{})
+		assert.NotContains(t, exporters["otlp"], "tls")
+		assert.Contains(t, exporters["otlphttp/backend"], "tls")
+	})
+}
+
+func TestSpiffeDeployment(t *testing.T) {
+	// prepare
+	params := deploymentParams()
+	params.Config = config.New(config.WithCollectorImage(defaultCollectorImage), config.WithSpiffeHelperImage("spiffe-helper:test"))
+	params.OtelCol.Spec.Spiffe = v1alpha1.SpiffeSpec{Enabled: true, SocketName: "agent.sock"}
+	params.OtelCol.Spec.InitContainers = []corev1.Container{{Name: "init"}}
+
+	// test
+	d := Deployment(params)
+	cm := ConfigMap(params)
+
+	// verify
+	pod := d.Spec.Template.Spec
+	require.Len(t, pod.InitContainers, 2)
+	assert.Equal(t, "spiffe-helper-init", pod.InitContainers[1].Name)
+	assert.Equal(t, "spiffe-helper:test", pod.InitContainers[1].Image)
+	assert.Contains(t, pod.InitContainers[1].Args, "-daemon-mode=false")
+	require.Len(t, pod.Containers, 2)
+	assert.Equal(t, "spiffe-helper", pod.Containers[0].Name)
+	assert.Equal(t, "otc-container", pod.Containers[1].Name)
+	assert.Contains(t, pod.Containers[1].VolumeMounts, corev1.VolumeMount{Name: "spiffe-certs", MountPath: "/var/run/spiffe/certs", ReadOnly: true})
+	var csi *corev1.CSIVolumeSource
+	for _, v := range pod.Volumes {
+		if v.Name == "spiffe-workload-api" {
+			csi = v.CSI
+		}
+	}
+	require.NotNil(t, csi)
+	assert.Equal(t, "csi.spiffe.io", csi.Driver)
+	assert.Contains(t, cm.Data[SpiffeHelperConfigMapEntry], `agent_address = "/spiffe-workload-api/agent.sock"`)
+	assert.Len(t, params.OtelCol.Spec.InitContainers, 1)
+}
@@ -19,6 +19,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
@@ -26,11 +27,12 @@ import (
 
 // StatefulSet builds the statefulset for the given instance.
 func StatefulSet(params manifests.Params) *appsv1.StatefulSet {
-	name := naming.Collector(params.OtelCol.Name)
-	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter())
+	name := naming.Collector(&params.OtelCol)
+	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter(), params.OtelCol.Spec.StandardLabels)
 
-	annotations := Annotations(params.OtelCol)
-	podAnnotations := PodAnnotations(params.OtelCol)
+	annotations := WorkloadAnnotations(params.OtelCol, params.Config.AnnotationsFilter())
+	podAnnotations := PodAnnotations(params.OtelCol, params.Config.AnnotationsFilter())
+	addServiceMeshAnnotations(params, podAnnotations)
 
 	return &appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
@@ -40,9 +42,9 @@ func StatefulSet(params manifests.Params) *appsv1.StatefulSet {
 			Annotations: annotations,
 		},
 		Spec: appsv1.StatefulSetSpec{
-			ServiceName: naming.Service(params.OtelCol.Name),
+			ServiceName: statefulSetServiceName(params.OtelCol),
 			Selector: &metav1.LabelSelector{
-				MatchLabels: manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollector),
+				MatchLabels: manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollector, params.OtelCol.Spec.StandardLabels),
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
@@ -50,23 +52,34 @@ func StatefulSet(params manifests.Params) *appsv1.StatefulSet {
 					Annotations: podAnnotations,
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName:        ServiceAccountName(params.OtelCol),
-					InitContainers:            params.OtelCol.Spec.InitContainers,
-					Containers:                append(params.OtelCol.Spec.AdditionalContainers, Container(params.Config, params.Log, params.OtelCol, true)),
-					Volumes:                   Volumes(params.Config, params.OtelCol),
-					DNSPolicy:                 getDNSPolicy(params.OtelCol),
-					HostNetwork:               params.OtelCol.Spec.HostNetwork,
-					Tolerations:               params.OtelCol.Spec.Tolerations,
-					NodeSelector:              params.OtelCol.Spec.NodeSelector,
-					SecurityContext:           params.OtelCol.Spec.PodSecurityContext,
-					PriorityClassName:         params.OtelCol.Spec.PriorityClassName,
-					Affinity:                  params.OtelCol.Spec.Affinity,
-					TopologySpreadConstraints: params.OtelCol.Spec.TopologySpreadConstraints,
+					ServiceAccountName:            ServiceAccountName(params.OtelCol),
+					InitContainers:                InitContainers(params),
+					Containers:                    Containers(params),
+					Volumes:                       Volumes(params.Config, params.OtelCol),
+					DNSPolicy:                     getDNSPolicy(params.OtelCol),
+					HostNetwork:                   params.OtelCol.Spec.HostNetwork,
+					Tolerations:                   manifestutils.Tolerations(params.Config, params.OtelCol.Spec.Tolerations),
+					NodeSelector:                  manifestutils.NodeSelector(params.Config, params.OtelCol.Spec.NodeSelector),
+					SecurityContext:               params.OtelCol.Spec.PodSecurityContext,
+					PriorityClassName:             params.OtelCol.Spec.PriorityClassName,
+					Affinity:                      affinity(params.OtelCol),
+					TopologySpreadConstraints:     params.OtelCol.Spec.TopologySpreadConstraints,
+					TerminationGracePeriodSeconds: terminationGracePeriodSeconds(params.OtelCol),
 				},
 			},
-			Replicas:             params.OtelCol.Spec.Replicas,
-			PodManagementPolicy:  "Parallel",
-			VolumeClaimTemplates: VolumeClaimTemplates(params.OtelCol),
+			Replicas:                             params.OtelCol.Spec.Replicas,
+			PodManagementPolicy:                  podManagementPolicy(params.OtelCol),
+			MinReadySeconds:                      params.OtelCol.Spec.StatefulSet.MinReadySeconds,
+			VolumeClaimTemplates:                 VolumeClaimTemplates(params.OtelCol),
+			PersistentVolumeClaimRetentionPolicy: params.OtelCol.Spec.PersistentVolumeClaimRetentionPolicy,
 		},
 	}
 }
+
+// podManagementPolicy returns the policy of the statefulset, starting the replicas in parallel unless it's set.
+func podManagementPolicy(otelcol v1alpha1.OpenTelemetryCollector) appsv1.PodManagementPolicyType {
+	if otelcol.Spec.StatefulSet.PodManagementPolicy != "" {
+		return otelcol.Spec.StatefulSet.PodManagementPolicy
+	}
+	return appsv1.ParallelPodManagement
+}
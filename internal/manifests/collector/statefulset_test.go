@@ -129,6 +129,33 @@ func TestStatefulSetReplicas(t *testing.T) {
 	assert.Equal(t, int32(3), *ss.Spec.Replicas)
 }
 
+func TestStatefulSetPodManagementPolicyAndMinReadySeconds(t *testing.T) {
+	// prepare
+	params := manifests.Params{
+		OtelCol: v1alpha1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "my-instance",
+			},
+			Spec: v1alpha1.OpenTelemetryCollectorSpec{
+				Mode: "statefulset",
+				StatefulSet: v1alpha1.StatefulSetSpec{
+					PodManagementPolicy: appsv1.OrderedReadyPodManagement,
+					MinReadySeconds:     30,
+				},
+			},
+		},
+		Config: config.New(),
+		Log:    logger,
+	}
+
+	// test
+	ss := StatefulSet(params)
+
+	// verify
+	assert.Equal(t, appsv1.OrderedReadyPodManagement, ss.Spec.PodManagementPolicy)
+	assert.Equal(t, int32(30), ss.Spec.MinReadySeconds)
+}
+
 func TestStatefulSetVolumeClaimTemplates(t *testing.T) {
 	// prepare
 	otelcol := v1alpha1.OpenTelemetryCollector{
@@ -171,6 +198,33 @@ func TestStatefulSetVolumeClaimTemplates(t *testing.T) {
 	assert.Equal(t, resource.MustParse("1Gi"), ss.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests["storage"])
 }
 
+func TestStatefulSetPersistentVolumeClaimRetentionPolicy(t *testing.T) {
+	// prepare
+	policy := &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
+		WhenDeleted: appsv1.DeletePersistentVolumeClaimRetentionPolicyType,
+		WhenScaled:  appsv1.RetainPersistentVolumeClaimRetentionPolicyType,
+	}
+	params := manifests.Params{
+		OtelCol: v1alpha1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "my-instance",
+			},
+			Spec: v1alpha1.OpenTelemetryCollectorSpec{
+				Mode:                                 "statefulset",
+				PersistentVolumeClaimRetentionPolicy: policy,
+			},
+		},
+		Config: config.New(),
+		Log:    logger,
+	}
+
+	// test
+	ss := StatefulSet(params)
+
+	// verify
+	assert.Equal(t, policy, ss.Spec.PersistentVolumeClaimRetentionPolicy)
+}
+
 func TestStatefulSetPodAnnotations(t *testing.T) {
 	// prepare
 	testPodAnnotationValues := map[string]string{"annotation-key": "annotation-value"}
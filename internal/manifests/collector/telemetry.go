@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+)
+
+const (
+	defaultMetricsPort int32 = 8888
+	defaultMetricsHost       = "0.0.0.0"
+)
+
+// AddTelemetry merges the internal telemetry set in the spec into the service::telemetry section of the configuration.
+// The configuration is returned as is when the spec doesn't set any field.
+func AddTelemetry(cfg string, telemetry v1alpha1.TelemetrySpec) (string, error) {
+	if telemetry == (v1alpha1.TelemetrySpec{}) {
+		return cfg, nil
+	}
+	config, err := adapters.ConfigFromString(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	service, err := configSection(config, "service")
+	if err != nil {
+		return "", err
+	}
+	section, err := configSection(service, "telemetry")
+	if err != nil {
+		return "", err
+	}
+
+	if telemetry.Metrics != (v1alpha1.TelemetryMetricsSpec{}) {
+		metrics, err := configSection(section, "metrics")
+		if err != nil {
+			return "", err
+		}
+		if telemetry.Metrics.Level != "" {
+			metrics["level"] = string(telemetry.Metrics.Level)
+		}
+		if telemetry.Metrics.Port != 0 {
+			// keep the host of the address, which may be an env var like ${env:MY_POD_IP}
+			host := defaultMetricsHost + ":"
+			if address, ok := metrics["address"].(string); ok {
+				if i := strings.LastIndex(address, ":"); i >= 0 {
+					host = address[:i+1]
+				}
+			}
+			metrics["address"] = host + strconv.Itoa(int(telemetry.Metrics.Port))
+		}
+	}
+	if telemetry.Logs != (v1alpha1.TelemetryLogsSpec{}) {
+		logs, err := configSection(section, "logs")
+		if err != nil {
+			return "", err
+		}
+		if telemetry.Logs.Level != "" {
+			logs["level"] = string(telemetry.Logs.Level)
+		}
+		if telemetry.Logs.Encoding != "" {
+			logs["encoding"] = string(telemetry.Logs.Encoding)
+		}
+	}
+
+	out, err := manifestutils.MarshalYAML(config)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// configSection returns the section of the given name of the configuration, adding it when missing.
+func configSection(config map[interface{}]interface{}, name string) (map[interface{}]interface{}, error) {
+	if config[name] == nil {
+		section := map[interface{}]interface{}{}
+		config[name] = section
+		return section, nil
+	}
+	section, ok := config[name].(map[interface{}]interface{})
+	if !ok {
+		return nil, fmt.Errorf("the %s section of the configuration isn't a map", name)
+	}
+	return section, nil
+}
+
+// MetricsPort returns the port the collector exposes its metrics on, the target port of its monitoring Service.
+func MetricsPort(otelcol v1alpha1.OpenTelemetryCollector) int32 {
+	return metricsPort(logr.Discard(), otelcol)
+}
+
+// metricsPort returns the port the collector exposes its metrics on: the port set in the spec, or else the one of the
+// address set in the configuration, or else the default port of the collector.
+func metricsPort(logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector) int32 {
+	if otelcol.Spec.Telemetry.Metrics.Port != 0 {
+		return otelcol.Spec.Telemetry.Metrics.Port
+	}
+	c, err := adapters.ConfigFromString(otelcol.Spec.Config)
+	if err == nil {
+		var port int32
+		if port, err = adapters.ConfigToMetricsPort(logger, c); err == nil {
+			return port
+		}
+	}
+	logger.V(2).Info("couldn't determine metrics port from configuration, using 8888 default value", "error", err)
+	return defaultMetricsPort
+}
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/adapters"
+)
+
+func TestAddTelemetry(t *testing.T) {
+	t.Run("the configuration is left as is without telemetry", func(t *testing.T) {
+		// prepare
+		cfg := "receivers:\n  otlp:\n"
+
+		// test
+		out, err := AddTelemetry(cfg, v1alpha1.TelemetrySpec{})
+
+		// verify
+		require.NoError(t, err)
+		assert.Equal(t, cfg, out)
+	})
+
+	t.Run("the telemetry of the spec overrides the configuration", func(t *testing.T) {
+		// prepare
+		cfg := `service:
+  telemetry:
+    metrics:
+      address: ${env:MY_POD_IP}:8888
+      level: basic
+    logs:
+      level: info
+      sampling:
+        initial: 10
+`
+		telemetry := v1alpha1.TelemetrySpec{
+			Metrics: v1alpha1.TelemetryMetricsSpec{Level: "detailed", Port: 9090},
+			Logs:    v1alpha1.TelemetryLogsSpec{Level: "warn", Encoding: v1alpha1.LogFormatJSON},
+		}
+
+		// test
+		out, err := AddTelemetry(cfg, telemetry)
+
+		// verify
+		require.NoError(t, err)
+		config, err := adapters.ConfigFromString(out)
+		require.NoError(t, err)
+		section := config["service"].(map[interface{}]interface{})["telemetry"].(map[interface{}]interface{})
+		assert.Equal(t, map[interface{}]interface{}{"address": "${env:MY_POD_IP}:9090", "level": "detailed"}, section["metrics"])
+		assert.Equal(t, map[interface{}]interface{}{
+			"level":    "warn",
+			"encoding": "json",
+			"sampling": map[interface{}]interface{}{"initial": 10},
+		}, section["logs"])
+	})
+
+	t.Run("the missing sections are added", func(t *testing.T) {
+		// test
+		out, err := AddTelemetry("receivers:\n  otlp:\n", v1alpha1.TelemetrySpec{Metrics: v1alpha1.TelemetryMetricsSpec{Port: 9090}})
+
+		// verify
+		require.NoError(t, err)
+		config, err := adapters.ConfigFromString(out)
+		require.NoError(t, err)
+		port, err := adapters.ConfigToMetricsPort(logger, config)
+		require.NoError(t, err)
+		assert.EqualValues(t, 9090, port)
+	})
+
+	t.Run("a service section which isn't a map is an error", func(t *testing.T) {
+		// test
+		_, err := AddTelemetry("service: []\n", v1alpha1.TelemetrySpec{Logs: v1alpha1.TelemetryLogsSpec{Level: "debug"}})
+
+		// verify
+		assert.Error(t, err)
+	})
+}
+
+func TestMetricsPortIsConsistent(t *testing.T) {
+	// prepare
+	params := deploymentParams()
+	params.OtelCol.Spec.Telemetry.Metrics.Port = 9090
+
+	// test
+	c := Container(params.Config, params.Log, params.OtelCol, true)
+	monitoring := MonitoringService(params)
+	annotations := Annotations(params.OtelCol, nil)
+	cm := ConfigMap(params)
+
+	// verify
+	assert.Contains(t, c.Ports, corev1.ContainerPort{Name: "metrics", ContainerPort: 9090, Protocol: corev1.ProtocolTCP})
+	assert.EqualValues(t, 9090, monitoring.Spec.Ports[0].Port)
+	assert.Equal(t, "9090", annotations["prometheus.io/port"])
+	config, err := adapters.ConfigFromString(cm.Data["collector.yaml"])
+	require.NoError(t, err)
+	port, err := adapters.ConfigToMetricsPort(logger, config)
+	require.NoError(t, err)
+	assert.EqualValues(t, 9090, port)
+	assert.NotEqual(t, getConfigMapSHA(params.OtelCol.Spec.Config), annotations["opentelemetry-operator-config/sha256"])
+}
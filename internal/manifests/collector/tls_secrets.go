@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"path"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+// tlsSecrets returns the sorted names of the Secrets the TLS settings of the exporters read their files from by naming
+// convention, leaving out the directories already mounted by the volume mounts of the spec.
+func tlsSecrets(otelcol v1alpha1.OpenTelemetryCollector) []string {
+	if !otelcol.Spec.MountTLSSecrets {
+		return nil
+	}
+	cfg, err := adapters.ConfigFromString(otelcol.Spec.Config)
+	if err != nil {
+		return nil
+	}
+	mounted := map[string]bool{}
+	for _, mount := range otelcol.Spec.VolumeMounts {
+		mounted[path.Clean(mount.MountPath)] = true
+	}
+	if mounted[adapters.TLSSecretsDir] {
+		return nil
+	}
+	var secrets []string
+	for _, file := range adapters.ConfigToTLSFiles(cfg) {
+		secret, ok := file.Secret()
+		if !ok || mounted[path.Join(adapters.TLSSecretsDir, secret)] {
+			continue
+		}
+		mounted[path.Join(adapters.TLSSecretsDir, secret)] = true
+		secrets = append(secrets, secret)
+	}
+	sort.Strings(secrets)
+	return secrets
+}
+
+// TLSSecretVolumes returns the volumes of the Secrets read by the TLS settings of the exporters, when the collector
+// mounts them.
+func TLSSecretVolumes(otelcol v1alpha1.OpenTelemetryCollector) []corev1.Volume {
+	var volumes []corev1.Volume
+	for _, secret := range tlsSecrets(otelcol) {
+		volumes = append(volumes, corev1.Volume{
+			Name:         naming.TLSSecretVolume(secret),
+			VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: secret}},
+		})
+	}
+	return volumes
+}
+
+// tlsSecretVolumeMounts returns the volume mounts of the volumes returned by TLSSecretVolumes in the collector
+// container.
+func tlsSecretVolumeMounts(otelcol v1alpha1.OpenTelemetryCollector) []corev1.VolumeMount {
+	var mounts []corev1.VolumeMount
+	for _, secret := range tlsSecrets(otelcol) {
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      naming.TLSSecretVolume(secret),
+			MountPath: path.Join(adapters.TLSSecretsDir, secret),
+			ReadOnly:  true,
+		})
+	}
+	return mounts
+}
@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestTLSSecrets(t *testing.T) {
+	// prepare
+	params := deploymentParams()
+	params.OtelCol.Spec.MountTLSSecrets = true
+	params.OtelCol.Spec.VolumeMounts = []corev1.VolumeMount{{Name: "ca", MountPath: "/etc/otelcol/tls/backend-ca"}}
+	params.OtelCol.Spec.Volumes = []corev1.Volume{{Name: "ca"}}
+	params.OtelCol.Spec.Config = `exporters:
+  otlp:
+    tls:
+      ca_file: /etc/otelcol/tls/backend-ca/ca.crt
+      cert_file: /etc/otelcol/tls/client/tls.crt
+      key_file: /etc/otelcol/tls/client/tls.key
+  otlphttp:
+    tls:
+      cert_file: /certs/tls.crt
+`
+
+	// test
+	d := Deployment(params)
+
+	// verify
+	podSpec := d.Spec.Template.Spec
+	c := podSpec.Containers[len(podSpec.Containers)-1]
+	assert.Contains(t, c.VolumeMounts, corev1.VolumeMount{Name: "tls-client", MountPath: "/etc/otelcol/tls/client", ReadOnly: true})
+	assert.Contains(t, podSpec.Volumes, corev1.Volume{Name: "tls-client", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "client"}}})
+	assert.Len(t, c.VolumeMounts, 3, "the config, the spec volume mount and the client Secret are mounted")
+	assert.Len(t, podSpec.Volumes, 3, "the config, the spec volume and the client Secret are mounted")
+}
+
+func TestTLSSecretsDisabled(t *testing.T) {
+	// prepare
+	params := deploymentParams()
+	params.OtelCol.Spec.Config = `exporters:
+  otlp:
+    tls:
+      cert_file: /etc/otelcol/tls/client/tls.crt
+`
+
+	// test
+	d := Deployment(params)
+
+	// verify
+	assert.Empty(t, TLSSecretVolumes(params.OtelCol))
+	assert.NotContains(t, d.Spec.Template.Spec.Volumes, corev1.Volume{Name: "tls-client", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "client"}}})
+}
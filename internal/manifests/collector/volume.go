@@ -20,6 +20,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 )
 
@@ -29,7 +30,7 @@ func Volumes(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) []corev
 		Name: naming.ConfigMapVolume(),
 		VolumeSource: corev1.VolumeSource{
 			ConfigMap: &corev1.ConfigMapVolumeSource{
-				LocalObjectReference: corev1.LocalObjectReference{Name: naming.ConfigMap(otelcol.Name)},
+				LocalObjectReference: corev1.LocalObjectReference{Name: naming.ConfigMap(&otelcol)},
 				Items: []corev1.KeyToPath{{
 					Key:  cfg.CollectorConfigMapEntry(),
 					Path: cfg.CollectorConfigMapEntry(),
@@ -42,6 +43,12 @@ func Volumes(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) []corev
 		volumes = append(volumes, otelcol.Spec.Volumes...)
 	}
 
+	volumes = append(volumes, manifestutils.SecretsStoreVolumes(otelcol.Spec.SecretProviderClasses)...)
+	volumes = append(volumes, spiffeVolumes(otelcol)...)
+	volumes = append(volumes, WritableVolumes(cfg, otelcol)...)
+	volumes = append(volumes, drainVolumes(otelcol)...)
+	volumes = append(volumes, TLSSecretVolumes(otelcol)...)
+
 	if len(otelcol.Spec.ConfigMaps) > 0 {
 		for keyCfgMap := range otelcol.Spec.ConfigMaps {
 			volumes = append(volumes, corev1.Volume{
@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
@@ -87,3 +88,28 @@ func TestVolumeWithMoreConfigMaps(t *testing.T) {
 	assert.Equal(t, "configmap-configmap-test", volumes[1].Name)
 	assert.Equal(t, "configmap-configmap-test2", volumes[2].Name)
 }
+
+func TestVolumeWithSecretProviderClasses(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			SecretProviderClasses: []v1alpha1.SecretProviderClassVolume{{
+				Name:                 "vault-api-keys",
+				NodePublishSecretRef: "vault-credentials",
+			}},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	volumes := Volumes(cfg, otelcol)
+
+	// verify
+	require.Len(t, volumes, 2)
+	assert.Equal(t, "secrets-store-vault-api-keys", volumes[1].Name)
+	require.NotNil(t, volumes[1].CSI)
+	assert.Equal(t, "secrets-store.csi.k8s.io", volumes[1].CSI.Driver)
+	assert.True(t, *volumes[1].CSI.ReadOnly)
+	assert.Equal(t, map[string]string{"secretProviderClass": "vault-api-keys"}, volumes[1].CSI.VolumeAttributes)
+	assert.Equal(t, &corev1.LocalObjectReference{Name: "vault-credentials"}, volumes[1].CSI.NodePublishSecretRef)
+}
@@ -0,0 +1,184 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifests
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ignoredMetadata are the metadata fields maintained by the API server, whose changes are of no interest.
+var ignoredMetadata = []string{"resourceVersion", "generation", "managedFields", "creationTimestamp", "uid", "selfLink"}
+
+// redacted replaces the values of the fields which may hold credentials, see sensitivePath.
+const redacted = "<redacted>"
+
+// sensitivePath matches the paths of the fields which may hold credentials: the data of the ConfigMaps, e.g. the
+// configuration of the collector with the headers of its exporters, and the values of the env vars.
+var sensitivePath = regexp.MustCompile(`^(data|binaryData|stringData)\.|(^|\.)env\[[^\]]+\]\.value$`)
+
+// Change is a difference between two versions of an object, at the given path of its fields.
+type Change struct {
+	Path string      `json:"path"`
+	Old  interface{} `json:"old,omitempty"`
+	New  interface{} `json:"new,omitempty"`
+}
+
+// Diff returns the changes between two versions of an object, leaving out its status and the metadata maintained by
+// the API server. The items of the lists whose items all have a distinct name, like containers, env vars or ports, are
+// matched by name, e.g. the path of a container image is "spec.template.spec.containers[otc-container].image".
+// The values of the fields which may hold credentials are redacted, only their paths are reported.
+func Diff(before, after client.Object) ([]Change, error) {
+	oldFields, err := comparableFields(before)
+	if err != nil {
+		return nil, err
+	}
+	newFields, err := comparableFields(after)
+	if err != nil {
+		return nil, err
+	}
+	var changes []Change
+	diffValues("", oldFields, newFields, &changes)
+	for i := range changes {
+		changes[i].Old = redact(changes[i].Path, changes[i].Old)
+		changes[i].New = redact(changes[i].Path, changes[i].New)
+	}
+	return changes, nil
+}
+
+// redact returns a copy of the value at the given path, whose sensitive fields are redacted.
+func redact(path string, value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	if sensitivePath.MatchString(path) {
+		return redacted
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		fields := make(map[string]interface{}, len(v))
+		for k, field := range v {
+			fields[k] = redact(joinPath(path, k), field)
+		}
+		return fields
+	case []interface{}:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = redact(fmt.Sprintf("%s[%d]", path, i), item)
+		}
+		return items
+	}
+	return value
+}
+
+func comparableFields(obj client.Object) (map[string]interface{}, error) {
+	fields, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %s: %w", obj.GetName(), err)
+	}
+	delete(fields, "apiVersion")
+	delete(fields, "kind")
+	delete(fields, "status")
+	if metadata, ok := fields["metadata"].(map[string]interface{}); ok {
+		for _, field := range ignoredMetadata {
+			delete(metadata, field)
+		}
+	}
+	return fields, nil
+}
+
+func diffValues(path string, oldValue, newValue interface{}, changes *[]Change) {
+	switch o := oldValue.(type) {
+	case map[string]interface{}:
+		n, ok := newValue.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := map[string]bool{}
+		for k := range o {
+			keys[k] = true
+		}
+		for k := range n {
+			keys[k] = true
+		}
+		sorted := make([]string, 0, len(keys))
+		for k := range keys {
+			sorted = append(sorted, k)
+		}
+		sort.Strings(sorted)
+		for _, k := range sorted {
+			diffValues(joinPath(path, k), o[k], n[k], changes)
+		}
+		return
+	case []interface{}:
+		n, ok := newValue.([]interface{})
+		if !ok {
+			break
+		}
+		oldByName, oldNames := itemsByName(o)
+		newByName, newNames := itemsByName(n)
+		if oldByName != nil && newByName != nil {
+			for _, name := range newNames {
+				if _, found := oldByName[name]; !found {
+					oldNames = append(oldNames, name)
+				}
+			}
+			for _, name := range oldNames {
+				diffValues(fmt.Sprintf("%s[%s]", path, name), oldByName[name], newByName[name], changes)
+			}
+			return
+		}
+		if len(o) == len(n) {
+			for i := range o {
+				diffValues(fmt.Sprintf("%s[%d]", path, i), o[i], n[i], changes)
+			}
+			return
+		}
+	}
+	if !reflect.DeepEqual(oldValue, newValue) {
+		*changes = append(*changes, Change{Path: path, Old: oldValue, New: newValue})
+	}
+}
+
+// itemsByName indexes the items of the list by name, if they all have a distinct one.
+func itemsByName(items []interface{}) (map[string]interface{}, []string) {
+	byName := map[string]interface{}{}
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		fields, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, nil
+		}
+		name, ok := fields["name"].(string)
+		if !ok || name == "" || byName[name] != nil {
+			return nil, nil
+		}
+		byName[name] = item
+		names = append(names, name)
+	}
+	return byName, names
+}
+
+func joinPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
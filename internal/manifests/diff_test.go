@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDiff(t *testing.T) {
+	// prepare
+	before := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "my-instance-collector",
+			ResourceVersion: "1",
+			Annotations:     map[string]string{"team": "a"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "otc-container",
+							Image: "collector:0.88.0",
+							Env:   []corev1.EnvVar{{Name: "A", Value: "a"}},
+							Ports: []corev1.ContainerPort{{Name: "otlp", ContainerPort: 4317}},
+							Args:  []string{"--config=/conf/collector.yaml"},
+						},
+					},
+				},
+			},
+		},
+		Status: appsv1.DeploymentStatus{Replicas: 1},
+	}
+	after := before.DeepCopy()
+	after.ResourceVersion = "2"
+	after.Status.Replicas = 2
+	after.Annotations["owner"] = "b"
+	container := &after.Spec.Template.Spec.Containers[0]
+	container.Image = "collector:0.89.0"
+	container.Env = append([]corev1.EnvVar{{Name: "B", Value: "b"}}, container.Env...)
+	container.Ports[0].ContainerPort = 4318
+	container.Args = append(container.Args, "--feature-gates=-component")
+
+	// test
+	changes, err := Diff(before, after)
+
+	// verify
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []Change{
+		{Path: "metadata.annotations.owner", New: "b"},
+		{Path: "spec.template.spec.containers[otc-container].image", Old: "collector:0.88.0", New: "collector:0.89.0"},
+		{Path: "spec.template.spec.containers[otc-container].env[B]", New: map[string]interface{}{"name": "B", "value": "<redacted>"}},
+		{Path: "spec.template.spec.containers[otc-container].ports[otlp].containerPort", Old: int64(4317), New: int64(4318)},
+		{
+			Path: "spec.template.spec.containers[otc-container].args",
+			Old:  []interface{}{"--config=/conf/collector.yaml"},
+			New:  []interface{}{"--config=/conf/collector.yaml", "--feature-gates=-component"},
+		},
+	}, changes)
+}
+
+func TestDiffRedacted(t *testing.T) {
+	// prepare
+	before := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-instance-collector"},
+		Data:       map[string]string{"collector.yaml": "exporters: {otlp: {headers: {authorization: secret}}}"},
+	}
+	after := before.DeepCopy()
+	after.Data["collector.yaml"] = "exporters: {otlp: {headers: {authorization: other}}}"
+	after.Data["extra.yaml"] = "password: secret"
+
+	// test
+	changes, err := Diff(before, after)
+
+	// verify
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []Change{
+		{Path: "data.collector.yaml", Old: "<redacted>", New: "<redacted>"},
+		{Path: "data.extra.yaml", New: "<redacted>"},
+	}, changes)
+}
+
+func TestDiffRedactedContainer(t *testing.T) {
+	// prepare
+	before := &corev1.Pod{}
+	after := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "otc-container", Env: []corev1.EnvVar{{Name: "TOKEN", Value: "secret"}}}},
+		},
+	}
+
+	// test
+	changes, err := Diff(before, after)
+
+	// verify
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.NotContains(t, fmt.Sprint(changes[0].New), "secret")
+	assert.Contains(t, fmt.Sprint(changes[0].New), "TOKEN")
+}
+
+func TestDiffUnchanged(t *testing.T) {
+	// prepare
+	before := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-instance-collector", ResourceVersion: "1"},
+		Data:       map[string]string{"collector.yaml": "receivers: {}"},
+	}
+	after := before.DeepCopy()
+	after.ResourceVersion = "2"
+
+	// test
+	changes, err := Diff(before, after)
+
+	// verify
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifestutils
+
+import "regexp"
+
+// FilterAnnotations returns a copy of the given annotations without the ones matching any of the filters, so that the
+// annotations of the custom resource meant for other tools, e.g. the markers of Kustomize, aren't propagated.
+func FilterAnnotations(annotations map[string]string, filterAnnotations []string) map[string]string {
+	if annotations == nil {
+		return nil
+	}
+	filtered := make(map[string]string, len(annotations))
+	for k, v := range annotations {
+		if !isFilteredAnnotation(k, filterAnnotations) {
+			filtered[k] = v
+		}
+	}
+	return filtered
+}
+
+func isFilteredAnnotation(annotation string, filterAnnotations []string) bool {
+	for _, pattern := range filterAnnotations {
+		if match, _ := regexp.MatchString(pattern, annotation); match {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifestutils
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+var (
+	// autopilotDefaultRequests are the minimum resources GKE Autopilot allocates to a container.
+	autopilotDefaultRequests = corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("250m"),
+		corev1.ResourceMemory: resource.MustParse("512Mi"),
+	}
+)
+
+// Resources returns the resource requirements of a container, or the default ones of the operator when the custom
+// resource sets neither requests nor limits. On GKE Autopilot, the containers are billed and scheduled by their
+// requests, which are then mandatory: the missing requests are set to the limits, or to the minimum Autopilot
+// allocates.
+func Resources(cfg config.Config, resources corev1.ResourceRequirements) corev1.ResourceRequirements {
+	if len(resources.Requests) == 0 && len(resources.Limits) == 0 {
+		defaults := cfg.DefaultResources()
+		resources.Requests, resources.Limits = defaults.Requests, defaults.Limits
+	}
+	if !cfg.Autopilot() {
+		return resources
+	}
+	// copy to avoid modifying the resources of the custom resource
+	requests := corev1.ResourceList{}
+	for name, quantity := range resources.Requests {
+		requests[name] = quantity
+	}
+	for name, quantity := range autopilotDefaultRequests {
+		if _, ok := requests[name]; ok {
+			continue
+		}
+		if limit, ok := resources.Limits[name]; ok {
+			requests[name] = limit
+		} else {
+			requests[name] = quantity
+		}
+	}
+	return corev1.ResourceRequirements{
+		Limits:   resources.Limits,
+		Requests: requests,
+		Claims:   resources.Claims,
+	}
+}
@@ -20,6 +20,7 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 )
 
@@ -31,8 +32,9 @@ func isFilteredLabel(label string, filterLabels []string) bool {
 	return false
 }
 
-// Labels return the common labels to all objects that are part of a managed CR.
-func Labels(instance metav1.ObjectMeta, name string, image string, component string, filterLabels []string) map[string]string {
+// Labels return the common labels to all objects that are part of a managed CR. The standard labels of the spec, if
+// any, override the values set by the operator.
+func Labels(instance metav1.ObjectMeta, name string, image string, component string, filterLabels []string, standard *v1alpha1.StandardLabels) map[string]string {
 	var versionLabel string
 	// new map every time, so that we don't touch the instance's label
 	base := map[string]string{}
@@ -44,7 +46,7 @@ func Labels(instance metav1.ObjectMeta, name string, image string, component str
 		}
 	}
 
-	for k, v := range SelectorLabels(instance, component) {
+	for k, v := range SelectorLabels(instance, component, standard) {
 		base[k] = v
 	}
 
@@ -67,17 +69,47 @@ func Labels(instance metav1.ObjectMeta, name string, image string, component str
 	if _, ok := base["app.kubernetes.io/name"]; !ok {
 		base["app.kubernetes.io/name"] = name
 	}
+	if standard != nil && standard.Name != "" {
+		base["app.kubernetes.io/name"] = standard.Name
+	}
+	if standard != nil && standard.Version != "" {
+		base["app.kubernetes.io/version"] = standard.Version
+	}
 	return base
 }
 
 // SelectorLabels return the common labels to all objects that are part of a managed CR to use as selector.
 // Selector labels are immutable for Deployment, StatefulSet and DaemonSet, therefore, no labels in selector should be
-// expected to be modified for the lifetime of the object.
-func SelectorLabels(instance metav1.ObjectMeta, component string) map[string]string {
+// expected to be modified for the lifetime of the object, unless the part-of label is overridden by the standard labels.
+func SelectorLabels(instance metav1.ObjectMeta, component string, standard *v1alpha1.StandardLabels) map[string]string {
 	return map[string]string{
 		"app.kubernetes.io/managed-by": "opentelemetry-operator",
 		"app.kubernetes.io/instance":   naming.Truncate("%s.%s", 63, instance.Namespace, instance.Name),
-		"app.kubernetes.io/part-of":    "opentelemetry",
+		"app.kubernetes.io/part-of":    PartOf(standard),
 		"app.kubernetes.io/component":  component,
 	}
 }
+
+// PartOf returns the value of the app.kubernetes.io/part-of label, "opentelemetry" unless overridden by the standard
+// labels.
+func PartOf(standard *v1alpha1.StandardLabels) string {
+	if standard != nil && standard.PartOf != "" {
+		return standard.PartOf
+	}
+	return "opentelemetry"
+}
+
+// Merge returns a new map with the entries of the given maps, the entries of the latter maps override the ones of
+// the former maps, or nil when they are all empty. It is used to add user-defined labels and annotations without modifying the maps of the instance.
+func Merge(maps ...map[string]string) map[string]string {
+	var merged map[string]string
+	for _, m := range maps {
+		for k, v := range m {
+			if merged == nil {
+				merged = map[string]string{}
+			}
+			merged[k] = v
+		}
+	}
+	return merged
+}
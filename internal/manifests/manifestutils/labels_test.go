@@ -41,7 +41,7 @@ func TestLabelsCommonSet(t *testing.T) {
 	}
 
 	// test
-	labels := Labels(otelcol.ObjectMeta, collectorName, otelcol.Spec.Image, "opentelemetry-collector", []string{})
+	labels := Labels(otelcol.ObjectMeta, collectorName, otelcol.Spec.Image, "opentelemetry-collector", []string{}, nil)
 	assert.Equal(t, "opentelemetry-operator", labels["app.kubernetes.io/managed-by"])
 	assert.Equal(t, "my-ns.my-instance", labels["app.kubernetes.io/instance"])
 	assert.Equal(t, "0.47.0", labels["app.kubernetes.io/version"])
@@ -61,7 +61,7 @@ func TestLabelsSha256Set(t *testing.T) {
 	}
 
 	// test
-	labels := Labels(otelcol.ObjectMeta, collectorName, otelcol.Spec.Image, "opentelemetry-collector", []string{})
+	labels := Labels(otelcol.ObjectMeta, collectorName, otelcol.Spec.Image, "opentelemetry-collector", []string{}, nil)
 	assert.Equal(t, "opentelemetry-operator", labels["app.kubernetes.io/managed-by"])
 	assert.Equal(t, "my-ns.my-instance", labels["app.kubernetes.io/instance"])
 	assert.Equal(t, "c6671841470b83007e0553cdadbc9d05f6cfe17b3ebe9733728dc4a579a5b53", labels["app.kubernetes.io/version"])
@@ -80,7 +80,7 @@ func TestLabelsSha256Set(t *testing.T) {
 	}
 
 	// test
-	labelsTag := Labels(otelcolTag.ObjectMeta, collectorName, otelcolTag.Spec.Image, "opentelemetry-collector", []string{})
+	labelsTag := Labels(otelcolTag.ObjectMeta, collectorName, otelcolTag.Spec.Image, "opentelemetry-collector", []string{}, nil)
 	assert.Equal(t, "opentelemetry-operator", labelsTag["app.kubernetes.io/managed-by"])
 	assert.Equal(t, "my-ns.my-instance", labelsTag["app.kubernetes.io/instance"])
 	assert.Equal(t, "0.81.0", labelsTag["app.kubernetes.io/version"])
@@ -100,7 +100,7 @@ func TestLabelsTagUnset(t *testing.T) {
 	}
 
 	// test
-	labels := Labels(otelcol.ObjectMeta, collectorName, otelcol.Spec.Image, "opentelemetry-collector", []string{})
+	labels := Labels(otelcol.ObjectMeta, collectorName, otelcol.Spec.Image, "opentelemetry-collector", []string{}, nil)
 	assert.Equal(t, "opentelemetry-operator", labels["app.kubernetes.io/managed-by"])
 	assert.Equal(t, "my-ns.my-instance", labels["app.kubernetes.io/instance"])
 	assert.Equal(t, "latest", labels["app.kubernetes.io/version"])
@@ -123,7 +123,7 @@ func TestLabelsPropagateDown(t *testing.T) {
 	}
 
 	// test
-	labels := Labels(otelcol.ObjectMeta, collectorName, otelcol.Spec.Image, "opentelemetry-collector", []string{})
+	labels := Labels(otelcol.ObjectMeta, collectorName, otelcol.Spec.Image, "opentelemetry-collector", []string{}, nil)
 
 	// verify
 	assert.Len(t, labels, 7)
@@ -142,7 +142,7 @@ func TestLabelsFilter(t *testing.T) {
 	}
 
 	// This requires the filter to be in regex match form and not the other simpler wildcard one.
-	labels := Labels(otelcol.ObjectMeta, collectorName, otelcol.Spec.Image, "opentelemetry-collector", []string{".*.bar.io"})
+	labels := Labels(otelcol.ObjectMeta, collectorName, otelcol.Spec.Image, "opentelemetry-collector", []string{".*.bar.io"}, nil)
 
 	// verify
 	assert.Len(t, labels, 7)
@@ -150,6 +150,40 @@ func TestLabelsFilter(t *testing.T) {
 	assert.Equal(t, "bar", labels["test.foo.io"])
 }
 
+func TestLabelsStandardOverrides(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      collectorName,
+			Namespace: collectorNamespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/name":       "test",
+				"app.kubernetes.io/managed-by": "argocd",
+			},
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Image: "ghcr.io/open-telemetry/opentelemetry-operator/opentelemetry-operator:0.47.0",
+			StandardLabels: &v1alpha1.StandardLabels{
+				PartOf:  "payments",
+				Name:    "payments-gateway",
+				Version: "2024.1",
+			},
+		},
+	}
+
+	// test
+	labels := Labels(otelcol.ObjectMeta, collectorName, otelcol.Spec.Image, "opentelemetry-collector", []string{}, otelcol.Spec.StandardLabels)
+	selector := SelectorLabels(otelcol.ObjectMeta, "opentelemetry-collector", otelcol.Spec.StandardLabels)
+
+	// verify
+	assert.Equal(t, "payments", labels["app.kubernetes.io/part-of"])
+	assert.Equal(t, "payments-gateway", labels["app.kubernetes.io/name"])
+	assert.Equal(t, "2024.1", labels["app.kubernetes.io/version"])
+	assert.Equal(t, "opentelemetry-operator", labels["app.kubernetes.io/managed-by"])
+	assert.Equal(t, "payments", selector["app.kubernetes.io/part-of"])
+	assert.Subset(t, labels, selector)
+}
+
 func TestSelectorLabels(t *testing.T) {
 	// prepare
 	expected := map[string]string{
@@ -163,7 +197,7 @@ func TestSelectorLabels(t *testing.T) {
 	}
 
 	// test
-	result := SelectorLabels(otelcol.ObjectMeta, "opentelemetry-collector")
+	result := SelectorLabels(otelcol.ObjectMeta, "opentelemetry-collector", nil)
 
 	// verify
 	assert.Equal(t, expected, result)
@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifestutils
+
+import (
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// LogArgs returns the arguments setting the level and the format of the logs of the components built with the
+// controller-runtime logger, like the TargetAllocator and the OpAMPBridge. Unset values keep the defaults of the component.
+func LogArgs(level v1alpha1.LogLevel, format v1alpha1.LogFormat) []string {
+	var args []string
+	if level != "" {
+		args = append(args, fmt.Sprintf("--zap-log-level=%s", level))
+	}
+	if format != "" {
+		args = append(args, fmt.Sprintf("--zap-encoder=%s", format))
+	}
+	return args
+}
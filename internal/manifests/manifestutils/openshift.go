@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifestutils
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect"
+)
+
+// ServingCertSecretAnnotation asks the OpenShift service CA operator to issue a serving certificate for a service.
+const ServingCertSecretAnnotation = "service.beta.openshift.io/serving-cert-secret-name"
+
+// SecurityContext returns the security context of a container. On OpenShift, the containers without a security context
+// get one admitted by the restricted-v2 SecurityContextConstraints, which leaves the user and group IDs to the SCC. The
+// fields left unset are then defaulted from the default security context of the operator, if any.
+func SecurityContext(cfg config.Config, securityContext *corev1.SecurityContext) *corev1.SecurityContext {
+	if securityContext != nil || cfg.Platform() != autodetect.PlatformOpenShift {
+		return withDefaultSecurityContext(cfg, securityContext)
+	}
+	allowPrivilegeEscalation := false
+	runAsNonRoot := true
+	return withDefaultSecurityContext(cfg, &corev1.SecurityContext{
+		AllowPrivilegeEscalation: &allowPrivilegeEscalation,
+		RunAsNonRoot:             &runAsNonRoot,
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{"ALL"},
+		},
+		SeccompProfile: &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
+		},
+	})
+}
+
+// ServiceAnnotations returns the annotations of a service. On OpenShift, the service CA operator is asked to issue a
+// serving certificate into the secret named <service>-tls, unless the annotations already name a secret.
+func ServiceAnnotations(cfg config.Config, serviceName string, annotations map[string]string) map[string]string {
+	if cfg.Platform() != autodetect.PlatformOpenShift {
+		return annotations
+	}
+	// copy to avoid modifying the annotations of the custom resource
+	copied := make(map[string]string, len(annotations)+1)
+	for k, v := range annotations {
+		copied[k] = v
+	}
+	if _, ok := copied[ServingCertSecretAnnotation]; !ok {
+		copied[ServingCertSecretAnnotation] = serviceName + "-tls"
+	}
+	return copied
+}
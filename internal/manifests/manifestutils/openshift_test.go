@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifestutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func TestSecurityContext(t *testing.T) {
+	runAsUser := int64(1000)
+	custom := &corev1.SecurityContext{RunAsUser: &runAsUser}
+
+	// the security context is left alone outside of OpenShift
+	assert.Nil(t, SecurityContext(config.New(), nil))
+	assert.Equal(t, custom, SecurityContext(config.New(), custom))
+
+	openshift := config.New(config.WithOpenShiftPlatform("4.14.1"))
+	assert.Equal(t, custom, SecurityContext(openshift, custom))
+	restricted := SecurityContext(openshift, nil)
+	require.NotNil(t, restricted)
+	assert.Nil(t, restricted.RunAsUser)
+	assert.False(t, *restricted.AllowPrivilegeEscalation)
+	assert.True(t, *restricted.RunAsNonRoot)
+	assert.Equal(t, []corev1.Capability{"ALL"}, restricted.Capabilities.Drop)
+	assert.Equal(t, corev1.SeccompProfileTypeRuntimeDefault, restricted.SeccompProfile.Type)
+}
+
+func TestServiceAnnotations(t *testing.T) {
+	annotations := map[string]string{"custom": "value"}
+
+	assert.Equal(t, annotations, ServiceAnnotations(config.New(), "my-service", annotations))
+
+	openshift := config.New(config.WithOpenShiftPlatform(""))
+	assert.Equal(t, map[string]string{
+		"custom":                    "value",
+		ServingCertSecretAnnotation: "my-service-tls",
+	}, ServiceAnnotations(openshift, "my-service", annotations))
+	// the annotations of the custom resource are not modified
+	assert.Len(t, annotations, 1)
+
+	annotations[ServingCertSecretAnnotation] = "my-secret"
+	assert.Equal(t, "my-secret", ServiceAnnotations(openshift, "my-service", annotations)[ServingCertSecretAnnotation])
+}
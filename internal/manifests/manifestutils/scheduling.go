@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifestutils
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+// Tolerations returns the tolerations of the custom resource, or the default tolerations of the operator when it sets
+// none. The tolerations aren't merged, so that a custom resource can opt out of the nodes tolerated by default.
+func Tolerations(cfg config.Config, tolerations []corev1.Toleration) []corev1.Toleration {
+	if len(tolerations) > 0 {
+		return tolerations
+	}
+	return cfg.DefaultTolerations()
+}
+
+// NodeSelector returns the node selector of the custom resource, or the default node selector of the operator when
+// it sets none.
+func NodeSelector(cfg config.Config, nodeSelector map[string]string) map[string]string {
+	if len(nodeSelector) > 0 {
+		return nodeSelector
+	}
+	return cfg.DefaultNodeSelector()
+}
@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifestutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func TestDefaultScheduling(t *testing.T) {
+	defaultTolerations := []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "telemetry", Effect: corev1.TaintEffectNoSchedule}}
+	cfg := config.New(
+		config.WithDefaultTolerations(defaultTolerations),
+		config.WithDefaultNodeSelector(map[string]string{"pool": "telemetry"}),
+	)
+	custom := []corev1.Toleration{{Key: "other", Operator: corev1.TolerationOpExists}}
+
+	assert.Equal(t, defaultTolerations, Tolerations(cfg, nil))
+	assert.Equal(t, custom, Tolerations(cfg, custom))
+	assert.Equal(t, map[string]string{"pool": "telemetry"}, NodeSelector(cfg, map[string]string{}))
+	assert.Equal(t, map[string]string{"pool": "other"}, NodeSelector(cfg, map[string]string{"pool": "other"}))
+
+	// without defaults, the custom resource is used as is
+	assert.Nil(t, Tolerations(config.New(), nil))
+	assert.Nil(t, NodeSelector(config.New(), nil))
+}
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifestutils
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+const (
+	// SecretsStoreCSIDriver is the name of the CSI driver of the secrets-store CSI driver project.
+	SecretsStoreCSIDriver = "secrets-store.csi.k8s.io"
+)
+
+// SecretsStoreVolumes returns the CSI volumes mounting the secrets of the given SecretProviderClasses.
+func SecretsStoreVolumes(classes []v1alpha1.SecretProviderClassVolume) []corev1.Volume {
+	var volumes []corev1.Volume
+	for _, class := range classes {
+		readOnly := true
+		volume := corev1.Volume{
+			Name: naming.SecretsStoreVolume(class.Name),
+			VolumeSource: corev1.VolumeSource{
+				CSI: &corev1.CSIVolumeSource{
+					Driver:   SecretsStoreCSIDriver,
+					ReadOnly: &readOnly,
+					VolumeAttributes: map[string]string{
+						"secretProviderClass": class.Name,
+					},
+				},
+			},
+		}
+		if class.NodePublishSecretRef != "" {
+			volume.CSI.NodePublishSecretRef = &corev1.LocalObjectReference{Name: class.NodePublishSecretRef}
+		}
+		volumes = append(volumes, volume)
+	}
+	return volumes
+}
+
+// SecretsStoreVolumeMounts returns the read-only mounts of the volumes returned by SecretsStoreVolumes.
+func SecretsStoreVolumeMounts(classes []v1alpha1.SecretProviderClassVolume) []corev1.VolumeMount {
+	var mounts []corev1.VolumeMount
+	for _, class := range classes {
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      naming.SecretsStoreVolume(class.Name),
+			MountPath: class.GetMountPath(),
+			ReadOnly:  true,
+		})
+	}
+	return mounts
+}
@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifestutils
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+// withDefaultSecurityContext fills the fields the given security context leaves unset from the default security
+// context of the operator. The given security context, which may be the one of the custom resource, isn't modified.
+func withDefaultSecurityContext(cfg config.Config, securityContext *corev1.SecurityContext) *corev1.SecurityContext {
+	defaults := cfg.DefaultSecurityContext()
+	if defaults == nil {
+		return securityContext
+	}
+	if securityContext == nil {
+		return defaults
+	}
+	merged := securityContext.DeepCopy()
+	if merged.RunAsNonRoot == nil {
+		merged.RunAsNonRoot = defaults.RunAsNonRoot
+	}
+	if merged.ReadOnlyRootFilesystem == nil {
+		merged.ReadOnlyRootFilesystem = defaults.ReadOnlyRootFilesystem
+	}
+	if merged.AllowPrivilegeEscalation == nil {
+		merged.AllowPrivilegeEscalation = defaults.AllowPrivilegeEscalation
+	}
+	if merged.Capabilities == nil {
+		merged.Capabilities = defaults.Capabilities
+	}
+	if merged.SeccompProfile == nil {
+		merged.SeccompProfile = defaults.SeccompProfile
+	}
+	return merged
+}
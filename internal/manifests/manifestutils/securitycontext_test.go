@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifestutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func TestDefaultSecurityContext(t *testing.T) {
+	enabled, disabled := true, false
+	cfg := config.New(config.WithDefaultSecurityContext(&corev1.SecurityContext{
+		RunAsNonRoot:           &enabled,
+		ReadOnlyRootFilesystem: &enabled,
+		Capabilities:           &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+	}))
+
+	// the containers without a security context get the default one
+	defaulted := SecurityContext(cfg, nil)
+	require.NotNil(t, defaulted)
+	assert.True(t, *defaulted.RunAsNonRoot)
+	assert.True(t, *defaulted.ReadOnlyRootFilesystem)
+	assert.Equal(t, []corev1.Capability{"ALL"}, defaulted.Capabilities.Drop)
+
+	// the fields set by the custom resource take precedence, and its security context isn't modified
+	custom := &corev1.SecurityContext{
+		ReadOnlyRootFilesystem: &disabled,
+		Capabilities:           &corev1.Capabilities{Add: []corev1.Capability{"NET_BIND_SERVICE"}},
+	}
+	merged := SecurityContext(cfg, custom)
+	assert.True(t, *merged.RunAsNonRoot)
+	assert.False(t, *merged.ReadOnlyRootFilesystem)
+	assert.Equal(t, []corev1.Capability{"NET_BIND_SERVICE"}, merged.Capabilities.Add)
+	assert.Empty(t, merged.Capabilities.Drop)
+	assert.Nil(t, custom.RunAsNonRoot)
+
+	// the defaults are applied on top of the restricted security context of OpenShift
+	openshift := config.New(
+		config.WithOpenShiftPlatform("4.14.1"),
+		config.WithDefaultSecurityContext(&corev1.SecurityContext{ReadOnlyRootFilesystem: &enabled}),
+	)
+	restricted := SecurityContext(openshift, nil)
+	assert.True(t, *restricted.RunAsNonRoot)
+	assert.True(t, *restricted.ReadOnlyRootFilesystem)
+}
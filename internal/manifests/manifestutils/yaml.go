@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifestutils
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"gopkg.in/yaml.v2"
+)
+
+// MarshalYAML marshals the given configuration with its maps in a canonical order, so that rendering the same
+// configuration always yields the same string. The maps decoded from YAML may mix key types, e.g. 1 and "1", which the
+// ordering of yaml.v2 doesn't sort consistently.
+func MarshalYAML(in interface{}) ([]byte, error) {
+	return yaml.Marshal(canonicalize(reflect.ValueOf(in)))
+}
+
+func canonicalize(value reflect.Value) interface{} {
+	if !value.IsValid() {
+		return nil
+	}
+	switch value.Kind() {
+	case reflect.Interface:
+		if value.IsNil() {
+			return nil
+		}
+		return canonicalize(value.Elem())
+	case reflect.Ptr:
+		if !value.IsNil() && value.Elem().Kind() == reflect.Map {
+			return canonicalize(value.Elem())
+		}
+	case reflect.Map:
+		items := make(yaml.MapSlice, 0, value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			items = append(items, yaml.MapItem{
+				Key:   canonicalize(iter.Key()),
+				Value: canonicalize(iter.Value()),
+			})
+		}
+		sort.Slice(items, func(i, j int) bool {
+			return lessKey(items[i].Key, items[j].Key)
+		})
+		return items
+	case reflect.Slice:
+		if value.Type().Elem().Kind() == reflect.Interface {
+			items := make([]interface{}, value.Len())
+			for i := range items {
+				items[i] = canonicalize(value.Index(i))
+			}
+			return items
+		}
+	}
+	return value.Interface()
+}
+
+// lessKey orders the keys by their string representation, then by their type.
+func lessKey(a, b interface{}) bool {
+	as, bs := fmt.Sprint(a), fmt.Sprint(b)
+	if as != bs {
+		return as < bs
+	}
+	return fmt.Sprintf("%T", a) < fmt.Sprintf("%T", b)
+}
@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifestutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestMarshalYAMLIsStable(t *testing.T) {
+	// prepare
+	config := map[interface{}]interface{}{
+		"receivers": map[interface{}]interface{}{
+			1:      "int",
+			"1":    "string",
+			true:   "bool",
+			"otlp": []interface{}{map[string]string{"b": "2", "a": "1"}},
+		},
+		"label_selector": &map[string]string{"z": "1", "a": "2"},
+	}
+	first, err := MarshalYAML(config)
+	require.NoError(t, err)
+
+	// test
+	for i := 0; i < 100; i++ {
+		out, err := MarshalYAML(config)
+
+		// verify
+		require.NoError(t, err)
+		require.Equal(t, string(first), string(out))
+	}
+	assert.Equal(t, `label_selector:
+  a: "2"
+  z: "1"
+receivers:
+  1: int
+  "1": string
+  otlp:
+  - a: "1"
+    b: "2"
+  true: bool
+`, string(first))
+}
+
+func TestMarshalYAMLKeepsContent(t *testing.T) {
+	// prepare
+	in := `exporters:
+  debug: null
+receivers:
+  prometheus:
+    config:
+      scrape_configs:
+      - job_name: otel
+        scrape_interval: 10s
+`
+	config := map[interface{}]interface{}{}
+	require.NoError(t, yaml.Unmarshal([]byte(in), &config))
+
+	// test
+	out, err := MarshalYAML(config)
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, in, string(out))
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/imdario/mergo"
 	routev1 "github.com/openshift/api/route/v1"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"gopkg.in/yaml.v2"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
@@ -52,6 +53,7 @@ var (
 // - StatefulSet
 // - ServiceMonitor
 // - Ingress
+// - NetworkPolicy
 // - HorizontalPodAutoscaler
 // - Route
 // - Secret
@@ -141,6 +143,11 @@ func MutateFuncFor(existing, desired client.Object) controllerutil.MutateFn {
 			wantIng := desired.(*networkingv1.Ingress)
 			mutateIngress(ing, wantIng)
 
+		case *networkingv1.NetworkPolicy:
+			np := existing.(*networkingv1.NetworkPolicy)
+			wantNp := desired.(*networkingv1.NetworkPolicy)
+			mutateNetworkPolicy(np, wantNp)
+
 		case *autoscalingv2.HorizontalPodAutoscaler:
 			existingHPA := existing.(*autoscalingv2.HorizontalPodAutoscaler)
 			desiredHPA := desired.(*autoscalingv2.HorizontalPodAutoscaler)
@@ -181,7 +188,34 @@ func mutateSecret(existing, desired *corev1.Secret) {
 
 func mutateConfigMap(existing, desired *corev1.ConfigMap) {
 	existing.BinaryData = desired.BinaryData
-	existing.Data = desired.Data
+	// a configuration rendered differently but with the same content isn't written again
+	if !equivalentData(existing.Data, desired.Data) {
+		existing.Data = desired.Data
+	}
+}
+
+// equivalentData tells whether the values of the two ConfigMaps are equal, or decode to the same YAML.
+func equivalentData(existing, desired map[string]string) bool {
+	if len(existing) != len(desired) {
+		return false
+	}
+	for key, value := range desired {
+		existingValue, ok := existing[key]
+		if !ok {
+			return false
+		}
+		if existingValue == value {
+			continue
+		}
+		var existingContent, desiredContent interface{}
+		if yaml.Unmarshal([]byte(existingValue), &existingContent) != nil || yaml.Unmarshal([]byte(value), &desiredContent) != nil {
+			return false
+		}
+		if !reflect.DeepEqual(existingContent, desiredContent) {
+			return false
+		}
+	}
+	return true
 }
 
 func mutateServiceAccount(existing, desired *corev1.ServiceAccount) {
@@ -233,6 +267,12 @@ func mutateIngress(existing, desired *networkingv1.Ingress) {
 	existing.Spec.TLS = desired.Spec.TLS
 }
 
+func mutateNetworkPolicy(existing, desired *networkingv1.NetworkPolicy) {
+	existing.Annotations = desired.Annotations
+	existing.Labels = desired.Labels
+	existing.Spec = desired.Spec
+}
+
 func mutateRoute(existing, desired *routev1.Route) {
 	existing.Annotations = desired.Annotations
 	existing.Labels = desired.Labels
@@ -299,6 +339,8 @@ func mutateStatefulSet(existing, desired *appsv1.StatefulSet) error {
 	}
 	existing.Spec.PodManagementPolicy = desired.Spec.PodManagementPolicy
 	existing.Spec.Replicas = desired.Spec.Replicas
+	existing.Spec.MinReadySeconds = desired.Spec.MinReadySeconds
+	existing.Spec.PersistentVolumeClaimRetentionPolicy = desired.Spec.PersistentVolumeClaimRetentionPolicy
 
 	for i := range existing.Spec.VolumeClaimTemplates {
 		existing.Spec.VolumeClaimTemplates[i].TypeMeta = desired.Spec.VolumeClaimTemplates[i].TypeMeta
@@ -323,6 +365,14 @@ func hasImmutableFieldChange(existing, desired *appsv1.StatefulSet) (bool, strin
 		return true, "Spec.VolumeClaimTemplates"
 	}
 
+	if desired.Spec.PodManagementPolicy != existing.Spec.PodManagementPolicy {
+		return true, fmt.Sprintf("Spec.PodManagementPolicy: desired: %s existing: %s", desired.Spec.PodManagementPolicy, existing.Spec.PodManagementPolicy)
+	}
+
+	if desired.Spec.ServiceName != existing.Spec.ServiceName {
+		return true, fmt.Sprintf("Spec.ServiceName: desired: %s existing: %s", desired.Spec.ServiceName, existing.Spec.ServiceName)
+	}
+
 	return false, ""
 }
 
@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMutateConfigMapKeepsEquivalentData(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		existing string
+		desired  string
+		expected string
+	}{
+		{
+			name:     "same content rendered differently",
+			existing: "receivers:\n  otlp: {}\nexporters:\n  debug: {}\n",
+			desired:  "exporters:\n  debug: {}\nreceivers:\n  otlp: {}\n",
+			expected: "receivers:\n  otlp: {}\nexporters:\n  debug: {}\n",
+		},
+		{
+			name:     "changed content",
+			existing: "receivers:\n  otlp: {}\n",
+			desired:  "receivers:\n  jaeger: {}\n",
+			expected: "receivers:\n  jaeger: {}\n",
+		},
+		{
+			name:     "invalid content",
+			existing: "receivers: [",
+			desired:  "receivers: {}\n",
+			expected: "receivers: {}\n",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// prepare
+			existing := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "config"},
+				Data:       map[string]string{"collector.yaml": tt.existing},
+			}
+			desired := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "config"},
+				Data:       map[string]string{"collector.yaml": tt.desired},
+			}
+
+			// test
+			require.NoError(t, MutateFuncFor(existing, desired)())
+
+			// verify
+			assert.Equal(t, tt.expected, existing.Data["collector.yaml"])
+		})
+	}
+}
+
+func TestMutateStatefulSetPodManagementPolicyChange(t *testing.T) {
+	// prepare
+	existing := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-collector", CreationTimestamp: metav1.Now()},
+		Spec:       appsv1.StatefulSetSpec{PodManagementPolicy: appsv1.ParallelPodManagement},
+	}
+	desired := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-collector"},
+		Spec:       appsv1.StatefulSetSpec{PodManagementPolicy: appsv1.OrderedReadyPodManagement, MinReadySeconds: 30},
+	}
+
+	// test
+	err := MutateFuncFor(existing, desired)()
+
+	// verify
+	assert.ErrorIs(t, err, ImmutableChangeErr)
+
+	// the minReadySeconds alone can be updated in place
+	desired.Spec.PodManagementPolicy = appsv1.ParallelPodManagement
+	require.NoError(t, MutateFuncFor(existing, desired)())
+	assert.Equal(t, int32(30), existing.Spec.MinReadySeconds)
+}
+
+func TestMutateStatefulSetServiceNameChange(t *testing.T) {
+	// prepare
+	existing := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-collector", CreationTimestamp: metav1.Now()},
+		Spec:       appsv1.StatefulSetSpec{ServiceName: "test-collector"},
+	}
+	desired := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-collector"},
+		Spec:       appsv1.StatefulSetSpec{ServiceName: "test-collector-headless"},
+	}
+
+	// test
+	err := MutateFuncFor(existing, desired)()
+
+	// verify
+	assert.ErrorIs(t, err, ImmutableChangeErr)
+}
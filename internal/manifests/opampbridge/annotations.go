@@ -0,0 +1,26 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opampbridge
+
+import (
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+)
+
+// Annotations return the annotations for the OpAMPBridge Deployment. The annotations of the instance matching the
+// filters aren't propagated, the annotations of the spec override the ones of the instance.
+func Annotations(instance v1alpha1.OpAMPBridge, filterAnnotations []string) map[string]string {
+	return manifestutils.Merge(manifestutils.FilterAnnotations(instance.Annotations, filterAnnotations), instance.Spec.Annotations)
+}
@@ -15,13 +15,14 @@
 package opampbridge
 
 import (
+	"fmt"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
 
 	"strings"
 
-	"gopkg.in/yaml.v2"
-
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
@@ -30,7 +31,7 @@ import (
 func ConfigMap(params manifests.Params) (*corev1.ConfigMap, error) {
 	name := naming.OpAMPBridgeConfigMap(params.OpAMPBridge.Name)
 	version := strings.Split(params.OpAMPBridge.Spec.Image, ":")
-	labels := manifestutils.Labels(params.OpAMPBridge.ObjectMeta, name, params.OpAMPBridge.Spec.Image, ComponentOpAMPBridge, []string{})
+	labels := manifestutils.Labels(params.OpAMPBridge.ObjectMeta, name, params.OpAMPBridge.Spec.Image, ComponentOpAMPBridge, []string{}, params.OpAMPBridge.Spec.StandardLabels)
 
 	if len(version) > 1 {
 		labels["app.kubernetes.io/version"] = version[len(version)-1]
@@ -52,7 +53,36 @@ func ConfigMap(params manifests.Params) (*corev1.ConfigMap, error) {
 		config["componentsAllowed"] = params.OpAMPBridge.Spec.ComponentsAllowed
 	}
 
-	configYAML, err := yaml.Marshal(config)
+	if params.OpAMPBridge.Spec.HeartbeatInterval != nil {
+		config["heartbeatInterval"] = params.OpAMPBridge.Spec.HeartbeatInterval.Duration.String()
+	}
+
+	if params.OpAMPBridge.Spec.ReportingInterval != nil {
+		config["reportingInterval"] = params.OpAMPBridge.Spec.ReportingInterval.Duration.String()
+	}
+
+	if params.OpAMPBridge.Spec.PersistInstanceID {
+		config["instanceIDConfigMap"] = naming.OpAMPBridgeInstanceIDConfigMap(params.OpAMPBridge.Name)
+	}
+
+	if params.OpAMPBridge.Spec.CollectorSelector != nil {
+		// the selector is passed in its string form, e.g. "team=a,env in (dev,test)", which the bridge parses back
+		selector, err := metav1.LabelSelectorAsSelector(params.OpAMPBridge.Spec.CollectorSelector)
+		if err != nil {
+			return &corev1.ConfigMap{}, err
+		}
+		config["collectorSelector"] = selector.String()
+	}
+
+	if params.OpAMPBridge.Spec.DryRun {
+		config["dryRun"] = true
+	}
+
+	if err := mergeConfigPatches(config, params.OpAMPBridge.Spec.ConfigPatches); err != nil {
+		return &corev1.ConfigMap{}, err
+	}
+
+	configYAML, err := manifestutils.MarshalYAML(config)
 	if err != nil {
 		return &corev1.ConfigMap{}, err
 	}
@@ -69,3 +99,22 @@ func ConfigMap(params manifests.Params) (*corev1.ConfigMap, error) {
 		},
 	}, nil
 }
+
+// mergeConfigPatches adds the options of the config patches to the generated configuration. The options generated from
+// the spec are rejected by the webhook, they're also refused here so that they're never silently overridden.
+func mergeConfigPatches(config map[interface{}]interface{}, patches string) error {
+	if len(strings.TrimSpace(patches)) == 0 {
+		return nil
+	}
+	options := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(patches), &options); err != nil {
+		return fmt.Errorf("couldn't parse the config patches: %w", err)
+	}
+	for option, value := range options {
+		if _, exists := config[option]; exists {
+			return fmt.Errorf("the config patches can't override the option %s generated from the spec", option)
+		}
+		config[option] = value
+	}
+	return nil
+}
@@ -18,6 +18,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"testing"
+	"time"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
@@ -101,4 +102,134 @@ endpoint: ws://opamp-server:4320/v1/opamp
 		assert.Equal(t, expectedLables, actual.Labels)
 		assert.Equal(t, expectedData, actual.Data)
 	})
+
+	t.Run("should return the dry-run mode in the opamp-bridge config map", func(t *testing.T) {
+		params := manifests.Params{
+			Config: config.New(),
+			OpAMPBridge: v1alpha1.OpAMPBridge{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-instance",
+					Namespace: "my-namespace",
+				},
+				Spec: v1alpha1.OpAMPBridgeSpec{
+					Endpoint: "ws://opamp-server:4320/v1/opamp",
+					DryRun:   true,
+				},
+			},
+			Log: logger,
+		}
+
+		actual, err := ConfigMap(params)
+		assert.NoError(t, err)
+
+		assert.Equal(t, map[string]string{
+			"remoteconfiguration.yaml": "dryRun: true\nendpoint: ws://opamp-server:4320/v1/opamp\n",
+		}, actual.Data)
+	})
+
+	t.Run("should return the intervals in the opamp-bridge config map", func(t *testing.T) {
+		params := manifests.Params{
+			Config: config.New(),
+			OpAMPBridge: v1alpha1.OpAMPBridge{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-instance",
+					Namespace: "my-namespace",
+				},
+				Spec: v1alpha1.OpAMPBridgeSpec{
+					Endpoint:          "ws://opamp-server:4320/v1/opamp",
+					HeartbeatInterval: &metav1.Duration{Duration: 45 * time.Second},
+					ReportingInterval: &metav1.Duration{Duration: time.Minute},
+				},
+			},
+			Log: logger,
+		}
+
+		actual, err := ConfigMap(params)
+		assert.NoError(t, err)
+
+		assert.Equal(t, map[string]string{
+			"remoteconfiguration.yaml": "endpoint: ws://opamp-server:4320/v1/opamp\nheartbeatInterval: 45s\nreportingInterval: 1m0s\n",
+		}, actual.Data)
+	})
+
+	t.Run("should merge the config patches into the opamp-bridge config map", func(t *testing.T) {
+		params := manifests.Params{
+			Config: config.New(),
+			OpAMPBridge: v1alpha1.OpAMPBridge{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-instance",
+					Namespace: "my-namespace",
+				},
+				Spec: v1alpha1.OpAMPBridgeSpec{
+					Endpoint:      "ws://opamp-server:4320/v1/opamp",
+					ConfigPatches: "name: team-a-bridge\nlistenAddr: \":8081\"\n",
+				},
+			},
+			Log: logger,
+		}
+
+		actual, err := ConfigMap(params)
+		assert.NoError(t, err)
+
+		assert.Equal(t, map[string]string{
+			"remoteconfiguration.yaml": "endpoint: ws://opamp-server:4320/v1/opamp\nlistenAddr: :8081\nname: team-a-bridge\n",
+		}, actual.Data)
+
+		params.OpAMPBridge.Spec.ConfigPatches = "endpoint: ws://other:4320/v1/opamp"
+		_, err = ConfigMap(params)
+		assert.ErrorContains(t, err, "the config patches can't override the option endpoint generated from the spec")
+	})
+
+	t.Run("should return the instance id config map in the opamp-bridge config map", func(t *testing.T) {
+		params := manifests.Params{
+			Config: config.New(),
+			OpAMPBridge: v1alpha1.OpAMPBridge{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-instance",
+					Namespace: "my-namespace",
+				},
+				Spec: v1alpha1.OpAMPBridgeSpec{
+					Endpoint:          "ws://opamp-server:4320/v1/opamp",
+					PersistInstanceID: true,
+				},
+			},
+			Log: logger,
+		}
+
+		actual, err := ConfigMap(params)
+		assert.NoError(t, err)
+
+		assert.Equal(t, map[string]string{
+			"remoteconfiguration.yaml": "endpoint: ws://opamp-server:4320/v1/opamp\ninstanceIDConfigMap: my-instance-opamp-bridge-instance-id\n",
+		}, actual.Data)
+	})
+
+	t.Run("should return the collector selector in the opamp-bridge config map", func(t *testing.T) {
+		params := manifests.Params{
+			Config: config.New(),
+			OpAMPBridge: v1alpha1.OpAMPBridge{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-instance",
+					Namespace: "my-namespace",
+				},
+				Spec: v1alpha1.OpAMPBridgeSpec{
+					Endpoint: "ws://opamp-server:4320/v1/opamp",
+					CollectorSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"team": "a"},
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: "env", Operator: metav1.LabelSelectorOpIn, Values: []string{"dev", "test"}},
+						},
+					},
+				},
+			},
+			Log: logger,
+		}
+
+		actual, err := ConfigMap(params)
+		assert.NoError(t, err)
+
+		assert.Equal(t, map[string]string{
+			"remoteconfiguration.yaml": "collectorSelector: env in (dev,test),team=a\nendpoint: ws://opamp-server:4320/v1/opamp\n",
+		}, actual.Data)
+	})
 }
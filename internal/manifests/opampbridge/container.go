@@ -15,12 +15,15 @@
 package opampbridge
 
 import (
+	"time"
+
 	"github.com/go-logr/logr"
 	"github.com/operator-framework/operator-lib/proxy"
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 )
 
@@ -29,6 +32,10 @@ func Container(cfg config.Config, logger logr.Logger, opampBridge v1alpha1.OpAMP
 	image := opampBridge.Spec.Image
 	if len(image) == 0 {
 		image = cfg.OperatorOpAMPBridgeImage()
+		// outside of the upgrade windows, keep the image currently deployed instead of the new default
+		if len(opampBridge.Status.Image) > 0 && !v1alpha1.UpgradeAllowed(opampBridge.Spec.UpgradeWindows, time.Now()) {
+			image = opampBridge.Status.Image
+		}
 	}
 
 	volumeMounts := []corev1.VolumeMount{{
@@ -39,6 +46,7 @@ func Container(cfg config.Config, logger logr.Logger, opampBridge v1alpha1.OpAMP
 	if len(opampBridge.Spec.VolumeMounts) > 0 {
 		volumeMounts = append(volumeMounts, opampBridge.Spec.VolumeMounts...)
 	}
+	volumeMounts = append(volumeMounts, manifestutils.SecretsStoreVolumeMounts(opampBridge.Spec.SecretProviderClasses)...)
 
 	var envVars = opampBridge.Spec.Env
 	if opampBridge.Spec.Env == nil {
@@ -62,6 +70,20 @@ func Container(cfg config.Config, logger logr.Logger, opampBridge v1alpha1.OpAMP
 		})
 	}
 
+	// the bridge reports the status of the remote configurations to its OpAMPBridge resource
+	envVars = append(envVars, corev1.EnvVar{
+		Name:  "OPAMP_BRIDGE_NAME",
+		Value: opampBridge.Name,
+	})
+
+	// the ConfigMap keeping the instance UID of the bridge is owned by its OpAMPBridge resource
+	if opampBridge.Spec.PersistInstanceID {
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  "OPAMP_BRIDGE_UID",
+			Value: string(opampBridge.UID),
+		})
+	}
+
 	envVars = append(envVars, proxy.ReadProxyVarsFromEnv()...)
 
 	return corev1.Container{
@@ -71,7 +93,8 @@ func Container(cfg config.Config, logger logr.Logger, opampBridge v1alpha1.OpAMP
 		Env:             envVars,
 		VolumeMounts:    volumeMounts,
 		EnvFrom:         opampBridge.Spec.EnvFrom,
-		Resources:       opampBridge.Spec.Resources,
-		SecurityContext: opampBridge.Spec.SecurityContext,
+		Args:            manifestutils.LogArgs(opampBridge.Spec.LogLevel, opampBridge.Spec.LogFormat),
+		Resources:       manifestutils.Resources(cfg, opampBridge.Spec.Resources),
+		SecurityContext: manifestutils.SecurityContext(cfg, opampBridge.Spec.SecurityContext),
 	}
 }
@@ -18,6 +18,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
@@ -56,6 +58,66 @@ func TestContainerWithImageOverridden(t *testing.T) {
 	assert.Equal(t, "overridden-image", c.Image)
 }
 
+func TestContainerLogArgs(t *testing.T) {
+	// prepare
+	opampBridge := v1alpha1.OpAMPBridge{
+		Spec: v1alpha1.OpAMPBridgeSpec{
+			LogLevel:  v1alpha1.LogLevelError,
+			LogFormat: v1alpha1.LogFormatConsole,
+		},
+	}
+	cfg := config.New()
+
+	// test
+	c := Container(cfg, logger, opampBridge)
+
+	// verify
+	assert.Equal(t, []string{"--zap-log-level=error", "--zap-encoder=console"}, c.Args)
+	assert.Empty(t, Container(cfg, logger, v1alpha1.OpAMPBridge{}).Args)
+}
+
+func TestContainerBridgeNameEnvVar(t *testing.T) {
+	// prepare
+	opampBridge := v1alpha1.OpAMPBridge{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+	}
+	cfg := config.New()
+
+	// test
+	c := Container(cfg, logger, opampBridge)
+
+	// verify
+	assert.Contains(t, c.Env, corev1.EnvVar{Name: "OPAMP_BRIDGE_NAME", Value: "my-instance"})
+}
+
+func TestContainerBridgeUIDEnvVar(t *testing.T) {
+	// prepare
+	opampBridge := v1alpha1.OpAMPBridge{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+			UID:  "bridge-uid",
+		},
+	}
+	cfg := config.New()
+
+	// test
+	c := Container(cfg, logger, opampBridge)
+
+	// verify
+	for _, env := range c.Env {
+		assert.NotEqual(t, "OPAMP_BRIDGE_UID", env.Name)
+	}
+
+	// test
+	opampBridge.Spec.PersistInstanceID = true
+	c = Container(cfg, logger, opampBridge)
+
+	// verify
+	assert.Contains(t, c.Env, corev1.EnvVar{Name: "OPAMP_BRIDGE_UID", Value: "bridge-uid"})
+}
+
 func TestContainerVolumes(t *testing.T) {
 	// prepare
 	opampBridge := v1alpha1.OpAMPBridge{
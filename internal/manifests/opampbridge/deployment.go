@@ -27,19 +27,19 @@ import (
 // Deployment builds the deployment for the given instance.
 func Deployment(params manifests.Params) *appsv1.Deployment {
 	name := naming.OpAMPBridge(params.OpAMPBridge.Name)
-	labels := manifestutils.Labels(params.OpAMPBridge.ObjectMeta, name, params.OpAMPBridge.Spec.Image, ComponentOpAMPBridge, params.Config.LabelsFilter())
+	labels := manifestutils.Labels(params.OpAMPBridge.ObjectMeta, name, params.OpAMPBridge.Spec.Image, ComponentOpAMPBridge, params.Config.LabelsFilter(), params.OpAMPBridge.Spec.StandardLabels)
 
 	return &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        name,
 			Namespace:   params.OpAMPBridge.Namespace,
 			Labels:      labels,
-			Annotations: params.OpAMPBridge.Annotations,
+			Annotations: Annotations(params.OpAMPBridge, params.Config.AnnotationsFilter()),
 		},
 		Spec: appsv1.DeploymentSpec{
 			Replicas: params.OpAMPBridge.Spec.Replicas,
 			Selector: &metav1.LabelSelector{
-				MatchLabels: manifestutils.SelectorLabels(params.OpAMPBridge.ObjectMeta, ComponentOpAMPBridge),
+				MatchLabels: manifestutils.SelectorLabels(params.OpAMPBridge.ObjectMeta, ComponentOpAMPBridge, params.OpAMPBridge.Spec.StandardLabels),
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
@@ -52,8 +52,8 @@ func Deployment(params manifests.Params) *appsv1.Deployment {
 					Volumes:                   Volumes(params.Config, params.OpAMPBridge),
 					DNSPolicy:                 getDNSPolicy(params.OpAMPBridge),
 					HostNetwork:               params.OpAMPBridge.Spec.HostNetwork,
-					Tolerations:               params.OpAMPBridge.Spec.Tolerations,
-					NodeSelector:              params.OpAMPBridge.Spec.NodeSelector,
+					Tolerations:               manifestutils.Tolerations(params.Config, params.OpAMPBridge.Spec.Tolerations),
+					NodeSelector:              manifestutils.NodeSelector(params.Config, params.OpAMPBridge.Spec.NodeSelector),
 					SecurityContext:           params.OpAMPBridge.Spec.PodSecurityContext,
 					PriorityClassName:         params.OpAMPBridge.Spec.PriorityClassName,
 					Affinity:                  params.OpAMPBridge.Spec.Affinity,
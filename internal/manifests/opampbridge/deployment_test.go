@@ -223,6 +223,37 @@ func TestDeploymentHostNetwork(t *testing.T) {
 	assert.Equal(t, d2.Spec.Template.Spec.DNSPolicy, v1.DNSClusterFirstWithHostNet)
 }
 
+func TestDeploymentAnnotations(t *testing.T) {
+	// prepare
+	opampBridge := v1alpha1.OpAMPBridge{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+			Annotations: map[string]string{
+				"owner":                              "team",
+				"reloader.stakater.com/auto":         "false",
+				"kustomize.config.k8s.io/needs-hash": "true",
+			},
+		},
+		Spec: v1alpha1.OpAMPBridgeSpec{
+			Annotations:    map[string]string{"reloader.stakater.com/auto": "true"},
+			PodAnnotations: map[string]string{"pod": "annotation"},
+		},
+	}
+
+	params := manifests.Params{
+		Config:      config.New(config.WithAnnotationFilters([]string{"kustomize.config.k8s.io/*"})),
+		OpAMPBridge: opampBridge,
+		Log:         logger,
+	}
+
+	// test
+	d := Deployment(params)
+
+	// verify
+	assert.Equal(t, map[string]string{"owner": "team", "reloader.stakater.com/auto": "true"}, d.Annotations)
+	assert.Equal(t, map[string]string{"pod": "annotation"}, d.Spec.Template.Annotations)
+}
+
 func TestDeploymentFilterLabels(t *testing.T) {
 	excludedLabels := map[string]string{
 		"foo":         "1",
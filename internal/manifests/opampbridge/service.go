@@ -26,8 +26,8 @@ import (
 
 func Service(params manifests.Params) *corev1.Service {
 	name := naming.OpAMPBridgeService(params.OpAMPBridge.Name)
-	labels := manifestutils.Labels(params.OpAMPBridge.ObjectMeta, name, params.OpAMPBridge.Spec.Image, ComponentOpAMPBridge, []string{})
-	selector := manifestutils.SelectorLabels(params.OpAMPBridge.ObjectMeta, ComponentOpAMPBridge)
+	labels := manifestutils.Labels(params.OpAMPBridge.ObjectMeta, name, params.OpAMPBridge.Spec.Image, ComponentOpAMPBridge, []string{}, params.OpAMPBridge.Spec.StandardLabels)
+	selector := manifestutils.SelectorLabels(params.OpAMPBridge.ObjectMeta, ComponentOpAMPBridge, params.OpAMPBridge.Spec.StandardLabels)
 
 	ports := []corev1.ServicePort{{
 		Name:       "opamp-bridge",
@@ -44,8 +44,10 @@ func Service(params manifests.Params) *corev1.Service {
 			Labels:    labels,
 		},
 		Spec: corev1.ServiceSpec{
-			Selector: selector,
-			Ports:    ports,
+			Selector:       selector,
+			Ports:          ports,
+			IPFamilies:     params.OpAMPBridge.Spec.IPFamilies,
+			IPFamilyPolicy: params.OpAMPBridge.Spec.IPFamilyPolicy,
 		},
 	}
 }
@@ -19,6 +19,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 )
 
@@ -39,5 +40,7 @@ func Volumes(cfg config.Config, opampBridge v1alpha1.OpAMPBridge) []corev1.Volum
 		},
 	}}
 
+	volumes = append(volumes, manifestutils.SecretsStoreVolumes(opampBridge.Spec.SecretProviderClasses)...)
+
 	return volumes
 }
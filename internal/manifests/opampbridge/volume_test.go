@@ -41,3 +41,25 @@ func TestVolumeNewDefault(t *testing.T) {
 	// check that it's the opamp-bridge-internal volume, with the config map
 	assert.Equal(t, naming.OpAMPBridgeConfigMapVolume(), volumes[0].Name)
 }
+
+func TestVolumeWithSecretProviderClasses(t *testing.T) {
+	// prepare
+	opampBridge := v1alpha1.OpAMPBridge{
+		Spec: v1alpha1.OpAMPBridgeSpec{
+			SecretProviderClasses: []v1alpha1.SecretProviderClassVolume{{Name: "opamp-credentials"}},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	volumes := Volumes(cfg, opampBridge)
+	c := Container(cfg, logger, opampBridge)
+
+	// verify
+	assert.Len(t, volumes, 2)
+	assert.Equal(t, "secrets-store-opamp-credentials", volumes[1].Name)
+	assert.Equal(t, "opamp-credentials", volumes[1].CSI.VolumeAttributes["secretProviderClass"])
+	assert.Len(t, c.VolumeMounts, 2)
+	assert.Equal(t, "secrets-store-opamp-credentials", c.VolumeMounts[1].Name)
+	assert.Equal(t, "/var/run/secrets-store/opamp-credentials", c.VolumeMounts[1].MountPath)
+}
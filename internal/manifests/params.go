@@ -33,4 +33,6 @@ type Params struct {
 	OtelCol     v1alpha1.OpenTelemetryCollector
 	OpAMPBridge v1alpha1.OpAMPBridge
 	Config      config.Config
+	// ScrapeConfigs are the scrape configs resolved from the ServiceMonitors and PodMonitors selected by the collector.
+	ScrapeConfigs []map[string]interface{}
 }
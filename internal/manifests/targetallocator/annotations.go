@@ -21,6 +21,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 )
 
 const configMapHashAnnotationKey = "opentelemetry-targetallocator-config/hash"
@@ -43,6 +44,12 @@ func Annotations(instance v1alpha1.OpenTelemetryCollector, configMap *v1.ConfigM
 	return annotations
 }
 
+// WorkloadAnnotations returns the annotations for the TargetAllocator Deployment, i.e. the annotations of the instance
+// not matching the filters. They aren't set on the pods, so that annotating the instance doesn't restart them.
+func WorkloadAnnotations(instance v1alpha1.OpenTelemetryCollector, filterAnnotations []string) map[string]string {
+	return manifestutils.FilterAnnotations(instance.Annotations, filterAnnotations)
+}
+
 // getConfigMapSHA returns the hash of the content of the TA ConfigMap.
 func getConfigMapSHA(configMap *v1.ConfigMap) string {
 	configString, ok := configMap.Data[targetAllocatorFilename]
@@ -61,3 +61,26 @@ func TestInvalidConfigNoHash(t *testing.T) {
 	annotations := Annotations(instance, nil)
 	require.NotContains(t, annotations, configMapHashAnnotationKey)
 }
+
+func TestWorkloadAnnotationsFilter(t *testing.T) {
+	// prepare
+	instance := collectorInstance()
+	instance.Annotations = map[string]string{
+		"owner": "team",
+		"kubectl.kubernetes.io/last-applied-configuration": "{}",
+		"argocd.argoproj.io/sync-wave":                     "1",
+	}
+	cfg := config.New(config.WithAnnotationFilters([]string{"kubectl.kubernetes.io/last-applied-configuration", "argocd.argoproj.io/*"}))
+	params := manifests.Params{
+		OtelCol: instance,
+		Config:  cfg,
+		Log:     logr.Discard(),
+	}
+
+	// test
+	d := Deployment(params)
+
+	// verify
+	assert.Equal(t, map[string]string{"owner": "team"}, d.Annotations)
+	assert.NotContains(t, d.Spec.Template.Annotations, "owner")
+}
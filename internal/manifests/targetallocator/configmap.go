@@ -17,7 +17,6 @@ package targetallocator
 import (
 	"strings"
 
-	"gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -52,7 +51,7 @@ func ConfigMap(params manifests.Params) (*corev1.ConfigMap, error) {
 
 	taConfig := make(map[interface{}]interface{})
 	prometheusCRConfig := make(map[interface{}]interface{})
-	taConfig["label_selector"] = manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, collector.ComponentOpenTelemetryCollector)
+	taConfig["label_selector"] = manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, collector.ComponentOpenTelemetryCollector, params.OtelCol.Spec.StandardLabels)
 	// We only take the "config" from the returned object, if it's present
 	if prometheusConfig, ok := prometheusReceiverConfig["config"]; ok {
 		taConfig["config"] = prometheusConfig
@@ -80,11 +79,22 @@ func ConfigMap(params manifests.Params) (*corev1.ConfigMap, error) {
 		taConfig["pod_monitor_selector"] = &params.OtelCol.Spec.TargetAllocator.PrometheusCR.PodMonitorSelector
 	}
 
+	var remoteClusters []map[string]string
+	for _, cluster := range params.OtelCol.Spec.TargetAllocator.PrometheusCR.RemoteClusters {
+		remoteClusters = append(remoteClusters, map[string]string{
+			"name":                  cluster.Name,
+			"kube_config_file_path": remoteClusterKubeconfigPath(cluster.Name),
+		})
+	}
+	if len(remoteClusters) > 0 {
+		prometheusCRConfig["remote_clusters"] = remoteClusters
+	}
+
 	if len(prometheusCRConfig) > 0 {
 		taConfig["prometheus_cr"] = prometheusCRConfig
 	}
 
-	taConfigYAML, err := yaml.Marshal(taConfig)
+	taConfigYAML, err := manifestutils.MarshalYAML(taConfig)
 	if err != nil {
 		return &corev1.ConfigMap{}, err
 	}
@@ -20,8 +20,10 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
 )
@@ -157,4 +159,51 @@ prometheus_cr:
 
 	})
 
+	t.Run("should return expected target allocator config map with remote clusters", func(t *testing.T) {
+		expectedLables["app.kubernetes.io/component"] = "opentelemetry-targetallocator"
+		expectedLables["app.kubernetes.io/name"] = "my-instance-targetallocator"
+
+		expectedData := map[string]string{
+			"targetallocator.yaml": `allocation_strategy: least-weighted
+config:
+  scrape_configs:
+  - job_name: otel-collector
+    scrape_interval: 10s
+    static_configs:
+    - targets:
+      - 0.0.0.0:8888
+      - 0.0.0.0:9999
+label_selector:
+  app.kubernetes.io/component: opentelemetry-collector
+  app.kubernetes.io/instance: default.my-instance
+  app.kubernetes.io/managed-by: opentelemetry-operator
+  app.kubernetes.io/part-of: opentelemetry
+prometheus_cr:
+  remote_clusters:
+  - kube_config_file_path: /etc/remote-clusters/east/kubeconfig
+    name: east
+`,
+		}
+
+		collector := collectorInstance()
+		collector.Spec.TargetAllocator.PrometheusCR.Enabled = true
+		collector.Spec.TargetAllocator.PrometheusCR.RemoteClusters = []v1alpha1.OpenTelemetryTargetAllocatorRemoteCluster{{
+			Name: "east",
+			KubeconfigSecret: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "east-kubeconfig"},
+				Key:                  "config",
+			},
+		}}
+		cfg := config.New()
+		params := manifests.Params{
+			OtelCol: collector,
+			Config:  cfg,
+			Log:     logr.Discard(),
+		}
+		actual, err := ConfigMap(params)
+		assert.NoError(t, err)
+
+		assert.Equal(t, expectedData, actual.Data)
+	})
+
 }
@@ -21,6 +21,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 )
 
@@ -35,6 +36,7 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelem
 		Name:      naming.TAConfigMapVolume(),
 		MountPath: "/conf",
 	}}
+	volumeMounts = append(volumeMounts, remoteClusterVolumeMounts(otelcol)...)
 
 	var envVars = otelcol.Spec.TargetAllocator.Env
 	if otelcol.Spec.TargetAllocator.Env == nil {
@@ -62,13 +64,15 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelem
 	if otelcol.Spec.TargetAllocator.PrometheusCR.Enabled {
 		args = append(args, "--enable-prometheus-cr-watcher")
 	}
+	args = append(args, manifestutils.LogArgs(otelcol.Spec.TargetAllocator.LogLevel, otelcol.Spec.TargetAllocator.LogFormat)...)
 	envVars = append(envVars, proxy.ReadProxyVarsFromEnv()...)
 	return corev1.Container{
-		Name:         naming.TAContainer(),
-		Image:        image,
-		Env:          envVars,
-		VolumeMounts: volumeMounts,
-		Resources:    otelcol.Spec.TargetAllocator.Resources,
-		Args:         args,
+		Name:            naming.TAContainer(),
+		Image:           image,
+		Env:             envVars,
+		VolumeMounts:    volumeMounts,
+		Resources:       manifestutils.Resources(cfg, otelcol.Spec.TargetAllocator.Resources),
+		Args:            args,
+		SecurityContext: manifestutils.SecurityContext(cfg, nil),
 	}
 }
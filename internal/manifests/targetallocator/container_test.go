@@ -62,6 +62,29 @@ func TestContainerWithImageOverridden(t *testing.T) {
 	assert.Equal(t, "overridden-image", c.Image)
 }
 
+func TestContainerLogArgs(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			TargetAllocator: v1alpha1.OpenTelemetryTargetAllocator{
+				Enabled: true,
+				PrometheusCR: v1alpha1.OpenTelemetryTargetAllocatorPrometheusCR{
+					Enabled: true,
+				},
+				LogLevel:  v1alpha1.LogLevelDebug,
+				LogFormat: v1alpha1.LogFormatJSON,
+			},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	c := Container(cfg, logger, otelcol)
+
+	// verify
+	assert.Equal(t, []string{"--enable-prometheus-cr-watcher", "--zap-log-level=debug", "--zap-encoder=json"}, c.Args)
+}
+
 func TestContainerVolumes(t *testing.T) {
 	// prepare
 	otelcol := v1alpha1.OpenTelemetryCollector{
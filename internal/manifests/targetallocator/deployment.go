@@ -20,6 +20,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 )
 
@@ -37,9 +38,10 @@ func Deployment(params manifests.Params) *appsv1.Deployment {
 
 	return &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: params.OtelCol.Namespace,
-			Labels:    labels,
+			Name:        name,
+			Namespace:   params.OtelCol.Namespace,
+			Labels:      labels,
+			Annotations: WorkloadAnnotations(params.OtelCol, params.Config.AnnotationsFilter()),
 		},
 		Spec: appsv1.DeploymentSpec{
 			Replicas: params.OtelCol.Spec.TargetAllocator.Replicas,
@@ -55,8 +57,8 @@ func Deployment(params manifests.Params) *appsv1.Deployment {
 					ServiceAccountName:        ServiceAccountName(params.OtelCol),
 					Containers:                []corev1.Container{Container(params.Config, params.Log, params.OtelCol)},
 					Volumes:                   Volumes(params.Config, params.OtelCol),
-					NodeSelector:              params.OtelCol.Spec.TargetAllocator.NodeSelector,
-					Tolerations:               params.OtelCol.Spec.TargetAllocator.Tolerations,
+					NodeSelector:              manifestutils.NodeSelector(params.Config, params.OtelCol.Spec.TargetAllocator.NodeSelector),
+					Tolerations:               manifestutils.Tolerations(params.Config, params.OtelCol.Spec.TargetAllocator.Tolerations),
 					TopologySpreadConstraints: params.OtelCol.Spec.TargetAllocator.TopologySpreadConstraints,
 				},
 			},
@@ -199,6 +199,11 @@ func TestDeploymentTolerations(t *testing.T) {
 	assert.NotNil(t, d2.Spec.Template.Spec.Tolerations)
 	assert.NotEmpty(t, d2.Spec.Template.Spec.Tolerations)
 	assert.Equal(t, testTolerationValues, d2.Spec.Template.Spec.Tolerations)
+
+	// Test the default tolerations of the operator
+	params1.Config = config.New(config.WithDefaultTolerations(testTolerationValues))
+	d3 := Deployment(params1)
+	assert.Equal(t, testTolerationValues, d3.Spec.Template.Spec.Tolerations)
 }
 
 func TestDeploymentTopologySpreadConstraints(t *testing.T) {
@@ -16,6 +16,7 @@ package targetallocator
 
 import (
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 )
 
@@ -31,12 +32,20 @@ func Labels(instance v1alpha1.OpenTelemetryCollector, name string) map[string]st
 
 	base["app.kubernetes.io/managed-by"] = "opentelemetry-operator"
 	base["app.kubernetes.io/instance"] = naming.Truncate("%s.%s", 63, instance.Namespace, instance.Name)
-	base["app.kubernetes.io/part-of"] = "opentelemetry"
+	base["app.kubernetes.io/part-of"] = manifestutils.PartOf(instance.Spec.StandardLabels)
 	base["app.kubernetes.io/component"] = "opentelemetry-targetallocator"
 
 	if _, ok := base["app.kubernetes.io/name"]; !ok {
 		base["app.kubernetes.io/name"] = name
 	}
+	if standard := instance.Spec.StandardLabels; standard != nil {
+		if standard.Name != "" {
+			base["app.kubernetes.io/name"] = standard.Name
+		}
+		if standard.Version != "" {
+			base["app.kubernetes.io/version"] = standard.Version
+		}
+	}
 
 	return base
 }
@@ -65,3 +65,25 @@ func TestLabelsPropagateDown(t *testing.T) {
 	assert.Equal(t, "mycomponent", labels["myapp"])
 	assert.Equal(t, "test", labels["app.kubernetes.io/name"])
 }
+
+func TestLabelsStandardOverrides(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			StandardLabels: &v1alpha1.StandardLabels{PartOf: "payments", Version: "2024.1"},
+		},
+	}
+
+	// test
+	labels := Labels(otelcol, name)
+
+	// verify
+	assert.Equal(t, "payments", labels["app.kubernetes.io/part-of"])
+	assert.Equal(t, "2024.1", labels["app.kubernetes.io/version"])
+	assert.Equal(t, name, labels["app.kubernetes.io/name"])
+	assert.Equal(t, "opentelemetry-operator", labels["app.kubernetes.io/managed-by"])
+}
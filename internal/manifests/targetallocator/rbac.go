@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetallocator
+
+import (
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect"
+)
+
+// prometheusCRRules are the permissions the TargetAllocator needs to discover the targets of the ServiceMonitors and
+// PodMonitors. The operator must hold them itself to be allowed to grant them.
+var prometheusCRRules = []rbacv1.PolicyRule{
+	{
+		APIGroups: []string{""},
+		Resources: []string{"pods", "services", "endpoints", "namespaces"},
+		Verbs:     []string{"get", "list", "watch"},
+	},
+	{
+		APIGroups: []string{"discovery.k8s.io"},
+		Resources: []string{"endpointslices"},
+		Verbs:     []string{"get", "list", "watch"},
+	},
+	{
+		APIGroups: []string{"monitoring.coreos.com"},
+		Resources: []string{"servicemonitors", "podmonitors"},
+		Verbs:     []string{"get", "list", "watch"},
+	},
+}
+
+// ClusterRole returns the ClusterRole granting the TargetAllocator access to the Prometheus CRs and their targets, when
+// the prometheusCR mode is enabled and the operator is allowed to create it.
+func ClusterRole(params manifests.Params) *rbacv1.ClusterRole {
+	if !needsClusterRBAC(params) {
+		return nil
+	}
+	name := naming.TargetAllocatorClusterRole(params.OtelCol.Name, params.OtelCol.Namespace)
+	return &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: Labels(params.OtelCol, name),
+		},
+		Rules: prometheusCRRules,
+	}
+}
+
+// ClusterRoleBinding returns the ClusterRoleBinding granting the ClusterRole to the service account of the
+// TargetAllocator.
+func ClusterRoleBinding(params manifests.Params) *rbacv1.ClusterRoleBinding {
+	if !needsClusterRBAC(params) {
+		return nil
+	}
+	name := naming.TargetAllocatorClusterRoleBinding(params.OtelCol.Name, params.OtelCol.Namespace)
+	return &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: Labels(params.OtelCol, name),
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      ServiceAccountName(params.OtelCol),
+				Namespace: params.OtelCol.Namespace,
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     naming.TargetAllocatorClusterRole(params.OtelCol.Name, params.OtelCol.Namespace),
+		},
+	}
+}
+
+func needsClusterRBAC(params manifests.Params) bool {
+	return params.OtelCol.Spec.TargetAllocator.PrometheusCR.Enabled &&
+		params.Config.CreateRBACPermissions() == autodetect.RBACPermissionsAvailable
+}
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetallocator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect"
+)
+
+func rbacParams(prometheusCR bool, rbac autodetect.RBACPermissions) manifests.Params {
+	return manifests.Params{
+		Config: config.New(config.WithRBACPermissions(rbac)),
+		OtelCol: v1alpha1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-instance",
+				Namespace: "my-ns",
+			},
+			Spec: v1alpha1.OpenTelemetryCollectorSpec{
+				TargetAllocator: v1alpha1.OpenTelemetryTargetAllocator{
+					Enabled: true,
+					PrometheusCR: v1alpha1.OpenTelemetryTargetAllocatorPrometheusCR{
+						Enabled: prometheusCR,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestClusterRBACForPrometheusCR(t *testing.T) {
+	// prepare
+	params := rbacParams(true, autodetect.RBACPermissionsAvailable)
+
+	// test
+	role := ClusterRole(params)
+	binding := ClusterRoleBinding(params)
+
+	// verify
+	require.NotNil(t, role)
+	require.NotNil(t, binding)
+	assert.Equal(t, "my-ns.my-instance-targetallocator", role.Name)
+	assert.Equal(t, "my-ns.my-instance", role.Labels["app.kubernetes.io/instance"])
+	assert.Contains(t, role.Rules, rbacv1.PolicyRule{
+		APIGroups: []string{"monitoring.coreos.com"},
+		Resources: []string{"servicemonitors", "podmonitors"},
+		Verbs:     []string{"get", "list", "watch"},
+	})
+	assert.Equal(t, role.Name, binding.RoleRef.Name)
+	assert.Equal(t, []rbacv1.Subject{{Kind: "ServiceAccount", Name: "my-instance-collector", Namespace: "my-ns"}}, binding.Subjects)
+}
+
+func TestNoClusterRBAC(t *testing.T) {
+	for _, tt := range []struct {
+		desc         string
+		prometheusCR bool
+		rbac         autodetect.RBACPermissions
+	}{
+		{"prometheusCR disabled", false, autodetect.RBACPermissionsAvailable},
+		{"operator not allowed", true, autodetect.RBACPermissionsNotAvailable},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			params := rbacParams(tt.prometheusCR, tt.rbac)
+			assert.Nil(t, ClusterRole(params))
+			assert.Nil(t, ClusterRoleBinding(params))
+		})
+	}
+}
+
+func TestNamespaceScopedRejectsPrometheusCR(t *testing.T) {
+	// prepare
+	params := rbacParams(true, autodetect.RBACPermissionsNotAvailable)
+	params.Config = config.New(config.WithNamespaceScoped(true))
+
+	// test
+	objs, err := Build(params)
+
+	// verify
+	assert.ErrorContains(t, err, "namespace-scoped operator")
+	assert.Empty(t, objs)
+}
@@ -42,6 +42,8 @@ func Service(params manifests.Params) *corev1.Service {
 				Port:       80,
 				TargetPort: intstr.FromInt(8080),
 			}},
+			IPFamilies:     params.OtelCol.Spec.TargetAllocator.IPFamilies,
+			IPFamilyPolicy: params.OtelCol.Spec.TargetAllocator.IPFamilyPolicy,
 		},
 	}
 }
@@ -27,7 +27,7 @@ import (
 // ServiceAccountName returns the name of the existing or self-provisioned service account to use for the given instance.
 func ServiceAccountName(instance v1alpha1.OpenTelemetryCollector) string {
 	if len(instance.Spec.TargetAllocator.ServiceAccount) == 0 {
-		return naming.ServiceAccount(instance.Name)
+		return naming.ServiceAccount(&instance)
 	}
 
 	return instance.Spec.TargetAllocator.ServiceAccount
@@ -15,6 +15,8 @@
 package targetallocator
 
 import (
+	"errors"
+
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
@@ -26,11 +28,17 @@ func Build(params manifests.Params) ([]client.Object, error) {
 	if !params.OtelCol.Spec.TargetAllocator.Enabled {
 		return resourceManifests, nil
 	}
+	// the webhooks are usually disabled along with the cluster-scoped permissions, the resource is then rejected here
+	if params.Config.NamespaceScoped() && params.OtelCol.Spec.TargetAllocator.PrometheusCR.Enabled {
+		return nil, errors.New("the prometheusCR of the target allocator needs a ClusterRole, which a namespace-scoped operator can't create")
+	}
 	resourceFactories := []manifests.K8sManifestFactory{
 		manifests.Factory(ConfigMap),
 		manifests.FactoryWithoutError(Deployment),
 		manifests.FactoryWithoutError(ServiceAccount),
 		manifests.FactoryWithoutError(Service),
+		manifests.FactoryWithoutError(ClusterRole),
+		manifests.FactoryWithoutError(ClusterRoleBinding),
 	}
 	for _, factory := range resourceFactories {
 		res, err := factory(params)
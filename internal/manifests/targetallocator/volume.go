@@ -15,6 +15,8 @@
 package targetallocator
 
 import (
+	"path"
+
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
@@ -22,6 +24,12 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 )
 
+const (
+	// remoteClustersMountPath is the directory holding the kubeconfigs of the remote clusters, one per subdirectory.
+	remoteClustersMountPath = "/etc/remote-clusters"
+	remoteClusterKubeconfig = "kubeconfig"
+)
+
 // Volumes builds the volumes for the given instance, including the config map volume.
 func Volumes(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) []corev1.Volume {
 	volumes := []corev1.Volume{{
@@ -38,5 +46,38 @@ func Volumes(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) []corev
 		},
 	}}
 
+	for _, cluster := range otelcol.Spec.TargetAllocator.PrometheusCR.RemoteClusters {
+		volumes = append(volumes, corev1.Volume{
+			Name: naming.TARemoteClusterVolume(cluster.Name),
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: cluster.KubeconfigSecret.Name,
+					Items: []corev1.KeyToPath{{
+						Key:  cluster.KubeconfigSecret.Key,
+						Path: remoteClusterKubeconfig,
+					}},
+				},
+			},
+		})
+	}
+
 	return volumes
 }
+
+// remoteClusterVolumeMounts mounts the kubeconfig of each remote cluster in its own directory.
+func remoteClusterVolumeMounts(otelcol v1alpha1.OpenTelemetryCollector) []corev1.VolumeMount {
+	var mounts []corev1.VolumeMount
+	for _, cluster := range otelcol.Spec.TargetAllocator.PrometheusCR.RemoteClusters {
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      naming.TARemoteClusterVolume(cluster.Name),
+			MountPath: path.Join(remoteClustersMountPath, cluster.Name),
+			ReadOnly:  true,
+		})
+	}
+	return mounts
+}
+
+// remoteClusterKubeconfigPath returns the path of the kubeconfig of the remote cluster in the TargetAllocator pod.
+func remoteClusterKubeconfigPath(cluster string) string {
+	return path.Join(remoteClustersMountPath, cluster, remoteClusterKubeconfig)
+}
@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
@@ -41,3 +42,35 @@ func TestVolumeNewDefault(t *testing.T) {
 	// check that it's the ta-internal volume, with the config map
 	assert.Equal(t, naming.TAConfigMapVolume(), volumes[0].Name)
 }
+
+func TestVolumeRemoteClusters(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			TargetAllocator: v1alpha1.OpenTelemetryTargetAllocator{
+				PrometheusCR: v1alpha1.OpenTelemetryTargetAllocatorPrometheusCR{
+					Enabled: true,
+					RemoteClusters: []v1alpha1.OpenTelemetryTargetAllocatorRemoteCluster{{
+						Name: "east",
+						KubeconfigSecret: corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "east-kubeconfig"},
+							Key:                  "config",
+						},
+					}},
+				},
+			},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	volumes := Volumes(cfg, otelcol)
+	mounts := remoteClusterVolumeMounts(otelcol)
+
+	// verify
+	assert.Len(t, volumes, 2)
+	assert.Equal(t, "remote-cluster-east", volumes[1].Name)
+	assert.Equal(t, "east-kubeconfig", volumes[1].Secret.SecretName)
+	assert.Equal(t, []corev1.KeyToPath{{Key: "config", Path: "kubeconfig"}}, volumes[1].Secret.Items)
+	assert.Equal(t, []corev1.VolumeMount{{Name: "remote-cluster-east", MountPath: "/etc/remote-clusters/east", ReadOnly: true}}, mounts)
+}
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics contains the metrics the operator exposes about the reconciliation of the custom resources it manages.
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// OperationCreated is recorded when a child object was created.
+	OperationCreated = "created"
+	// OperationUpdated is recorded when a child object was updated.
+	OperationUpdated = "updated"
+	// OperationDeleted is recorded when a child object was deleted.
+	OperationDeleted = "deleted"
+)
+
+var (
+	// the metrics are registered with the controller-runtime registry, so they are served by the manager's metrics endpoint
+	factory = promauto.With(metrics.Registry)
+
+	reconcileDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "opentelemetry_operator_reconcile_duration_seconds",
+		Help: "Duration of the reconciliation of a custom resource.",
+	}, []string{"kind", "namespace", "name"})
+	reconcileErrors = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "opentelemetry_operator_reconcile_errors_total",
+		Help: "Number of failed reconciliations of a custom resource.",
+	}, []string{"kind", "namespace", "name"})
+	childObjects = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "opentelemetry_operator_child_objects_total",
+		Help: "Number of child objects created, updated or deleted for a custom resource.",
+	}, []string{"kind", "namespace", "name", "operation"})
+	manifestBuildDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "opentelemetry_operator_manifest_build_duration_seconds",
+		Help: "Duration of rendering the configuration and building the manifests of a custom resource.",
+	}, []string{"kind", "namespace", "name"})
+)
+
+// ObserveReconcile records the duration of a reconciliation and whether it failed.
+func ObserveReconcile(kind string, key types.NamespacedName, duration time.Duration, err error) {
+	reconcileDuration.WithLabelValues(kind, key.Namespace, key.Name).Observe(duration.Seconds())
+	if err != nil {
+		reconcileErrors.WithLabelValues(kind, key.Namespace, key.Name).Inc()
+	}
+}
+
+// ObserveManifestBuild records the time it took to build the manifests.
+func ObserveManifestBuild(kind string, key types.NamespacedName, duration time.Duration) {
+	manifestBuildDuration.WithLabelValues(kind, key.Namespace, key.Name).Observe(duration.Seconds())
+}
+
+// RecordChildObject counts an operation on one of the child objects.
+func RecordChildObject(kind string, key types.NamespacedName, operation string) {
+	childObjects.WithLabelValues(kind, key.Namespace, key.Name, operation).Inc()
+}
+
+// Forget removes the series of a custom resource which no longer exists.
+func Forget(kind string, key types.NamespacedName) {
+	labels := prometheus.Labels{"kind": kind, "namespace": key.Namespace, "name": key.Name}
+	reconcileDuration.DeletePartialMatch(labels)
+	reconcileErrors.DeletePartialMatch(labels)
+	childObjects.DeletePartialMatch(labels)
+	manifestBuildDuration.DeletePartialMatch(labels)
+}
@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestReconcileMetrics(t *testing.T) {
+	key := types.NamespacedName{Namespace: "default", Name: "metrics"}
+
+	ObserveReconcile("OpenTelemetryCollector", key, time.Second, nil)
+	ObserveReconcile("OpenTelemetryCollector", key, time.Second, errors.New("boom"))
+	ObserveManifestBuild("OpenTelemetryCollector", key, time.Millisecond)
+	RecordChildObject("OpenTelemetryCollector", key, OperationCreated)
+	RecordChildObject("OpenTelemetryCollector", key, OperationCreated)
+	RecordChildObject("OpenTelemetryCollector", key, OperationDeleted)
+
+	assert.Equal(t, 1, testutil.CollectAndCount(reconcileDuration))
+	assert.Equal(t, 1.0, testutil.ToFloat64(reconcileErrors.WithLabelValues("OpenTelemetryCollector", "default", "metrics")))
+	assert.Equal(t, 1, testutil.CollectAndCount(manifestBuildDuration))
+	assert.Equal(t, 2.0, testutil.ToFloat64(childObjects.WithLabelValues("OpenTelemetryCollector", "default", "metrics", OperationCreated)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(childObjects.WithLabelValues("OpenTelemetryCollector", "default", "metrics", OperationDeleted)))
+
+	// the series of other custom resources are kept
+	ObserveReconcile("OpAMPBridge", key, time.Second, nil)
+	Forget("OpenTelemetryCollector", key)
+	assert.Equal(t, 1, testutil.CollectAndCount(reconcileDuration))
+	assert.Equal(t, 0, testutil.CollectAndCount(reconcileErrors))
+	assert.Equal(t, 0, testutil.CollectAndCount(manifestBuildDuration))
+	assert.Equal(t, 0, testutil.CollectAndCount(childObjects))
+}
@@ -15,19 +15,42 @@
 // Package naming is for determining the names for components (containers, services, ...).
 package naming
 
+import "strconv"
+
+// Instance is a custom resource whose child resources are named after it.
+type Instance interface {
+	GetName() string
+	// GetNameOverride returns the name replacing the one of the instance in the names of its child resources.
+	GetNameOverride() string
+	// GetFullnameOverride returns the name replacing the names of the child resources altogether.
+	GetFullnameOverride() string
+}
+
+// collector builds the name shared by the resources of the collector instance, unless it's overridden.
+func collector(otelcol Instance) string {
+	if fullname := otelcol.GetFullnameOverride(); fullname != "" {
+		return DNSName(TruncateWithHash("%s", 63, fullname))
+	}
+	name := otelcol.GetName()
+	if override := otelcol.GetNameOverride(); override != "" {
+		name = override
+	}
+	return DNSName(TruncateWithHash("%s-collector", 63, name))
+}
+
 // ConfigMap builds the name for the config map used in the OpenTelemetryCollector containers.
-func ConfigMap(otelcol string) string {
-	return DNSName(Truncate("%s-collector", 63, otelcol))
+func ConfigMap(otelcol Instance) string {
+	return collector(otelcol)
 }
 
 // TAConfigMap returns the name for the config map used in the TargetAllocator.
 func TAConfigMap(otelcol string) string {
-	return DNSName(Truncate("%s-targetallocator", 63, otelcol))
+	return DNSName(TruncateWithHash("%s-targetallocator", 63, otelcol))
 }
 
 // OpAMPBridgeConfigMap builds the name for the config map used in the OpAMPBridge containers.
 func OpAMPBridgeConfigMap(opampBridge string) string {
-	return DNSName(Truncate("%s-opamp-bridge", 63, opampBridge))
+	return DNSName(TruncateWithHash("%s-opamp-bridge", 63, opampBridge))
 }
 
 // ConfigMapVolume returns the name to use for the config map's volume in the pod.
@@ -40,12 +63,33 @@ func ConfigMapExtra(extraConfigMapName string) string {
 	return DNSName(Truncate("configmap-%s", 63, extraConfigMapName))
 }
 
+// SecretsStoreVolume returns the name to use for the volume of a SecretProviderClass in the pod.
+func SecretsStoreVolume(secretProviderClass string) string {
+	return DNSName(Truncate("secrets-store-%s", 63, secretProviderClass))
+}
+
+// TLSSecretVolume returns the name to use for the volume of a Secret read by the TLS settings of the collector.
+func TLSSecretVolume(secret string) string {
+	return DNSName(Truncate("tls-%s", 63, secret))
+}
+
 // TAConfigMapVolume returns the name to use for the config map's volume in the TargetAllocator pod.
 func TAConfigMapVolume() string {
 	return "ta-internal"
 }
 
+// TARemoteClusterVolume returns the name to use for the volume of the kubeconfig of a remote cluster in the
+// TargetAllocator pod.
+func TARemoteClusterVolume(cluster string) string {
+	return DNSName(Truncate("remote-cluster-%s", 63, cluster))
+}
+
 // OpAMPBridgeConfigMapVolume returns the name to use for the config map's volume in the OpAMPBridge pod.
+// OpAMPBridgeInstanceIDConfigMap returns the name of the ConfigMap the OpAMPBridge stores its OpAMP instance UID in.
+func OpAMPBridgeInstanceIDConfigMap(opampBridge string) string {
+	return DNSName(TruncateWithHash("%s-opamp-bridge-instance-id", 63, opampBridge))
+}
+
 func OpAMPBridgeConfigMapVolume() string {
 	return "opamp-bridge-internal"
 }
@@ -66,91 +110,138 @@ func OpAMPBridgeContainer() string {
 }
 
 // Collector builds the collector (deployment/daemonset) name based on the instance.
-func Collector(otelcol string) string {
-	return DNSName(Truncate("%s-collector", 63, otelcol))
+func Collector(otelcol Instance) string {
+	return collector(otelcol)
+}
+
+// CollectorCanary builds the name of the Deployment and the ConfigMap of the canary rolling a new configuration out.
+func CollectorCanary(otelcol Instance) string {
+	return DNSName(TruncateWithHash("%s-canary", 63, collector(otelcol)))
 }
 
 // HorizontalPodAutoscaler builds the autoscaler name based on the instance.
-func HorizontalPodAutoscaler(otelcol string) string {
-	return DNSName(Truncate("%s-collector", 63, otelcol))
+func HorizontalPodAutoscaler(otelcol Instance) string {
+	return collector(otelcol)
 }
 
 // HorizontalPodAutoscaler builds the autoscaler name based on the instance.
-func PodDisruptionBudget(otelcol string) string {
-	return DNSName(Truncate("%s-collector", 63, otelcol))
+func PodDisruptionBudget(otelcol Instance) string {
+	return collector(otelcol)
 }
 
 // OpenTelemetryCollector builds the collector (deployment/daemonset) name based on the instance.
 func OpenTelemetryCollector(otelcol string) string {
-	return DNSName(Truncate("%s", 63, otelcol))
+	return DNSName(TruncateWithHash("%s", 63, otelcol))
 }
 
 // OpenTelemetryCollectorName builds the collector (deployment/daemonset) name based on the instance.
 func OpenTelemetryCollectorName(otelcolName string) string {
-	return DNSName(Truncate("%s", 63, otelcolName))
+	return DNSName(TruncateWithHash("%s", 63, otelcolName))
 }
 
 // TargetAllocator returns the TargetAllocator deployment resource name.
 func TargetAllocator(otelcol string) string {
-	return DNSName(Truncate("%s-targetallocator", 63, otelcol))
+	return DNSName(TruncateWithHash("%s-targetallocator", 63, otelcol))
 }
 
 // OpAMPBridge returns the OpAMPBridge deployment resource name.
 func OpAMPBridge(opampBridge string) string {
-	return DNSName(Truncate("%s-opamp-bridge", 63, opampBridge))
+	return DNSName(TruncateWithHash("%s-opamp-bridge", 63, opampBridge))
 }
 
 // HeadlessService builds the name for the headless service based on the instance.
-func HeadlessService(otelcol string) string {
-	return DNSName(Truncate("%s-headless", 63, Service(otelcol)))
+func HeadlessService(otelcol Instance) string {
+	return DNSName(TruncateWithHash("%s-headless", 63, Service(otelcol)))
+}
+
+// PodService builds the name for the service of the pod of the given ordinal of the statefulset of the instance.
+func PodService(otelcol Instance, ordinal int) string {
+	return DNSName(TruncateWithHash("%s-"+strconv.Itoa(ordinal), 63, Collector(otelcol)))
 }
 
 // MonitoringService builds the name for the monitoring service based on the instance.
-func MonitoringService(otelcol string) string {
-	return DNSName(Truncate("%s-monitoring", 63, Service(otelcol)))
+func MonitoringService(otelcol Instance) string {
+	return DNSName(TruncateWithHash("%s-monitoring", 63, Service(otelcol)))
+}
+
+// PrometheusExporterService builds the name for the service of the prometheus exporters based on the instance.
+func PrometheusExporterService(otelcol Instance) string {
+	return DNSName(TruncateWithHash("%s-prometheus", 63, Service(otelcol)))
+}
+
+// DebugService builds the name for the service of the debugging endpoints based on the instance.
+func DebugService(otelcol Instance) string {
+	return DNSName(TruncateWithHash("%s-debug", 63, Service(otelcol)))
+}
+
+// DashboardsConfigMap builds the name for the ConfigMap of the Grafana dashboards based on the instance.
+func DashboardsConfigMap(otelcol Instance) string {
+	return DNSName(TruncateWithHash("%s-dashboards", 63, ConfigMap(otelcol)))
+}
+
+// DebugNetworkPolicy builds the name for the network policy restricting the debugging endpoints based on the instance.
+func DebugNetworkPolicy(otelcol Instance) string {
+	return DebugService(otelcol)
 }
 
 // Service builds the service name based on the instance.
-func Service(otelcol string) string {
-	return DNSName(Truncate("%s-collector", 63, otelcol))
+func Service(otelcol Instance) string {
+	return collector(otelcol)
 }
 
 // Ingress builds the ingress name based on the instance.
 func Ingress(otelcol string) string {
-	return DNSName(Truncate("%s-ingress", 63, otelcol))
+	return DNSName(TruncateWithHash("%s-ingress", 63, otelcol))
 }
 
 // Route builds the route name based on the instance.
 func Route(otelcol string, prefix string) string {
-	return DNSName(Truncate("%s-%s-route", 63, prefix, otelcol))
+	return DNSName(TruncateWithHash("%s-%s-route", 63, prefix, otelcol))
 }
 
 // TAService returns the name to use for the TargetAllocator service.
 func TAService(otelcol string) string {
-	return DNSName(Truncate("%s-targetallocator", 63, otelcol))
+	return DNSName(TruncateWithHash("%s-targetallocator", 63, otelcol))
 }
 
 // OpAMPBridgeService returns the name to use for the OpAMPBridge service.
 func OpAMPBridgeService(opampBridge string) string {
-	return DNSName(Truncate("%s-opamp-bridge", 63, opampBridge))
+	return DNSName(TruncateWithHash("%s-opamp-bridge", 63, opampBridge))
 }
 
 // ServiceAccount builds the service account name based on the instance.
-func ServiceAccount(otelcol string) string {
-	return DNSName(Truncate("%s-collector", 63, otelcol))
+func ServiceAccount(otelcol Instance) string {
+	return collector(otelcol)
 }
 
 // ServiceMonitor builds the service account name based on the instance.
-func ServiceMonitor(otelcol string) string {
-	return DNSName(Truncate("%s-collector", 63, otelcol))
+func ServiceMonitor(otelcol Instance) string {
+	return collector(otelcol)
 }
 
 // TargetAllocatorServiceAccount returns the TargetAllocator service account resource name.
 func TargetAllocatorServiceAccount(otelcol string) string {
-	return DNSName(Truncate("%s-targetallocator", 63, otelcol))
+	return DNSName(TruncateWithHash("%s-targetallocator", 63, otelcol))
+}
+
+// TargetAllocatorClusterRole returns the name of the TargetAllocator ClusterRole, which is unique across namespaces.
+func TargetAllocatorClusterRole(otelcol, namespace string) string {
+	return clusterScoped("%s.%s-targetallocator", namespace, otelcol)
+}
+
+// TargetAllocatorClusterRoleBinding returns the name of the TargetAllocator ClusterRoleBinding, which is unique across namespaces.
+func TargetAllocatorClusterRoleBinding(otelcol, namespace string) string {
+	return clusterScoped("%s.%s-targetallocator", namespace, otelcol)
+}
+
+// clusterScoped builds the name of a cluster-scoped resource of the instance from its namespace and name. The namespace
+// comes first and is followed by a dot, which it can't contain, so that distinct instances never share a name, and the
+// long names are always shortened with a hash for the same reason.
+func clusterScoped(format, namespace, name string) string {
+	return truncateWithHash(format, 63, namespace, name)
 }
 
 // OpAMPBridgeServiceAccount builds the service account name based on the instance.
 func OpAMPBridgeServiceAccount(opampBridge string) string {
-	return DNSName(Truncate("%s-opamp-bridge", 63, opampBridge))
+	return DNSName(TruncateWithHash("%s-opamp-bridge", 63, opampBridge))
 }
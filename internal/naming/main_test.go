@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package naming
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	colfeaturegate "go.opentelemetry.io/collector/featuregate"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+type instance struct {
+	name, nameOverride, fullnameOverride string
+}
+
+func (i instance) GetName() string             { return i.name }
+func (i instance) GetNameOverride() string     { return i.nameOverride }
+func (i instance) GetFullnameOverride() string { return i.fullnameOverride }
+
+func TestCollectorNameOverrides(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		instance instance
+		expected string
+	}{
+		{
+			desc:     "instance name",
+			instance: instance{name: "my-instance"},
+			expected: "my-instance-collector",
+		},
+		{
+			desc:     "name override",
+			instance: instance{name: "my-instance", nameOverride: "otel"},
+			expected: "otel-collector",
+		},
+		{
+			desc:     "full name override",
+			instance: instance{name: "my-instance", nameOverride: "otel", fullnameOverride: "gateway"},
+			expected: "gateway",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Collector(tt.instance))
+			assert.Equal(t, tt.expected, Service(tt.instance))
+			assert.Equal(t, tt.expected, ConfigMap(tt.instance))
+			assert.Equal(t, tt.expected+"-headless", HeadlessService(tt.instance))
+		})
+	}
+}
+
+func TestCollectorLongName(t *testing.T) {
+	long := instance{name: "a-very-long-name-for-an-opentelemetry-collector-instance-in-production"}
+	other := instance{name: long.name + "-2"}
+	require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableHashedLongNames.ID(), true))
+	t.Cleanup(func() {
+		_ = colfeaturegate.GlobalRegistry().Set(featuregate.EnableHashedLongNames.ID(), false)
+	})
+
+	assert.LessOrEqual(t, len(Collector(long)), 63)
+	assert.LessOrEqual(t, len(MonitoringService(long)), 63)
+	assert.LessOrEqual(t, len(DashboardsConfigMap(long)), 63)
+	assert.LessOrEqual(t, len(PrometheusExporterService(long)), 63)
+	assert.LessOrEqual(t, len(PodService(long, 12)), 63)
+	assert.NotEqual(t, Collector(long), Collector(other))
+}
+
+func TestTargetAllocatorClusterRole(t *testing.T) {
+	assert.Equal(t, "my-ns.my-collector-targetallocator", TargetAllocatorClusterRole("my-collector", "my-ns"))
+
+	// the instance a-b of the namespace c and the instance a of the namespace b-c don't share their ClusterRole
+	assert.NotEqual(t, TargetAllocatorClusterRole("a-b", "c"), TargetAllocatorClusterRole("a", "b-c"))
+
+	long := "a-very-long-name-for-an-opentelemetry-collector-instance-in-production"
+	assert.LessOrEqual(t, len(TargetAllocatorClusterRoleBinding(long, "my-ns")), 63)
+	assert.NotEqual(t, TargetAllocatorClusterRoleBinding(long, "my-ns"), TargetAllocatorClusterRoleBinding(long+"-2", "my-ns"))
+}
@@ -19,7 +19,10 @@ package naming
 
 import (
 	"fmt"
+	"hash/fnv"
 	"regexp"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 var regexpEndReplace, regexpBeginReplace *regexp.Regexp
@@ -66,6 +69,29 @@ func Truncate(format string, max int, values ...interface{}) string {
 	return trimNonAlphaNumeric(result)
 }
 
+// TruncateWithHash shortens the result like Truncate, but replaces the end of the shortened instance name with a hash
+// of the result before shortening, so that long names sharing a prefix don't end up shortened into the same name.
+// As this renames the existing resources with long names, the names are only hashed when the
+// operator.naming.hashlongnames feature gate is enabled.
+func TruncateWithHash(format string, max int, values ...interface{}) string {
+	if !featuregate.EnableHashedLongNames.IsEnabled() {
+		return Truncate(format, max, values...)
+	}
+	return truncateWithHash(format, max, values...)
+}
+
+// truncateWithHash is TruncateWithHash regardless of the feature gate, for the names which never were cut.
+func truncateWithHash(format string, max int, values ...interface{}) string {
+	result := fmt.Sprintf(format, values...)
+	if len(result) <= max {
+		return Truncate(format, max, values...)
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(result))
+	hash := fmt.Sprintf("%08x", h.Sum32())
+	return fmt.Sprintf("%s-%s", Truncate(format, max-len(hash)-1, values...), hash)
+}
+
 // trimNonAlphaNumeric remove all non-alphanumeric values from start and end of the string
 // source: https://github.com/jaegertracing/jaeger-operator/blob/91e3b69ee5c8761bbda9d3cf431400a73fc1112a/pkg/util/truncate.go#L53
 func trimNonAlphaNumeric(text string) string {
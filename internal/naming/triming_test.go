@@ -21,6 +21,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	colfeaturegate "go.opentelemetry.io/collector/featuregate"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 func TestTruncate(t *testing.T) {
@@ -80,6 +84,29 @@ func TestTruncate(t *testing.T) {
 	}
 }
 
+func TestTruncateWithHash(t *testing.T) {
+	long := "d0c1e62-4d96-11ea-b174-c85b7644b6b5-5d0c1e62-4d96-11ea-b174-c85b7644b6b5"
+
+	// the long names are cut unless the feature gate is enabled, so that the existing resources aren't renamed
+	assert.Equal(t, Truncate("%s-collector", 63, long), TruncateWithHash("%s-collector", 63, long))
+
+	require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableHashedLongNames.ID(), true))
+	t.Cleanup(func() {
+		_ = colfeaturegate.GlobalRegistry().Set(featuregate.EnableHashedLongNames.ID(), false)
+	})
+
+	// a short name is kept as is
+	assert.Equal(t, "simplest-collector", TruncateWithHash("%s-collector", 63, "simplest"))
+
+	// a long name is shortened, with a hash of the whole name
+	truncated := TruncateWithHash("%s-collector", 63, long)
+	assert.Len(t, truncated, 63)
+	assert.Regexp(t, "^d0c1e62-4d96-11ea-b174-c85b7644b6b5-5d0c1e62-collector-[0-9a-f]{8}$", truncated)
+
+	// long names sharing a prefix are shortened into distinct names
+	assert.NotEqual(t, truncated, TruncateWithHash("%s-collector", 63, long+"-other"))
+}
+
 func TestTrimNonAlphaNumeric(t *testing.T) {
 	tests := []struct {
 		input    string
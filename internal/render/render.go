@@ -0,0 +1,168 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package render builds the manifests the operator creates for its custom resources, without a cluster.
+package render
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/yaml"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/controllers"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+)
+
+// DefaultNamespace is used for the custom resources which don't set a namespace.
+const DefaultNamespace = "default"
+
+// Render reads a YAML stream of OpenTelemetryCollector and OpAMPBridge resources from in, and writes the manifests
+// the operator would create for them to out. The resources are defaulted and validated the same way the webhooks
+// do it. Documents of any other kind are skipped, so that whole directories of manifests can be piped in. Every
+// manifest starts with a document separator, so that the outputs of several calls can be concatenated.
+func Render(ctx context.Context, cfg config.Config, scheme *runtime.Scheme, logger logr.Logger, in io.Reader, out io.Writer) error {
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+	reader := k8syaml.NewYAMLReader(bufio.NewReader(in))
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read the custom resources: %w", err)
+		}
+
+		var typeMeta metav1.TypeMeta
+		if err = yaml.Unmarshal(doc, &typeMeta); err != nil {
+			return fmt.Errorf("failed to read the custom resources: %w", err)
+		}
+		if typeMeta.GroupVersionKind().Group != v1alpha1.GroupVersion.Group {
+			continue
+		}
+		obj, _, err := decoder.Decode(doc, nil, nil)
+		if err != nil {
+			return fmt.Errorf("failed to decode the custom resource: %w", err)
+		}
+
+		objects, err := build(ctx, cfg, scheme, logger, obj)
+		if err != nil {
+			return err
+		}
+		for _, o := range objects {
+			if _, err = io.WriteString(out, "---\n"); err != nil {
+				return err
+			}
+			if err = write(scheme, o, out); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// build defaults and validates the custom resource, and returns the objects built for it.
+func build(ctx context.Context, cfg config.Config, scheme *runtime.Scheme, logger logr.Logger, obj runtime.Object) ([]client.Object, error) {
+	params := manifests.Params{
+		Config:   cfg,
+		Scheme:   scheme,
+		Log:      logger,
+		Recorder: record.NewFakeRecorder(0),
+	}
+	switch cr := obj.(type) {
+	case *v1beta1.OpenTelemetryCollector:
+		var hub v1alpha1.OpenTelemetryCollector
+		if err := cr.ConvertTo(&hub); err != nil {
+			return nil, fmt.Errorf("failed to convert the OpenTelemetryCollector %s: %w", cr.Name, err)
+		}
+		return build(ctx, cfg, scheme, logger, &hub)
+	case *v1alpha1.OpenTelemetryCollector:
+		if cr.Namespace == "" {
+			cr.Namespace = DefaultNamespace
+		}
+		webhook := v1alpha1.NewCollectorWebhook(logger, scheme, cfg, nil)
+		if err := webhook.Default(ctx, cr); err != nil {
+			return nil, err
+		}
+		if err := validate(ctx, logger, cr, webhook); err != nil {
+			return nil, err
+		}
+		params.OtelCol = *cr
+		return controllers.BuildCollector(params)
+	case *v1alpha1.OpAMPBridge:
+		if cr.Namespace == "" {
+			cr.Namespace = DefaultNamespace
+		}
+		webhook := v1alpha1.NewOpAMPBridgeWebhook(logger, scheme, cfg, nil)
+		if err := webhook.Default(ctx, cr); err != nil {
+			return nil, err
+		}
+		if err := validate(ctx, logger, cr, webhook); err != nil {
+			return nil, err
+		}
+		params.OpAMPBridge = *cr
+		return controllers.BuildOpAMPBridge(params)
+	default:
+		// other resources of the group, like the Instrumentation, don't result in any manifests
+		return nil, nil
+	}
+}
+
+func validate(ctx context.Context, logger logr.Logger, obj client.Object, validator admission.CustomValidator) error {
+	warnings, err := validator.ValidateCreate(ctx, obj)
+	for _, w := range warnings {
+		logger.Info("validation warning", "name", obj.GetName(), "warning", w)
+	}
+	if err != nil {
+		return fmt.Errorf("the custom resource %s is invalid: %w", obj.GetName(), err)
+	}
+	return nil
+}
+
+// write prints the object as YAML, leaving out the fields which are only ever set by the cluster.
+func write(scheme *runtime.Scheme, obj client.Object, out io.Writer) error {
+	gvk, err := apiutil.GVKForObject(obj, scheme)
+	if err != nil {
+		return err
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return err
+	}
+	u := &unstructured.Unstructured{Object: content}
+	u.SetGroupVersionKind(gvk)
+	unstructured.RemoveNestedField(u.Object, "status")
+	unstructured.RemoveNestedField(u.Object, "metadata", "creationTimestamp")
+	b, err := yaml.Marshal(u.Object)
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(b)
+	return err
+}
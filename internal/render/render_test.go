@@ -0,0 +1,236 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+var update = flag.Bool("update", false, "update the golden files of the rendered manifests")
+
+const collectorConfig = `
+    receivers:
+      otlp:
+        protocols:
+          grpc:
+    exporters:
+      debug:
+    service:
+      pipelines:
+        traces:
+          receivers: [otlp]
+          exporters: [debug]
+`
+
+func testScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+	utilruntime.Must(v1beta1.AddToScheme(scheme))
+	return scheme
+}
+
+func TestRender(t *testing.T) {
+	in := `apiVersion: opentelemetry.io/v1alpha1
+kind: OpenTelemetryCollector
+metadata:
+  name: simplest
+spec:
+  config: |` + collectorConfig + `
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: not-a-custom-resource
+---
+apiVersion: opentelemetry.io/v1alpha1
+kind: OpAMPBridge
+metadata:
+  name: bridge
+  namespace: observability
+spec:
+  endpoint: ws://opamp-server:4320/v1/opamp
+  capabilities:
+    AcceptsRemoteConfig: true
+    ReportsEffectiveConfig: true
+    ReportsHealth: true
+    ReportsRemoteConfig: true
+  componentsAllowed:
+    receivers:
+    - otlp
+`
+	var out bytes.Buffer
+	cfg := config.New(config.WithCollectorImage("collector:test"), config.WithOperatorOpAMPBridgeImage("bridge:test"))
+	err := Render(context.Background(), cfg, testScheme(), logf.Log, strings.NewReader(in), &out)
+	require.NoError(t, err)
+
+	rendered := out.String()
+	assert.Contains(t, rendered, "kind: Deployment\nmetadata:")
+	assert.Contains(t, rendered, "name: simplest-collector\n  namespace: default\n")
+	assert.Contains(t, rendered, "name: bridge-opamp-bridge\n  namespace: observability\n")
+	assert.Contains(t, rendered, "image: collector:test")
+	assert.Contains(t, rendered, "image: bridge:test")
+	assert.NotContains(t, rendered, "not-a-custom-resource")
+	assert.NotContains(t, rendered, "status:")
+	assert.NotContains(t, rendered, "\n  creationTimestamp:")
+}
+
+func TestRenderV1beta1(t *testing.T) {
+	in := `apiVersion: opentelemetry.io/v1beta1
+kind: OpenTelemetryCollector
+metadata:
+  name: simplest
+  namespace: observability
+spec:
+  config:
+    receivers:
+      otlp:
+        protocols:
+          grpc: {}
+    exporters:
+      debug: {}
+    service:
+      pipelines:
+        traces:
+          receivers: [otlp]
+          exporters: [debug]
+`
+	var out bytes.Buffer
+	err := Render(context.Background(), config.New(), testScheme(), logf.Log, strings.NewReader(in), &out)
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "name: simplest-collector\n  namespace: observability\n")
+}
+
+func TestRenderSeveralInputs(t *testing.T) {
+	inputs := []string{`apiVersion: opentelemetry.io/v1alpha1
+kind: OpenTelemetryCollector
+metadata:
+  name: first
+spec:
+  config: |` + collectorConfig, `apiVersion: opentelemetry.io/v1alpha1
+kind: OpenTelemetryCollector
+metadata:
+  name: second
+spec:
+  config: |` + collectorConfig}
+
+	var out bytes.Buffer
+	for _, in := range inputs {
+		err := Render(context.Background(), config.New(), testScheme(), logf.Log, strings.NewReader(in), &out)
+		require.NoError(t, err)
+	}
+
+	// the last manifest of the first input and the first manifest of the second one are separate documents
+	var names []string
+	reader := k8syaml.NewYAMLReader(bufio.NewReader(&out))
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		require.Equal(t, 1, strings.Count("\n"+string(doc), "\nkind: "), "a document holds a single manifest:\n%s", doc)
+		var obj metav1.PartialObjectMetadata
+		require.NoError(t, yaml.Unmarshal(doc, &obj))
+		names = append(names, obj.Kind+"/"+obj.Name)
+	}
+	assert.Contains(t, names, "Deployment/first-collector")
+	assert.Contains(t, names, "Deployment/second-collector")
+}
+
+func TestRenderInvalid(t *testing.T) {
+	in := `apiVersion: opentelemetry.io/v1alpha1
+kind: OpenTelemetryCollector
+metadata:
+  name: invalid
+spec:
+  mode: sidecar
+  priorityClassName: high
+  config: |` + collectorConfig
+
+	var out bytes.Buffer
+	err := Render(context.Background(), config.New(), testScheme(), logf.Log, strings.NewReader(in), &out)
+	assert.ErrorContains(t, err, "the custom resource invalid is invalid")
+	assert.Empty(t, out.String())
+}
+
+// TestRenderGolden renders the custom resources of the testdata directory, and compares the manifests with the golden
+// files next to them. The manifests must be rendered byte for byte the same every time, otherwise GitOps tools
+// report drift where there is none. Run the test with -update to regenerate the golden files.
+func TestRenderGolden(t *testing.T) {
+	inputs, err := filepath.Glob(filepath.Join("testdata", "*.yaml"))
+	require.NoError(t, err)
+	require.NotEmpty(t, inputs)
+
+	cfg := config.New(
+		config.WithCollectorImage("collector:test"),
+		config.WithTargetAllocatorImage("target-allocator:test"),
+		config.WithOperatorOpAMPBridgeImage("bridge:test"),
+	)
+	for _, input := range inputs {
+		t.Run(filepath.Base(input), func(t *testing.T) {
+			// prepare
+			in, err := os.ReadFile(input)
+			require.NoError(t, err)
+
+			// test
+			var rendered []byte
+			for i := 0; i < 10; i++ {
+				var out bytes.Buffer
+				err = Render(context.Background(), cfg, testScheme(), logf.Log, bytes.NewReader(in), &out)
+				require.NoError(t, err)
+				if rendered != nil {
+					require.Equal(t, string(rendered), out.String(), "the manifests must be rendered the same every time")
+				}
+				rendered = out.Bytes()
+			}
+
+			// verify
+			golden := strings.TrimSuffix(input, ".yaml") + ".golden"
+			if *update {
+				require.NoError(t, os.WriteFile(golden, rendered, 0600))
+			}
+			expected, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), string(rendered))
+		})
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+)
+
+const (
+	defaultAnalysisWindow = 5 * time.Minute
+
+	// canaryAnalysisInterval is how often the metrics of the canary are scraped during its analysis.
+	canaryAnalysisInterval = 30 * time.Second
+)
+
+var (
+	// refusedMetrics count the data points refused by the receivers of the collector.
+	refusedMetrics = []string{
+		"otelcol_receiver_refused_spans",
+		"otelcol_receiver_refused_metric_points",
+		"otelcol_receiver_refused_log_records",
+	}
+	// droppedMetrics count the data points dropped by the processors of the collector, or that its exporters failed
+	// to send.
+	droppedMetrics = []string{
+		"otelcol_processor_dropped_spans",
+		"otelcol_processor_dropped_metric_points",
+		"otelcol_processor_dropped_log_records",
+		"otelcol_exporter_send_failed_spans",
+		"otelcol_exporter_send_failed_metric_points",
+		"otelcol_exporter_send_failed_log_records",
+	}
+
+	metricsClient = &http.Client{Timeout: 5 * time.Second}
+
+	// scrapeMetrics returns the metric families exposed by the collector of the pod on the port, it's replaced by the
+	// tests.
+	scrapeMetrics = scrapePodMetrics
+)
+
+func analysisWindow(analysis *v1alpha1.RolloutAnalysis) time.Duration {
+	if analysis.Window == nil {
+		return defaultAnalysisWindow
+	}
+	return analysis.Window.Duration
+}
+
+// analyzeCanary sums the data points refused and dropped by the running pods of the canary, and rolls it back when
+// they exceed the thresholds of the analysis. The counters of the collector start with its pods, so they're only
+// those of the canary.
+func analyzeCanary(ctx context.Context, cli client.Client, changed *v1alpha1.OpenTelemetryCollector, canary *appsv1.Deployment, rollout *v1alpha1.RolloutStatus) error {
+	pods := &corev1.PodList{}
+	if err := cli.List(ctx, pods, client.InNamespace(canary.Namespace), client.MatchingLabels(canary.Spec.Selector.MatchLabels)); err != nil {
+		return fmt.Errorf("failed to list the pods of the canary: %w", err)
+	}
+	port := collector.MetricsPort(*changed)
+	var refused, dropped float64
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" {
+			continue
+		}
+		families, err := scrapeMetrics(ctx, pod, port)
+		if err != nil {
+			// the analysis carries on with the next scrape, the canary isn't promoted automatically before it ends
+			rollout.Message = fmt.Sprintf("failed to scrape the metrics of the canary pod %s: %s", pod.Name, err)
+			return nil
+		}
+		refused += sumCounters(families, refusedMetrics)
+		dropped += sumCounters(families, droppedMetrics)
+	}
+	rollout.CanaryRefused, rollout.CanaryDropped = int64(refused), int64(dropped)
+	rollout.Message = ""
+
+	analysis := changed.Spec.Rollout.Analysis
+	switch {
+	case analysis.MaxRefused != nil && rollout.CanaryRefused > *analysis.MaxRefused:
+		rollout.Message = fmt.Sprintf("the canary was rolled back, its receivers refused %d data points, more than the %d allowed", rollout.CanaryRefused, *analysis.MaxRefused)
+	case analysis.MaxDropped != nil && rollout.CanaryDropped > *analysis.MaxDropped:
+		rollout.Message = fmt.Sprintf("the canary was rolled back, it dropped %d data points, more than the %d allowed", rollout.CanaryDropped, *analysis.MaxDropped)
+	default:
+		return nil
+	}
+	rollout.Phase = v1alpha1.RolloutPhaseRolledBack
+	rollout.CanaryReadySince = nil
+	return nil
+}
+
+// sumCounters sums the samples of the families of the names, which may have the _total suffix of the newer versions
+// of the collector.
+func sumCounters(families map[string]*dto.MetricFamily, names []string) float64 {
+	var sum float64
+	for _, name := range names {
+		for _, familyName := range []string{name, name + "_total"} {
+			family, ok := families[familyName]
+			if !ok {
+				continue
+			}
+			for _, metric := range family.GetMetric() {
+				switch {
+				case metric.GetCounter() != nil:
+					sum += metric.GetCounter().GetValue()
+				case metric.GetUntyped() != nil:
+					sum += metric.GetUntyped().GetValue()
+				}
+			}
+		}
+	}
+	return sum
+}
+
+func scrapePodMetrics(ctx context.Context, pod corev1.Pod, port int32) (map[string]*dto.MetricFamily, error) {
+	url := fmt.Sprintf("http://%s/metrics", net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(int(port))))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := metricsClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	var parser expfmt.TextParser
+	return parser.TextToMetricFamilies(resp.Body)
+}
@@ -0,0 +1,198 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+const canaryMetrics = `# TYPE otelcol_receiver_refused_spans counter
+otelcol_receiver_refused_spans{receiver="otlp",transport="grpc"} 3
+otelcol_receiver_refused_spans{receiver="otlp",transport="http"} 2
+# TYPE otelcol_exporter_send_failed_metric_points_total counter
+otelcol_exporter_send_failed_metric_points_total{exporter="otlp"} 7
+# TYPE otelcol_exporter_sent_spans counter
+otelcol_exporter_sent_spans{exporter="otlp"} 100
+`
+
+func TestScrapePodMetrics(t *testing.T) {
+	// prepare
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/metrics", r.URL.Path)
+		_, _ = w.Write([]byte(canaryMetrics))
+	}))
+	defer server.Close()
+	host, portStr, err := net.SplitHostPort(strings.TrimPrefix(server.URL, "http://"))
+	require.NoError(t, err)
+	port, err := strconv.Atoi(portStr)
+	require.NoError(t, err)
+	pod := corev1.Pod{Status: corev1.PodStatus{PodIP: host}}
+
+	// test
+	families, err := scrapePodMetrics(context.Background(), pod, int32(port))
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, float64(5), sumCounters(families, refusedMetrics))
+	assert.Equal(t, float64(7), sumCounters(families, droppedMetrics))
+}
+
+func TestAnalyzeCanary(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	now := time.Now()
+	stableConfig, canaryConfig := "receivers: {}\n", "receivers: {otlp: {}}\n"
+	one := int32(1)
+	selector := map[string]string{"operator.opentelemetry.io/canary": "true"}
+	newCollector := func(maxRefused, maxDropped int64) *v1alpha1.OpenTelemetryCollector {
+		return &v1alpha1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+			Spec: v1alpha1.OpenTelemetryCollectorSpec{
+				Mode:   v1alpha1.ModeDeployment,
+				Config: canaryConfig,
+				Rollout: &v1alpha1.Rollout{
+					Strategy: v1alpha1.RolloutStrategyCanary,
+					Analysis: &v1alpha1.RolloutAnalysis{MaxRefused: &maxRefused, MaxDropped: &maxDropped},
+				},
+			},
+			Status: v1alpha1.OpenTelemetryCollectorStatus{Rollout: &v1alpha1.RolloutStatus{
+				Phase:            v1alpha1.RolloutPhaseCanaryReady,
+				StableConfig:     stableConfig,
+				CanaryConfigHash: collector.ConfigHash(canaryConfig),
+				CanaryReadySince: &metav1.Time{Time: now.Add(-time.Minute)},
+			}},
+		}
+	}
+	canary := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: naming.CollectorCanary(newCollector(0, 0)), Namespace: "test"},
+		Spec:       appsv1.DeploymentSpec{Replicas: &one, Selector: &metav1.LabelSelector{MatchLabels: selector}},
+		Status:     appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, ReadyReplicas: 1, AvailableReplicas: 1},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-collector-canary-abc", Namespace: "test", Labels: selector},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning, PodIP: "10.0.0.1"},
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(canary, pod).Build()
+	scraped := func(_ context.Context, pod corev1.Pod, port int32) (map[string]*dto.MetricFamily, error) {
+		assert.Equal(t, "10.0.0.1", pod.Status.PodIP)
+		assert.Equal(t, int32(8888), port)
+		var parser expfmt.TextParser
+		return parser.TextToMetricFamilies(strings.NewReader(canaryMetrics))
+	}
+
+	for _, tt := range []struct {
+		name    string
+		otelcol *v1alpha1.OpenTelemetryCollector
+		scrape  func(context.Context, corev1.Pod, int32) (map[string]*dto.MetricFamily, error)
+		phase   v1alpha1.RolloutPhase
+		message string
+	}{
+		{
+			name:    "under the thresholds",
+			otelcol: newCollector(5, 7),
+			scrape:  scraped,
+			phase:   v1alpha1.RolloutPhaseCanaryReady,
+		},
+		{
+			name:    "too many refused data points",
+			otelcol: newCollector(4, 7),
+			scrape:  scraped,
+			phase:   v1alpha1.RolloutPhaseRolledBack,
+			message: "the canary was rolled back, its receivers refused 5 data points, more than the 4 allowed",
+		},
+		{
+			name:    "too many dropped data points",
+			otelcol: newCollector(5, 6),
+			scrape:  scraped,
+			phase:   v1alpha1.RolloutPhaseRolledBack,
+			message: "the canary was rolled back, it dropped 7 data points, more than the 6 allowed",
+		},
+		{
+			name:    "failed scrape",
+			otelcol: newCollector(0, 0),
+			scrape: func(context.Context, corev1.Pod, int32) (map[string]*dto.MetricFamily, error) {
+				return nil, fmt.Errorf("connection refused")
+			},
+			phase:   v1alpha1.RolloutPhaseCanaryReady,
+			message: "failed to scrape the metrics of the canary pod test-collector-canary-abc: connection refused",
+		},
+		{
+			name: "after the window",
+			otelcol: func() *v1alpha1.OpenTelemetryCollector {
+				otelcol := newCollector(0, 0)
+				otelcol.Spec.Rollout.Analysis.Window = &metav1.Duration{Duration: 30 * time.Second}
+				return otelcol
+			}(),
+			scrape: func(context.Context, corev1.Pod, int32) (map[string]*dto.MetricFamily, error) {
+				t.Error("the canary mustn't be scraped after its analysis")
+				return nil, nil
+			},
+			phase: v1alpha1.RolloutPhaseCanaryReady,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// prepare
+			scrapeMetrics = tt.scrape
+			defer func() { scrapeMetrics = scrapePodMetrics }()
+
+			// test
+			err := updateRolloutStatus(context.Background(), cli, tt.otelcol, now)
+
+			// verify
+			require.NoError(t, err)
+			assert.Equal(t, tt.phase, tt.otelcol.Status.Rollout.Phase)
+			assert.Equal(t, tt.message, tt.otelcol.Status.Rollout.Message)
+			assert.Equal(t, stableConfig, tt.otelcol.Status.Rollout.StableConfig)
+		})
+	}
+
+	t.Run("rolled back canary", func(t *testing.T) {
+		// prepare
+		otelcol := newCollector(0, 0)
+		otelcol.Status.Rollout.Phase = v1alpha1.RolloutPhaseRolledBack
+		expected := otelcol.Status.Rollout.DeepCopy()
+
+		// test
+		err := updateRolloutStatus(context.Background(), cli, otelcol, now)
+
+		// verify
+		require.NoError(t, err)
+		assert.Equal(t, expected, otelcol.Status.Rollout)
+	})
+}
@@ -18,15 +18,21 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/adapters"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/internal/status/conditions"
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
 )
 
@@ -35,17 +41,21 @@ func UpdateCollectorStatus(ctx context.Context, cli client.Client, changed *v1al
 		// a version is not set, otherwise let the upgrade mechanism take care of it!
 		changed.Status.Version = version.OpenTelemetryCollector()
 	}
+	changed.Status.Endpoint = endpoint(changed)
+	if err := updateRolloutStatus(ctx, cli, changed, time.Now()); err != nil {
+		return err
+	}
 	mode := changed.Spec.Mode
 	if mode != v1alpha1.ModeDeployment && mode != v1alpha1.ModeStatefulSet {
 		changed.Status.Scale.Replicas = 0
 		changed.Status.Scale.Selector = ""
-		return nil
+		return updateUnscaledConditions(ctx, cli, changed)
 	}
 
-	name := naming.Collector(changed.Name)
+	name := naming.Collector(changed)
 
 	// Set the scale selector
-	labels := manifestutils.Labels(changed.ObjectMeta, name, changed.Spec.Image, collector.ComponentOpenTelemetryCollector, []string{})
+	labels := manifestutils.Labels(changed.ObjectMeta, name, changed.Spec.Image, collector.ComponentOpenTelemetryCollector, []string{}, changed.Spec.StandardLabels)
 	selector, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{MatchLabels: labels})
 	if err != nil {
 		return fmt.Errorf("failed to get selector for labelSelector: %w", err)
@@ -55,13 +65,16 @@ func UpdateCollectorStatus(ctx context.Context, cli client.Client, changed *v1al
 	// Set the scale replicas
 	objKey := client.ObjectKey{
 		Namespace: changed.GetNamespace(),
-		Name:      naming.Collector(changed.Name),
+		Name:      naming.Collector(changed),
 	}
 
 	var replicas int32
 	var readyReplicas int32
 	var statusReplicas string
 	var statusImage string
+	var ready bool
+	var message string
+	var podSelector *metav1.LabelSelector
 
 	switch mode { // nolint:exhaustive
 	case v1alpha1.ModeDeployment:
@@ -73,6 +86,8 @@ func UpdateCollectorStatus(ctx context.Context, cli client.Client, changed *v1al
 		readyReplicas = obj.Status.ReadyReplicas
 		statusReplicas = strconv.Itoa(int(readyReplicas)) + "/" + strconv.Itoa(int(replicas))
 		statusImage = obj.Spec.Template.Spec.Containers[0].Image
+		ready, message = conditions.DeploymentReadiness(obj)
+		podSelector = obj.Spec.Selector
 
 	case v1alpha1.ModeStatefulSet:
 		obj := &appsv1.StatefulSet{}
@@ -83,6 +98,8 @@ func UpdateCollectorStatus(ctx context.Context, cli client.Client, changed *v1al
 		readyReplicas = obj.Status.ReadyReplicas
 		statusReplicas = strconv.Itoa(int(readyReplicas)) + "/" + strconv.Itoa(int(replicas))
 		statusImage = obj.Spec.Template.Spec.Containers[0].Image
+		ready, message = conditions.StatefulSetReadiness(obj)
+		podSelector = obj.Spec.Selector
 
 	case v1alpha1.ModeDaemonSet:
 		obj := &appsv1.DaemonSet{}
@@ -94,6 +111,70 @@ func UpdateCollectorStatus(ctx context.Context, cli client.Client, changed *v1al
 	changed.Status.Scale.Replicas = replicas
 	changed.Status.Image = statusImage
 	changed.Status.Scale.StatusReplicas = statusReplicas
+	return setReadinessConditions(ctx, cli, changed, podSelector, ready, message)
+}
+
+// updateUnscaledConditions sets the conditions of the collectors which aren't exposed through the scale subresource.
+func updateUnscaledConditions(ctx context.Context, cli client.Client, changed *v1alpha1.OpenTelemetryCollector) error {
+	if changed.Spec.Mode != v1alpha1.ModeDaemonSet {
+		conditions.SetReady(&changed.Status.Conditions, changed.Generation, conditions.ReasonReconciled, "the sidecar configuration is available for injection")
+		return nil
+	}
+
+	obj := &appsv1.DaemonSet{}
+	objKey := client.ObjectKey{
+		Namespace: changed.GetNamespace(),
+		Name:      naming.Collector(changed),
+	}
+	if err := cli.Get(ctx, objKey, obj); err != nil {
+		return fmt.Errorf("failed to get daemonSet status: %w", err)
+	}
+	changed.Status.Image = obj.Spec.Template.Spec.Containers[0].Image
+	changed.Status.Scale.StatusReplicas = strconv.Itoa(int(obj.Status.NumberReady)) + "/" + strconv.Itoa(int(obj.Status.DesiredNumberScheduled))
+	ready, message := conditions.DaemonSetReadiness(obj)
+	return setReadinessConditions(ctx, cli, changed, obj.Spec.Selector, ready, message)
+}
 
+// setReadinessConditions sets the conditions from the readiness of the workload. When the workload isn't ready because
+// the collector keeps crashing, e.g. on an invalid configuration, the collector is marked as degraded with the error
+// it exited with, so that it doesn't have to be looked for in the logs of the pods.
+func setReadinessConditions(ctx context.Context, cli client.Client, changed *v1alpha1.OpenTelemetryCollector, selector *metav1.LabelSelector, ready bool, message string) error {
+	if !ready && selector != nil {
+		pods := &corev1.PodList{}
+		if err := cli.List(ctx, pods, client.InNamespace(changed.Namespace), client.MatchingLabels(selector.MatchLabels)); err != nil {
+			return fmt.Errorf("failed to list the pods of the collector: %w", err)
+		}
+		if crashMessage, crashing := conditions.CrashingContainer(pods.Items, naming.Container()); crashing {
+			conditions.SetDegraded(&changed.Status.Conditions, changed.Generation, conditions.ReasonContainerCrashing, crashMessage)
+			return nil
+		}
+	}
+	conditions.SetFromReadiness(&changed.Status.Conditions, changed.Generation, ready, message)
 	return nil
 }
+
+// endpoint returns the address of the OTLP receiver of the collector behind its Service, e.g.
+// otel-collector.observability.svc:4317, gRPC being preferred over HTTP. The sidecars and the collectors without OTLP
+// receiver have no endpoint.
+func endpoint(otelcol *v1alpha1.OpenTelemetryCollector) string {
+	if otelcol.Spec.Mode == v1alpha1.ModeSidecar {
+		return ""
+	}
+	cfg, err := adapters.ConfigFromString(otelcol.Spec.Config)
+	if err != nil {
+		return ""
+	}
+	ports, err := adapters.ConfigToReceiverPorts(logr.Discard(), cfg)
+	if err != nil {
+		return ""
+	}
+	for _, protocol := range []string{"grpc", "http"} {
+		for _, p := range ports {
+			// the ports of the OTLP receivers are named after the receiver and the protocol, e.g. otlp-2-http
+			if strings.HasPrefix(p.Name, "otlp") && strings.HasSuffix(p.Name, "-"+protocol) {
+				return fmt.Sprintf("%s.%s.svc:%d", naming.Service(otelcol), otelcol.Namespace, p.Port)
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestEndpoint(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		mode     v1alpha1.Mode
+		config   string
+		expected string
+	}{
+		{
+			desc: "gRPC preferred",
+			mode: v1alpha1.ModeDeployment,
+			config: `receivers:
+  otlp:
+    protocols:
+      http:
+      grpc:
+        endpoint: 0.0.0.0:14317
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+`,
+			expected: "test-collector.observability.svc:14317",
+		},
+		{
+			desc: "HTTP only",
+			mode: v1alpha1.ModeDaemonSet,
+			config: `receivers:
+  otlp/2:
+    protocols:
+      http:
+        endpoint: 0.0.0.0:4318
+service:
+  pipelines:
+    traces:
+      receivers: [otlp/2]
+`,
+			expected: "test-collector.observability.svc:4318",
+		},
+		{
+			desc: "no OTLP receiver",
+			mode: v1alpha1.ModeDeployment,
+			config: `receivers:
+  zipkin:
+service:
+  pipelines:
+    traces:
+      receivers: [zipkin]
+`,
+		},
+		{
+			desc: "sidecar",
+			mode: v1alpha1.ModeSidecar,
+			config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+`,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			otelcol := &v1alpha1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "observability"},
+				Spec:       v1alpha1.OpenTelemetryCollectorSpec{Mode: tt.mode, Config: tt.config},
+			}
+			assert.Equal(t, tt.expected, endpoint(otelcol))
+		})
+	}
+}
+
+func TestUpdateDaemonSetCollectorStatus(t *testing.T) {
+	// prepare
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	otelcol := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "observability"},
+		Spec:       v1alpha1.OpenTelemetryCollectorSpec{Mode: v1alpha1.ModeDaemonSet},
+	}
+	daemonSet := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-collector", Namespace: "observability"},
+		Spec: appsv1.DaemonSetSpec{
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "otc-container", Image: "otelcol:0.89.0"}},
+			}},
+		},
+		Status: appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, NumberReady: 2},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(daemonSet).Build()
+
+	// test
+	err := UpdateCollectorStatus(context.Background(), cl, otelcol)
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, "otelcol:0.89.0", otelcol.Status.Image)
+	assert.Equal(t, "2/3", otelcol.Status.Scale.StatusReplicas)
+	assert.Zero(t, otelcol.Status.Scale.Replicas)
+}
@@ -19,10 +19,13 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/status/conditions"
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
 	collectorupgrade "github.com/open-telemetry/opentelemetry-operator/pkg/collector/upgrade"
 )
@@ -31,20 +34,31 @@ const (
 	eventTypeNormal  = "Normal"
 	eventTypeWarning = "Warning"
 
-	reasonError         = "Error"
-	reasonStatusFailure = "StatusFailure"
-	reasonInfo          = "Info"
+	reasonStatusFailure    = "StatusFailure"
+	reasonInfo             = "Info"
+	reasonCanaryRolledBack = "CanaryRolledBack"
 )
 
-// HandleReconcileStatus handles updating the status of the CRDs managed by the operator.
+// HandleReconcileStatus handles updating the status of the CRDs managed by the operator. The components are the objects
+// generated for the instance, they are only recorded when the reconciliation succeeded.
 // TODO: make the status more useful https://github.com/open-telemetry/opentelemetry-operator/issues/1972
-func HandleReconcileStatus(ctx context.Context, log logr.Logger, params manifests.Params, err error) (ctrl.Result, error) {
+func HandleReconcileStatus(ctx context.Context, log logr.Logger, params manifests.Params, components []v1alpha1.ComponentStatus, err error) (ctrl.Result, error) {
 	log.V(2).Info("updating collector status")
 	if err != nil {
-		params.Recorder.Event(&params.OtelCol, eventTypeWarning, reasonError, err.Error())
+		reason := conditions.ReasonFor(err)
+		params.Recorder.Event(&params.OtelCol, eventTypeWarning, reason, err.Error())
+		degraded := params.OtelCol.DeepCopy()
+		conditions.SetDegraded(&degraded.Status.Conditions, degraded.Generation, reason, err.Error())
+		if apiequality.Semantic.DeepEqual(degraded.Status, params.OtelCol.Status) {
+			return ctrl.Result{}, err
+		}
+		if statusErr := params.Client.Status().Patch(ctx, degraded, client.MergeFrom(&params.OtelCol)); statusErr != nil {
+			log.Error(statusErr, "failed to apply the degraded condition to the OpenTelemetry CR")
+		}
 		return ctrl.Result{}, err
 	}
 	changed := params.OtelCol.DeepCopy()
+	changed.Status.Components = components
 
 	up := &collectorupgrade.VersionUpgrade{
 		Log:      params.Log,
@@ -63,6 +77,14 @@ func HandleReconcileStatus(ctx context.Context, log logr.Logger, params manifest
 		params.Recorder.Event(changed, eventTypeWarning, reasonStatusFailure, statusErr.Error())
 		return ctrl.Result{}, statusErr
 	}
+	if rolledBack(changed.Status.Rollout) && !rolledBack(params.OtelCol.Status.Rollout) {
+		params.Recorder.Event(changed, eventTypeWarning, reasonCanaryRolledBack, changed.Status.Rollout.Message)
+	}
+	// the status is only written when it changed, as a merge patch without optimistic locking, so that steady-state
+	// reconciles don't write and the concurrent edits of the spec don't cause conflicts
+	if apiequality.Semantic.DeepEqual(changed.Status, params.OtelCol.Status) {
+		return ctrl.Result{}, nil
+	}
 	statusPatch := client.MergeFrom(&params.OtelCol)
 	if err := params.Client.Status().Patch(ctx, changed, statusPatch); err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to apply status changes to the OpenTelemetry CR: %w", err)
@@ -70,3 +92,7 @@ func HandleReconcileStatus(ctx context.Context, log logr.Logger, params manifest
 	params.Recorder.Event(changed, eventTypeNormal, reasonInfo, "applied status changes")
 	return ctrl.Result{}, nil
 }
+
+func rolledBack(rollout *v1alpha1.RolloutStatus) bool {
+	return rollout != nil && rollout.Phase == v1alpha1.RolloutPhaseRolledBack
+}
@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/internal/status/conditions"
+)
+
+func TestHandleReconcileStatusOnlyPatchesChanges(t *testing.T) {
+	// prepare
+	scheme := runtime.NewScheme()
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+	otelcol := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode: v1alpha1.ModeSidecar,
+		},
+	}
+	patches := 0
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(otelcol).WithStatusSubresource(otelcol).WithInterceptorFuncs(interceptor.Funcs{
+		SubResourcePatch: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+			patches++
+			return c.SubResource(subResourceName).Patch(ctx, obj, patch, opts...)
+		},
+	}).Build()
+	ctx := context.Background()
+	components := []v1alpha1.ComponentStatus{{Kind: "ConfigMap", Name: "test-collector", Hash: "abc"}}
+	reconcile := func() {
+		var current v1alpha1.OpenTelemetryCollector
+		require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(otelcol), &current))
+		params := manifests.Params{
+			Client:   cl,
+			Recorder: record.NewFakeRecorder(10),
+			Log:      logr.Discard(),
+			OtelCol:  current,
+		}
+		_, err := HandleReconcileStatus(ctx, logr.Discard(), params, components, nil)
+		require.NoError(t, err)
+	}
+
+	// the first reconciles set the version, then the upgrade condition
+	reconcile()
+	reconcile()
+	require.Equal(t, 2, patches)
+
+	// test
+	reconcile()
+
+	// verify
+	assert.Equal(t, 2, patches)
+	var current v1alpha1.OpenTelemetryCollector
+	require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(otelcol), &current))
+	assert.NotEmpty(t, current.Status.Version)
+	assert.NotEmpty(t, current.Status.Conditions)
+	assert.Equal(t, components, current.Status.Components)
+}
+
+func TestHandleReconcileStatusReportsCrashingCollector(t *testing.T) {
+	// prepare
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+	otelcol := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode: v1alpha1.ModeDeployment,
+		},
+	}
+	selector := map[string]string{"app.kubernetes.io/instance": "test.test"}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: naming.Collector(otelcol), Namespace: "test"},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: selector},
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: naming.Container(), Image: "collector"}}},
+			},
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-collector-abc", Namespace: "test", Labels: selector},
+		Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+			Name:  naming.Container(),
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+			LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				ExitCode: 1,
+				Message:  "invalid configuration",
+			}},
+		}}},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(otelcol, deployment, pod).WithStatusSubresource(otelcol).Build()
+	params := manifests.Params{
+		Client:   cl,
+		Recorder: record.NewFakeRecorder(10),
+		Log:      logr.Discard(),
+		OtelCol:  *otelcol,
+	}
+
+	// test
+	_, err := HandleReconcileStatus(context.Background(), logr.Discard(), params, nil, nil)
+
+	// verify
+	require.NoError(t, err)
+	var current v1alpha1.OpenTelemetryCollector
+	require.NoError(t, cl.Get(context.Background(), client.ObjectKeyFromObject(otelcol), &current))
+	degraded := meta.FindStatusCondition(current.Status.Conditions, v1alpha1.ConditionTypeDegraded)
+	require.NotNil(t, degraded)
+	assert.Equal(t, metav1.ConditionTrue, degraded.Status)
+	assert.Equal(t, conditions.ReasonContainerCrashing, degraded.Reason)
+	assert.Contains(t, degraded.Message, "invalid configuration")
+}
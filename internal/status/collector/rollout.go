@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/internal/status/conditions"
+)
+
+// updateRolloutStatus reports the progress of the canary of the configuration of the spec, and promotes it to the
+// stable configuration once it has been ready for the promotion delay, or once the promotion annotation is set to its
+// hash. The stable configuration starts as the one of the spec, when the canary strategy is enabled.
+func updateRolloutStatus(ctx context.Context, cli client.Client, changed *v1alpha1.OpenTelemetryCollector, now time.Time) error {
+	if !collector.CanaryRolloutEnabled(*changed) {
+		changed.Status.Rollout = nil
+		return nil
+	}
+	rollout := changed.Status.Rollout
+	if rollout == nil || rollout.StableConfig == "" || rollout.StableConfig == changed.Spec.Config || canaryPromoted(*changed, now) {
+		changed.Status.Rollout = &v1alpha1.RolloutStatus{
+			Phase:            v1alpha1.RolloutPhaseStable,
+			StableConfig:     changed.Spec.Config,
+			StableConfigHash: collector.ConfigHash(changed.Spec.Config),
+		}
+		return nil
+	}
+
+	if collector.CanaryRolledBack(*changed) {
+		// the replicas keep the stable configuration until the one of the spec changes
+		return nil
+	}
+	canaryHash := collector.ConfigHash(changed.Spec.Config)
+	if rollout.CanaryConfigHash != canaryHash {
+		// the canary of another configuration has to become ready, and to be analyzed, again
+		rollout.CanaryReadySince = nil
+		rollout.CanaryRefused, rollout.CanaryDropped, rollout.Message = 0, 0, ""
+	}
+	rollout.CanaryConfigHash = canaryHash
+	rollout.Phase = v1alpha1.RolloutPhaseProgressing
+
+	obj := &appsv1.Deployment{}
+	objKey := client.ObjectKey{Namespace: changed.Namespace, Name: naming.CollectorCanary(changed)}
+	if err := cli.Get(ctx, objKey, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			rollout.CanaryReplicas, rollout.CanaryReadyReplicas, rollout.CanaryReadySince = 0, 0, nil
+			return nil
+		}
+		return fmt.Errorf("failed to get the canary deployment: %w", err)
+	}
+	rollout.CanaryReplicas = obj.Status.Replicas
+	rollout.CanaryReadyReplicas = obj.Status.ReadyReplicas
+	if ready, _ := conditions.DeploymentReadiness(obj); !ready {
+		rollout.CanaryReadySince = nil
+		return nil
+	}
+	if rollout.CanaryReadySince == nil {
+		rollout.CanaryReadySince = &metav1.Time{Time: now}
+	}
+	rollout.Phase = v1alpha1.RolloutPhaseCanaryReady
+	if analysis := changed.Spec.Rollout.Analysis; analysis != nil && now.Before(rollout.CanaryReadySince.Add(analysisWindow(analysis))) {
+		return analyzeCanary(ctx, cli, changed, obj, rollout)
+	}
+	return nil
+}
+
+// canaryPromoted returns whether the canary reported in the status is promoted, either manually or by having been
+// ready for the promotion delay.
+func canaryPromoted(otelcol v1alpha1.OpenTelemetryCollector, now time.Time) bool {
+	rollout := otelcol.Status.Rollout
+	if rollout.Phase != v1alpha1.RolloutPhaseCanaryReady || rollout.CanaryConfigHash != collector.ConfigHash(otelcol.Spec.Config) {
+		return false
+	}
+	if otelcol.Annotations[collector.PromoteCanaryAnnotation] == rollout.CanaryConfigHash {
+		return true
+	}
+	promotion := promotionTime(otelcol)
+	return !promotion.IsZero() && !now.Before(promotion)
+}
+
+// promotionTime returns when the ready canary is promoted automatically: once it has been ready for the promotion
+// delay, and its analysis, if any, has ended. It's zero when the canary is only promoted manually.
+func promotionTime(otelcol v1alpha1.OpenTelemetryCollector) time.Time {
+	rollout := otelcol.Status.Rollout
+	delay := otelcol.Spec.Rollout.PromotionDelay
+	if delay == nil || rollout.CanaryReadySince == nil {
+		return time.Time{}
+	}
+	wait := delay.Duration
+	if analysis := otelcol.Spec.Rollout.Analysis; analysis != nil && analysisWindow(analysis) > wait {
+		wait = analysisWindow(analysis)
+	}
+	return rollout.CanaryReadySince.Add(wait)
+}
+
+// RolloutRequeueAfter returns the time after which the ready canary of the instance is to be analyzed again, or is
+// due to be promoted automatically, zero when there's none.
+func RolloutRequeueAfter(otelcol v1alpha1.OpenTelemetryCollector, now time.Time) time.Duration {
+	rollout := otelcol.Status.Rollout
+	if !collector.CanaryInProgress(otelcol) || rollout.Phase != v1alpha1.RolloutPhaseCanaryReady || rollout.CanaryReadySince == nil {
+		return 0
+	}
+	var requeue time.Duration
+	if analysis := otelcol.Spec.Rollout.Analysis; analysis != nil {
+		if remaining := rollout.CanaryReadySince.Add(analysisWindow(analysis)).Sub(now); remaining > 0 {
+			requeue = canaryAnalysisInterval
+			if remaining < requeue {
+				requeue = remaining
+			}
+		}
+	}
+	if promotion := promotionTime(otelcol); !promotion.IsZero() {
+		remaining := promotion.Sub(now)
+		if remaining <= 0 {
+			// the promotion is overdue, e.g. the status couldn't be written
+			remaining = time.Second
+		}
+		if requeue == 0 || remaining < requeue {
+			requeue = remaining
+		}
+	}
+	return requeue
+}
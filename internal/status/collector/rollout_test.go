@@ -0,0 +1,211 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+func TestUpdateRolloutStatus(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	now := time.Now()
+	stableConfig, canaryConfig := "receivers: {}\n", "receivers: {otlp: {}}\n"
+	one := int32(1)
+	newCollector := func(rollout *v1alpha1.RolloutStatus) *v1alpha1.OpenTelemetryCollector {
+		return &v1alpha1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+			Spec: v1alpha1.OpenTelemetryCollectorSpec{
+				Mode:    v1alpha1.ModeDeployment,
+				Config:  canaryConfig,
+				Rollout: &v1alpha1.Rollout{Strategy: v1alpha1.RolloutStrategyCanary, PromotionDelay: &metav1.Duration{Duration: time.Minute}},
+			},
+			Status: v1alpha1.OpenTelemetryCollectorStatus{Rollout: rollout},
+		}
+	}
+	canary := func(ready bool) *appsv1.Deployment {
+		deployment := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: naming.CollectorCanary(newCollector(nil)), Namespace: "test"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &one},
+			Status:     appsv1.DeploymentStatus{Replicas: 1},
+		}
+		if ready {
+			deployment.Status.UpdatedReplicas, deployment.Status.ReadyReplicas, deployment.Status.AvailableReplicas = 1, 1, 1
+		}
+		return deployment
+	}
+	inProgress := func(phase v1alpha1.RolloutPhase, readySince *metav1.Time) *v1alpha1.RolloutStatus {
+		return &v1alpha1.RolloutStatus{
+			Phase:            phase,
+			StableConfig:     stableConfig,
+			StableConfigHash: collector.ConfigHash(stableConfig),
+			CanaryConfigHash: collector.ConfigHash(canaryConfig),
+			CanaryReadySince: readySince,
+		}
+	}
+
+	for _, tt := range []struct {
+		name       string
+		otelcol    *v1alpha1.OpenTelemetryCollector
+		canary     *appsv1.Deployment
+		phase      v1alpha1.RolloutPhase
+		stable     string
+		readySince bool
+	}{
+		{
+			name:    "the configuration of the spec starts as the stable one",
+			otelcol: newCollector(nil),
+			phase:   v1alpha1.RolloutPhaseStable,
+			stable:  canaryConfig,
+		},
+		{
+			name:    "the canary isn't created yet",
+			otelcol: newCollector(inProgress(v1alpha1.RolloutPhaseStable, nil)),
+			phase:   v1alpha1.RolloutPhaseProgressing,
+			stable:  stableConfig,
+		},
+		{
+			name:    "the canary isn't ready",
+			otelcol: newCollector(inProgress(v1alpha1.RolloutPhaseProgressing, nil)),
+			canary:  canary(false),
+			phase:   v1alpha1.RolloutPhaseProgressing,
+			stable:  stableConfig,
+		},
+		{
+			name:       "the canary becomes ready",
+			otelcol:    newCollector(inProgress(v1alpha1.RolloutPhaseProgressing, nil)),
+			canary:     canary(true),
+			phase:      v1alpha1.RolloutPhaseCanaryReady,
+			stable:     stableConfig,
+			readySince: true,
+		},
+		{
+			name:       "the canary waits for the promotion delay",
+			otelcol:    newCollector(inProgress(v1alpha1.RolloutPhaseCanaryReady, &metav1.Time{Time: now.Add(-30 * time.Second)})),
+			canary:     canary(true),
+			phase:      v1alpha1.RolloutPhaseCanaryReady,
+			stable:     stableConfig,
+			readySince: true,
+		},
+		{
+			name:    "the canary is promoted after the promotion delay",
+			otelcol: newCollector(inProgress(v1alpha1.RolloutPhaseCanaryReady, &metav1.Time{Time: now.Add(-time.Minute)})),
+			canary:  canary(true),
+			phase:   v1alpha1.RolloutPhaseStable,
+			stable:  canaryConfig,
+		},
+		{
+			name: "the canary is promoted by the annotation",
+			otelcol: func() *v1alpha1.OpenTelemetryCollector {
+				otelcol := newCollector(inProgress(v1alpha1.RolloutPhaseCanaryReady, &metav1.Time{Time: now}))
+				otelcol.Spec.Rollout.PromotionDelay = nil
+				otelcol.Annotations = map[string]string{collector.PromoteCanaryAnnotation: collector.ConfigHash(canaryConfig)}
+				return otelcol
+			}(),
+			canary: canary(true),
+			phase:  v1alpha1.RolloutPhaseStable,
+			stable: canaryConfig,
+		},
+		{
+			name: "the canary is aborted by reverting the configuration",
+			otelcol: func() *v1alpha1.OpenTelemetryCollector {
+				otelcol := newCollector(inProgress(v1alpha1.RolloutPhaseProgressing, nil))
+				otelcol.Spec.Config = stableConfig
+				return otelcol
+			}(),
+			canary: canary(false),
+			phase:  v1alpha1.RolloutPhaseStable,
+			stable: stableConfig,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// prepare
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			if tt.canary != nil {
+				builder = builder.WithObjects(tt.canary)
+			}
+
+			// test
+			err := updateRolloutStatus(context.Background(), builder.Build(), tt.otelcol, now)
+
+			// verify
+			require.NoError(t, err)
+			rollout := tt.otelcol.Status.Rollout
+			require.NotNil(t, rollout)
+			assert.Equal(t, tt.phase, rollout.Phase)
+			assert.Equal(t, tt.stable, rollout.StableConfig)
+			assert.Equal(t, collector.ConfigHash(tt.stable), rollout.StableConfigHash)
+			assert.Equal(t, tt.readySince, rollout.CanaryReadySince != nil)
+		})
+	}
+}
+
+func TestUpdateRolloutStatusDisabled(t *testing.T) {
+	// prepare
+	otelcol := &v1alpha1.OpenTelemetryCollector{
+		Spec:   v1alpha1.OpenTelemetryCollectorSpec{Mode: v1alpha1.ModeDeployment},
+		Status: v1alpha1.OpenTelemetryCollectorStatus{Rollout: &v1alpha1.RolloutStatus{Phase: v1alpha1.RolloutPhaseStable}},
+	}
+
+	// test
+	err := updateRolloutStatus(context.Background(), fake.NewClientBuilder().Build(), otelcol, time.Now())
+
+	// verify
+	require.NoError(t, err)
+	assert.Nil(t, otelcol.Status.Rollout)
+}
+
+func TestRolloutRequeueAfter(t *testing.T) {
+	// prepare
+	now := time.Now()
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:    v1alpha1.ModeDeployment,
+			Config:  "receivers: {otlp: {}}\n",
+			Rollout: &v1alpha1.Rollout{Strategy: v1alpha1.RolloutStrategyCanary, PromotionDelay: &metav1.Duration{Duration: time.Minute}},
+		},
+		Status: v1alpha1.OpenTelemetryCollectorStatus{Rollout: &v1alpha1.RolloutStatus{
+			Phase:            v1alpha1.RolloutPhaseCanaryReady,
+			StableConfig:     "receivers: {}\n",
+			CanaryReadySince: &metav1.Time{Time: now.Add(-20 * time.Second)},
+		}},
+	}
+
+	// test
+	requeue := RolloutRequeueAfter(otelcol, now)
+
+	// verify
+	assert.Equal(t, 40*time.Second, requeue)
+	otelcol.Spec.Rollout.Analysis = &v1alpha1.RolloutAnalysis{Window: &metav1.Duration{Duration: 5 * time.Minute}}
+	assert.Equal(t, canaryAnalysisInterval, RolloutRequeueAfter(otelcol, now))
+	otelcol.Spec.Rollout.PromotionDelay = nil
+	otelcol.Spec.Rollout.Analysis = nil
+	assert.Zero(t, RolloutRequeueAfter(otelcol, now))
+}
@@ -0,0 +1,207 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package conditions handles the Ready, Progressing and Degraded conditions shared by the operator's custom resources.
+package conditions
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// crashLoopBackOff is the reason the kubelet gives to the containers waiting to be restarted after crashing.
+const crashLoopBackOff = "CrashLoopBackOff"
+
+const (
+	// ReasonReconciled is used when the resource has no workload of its own to wait for.
+	ReasonReconciled = "Reconciled"
+	// ReasonWorkloadReady is used when all the replicas of the managed workload are available.
+	ReasonWorkloadReady = "WorkloadReady"
+	// ReasonWorkloadNotReady is used while the managed workload is being rolled out.
+	ReasonWorkloadNotReady = "WorkloadNotReady"
+	// ReasonContainerCrashing is used when the containers of the workload keep terminating, e.g. because the collector
+	// rejects its configuration.
+	ReasonContainerCrashing = "ContainerCrashing"
+	// ReasonReconcileError is used when the reconciliation of the resource failed.
+	ReasonReconcileError = "ReconcileError"
+	// ReasonInvalidConfig is used when the configuration of the resource can't be parsed.
+	ReasonInvalidConfig = "InvalidConfig"
+	// ReasonManifestBuildFailed is used when the manifests of the child resources can't be built.
+	ReasonManifestBuildFailed = "ManifestBuildFailed"
+	// ReasonResourceRejected is used when the API server rejects one of the child resources.
+	ReasonResourceRejected = "ResourceRejected"
+	// ReasonConfigMapNotOwned is used when a ConfigMap named after the one generated for the resource exists and
+	// belongs to someone else, in which case it's left untouched.
+	ReasonConfigMapNotOwned = "ConfigMapNotOwned"
+	// ReasonUpToDate is used when the resource doesn't need to be migrated, or was migrated successfully.
+	ReasonUpToDate = "UpToDate"
+	// ReasonUpgradeSkipped is used when the migration of the resource isn't allowed by its upgrade strategy or windows.
+	ReasonUpgradeSkipped = "UpgradeSkipped"
+	// ReasonUpgradeFailed is used when one of the upgrade routines failed to migrate the resource.
+	ReasonUpgradeFailed = "UpgradeFailed"
+	// ReasonUnsupportedVersion is used when there's no migration path from the version of the resource.
+	ReasonUnsupportedVersion = "UnsupportedVersion"
+)
+
+// ReasonError annotates a reconciliation error with the reason it's reported under, in events and the Degraded condition.
+type ReasonError struct {
+	Reason string
+	Err    error
+}
+
+func (e *ReasonError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ReasonError) Unwrap() error {
+	return e.Err
+}
+
+// WithReason annotates the given error with a reason, nil errors are returned as is.
+func WithReason(reason string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &ReasonError{Reason: reason, Err: err}
+}
+
+// ReasonFor returns the reason the given error is reported under, ReasonReconcileError when it wasn't annotated.
+func ReasonFor(err error) string {
+	var reasonErr *ReasonError
+	if errors.As(err, &reasonErr) {
+		return reasonErr.Reason
+	}
+	return ReasonReconcileError
+}
+
+// SetReady marks the resource as ready.
+func SetReady(conditions *[]metav1.Condition, generation int64, reason, message string) {
+	set(conditions, generation, metav1.ConditionTrue, metav1.ConditionFalse, metav1.ConditionFalse, reason, message)
+}
+
+// SetProgressing marks the resource as being rolled out.
+func SetProgressing(conditions *[]metav1.Condition, generation int64, reason, message string) {
+	set(conditions, generation, metav1.ConditionFalse, metav1.ConditionTrue, metav1.ConditionFalse, reason, message)
+}
+
+// SetDegraded marks the resource as failing to reconcile.
+func SetDegraded(conditions *[]metav1.Condition, generation int64, reason, message string) {
+	set(conditions, generation, metav1.ConditionFalse, metav1.ConditionFalse, metav1.ConditionTrue, reason, message)
+}
+
+// SetUpgraded marks the resource as migrated, or not, to the version managed by the operator.
+func SetUpgraded(conditions *[]metav1.Condition, generation int64, upgraded bool, reason, message string) {
+	status := metav1.ConditionFalse
+	if upgraded {
+		status = metav1.ConditionTrue
+	}
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               v1alpha1.ConditionTypeUpgraded,
+		Status:             status,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// SetFromReadiness marks the resource as ready or progressing, depending on the readiness of its workload.
+func SetFromReadiness(conditions *[]metav1.Condition, generation int64, ready bool, message string) {
+	if ready {
+		SetReady(conditions, generation, ReasonWorkloadReady, message)
+		return
+	}
+	SetProgressing(conditions, generation, ReasonWorkloadNotReady, message)
+}
+
+func set(conditions *[]metav1.Condition, generation int64, ready, progressing, degraded metav1.ConditionStatus, reason, message string) {
+	for _, c := range []struct {
+		conditionType string
+		status        metav1.ConditionStatus
+	}{
+		{v1alpha1.ConditionTypeReady, ready},
+		{v1alpha1.ConditionTypeProgressing, progressing},
+		{v1alpha1.ConditionTypeDegraded, degraded},
+	} {
+		meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:               c.conditionType,
+			Status:             c.status,
+			ObservedGeneration: generation,
+			Reason:             reason,
+			Message:            message,
+		})
+	}
+}
+
+// DeploymentReadiness returns whether all the desired replicas of the deployment are updated and available.
+func DeploymentReadiness(obj *appsv1.Deployment) (bool, string) {
+	desired := desiredReplicas(obj.Spec.Replicas)
+	ready := obj.Status.ObservedGeneration >= obj.Generation &&
+		obj.Status.UpdatedReplicas == desired &&
+		obj.Status.AvailableReplicas == desired
+	return ready, fmt.Sprintf("%d/%d replicas are available", obj.Status.AvailableReplicas, desired)
+}
+
+// StatefulSetReadiness returns whether all the desired replicas of the statefulset are updated and ready.
+func StatefulSetReadiness(obj *appsv1.StatefulSet) (bool, string) {
+	desired := desiredReplicas(obj.Spec.Replicas)
+	ready := obj.Status.ObservedGeneration >= obj.Generation &&
+		obj.Status.UpdatedReplicas == desired &&
+		obj.Status.ReadyReplicas == desired
+	return ready, fmt.Sprintf("%d/%d replicas are ready", obj.Status.ReadyReplicas, desired)
+}
+
+// DaemonSetReadiness returns whether the daemonset pods are updated and available on all the scheduled nodes.
+func DaemonSetReadiness(obj *appsv1.DaemonSet) (bool, string) {
+	desired := obj.Status.DesiredNumberScheduled
+	ready := obj.Status.ObservedGeneration >= obj.Generation &&
+		obj.Status.UpdatedNumberScheduled == desired &&
+		obj.Status.NumberAvailable == desired
+	return ready, fmt.Sprintf("%d/%d pods are available", obj.Status.NumberAvailable, desired)
+}
+
+// CrashingContainer looks for a container with the given name crash-looping in the pods, and returns the reason of
+// its last termination along with its termination message, the last lines of its logs when it doesn't write one.
+func CrashingContainer(pods []corev1.Pod, containerName string) (string, bool) {
+	for _, pod := range pods {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name != containerName || status.State.Waiting == nil || status.State.Waiting.Reason != crashLoopBackOff {
+				continue
+			}
+			message := fmt.Sprintf("the container %s of the pod %s is crash-looping", containerName, pod.Name)
+			if terminated := status.LastTerminationState.Terminated; terminated != nil {
+				message = fmt.Sprintf("%s, it exited with code %d", message, terminated.ExitCode)
+				if terminationMessage := strings.TrimSpace(terminated.Message); terminationMessage != "" {
+					message = fmt.Sprintf("%s: %s", message, terminationMessage)
+				}
+			}
+			return message, true
+		}
+	}
+	return "", false
+}
+
+func desiredReplicas(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
@@ -0,0 +1,184 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conditions
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestSetConditions(t *testing.T) {
+	var conditions []metav1.Condition
+
+	SetProgressing(&conditions, 1, ReasonWorkloadNotReady, "0/1 replicas are available")
+	assert.Len(t, conditions, 3)
+	assert.True(t, meta.IsStatusConditionFalse(conditions, v1alpha1.ConditionTypeReady))
+	assert.True(t, meta.IsStatusConditionTrue(conditions, v1alpha1.ConditionTypeProgressing))
+	assert.True(t, meta.IsStatusConditionFalse(conditions, v1alpha1.ConditionTypeDegraded))
+
+	SetDegraded(&conditions, 2, ReasonReconcileError, "boom")
+	assert.Len(t, conditions, 3)
+	degraded := meta.FindStatusCondition(conditions, v1alpha1.ConditionTypeDegraded)
+	assert.Equal(t, metav1.ConditionTrue, degraded.Status)
+	assert.Equal(t, ReasonReconcileError, degraded.Reason)
+	assert.Equal(t, "boom", degraded.Message)
+	assert.Equal(t, int64(2), degraded.ObservedGeneration)
+
+	SetFromReadiness(&conditions, 2, true, "1/1 replicas are available")
+	assert.True(t, meta.IsStatusConditionTrue(conditions, v1alpha1.ConditionTypeReady))
+	assert.True(t, meta.IsStatusConditionFalse(conditions, v1alpha1.ConditionTypeProgressing))
+	assert.True(t, meta.IsStatusConditionFalse(conditions, v1alpha1.ConditionTypeDegraded))
+	assert.Equal(t, ReasonWorkloadReady, meta.FindStatusCondition(conditions, v1alpha1.ConditionTypeReady).Reason)
+}
+
+func TestSetUpgraded(t *testing.T) {
+	var conditions []metav1.Condition
+
+	SetReady(&conditions, 1, ReasonWorkloadReady, "1/1 replicas are available")
+	SetUpgraded(&conditions, 1, false, ReasonUpgradeSkipped, "deferred")
+	assert.Len(t, conditions, 4)
+	assert.True(t, meta.IsStatusConditionFalse(conditions, v1alpha1.ConditionTypeUpgraded))
+	assert.True(t, meta.IsStatusConditionTrue(conditions, v1alpha1.ConditionTypeReady))
+
+	SetUpgraded(&conditions, 1, true, ReasonUpToDate, "upgraded")
+	assert.Len(t, conditions, 4)
+	upgraded := meta.FindStatusCondition(conditions, v1alpha1.ConditionTypeUpgraded)
+	assert.Equal(t, metav1.ConditionTrue, upgraded.Status)
+	assert.Equal(t, ReasonUpToDate, upgraded.Reason)
+}
+
+func TestWorkloadReadiness(t *testing.T) {
+	three := int32(3)
+
+	for _, tt := range []struct {
+		name    string
+		ready   bool
+		message string
+		check   func() (bool, string)
+	}{
+		{
+			name:    "deployment available",
+			ready:   true,
+			message: "3/3 replicas are available",
+			check: func() (bool, string) {
+				return DeploymentReadiness(&appsv1.Deployment{
+					Spec:   appsv1.DeploymentSpec{Replicas: &three},
+					Status: appsv1.DeploymentStatus{UpdatedReplicas: 3, AvailableReplicas: 3},
+				})
+			},
+		},
+		{
+			name:    "deployment rolling out",
+			ready:   false,
+			message: "3/3 replicas are available",
+			check: func() (bool, string) {
+				return DeploymentReadiness(&appsv1.Deployment{
+					Spec:   appsv1.DeploymentSpec{Replicas: &three},
+					Status: appsv1.DeploymentStatus{UpdatedReplicas: 1, AvailableReplicas: 3},
+				})
+			},
+		},
+		{
+			name:    "deployment with outdated status",
+			ready:   false,
+			message: "1/1 replicas are available",
+			check: func() (bool, string) {
+				return DeploymentReadiness(&appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Generation: 2},
+					Status:     appsv1.DeploymentStatus{ObservedGeneration: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+				})
+			},
+		},
+		{
+			name:    "statefulset not ready",
+			ready:   false,
+			message: "2/3 replicas are ready",
+			check: func() (bool, string) {
+				return StatefulSetReadiness(&appsv1.StatefulSet{
+					Spec:   appsv1.StatefulSetSpec{Replicas: &three},
+					Status: appsv1.StatefulSetStatus{UpdatedReplicas: 3, ReadyReplicas: 2},
+				})
+			},
+		},
+		{
+			name:    "daemonset available",
+			ready:   true,
+			message: "2/2 pods are available",
+			check: func() (bool, string) {
+				return DaemonSetReadiness(&appsv1.DaemonSet{
+					Status: appsv1.DaemonSetStatus{DesiredNumberScheduled: 2, UpdatedNumberScheduled: 2, NumberAvailable: 2},
+				})
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ready, message := tt.check()
+			assert.Equal(t, tt.ready, ready)
+			assert.Equal(t, tt.message, message)
+		})
+	}
+}
+
+func TestCrashingContainer(t *testing.T) {
+	pod := func(name string, status corev1.ContainerStatus) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{status}},
+		}
+	}
+	running := pod("running", corev1.ContainerStatus{
+		Name:  "otc-container",
+		State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+	})
+	crashing := pod("crashing", corev1.ContainerStatus{
+		Name:  "otc-container",
+		State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+		LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+			ExitCode: 1,
+			Message:  "Error: failed to get config: invalid configuration\n",
+		}},
+	})
+	otherContainer := pod("other", corev1.ContainerStatus{
+		Name:  "app",
+		State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+	})
+
+	_, found := CrashingContainer([]corev1.Pod{running, otherContainer}, "otc-container")
+	assert.False(t, found)
+
+	message, found := CrashingContainer([]corev1.Pod{running, crashing}, "otc-container")
+	assert.True(t, found)
+	assert.Equal(t, "the container otc-container of the pod crashing is crash-looping, it exited with code 1: Error: failed to get config: invalid configuration", message)
+}
+
+func TestReasonFor(t *testing.T) {
+	err := errors.New("boom")
+
+	assert.Equal(t, ReasonReconcileError, ReasonFor(err))
+	assert.Equal(t, ReasonInvalidConfig, ReasonFor(WithReason(ReasonInvalidConfig, err)))
+	assert.Equal(t, ReasonResourceRejected, ReasonFor(fmt.Errorf("wrapped: %w", WithReason(ReasonResourceRejected, err))))
+	assert.ErrorIs(t, WithReason(ReasonManifestBuildFailed, err), err)
+	assert.EqualError(t, WithReason(ReasonManifestBuildFailed, err), "boom")
+	assert.NoError(t, WithReason(ReasonManifestBuildFailed, nil))
+}
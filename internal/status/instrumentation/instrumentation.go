@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/status/conditions"
+)
+
+// UpdateInstrumentationStatus records the images resolved by the defaulting webhook or the upgrade mechanism.
+func UpdateInstrumentationStatus(changed *v1alpha1.Instrumentation) {
+	changed.Status.Images = v1alpha1.AutoInstrumentationImages{
+		Java:        changed.Spec.Java.Image,
+		NodeJS:      changed.Spec.NodeJS.Image,
+		Python:      changed.Spec.Python.Image,
+		DotNet:      changed.Spec.DotNet.Image,
+		Go:          changed.Spec.Go.Image,
+		ApacheHttpd: changed.Spec.ApacheHttpd.Image,
+		Nginx:       changed.Spec.Nginx.Image,
+	}
+	conditions.SetReady(&changed.Status.Conditions, changed.Generation, conditions.ReasonReconciled, "the instrumentation is available for injection")
+}
@@ -16,10 +16,15 @@ package opampbridge
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 
+	appsv1 "k8s.io/api/apps/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/internal/status/conditions"
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
 )
 
@@ -27,5 +32,18 @@ func UpdateOpAMPBridgeStatus(ctx context.Context, cli client.Client, changed *v1
 	if changed.Status.Version == "" {
 		changed.Status.Version = version.OperatorOpAMPBridge()
 	}
+
+	obj := &appsv1.Deployment{}
+	objKey := client.ObjectKey{
+		Namespace: changed.GetNamespace(),
+		Name:      naming.OpAMPBridge(changed.Name),
+	}
+	if err := cli.Get(ctx, objKey, obj); err != nil {
+		return fmt.Errorf("failed to get deployment status: %w", err)
+	}
+	changed.Status.Image = obj.Spec.Template.Spec.Containers[0].Image
+	changed.Status.StatusReplicas = strconv.Itoa(int(obj.Status.ReadyReplicas)) + "/" + strconv.Itoa(int(obj.Status.Replicas))
+	ready, message := conditions.DeploymentReadiness(obj)
+	conditions.SetFromReadiness(&changed.Status.Conditions, changed.Generation, ready, message)
 	return nil
 }
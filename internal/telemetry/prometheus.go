@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// PrometheusProducer produces the metrics of a Prometheus registry, e.g. the reconciliation and webhook metrics
+// registered with the controller-runtime registry, so that they are exported along with the OTLP telemetry. The
+// summaries aren't converted.
+type PrometheusProducer struct {
+	gatherer prometheus.Gatherer
+	start    time.Time
+}
+
+var _ sdkmetric.Producer = (*PrometheusProducer)(nil)
+
+// NewPrometheusProducer returns a producer of the metrics of the gatherer.
+func NewPrometheusProducer(gatherer prometheus.Gatherer) *PrometheusProducer {
+	return &PrometheusProducer{gatherer: gatherer, start: time.Now()}
+}
+
+// Produce gathers the metrics of the registry and converts them.
+func (p *PrometheusProducer) Produce(context.Context) ([]metricdata.ScopeMetrics, error) {
+	families, err := p.gatherer.Gather()
+	if err != nil && len(families) == 0 {
+		return nil, err
+	}
+	now := time.Now()
+	var converted []metricdata.Metrics
+	for _, family := range families {
+		m := metricdata.Metrics{Name: family.GetName(), Description: family.GetHelp()}
+		switch family.GetType() {
+		case dto.MetricType_COUNTER:
+			sum := metricdata.Sum[float64]{Temporality: metricdata.CumulativeTemporality, IsMonotonic: true}
+			for _, metric := range family.GetMetric() {
+				sum.DataPoints = append(sum.DataPoints, metricdata.DataPoint[float64]{
+					Attributes: attributes(metric), StartTime: p.start, Time: now, Value: metric.GetCounter().GetValue(),
+				})
+			}
+			m.Data = sum
+		case dto.MetricType_GAUGE, dto.MetricType_UNTYPED:
+			gauge := metricdata.Gauge[float64]{}
+			for _, metric := range family.GetMetric() {
+				value := metric.GetGauge().GetValue()
+				if family.GetType() == dto.MetricType_UNTYPED {
+					value = metric.GetUntyped().GetValue()
+				}
+				gauge.DataPoints = append(gauge.DataPoints, metricdata.DataPoint[float64]{
+					Attributes: attributes(metric), StartTime: p.start, Time: now, Value: value,
+				})
+			}
+			m.Data = gauge
+		case dto.MetricType_HISTOGRAM, dto.MetricType_GAUGE_HISTOGRAM:
+			histogram := metricdata.Histogram[float64]{Temporality: metricdata.CumulativeTemporality}
+			for _, metric := range family.GetMetric() {
+				histogram.DataPoints = append(histogram.DataPoints, histogramDataPoint(metric, p.start, now))
+			}
+			m.Data = histogram
+		default:
+			continue
+		}
+		converted = append(converted, m)
+	}
+	if len(converted) == 0 {
+		return nil, err
+	}
+	return []metricdata.ScopeMetrics{{
+		Scope:   instrumentation.Scope{Name: scopeName + "/prometheus"},
+		Metrics: converted,
+	}}, err
+}
+
+// histogramDataPoint converts a Prometheus histogram, whose buckets are cumulative, to an OTLP one.
+func histogramDataPoint(metric *dto.Metric, start, now time.Time) metricdata.HistogramDataPoint[float64] {
+	h := metric.GetHistogram()
+	point := metricdata.HistogramDataPoint[float64]{
+		Attributes: attributes(metric),
+		StartTime:  start,
+		Time:       now,
+		Count:      h.GetSampleCount(),
+		Sum:        h.GetSampleSum(),
+	}
+	var previous uint64
+	for _, bucket := range h.GetBucket() {
+		if math.IsInf(bucket.GetUpperBound(), 1) {
+			continue
+		}
+		point.Bounds = append(point.Bounds, bucket.GetUpperBound())
+		point.BucketCounts = append(point.BucketCounts, bucket.GetCumulativeCount()-previous)
+		previous = bucket.GetCumulativeCount()
+	}
+	// the overflow bucket
+	point.BucketCounts = append(point.BucketCounts, h.GetSampleCount()-previous)
+	return point
+}
+
+func attributes(metric *dto.Metric) attribute.Set {
+	kvs := make([]attribute.KeyValue, 0, len(metric.GetLabel()))
+	for _, label := range metric.GetLabel() {
+		kvs = append(kvs, attribute.String(label.GetName(), label.GetValue()))
+	}
+	return attribute.NewSet(kvs...)
+}
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestPrometheusProducer(t *testing.T) {
+	// prepare
+	registry := prometheus.NewRegistry()
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "reconcile_errors_total", Help: "errors"}, []string{"kind"})
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{Name: "workqueue_depth", Help: "depth"})
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "webhook_latency_seconds", Help: "latency", Buckets: []float64{0.1, 1}})
+	summary := prometheus.NewSummary(prometheus.SummaryOpts{Name: "gc_duration_seconds", Help: "gc"})
+	registry.MustRegister(counter, gauge, histogram, summary)
+	counter.WithLabelValues("OpenTelemetryCollector").Add(2)
+	gauge.Set(5)
+	for _, v := range []float64{0.05, 0.5, 0.5, 3} {
+		histogram.Observe(v)
+	}
+	summary.Observe(1)
+
+	// test
+	scopes, err := NewPrometheusProducer(registry).Produce(context.Background())
+
+	// verify
+	require.NoError(t, err)
+	require.Len(t, scopes, 1)
+	metrics := map[string]metricdata.Metrics{}
+	for _, m := range scopes[0].Metrics {
+		metrics[m.Name] = m
+	}
+	assert.NotContains(t, metrics, "gc_duration_seconds")
+
+	sum := metrics["reconcile_errors_total"].Data.(metricdata.Sum[float64])
+	assert.True(t, sum.IsMonotonic)
+	assert.Equal(t, metricdata.CumulativeTemporality, sum.Temporality)
+	require.Len(t, sum.DataPoints, 1)
+	assert.Equal(t, 2.0, sum.DataPoints[0].Value)
+	kind, _ := sum.DataPoints[0].Attributes.Value(attribute.Key("kind"))
+	assert.Equal(t, "OpenTelemetryCollector", kind.AsString())
+
+	assert.Equal(t, 5.0, metrics["workqueue_depth"].Data.(metricdata.Gauge[float64]).DataPoints[0].Value)
+
+	h := metrics["webhook_latency_seconds"].Data.(metricdata.Histogram[float64]).DataPoints[0]
+	assert.Equal(t, uint64(4), h.Count)
+	assert.Equal(t, 4.05, h.Sum)
+	assert.Equal(t, []float64{0.1, 1}, h.Bounds)
+	assert.Equal(t, []uint64{1, 2, 1}, h.BucketCounts)
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+	})
+	return recorder
+}
+
+func TestReconcileSpan(t *testing.T) {
+	// prepare
+	recorder := recordSpans(t)
+
+	// test
+	_, span := StartReconcile(context.Background(), "OpenTelemetryCollector", types.NamespacedName{Namespace: "observability", Name: "gateway"})
+	End(span, errors.New("failed to apply the deployment"))
+
+	// verify
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "reconcile OpenTelemetryCollector", spans[0].Name())
+	assert.Contains(t, spans[0].Attributes(), attribute.String("k8s.resource.name", "gateway"))
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Len(t, spans[0].Events(), 1)
+}
+
+func TestWebhookSpan(t *testing.T) {
+	// prepare
+	recorder := recordSpans(t)
+	handler := traceHandler("/mutate-v1-pod", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+
+	// test
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/mutate-v1-pod", nil))
+
+	// verify
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "webhook /mutate-v1-pod", spans[0].Name())
+	assert.Contains(t, spans[0].Attributes(), attribute.Int("http.status_code", http.StatusInternalServerError))
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+}
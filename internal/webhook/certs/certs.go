@@ -0,0 +1,339 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package certs provisions and rotates the certificates of the webhook server, for clusters without cert-manager.
+package certs
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-logr/logr"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=mutatingwebhookconfigurations;validatingwebhookconfigurations,verbs=get;update,resourceNames=opentelemetry-operator-mutating-webhook-configuration;opentelemetry-operator-validating-webhook-configuration
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;update,resourceNames=opentelemetrycollectors.opentelemetry.io;opampbridges.opentelemetry.io
+
+const (
+	caCertKey = "ca.crt"
+	caKeyKey  = "ca.key"
+	// previousCACertKey holds the CA replaced by the last renewal, which stays in the CA bundle until the time of the
+	// previousCAUntilAnnotation of the Secret.
+	previousCACertKey         = "previous-ca.crt"
+	previousCAUntilAnnotation = "operator.opentelemetry.io/previous-ca-until"
+
+	defaultCAValidity    = 10 * 365 * 24 * time.Hour
+	defaultCertValidity  = 365 * 24 * time.Hour
+	defaultRenewBefore   = 30 * 24 * time.Hour
+	defaultCheckInterval = time.Hour
+)
+
+// Provisioner generates the serving certificate of the webhook server, signed by a self-signed CA, and injects the CA
+// into the webhook configurations and the conversion webhooks of the CRDs. The certificates are kept in a Secret
+// shared by all the replicas of the operator, and renewed before they expire. When the CA is renewed, the CA bundle
+// keeps the previous one for two check intervals, by then every replica serves a certificate signed by the new one.
+type Provisioner struct {
+	// Client must not read from the cache, as it's used before the manager starts.
+	Client client.Client
+	Logger logr.Logger
+
+	// Service is the webhook service, the serving certificate is valid for its DNS names.
+	Service types.NamespacedName
+	// CertDir is the directory the webhook server loads its certificate from.
+	CertDir string
+
+	// MutatingWebhookConfigurations, ValidatingWebhookConfigurations and CustomResourceDefinitions are the names of
+	// the resources the CA is injected into, for the webhooks calling the webhook service.
+	MutatingWebhookConfigurations   []string
+	ValidatingWebhookConfigurations []string
+	CustomResourceDefinitions       []string
+
+	// CAValidity, CertValidity, RenewBefore and CheckInterval default to 10 years, 1 year, 30 days and 1 hour.
+	CAValidity    time.Duration
+	CertValidity  time.Duration
+	RenewBefore   time.Duration
+	CheckInterval time.Duration
+}
+
+// SecretName returns the name of the Secret holding the certificates.
+func (p *Provisioner) SecretName() string {
+	return p.Service.Name + "-cert"
+}
+
+// NeedLeaderElection tells the manager to run the rotation on all replicas, as each one serves the webhooks.
+func (p *Provisioner) NeedLeaderElection() bool {
+	return false
+}
+
+// Start renews the certificates periodically, until the context is done.
+func (p *Provisioner) Start(ctx context.Context) error {
+	ticker := time.NewTicker(durationOrDefault(p.CheckInterval, defaultCheckInterval))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := p.Provision(ctx); err != nil {
+				p.Logger.Error(err, "failed to rotate the webhook certificates")
+			}
+		}
+	}
+}
+
+// Provision makes sure the certificates are valid, generating them when needed, writes them to the certificate
+// directory, and injects the CA into the resources calling the webhooks.
+func (p *Provisioner) Provision(ctx context.Context) error {
+	return p.provision(ctx, time.Now())
+}
+
+func (p *Provisioner) provision(ctx context.Context, now time.Time) error {
+	var secret *corev1.Secret
+	// another replica may create or renew the certificates concurrently, in which case its certificates are used
+	err := retry.OnError(retry.DefaultRetry, func(err error) bool {
+		return apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err)
+	}, func() (err error) {
+		secret, err = p.ensureSecret(ctx, now)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to provision the webhook certificates: %w", err)
+	}
+	// the CA is injected first, so that the webhooks trust a renewed CA before they're served a certificate signed by it
+	injectErr := p.injectCABundle(ctx, caBundle(secret))
+	if err := p.writeCertFiles(secret); err != nil {
+		return errors.Join(injectErr, fmt.Errorf("failed to write the webhook certificates: %w", err))
+	}
+	return injectErr
+}
+
+// caBundle returns the CA, along with the previous one while it's kept.
+func caBundle(secret *corev1.Secret) []byte {
+	return append(append([]byte{}, secret.Data[caCertKey]...), secret.Data[previousCACertKey]...)
+}
+
+// ensureSecret returns the Secret with valid certificates, creating or renewing them as needed.
+func (p *Provisioner) ensureSecret(ctx context.Context, now time.Time) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: p.Service.Namespace, Name: p.SecretName()}
+	if err := p.Client.Get(ctx, key, secret); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+			Type:       corev1.SecretTypeTLS,
+		}
+		if err := p.renew(secret, nil, now); err != nil {
+			return nil, err
+		}
+		p.Logger.Info("generated the webhook certificates", "secret", key)
+		return secret, p.Client.Create(ctx, secret)
+	}
+
+	previousCA, keepUntil := secret.Data[previousCACertKey], secret.Annotations[previousCAUntilAnnotation]
+	ca, err := parseKeyPair(secret.Data[caCertKey], secret.Data[caKeyKey])
+	if err != nil || p.expiring(ca.cert, now) {
+		// without a valid CA, the serving certificate is renewed as well. The replicas serving a certificate signed by
+		// the expiring CA keep doing so until their next check, so the CA bundle keeps it meanwhile.
+		if err == nil {
+			previousCA = secret.Data[caCertKey]
+			keepUntil = now.Add(2 * durationOrDefault(p.CheckInterval, defaultCheckInterval)).Format(time.RFC3339)
+		}
+		ca = nil
+	} else if serving, err := parseKeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey]); err == nil &&
+		!p.expiring(serving.cert, now) && serving.cert.CheckSignatureFrom(ca.cert) == nil && p.validFor(serving.cert) {
+		if previousCA == nil || keep(keepUntil, now) {
+			return secret, nil
+		}
+		delete(secret.Data, previousCACertKey)
+		delete(secret.Annotations, previousCAUntilAnnotation)
+		p.Logger.Info("removed the previous webhook CA from the CA bundle", "secret", key)
+		return secret, p.Client.Update(ctx, secret)
+	}
+	if err := p.renew(secret, ca, now); err != nil {
+		return nil, err
+	}
+	if previousCA != nil && keep(keepUntil, now) {
+		secret.Data[previousCACertKey] = previousCA
+		metav1.SetMetaDataAnnotation(&secret.ObjectMeta, previousCAUntilAnnotation, keepUntil)
+	} else {
+		delete(secret.Annotations, previousCAUntilAnnotation)
+	}
+	p.Logger.Info("renewed the webhook certificates", "secret", key, "ca-renewed", ca == nil)
+	return secret, p.Client.Update(ctx, secret)
+}
+
+// keep returns whether the previous CA is still kept at the given time.
+func keep(until string, now time.Time) bool {
+	t, err := time.Parse(time.RFC3339, until)
+	return err == nil && now.Before(t)
+}
+
+// renew generates a serving certificate signed by the given CA, or by a new CA when none is given, into the Secret.
+func (p *Provisioner) renew(secret *corev1.Secret, ca *keyPair, now time.Time) error {
+	if ca == nil {
+		var err error
+		if ca, err = newCA(p.Service.Name+"-ca", now, durationOrDefault(p.CAValidity, defaultCAValidity)); err != nil {
+			return err
+		}
+	}
+	serving, err := newServingCert(ca, p.dnsNames(), now, durationOrDefault(p.CertValidity, defaultCertValidity))
+	if err != nil {
+		return err
+	}
+	secret.Data = map[string][]byte{
+		caCertKey:               ca.certPEM,
+		caKeyKey:                ca.keyPEM,
+		corev1.TLSCertKey:       serving.certPEM,
+		corev1.TLSPrivateKeyKey: serving.keyPEM,
+	}
+	return nil
+}
+
+func (p *Provisioner) expiring(cert *x509.Certificate, now time.Time) bool {
+	return now.Add(durationOrDefault(p.RenewBefore, defaultRenewBefore)).After(cert.NotAfter)
+}
+
+func (p *Provisioner) validFor(cert *x509.Certificate) bool {
+	for _, name := range p.dnsNames() {
+		if cert.VerifyHostname(name) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *Provisioner) dnsNames() []string {
+	return []string{
+		fmt.Sprintf("%s.%s.svc", p.Service.Name, p.Service.Namespace),
+		fmt.Sprintf("%s.%s.svc.cluster.local", p.Service.Name, p.Service.Namespace),
+		fmt.Sprintf("%s.%s", p.Service.Name, p.Service.Namespace),
+		p.Service.Name,
+	}
+}
+
+// writeCertFiles writes the serving certificate for the webhook server, which reloads it when the files change.
+func (p *Provisioner) writeCertFiles(secret *corev1.Secret) error {
+	if err := os.MkdirAll(p.CertDir, 0o700); err != nil {
+		return err
+	}
+	for _, name := range []string{corev1.TLSPrivateKeyKey, corev1.TLSCertKey} {
+		path := filepath.Join(p.CertDir, name)
+		if current, err := os.ReadFile(path); err == nil && bytes.Equal(current, secret.Data[name]) {
+			continue
+		}
+		if err := os.WriteFile(path, secret.Data[name], 0o600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// injectCABundle sets the CA bundle of the webhooks calling the webhook service. Missing resources are skipped, as
+// they may be named differently when the operator isn't deployed with its own manifests.
+func (p *Provisioner) injectCABundle(ctx context.Context, caBundle []byte) error {
+	for _, name := range p.MutatingWebhookConfigurations {
+		cfg := &admissionregistrationv1.MutatingWebhookConfiguration{}
+		err := p.inject(ctx, name, cfg, func() bool {
+			changed := false
+			for i := range cfg.Webhooks {
+				changed = p.setCABundle(&cfg.Webhooks[i].ClientConfig, caBundle) || changed
+			}
+			return changed
+		})
+		if err != nil {
+			return err
+		}
+	}
+	for _, name := range p.ValidatingWebhookConfigurations {
+		cfg := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+		err := p.inject(ctx, name, cfg, func() bool {
+			changed := false
+			for i := range cfg.Webhooks {
+				changed = p.setCABundle(&cfg.Webhooks[i].ClientConfig, caBundle) || changed
+			}
+			return changed
+		})
+		if err != nil {
+			return err
+		}
+	}
+	for _, name := range p.CustomResourceDefinitions {
+		crd := &apiextensionsv1.CustomResourceDefinition{}
+		err := p.inject(ctx, name, crd, func() bool {
+			conversion := crd.Spec.Conversion
+			if conversion == nil || conversion.Webhook == nil || conversion.Webhook.ClientConfig == nil {
+				return false
+			}
+			cc := conversion.Webhook.ClientConfig
+			if cc.Service == nil || cc.Service.Name != p.Service.Name || cc.Service.Namespace != p.Service.Namespace || bytes.Equal(cc.CABundle, caBundle) {
+				return false
+			}
+			cc.CABundle = caBundle
+			return true
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// inject gets the resource, and updates it when the mutate function reports a change.
+func (p *Provisioner) inject(ctx context.Context, name string, obj client.Object, mutate func() bool) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := p.Client.Get(ctx, types.NamespacedName{Name: name}, obj); err != nil {
+			if apierrors.IsNotFound(err) {
+				p.Logger.V(1).Info("skipping the CA injection into a missing resource", "name", name)
+				return nil
+			}
+			return err
+		}
+		if !mutate() {
+			return nil
+		}
+		p.Logger.Info("injecting the webhook CA", "name", name)
+		return p.Client.Update(ctx, obj)
+	})
+}
+
+func (p *Provisioner) setCABundle(cc *admissionregistrationv1.WebhookClientConfig, caBundle []byte) bool {
+	if cc.Service == nil || cc.Service.Name != p.Service.Name || cc.Service.Namespace != p.Service.Namespace || bytes.Equal(cc.CABundle, caBundle) {
+		return false
+	}
+	cc.CABundle = caBundle
+	return true
+}
+
+func durationOrDefault(d, def time.Duration) time.Duration {
+	if d <= 0 {
+		return def
+	}
+	return d
+}
@@ -0,0 +1,204 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certs
+
+import (
+	"context"
+	"crypto/x509"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var service = types.NamespacedName{Namespace: "opentelemetry-operator-system", Name: "opentelemetry-operator-webhook-service"}
+
+func TestProvision(t *testing.T) {
+	// prepare
+	mutating := &admissionregistrationv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "mutating"},
+		Webhooks: []admissionregistrationv1.MutatingWebhook{
+			{Name: "operator", ClientConfig: clientConfig(service)},
+			{Name: "other", ClientConfig: clientConfig(types.NamespacedName{Namespace: "other", Name: "other"})},
+		},
+	}
+	crd := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "opentelemetrycollectors.opentelemetry.io"},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Conversion: &apiextensionsv1.CustomResourceConversion{
+				Strategy: apiextensionsv1.WebhookConverter,
+				Webhook: &apiextensionsv1.WebhookConversion{
+					ClientConfig: &apiextensionsv1.WebhookClientConfig{
+						Service: &apiextensionsv1.ServiceReference{Namespace: service.Namespace, Name: service.Name},
+					},
+				},
+			},
+		},
+	}
+	cl := fake.NewClientBuilder().WithScheme(testScheme(t)).WithObjects(mutating, crd).Build()
+	p := newProvisioner(t, cl)
+
+	// test
+	require.NoError(t, p.Provision(context.Background()))
+
+	// verify
+	secret := &corev1.Secret{}
+	require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: service.Namespace, Name: p.SecretName()}, secret))
+	caBundle := secret.Data[caCertKey]
+	require.NotEmpty(t, caBundle)
+
+	servingCert, err := os.ReadFile(filepath.Join(p.CertDir, corev1.TLSCertKey))
+	require.NoError(t, err)
+	assert.Equal(t, secret.Data[corev1.TLSCertKey], servingCert)
+	serving, err := parseKeyPair(servingCert, secret.Data[corev1.TLSPrivateKeyKey])
+	require.NoError(t, err)
+	roots := x509.NewCertPool()
+	require.True(t, roots.AppendCertsFromPEM(caBundle))
+	_, err = serving.cert.Verify(x509.VerifyOptions{DNSName: "opentelemetry-operator-webhook-service.opentelemetry-operator-system.svc", Roots: roots})
+	assert.NoError(t, err)
+
+	require.NoError(t, cl.Get(context.Background(), client.ObjectKeyFromObject(mutating), mutating))
+	assert.Equal(t, caBundle, mutating.Webhooks[0].ClientConfig.CABundle)
+	assert.Empty(t, mutating.Webhooks[1].ClientConfig.CABundle)
+	require.NoError(t, cl.Get(context.Background(), client.ObjectKeyFromObject(crd), crd))
+	assert.Equal(t, caBundle, crd.Spec.Conversion.Webhook.ClientConfig.CABundle)
+
+	// test
+	require.NoError(t, p.Provision(context.Background()))
+
+	// verify, the valid certificates are kept
+	unchanged := &corev1.Secret{}
+	require.NoError(t, cl.Get(context.Background(), client.ObjectKeyFromObject(secret), unchanged))
+	assert.Equal(t, secret.ResourceVersion, unchanged.ResourceVersion)
+}
+
+func TestProvisionRenewsExpiringCertificates(t *testing.T) {
+	// prepare
+	cl := fake.NewClientBuilder().WithScheme(testScheme(t)).Build()
+	p := newProvisioner(t, cl)
+	require.NoError(t, p.Provision(context.Background()))
+	secret := &corev1.Secret{}
+	require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: service.Namespace, Name: p.SecretName()}, secret))
+
+	// test
+	p.RenewBefore = 2 * p.CertValidity
+	require.NoError(t, p.Provision(context.Background()))
+
+	// verify, the serving certificate is renewed with the same CA
+	renewed := &corev1.Secret{}
+	require.NoError(t, cl.Get(context.Background(), client.ObjectKeyFromObject(secret), renewed))
+	assert.Equal(t, secret.Data[caCertKey], renewed.Data[caCertKey])
+	assert.NotEqual(t, secret.Data[corev1.TLSCertKey], renewed.Data[corev1.TLSCertKey])
+	servingCert, err := os.ReadFile(filepath.Join(p.CertDir, corev1.TLSCertKey))
+	require.NoError(t, err)
+	assert.Equal(t, renewed.Data[corev1.TLSCertKey], servingCert)
+
+	// test
+	p.RenewBefore = 2 * p.CAValidity
+	require.NoError(t, p.Provision(context.Background()))
+
+	// verify, the CA is renewed as well
+	require.NoError(t, cl.Get(context.Background(), client.ObjectKeyFromObject(secret), secret))
+	assert.NotEqual(t, renewed.Data[caCertKey], secret.Data[caCertKey])
+}
+
+func TestProvisionKeepsThePreviousCA(t *testing.T) {
+	// prepare
+	mutating := &admissionregistrationv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "mutating"},
+		Webhooks:   []admissionregistrationv1.MutatingWebhook{{Name: "operator", ClientConfig: clientConfig(service)}},
+	}
+	cl := fake.NewClientBuilder().WithScheme(testScheme(t)).WithObjects(mutating).Build()
+	p := newProvisioner(t, cl)
+	now := time.Now()
+	require.NoError(t, p.provision(context.Background(), now))
+	previous := &corev1.Secret{}
+	require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: service.Namespace, Name: p.SecretName()}, previous))
+	verify := func(certs *corev1.Secret) error {
+		require.NoError(t, cl.Get(context.Background(), client.ObjectKeyFromObject(mutating), mutating))
+		roots := x509.NewCertPool()
+		require.True(t, roots.AppendCertsFromPEM(mutating.Webhooks[0].ClientConfig.CABundle))
+		serving, err := parseKeyPair(certs.Data[corev1.TLSCertKey], certs.Data[corev1.TLSPrivateKeyKey])
+		require.NoError(t, err)
+		_, err = serving.cert.Verify(x509.VerifyOptions{DNSName: "opentelemetry-operator-webhook-service.opentelemetry-operator-system.svc", Roots: roots})
+		return err
+	}
+
+	// test: the CA is renewed by a replica
+	p.RenewBefore = 2 * p.CAValidity
+	require.NoError(t, p.provision(context.Background(), now))
+	p.RenewBefore = time.Hour
+
+	// verify: the certificates of both CAs are trusted
+	secret := &corev1.Secret{}
+	require.NoError(t, cl.Get(context.Background(), client.ObjectKeyFromObject(previous), secret))
+	require.NotEqual(t, previous.Data[caCertKey], secret.Data[caCertKey])
+	assert.Equal(t, previous.Data[caCertKey], secret.Data[previousCACertKey])
+	assert.NoError(t, verify(previous), "the replicas which didn't rotate yet must be trusted")
+	assert.NoError(t, verify(secret))
+
+	// test: the replicas rotated
+	require.NoError(t, p.provision(context.Background(), now.Add(time.Hour)))
+	require.NoError(t, cl.Get(context.Background(), client.ObjectKeyFromObject(previous), secret))
+	assert.NotEmpty(t, secret.Data[previousCACertKey], "the previous CA is kept for two check intervals")
+	require.NoError(t, p.provision(context.Background(), now.Add(3*time.Hour)))
+
+	// verify: the previous CA is dropped
+	require.NoError(t, cl.Get(context.Background(), client.ObjectKeyFromObject(previous), secret))
+	assert.Empty(t, secret.Data[previousCACertKey])
+	assert.Empty(t, secret.Annotations[previousCAUntilAnnotation])
+	assert.Error(t, verify(previous))
+	assert.NoError(t, verify(secret))
+}
+
+func newProvisioner(t *testing.T, cl client.Client) *Provisioner {
+	return &Provisioner{
+		Client:                        cl,
+		Logger:                        logf.Log.WithName("unit-tests"),
+		Service:                       service,
+		CertDir:                       t.TempDir(),
+		MutatingWebhookConfigurations: []string{"mutating", "missing"},
+		CustomResourceDefinitions:     []string{"opentelemetrycollectors.opentelemetry.io"},
+		CAValidity:                    10 * 24 * time.Hour,
+		CertValidity:                  24 * time.Hour,
+		RenewBefore:                   time.Hour,
+	}
+}
+
+func clientConfig(svc types.NamespacedName) admissionregistrationv1.WebhookClientConfig {
+	return admissionregistrationv1.WebhookClientConfig{
+		Service: &admissionregistrationv1.ServiceReference{Namespace: svc.Namespace, Name: svc.Name},
+	}
+}
+
+func testScheme(t *testing.T) *runtime.Scheme {
+	s := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(s))
+	require.NoError(t, apiextensionsv1.AddToScheme(s))
+	return s
+}
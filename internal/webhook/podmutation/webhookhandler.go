@@ -18,10 +18,12 @@ package podmutation
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -56,6 +58,15 @@ type PodMutator interface {
 	Mutate(ctx context.Context, ns corev1.Namespace, pod corev1.Pod) (corev1.Pod, error)
 }
 
+// Warning is returned by the pod mutators which leave the pod unchanged for a reason its creator should know about,
+// e.g. an injection requested for a collector which doesn't exist. It's returned to the client as an admission
+// warning, and the pod is admitted with the changes of the other mutators.
+type Warning string
+
+func (w Warning) Error() string {
+	return string(w)
+}
+
 // NewWebhookHandler creates a new WebhookHandler.
 func NewWebhookHandler(cfg config.Config, logger logr.Logger, decoder *admission.Decoder, cl client.Client, podMutators []PodMutator) WebhookHandler {
 	return &podMutationWebhook{
@@ -75,8 +86,11 @@ func (p *podMutationWebhook) Handle(ctx context.Context, req admission.Request)
 	}
 
 	// we use the req.Namespace here because the pod might have not been created yet
-	ns := corev1.Namespace{}
-	err = p.client.Get(ctx, types.NamespacedName{Name: req.Namespace, Namespace: ""}, &ns)
+	ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: req.Namespace}}
+	// the namespace-scoped operator can't read the namespaces, the injection is then only enabled by the pods themselves
+	if !p.config.NamespaceScoped() {
+		err = p.client.Get(ctx, types.NamespacedName{Name: req.Namespace, Namespace: ""}, &ns)
+	}
 	if err != nil {
 		res := admission.Errored(http.StatusInternalServerError, err)
 		// By default, admission.Errored sets Allowed to false which blocks pod creation even though the failurePolicy=ignore.
@@ -88,8 +102,15 @@ func (p *podMutationWebhook) Handle(ctx context.Context, req admission.Request)
 		return res
 	}
 
+	var warnings []string
 	for _, m := range p.podMutators {
-		pod, err = m.Mutate(ctx, ns, pod)
+		mutated, err := m.Mutate(ctx, ns, pod)
+		var warning Warning
+		if errors.As(err, &warning) {
+			warnings = append(warnings, warning.Error())
+			continue
+		}
+		pod = mutated
 		if err != nil {
 			res := admission.Errored(http.StatusInternalServerError, err)
 			res.Allowed = true
@@ -103,5 +124,5 @@ func (p *podMutationWebhook) Handle(ctx context.Context, req admission.Request)
 		res.Allowed = true
 		return res
 	}
-	return admission.PatchResponseFromRaw(req.Object.Raw, marshaledPod)
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaledPod).WithWarnings(warnings...)
 }
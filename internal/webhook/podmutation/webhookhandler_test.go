@@ -193,6 +193,7 @@ func TestPodShouldNotBeChanged(t *testing.T) {
 		ns       corev1.Namespace
 		pod      corev1.Pod
 		otelcols []v1alpha1.OpenTelemetryCollector
+		warnings []string
 	}{
 		{
 			name: "namespace has no annotations",
@@ -241,6 +242,7 @@ func TestPodShouldNotBeChanged(t *testing.T) {
 					},
 				},
 			},
+			warnings: []string{"the sidecar requested by the sidecar.opentelemetry.io/inject annotation isn't injected: multiple OpenTelemetry Collector instances available, cannot determine which one to select in the namespace my-namespace-multiple-otelcols"},
 		},
 		{
 			name: "no otelcols",
@@ -252,6 +254,7 @@ func TestPodShouldNotBeChanged(t *testing.T) {
 			},
 			pod:      corev1.Pod{},
 			otelcols: []v1alpha1.OpenTelemetryCollector{},
+			warnings: []string{"the sidecar requested by the sidecar.opentelemetry.io/inject annotation isn't injected: no OpenTelemetry Collector instances available in the namespace my-namespace-no-otelcols"},
 		},
 		{
 			name: "otelcol is not a sidecar",
@@ -271,6 +274,7 @@ func TestPodShouldNotBeChanged(t *testing.T) {
 					Mode: v1alpha1.ModeDaemonSet,
 				},
 			}},
+			warnings: []string{"the sidecar requested by the sidecar.opentelemetry.io/inject annotation isn't injected: the OpenTelemetry Collector's mode is not set to sidecar: my-namespace-no-sidecar-otelcol/my-instance"},
 		},
 		{
 			name: "automatically injected otelcol is not a sidecar",
@@ -290,6 +294,19 @@ func TestPodShouldNotBeChanged(t *testing.T) {
 					Mode: v1alpha1.ModeDaemonSet,
 				},
 			}},
+			warnings: []string{"the sidecar requested by the sidecar.opentelemetry.io/inject annotation isn't injected: no OpenTelemetry Collector instances available in the namespace my-namespace-no-automatic-sidecar-otelcol"},
+		},
+		{
+			name: "otelcol doesn't exist",
+			ns: corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "my-namespace-missing-otelcol",
+					Annotations: map[string]string{sidecar.Annotation: "my-instance"},
+				},
+			},
+			pod:      corev1.Pod{},
+			otelcols: []v1alpha1.OpenTelemetryCollector{},
+			warnings: []string{"the sidecar requested by the sidecar.opentelemetry.io/inject annotation isn't injected: the OpenTelemetry Collector doesn't exist: my-namespace-missing-otelcol/my-instance"},
 		},
 		{
 			name: "pod has sidecar already",
@@ -379,6 +396,7 @@ func TestPodShouldNotBeChanged(t *testing.T) {
 			assert.True(t, res.Allowed)
 			assert.Nil(t, res.AdmissionResponse.Result)
 			assert.Len(t, res.Patches, 0)
+			assert.Equal(t, tt.warnings, res.Warnings)
 
 			// cleanup
 			for i := range tt.otelcols {
@@ -439,3 +457,59 @@ func TestFailOnInvalidRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestNamespaceScopedDoesNotReadNamespace(t *testing.T) {
+	// prepare
+	pod := corev1.Pod{}
+	encoded, err := json.Marshal(pod)
+	require.NoError(t, err)
+	req := admission.Request{
+		AdmissionRequest: admv1.AdmissionRequest{
+			Namespace: "non-existing",
+			Object: runtime.RawExtension{
+				Raw: encoded,
+			},
+		},
+	}
+	cfg := config.New(config.WithNamespaceScoped(true))
+	decoder := admission.NewDecoder(scheme.Scheme)
+	injector := NewWebhookHandler(cfg, logger, decoder, k8sClient, []PodMutator{sidecar.NewMutator(logger, cfg, k8sClient)})
+
+	// test
+	res := injector.Handle(context.Background(), req)
+
+	// verify
+	assert.True(t, res.Allowed)
+	assert.Nil(t, res.AdmissionResponse.Result)
+	assert.Len(t, res.Patches, 0)
+}
+
+func TestWarnOnCollectorOfUnwatchedNamespace(t *testing.T) {
+	// prepare
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{sidecar.Annotation: "other-namespace/my-instance"},
+		},
+	}
+	encoded, err := json.Marshal(pod)
+	require.NoError(t, err)
+	req := admission.Request{
+		AdmissionRequest: admv1.AdmissionRequest{
+			Namespace: "my-namespace",
+			Object: runtime.RawExtension{
+				Raw: encoded,
+			},
+		},
+	}
+	cfg := config.New(config.WithNamespaceScoped(true), config.WithWatchNamespaces([]string{"my-namespace"}))
+	decoder := admission.NewDecoder(scheme.Scheme)
+	injector := NewWebhookHandler(cfg, logger, decoder, k8sClient, []PodMutator{sidecar.NewMutator(logger, cfg, k8sClient)})
+
+	// test
+	res := injector.Handle(context.Background(), req)
+
+	// verify
+	assert.True(t, res.Allowed)
+	assert.Len(t, res.Patches, 0)
+	assert.Equal(t, []string{"the sidecar requested by the sidecar.opentelemetry.io/inject annotation isn't injected: the OpenTelemetry Collector is in a namespace the operator doesn't watch: other-namespace/my-instance"}, res.Warnings)
+}
@@ -17,9 +17,13 @@ package main
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"time"
@@ -28,7 +32,16 @@ import (
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/spf13/pflag"
 	colfeaturegate "go.opentelemetry.io/collector/featuregate"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
@@ -36,17 +49,24 @@ import (
 	k8sapiflag "k8s.io/component-base/cli/flag"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	otelv1alpha1 "github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	otelv1beta1 "github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/controllers"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/imagedigest"
+	manifestrender "github.com/open-telemetry/opentelemetry-operator/internal/render"
+	"github.com/open-telemetry/opentelemetry-operator/internal/telemetry"
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
+	"github.com/open-telemetry/opentelemetry-operator/internal/webhook/certs"
 	"github.com/open-telemetry/opentelemetry-operator/internal/webhook/podmutation"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect"
 	collectorupgrade "github.com/open-telemetry/opentelemetry-operator/pkg/collector/upgrade"
@@ -57,19 +77,67 @@ import (
 	// +kubebuilder:scaffold:imports
 )
 
+// configFileReloadInterval is how often the configuration file is checked for changes.
+const configFileReloadInterval = 10 * time.Second
+
 var (
 	scheme   = k8sruntime.NewScheme()
 	setupLog = ctrl.Log.WithName("setup")
+
+	// reloadableFlags are the flags applied without restarting the operator when the configuration file changes.
+	reloadableFlags = map[string]bool{
+		"collector-image":                         true,
+		"target-allocator-image":                  true,
+		"operator-opamp-bridge-image":             true,
+		"auto-instrumentation-java-image":         true,
+		"auto-instrumentation-nodejs-image":       true,
+		"auto-instrumentation-python-image":       true,
+		"auto-instrumentation-dotnet-image":       true,
+		"auto-instrumentation-go-image":           true,
+		"auto-instrumentation-apache-httpd-image": true,
+		"auto-instrumentation-nginx-image":        true,
+		"spiffe-helper-image":                     true,
+		"drain-image":                             true,
+		"labels":                                  true,
+		"annotations":                             true,
+		"allowed-image-registries":                true,
+	}
 )
 
+// allReplicasRunnable is started on every replica of the operator instead of only on the leader.
+type allReplicasRunnable struct {
+	manager.RunnableFunc
+}
+
+func (allReplicasRunnable) NeedLeaderElection() bool {
+	return false
+}
+
 type tlsConfig struct {
 	minVersion   string
 	cipherSuites []string
 }
 
+// backoffConfig holds the delays between the retries of the failed reconciles of a controller.
+type backoffConfig struct {
+	baseDelay time.Duration
+	maxDelay  time.Duration
+}
+
+func (b *backoffConfig) addFlags(prefix, kind string) {
+	pflag.DurationVar(&b.baseDelay, prefix+"-backoff-base-delay", controllers.DefaultBackoffBaseDelay, fmt.Sprintf("The delay before retrying the failed reconcile of a %s resource, doubled on each further failure.", kind))
+	pflag.DurationVar(&b.maxDelay, prefix+"-backoff-max-delay", controllers.DefaultBackoffMaxDelay, fmt.Sprintf("The longest delay between the retries of the failed reconciles of a %s resource.", kind))
+}
+
+func (b backoffConfig) rateLimiter() ratelimiter.RateLimiter {
+	return controllers.NewRateLimiter(b.baseDelay, b.maxDelay)
+}
+
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
 	utilruntime.Must(otelv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(otelv1beta1.AddToScheme(scheme))
 	utilruntime.Must(routev1.AddToScheme(scheme))
 	utilruntime.Must(monitoringv1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
@@ -91,6 +159,9 @@ func main() {
 		probeAddr                      string
 		pprofAddr                      string
 		enableLeaderElection           bool
+		leaseDuration                  time.Duration
+		renewDeadline                  time.Duration
+		retryPeriod                    time.Duration
 		collectorImage                 string
 		targetAllocatorImage           string
 		operatorOpAMPBridgeImage       string
@@ -101,8 +172,45 @@ func main() {
 		autoInstrumentationApacheHttpd string
 		autoInstrumentationNginx       string
 		autoInstrumentationGo          string
+		spiffeHelperImage              string
+		drainImage                     string
 		labelsFilter                   []string
+		annotationsFilter              []string
+		watchNamespaces                []string
+		crLabelSelector                string
+		configFile                     string
 		webhookPort                    int
+		webhookCertDir                 string
+		webhookServiceName             string
+		selfSignedWebhookCerts         bool
+		autopilot                      bool
+		defaultRunAsNonRoot            bool
+		defaultReadOnlyRootFilesystem  bool
+		defaultDropAllCapabilities     bool
+		sidecarMaxCPU                  string
+		sidecarMaxMemory               string
+		sidecarAllowPrivileged         bool
+		namespaceScoped                bool
+		validateResourceQuotas         bool
+		allowedImageRegistries         []string
+		imageDigests                   []string
+		resolveImageDigests            bool
+		defaultTolerations             []string
+		defaultNodeSelector            []string
+		defaultRequests                []string
+		defaultLimits                  []string
+		collectorConcurrency           int
+		collectorStatusInterval        time.Duration
+		opampBridgeConcurrency         int
+		instrumentationConcurrency     int
+		collectorBackoff               backoffConfig
+		opampBridgeBackoff             backoffConfig
+		instrumentationBackoff         backoffConfig
+		kubeAPIQPS                     float32
+		kubeAPIBurst                   int
+		telemetryEndpoint              string
+		telemetryInsecure              bool
+		telemetryMetricsInterval       time.Duration
 		tlsOpt                         tlsConfig
 	)
 
@@ -112,6 +220,10 @@ func main() {
 	pflag.BoolVar(&enableLeaderElection, "enable-leader-election", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	// the defaults follow https://github.com/openshift/library-go/blob/4362aa519714a4b62b00ab8318197ba2bba51cb7/pkg/config/leaderelection/leaderelection.go#L104
+	pflag.DurationVar(&leaseDuration, "leader-election-lease-duration", 137*time.Second, "The duration non-leader replicas wait before trying to acquire the leadership of a lease which wasn't renewed.")
+	pflag.DurationVar(&renewDeadline, "leader-election-renew-deadline", 107*time.Second, "The duration the leader keeps retrying to renew the lease before giving up the leadership. Must be shorter than the lease duration.")
+	pflag.DurationVar(&retryPeriod, "leader-election-retry-period", 26*time.Second, "The duration the replicas wait between attempts to acquire or renew the leadership.")
 	pflag.StringVar(&collectorImage, "collector-image", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-collector-releases/opentelemetry-collector:%s", v.OpenTelemetryCollector), "The default OpenTelemetry collector image. This image is used when no image is specified in the CustomResource.")
 	pflag.StringVar(&targetAllocatorImage, "target-allocator-image", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/target-allocator:%s", v.TargetAllocator), "The default OpenTelemetry target allocator image. This image is used when no image is specified in the CustomResource.")
 	pflag.StringVar(&operatorOpAMPBridgeImage, "operator-opamp-bridge-image", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/operator-opamp-bridge:%s", v.OperatorOpAMPBridge), "The default OpenTelemetry Operator OpAMP Bridge image. This image is used when no image is specified in the CustomResource.")
@@ -122,15 +234,146 @@ func main() {
 	pflag.StringVar(&autoInstrumentationGo, "auto-instrumentation-go-image", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-go-instrumentation/autoinstrumentation-go:%s", v.AutoInstrumentationGo), "The default OpenTelemetry Go instrumentation image. This image is used when no image is specified in the CustomResource.")
 	pflag.StringVar(&autoInstrumentationApacheHttpd, "auto-instrumentation-apache-httpd-image", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-apache-httpd:%s", v.AutoInstrumentationApacheHttpd), "The default OpenTelemetry Apache HTTPD instrumentation image. This image is used when no image is specified in the CustomResource.")
 	pflag.StringVar(&autoInstrumentationNginx, "auto-instrumentation-nginx-image", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-apache-httpd:%s", v.AutoInstrumentationNginx), "The default OpenTelemetry Nginx instrumentation image. This image is used when no image is specified in the CustomResource.")
+	pflag.StringVar(&drainImage, "drain-image", "busybox:1.36", "The default image providing the sleep binary of the preStop hook of the collectors with a drain delay, as the collector images don't ship one. This image is used when no image is specified in the CustomResource.")
+	pflag.StringVar(&spiffeHelperImage, "spiffe-helper-image", "ghcr.io/spiffe/spiffe-helper:0.8.0", "The default spiffe-helper image, writing the SVIDs of the collectors with SPIFFE enabled to files. This image is used when no image is specified in the CustomResource.")
 	pflag.StringArrayVar(&labelsFilter, "labels", []string{}, "Labels to filter away from propagating onto deploys")
+	pflag.StringArrayVar(&annotationsFilter, "annotations", []string{}, "Annotations to filter away from propagating onto deploys and their pods, e.g. 'kustomize.config.k8s.io/*'")
+	pflag.StringSliceVar(&watchNamespaces, "watch-namespaces", nil, "Comma-separated list of namespaces the operator watches. Takes precedence over the WATCH_NAMESPACE env var, all namespaces are watched when neither is set.")
+	pflag.StringVar(&crLabelSelector, "cr-label-selector", "", "Label selector restricting the OpenTelemetryCollector, OpAMPBridge and Instrumentation resources the operator reconciles. Allows several operators to share a cluster.")
+	pflag.StringVar(&configFile, "config-file", "", "Path of a YAML file setting the flags of the operator, keyed by flag name. The flags given on the command line take precedence. The file is reloaded when it changes: the default images, the labels and annotations filters and the allowed image registries are applied at once, the other settings once the operator restarts.")
 	pflag.IntVar(&webhookPort, "webhook-port", 9443, "The port the webhook endpoint binds to.")
+	pflag.StringVar(&webhookCertDir, "webhook-cert-dir", filepath.Join(os.TempDir(), "k8s-webhook-server", "serving-certs"), "The directory the webhook server loads its certificate from.")
+	pflag.BoolVar(&selfSignedWebhookCerts, "self-signed-webhook-certs", false, "Generate and rotate the certificates of the webhook server with a self-signed CA, for clusters without cert-manager. The certificates are written to the webhook cert directory, which must be writable.")
+	pflag.StringVar(&webhookServiceName, "webhook-service-name", "opentelemetry-operator-webhook-service", "The name of the Service in front of the webhook server, in the namespace of the operator. Used to generate the self-signed webhook certificates.")
+	pflag.BoolVar(&autopilot, "autopilot", false, "Generate the manifests for the constraints of GKE Autopilot clusters: the containers get resource requests, and the custom resources requesting the host network, hostPath volumes or privileged containers are rejected.")
+	pflag.BoolVar(&defaultRunAsNonRoot, "default-run-as-non-root", false, "Set runAsNonRoot in the security context of the containers generated by the operator, unless their custom resource sets it.")
+	pflag.BoolVar(&defaultReadOnlyRootFilesystem, "default-read-only-root-filesystem", false, "Set readOnlyRootFilesystem in the security context of the containers generated by the operator, unless their custom resource sets it.")
+	pflag.BoolVar(&defaultDropAllCapabilities, "default-drop-all-capabilities", false, "Drop all the capabilities of the containers generated by the operator, unless their custom resource sets their capabilities.")
+	pflag.StringSliceVar(&defaultTolerations, "default-tolerations", nil, "Comma-separated list of the tolerations of the pods generated by the operator whose custom resource sets none, in the key[=value][:effect] format of the taints, e.g. 'dedicated=telemetry:NoSchedule'. A toleration without a value tolerates any value of the key.")
+	pflag.StringSliceVar(&defaultNodeSelector, "default-node-selector", nil, "Comma-separated list of the key=value labels of the nodes the pods generated by the operator are scheduled on, when their custom resource sets no node selector, e.g. 'pool=telemetry'.")
+	pflag.StringSliceVar(&defaultRequests, "default-resources-requests", nil, "Comma-separated list of the name=quantity resource requests of the containers generated by the operator whose custom resource sets neither requests nor limits, e.g. 'cpu=100m,memory=128Mi'.")
+	pflag.StringSliceVar(&defaultLimits, "default-resources-limits", nil, "Comma-separated list of the name=quantity resource limits of the containers generated by the operator whose custom resource sets neither requests nor limits, e.g. 'memory=512Mi'.")
+	pflag.StringVar(&sidecarMaxCPU, "sidecar-max-cpu", "", "The upper bound of the CPU requests and limits the pods may set on their collector sidecar with annotations, e.g. 500m. Unbounded when empty.")
+	pflag.StringVar(&sidecarMaxMemory, "sidecar-max-memory", "", "The upper bound of the memory requests and limits the pods may set on their collector sidecar with annotations, e.g. 512Mi. Unbounded when empty.")
+	pflag.BoolVar(&sidecarAllowPrivileged, "sidecar-allow-privileged-security-context", false, "Let the pods make their collector sidecar privileged, allow its privilege escalation, add capabilities to it or run it as root with the security context annotation.")
+	pflag.BoolVar(&namespaceScoped, "namespace-scoped", false, "Run the operator with namespace-scoped permissions only, in the namespaces it watches: it neither reads the namespaces nor creates ClusterRoles, and the custom resources needing cluster-scoped permissions are rejected. Requires the watched namespaces to be set.")
+	pflag.StringSliceVar(&allowedImageRegistries, "allowed-image-registries", nil, "Comma-separated list of the registries the images of the OpenTelemetryCollector, OpAMPBridge and Instrumentation resources must be pulled from, e.g. 'registry.example.com,registry.example.com/mirror'. The default images of the operator are always allowed. Any registry is allowed when empty.")
+	pflag.StringSliceVar(&imageDigests, "image-digests", nil, "Comma-separated list of image=digest pairs, e.g. 'ghcr.io/org/collector:1.0=sha256:...'. The defaulting webhooks pin the listed images of the OpenTelemetryCollector, OpAMPBridge and Instrumentation resources to their digest.")
+	pflag.BoolVar(&resolveImageDigests, "resolve-image-digests", false, "Pin the images of the OpenTelemetryCollector, OpAMPBridge and Instrumentation resources which aren't listed in --image-digests to the digest their tag points to in their registry, rejecting the resources whose images can't be resolved, including the private images. Only the public images of the --allowed-image-registries, which must be set, are resolved.")
+	pflag.BoolVar(&validateResourceQuotas, "validate-resource-quotas", false, "Reject the OpenTelemetryCollector and OpAMPBridge resources whose pods would be refused by the LimitRanges or the ResourceQuotas of their namespace, e.g. for lacking resource limits, instead of leaving their workloads without pods.")
+	pflag.IntVar(&collectorConcurrency, "collector-max-concurrent-reconciles", 1, "The number of OpenTelemetryCollector resources reconciled in parallel.")
+	pflag.DurationVar(&collectorStatusInterval, "collector-status-interval", 0, "The interval at which the readiness of the workloads is aggregated into the status of the OpenTelemetryCollector resources, e.g. 30s. When unset, the status is updated on each change of the workloads.")
+	pflag.IntVar(&opampBridgeConcurrency, "opamp-bridge-max-concurrent-reconciles", 1, "The number of OpAMPBridge resources reconciled in parallel.")
+	pflag.IntVar(&instrumentationConcurrency, "instrumentation-max-concurrent-reconciles", 1, "The number of Instrumentation resources reconciled in parallel.")
+	collectorBackoff.addFlags("collector", "OpenTelemetryCollector")
+	opampBridgeBackoff.addFlags("opamp-bridge", "OpAMPBridge")
+	instrumentationBackoff.addFlags("instrumentation", "Instrumentation")
+	pflag.Float32Var(&kubeAPIQPS, "kube-api-qps", 20, "The maximum number of queries per second the operator sends to the Kubernetes API server.")
+	pflag.IntVar(&kubeAPIBurst, "kube-api-burst", 30, "The maximum burst of queries the operator sends to the Kubernetes API server above the QPS limit.")
+	pflag.StringVar(&telemetryEndpoint, "telemetry-otlp-endpoint", "", "The host:port of the OTLP gRPC receiver the operator exports its traces and metrics to, e.g. a collector it manages. The export is disabled when empty.")
+	pflag.BoolVar(&telemetryInsecure, "telemetry-otlp-insecure", false, "Disable the TLS of the connection to the OTLP receiver the operator exports its telemetry to.")
+	pflag.DurationVar(&telemetryMetricsInterval, "telemetry-metrics-interval", 30*time.Second, "The interval at which the operator exports its metrics over OTLP.")
 	pflag.StringVar(&tlsOpt.minVersion, "tls-min-version", "VersionTLS12", "Minimum TLS version supported. Value must match version names from https://golang.org/pkg/crypto/tls/#pkg-constants.")
 	pflag.StringSliceVar(&tlsOpt.cipherSuites, "tls-cipher-suites", nil, "Comma-separated list of cipher suites for the server. Values are from tls package constants (https://golang.org/pkg/crypto/tls/#pkg-constants). If omitted, the default Go cipher suites will be used")
 	pflag.Parse()
 
+	// the configuration file is loaded before the logger is created, as it may set the log level as well
+	var cfgFile *config.FileLoader
+	var cfgFileErr error
+	if configFile != "" {
+		cfgFile = config.NewFileLoader(pflag.CommandLine, configFile, ctrl.Log.WithName("config-file"))
+		_, cfgFileErr = cfgFile.Load()
+	}
+
 	logger := zap.New(zap.UseFlagOptions(&opts))
 	ctrl.SetLogger(logger)
 
+	if cfgFileErr != nil {
+		setupLog.Error(cfgFileErr, "invalid configuration file", "config-file", configFile)
+		os.Exit(1)
+	}
+
+	maxResources, err := sidecarMaxResources(sidecarMaxCPU, sidecarMaxMemory)
+	if err != nil {
+		setupLog.Error(err, "invalid bounds of the sidecar resources")
+		os.Exit(1)
+	}
+
+	tolerations, err := parseTolerations(defaultTolerations)
+	if err != nil {
+		setupLog.Error(err, "invalid default tolerations")
+		os.Exit(1)
+	}
+	nodeSelector, err := parseNodeSelector(defaultNodeSelector)
+	if err != nil {
+		setupLog.Error(err, "invalid default node selector")
+		os.Exit(1)
+	}
+	requests, err := parseResourceList(defaultRequests)
+	if err != nil {
+		setupLog.Error(err, "invalid default resource requests")
+		os.Exit(1)
+	}
+	limits, err := parseResourceList(defaultLimits)
+	if err != nil {
+		setupLog.Error(err, "invalid default resource limits")
+		os.Exit(1)
+	}
+
+	if resolveImageDigests && len(allowedImageRegistries) == 0 {
+		setupLog.Error(errors.New("--resolve-image-digests requires --allowed-image-registries, the registries the digests are resolved from"), "invalid image digests settings")
+		os.Exit(1)
+	}
+	digestResolver, err := imageDigestResolver(imageDigests, resolveImageDigests)
+	if err != nil {
+		setupLog.Error(err, "invalid image digests settings")
+		os.Exit(1)
+	}
+
+	// the options which can be changed by reloading the configuration file
+	reloadableOpts := func() []config.Option {
+		return []config.Option{
+			config.WithCollectorImage(collectorImage),
+			config.WithTargetAllocatorImage(targetAllocatorImage),
+			config.WithOperatorOpAMPBridgeImage(operatorOpAMPBridgeImage),
+			config.WithAutoInstrumentationJavaImage(autoInstrumentationJava),
+			config.WithAutoInstrumentationNodeJSImage(autoInstrumentationNodeJS),
+			config.WithAutoInstrumentationPythonImage(autoInstrumentationPython),
+			config.WithAutoInstrumentationDotNetImage(autoInstrumentationDotNet),
+			config.WithAutoInstrumentationGoImage(autoInstrumentationGo),
+			config.WithAutoInstrumentationApacheHttpdImage(autoInstrumentationApacheHttpd),
+			config.WithAutoInstrumentationNginxImage(autoInstrumentationNginx),
+			config.WithSpiffeHelperImage(spiffeHelperImage),
+			config.WithDrainImage(drainImage),
+			config.WithLabelFilters(labelsFilter),
+			config.WithAnnotationFilters(annotationsFilter),
+			config.WithAllowedImageRegistries(allowedImageRegistries),
+		}
+	}
+	cfgOpts := append([]config.Option{
+		config.WithLogger(ctrl.Log.WithName("config")),
+		config.WithVersion(v),
+		config.WithAutopilot(autopilot),
+		config.WithDefaultSecurityContext(defaultSecurityContext(defaultRunAsNonRoot, defaultReadOnlyRootFilesystem, defaultDropAllCapabilities)),
+		config.WithSidecarMaxResources(maxResources),
+		config.WithSidecarAllowPrivileged(sidecarAllowPrivileged),
+		config.WithNamespaceScoped(namespaceScoped),
+		config.WithResourceQuotaValidation(validateResourceQuotas),
+		config.WithImageDigestResolver(digestResolver),
+		config.WithDefaultTolerations(tolerations),
+		config.WithDefaultNodeSelector(nodeSelector),
+		config.WithDefaultResources(corev1.ResourceRequirements{Requests: requests, Limits: limits}),
+	}, reloadableOpts()...)
+
+	// the render command prints the manifests built for the custom resources in the given files and exits
+	if pflag.Arg(0) == "render" {
+		if err := render(config.New(cfgOpts...), pflag.Args()[1:]); err != nil {
+			setupLog.Error(err, "failed to render the manifests")
+			os.Exit(1)
+		}
+		return
+	}
+
 	logger.Info("Starting the OpenTelemetry Operator",
 		"opentelemetry-operator", v.Operator,
 		"opentelemetry-collector", collectorImage,
@@ -143,15 +386,52 @@ func main() {
 		"auto-instrumentation-go", autoInstrumentationGo,
 		"auto-instrumentation-apache-httpd", autoInstrumentationApacheHttpd,
 		"auto-instrumentation-nginx", autoInstrumentationNginx,
+		"spiffe-helper", spiffeHelperImage,
+		"drain", drainImage,
 		"feature-gates", flagset.Lookup(featuregate.FeatureGatesFlag).Value.String(),
 		"build-date", v.BuildDate,
 		"go-version", v.Go,
 		"go-arch", runtime.GOARCH,
 		"go-os", runtime.GOOS,
 		"labels-filter", labelsFilter,
+		"annotations-filter", annotationsFilter,
 	)
 
+	for name, value := range map[string]int{
+		"collector-max-concurrent-reconciles":       collectorConcurrency,
+		"opamp-bridge-max-concurrent-reconciles":    opampBridgeConcurrency,
+		"instrumentation-max-concurrent-reconciles": instrumentationConcurrency,
+	} {
+		if value < 1 {
+			setupLog.Error(fmt.Errorf("%s must be at least 1, got %d", name, value), "invalid controller settings")
+			os.Exit(1)
+		}
+	}
+	if kubeAPIQPS <= 0 || float32(kubeAPIBurst) < kubeAPIQPS {
+		setupLog.Error(fmt.Errorf("the QPS %v must be positive and no greater than the burst %d", kubeAPIQPS, kubeAPIBurst), "invalid Kubernetes API client settings")
+		os.Exit(1)
+	}
+
 	restConfig := ctrl.GetConfigOrDie()
+	restConfig.QPS = kubeAPIQPS
+	restConfig.Burst = kubeAPIBurst
+
+	// exports the traces and metrics of the operator
+	var stopTelemetry func(context.Context) error
+	if telemetryEndpoint != "" {
+		var err error
+		stopTelemetry, err = telemetry.Setup(context.Background(), telemetry.Options{
+			Endpoint:        telemetryEndpoint,
+			Insecure:        telemetryInsecure,
+			MetricsInterval: telemetryMetricsInterval,
+			Version:         v.Operator,
+		})
+		if err != nil {
+			setupLog.Error(err, "failed to set up the export of the telemetry", "telemetry-otlp-endpoint", telemetryEndpoint)
+			os.Exit(1)
+		}
+		setupLog.Info("exporting the telemetry of the operator", "telemetry-otlp-endpoint", telemetryEndpoint)
+	}
 
 	// builds the operator's configuration
 	ad, err := autodetect.New(restConfig)
@@ -160,44 +440,88 @@ func main() {
 		os.Exit(1)
 	}
 
-	cfg := config.New(
-		config.WithLogger(ctrl.Log.WithName("config")),
-		config.WithVersion(v),
-		config.WithCollectorImage(collectorImage),
-		config.WithTargetAllocatorImage(targetAllocatorImage),
-		config.WithOperatorOpAMPBridgeImage(operatorOpAMPBridgeImage),
-		config.WithAutoInstrumentationJavaImage(autoInstrumentationJava),
-		config.WithAutoInstrumentationNodeJSImage(autoInstrumentationNodeJS),
-		config.WithAutoInstrumentationPythonImage(autoInstrumentationPython),
-		config.WithAutoInstrumentationDotNetImage(autoInstrumentationDotNet),
-		config.WithAutoInstrumentationGoImage(autoInstrumentationGo),
-		config.WithAutoInstrumentationApacheHttpdImage(autoInstrumentationApacheHttpd),
-		config.WithAutoInstrumentationNginxImage(autoInstrumentationNginx),
-		config.WithAutoDetect(ad),
-		config.WithLabelFilters(labelsFilter),
-	)
+	if len(watchNamespaces) == 0 {
+		if watchNamespace, found := os.LookupEnv("WATCH_NAMESPACE"); found && watchNamespace != "" {
+			watchNamespaces = strings.Split(watchNamespace, ",")
+		}
+	}
+	for i := range watchNamespaces {
+		watchNamespaces[i] = strings.TrimSpace(watchNamespaces[i])
+	}
+	cfg := config.New(append(cfgOpts, config.WithAutoDetect(ad), config.WithWatchNamespaces(watchNamespaces))...)
 
-	watchNamespace, found := os.LookupEnv("WATCH_NAMESPACE")
-	if found {
-		setupLog.Info("watching namespace(s)", "namespaces", watchNamespace)
+	if len(watchNamespaces) > 0 {
+		setupLog.Info("watching namespace(s)", "namespaces", watchNamespaces)
 	} else {
-		setupLog.Info("the env var WATCH_NAMESPACE isn't set, watching all namespaces")
+		setupLog.Info("neither --watch-namespaces nor the env var WATCH_NAMESPACE are set, watching all namespaces")
+	}
+	if namespaceScoped {
+		// the objects of the namespaces which aren't watched can't be read without cluster-scoped permissions, and the
+		// self-signed certificates are injected into the cluster-scoped webhook configurations and CRDs
+		if len(watchNamespaces) == 0 {
+			setupLog.Error(fmt.Errorf("the namespace-scoped operator needs the watched namespaces"), "invalid namespace-scoped settings")
+			os.Exit(1)
+		}
+		if selfSignedWebhookCerts {
+			setupLog.Error(fmt.Errorf("the namespace-scoped operator can't inject the self-signed webhook certificates"), "invalid namespace-scoped settings")
+			os.Exit(1)
+		}
+		setupLog.Info("running with namespace-scoped permissions only", "namespaces", watchNamespaces)
+	}
+
+	crSelector, err := labels.Parse(crLabelSelector)
+	if err != nil {
+		setupLog.Error(err, "invalid custom resource label selector", "cr-label-selector", crLabelSelector)
+		os.Exit(1)
+	}
+	if !crSelector.Empty() {
+		setupLog.Info("reconciling only the custom resources matching the label selector", "cr-label-selector", crSelector.String())
 	}
 
-	// see https://github.com/openshift/library-go/blob/4362aa519714a4b62b00ab8318197ba2bba51cb7/pkg/config/leaderelection/leaderelection.go#L104
-	leaseDuration := time.Second * 137
-	renewDeadline := time.Second * 107
-	retryPeriod := time.Second * 26
+	if enableLeaderElection && renewDeadline >= leaseDuration {
+		setupLog.Error(fmt.Errorf("the renew deadline %s must be shorter than the lease duration %s", renewDeadline, leaseDuration), "invalid leader election settings")
+		os.Exit(1)
+	}
 
 	optionsTlSOptsFuncs := []func(*tls.Config){
 		func(config *tls.Config) { tlsConfigSetting(config, tlsOpt) },
 	}
 	var namespaces map[string]cache.Config
-	if strings.Contains(watchNamespace, ",") {
-		namespaces = map[string]cache.Config{}
-		for _, ns := range strings.Split(watchNamespace, ",") {
-			namespaces[ns] = cache.Config{}
+	for _, ns := range watchNamespaces {
+		if namespaces == nil {
+			namespaces = map[string]cache.Config{}
 		}
+		namespaces[ns] = cache.Config{}
+	}
+	// only the child objects managed by the operator are cached, instead of every ConfigMap, Service or Deployment of
+	// the cluster. The other ones, e.g. the objects to adopt, are read from the API server by the reconcilers.
+	managedSelector := labels.SelectorFromSet(labels.Set{"app.kubernetes.io/managed-by": "opentelemetry-operator"})
+	managedObjects := []client.Object{
+		&corev1.ConfigMap{},
+		&corev1.Service{},
+		&corev1.ServiceAccount{},
+		&appsv1.Deployment{},
+		&appsv1.DaemonSet{},
+		&appsv1.StatefulSet{},
+		&autoscalingv2.HorizontalPodAutoscaler{},
+		&policyv1.PodDisruptionBudget{},
+		&networkingv1.NetworkPolicy{},
+	}
+	byObject := map[client.Object]cache.ByObject{}
+	for _, obj := range managedObjects {
+		byObject[obj] = cache.ByObject{Label: managedSelector}
+	}
+	// the pods of the collectors are watched to report their crashes in the status of the collectors
+	byObject[&corev1.Pod{}] = cache.ByObject{Label: labels.SelectorFromSet(labels.Set{
+		"app.kubernetes.io/managed-by": "opentelemetry-operator",
+		"app.kubernetes.io/component":  "opentelemetry-collector",
+	})}
+	// the custom resources not matching the selector are filtered out by the cache, so they are neither
+	// reconciled nor seen by the webhooks and the upgrade routines that read through the manager's client
+	if !crSelector.Empty() {
+		byObject[&otelv1alpha1.OpenTelemetryCollector{}] = cache.ByObject{Label: crSelector}
+		byObject[&otelv1alpha1.OpAMPBridge{}] = cache.ByObject{Label: crSelector}
+		byObject[&otelv1alpha1.Instrumentation{}] = cache.ByObject{Label: crSelector}
 	}
 
 	mgrOptions := ctrl.Options{
@@ -208,26 +532,55 @@ func main() {
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "9f7554c3.opentelemetry.io",
-		LeaseDuration:          &leaseDuration,
-		RenewDeadline:          &renewDeadline,
-		RetryPeriod:            &retryPeriod,
-		PprofBindAddress:       pprofAddr,
-		WebhookServer: webhook.NewServer(webhook.Options{
+		// the process exits right after the manager stops, so the lease can be handed over to another replica at once
+		LeaderElectionReleaseOnCancel: true,
+		LeaseDuration:                 &leaseDuration,
+		RenewDeadline:                 &renewDeadline,
+		RetryPeriod:                   &retryPeriod,
+		PprofBindAddress:              pprofAddr,
+		WebhookServer: telemetry.WebhookServer{Server: webhook.NewServer(webhook.Options{
 			Port:    webhookPort,
+			CertDir: webhookCertDir,
 			TLSOpts: optionsTlSOptsFuncs,
-		}),
+		})},
 		Cache: cache.Options{
 			DefaultNamespaces: namespaces,
+			ByObject:          byObject,
+		},
+		// the pods and their ReplicaSets are read by the webhooks, one at a time, caching every one of them in large
+		// clusters would cost much more memory than the reads. Only the pods of the collectors are cached, for the watch.
+		Client: client.Options{
+			Cache: &client.CacheOptions{
+				DisableFor: []client.Object{&corev1.Pod{}, &appsv1.ReplicaSet{}},
+			},
 		},
 	}
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), mgrOptions)
+	mgr, err := ctrl.NewManager(restConfig, mgrOptions)
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
 	ctx := ctrl.SetupSignalHandler()
+	if cfgFile != nil {
+		err = mgr.Add(allReplicasRunnable{manager.RunnableFunc(func(ctx context.Context) error {
+			cfgFile.Watch(ctx, configFileReloadInterval, reloadableFlags, func(changed, ignored []string) {
+				if len(ignored) > 0 {
+					setupLog.Info("the changed settings are applied once the operator restarts", "settings", ignored)
+				}
+				if len(changed) > 0 {
+					setupLog.Info("the configuration file changed", "settings", changed)
+					cfg.Reload(reloadableOpts()...)
+				}
+			})
+			return nil
+		})})
+		if err != nil {
+			setupLog.Error(err, "failed to watch the configuration file")
+			os.Exit(1)
+		}
+	}
 	err = addDependencies(ctx, mgr, cfg, v)
 	if err != nil {
 		setupLog.Error(err, "failed to add/run bootstrap dependencies to the controller manager")
@@ -235,27 +588,46 @@ func main() {
 	}
 
 	if err = controllers.NewReconciler(controllers.Params{
-		Client:   mgr.GetClient(),
+		Client:   controllers.WithAPIReaderFallback(mgr.GetClient(), mgr.GetAPIReader(), managedObjects...),
 		Log:      ctrl.Log.WithName("controllers").WithName("OpenTelemetryCollector"),
 		Scheme:   mgr.GetScheme(),
 		Config:   cfg,
 		Recorder: mgr.GetEventRecorderFor("opentelemetry-operator"),
+
+		MaxConcurrentReconciles: collectorConcurrency,
+		StatusInterval:          collectorStatusInterval,
+		RateLimiter:             collectorBackoff.rateLimiter(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "OpenTelemetryCollector")
 		os.Exit(1)
 	}
 
 	if err = controllers.NewOpAMPBridgeReconciler(controllers.OpAMPBridgeReconcilerParams{
-		Client:   mgr.GetClient(),
+		Client:   controllers.WithAPIReaderFallback(mgr.GetClient(), mgr.GetAPIReader(), managedObjects...),
 		Log:      ctrl.Log.WithName("controllers").WithName("OpAMPBridge"),
 		Scheme:   mgr.GetScheme(),
 		Config:   cfg,
 		Recorder: mgr.GetEventRecorderFor("opamp-bridge"),
+
+		MaxConcurrentReconciles: opampBridgeConcurrency,
+		RateLimiter:             opampBridgeBackoff.rateLimiter(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "OpAMPBridge")
 		os.Exit(1)
 	}
 
+	if err = controllers.NewInstrumentationReconciler(controllers.InstrumentationReconcilerParams{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("Instrumentation"),
+		Scheme: mgr.GetScheme(),
+
+		MaxConcurrentReconciles: instrumentationConcurrency,
+		RateLimiter:             instrumentationBackoff.rateLimiter(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Instrumentation")
+		os.Exit(1)
+	}
+
 	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
 		if err = otelv1alpha1.SetupCollectorWebhook(mgr, cfg); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "OpenTelemetryCollector")
@@ -278,6 +650,13 @@ func main() {
 			setupLog.Error(err, "unable to create webhook", "webhook", "OpAMPBridge")
 			os.Exit(1)
 		}
+
+		if selfSignedWebhookCerts {
+			if err = provisionWebhookCerts(ctx, mgr, webhookServiceName, webhookCertDir); err != nil {
+				setupLog.Error(err, "unable to provision the webhook certificates")
+				os.Exit(1)
+			}
+		}
 	} else {
 		ctrl.Log.Info("Webhooks are disabled, operator is running an unsupported mode", "ENABLE_WEBHOOKS", "false")
 	}
@@ -291,19 +670,39 @@ func main() {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
+	if err := mgr.AddReadyzCheck("informers", informersSynced(mgr.GetCache())); err != nil {
+		setupLog.Error(err, "unable to set up ready check", "check", "informers")
+		os.Exit(1)
+	}
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		// the webhook server only starts once it loaded its certificate
+		if err := mgr.AddReadyzCheck("webhook", mgr.GetWebhookServer().StartedChecker()); err != nil {
+			setupLog.Error(err, "unable to set up ready check", "check", "webhook")
+			os.Exit(1)
+		}
+	}
 
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctx); err != nil {
+	err = mgr.Start(ctx)
+	if stopTelemetry != nil {
+		// flush the telemetry of the last reconciliations
+		stopCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if stopErr := stopTelemetry(stopCtx); stopErr != nil {
+			setupLog.Error(stopErr, "failed to flush the telemetry")
+		}
+		cancel()
+	}
+	if err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
 }
 
 func addDependencies(_ context.Context, mgr ctrl.Manager, cfg config.Config, v version.Version) error {
-	// run the auto-detect mechanism for the configuration
-	err := mgr.Add(manager.RunnableFunc(func(_ context.Context) error {
+	// run the auto-detect mechanism for the configuration, the webhooks served by every replica rely on it as well
+	err := mgr.Add(allReplicasRunnable{manager.RunnableFunc(func(_ context.Context) error {
 		return cfg.StartAutoDetect()
-	}))
+	})})
 	if err != nil {
 		return fmt.Errorf("failed to start the auto-detect mechanism: %w", err)
 	}
@@ -329,7 +728,7 @@ func addDependencies(_ context.Context, mgr ctrl.Manager, cfg config.Config, v v
 			DefaultAutoInstNodeJS:      cfg.AutoInstrumentationNodeJSImage(),
 			DefaultAutoInstPython:      cfg.AutoInstrumentationPythonImage(),
 			DefaultAutoInstDotNet:      cfg.AutoInstrumentationDotNetImage(),
-			DefaultAutoInstGo:          cfg.AutoInstrumentationDotNetImage(),
+			DefaultAutoInstGo:          cfg.AutoInstrumentationGoImage(),
 			DefaultAutoInstApacheHttpd: cfg.AutoInstrumentationApacheHttpdImage(),
 			DefaultAutoInstNginx:       cfg.AutoInstrumentationNginxImage(),
 			Client:                     mgr.GetClient(),
@@ -343,9 +742,197 @@ func addDependencies(_ context.Context, mgr ctrl.Manager, cfg config.Config, v v
 	return nil
 }
 
+// render writes the manifests built for the custom resources in the given files to stdout, reading stdin when the
+// file is "-" or no file is given.
+func render(cfg config.Config, files []string) error {
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+	for _, file := range files {
+		if err := renderFile(cfg, file); err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+	}
+	return nil
+}
+
+func renderFile(cfg config.Config, file string) error {
+	in := io.ReadCloser(os.Stdin)
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		in = f
+	}
+	defer in.Close()
+	return manifestrender.Render(context.Background(), cfg, scheme, ctrl.Log.WithName("render"), in, os.Stdout)
+}
+
+// provisionWebhookCerts generates the certificates of the webhook server before it starts, and keeps rotating them.
+func provisionWebhookCerts(ctx context.Context, mgr ctrl.Manager, serviceName, certDir string) error {
+	namespace, err := operatorNamespace()
+	if err != nil {
+		return err
+	}
+	// the manager's client can't be used before the manager starts
+	cl, err := client.New(mgr.GetConfig(), client.Options{Scheme: mgr.GetScheme()})
+	if err != nil {
+		return err
+	}
+	provisioner := &certs.Provisioner{
+		Client:                          cl,
+		Logger:                          ctrl.Log.WithName("webhook-certs"),
+		Service:                         types.NamespacedName{Namespace: namespace, Name: serviceName},
+		CertDir:                         certDir,
+		MutatingWebhookConfigurations:   []string{"opentelemetry-operator-mutating-webhook-configuration"},
+		ValidatingWebhookConfigurations: []string{"opentelemetry-operator-validating-webhook-configuration"},
+		CustomResourceDefinitions:       []string{"opentelemetrycollectors.opentelemetry.io", "opampbridges.opentelemetry.io"},
+	}
+	if err := provisioner.Provision(ctx); err != nil {
+		return err
+	}
+	return mgr.Add(provisioner)
+}
+
+// operatorNamespace returns the namespace the operator runs in, from the service account mounted into its pod.
+func operatorNamespace() (string, error) {
+	ns, err := os.ReadFile("/var/run/secrets/kubernetes.io/serviceaccount/namespace")
+	if err != nil {
+		return "", fmt.Errorf("failed to determine the namespace of the operator: %w", err)
+	}
+	return strings.TrimSpace(string(ns)), nil
+}
+
+// informersSynced reports the operator as not ready until the informers of its cache have synced.
+func informersSynced(c cache.Cache) healthz.Checker {
+	return func(req *http.Request) error {
+		ctx, cancel := context.WithTimeout(req.Context(), time.Second)
+		defer cancel()
+		if !c.WaitForCacheSync(ctx) {
+			return errors.New("the informers haven't synced yet")
+		}
+		return nil
+	}
+}
+
 // This function get the option from command argument (tlsConfig), check the validity through k8sapiflag
 // and set the config for webhook server.
 // refer to https://pkg.go.dev/k8s.io/component-base/cli/flag
+// defaultSecurityContext returns the security context the security contexts of the generated containers are defaulted
+// from, nil when no default is set.
+func defaultSecurityContext(runAsNonRoot, readOnlyRootFilesystem, dropAllCapabilities bool) *corev1.SecurityContext {
+	if !runAsNonRoot && !readOnlyRootFilesystem && !dropAllCapabilities {
+		return nil
+	}
+	securityContext := &corev1.SecurityContext{}
+	if runAsNonRoot {
+		securityContext.RunAsNonRoot = &runAsNonRoot
+	}
+	if readOnlyRootFilesystem {
+		securityContext.ReadOnlyRootFilesystem = &readOnlyRootFilesystem
+	}
+	if dropAllCapabilities {
+		securityContext.Capabilities = &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}}
+	}
+	return securityContext
+}
+
+// sidecarMaxResources returns the upper bounds of the resources the pods may set on their sidecar, from the given
+// quantities, the empty ones being unbounded.
+func sidecarMaxResources(cpu, memory string) (corev1.ResourceList, error) {
+	resources := corev1.ResourceList{}
+	for name, value := range map[corev1.ResourceName]string{corev1.ResourceCPU: cpu, corev1.ResourceMemory: memory} {
+		if value == "" {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s bound %q: %w", name, value, err)
+		}
+		resources[name] = quantity
+	}
+	return resources, nil
+}
+
+// parseTolerations parses tolerations in the key[=value][:effect] format of the taints. The tolerations without a
+// value use the Exists operator, the ones without an effect tolerate all the effects.
+func parseTolerations(specs []string) ([]corev1.Toleration, error) {
+	var tolerations []corev1.Toleration
+	for _, spec := range specs {
+		keyValue, effect, _ := strings.Cut(spec, ":")
+		key, value, hasValue := strings.Cut(keyValue, "=")
+		if key == "" {
+			return nil, fmt.Errorf("invalid toleration %q, expected key[=value][:effect]", spec)
+		}
+		toleration := corev1.Toleration{Key: key, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffect(effect)}
+		if hasValue {
+			toleration.Operator = corev1.TolerationOpEqual
+			toleration.Value = value
+		}
+		switch toleration.Effect {
+		case "", corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+		default:
+			return nil, fmt.Errorf("invalid effect %q of the toleration %q", effect, spec)
+		}
+		tolerations = append(tolerations, toleration)
+	}
+	return tolerations, nil
+}
+
+// parseNodeSelector parses the key=value labels of a node selector.
+func parseNodeSelector(labels []string) (map[string]string, error) {
+	if len(labels) == 0 {
+		return nil, nil
+	}
+	nodeSelector := map[string]string{}
+	for _, label := range labels {
+		key, value, found := strings.Cut(label, "=")
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid node selector label %q, expected key=value", label)
+		}
+		nodeSelector[key] = value
+	}
+	return nodeSelector, nil
+}
+
+// parseResourceList parses the name=quantity pairs of a resource list.
+func parseResourceList(pairs []string) (corev1.ResourceList, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	resources := corev1.ResourceList{}
+	for _, pair := range pairs {
+		name, value, found := strings.Cut(pair, "=")
+		if !found || name == "" {
+			return nil, fmt.Errorf("invalid resource %q, expected name=quantity", pair)
+		}
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quantity of the resource %q: %w", name, err)
+		}
+		resources[corev1.ResourceName(name)] = quantity
+	}
+	return resources, nil
+}
+
+// imageDigestResolver returns the resolver pinning the images to the given image=digest pairs, and to the digests of
+// their registries when resolve is set. It returns nil when the images aren't pinned.
+func imageDigestResolver(pairs []string, resolve bool) (*imagedigest.Resolver, error) {
+	if len(pairs) == 0 && !resolve {
+		return nil, nil
+	}
+	mapping := map[string]string{}
+	for _, pair := range pairs {
+		image, digest, found := strings.Cut(pair, "=")
+		if !found || image == "" {
+			return nil, fmt.Errorf("invalid image digest %q, expected image=digest", pair)
+		}
+		mapping[image] = digest
+	}
+	return imagedigest.New(ctrl.Log.WithName("image-digests"), mapping, resolve)
+}
+
 func tlsConfigSetting(cfg *tls.Config, tlsOpt tlsConfig) {
 	// TLSVersion helper function returns the TLS Version ID for the version name passed.
 	tlsVersion, err := k8sapiflag.TLSVersion(tlsOpt.minVersion)
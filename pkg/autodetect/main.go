@@ -16,7 +16,13 @@
 package autodetect
 
 import (
+	"context"
+	"encoding/json"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/discovery"
+	authorizationv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
 	"k8s.io/client-go/rest"
 )
 
@@ -25,10 +31,15 @@ var _ AutoDetect = (*autoDetect)(nil)
 // AutoDetect provides an assortment of routines that auto-detect traits based on the runtime.
 type AutoDetect interface {
 	OpenShiftRoutesAvailability() (OpenShiftRoutesAvailability, error)
+	Platform() (Platform, error)
+	OpenShiftVersion() (string, error)
+	RBACPermissions() (RBACPermissions, error)
+	ServiceMesh() (ServiceMesh, error)
 }
 
 type autoDetect struct {
 	dcl discovery.DiscoveryInterface
+	acl authorizationv1client.SelfSubjectAccessReviewsGetter
 }
 
 // New creates a new auto-detection worker, using the given client when talking to the current cluster.
@@ -40,9 +51,14 @@ func New(restConfig *rest.Config) (AutoDetect, error) {
 		// but let's handle this error anyway...
 		return nil, err
 	}
+	acl, err := authorizationv1client.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
 
 	return &autoDetect{
 		dcl: dcl,
+		acl: acl,
 	}, nil
 }
 
@@ -62,3 +78,82 @@ func (a *autoDetect) OpenShiftRoutesAvailability() (OpenShiftRoutesAvailability,
 
 	return OpenShiftRoutesNotAvailable, nil
 }
+
+// Platform checks whether the cluster is an OpenShift cluster, based on the availability of the OpenShift config API.
+func (a *autoDetect) Platform() (Platform, error) {
+	apiList, err := a.dcl.ServerGroups()
+	if err != nil {
+		return PlatformKubernetes, err
+	}
+
+	for _, group := range apiList.Groups {
+		if group.Name == "config.openshift.io" {
+			return PlatformOpenShift, nil
+		}
+	}
+
+	return PlatformKubernetes, nil
+}
+
+// OpenShiftVersion returns the version the OpenShift cluster is being upgraded to, or runs if there's no ongoing
+// upgrade, as reported by the ClusterVersion resource.
+func (a *autoDetect) OpenShiftVersion() (string, error) {
+	raw, err := a.dcl.RESTClient().Get().AbsPath("/apis/config.openshift.io/v1/clusterversions/version").DoRaw(context.Background())
+	if err != nil {
+		return "", err
+	}
+
+	var clusterVersion struct {
+		Status struct {
+			Desired struct {
+				Version string `json:"version"`
+			} `json:"desired"`
+		} `json:"status"`
+	}
+	if err := json.Unmarshal(raw, &clusterVersion); err != nil {
+		return "", err
+	}
+	return clusterVersion.Status.Desired.Version, nil
+}
+
+// RBACPermissions checks whether the operator is allowed to create ClusterRoles and ClusterRoleBindings, so that it can
+// grant the components it manages the permissions they need.
+func (a *autoDetect) RBACPermissions() (RBACPermissions, error) {
+	for _, resource := range []string{"clusterroles", "clusterrolebindings"} {
+		review, err := a.acl.SelfSubjectAccessReviews().Create(context.Background(), &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Verb:     "create",
+					Group:    "rbac.authorization.k8s.io",
+					Resource: resource,
+				},
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return RBACPermissionsNotAvailable, err
+		}
+		if !review.Status.Allowed {
+			return RBACPermissionsNotAvailable, nil
+		}
+	}
+	return RBACPermissionsAvailable, nil
+}
+
+// ServiceMesh checks whether Istio or Linkerd is installed in the cluster, based on the availability of their APIs.
+func (a *autoDetect) ServiceMesh() (ServiceMesh, error) {
+	apiList, err := a.dcl.ServerGroups()
+	if err != nil {
+		return ServiceMeshNone, err
+	}
+
+	for _, group := range apiList.Groups {
+		switch group.Name {
+		case "networking.istio.io":
+			return ServiceMeshIstio, nil
+		case "linkerd.io":
+			return ServiceMeshLinkerd, nil
+		}
+	}
+
+	return ServiceMeshNone, nil
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/rest"
 
@@ -70,3 +71,151 @@ func TestDetectPlatformBasedOnAvailableAPIGroups(t *testing.T) {
 		assert.Equal(t, tt.expected, ora)
 	}
 }
+
+func TestDetectOpenShiftPlatformAndVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var output []byte
+		var err error
+		switch req.URL.Path {
+		case "/api":
+			output, err = json.Marshal(&metav1.APIVersions{})
+		case "/apis":
+			output, err = json.Marshal(&metav1.APIGroupList{
+				Groups: []metav1.APIGroup{{Name: "config.openshift.io"}},
+			})
+		case "/apis/config.openshift.io/v1/clusterversions/version":
+			output = []byte(`{"kind":"ClusterVersion","status":{"desired":{"version":"4.14.1"}}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		require.NoError(t, err)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err = w.Write(output)
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	autoDetect, err := autodetect.New(&rest.Config{Host: server.URL})
+	require.NoError(t, err)
+
+	// test
+	plt, err := autoDetect.Platform()
+	require.NoError(t, err)
+	version, err := autoDetect.OpenShiftVersion()
+	require.NoError(t, err)
+
+	// verify
+	assert.Equal(t, autodetect.PlatformOpenShift, plt)
+	assert.Equal(t, "4.14.1", version)
+}
+
+func TestDetectRBACPermissions(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		allowed  map[string]bool
+		expected autodetect.RBACPermissions
+	}{
+		{
+			desc:     "allowed",
+			allowed:  map[string]bool{"clusterroles": true, "clusterrolebindings": true},
+			expected: autodetect.RBACPermissionsAvailable,
+		},
+		{
+			desc:     "bindings not allowed",
+			allowed:  map[string]bool{"clusterroles": true},
+			expected: autodetect.RBACPermissionsNotAvailable,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				if req.URL.Path != "/apis/authorization.k8s.io/v1/selfsubjectaccessreviews" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				review := &authorizationv1.SelfSubjectAccessReview{}
+				require.NoError(t, json.NewDecoder(req.Body).Decode(review))
+				review.Status.Allowed = tt.allowed[review.Spec.ResourceAttributes.Resource]
+				output, err := json.Marshal(review)
+				require.NoError(t, err)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusCreated)
+				_, err = w.Write(output)
+				require.NoError(t, err)
+			}))
+			defer server.Close()
+
+			autoDetect, err := autodetect.New(&rest.Config{Host: server.URL})
+			require.NoError(t, err)
+
+			// test
+			rbac, err := autoDetect.RBACPermissions()
+
+			// verify
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, rbac)
+		})
+	}
+}
+
+func TestDetectServiceMesh(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		group    string
+		expected autodetect.ServiceMesh
+	}{
+		{
+			desc:     "none",
+			group:    "apps",
+			expected: autodetect.ServiceMeshNone,
+		},
+		{
+			desc:     "istio",
+			group:    "networking.istio.io",
+			expected: autodetect.ServiceMeshIstio,
+		},
+		{
+			desc:     "linkerd",
+			group:    "linkerd.io",
+			expected: autodetect.ServiceMeshLinkerd,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				var output []byte
+				var err error
+				switch req.URL.Path {
+				case "/api":
+					output, err = json.Marshal(&metav1.APIVersions{})
+				case "/apis":
+					output, err = json.Marshal(&metav1.APIGroupList{
+						Groups: []metav1.APIGroup{{Name: tt.group}},
+					})
+				default:
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				require.NoError(t, err)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				_, err = w.Write(output)
+				require.NoError(t, err)
+			}))
+			defer server.Close()
+
+			autoDetect, err := autodetect.New(&rest.Config{Host: server.URL})
+			require.NoError(t, err)
+
+			// test
+			mesh, err := autoDetect.ServiceMesh()
+
+			// verify
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, mesh)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autodetect
+
+// Platform holds the auto-detected platform the operator runs on.
+type Platform int
+
+const (
+	// PlatformKubernetes represents a vanilla Kubernetes cluster.
+	PlatformKubernetes Platform = iota
+
+	// PlatformOpenShift represents an OpenShift cluster.
+	PlatformOpenShift
+)
+
+func (p Platform) String() string {
+	return [...]string{"Kubernetes", "OpenShift"}[p]
+}
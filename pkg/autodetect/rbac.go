@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autodetect
+
+// RBACPermissions holds whether the operator is allowed to create the RBAC objects needed by the components it manages.
+type RBACPermissions int
+
+const (
+	// RBACPermissionsNotAvailable represents an operator which can't create ClusterRoles and ClusterRoleBindings.
+	RBACPermissionsNotAvailable RBACPermissions = iota
+
+	// RBACPermissionsAvailable represents an operator which can create ClusterRoles and ClusterRoleBindings.
+	RBACPermissionsAvailable
+)
+
+func (p RBACPermissions) String() string {
+	return [...]string{"NotAvailable", "Available"}[p]
+}
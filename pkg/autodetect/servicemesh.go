@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autodetect
+
+// ServiceMesh holds the auto-detected service mesh installed in the cluster.
+type ServiceMesh int
+
+const (
+	// ServiceMeshNone represents a cluster without a known service mesh.
+	ServiceMeshNone ServiceMesh = iota
+
+	// ServiceMeshIstio represents a cluster running Istio.
+	ServiceMeshIstio
+
+	// ServiceMeshLinkerd represents a cluster running Linkerd.
+	ServiceMeshLinkerd
+)
+
+func (m ServiceMesh) String() string {
+	return [...]string{"None", "Istio", "Linkerd"}[m]
+}
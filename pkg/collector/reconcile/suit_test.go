@@ -215,7 +215,7 @@ func paramsWithMode(mode v1alpha1.Mode) manifests.Params {
 			},
 			Spec: v1alpha1.OpenTelemetryCollectorSpec{
 				Image: "ghcr.io/open-telemetry/opentelemetry-operator/opentelemetry-operator:0.47.0",
-				Ports: []v1.ServicePort{{
+				Ports: []v1alpha1.PortsSpec{{ServicePort: v1.ServicePort{
 					Name: "web",
 					Port: 80,
 					TargetPort: intstr.IntOrString{
@@ -223,7 +223,7 @@ func paramsWithMode(mode v1alpha1.Mode) manifests.Params {
 						IntVal: 80,
 					},
 					NodePort: 0,
-				}},
+				}}},
 				Replicas: &replicas,
 				Config:   string(configYAML),
 				Mode:     mode,
@@ -266,7 +266,7 @@ func newParams(taContainerImage string, file string) (manifests.Params, error) {
 			},
 			Spec: v1alpha1.OpenTelemetryCollectorSpec{
 				Mode: v1alpha1.ModeStatefulSet,
-				Ports: []v1.ServicePort{{
+				Ports: []v1alpha1.PortsSpec{{ServicePort: v1.ServicePort{
 					Name: "web",
 					Port: 80,
 					TargetPort: intstr.IntOrString{
@@ -274,7 +274,7 @@ func newParams(taContainerImage string, file string) (manifests.Params, error) {
 						IntVal: 80,
 					},
 					NodePort: 0,
-				}},
+				}}},
 				TargetAllocator: v1alpha1.OpenTelemetryTargetAllocator{
 					Enabled: true,
 					Image:   taContainerImage,
@@ -19,6 +19,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"time"
 
 	semver "github.com/Masterminds/semver/v3"
 	"github.com/go-logr/logr"
@@ -26,6 +27,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/status/conditions"
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
 )
 
@@ -61,15 +63,17 @@ func (u VersionUpgrade) ManagedInstances(ctx context.Context) error {
 			continue
 		}
 
-		if original.Spec.UpgradeStrategy == v1alpha1.UpgradeStrategyNone {
-			itemLogger.Info("skipping instance upgrade due to UpgradeStrategy")
-			continue
-		}
 		upgraded, err := u.ManagedInstance(ctx, original)
 		if err != nil {
 			const msg = "automated update not possible. Configuration must be corrected manually and CR instance must be re-created."
 			itemLogger.Info(msg)
 			u.Recorder.Event(&original, "Error", "Upgrade", msg)
+			// keep the condition telling why the instance couldn't be migrated, without the partially applied changes
+			failed := original.DeepCopy()
+			failed.Status.Conditions = upgraded.Status.Conditions
+			if err := u.Client.Status().Patch(ctx, failed, client.MergeFrom(&original)); err != nil {
+				itemLogger.Error(err, "failed to apply changes to instance's status object")
+			}
 			continue
 		}
 
@@ -101,6 +105,7 @@ func (u VersionUpgrade) ManagedInstances(ctx context.Context) error {
 }
 
 // ManagedInstance performs the necessary changes to bring the given otelcol instance to the current version.
+// The outcome is recorded in the Upgraded condition of the instance, so that skipped or failed migrations are visible.
 func (u VersionUpgrade) ManagedInstance(ctx context.Context, otelcol v1alpha1.OpenTelemetryCollector) (v1alpha1.OpenTelemetryCollector, error) {
 	// this is likely a new instance, assume it's already up to date
 	if otelcol.Status.Version == "" {
@@ -110,6 +115,7 @@ func (u VersionUpgrade) ManagedInstance(ctx context.Context, otelcol v1alpha1.Op
 	instanceV, err := semver.NewVersion(otelcol.Status.Version)
 	if err != nil {
 		u.Log.Error(err, "failed to parse version for OpenTelemetry Collector instance", "name", otelcol.Name, "namespace", otelcol.Namespace, "version", otelcol.Status.Version)
+		setUpgraded(&otelcol, false, conditions.ReasonUnsupportedVersion, fmt.Sprintf("the version %q can't be parsed, the instance can't be migrated", otelcol.Status.Version))
 		return otelcol, err
 	}
 
@@ -121,22 +127,37 @@ func (u VersionUpgrade) ManagedInstance(ctx context.Context, otelcol v1alpha1.Op
 		if err != nil {
 			return otelcol, err
 		}
-		if instanceV.LessThan(otelColV) {
+		switch {
+		case instanceV.GreaterThan(otelColV):
+			// the instance was managed by a newer operator, there's no migration path back to our version
+			u.Log.Info("skipping upgrade for OpenTelemetry Collector instance newer than the operator", "name", otelcol.Name, "namespace", otelcol.Namespace, "version", otelcol.Status.Version, "operator", otelColV.String())
+			setUpgraded(&otelcol, false, conditions.ReasonUnsupportedVersion, fmt.Sprintf("the version %s is newer than the version %s managed by the operator, downgrades aren't supported", instanceV, otelColV))
+		case instanceV.LessThan(otelColV):
+			if !u.allowed(&otelcol) {
+				return otelcol, nil
+			}
 			u.Log.Info("upgraded OpenTelemetry Collector version", "name", otelcol.Name, "namespace", otelcol.Namespace, "version", otelcol.Status.Version)
 			otelcol.Status.Version = u.Version.OpenTelemetryCollector
-		} else {
+			setUpgraded(&otelcol, true, conditions.ReasonUpToDate, fmt.Sprintf("upgraded from version %s", instanceV))
+		default:
 			u.Log.Info("skipping upgrade for OpenTelemetry Collector instance", "name", otelcol.Name, "namespace", otelcol.Namespace)
+			setUpgraded(&otelcol, true, conditions.ReasonUpToDate, fmt.Sprintf("the instance is at version %s", instanceV))
 		}
 
 		return otelcol, nil
 	}
 
+	if !u.allowed(&otelcol) {
+		return otelcol, nil
+	}
+
 	for _, available := range versions {
 		if available.GreaterThan(instanceV) {
 			upgraded, err := available.upgrade(u, &otelcol) //available.upgrade(params., &otelcol)
 
 			if err != nil {
 				u.Log.Error(err, "failed to upgrade managed otelcol instances", "name", otelcol.Name, "namespace", otelcol.Namespace)
+				setUpgraded(&otelcol, false, conditions.ReasonUpgradeFailed, fmt.Sprintf("the migration from version %s to %s failed: %s", otelcol.Status.Version, available.String(), err))
 				return otelcol, err
 			}
 
@@ -147,7 +168,28 @@ func (u VersionUpgrade) ManagedInstance(ctx context.Context, otelcol v1alpha1.Op
 	}
 	// Update with the latest known version, which is what we have from versions.txt
 	otelcol.Status.Version = u.Version.OpenTelemetryCollector
+	setUpgraded(&otelcol, true, conditions.ReasonUpToDate, fmt.Sprintf("upgraded from version %s", instanceV))
 
 	u.Log.V(1).Info("final version", "name", otelcol.Name, "namespace", otelcol.Namespace, "version", otelcol.Status.Version)
 	return otelcol, nil
 }
+
+// allowed tells whether the upgrade strategy and windows of the instance allow migrating it now, recording the
+// skipped migration in the instance's conditions otherwise.
+func (u VersionUpgrade) allowed(otelcol *v1alpha1.OpenTelemetryCollector) bool {
+	if otelcol.Spec.UpgradeStrategy == v1alpha1.UpgradeStrategyNone {
+		u.Log.Info("skipping instance upgrade due to UpgradeStrategy", "name", otelcol.Name, "namespace", otelcol.Namespace, "version", otelcol.Status.Version)
+		setUpgraded(otelcol, false, conditions.ReasonUpgradeSkipped, fmt.Sprintf("the instance at version %s isn't migrated as its upgrade strategy is '%s'", otelcol.Status.Version, v1alpha1.UpgradeStrategyNone))
+		return false
+	}
+	if !v1alpha1.UpgradeAllowed(otelcol.Spec.UpgradeWindows, time.Now()) {
+		u.Log.Info("skipping instance upgrade outside of the upgrade windows", "name", otelcol.Name, "namespace", otelcol.Namespace, "version", otelcol.Status.Version)
+		setUpgraded(otelcol, false, conditions.ReasonUpgradeSkipped, fmt.Sprintf("the migration of the instance at version %s is deferred to the next upgrade window", otelcol.Status.Version))
+		return false
+	}
+	return true
+}
+
+func setUpgraded(otelcol *v1alpha1.OpenTelemetryCollector, upgraded bool, reason, message string) {
+	conditions.SetUpgraded(&otelcol.Status.Conditions, otelcol.Generation, upgraded, reason, message)
+}
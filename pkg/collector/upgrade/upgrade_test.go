@@ -17,15 +17,18 @@ package upgrade_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/status/conditions"
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/upgrade"
 )
@@ -116,6 +119,77 @@ func TestUpgradeUpToLatestKnownVersion(t *testing.T) {
 	}
 }
 
+func TestUpgradeSkippedOutsideUpgradeWindows(t *testing.T) {
+	// prepare
+	nsn := types.NamespacedName{Name: "my-instance", Namespace: "default"}
+	existing := makeOtelcol(nsn, v1alpha1.ManagementStateManaged)
+	existing.Status.Version = "0.8.0"
+	existing.Spec.UpgradeWindows = []v1alpha1.UpgradeWindow{
+		// once a year, for a second
+		{Schedule: "0 0 1 1 *", Duration: metav1.Duration{Duration: time.Second}},
+	}
+
+	up := &upgrade.VersionUpgrade{
+		Log:      logger,
+		Version:  version.Get(),
+		Client:   k8sClient,
+		Recorder: record.NewFakeRecorder(upgrade.RecordBufferSize),
+	}
+
+	// test
+	res, err := up.ManagedInstance(context.Background(), existing)
+
+	// verify
+	assert.NoError(t, err)
+	assert.Equal(t, "0.8.0", res.Status.Version)
+	cond := meta.FindStatusCondition(res.Status.Conditions, v1alpha1.ConditionTypeUpgraded)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, conditions.ReasonUpgradeSkipped, cond.Reason)
+}
+
+func TestUpgradedCondition(t *testing.T) {
+	for _, tt := range []struct {
+		desc           string
+		v              string
+		strategy       v1alpha1.UpgradeStrategy
+		expectedStatus metav1.ConditionStatus
+		expectedReason string
+	}{
+		{"upgraded", "0.8.0", v1alpha1.UpgradeStrategyAutomatic, metav1.ConditionTrue, conditions.ReasonUpToDate},
+		{"up-to-date", upgrade.Latest.String(), v1alpha1.UpgradeStrategyAutomatic, metav1.ConditionTrue, conditions.ReasonUpToDate},
+		{"upgrade-strategy-none", "0.8.0", v1alpha1.UpgradeStrategyNone, metav1.ConditionFalse, conditions.ReasonUpgradeSkipped},
+		{"newer-than-the-operator", "100.0.0", v1alpha1.UpgradeStrategyAutomatic, metav1.ConditionFalse, conditions.ReasonUnsupportedVersion},
+		{"unparseable", "unparseable", v1alpha1.UpgradeStrategyAutomatic, metav1.ConditionFalse, conditions.ReasonUnsupportedVersion},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			nsn := types.NamespacedName{Name: "my-instance", Namespace: "default"}
+			existing := makeOtelcol(nsn, v1alpha1.ManagementStateManaged)
+			existing.Spec.UpgradeStrategy = tt.strategy
+			existing.Status.Version = tt.v
+
+			currentV := version.Get()
+			currentV.OpenTelemetryCollector = upgrade.Latest.String()
+			up := &upgrade.VersionUpgrade{
+				Log:      logger,
+				Version:  currentV,
+				Client:   k8sClient,
+				Recorder: record.NewFakeRecorder(upgrade.RecordBufferSize),
+			}
+
+			// test
+			res, _ := up.ManagedInstance(context.Background(), existing)
+
+			// verify
+			cond := meta.FindStatusCondition(res.Status.Conditions, v1alpha1.ConditionTypeUpgraded)
+			require.NotNil(t, cond)
+			assert.Equal(t, tt.expectedStatus, cond.Status)
+			assert.Equal(t, tt.expectedReason, cond.Reason)
+		})
+	}
+}
+
 func TestVersionsShouldNotBeChanged(t *testing.T) {
 	nsn := types.NamespacedName{Name: "my-instance", Namespace: "default"}
 	for _, tt := range []struct {
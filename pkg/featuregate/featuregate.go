@@ -90,6 +90,34 @@ var (
 		featuregate.WithRegisterDescription("enables features associated to the Prometheus Operator"),
 		featuregate.WithRegisterFromVersion("v0.82.0"),
 	)
+
+	// EnableNativeSidecarContainers is the feature gate that controls whether the collector sidecar is injected as a
+	// native sidecar, an init container with the restart policy Always, which requires Kubernetes 1.28 or later.
+	EnableNativeSidecarContainers = featuregate.GlobalRegistry().MustRegister(
+		"operator.sidecarcontainers.native",
+		featuregate.StageAlpha,
+		featuregate.WithRegisterDescription("controls whether the operator injects the collector sidecar as a native sidecar container"),
+		featuregate.WithRegisterFromVersion("v0.89.0"),
+	)
+
+	// EnableServerSideApply is the feature gate that controls whether the child resources are reconciled with
+	// server-side apply, reverting manual changes to the fields owned by the operator.
+	EnableServerSideApply = featuregate.GlobalRegistry().MustRegister(
+		"operator.serversideapply",
+		featuregate.StageAlpha,
+		featuregate.WithRegisterDescription("controls whether the operator reconciles the child resources with server-side apply"),
+		featuregate.WithRegisterFromVersion("v0.89.0"),
+	)
+
+	// EnableHashedLongNames is the feature gate that controls whether the names of the child resources exceeding 63
+	// characters are shortened with a hash of the whole name, instead of being cut. Enabling it renames the child
+	// resources of the existing instances with such long names.
+	EnableHashedLongNames = featuregate.GlobalRegistry().MustRegister(
+		"operator.naming.hashlongnames",
+		featuregate.StageAlpha,
+		featuregate.WithRegisterDescription("controls whether the operator shortens the long names of the child resources with a hash"),
+		featuregate.WithRegisterFromVersion("v0.89.0"),
+	)
 )
 
 // Flags creates a new FlagSet that represents the available featuregate flags using the supplied featuregate registry.
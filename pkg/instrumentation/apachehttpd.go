@@ -57,6 +57,9 @@ const (
 	4) Create on the same volume a configuration file for OpenTelemetry module
 	5) Copy OpenTelemetry module from second init container (instrumentation image) to another shared volume
 	6) Inject mounting of volumes / files into appropriate directories in application container
+
+	When the config clone is skipped, steps 1) and 3) are omitted and the configuration file for OpenTelemetry module
+	is created next to the module, the application image is then responsible for including it.
 */
 
 func injectApacheHttpdagent(_ logr.Logger, apacheSpec v1alpha1.ApacheHttpd, pod corev1.Pod, index int, otlpEndpoint string, resourceMap map[string]string) corev1.Pod {
@@ -73,8 +76,8 @@ func injectApacheHttpdagent(_ logr.Logger, apacheSpec v1alpha1.ApacheHttpd, pod
 	}
 
 	// First make a clone of the instrumented container to take the existing Apache configuration from
-	// and create init container from it
-	if isApacheInitContainerMissing(pod, apacheAgentCloneContainerName) {
+	// and create init container from it, unless the image already references the agent configuration
+	if !apacheSpec.SkipConfigClone && isApacheInitContainerMissing(pod, apacheAgentCloneContainerName) {
 		// Inject volume for original Apache configuration
 		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
 			Name: apacheAgentConfigVolume,
@@ -143,22 +146,30 @@ func injectApacheHttpdagent(_ logr.Logger, apacheSpec v1alpha1.ApacheHttpd, pod
 				},
 			}})
 
+		// Without the clone init container the agent volume is not mounted yet into the instrumented container
+		agentInitVolumeMounts := []corev1.VolumeMount{
+			{
+				Name:      apacheAgentVolume,
+				MountPath: apacheAgentDirFull,
+			},
+		}
+		if apacheSpec.SkipConfigClone {
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+				Name:      apacheAgentVolume,
+				MountPath: apacheAgentDirFull,
+			})
+		} else {
+			agentInitVolumeMounts = append(agentInitVolumeMounts, corev1.VolumeMount{
+				Name:      apacheAgentConfigVolume,
+				MountPath: apacheAgentConfDirFull,
+			})
+		}
+
 		pod.Spec.InitContainers = append(pod.Spec.InitContainers, corev1.Container{
 			Name:    apacheAgentInitContainerName,
 			Image:   apacheSpec.Image,
 			Command: []string{"/bin/sh", "-c"},
-			Args: []string{
-				// Copy agent binaries to shared volume
-				"cp -r /opt/opentelemetry/* " + apacheAgentDirFull + " && " +
-					// setup logging configuration from template
-					"export agentLogDir=$(echo \"" + apacheAgentDirFull + "/logs\" | sed 's,/,\\\\/,g') && " +
-					"cat " + apacheAgentDirFull + "/conf/appdynamics_sdk_log4cxx.xml.template | sed 's/__agent_log_dir__/'${agentLogDir}'/g'  > " + apacheAgentDirFull + "/conf/appdynamics_sdk_log4cxx.xml &&" +
-					// Create agent configuration file by pasting content of env var to a file
-					"echo \"$" + apacheAttributesEnvVar + "\" > " + apacheAgentConfDirFull + "/" + apacheAgentConfigFile + " && " +
-					"sed -i 's/" + apacheServiceInstanceId + "/'${" + apacheServiceInstanceIdEnvVar + "}'/g' " + apacheAgentConfDirFull + "/" + apacheAgentConfigFile + " && " +
-					// Include a link to include Apache agent configuration file into httpd.conf
-					"echo 'Include " + getApacheConfDir(apacheSpec.ConfigPath) + "/" + apacheAgentConfigFile + "' >> " + apacheAgentConfDirFull + "/" + apacheConfigFile,
-			},
+			Args:    []string{getApacheAgentInitCommand(apacheSpec)},
 			Env: []corev1.EnvVar{
 				{
 					Name:  apacheAttributesEnvVar,
@@ -172,23 +183,38 @@ func injectApacheHttpdagent(_ logr.Logger, apacheSpec v1alpha1.ApacheHttpd, pod
 					},
 				},
 			},
-			Resources: apacheSpec.Resources,
-			VolumeMounts: []corev1.VolumeMount{
-				{
-					Name:      apacheAgentVolume,
-					MountPath: apacheAgentDirFull,
-				},
-				{
-					Name:      apacheAgentConfigVolume,
-					MountPath: apacheAgentConfDirFull,
-				},
-			},
+			Resources:    apacheSpec.Resources,
+			VolumeMounts: agentInitVolumeMounts,
 		})
 	}
 
 	return pod
 }
 
+// getApacheAgentInitCommand returns the shell command of the agent init container.
+// When the configuration clone is skipped, the agent configuration file is written next to the module
+// and the application image is expected to include it already.
+func getApacheAgentInitCommand(apacheSpec v1alpha1.ApacheHttpd) string {
+	agentConfigFile := apacheAgentConfDirFull + "/" + apacheAgentConfigFile
+	if apacheSpec.SkipConfigClone {
+		agentConfigFile = apacheAgentDirFull + "/" + apacheAgentConfigFile
+	}
+
+	// Copy agent binaries to shared volume
+	command := "cp -r /opt/opentelemetry/* " + apacheAgentDirFull + " && " +
+		// setup logging configuration from template
+		"export agentLogDir=$(echo \"" + apacheAgentDirFull + "/logs\" | sed 's,/,\\\\/,g') && " +
+		"cat " + apacheAgentDirFull + "/conf/appdynamics_sdk_log4cxx.xml.template | sed 's/__agent_log_dir__/'${agentLogDir}'/g'  > " + apacheAgentDirFull + "/conf/appdynamics_sdk_log4cxx.xml &&" +
+		// Create agent configuration file by pasting content of env var to a file
+		"echo \"$" + apacheAttributesEnvVar + "\" > " + agentConfigFile + " && " +
+		"sed -i 's/" + apacheServiceInstanceId + "/'${" + apacheServiceInstanceIdEnvVar + "}'/g' " + agentConfigFile
+	if !apacheSpec.SkipConfigClone {
+		// Include a link to include Apache agent configuration file into httpd.conf
+		command += " && echo 'Include " + getApacheConfDir(apacheSpec.ConfigPath) + "/" + apacheAgentConfigFile + "' >> " + apacheAgentConfDirFull + "/" + apacheConfigFile
+	}
+	return command
+}
+
 // Calculate if we already inject InitContainers.
 func isApacheInitContainerMissing(pod corev1.Pod, containerName string) bool {
 	for _, initContainer := range pod.Spec.InitContainers {
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// findConflictingJavaAgent returns the JAVA_TOOL_OPTIONS value when it already attaches a javaagent.
+func findConflictingJavaAgent(container corev1.Container) string {
+	idx := getIndexOfEnv(container.Env, envJavaToolsOptions)
+	if idx == -1 {
+		return ""
+	}
+	value := container.Env[idx].Value
+	if strings.Contains(value, "-javaagent:") && !strings.Contains(value, javaJVMArgument) {
+		return value
+	}
+	return ""
+}
+
+// findConflictingNodeJSAgent returns the NODE_OPTIONS value when it already preloads a module.
+func findConflictingNodeJSAgent(container corev1.Container) string {
+	idx := getIndexOfEnv(container.Env, envNodeOptions)
+	if idx == -1 {
+		return ""
+	}
+	value := container.Env[idx].Value
+	if strings.Contains(value, nodeRequireArgument) {
+		return ""
+	}
+	for _, option := range strings.Fields(value) {
+		if option == "--require" || option == "-r" || strings.HasPrefix(option, "--require=") {
+			return value
+		}
+	}
+	return ""
+}
+
+// findConflictingDotNetAgent returns the CORECLR_PROFILER value when another profiler is already configured.
+func findConflictingDotNetAgent(container corev1.Container) string {
+	idx := getIndexOfEnv(container.Env, envDotNetCoreClrProfiler)
+	if idx == -1 {
+		return ""
+	}
+	value := container.Env[idx].Value
+	if value != "" && !strings.EqualFold(value, dotNetCoreClrProfilerID) {
+		return value
+	}
+	return ""
+}
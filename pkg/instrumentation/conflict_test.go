@@ -0,0 +1,261 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
+)
+
+func TestFindConflictingAgent(t *testing.T) {
+	tests := []struct {
+		name      string
+		find      func(corev1.Container) string
+		container corev1.Container
+		expected  string
+	}{
+		{
+			name:      "java without options",
+			find:      findConflictingJavaAgent,
+			container: corev1.Container{},
+			expected:  "",
+		},
+		{
+			name: "java with options but no agent",
+			find: findConflictingJavaAgent,
+			container: corev1.Container{
+				Env: []corev1.EnvVar{{Name: envJavaToolsOptions, Value: "-Xmx512m"}},
+			},
+			expected: "",
+		},
+		{
+			name: "java with another agent",
+			find: findConflictingJavaAgent,
+			container: corev1.Container{
+				Env: []corev1.EnvVar{{Name: envJavaToolsOptions, Value: "-javaagent:/opt/apm/agent.jar"}},
+			},
+			expected: "-javaagent:/opt/apm/agent.jar",
+		},
+		{
+			name: "java with opentelemetry agent",
+			find: findConflictingJavaAgent,
+			container: corev1.Container{
+				Env: []corev1.EnvVar{{Name: envJavaToolsOptions, Value: javaJVMArgument}},
+			},
+			expected: "",
+		},
+		{
+			name: "nodejs with options but no required module",
+			find: findConflictingNodeJSAgent,
+			container: corev1.Container{
+				Env: []corev1.EnvVar{{Name: envNodeOptions, Value: "--max-old-space-size=4096"}},
+			},
+			expected: "",
+		},
+		{
+			name: "nodejs with another agent",
+			find: findConflictingNodeJSAgent,
+			container: corev1.Container{
+				Env: []corev1.EnvVar{{Name: envNodeOptions, Value: "-r dd-trace/init"}},
+			},
+			expected: "-r dd-trace/init",
+		},
+		{
+			name: "dotnet with another profiler",
+			find: findConflictingDotNetAgent,
+			container: corev1.Container{
+				Env: []corev1.EnvVar{{Name: envDotNetCoreClrProfiler, Value: "{846F5F1C-F9AE-4B07-969E-05C26BC060D8}"}},
+			},
+			expected: "{846F5F1C-F9AE-4B07-969E-05C26BC060D8}",
+		},
+		{
+			name: "dotnet with opentelemetry profiler",
+			find: findConflictingDotNetAgent,
+			container: corev1.Container{
+				Env: []corev1.EnvVar{{Name: envDotNetCoreClrProfiler, Value: dotNetCoreClrProfilerID}},
+			},
+			expected: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.find(test.container))
+		})
+	}
+}
+
+func TestInjectWithConflictPolicy(t *testing.T) {
+	tests := []struct {
+		name           string
+		policy         v1alpha1.ConflictPolicy
+		expectInjected bool
+		expectEvent    bool
+	}{
+		{
+			name:           "inject by default",
+			policy:         "",
+			expectInjected: true,
+			expectEvent:    false,
+		},
+		{
+			name:           "warn",
+			policy:         v1alpha1.ConflictPolicyWarn,
+			expectInjected: true,
+			expectEvent:    true,
+		},
+		{
+			name:           "skip",
+			policy:         v1alpha1.ConflictPolicySkip,
+			expectInjected: false,
+			expectEvent:    true,
+		},
+		{
+			name:           "merge",
+			policy:         v1alpha1.ConflictPolicyMerge,
+			expectInjected: false,
+			expectEvent:    false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			inst := v1alpha1.Instrumentation{
+				Spec: v1alpha1.InstrumentationSpec{
+					ConflictPolicy: test.policy,
+					Java: v1alpha1.Java{
+						Image: "img:1",
+					},
+				},
+			}
+			insts := languageInstrumentations{
+				Java: instrumentationWithContainers{Instrumentation: &inst, Containers: ""},
+			}
+			recorder := record.NewFakeRecorder(10)
+			inj := sdkInjector{
+				logger:   logr.Discard(),
+				recorder: recorder,
+			}
+			pod := inj.inject(context.Background(), insts,
+				corev1.Namespace{},
+				corev1.Pod{
+					// the pods being admitted have no name when it's generated
+					ObjectMeta: metav1.ObjectMeta{GenerateName: "app-7d9c-"},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name:  "app",
+								Image: "app:latest",
+								Env:   []corev1.EnvVar{{Name: envJavaToolsOptions, Value: "-javaagent:/opt/apm/agent.jar"}},
+							},
+						},
+					},
+				})
+
+			assert.Equal(t, test.expectInjected, !isInitContainerMissing(pod, javaInitContainerName))
+			assert.Equal(t, test.expectEvent, len(recorder.Events) == 1)
+			if test.expectEvent {
+				assert.Contains(t, <-recorder.Events, "container app of the pod app-7d9c- is already configured with another agent")
+			}
+		})
+	}
+}
+
+func TestInjectWithMergeConflictPolicy(t *testing.T) {
+	inst := v1alpha1.Instrumentation{
+		Spec: v1alpha1.InstrumentationSpec{
+			ConflictPolicy: v1alpha1.ConflictPolicyMerge,
+			Exporter: v1alpha1.Exporter{
+				Endpoint: "http://collector:4317",
+			},
+			Java: v1alpha1.Java{
+				Image: "img:1",
+				Env: []corev1.EnvVar{
+					{Name: "OTEL_LOGS_EXPORTER", Value: "otlp"},
+					{Name: "OTEL_METRICS_EXPORTER", Value: "otlp"},
+				},
+			},
+		},
+	}
+	insts := languageInstrumentations{
+		Java: instrumentationWithContainers{Instrumentation: &inst, Containers: ""},
+	}
+	recorder := record.NewFakeRecorder(10)
+	inj := sdkInjector{
+		logger:   logr.Discard(),
+		recorder: recorder,
+	}
+	pod := inj.inject(context.Background(), insts,
+		corev1.Namespace{},
+		corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "app"},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name:  "app",
+						Image: "app:latest",
+						Env: []corev1.EnvVar{
+							{Name: envJavaToolsOptions, Value: "-javaagent:/opt/otel/agent.jar"},
+							{Name: "OTEL_METRICS_EXPORTER", Value: "none"},
+							{Name: "OTEL_SERVICE_NAME", Value: "my-app"},
+						},
+					},
+				},
+			},
+		})
+
+	container := pod.Spec.Containers[0]
+	// the existing agent is the only one attached
+	assert.Empty(t, pod.Spec.InitContainers)
+	assert.Empty(t, pod.Spec.Volumes)
+	assert.Empty(t, container.VolumeMounts)
+	assert.Equal(t, "-javaagent:/opt/otel/agent.jar", container.Env[getIndexOfEnv(container.Env, envJavaToolsOptions)].Value)
+	// the environment variables of the container are kept
+	assert.Equal(t, "none", container.Env[getIndexOfEnv(container.Env, "OTEL_METRICS_EXPORTER")].Value)
+	assert.Equal(t, "my-app", container.Env[getIndexOfEnv(container.Env, constants.EnvOTELServiceName)].Value)
+	// and the missing ones are added
+	assert.Equal(t, "otlp", container.Env[getIndexOfEnv(container.Env, "OTEL_LOGS_EXPORTER")].Value)
+	assert.Equal(t, "http://collector:4317", container.Env[getIndexOfEnv(container.Env, constants.EnvOTELExporterOTLPEndpoint)].Value)
+	assert.Empty(t, recorder.Events)
+}
+
+func TestPodDescription(t *testing.T) {
+	isController := true
+	for _, test := range []struct {
+		pod      corev1.Pod
+		expected string
+	}{
+		{pod: corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "app"}}, expected: "pod app"},
+		{
+			pod: corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				GenerateName:    "app-7d9c-",
+				OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "app-7d9c", Controller: &isController}},
+			}},
+			expected: "pod of the ReplicaSet app-7d9c",
+		},
+		{pod: corev1.Pod{ObjectMeta: metav1.ObjectMeta{GenerateName: "app-"}}, expected: "pod app-"},
+	} {
+		assert.Equal(t, test.expected, podDescription(test.pod))
+	}
+}
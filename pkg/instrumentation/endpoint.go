@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
+)
+
+// the OTLP protocols of the receivers the discovered endpoints point to.
+const (
+	otlpProtocolGRPC = "grpc"
+	otlpProtocolHTTP = "http"
+)
+
+// withDiscoveredEndpoint returns the instrumentation with the endpoint of the nearest collector managed by the
+// operator, when the auto discovery is enabled and the endpoint is not set. allowNodeIP is false for the agents
+// which can't resolve the IP of the node from the environment.
+func (i *sdkInjector) withDiscoveredEndpoint(ctx context.Context, otelinst v1alpha1.Instrumentation, ns corev1.Namespace, pod corev1.Pod, protocol string, allowNodeIP bool) v1alpha1.Instrumentation {
+	if !otelinst.Spec.Exporter.AutoDiscovery || otelinst.Spec.Exporter.Endpoint != "" {
+		return otelinst
+	}
+	endpoint, err := i.discoverEndpoint(ctx, ns.Name, pod, protocol, allowNodeIP)
+	if err != nil {
+		i.logger.Error(err, "failed to discover the endpoint of the collector", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
+		return otelinst
+	}
+	if endpoint == "" {
+		i.logger.Info("no collector found to discover the endpoint from, leaving it unset", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name, "protocol", protocol)
+		return otelinst
+	}
+	i.logger.V(1).Info("discovered the endpoint of the collector", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name, "endpoint", endpoint)
+	otelinst.Spec.Exporter.Endpoint = endpoint
+	return otelinst
+}
+
+// discoverEndpoint resolves the OTLP endpoint of the nearest collector: the sidecar of the pod, then a DaemonSet
+// collector, reached on the IP of the node when it uses the network of the node or publishes its receivers on the
+// node, and on its node-local Service otherwise, then the Service of a Deployment or StatefulSet collector of the
+// namespace.
+func (i *sdkInjector) discoverEndpoint(ctx context.Context, namespace string, pod corev1.Pod, protocol string, allowNodeIP bool) (string, error) {
+	// the sidecar is injected before the instrumentation
+	for _, container := range pod.Spec.Containers {
+		if container.Name != naming.Container() {
+			continue
+		}
+		var ports []corev1.ServicePort
+		for _, p := range container.Ports {
+			ports = append(ports, corev1.ServicePort{Name: p.Name, Port: p.ContainerPort})
+		}
+		if port, ok := otlpPort(ports, protocol); ok {
+			return fmt.Sprintf("http://localhost:%d", port), nil
+		}
+	}
+
+	if i.client == nil {
+		return "", nil
+	}
+	if namespace == "" {
+		namespace = pod.Namespace
+	}
+	collectors := v1alpha1.OpenTelemetryCollectorList{}
+	if err := i.client.List(ctx, &collectors, client.InNamespace(namespace)); err != nil {
+		return "", err
+	}
+	sort.Slice(collectors.Items, func(a, b int) bool {
+		return collectors.Items[a].Name < collectors.Items[b].Name
+	})
+	for _, mode := range []v1alpha1.Mode{v1alpha1.ModeDaemonSet, v1alpha1.ModeDeployment, v1alpha1.ModeStatefulSet} {
+		for j := range collectors.Items {
+			otelcol := &collectors.Items[j]
+			if otelcol.Spec.Mode != mode || !otelcol.DeletionTimestamp.IsZero() {
+				continue
+			}
+			port, ok := collectorOTLPPort(i.logger, *otelcol, protocol)
+			if !ok {
+				continue
+			}
+			if hostPort, ok := nodeHostPort(*otelcol, port); ok {
+				if allowNodeIP {
+					return fmt.Sprintf("http://$(%s):%d", constants.EnvNodeIP, hostPort), nil
+				}
+				// the node-local Service of the collector is the fallback, unless it runs on the network of the node
+				if otelcol.Spec.HostNetwork {
+					continue
+				}
+			}
+			return fmt.Sprintf("http://%s.%s.svc:%d", naming.Service(otelcol), otelcol.Namespace, port), nil
+		}
+	}
+	return "", nil
+}
+
+// nodeHostPort returns the port of the node the OTLP port of a DaemonSet collector is published on: the hostPort of
+// the port in the spec, or the port itself when the collector uses the network of the node or publishes its endpoint
+// on the node.
+func nodeHostPort(otelcol v1alpha1.OpenTelemetryCollector, port int32) (int32, bool) {
+	if otelcol.Spec.Mode != v1alpha1.ModeDaemonSet {
+		return 0, false
+	}
+	for _, p := range otelcol.Spec.Ports {
+		if p.Port == port && p.HostPort != 0 {
+			return p.HostPort, true
+		}
+	}
+	if otelcol.Spec.HostNetwork || otelcol.Spec.DaemonSet.NodeLocalEndpoint == v1alpha1.NodeLocalEndpointHostPort {
+		return port, true
+	}
+	return 0, false
+}
+
+// collectorOTLPPort returns the port of the OTLP receiver of the collector for the protocol.
+func collectorOTLPPort(logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector, protocol string) (int32, bool) {
+	config, err := adapters.ConfigFromString(otelcol.Spec.Config)
+	if err != nil {
+		logger.V(1).Info("couldn't parse the configuration of the collector", "otelcol-namespace", otelcol.Namespace, "otelcol-name", otelcol.Name, "error", err)
+		return 0, false
+	}
+	ports, err := adapters.ConfigToReceiverPorts(logger, config)
+	if err != nil {
+		return 0, false
+	}
+	return otlpPort(ports, protocol)
+}
+
+// otlpPort returns the first port of an OTLP receiver for the protocol, the ports of the OTLP receivers being
+// named after the receiver and the protocol, e.g. otlp-grpc or otlp-2-http.
+func otlpPort(ports []corev1.ServicePort, protocol string) (int32, bool) {
+	for _, p := range ports {
+		if strings.HasPrefix(p.Name, "otlp") && strings.HasSuffix(p.Name, "-"+protocol) {
+			return p.Port, true
+		}
+	}
+	return 0, false
+}
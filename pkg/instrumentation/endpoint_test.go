@@ -0,0 +1,234 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestDiscoverEndpoint(t *testing.T) {
+	otlpConfig := `receivers:
+  otlp:
+    protocols:
+      grpc:
+      http:
+        endpoint: 0.0.0.0:4319
+exporters:
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [debug]
+`
+	collector := func(name string, mode v1alpha1.Mode, hostNetwork bool) client.Object {
+		return &v1alpha1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "apps"},
+			Spec: v1alpha1.OpenTelemetryCollectorSpec{
+				Mode:        mode,
+				HostNetwork: hostNetwork,
+				Config:      otlpConfig,
+			},
+		}
+	}
+	sidecar := corev1.Container{
+		Name: "otc-container",
+		Ports: []corev1.ContainerPort{
+			{Name: "otlp-grpc", ContainerPort: 4317},
+			{Name: "otlp-http", ContainerPort: 4318},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		collectors  []client.Object
+		containers  []corev1.Container
+		protocol    string
+		allowNodeIP bool
+		expected    string
+	}{
+		{
+			name:        "sidecar",
+			collectors:  []client.Object{collector("agent", v1alpha1.ModeDaemonSet, true)},
+			containers:  []corev1.Container{{Name: "app"}, sidecar},
+			protocol:    otlpProtocolHTTP,
+			allowNodeIP: true,
+			expected:    "http://localhost:4318",
+		},
+		{
+			name:        "daemonset on the network of the node",
+			collectors:  []client.Object{collector("gateway", v1alpha1.ModeDeployment, false), collector("agent", v1alpha1.ModeDaemonSet, true)},
+			containers:  []corev1.Container{{Name: "app"}},
+			protocol:    otlpProtocolGRPC,
+			allowNodeIP: true,
+			expected:    "http://$(OTEL_NODE_IP):4317",
+		},
+		{
+			name:       "daemonset on the network of the node without the node IP",
+			collectors: []client.Object{collector("gateway", v1alpha1.ModeDeployment, false), collector("agent", v1alpha1.ModeDaemonSet, true)},
+			containers: []corev1.Container{{Name: "app"}},
+			protocol:   otlpProtocolGRPC,
+			expected:   "http://gateway-collector.apps.svc:4317",
+		},
+		{
+			name: "daemonset publishing its endpoint on the node",
+			collectors: []client.Object{collector("gateway", v1alpha1.ModeDeployment, false), &v1alpha1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "apps"},
+				Spec: v1alpha1.OpenTelemetryCollectorSpec{
+					Mode:      v1alpha1.ModeDaemonSet,
+					DaemonSet: v1alpha1.DaemonSetSpec{NodeLocalEndpoint: v1alpha1.NodeLocalEndpointHostPort},
+					Config:    otlpConfig,
+				},
+			}},
+			containers:  []corev1.Container{{Name: "app"}},
+			protocol:    otlpProtocolHTTP,
+			allowNodeIP: true,
+			expected:    "http://$(OTEL_NODE_IP):4319",
+		},
+		{
+			name: "daemonset publishing its endpoint on the node without the node IP",
+			collectors: []client.Object{collector("gateway", v1alpha1.ModeDeployment, false), &v1alpha1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "apps"},
+				Spec: v1alpha1.OpenTelemetryCollectorSpec{
+					Mode:      v1alpha1.ModeDaemonSet,
+					DaemonSet: v1alpha1.DaemonSetSpec{NodeLocalEndpoint: v1alpha1.NodeLocalEndpointHostPort},
+					Config:    otlpConfig,
+				},
+			}},
+			containers: []corev1.Container{{Name: "app"}},
+			protocol:   otlpProtocolHTTP,
+			expected:   "http://agent-collector.apps.svc:4319",
+		},
+		{
+			name: "daemonset with a host port",
+			collectors: []client.Object{&v1alpha1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "apps"},
+				Spec: v1alpha1.OpenTelemetryCollectorSpec{
+					Mode: v1alpha1.ModeDaemonSet,
+					Ports: []v1alpha1.PortsSpec{{
+						HostPort:    14317,
+						ServicePort: corev1.ServicePort{Name: "otlp-grpc", Port: 4317},
+					}},
+					Config: otlpConfig,
+				},
+			}},
+			containers:  []corev1.Container{{Name: "app"}},
+			protocol:    otlpProtocolGRPC,
+			allowNodeIP: true,
+			expected:    "http://$(OTEL_NODE_IP):14317",
+		},
+		{
+			name:        "daemonset service",
+			collectors:  []client.Object{collector("gateway", v1alpha1.ModeDeployment, false), collector("agent", v1alpha1.ModeDaemonSet, false)},
+			containers:  []corev1.Container{{Name: "app"}},
+			protocol:    otlpProtocolHTTP,
+			allowNodeIP: true,
+			expected:    "http://agent-collector.apps.svc:4319",
+		},
+		{
+			name:        "gateway",
+			collectors:  []client.Object{collector("gateway", v1alpha1.ModeStatefulSet, false), collector("sidecar", v1alpha1.ModeSidecar, false)},
+			containers:  []corev1.Container{{Name: "app"}},
+			protocol:    otlpProtocolGRPC,
+			allowNodeIP: true,
+			expected:    "http://gateway-collector.apps.svc:4317",
+		},
+		{
+			name:        "no collector",
+			containers:  []corev1.Container{{Name: "app"}},
+			protocol:    otlpProtocolGRPC,
+			allowNodeIP: true,
+			expected:    "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// prepare
+			inj := sdkInjector{
+				client: fake.NewClientBuilder().WithScheme(testScheme).WithObjects(tt.collectors...).Build(),
+				logger: logr.Discard(),
+			}
+			pod := corev1.Pod{Spec: corev1.PodSpec{Containers: tt.containers}}
+
+			// test
+			endpoint, err := inj.discoverEndpoint(context.Background(), "apps", pod, tt.protocol, tt.allowNodeIP)
+
+			// verify
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, endpoint)
+		})
+	}
+}
+
+func TestInjectDiscoveredEndpoint(t *testing.T) {
+	// prepare
+	otelcol := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "apps"},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:        v1alpha1.ModeDaemonSet,
+			HostNetwork: true,
+			Config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+exporters:
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [debug]
+`,
+		},
+	}
+	inst := v1alpha1.Instrumentation{
+		Spec: v1alpha1.InstrumentationSpec{
+			Exporter: v1alpha1.Exporter{AutoDiscovery: true},
+		},
+	}
+	inj := sdkInjector{
+		client: fake.NewClientBuilder().WithScheme(testScheme).WithObjects(otelcol).Build(),
+		logger: logr.Discard(),
+	}
+	insts := languageInstrumentations{
+		Sdk: instrumentationWithContainers{Instrumentation: &inst, Containers: ""},
+	}
+
+	// test
+	pod := inj.inject(context.Background(), insts, corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "apps"}},
+		corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}}})
+
+	// verify
+	env := pod.Spec.Containers[0].Env
+	nodeIP := getIndexOfEnv(env, "OTEL_NODE_IP")
+	endpoint := getIndexOfEnv(env, "OTEL_EXPORTER_OTLP_ENDPOINT")
+	require.NotEqual(t, -1, nodeIP)
+	require.NotEqual(t, -1, endpoint)
+	assert.Less(t, nodeIP, endpoint, "the node IP must be defined before the endpoint referencing it")
+	assert.Equal(t, "status.hostIP", env[nodeIP].ValueFrom.FieldRef.FieldPath)
+	assert.Equal(t, "http://$(OTEL_NODE_IP):4317", env[endpoint].Value)
+	assert.Empty(t, inst.Spec.Endpoint)
+}
@@ -198,8 +198,9 @@ func NewMutator(logger logr.Logger, client client.Client, recorder record.EventR
 		Logger: logger,
 		Client: client,
 		sdkInjector: &sdkInjector{
-			logger: logger,
-			client: client,
+			logger:   logger,
+			client:   client,
+			recorder: recorder,
 		},
 		Recorder: recorder,
 	}
@@ -31,6 +31,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -47,8 +48,9 @@ const (
 // inject a new sidecar container to the given pod, based on the given OpenTelemetryCollector.
 
 type sdkInjector struct {
-	client client.Client
-	logger logr.Logger
+	client   client.Client
+	logger   logr.Logger
+	recorder record.EventRecorder
 }
 
 func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations, ns corev1.Namespace, pod corev1.Pod) corev1.Pod {
@@ -58,6 +60,7 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 
 	if insts.Java.Instrumentation != nil {
 		otelinst := *insts.Java.Instrumentation
+		otelinst = i.withDiscoveredEndpoint(ctx, otelinst, ns, pod, otlpProtocolGRPC, true)
 		var err error
 		i.logger.V(1).Info("injecting Java instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
 
@@ -65,6 +68,13 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 
 		for _, container := range strings.Split(javaContainers, ",") {
 			index := getContainerIndex(container, pod)
+			switch i.resolveConflict(otelinst, pod, index, findConflictingJavaAgent(pod.Spec.Containers[index])) {
+			case skipContainer:
+				continue
+			case mergeWithAgent:
+				pod = i.configureExistingAgent(ctx, otelinst, ns, pod, index, otelinst.Spec.Java.Env)
+				continue
+			}
 			pod, err = injectJavaagent(otelinst.Spec.Java, pod, index)
 			if err != nil {
 				i.logger.Info("Skipping javaagent injection", "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
@@ -77,6 +87,7 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 	}
 	if insts.NodeJS.Instrumentation != nil {
 		otelinst := *insts.NodeJS.Instrumentation
+		otelinst = i.withDiscoveredEndpoint(ctx, otelinst, ns, pod, otlpProtocolGRPC, true)
 		var err error
 		i.logger.V(1).Info("injecting NodeJS instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
 
@@ -84,6 +95,13 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 
 		for _, container := range strings.Split(nodejsContainers, ",") {
 			index := getContainerIndex(container, pod)
+			switch i.resolveConflict(otelinst, pod, index, findConflictingNodeJSAgent(pod.Spec.Containers[index])) {
+			case skipContainer:
+				continue
+			case mergeWithAgent:
+				pod = i.configureExistingAgent(ctx, otelinst, ns, pod, index, otelinst.Spec.NodeJS.Env)
+				continue
+			}
 			pod, err = injectNodeJSSDK(otelinst.Spec.NodeJS, pod, index)
 			if err != nil {
 				i.logger.Info("Skipping NodeJS SDK injection", "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
@@ -96,6 +114,7 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 	}
 	if insts.Python.Instrumentation != nil {
 		otelinst := *insts.Python.Instrumentation
+		otelinst = i.withDiscoveredEndpoint(ctx, otelinst, ns, pod, otlpProtocolHTTP, true)
 		var err error
 		i.logger.V(1).Info("injecting Python instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
 
@@ -115,6 +134,7 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 	}
 	if insts.DotNet.Instrumentation != nil {
 		otelinst := *insts.DotNet.Instrumentation
+		otelinst = i.withDiscoveredEndpoint(ctx, otelinst, ns, pod, otlpProtocolHTTP, true)
 		var err error
 		i.logger.V(1).Info("injecting DotNet instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
 
@@ -122,6 +142,13 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 
 		for _, container := range strings.Split(dotnetContainers, ",") {
 			index := getContainerIndex(container, pod)
+			switch i.resolveConflict(otelinst, pod, index, findConflictingDotNetAgent(pod.Spec.Containers[index])) {
+			case skipContainer:
+				continue
+			case mergeWithAgent:
+				pod = i.configureExistingAgent(ctx, otelinst, ns, pod, index, otelinst.Spec.DotNet.Env)
+				continue
+			}
 			pod, err = injectDotNetSDK(otelinst.Spec.DotNet, pod, index, insts.DotNet.AdditionalAnnotations[annotationDotNetRuntime])
 			if err != nil {
 				i.logger.Info("Skipping DotNet SDK injection", "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
@@ -135,6 +162,7 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 	if insts.Go.Instrumentation != nil {
 		origPod := pod
 		otelinst := *insts.Go.Instrumentation
+		otelinst = i.withDiscoveredEndpoint(ctx, otelinst, ns, pod, otlpProtocolHTTP, true)
 		var err error
 		i.logger.V(1).Info("injecting Go instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
 
@@ -160,6 +188,7 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 	}
 	if insts.ApacheHttpd.Instrumentation != nil {
 		otelinst := *insts.ApacheHttpd.Instrumentation
+		otelinst = i.withDiscoveredEndpoint(ctx, otelinst, ns, pod, otlpProtocolGRPC, false)
 		i.logger.V(1).Info("injecting Apache Httpd instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
 
 		apacheHttpdContainers := insts.ApacheHttpd.Containers
@@ -178,6 +207,7 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 
 	if insts.Nginx.Instrumentation != nil {
 		otelinst := *insts.Nginx.Instrumentation
+		otelinst = i.withDiscoveredEndpoint(ctx, otelinst, ns, pod, otlpProtocolGRPC, false)
 		i.logger.V(1).Info("injecting Nginx instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
 
 		nginxContainers := insts.Nginx.Containers
@@ -194,6 +224,7 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 
 	if insts.Sdk.Instrumentation != nil {
 		otelinst := *insts.Sdk.Instrumentation
+		otelinst = i.withDiscoveredEndpoint(ctx, otelinst, ns, pod, otlpProtocolGRPC, true)
 		i.logger.V(1).Info("injecting sdk-only instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
 
 		sdkContainers := insts.Sdk.Containers
@@ -208,6 +239,75 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 	return pod
 }
 
+// conflictResolution is how the instrumentation is injected into a container, according to the conflict policy of
+// the instrumentation when the container is already configured with another agent.
+type conflictResolution int
+
+const (
+	// injectAgent attaches the agent of the instrumentation, alongside the existing agent if any.
+	injectAgent conflictResolution = iota
+	// skipContainer leaves the container as it is.
+	skipContainer
+	// mergeWithAgent keeps the existing agent and only adds the SDK configuration of the instrumentation.
+	mergeWithAgent
+)
+
+// resolveConflict applies the conflict policy of the instrumentation when the container is already
+// configured with another agent and returns how the instrumentation should be injected.
+func (i *sdkInjector) resolveConflict(otelinst v1alpha1.Instrumentation, pod corev1.Pod, index int, conflictingAgent string) conflictResolution {
+	if conflictingAgent == "" {
+		return injectAgent
+	}
+	containerName := pod.Spec.Containers[index].Name
+	switch otelinst.Spec.ConflictPolicy {
+	case v1alpha1.ConflictPolicySkip:
+		i.logger.Info("Skipping instrumentation injection", "reason", "container already configured with another agent", "agent", conflictingAgent, "container", containerName)
+		i.recordEvent(otelinst, "InstrumentationSkipped", fmt.Sprintf("container %s of the %s is already configured with another agent, skipping instrumentation", containerName, podDescription(pod)))
+		return skipContainer
+	case v1alpha1.ConflictPolicyWarn:
+		i.logger.Info("Injecting instrumentation alongside another agent", "agent", conflictingAgent, "container", containerName)
+		i.recordEvent(otelinst, "InstrumentationConflict", fmt.Sprintf("container %s of the %s is already configured with another agent, both agents are attached", containerName, podDescription(pod)))
+	case v1alpha1.ConflictPolicyMerge:
+		i.logger.V(1).Info("Configuring the agent already attached to the container", "agent", conflictingAgent, "container", containerName)
+		return mergeWithAgent
+	}
+	return injectAgent
+}
+
+// configureExistingAgent configures the agent already attached to the container with the settings of the
+// instrumentation, which usually follow the OpenTelemetry SDK environment variables, instead of attaching another
+// agent. The environment variables of the container are kept, only the missing ones are added, and neither the
+// volume nor the init container of the instrumentation are added.
+func (i *sdkInjector) configureExistingAgent(ctx context.Context, otelinst v1alpha1.Instrumentation, ns corev1.Namespace, pod corev1.Pod, index int, languageEnv []corev1.EnvVar) corev1.Pod {
+	container := &pod.Spec.Containers[index]
+	for _, env := range languageEnv {
+		if getIndexOfEnv(container.Env, env.Name) == -1 {
+			container.Env = append(container.Env, env)
+		}
+	}
+	pod = i.injectCommonEnvVar(otelinst, pod, index)
+	return i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
+}
+
+// recordEvent records the event on the instrumentation: the pod is still being admitted, it has no UID yet and often
+// no name, so the events recorded on it would be orphaned.
+func (i *sdkInjector) recordEvent(otelinst v1alpha1.Instrumentation, reason, message string) {
+	if i.recorder != nil {
+		i.recorder.Event(otelinst.DeepCopy(), corev1.EventTypeWarning, reason, message)
+	}
+}
+
+// podDescription describes the pod being admitted by its name or, when it's generated, by its controller.
+func podDescription(pod corev1.Pod) string {
+	if pod.Name != "" {
+		return fmt.Sprintf("pod %s", pod.Name)
+	}
+	if owner := metav1.GetControllerOfNoCopy(&pod); owner != nil {
+		return fmt.Sprintf("pod of the %s %s", owner.Kind, owner.Name)
+	}
+	return fmt.Sprintf("pod %s", pod.GenerateName)
+}
+
 func (i *sdkInjector) setInitContainerSecurityContext(pod corev1.Pod, securityContext *corev1.SecurityContext, instrInitContainerName string) corev1.Pod {
 	for i, initContainer := range pod.Spec.InitContainers {
 		if initContainer.Name == instrInitContainerName {
@@ -262,6 +362,17 @@ func (i *sdkInjector) injectCommonSDKConfig(ctx context.Context, otelinst v1alph
 	if otelinst.Spec.Exporter.Endpoint != "" {
 		idx = getIndexOfEnv(container.Env, constants.EnvOTELExporterOTLPEndpoint)
 		if idx == -1 {
+			// the endpoint of a collector using the network of the node references its IP
+			if strings.Contains(otelinst.Spec.Endpoint, fmt.Sprintf("$(%s)", constants.EnvNodeIP)) && getIndexOfEnv(container.Env, constants.EnvNodeIP) == -1 {
+				container.Env = append(container.Env, corev1.EnvVar{
+					Name: constants.EnvNodeIP,
+					ValueFrom: &corev1.EnvVarSource{
+						FieldRef: &corev1.ObjectFieldSelector{
+							FieldPath: "status.hostIP",
+						},
+					},
+				})
+			}
 			container.Env = append(container.Env, corev1.EnvVar{
 				Name:  constants.EnvOTELExporterOTLPEndpoint,
 				Value: otelinst.Spec.Endpoint,
@@ -269,6 +380,37 @@ func (i *sdkInjector) injectCommonSDKConfig(ctx context.Context, otelinst v1alph
 		}
 	}
 
+	// When requested, the pod identity attributes are always resolved at runtime via k8s downward API
+	if otelinst.Spec.Resource.AddK8sDownwardAPIAttributes {
+		existingRes := getExistingResourceAttributes(pod.Spec.Containers[appIndex])
+		downwardAPIAttributes := []struct {
+			key       attribute.Key
+			envName   string
+			fieldPath string
+		}{
+			{key: semconv.K8SPodNameKey, envName: constants.EnvPodName, fieldPath: "metadata.name"},
+			{key: semconv.K8SPodUIDKey, envName: constants.EnvPodUID, fieldPath: "metadata.uid"},
+			{key: semconv.K8SNodeNameKey, envName: constants.EnvNodeName, fieldPath: "spec.nodeName"},
+			{key: semconv.K8SNamespaceNameKey, envName: constants.EnvNamespaceName, fieldPath: "metadata.namespace"},
+		}
+		for _, attr := range downwardAPIAttributes {
+			if existingRes[string(attr.key)] {
+				continue
+			}
+			if getIndexOfEnv(container.Env, attr.envName) == -1 {
+				container.Env = append(container.Env, corev1.EnvVar{
+					Name: attr.envName,
+					ValueFrom: &corev1.EnvVarSource{
+						FieldRef: &corev1.ObjectFieldSelector{
+							FieldPath: attr.fieldPath,
+						},
+					},
+				})
+			}
+			resourceMap[string(attr.key)] = fmt.Sprintf("$(%s)", attr.envName)
+		}
+	}
+
 	// Some attributes might be empty, we should get them via k8s downward API
 	if resourceMap[string(semconv.K8SPodNameKey)] == "" {
 		container.Env = append(container.Env, corev1.EnvVar{
@@ -404,19 +546,7 @@ func chooseServiceVersion(pod corev1.Pod, index int) string {
 // createResourceMap creates resource attribute map.
 // User defined attributes (in explicitly set env var) have higher precedence.
 func (i *sdkInjector) createResourceMap(ctx context.Context, otelinst v1alpha1.Instrumentation, ns corev1.Namespace, pod corev1.Pod, index int) map[string]string {
-	// get existing resources env var and parse it into a map
-	existingRes := map[string]bool{}
-	existingResourceEnvIdx := getIndexOfEnv(pod.Spec.Containers[index].Env, constants.EnvOTELResourceAttrs)
-	if existingResourceEnvIdx > -1 {
-		existingResArr := strings.Split(pod.Spec.Containers[index].Env[existingResourceEnvIdx].Value, ",")
-		for _, kv := range existingResArr {
-			keyValueArr := strings.Split(strings.TrimSpace(kv), "=")
-			if len(keyValueArr) != 2 {
-				continue
-			}
-			existingRes[keyValueArr[0]] = true
-		}
-	}
+	existingRes := getExistingResourceAttributes(pod.Spec.Containers[index])
 
 	res := map[string]string{}
 	for k, v := range otelinst.Spec.Resource.Attributes {
@@ -442,6 +572,24 @@ func (i *sdkInjector) createResourceMap(ctx context.Context, otelinst v1alpha1.I
 	return res
 }
 
+// getExistingResourceAttributes parses the resource attributes env var of the given container
+// and returns the set of attribute keys it already defines.
+func getExistingResourceAttributes(container corev1.Container) map[string]bool {
+	existingRes := map[string]bool{}
+	existingResourceEnvIdx := getIndexOfEnv(container.Env, constants.EnvOTELResourceAttrs)
+	if existingResourceEnvIdx > -1 {
+		existingResArr := strings.Split(container.Env[existingResourceEnvIdx].Value, ",")
+		for _, kv := range existingResArr {
+			keyValueArr := strings.Split(strings.TrimSpace(kv), "=")
+			if len(keyValueArr) != 2 {
+				continue
+			}
+			existingRes[keyValueArr[0]] = true
+		}
+	}
+	return existingRes
+}
+
 func (i *sdkInjector) addParentResourceLabels(ctx context.Context, uid bool, ns corev1.Namespace, objectMeta metav1.ObjectMeta, resources map[attribute.Key]string) {
 	for _, owner := range objectMeta.OwnerReferences {
 		switch strings.ToLower(owner.Kind) {
@@ -15,14 +15,20 @@
 package sidecar
 
 import (
+	"fmt"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 const (
 	// Annotation contains the annotation name that pods contain, indicating whether a sidecar is desired.
 	Annotation = "sidecar.opentelemetry.io/inject"
+
+	// PodSelectorAnnotation contains the annotation name that namespaces contain, restricting the injection enabled by
+	// their Annotation to the pods matching its label selector.
+	PodSelectorAnnotation = "sidecar.opentelemetry.io/pod-selector"
 )
 
 // annotationValue returns the effective annotation value, based on the annotations from the pod and namespace.
@@ -56,3 +62,21 @@ func annotationValue(ns corev1.Namespace, pod corev1.Pod) string {
 	// so, the namespace annotation can be used
 	return nsAnnValue
 }
+
+// selectedByNamespace returns whether the injection enabled by the annotation of the namespace applies to the pod. The
+// pods setting the annotation themselves are always selected, and so are all the pods of the namespaces without a pod
+// selector.
+func selectedByNamespace(ns corev1.Namespace, pod corev1.Pod) (bool, error) {
+	if len(pod.Annotations[Annotation]) > 0 {
+		return true, nil
+	}
+	rawSelector := ns.Annotations[PodSelectorAnnotation]
+	if len(rawSelector) == 0 {
+		return true, nil
+	}
+	selector, err := labels.Parse(rawSelector)
+	if err != nil {
+		return false, fmt.Errorf("the %s annotation of the namespace isn't a valid label selector: %w", PodSelectorAnnotation, err)
+	}
+	return selector.Matches(labels.Set(pod.Labels)), nil
+}
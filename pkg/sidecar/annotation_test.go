@@ -140,3 +140,75 @@ func TestEffectiveAnnotationValue(t *testing.T) {
 		})
 	}
 }
+
+func TestSelectedByNamespace(t *testing.T) {
+	for _, tt := range []struct {
+		desc        string
+		selector    string
+		pod         corev1.Pod
+		expected    bool
+		expectedErr bool
+	}{
+		{
+			desc:     "no-selector",
+			pod:      corev1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"job-name": "backup"}}},
+			expected: true,
+		},
+		{
+			desc:     "selected-pod",
+			selector: "!job-name,app notin (istiod)",
+			pod:      corev1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "my-app"}}},
+			expected: true,
+		},
+		{
+			desc:     "job-pod",
+			selector: "!job-name,app notin (istiod)",
+			pod:      corev1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"job-name": "backup"}}},
+			expected: false,
+		},
+		{
+			desc:     "mesh-control-plane-pod",
+			selector: "!job-name,app notin (istiod)",
+			pod:      corev1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "istiod"}}},
+			expected: false,
+		},
+		{
+			desc:     "pod-annotation-bypasses-selector",
+			selector: "!job-name",
+			pod: corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Labels:      map[string]string{"job-name": "backup"},
+				Annotations: map[string]string{Annotation: "true"},
+			}},
+			expected: true,
+		},
+		{
+			desc:        "invalid-selector",
+			selector:    "app in (",
+			pod:         corev1.Pod{},
+			expectedErr: true,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			ns := corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						Annotation:            "true",
+						PodSelectorAnnotation: tt.selector,
+					},
+				},
+			}
+
+			// test
+			selected, err := selectedByNamespace(ns, tt.pod)
+
+			// verify
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, selected)
+		})
+	}
+}
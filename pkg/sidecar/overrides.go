@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sidecar
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+const (
+	// CPURequestAnnotation overrides the CPU request of the sidecar of the pod.
+	CPURequestAnnotation = "sidecar.opentelemetry.io/cpu-request"
+	// CPULimitAnnotation overrides the CPU limit of the sidecar of the pod.
+	CPULimitAnnotation = "sidecar.opentelemetry.io/cpu-limit"
+	// MemoryRequestAnnotation overrides the memory request of the sidecar of the pod.
+	MemoryRequestAnnotation = "sidecar.opentelemetry.io/memory-request"
+	// MemoryLimitAnnotation overrides the memory limit of the sidecar of the pod.
+	MemoryLimitAnnotation = "sidecar.opentelemetry.io/memory-limit"
+	// SecurityContextAnnotation overrides fields of the security context of the sidecar of the pod, in JSON.
+	SecurityContextAnnotation = "sidecar.opentelemetry.io/security-context"
+)
+
+// resourceOverrides are the annotations overriding the resources of the sidecar, in the order they're applied.
+var resourceOverrides = []struct {
+	annotation string
+	name       corev1.ResourceName
+	limit      bool
+}{
+	{CPURequestAnnotation, corev1.ResourceCPU, false},
+	{CPULimitAnnotation, corev1.ResourceCPU, true},
+	{MemoryRequestAnnotation, corev1.ResourceMemory, false},
+	{MemoryLimitAnnotation, corev1.ResourceMemory, true},
+}
+
+// applyOverrides applies the resources and the security context set by the annotations of the pod to the sidecar
+// container, within the bounds of the configuration. The overrides which are invalid are skipped, and those exceeding
+// the bounds are capped, the returned error reporting both.
+func applyOverrides(cfg config.Config, pod corev1.Pod, container *corev1.Container) error {
+	var errs []error
+	maxResources := cfg.SidecarMaxResources()
+	for _, override := range resourceOverrides {
+		value, ok := pod.Annotations[override.annotation]
+		if !ok {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid %s annotation %q: %w", override.annotation, value, err))
+			continue
+		}
+		if bound, bounded := maxResources[override.name]; bounded && quantity.Cmp(bound) > 0 {
+			errs = append(errs, fmt.Errorf("the %s annotation %q exceeds the bound %s of the operator, the bound is used instead", override.annotation, value, bound.String()))
+			quantity = bound
+		}
+		if override.limit {
+			container.Resources.Limits = withResource(container.Resources.Limits, override.name, quantity)
+		} else {
+			container.Resources.Requests = withResource(container.Resources.Requests, override.name, quantity)
+		}
+	}
+	// a request greater than the limit makes the pod invalid
+	for name, request := range container.Resources.Requests {
+		if limit, ok := container.Resources.Limits[name]; ok && request.Cmp(limit) > 0 {
+			errs = append(errs, fmt.Errorf("the %s request %s of the sidecar exceeds its limit, the limit is used instead", name, request.String()))
+			container.Resources.Requests[name] = limit
+		}
+	}
+
+	if value, ok := pod.Annotations[SecurityContextAnnotation]; ok {
+		if err := overrideSecurityContext(cfg, container, value); err != nil {
+			errs = append(errs, fmt.Errorf("the %s annotation is ignored: %w", SecurityContextAnnotation, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// withResource returns the resources with the given quantity, copied so that those of the instance are left untouched.
+func withResource(resources corev1.ResourceList, name corev1.ResourceName, quantity resource.Quantity) corev1.ResourceList {
+	updated := resources.DeepCopy()
+	if updated == nil {
+		updated = corev1.ResourceList{}
+	}
+	updated[name] = quantity
+	return updated
+}
+
+// overrideSecurityContext sets the fields of the security context of the container which are set in the given JSON.
+// Unless the configuration allows it, the security context can't be made privileged, nor run as root.
+func overrideSecurityContext(cfg config.Config, container *corev1.Container, value string) error {
+	override := &corev1.SecurityContext{}
+	decoder := json.NewDecoder(bytes.NewReader([]byte(value)))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(override); err != nil {
+		return fmt.Errorf("invalid security context: %w", err)
+	}
+	if !cfg.SidecarAllowPrivileged() {
+		if err := checkUnprivileged(override); err != nil {
+			return err
+		}
+	}
+	securityContext := container.SecurityContext.DeepCopy()
+	if securityContext == nil {
+		securityContext = &corev1.SecurityContext{}
+	}
+	// the fields absent from the JSON keep their value
+	if err := json.Unmarshal([]byte(value), securityContext); err != nil {
+		return fmt.Errorf("invalid security context: %w", err)
+	}
+	container.SecurityContext = securityContext
+	return nil
+}
+
+func checkUnprivileged(securityContext *corev1.SecurityContext) error {
+	switch {
+	case securityContext.Privileged != nil && *securityContext.Privileged:
+		return fmt.Errorf("the sidecar can't be made privileged")
+	case securityContext.AllowPrivilegeEscalation != nil && *securityContext.AllowPrivilegeEscalation:
+		return fmt.Errorf("the privilege escalation of the sidecar can't be allowed")
+	case securityContext.Capabilities != nil && len(securityContext.Capabilities.Add) > 0:
+		return fmt.Errorf("capabilities can't be added to the sidecar")
+	case securityContext.RunAsNonRoot != nil && !*securityContext.RunAsNonRoot:
+		return fmt.Errorf("the sidecar can't be allowed to run as root")
+	case securityContext.RunAsUser != nil && *securityContext.RunAsUser == 0:
+		return fmt.Errorf("the sidecar can't run as root")
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sidecar
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func TestApplyResourceOverrides(t *testing.T) {
+	bounded := config.New(config.WithSidecarMaxResources(corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("1"),
+		corev1.ResourceMemory: resource.MustParse("1Gi"),
+	}))
+	for _, tt := range []struct {
+		desc        string
+		cfg         config.Config
+		annotations map[string]string
+		expected    corev1.ResourceRequirements
+		expectedErr string
+	}{
+		{
+			desc: "no-overrides",
+			cfg:  bounded,
+			expected: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+				Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("200m")},
+			},
+		},
+		{
+			desc: "overrides",
+			cfg:  bounded,
+			annotations: map[string]string{
+				CPURequestAnnotation:    "50m",
+				CPULimitAnnotation:      "500m",
+				MemoryRequestAnnotation: "64Mi",
+				MemoryLimitAnnotation:   "128Mi",
+			},
+			expected: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("50m"), corev1.ResourceMemory: resource.MustParse("64Mi")},
+				Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("128Mi")},
+			},
+		},
+		{
+			desc:        "capped-overrides",
+			cfg:         bounded,
+			annotations: map[string]string{CPULimitAnnotation: "4", MemoryLimitAnnotation: "8Gi"},
+			expected: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+				Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+			},
+			expectedErr: "the sidecar.opentelemetry.io/cpu-limit annotation \"4\" exceeds the bound 1 of the operator",
+		},
+		{
+			desc:        "unbounded-overrides",
+			cfg:         config.New(),
+			annotations: map[string]string{CPULimitAnnotation: "4"},
+			expected: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+				Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+			},
+		},
+		{
+			desc:        "invalid-override",
+			cfg:         bounded,
+			annotations: map[string]string{CPURequestAnnotation: "a lot", MemoryRequestAnnotation: "64Mi"},
+			expected: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m"), corev1.ResourceMemory: resource.MustParse("64Mi")},
+				Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("200m")},
+			},
+			expectedErr: "invalid sidecar.opentelemetry.io/cpu-request annotation \"a lot\"",
+		},
+		{
+			desc:        "request-over-limit",
+			cfg:         bounded,
+			annotations: map[string]string{CPURequestAnnotation: "300m"},
+			expected: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("200m")},
+				Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("200m")},
+			},
+			expectedErr: "the cpu request 300m of the sidecar exceeds its limit",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			pod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations}}
+			container := corev1.Container{Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+				Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("200m")},
+			}}
+
+			// test
+			err := applyOverrides(tt.cfg, pod, &container)
+
+			// verify
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			}
+			assert.Equal(t, tt.expected, container.Resources)
+		})
+	}
+}
+
+func TestApplySecurityContextOverride(t *testing.T) {
+	nonRoot, readOnly := true, true
+	uid := int64(1000)
+	for _, tt := range []struct {
+		desc        string
+		cfg         config.Config
+		annotation  string
+		expected    *corev1.SecurityContext
+		expectedErr string
+	}{
+		{
+			desc:       "merged",
+			cfg:        config.New(),
+			annotation: `{"runAsUser": 1000, "capabilities": {"drop": ["ALL"]}}`,
+			expected: &corev1.SecurityContext{
+				RunAsNonRoot:           &nonRoot,
+				ReadOnlyRootFilesystem: &readOnly,
+				RunAsUser:              &uid,
+				Capabilities:           &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+			},
+		},
+		{
+			desc:        "privileged",
+			cfg:         config.New(),
+			annotation:  `{"privileged": true}`,
+			expected:    &corev1.SecurityContext{RunAsNonRoot: &nonRoot, ReadOnlyRootFilesystem: &readOnly},
+			expectedErr: "the sidecar can't be made privileged",
+		},
+		{
+			desc:        "added-capabilities",
+			cfg:         config.New(),
+			annotation:  `{"capabilities": {"add": ["NET_ADMIN"]}}`,
+			expected:    &corev1.SecurityContext{RunAsNonRoot: &nonRoot, ReadOnlyRootFilesystem: &readOnly},
+			expectedErr: "capabilities can't be added to the sidecar",
+		},
+		{
+			desc:       "privileged-allowed",
+			cfg:        config.New(config.WithSidecarAllowPrivileged(true)),
+			annotation: `{"capabilities": {"add": ["NET_ADMIN"]}}`,
+			expected: &corev1.SecurityContext{
+				RunAsNonRoot:           &nonRoot,
+				ReadOnlyRootFilesystem: &readOnly,
+				Capabilities:           &corev1.Capabilities{Add: []corev1.Capability{"NET_ADMIN"}},
+			},
+		},
+		{
+			desc:        "unknown-field",
+			cfg:         config.New(),
+			annotation:  `{"runAsRoot": false}`,
+			expected:    &corev1.SecurityContext{RunAsNonRoot: &nonRoot, ReadOnlyRootFilesystem: &readOnly},
+			expectedErr: "invalid security context",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			pod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{SecurityContextAnnotation: tt.annotation}}}
+			original := &corev1.SecurityContext{RunAsNonRoot: &nonRoot, ReadOnlyRootFilesystem: &readOnly}
+			container := corev1.Container{SecurityContext: original}
+
+			// test
+			err := applyOverrides(tt.cfg, pod, &container)
+
+			// verify
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			}
+			assert.Equal(t, tt.expected, container.SecurityContext)
+			assert.Equal(t, &corev1.SecurityContext{RunAsNonRoot: &nonRoot, ReadOnlyRootFilesystem: &readOnly}, original)
+		})
+	}
+}
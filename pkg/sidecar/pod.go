@@ -17,6 +17,7 @@ package sidecar
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -24,12 +25,19 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 const (
 	label      = "sidecar.opentelemetry.io/injected"
 	confEnvVar = "OTEL_CONFIG"
+
+	// injectedInitContainersAnnotation and injectedVolumesAnnotation list the init containers and the volumes injected
+	// along with the sidecar, so that they're removed with it once the instance isn't known anymore.
+	injectedInitContainersAnnotation = "sidecar.opentelemetry.io/injected-init-containers"
+	injectedVolumesAnnotation        = "sidecar.opentelemetry.io/injected-volumes"
 )
 
 // add a new sidecar container to the given pod, based on the given OpenTelemetryCollector.
@@ -41,42 +49,127 @@ func add(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelemetryCo
 
 	container := collector.Container(cfg, logger, otelcol, false)
 	container.Args = append(container.Args, fmt.Sprintf("--config=env:%s", confEnvVar))
+	if err := applyOverrides(cfg, pod, &container); err != nil {
+		// the pod still gets the sidecar, with the overrides which could be applied
+		logger.Error(err, "failed to apply the sidecar overrides of the pod", "namespace", pod.Namespace, "name", pod.Name)
+	}
 
 	container.Env = append(container.Env, corev1.EnvVar{Name: confEnvVar, Value: otelColCfg})
 	if !hasResourceAttributeEnvVar(container.Env) {
 		container.Env = append(container.Env, attributes...)
 	}
+	var initContainers []string
+	for _, initContainer := range otelcol.Spec.InitContainers {
+		initContainers = append(initContainers, initContainer.Name)
+	}
 	pod.Spec.InitContainers = append(pod.Spec.InitContainers, otelcol.Spec.InitContainers...)
-	pod.Spec.Containers = append(pod.Spec.Containers, container)
-	pod.Spec.Volumes = append(pod.Spec.Volumes, otelcol.Spec.Volumes...)
+	if featuregate.EnableNativeSidecarContainers.IsEnabled() {
+		// native sidecars are started before and stopped after the regular containers, so that the telemetry
+		// emitted by the application while starting up and shutting down is not lost
+		policy := corev1.ContainerRestartPolicyAlways
+		container.RestartPolicy = &policy
+		pod.Spec.InitContainers = append(pod.Spec.InitContainers, container)
+	} else {
+		pod.Spec.Containers = append(pod.Spec.Containers, container)
+	}
+	var volumes []corev1.Volume
+	volumes = append(volumes, otelcol.Spec.Volumes...)
+	volumes = append(volumes, manifestutils.SecretsStoreVolumes(otelcol.Spec.SecretProviderClasses)...)
+	volumes = append(volumes, collector.WritableVolumes(cfg, otelcol)...)
+	volumes = append(volumes, collector.TLSSecretVolumes(otelcol)...)
+	var volumeNames []string
+	for _, volume := range volumes {
+		volumeNames = append(volumeNames, volume.Name)
+	}
+	pod.Spec.Volumes = append(pod.Spec.Volumes, volumes...)
 
 	if pod.Labels == nil {
 		pod.Labels = map[string]string{}
 	}
 	pod.Labels[label] = fmt.Sprintf("%s.%s", otelcol.Namespace, otelcol.Name)
+	if len(initContainers) > 0 || len(volumeNames) > 0 {
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		if len(initContainers) > 0 {
+			pod.Annotations[injectedInitContainersAnnotation] = strings.Join(initContainers, ",")
+		}
+		if len(volumeNames) > 0 {
+			pod.Annotations[injectedVolumesAnnotation] = strings.Join(volumeNames, ",")
+		}
+	}
 
 	return pod, nil
 }
 
-// remove the sidecar container from the given pod.
+// remove the sidecar container from the given pod, along with the init containers and the volumes injected with it,
+// whose environment and configuration go away with the container.
 func remove(pod corev1.Pod) (corev1.Pod, error) {
 	if !existsIn(pod) {
 		return pod, nil
 	}
 
-	var containers []corev1.Container
-	for _, container := range pod.Spec.Containers {
-		if container.Name != naming.Container() {
-			containers = append(containers, container)
+	pod.Spec.Containers = withoutContainers(pod.Spec.Containers, naming.Container())
+	pod.Spec.InitContainers = withoutContainers(pod.Spec.InitContainers, naming.Container())
+	pod.Spec.InitContainers = withoutContainers(pod.Spec.InitContainers, injectedNames(pod, injectedInitContainersAnnotation)...)
+	pod.Spec.Volumes = withoutVolumes(pod.Spec.Volumes, injectedNames(pod, injectedVolumesAnnotation)...)
+	delete(pod.Labels, label)
+	delete(pod.Annotations, injectedInitContainersAnnotation)
+	delete(pod.Annotations, injectedVolumesAnnotation)
+	return pod, nil
+}
+
+// injectedNames returns the names listed in the annotation of the pod.
+func injectedNames(pod corev1.Pod, annotation string) []string {
+	value := pod.Annotations[annotation]
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+func withoutContainers(containers []corev1.Container, names ...string) []corev1.Container {
+	var kept []corev1.Container
+	for _, container := range containers {
+		if !contains(names, container.Name) {
+			kept = append(kept, container)
+		}
+	}
+	return kept
+}
+
+func withoutVolumes(volumes []corev1.Volume, names ...string) []corev1.Volume {
+	var kept []corev1.Volume
+	for _, volume := range volumes {
+		if !contains(names, volume.Name) {
+			kept = append(kept, volume)
 		}
 	}
-	pod.Spec.Containers = containers
-	return pod, nil
+	return kept
 }
 
-// existsIn checks whether a sidecar container exists in the given pod.
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// wasInjected returns whether the sidecar of the pod was injected by the operator, e.g. into the pod whose template
+// was copied from an injected pod.
+func wasInjected(pod corev1.Pod) bool {
+	return pod.Labels[label] != "" && existsIn(pod)
+}
+
+// existsIn checks whether a sidecar container exists in the given pod, either as a regular or as a native sidecar.
 func existsIn(pod corev1.Pod) bool {
-	for _, container := range pod.Spec.Containers {
+	return hasSidecar(pod.Spec.Containers) || hasSidecar(pod.Spec.InitContainers)
+}
+
+func hasSidecar(containers []corev1.Container) bool {
+	for _, container := range containers {
 		if container.Name == naming.Container() {
 			return true
 		}
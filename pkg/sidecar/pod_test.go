@@ -19,6 +19,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	colfeaturegate "go.opentelemetry.io/collector/featuregate"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -26,6 +27,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 var logger = logf.Log.WithName("unit-tests")
@@ -75,10 +77,13 @@ processors:
 	require.Len(t, changed.Spec.InitContainers, 2)
 	require.Len(t, changed.Spec.Volumes, 1)
 	assert.Equal(t, "some-app.otelcol-sample", changed.Labels["sidecar.opentelemetry.io/injected"])
+	assert.Equal(t, "test", changed.Annotations["sidecar.opentelemetry.io/injected-init-containers"])
+	assert.NotContains(t, changed.Annotations, "sidecar.opentelemetry.io/injected-volumes")
 	assert.Equal(t, corev1.Container{
-		Name:  "otc-container",
-		Image: "some-default-image",
-		Args:  []string{"--config=env:OTEL_CONFIG"},
+		Name:                     "otc-container",
+		Image:                    "some-default-image",
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		Args:                     []string{"--config=env:OTEL_CONFIG"},
 		Env: []corev1.EnvVar{
 			{
 				Name: "POD_NAME",
@@ -126,6 +131,52 @@ func TestAddSidecarWhenOneExistsAlready(t *testing.T) {
 	assert.Len(t, changed.Spec.Containers, 3)
 }
 
+func TestAddNativeSidecar(t *testing.T) {
+	require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableNativeSidecarContainers.ID(), true))
+	defer func() {
+		require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableNativeSidecarContainers.ID(), false))
+	}()
+
+	// prepare
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "my-app"},
+			},
+			InitContainers: []corev1.Container{
+				{Name: "my-init"},
+			},
+		},
+	}
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "otelcol-native-sidecar",
+			Namespace: "some-app",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config: `
+receivers:
+exporters:
+processors:
+`,
+		},
+	}
+	cfg := config.New(config.WithCollectorImage("some-default-image"))
+
+	// test
+	changed, err := add(cfg, logger, otelcol, pod, nil)
+
+	// verify
+	assert.NoError(t, err)
+	require.Len(t, changed.Spec.Containers, 1)
+	require.Len(t, changed.Spec.InitContainers, 2)
+	sidecar := changed.Spec.InitContainers[1]
+	assert.Equal(t, naming.Container(), sidecar.Name)
+	require.NotNil(t, sidecar.RestartPolicy)
+	assert.Equal(t, corev1.ContainerRestartPolicyAlways, *sidecar.RestartPolicy)
+	assert.True(t, existsIn(changed))
+}
+
 func TestRemoveSidecar(t *testing.T) {
 	// prepare
 	pod := corev1.Pod{
@@ -146,6 +197,30 @@ func TestRemoveSidecar(t *testing.T) {
 	assert.Len(t, changed.Spec.Containers, 1)
 }
 
+func TestRemoveNativeSidecar(t *testing.T) {
+	// prepare
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "my-app"},
+			},
+			InitContainers: []corev1.Container{
+				{Name: "my-init"},
+				{Name: naming.Container()},
+			},
+		},
+	}
+
+	// test
+	changed, err := remove(pod)
+
+	// verify
+	assert.NoError(t, err)
+	assert.Len(t, changed.Spec.Containers, 1)
+	require.Len(t, changed.Spec.InitContainers, 1)
+	assert.Equal(t, "my-init", changed.Spec.InitContainers[0].Name)
+}
+
 func TestRemoveNonExistingSidecar(t *testing.T) {
 	// prepare
 	pod := corev1.Pod{
@@ -164,6 +239,95 @@ func TestRemoveNonExistingSidecar(t *testing.T) {
 	assert.Len(t, changed.Spec.Containers, 1)
 }
 
+func TestRemoveInjectedSidecar(t *testing.T) {
+	// prepare
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "my-app"},
+			},
+			InitContainers: []corev1.Container{
+				{Name: "my-init"},
+			},
+			Volumes: []corev1.Volume{{Name: "my-volume"}},
+		},
+	}
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "otelcol-sample",
+			Namespace: "some-app",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			InitContainers: []corev1.Container{{Name: "collector-init"}},
+			Volumes:        []corev1.Volume{{Name: "collector-volume"}},
+			Config: `
+receivers:
+exporters:
+processors:
+`,
+		},
+	}
+	cfg := config.New(config.WithCollectorImage("some-default-image"))
+	injected, err := add(cfg, logger, otelcol, pod, nil)
+	require.NoError(t, err)
+	require.Equal(t, "collector-volume", injected.Annotations["sidecar.opentelemetry.io/injected-volumes"])
+
+	// test
+	changed, err := remove(injected)
+
+	// verify
+	assert.NoError(t, err)
+	assert.Equal(t, []corev1.Container{{Name: "my-app"}}, changed.Spec.Containers)
+	assert.Equal(t, []corev1.Container{{Name: "my-init"}}, changed.Spec.InitContainers)
+	assert.Equal(t, []corev1.Volume{{Name: "my-volume"}}, changed.Spec.Volumes)
+	assert.NotContains(t, changed.Labels, "sidecar.opentelemetry.io/injected")
+	assert.Empty(t, changed.Annotations)
+}
+
+func TestWasInjected(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		pod      corev1.Pod
+		expected bool
+	}{
+		{
+			desc: "injected-sidecar",
+			pod: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"sidecar.opentelemetry.io/injected": "some-app.otelcol-sample"},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "my-app"}, {Name: naming.Container()}},
+				},
+			},
+			expected: true,
+		},
+		{
+			desc: "sidecar-without-label",
+			pod: corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "my-app"}, {Name: naming.Container()}},
+				},
+			},
+		},
+		{
+			desc: "label-without-sidecar",
+			pod: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"sidecar.opentelemetry.io/injected": "some-app.otelcol-sample"},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "my-app"}},
+				},
+			},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.expected, wasInjected(tt.pod))
+		})
+	}
+}
+
 func TestExistsIn(t *testing.T) {
 	for _, tt := range []struct {
 		desc     string
@@ -181,6 +345,19 @@ func TestExistsIn(t *testing.T) {
 			},
 			true},
 
+		{"has-native-sidecar",
+			corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "my-app"},
+					},
+					InitContainers: []corev1.Container{
+						{Name: naming.Container()},
+					},
+				},
+			},
+			true},
+
 		{"does-not-have-sidecar",
 			corev1.Pod{
 				Spec: corev1.PodSpec{
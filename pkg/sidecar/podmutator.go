@@ -17,11 +17,13 @@ package sidecar
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -35,6 +37,8 @@ var (
 	errMultipleInstancesPossible = errors.New("multiple OpenTelemetry Collector instances available, cannot determine which one to select")
 	errNoInstancesAvailable      = errors.New("no OpenTelemetry Collector instances available")
 	errInstanceNotSidecar        = errors.New("the OpenTelemetry Collector's mode is not set to sidecar")
+	errInstanceNotFound          = errors.New("the OpenTelemetry Collector doesn't exist")
+	errNamespaceNotWatched       = errors.New("the OpenTelemetry Collector is in a namespace the operator doesn't watch")
 )
 
 type sidecarPodMutator struct {
@@ -59,6 +63,11 @@ func (p *sidecarPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod
 	// if no annotations are found at all, just return the same pod
 	annValue := annotationValue(ns, pod)
 	if len(annValue) == 0 {
+		// the pod template may still carry the sidecar injected before the annotation was removed from the workload
+		if wasInjected(pod) {
+			logger.V(1).Info("annotation removed from the deployment, removing the injected sidecar")
+			return remove(pod)
+		}
 		logger.V(1).Info("annotation not present in deployment, skipping sidecar injection")
 		return pod, nil
 	}
@@ -69,6 +78,18 @@ func (p *sidecarPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod
 		return remove(pod)
 	}
 
+	// the injection enabled for the whole namespace may be restricted to some of its pods, e.g. to leave the jobs out
+	selected, err := selectedByNamespace(ns, pod)
+	if err != nil {
+		// we still allow the pod to be created, but we log a message to the operator's logs
+		logger.Error(err, "failed to select the pods of the namespace for the sidecar injection")
+		return pod, nil
+	}
+	if !selected {
+		logger.V(1).Info("pod isn't selected by the pod selector of the namespace, skipping sidecar injection")
+		return pod, nil
+	}
+
 	// from this point and on, a sidecar is wanted
 	// check whether there's a sidecar already -- return the same pod if that's the case.
 	if existsIn(pod) {
@@ -79,10 +100,11 @@ func (p *sidecarPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod
 	// which instance should it talk to?
 	otelcol, err := p.getCollectorInstance(ctx, ns, annValue)
 	if err != nil {
-		if errors.Is(err, errMultipleInstancesPossible) || errors.Is(err, errNoInstancesAvailable) || errors.Is(err, errInstanceNotSidecar) {
-			// we still allow the pod to be created, but we log a message to the operator's logs
+		if errors.Is(err, errMultipleInstancesPossible) || errors.Is(err, errNoInstancesAvailable) || errors.Is(err, errInstanceNotSidecar) ||
+			errors.Is(err, errInstanceNotFound) || errors.Is(err, errNamespaceNotWatched) {
+			// we still allow the pod to be created, but we log a message to the operator's logs and warn its creator
 			logger.Error(err, "failed to select an OpenTelemetry Collector instance for this pod's sidecar")
-			return pod, nil
+			return pod, podmutation.Warning(fmt.Sprintf("the sidecar requested by the %s annotation isn't injected: %s", Annotation, err))
 		}
 
 		// something else happened, better fail here
@@ -113,18 +135,39 @@ func (p *sidecarPodMutator) getCollectorInstance(ctx context.Context, ns corev1.
 	} else {
 		nsnOtelcol = types.NamespacedName{Name: ann, Namespace: ns.Name}
 	}
+	if !p.watched(nsnOtelcol.Namespace) {
+		return otelcol, fmt.Errorf("%w: %s", errNamespaceNotWatched, nsnOtelcol)
+	}
 	err := p.client.Get(ctx, nsnOtelcol, &otelcol)
+	if apierrors.IsNotFound(err) {
+		return otelcol, fmt.Errorf("%w: %s", errInstanceNotFound, nsnOtelcol)
+	}
 	if err != nil {
 		return otelcol, err
 	}
 
 	if otelcol.Spec.Mode != v1alpha1.ModeSidecar {
-		return v1alpha1.OpenTelemetryCollector{}, errInstanceNotSidecar
+		return v1alpha1.OpenTelemetryCollector{}, fmt.Errorf("%w: %s", errInstanceNotSidecar, nsnOtelcol)
 	}
 
 	return otelcol, nil
 }
 
+// watched tells whether the collectors of the namespace can be read, the operator only caches the ones of the
+// namespaces it watches.
+func (p *sidecarPodMutator) watched(namespace string) bool {
+	namespaces := p.config.WatchNamespaces()
+	if len(namespaces) == 0 {
+		return true
+	}
+	for _, ns := range namespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
 func (p *sidecarPodMutator) selectCollectorInstance(ctx context.Context, ns corev1.Namespace) (v1alpha1.OpenTelemetryCollector, error) {
 	var (
 		otelcols = v1alpha1.OpenTelemetryCollectorList{}
@@ -144,9 +187,9 @@ func (p *sidecarPodMutator) selectCollectorInstance(ctx context.Context, ns core
 
 	switch {
 	case len(sidecars) == 0:
-		return v1alpha1.OpenTelemetryCollector{}, errNoInstancesAvailable
+		return v1alpha1.OpenTelemetryCollector{}, fmt.Errorf("%w in the namespace %s", errNoInstancesAvailable, ns.Name)
 	case len(sidecars) > 1:
-		return v1alpha1.OpenTelemetryCollector{}, errMultipleInstancesPossible
+		return v1alpha1.OpenTelemetryCollector{}, fmt.Errorf("%w in the namespace %s", errMultipleInstancesPossible, ns.Name)
 	default:
 		return sidecars[0], nil
 	}
@@ -157,7 +200,7 @@ func (p *sidecarPodMutator) podReferences(ctx context.Context, ownerReferences [
 	replicaSet := p.getReplicaSetReference(ctx, ownerReferences, ns)
 	if replicaSet != nil {
 		references.replicaset = replicaSet
-		deployment := p.getDeploymentReference(ctx, replicaSet)
+		deployment := p.getDeploymentReference(replicaSet)
 		if deployment != nil {
 			references.deployment = deployment
 		}
@@ -177,13 +220,18 @@ func (p *sidecarPodMutator) getReplicaSetReference(ctx context.Context, ownerRef
 	return nil
 }
 
-func (p *sidecarPodMutator) getDeploymentReference(ctx context.Context, replicaSet *appsv1.ReplicaSet) *appsv1.Deployment {
-	deploymentName := findOwnerReferenceKind(replicaSet.OwnerReferences, "Deployment")
-	if deploymentName != "" {
-		deployment := &appsv1.Deployment{}
-		err := p.client.Get(ctx, types.NamespacedName{Name: deploymentName, Namespace: replicaSet.Namespace}, deployment)
-		if err == nil {
-			return deployment
+// getDeploymentReference returns the Deployment of the ReplicaSet, built from its owner reference which holds the name
+// and UID of the Deployment, so that the Deployments of the cluster don't need to be read.
+func (p *sidecarPodMutator) getDeploymentReference(replicaSet *appsv1.ReplicaSet) *appsv1.Deployment {
+	for _, reference := range replicaSet.OwnerReferences {
+		if reference.Kind == "Deployment" {
+			return &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      reference.Name,
+					Namespace: replicaSet.Namespace,
+					UID:       reference.UID,
+				},
+			}
 		}
 	}
 	return nil